@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +34,10 @@ These operations require authentication via JWT token (from 'iz login') or Perso
 				return err
 			}
 		}
+		// Multi-profile runs call this hook again for each profile
+		if multiProfilePending {
+			return nil
+		}
 
 		// Apply admin-specific authentication flags
 		if adminPATUsername != "" {
@@ -44,12 +50,23 @@ These operations require authentication via JWT token (from 'iz login') or Perso
 			cfg.PersonalAccessToken = adminPersonalAccessToken
 		}
 
+		// Fail early (or fall back to the PAT) when the session token has expired
+		if err := checkSessionExpiry(statusOut(cmd), cfg, time.Now()); err != nil {
+			return err
+		}
+
 		// Validate admin authentication
 		if err := cfg.ValidateAdminAuth(); err != nil {
 			return err
 		}
 
-		return nil
+		// Fail fast when the server is too old for the command
+		if err := checkServerCapability(cmd); err != nil {
+			return err
+		}
+
+		// Fail fast when the user lacks the right the command requires
+		return checkPermissions(cmd, args)
 	},
 }
 
@@ -60,6 +77,7 @@ func init() {
 	adminCmd.PersistentFlags().StringVar(&adminPATUsername, "personal-access-token-username", "", "Username for PAT authentication (env: IZ_PERSONAL_ACCESS_TOKEN_USERNAME)")
 	adminCmd.PersistentFlags().StringVar(&adminJwtToken, "jwt-token", "", "JWT token for admin authentication (env: IZ_JWT_TOKEN)")
 	adminCmd.PersistentFlags().StringVar(&adminPersonalAccessToken, "personal-access-token", "", "Personal access token for admin authentication (env: IZ_PERSONAL_ACCESS_TOKEN)")
+	adminCmd.PersistentFlags().BoolVar(&adminSkipPreflight, "skip-preflight", false, "Skip the check of your rights before admin changes")
 
 	// Features (admin operations)
 	adminCmd.AddCommand(featuresCmd)
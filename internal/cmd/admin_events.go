@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+var (
+	adminEventsProjects []string
+	adminEventsTypes    []string
+	adminEventsSince    string
+	adminEventsInterval time.Duration
+)
+
+// featureEventTypes maps the short event types accepted by --type to audit log types
+var featureEventTypes = map[string]string{
+	"created": "FEATURE_CREATED",
+	"updated": "FEATURE_UPDATED",
+	"deleted": "FEATURE_DELETED",
+}
+
+// adminEventsCmd groups the tenant event commands
+var adminEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Watch tenant events",
+	Long: `Watch the feature events of a tenant with admin credentials.
+
+For the client event stream (SSE, with client credentials), see "iz events watch".`,
+}
+
+// adminEventsTailCmd prints feature events of a tenant as they happen
+var adminEventsTailCmd = &cobra.Command{
+	Use:         "tail",
+	Short:       "Print feature created/updated/deleted events in real time",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/logs"},
+	Long: `Print the feature events of a tenant (created, updated, deleted) as they
+happen, until Ctrl+C. Useful for watching a release in progress.
+
+Izanami does not expose a tenant-wide admin event stream: the events are read
+from the tenant audit log, polled every --interval (at least 1s), so they are
+printed with up to --interval of delay. The client SSE stream ("iz events
+watch") reports activation changes but requires client credentials and does
+not tell who made a change.
+
+Filters (repeatable or comma-separated):
+  --project  Project names
+  --type     created, updated, deleted (default: all three)
+
+Output: aligned columns, or one JSON event per line with -o ndjson (or json).
+
+Examples:
+  # Watch every feature change of a tenant
+  iz admin events tail --tenant my-tenant
+
+  # Watch deletions in two projects, as JSON lines
+  iz admin events tail --tenant my-tenant --project shop,billing --type deleted -o ndjson
+
+  # Also print the events of the last 10 minutes first
+  iz admin events tail --tenant my-tenant --since 10m`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if adminEventsInterval < auditTailMinInterval {
+			return fmt.Errorf("--interval must be at least %s", auditTailMinInterval)
+		}
+
+		now := time.Now()
+		types, err := parseFeatureEventTypes(adminEventsTypes)
+		if err != nil {
+			return err
+		}
+		opts := &izanami.LogsRequest{
+			Projects: strings.Join(adminEventsProjects, ","),
+			Types:    strings.Join(types, ","),
+			Order:    "asc",
+			Count:    100,
+		}
+		if opts.Start, err = parseAuditTime("--since", adminEventsSince, now); err != nil {
+			return err
+		}
+		if opts.Start == "" {
+			opts.Start = now.UTC().Format(time.RFC3339)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		tenant := cfg.Tenant
+		fetch := func(ctx context.Context, opts *izanami.LogsRequest) ([]byte, error) {
+			return izanami.ListTenantLogs(client, ctx, tenant, opts, izanami.Identity)
+		}
+
+		ctx, cancel := context.WithCancel(commandContext())
+		defer cancel()
+
+		// Handle Ctrl+C gracefully
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			select {
+			case <-sigCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		w := cmd.OutOrStdout()
+		fmt.Fprintf(statusOut(cmd), "Watching feature events of tenant %s (Ctrl+C to stop)...\n", tenant)
+		printAuditEventHeader(w)
+		_, err = pollAuditLogs(ctx, fetch, opts, adminEventsInterval, func(err error) {
+			fmt.Fprintf(statusOut(cmd), "Warning: failed to fetch events: %v\n", err)
+		}, func(event json.RawMessage) error {
+			return printAuditEventLine(w, event)
+		})
+		if err != nil && err != context.Canceled {
+			return err
+		}
+		return nil
+	},
+}
+
+// parseFeatureEventTypes converts --type values (created, updated, deleted or
+// full audit types) to audit log types, all feature types when empty
+func parseFeatureEventTypes(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return []string{"FEATURE_CREATED", "FEATURE_UPDATED", "FEATURE_DELETED"}, nil
+	}
+	types := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if eventType, ok := featureEventTypes[strings.ToLower(value)]; ok {
+			types = append(types, eventType)
+			continue
+		}
+		eventType := strings.ToUpper(value)
+		if !strings.HasPrefix(eventType, "FEATURE_") {
+			return nil, fmt.Errorf("invalid --type %q (expected created, updated or deleted)", value)
+		}
+		types = append(types, eventType)
+	}
+	return types, nil
+}
+
+func init() {
+	adminCmd.AddCommand(adminEventsCmd)
+	adminEventsCmd.AddCommand(adminEventsTailCmd)
+
+	adminEventsTailCmd.Flags().StringSliceVar(&adminEventsProjects, "project", []string{}, "Filter by project (repeatable)")
+	adminEventsTailCmd.Flags().StringSliceVar(&adminEventsTypes, "type", []string{}, "Filter by event type: created, updated, deleted (repeatable)")
+	adminEventsTailCmd.Flags().StringVar(&adminEventsSince, "since", "", "Also print the events emitted since this date-time, date or duration ago (default: now)")
+	adminEventsTailCmd.Flags().DurationVar(&adminEventsInterval, "interval", 2*time.Second, "Polling interval (minimum 1s)")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestParseFeatureEventTypes(t *testing.T) {
+	types, err := parseFeatureEventTypes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FEATURE_CREATED", "FEATURE_UPDATED", "FEATURE_DELETED"}, types)
+
+	types, err = parseFeatureEventTypes([]string{"Deleted", "feature_updated"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FEATURE_DELETED", "FEATURE_UPDATED"}, types)
+
+	_, err = parseFeatureEventTypes([]string{"PROJECT_CREATED"})
+	assert.ErrorContains(t, err, "invalid --type")
+}
+
+func TestAdminEventsTailCmd_InvalidFlags(t *testing.T) {
+	origCfg := cfg
+	defer func() {
+		cfg = origCfg
+		adminEventsTypes, adminEventsSince = []string{}, ""
+	}()
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost", JwtToken: "token", Tenant: "acme"}
+
+	adminEventsTypes = []string{"renamed"}
+	assert.ErrorContains(t, adminEventsTailCmd.RunE(adminEventsTailCmd, nil), "invalid --type")
+
+	adminEventsTypes, adminEventsSince = []string{}, "yesterday"
+	assert.ErrorContains(t, adminEventsTailCmd.RunE(adminEventsTailCmd, nil), "invalid --since")
+}
+
+func TestPrintAuditEventLine(t *testing.T) {
+	origOutput := outputFormat
+	defer func() { outputFormat = origOutput }()
+	event := json.RawMessage(`{"eventId": 3, "type": "FEATURE_DELETED", "user": "alice", "name": "checkout", "project": "shop", "emittedAt": "2024-01-02T00:00:00Z"}`)
+
+	outputFormat = "table"
+	var buf bytes.Buffer
+	printAuditEventHeader(&buf)
+	require.NoError(t, printAuditEventLine(&buf, event))
+	assert.Contains(t, buf.String(), "EMITTED AT")
+	assert.Contains(t, buf.String(), "FEATURE_DELETED       alice             shop              checkout")
+
+	outputFormat = "ndjson"
+	buf.Reset()
+	printAuditEventHeader(&buf)
+	require.NoError(t, printAuditEventLine(&buf, event))
+	assert.Equal(t, `{"eventId":3,"type":"FEATURE_DELETED","user":"alice","name":"checkout","project":"shop","emittedAt":"2024-01-02T00:00:00Z"}`+"\n", buf.String())
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	manifestFiles    []string
+	manifestPrune    bool
+	applyAutoApprove bool
+)
+
+// manifestFormatHelp documents the manifest format for plan and apply
+const manifestFormatHelp = `Manifests are YAML or JSON files declaring tags, contexts and features:
+
+  tenant: my-tenant            # optional, defaults to --tenant or the profile's tenant
+  tags:
+    - name: checkout
+      description: Checkout funnel
+  contexts:
+    - path: prod/eu            # parent contexts are created as needed
+      project: shop            # omit for a global context
+      protected: true
+  features:
+    - name: new-checkout
+      project: shop
+      enabled: true
+      description: New checkout flow
+      tags: [checkout]
+      conditions: []           # activation conditions, as in the API
+
+Omitted feature fields take their default (disabled, boolean result, no tags,
+conditions or metadata). Only the projects referenced by the manifest are
+reconciled, and global contexts only when the manifest declares one. With
+--prune, undeclared features and contexts in that scope are deleted, and
+undeclared tags when the manifest declares tags. Overloads are not managed.`
+
+// planCmd shows the changes apply would make
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to match declarative manifests",
+	Long: `Compare declarative manifests with the server and show the tags, contexts and
+features that 'iz apply' would create, update or delete. Nothing is changed.
+
+` + manifestFormatHelp + `
+
+Examples:
+  # Show pending changes
+  iz plan -f features.yaml
+
+  # Include deletions of undeclared resources, for a directory of manifests
+  iz plan -f flags/ --prune
+
+  # Machine-readable plan
+  iz plan -f features.yaml -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := buildManifestPlan(cmd)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), plan, output.JSON)
+		}
+		printPlan(cmd.OutOrStdout(), plan)
+		return nil
+	},
+}
+
+// applyCmd reconciles the server with declarative manifests
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create and update resources to match declarative manifests",
+	Long: `Reconcile the server with declarative manifests: create missing tags, contexts
+and features, update features that differ and, with --prune, delete undeclared
+resources. Changes are shown before they are applied; deletions require
+confirmation unless --auto-approve is set.
+
+` + manifestFormatHelp + `
+
+Examples:
+  # Apply a manifest
+  iz apply -f features.yaml
+
+  # Apply every manifest of a directory, deleting undeclared resources
+  iz apply -f flags/ --prune --auto-approve
+
+  # Apply a manifest read from stdin to another tenant
+  cat features.yaml | iz apply -f - --tenant staging`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := buildManifestPlan(cmd)
+		if err != nil {
+			return err
+		}
+
+		out := statusOut(cmd)
+		printPlan(out, plan)
+		if len(plan.Changes) == 0 {
+			return nil
+		}
+		if plan.Count(izanami.PlanDelete) > 0 && !applyAutoApprove {
+			if !confirmAction(cmd, fmt.Sprintf("Apply these changes, including %d deletion(s)?", plan.Count(izanami.PlanDelete))) {
+				return nil
+			}
+		}
+
+		var projects []string
+		for _, change := range plan.Changes {
+			if change.Kind == izanami.PlanKindFeature {
+				project, _, _ := strings.Cut(change.Name, "/")
+				projects = append(projects, project)
+			}
+		}
+		if len(projects) > 0 {
+			client, err := izanami.NewAdminClient(cfg)
+			if err != nil {
+				return err
+			}
+			if err := saveMutationSnapshot(cmd, client, projects, true); err != nil {
+				return err
+			}
+		}
+
+		applied := 0
+		err = plan.Apply(commandContext(), func(change izanami.PlannedChange) {
+			applied++
+			if cfg.Verbose {
+				fmt.Fprintf(out, "[verbose] %s %s %s\n", change.Action, change.Kind, change.Name)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("%w (%d of %d change(s) applied)", err, applied, len(plan.Changes))
+		}
+		fmt.Fprintf(out, "Apply complete: %d created, %d updated, %d deleted\n",
+			plan.Count(izanami.PlanCreate), plan.Count(izanami.PlanUpdate), plan.Count(izanami.PlanDelete))
+		return nil
+	},
+}
+
+// buildManifestPlan loads the manifests given with --file and plans their reconciliation
+func buildManifestPlan(cmd *cobra.Command) (*izanami.Plan, error) {
+	if len(manifestFiles) == 0 {
+		return nil, fmt.Errorf("at least one manifest is required (use --file)")
+	}
+	manifest, err := izanami.LoadManifests(manifestFiles, cmd.InOrStdin())
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Tenant != "" {
+		if cmd.Flags().Changed("tenant") && tenant != manifest.Tenant {
+			return nil, fmt.Errorf("--tenant %s conflicts with the manifest tenant %s", tenant, manifest.Tenant)
+		}
+		cfg.Tenant = manifest.Tenant
+	}
+	if err := cfg.ValidateTenant(); err != nil {
+		return nil, err
+	}
+	if err := checkSessionExpiry(statusOut(cmd), cfg, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateAdminAuth(); err != nil {
+		return nil, err
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return izanami.BuildPlan(client, commandContext(), cfg.Tenant, manifest, manifestPrune)
+}
+
+// printPlan prints planned changes Terraform-style, followed by their warnings and a summary
+func printPlan(w io.Writer, plan *izanami.Plan) {
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Fprintf(w, "No changes: tenant %s matches the manifests\n", plan.Tenant)
+		return
+	}
+
+	fmt.Fprintf(w, "Changes for tenant %s:\n", plan.Tenant)
+	for _, change := range plan.Changes {
+		line := fmt.Sprintf("%s %s %s", change.Action, change.Kind, change.Name)
+		switch change.Action {
+		case izanami.PlanCreate:
+			fmt.Fprintln(w, color.GreenString("  + %s", line))
+		case izanami.PlanDelete:
+			fmt.Fprintln(w, color.RedString("  - %s", line))
+		default:
+			fmt.Fprintln(w, color.YellowString("  ~ %s", line))
+		}
+		for _, field := range change.Changes {
+			fmt.Fprintf(w, "      %s: %s -> %s\n", field.Field, displayPlanValue(field.From), displayPlanValue(field.To))
+		}
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete\n",
+		plan.Count(izanami.PlanCreate), plan.Count(izanami.PlanUpdate), plan.Count(izanami.PlanDelete))
+}
+
+// displayPlanValue shows unset values explicitly
+func displayPlanValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringSliceVarP(&manifestFiles, "file", "f", nil, "Manifest file or directory (repeatable, use - for stdin)")
+		c.Flags().BoolVar(&manifestPrune, "prune", false, "Delete undeclared resources in the manifests' scope")
+		c.MarkFlagRequired("file")
+	}
+	applyCmd.Flags().BoolVar(&applyAutoApprove, "auto-approve", false, "Apply deletions without confirmation")
+}
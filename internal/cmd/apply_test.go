@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// setupApplyTest serves a tenant with one feature, writes a manifest and points cfg to the server.
+// It returns the number of write requests received.
+func setupApplyTest(t *testing.T, manifest string) *int32 {
+	t.Helper()
+	var writes int32
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			atomic.AddInt32(&writes, 1)
+			io.WriteString(w, `{}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"checkout","project":"shop","enabled":true,"resultType":"boolean"},
+				{"id":"f2","name":"old","project":"shop","enabled":true,"resultType":"boolean"}]`)
+		default:
+			io.WriteString(w, `[]`)
+		}
+	})
+
+	file := filepath.Join(t.TempDir(), "features.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(manifest), 0600))
+
+	originalConfigDir := izanami.GetConfigDir()
+	configDir := t.TempDir()
+	izanami.SetGetConfigDirFunc(func() string { return configDir })
+
+	t.Cleanup(func() {
+		manifestFiles, manifestPrune, applyAutoApprove = nil, false, false
+		izanami.SetGetConfigDirFunc(func() string { return originalConfigDir })
+	})
+	outputFormat = "table"
+	manifestFiles = []string{file}
+	return &writes
+}
+
+const applyTestManifest = `
+features:
+  - name: checkout
+    project: shop
+    enabled: false
+  - name: search
+    project: shop
+    enabled: true
+`
+
+func TestPlanCmd_ShowsChanges(t *testing.T) {
+	writes := setupApplyTest(t, applyTestManifest)
+	manifestPrune = true
+
+	var buf bytes.Buffer
+	planCmd.SetOut(&buf)
+	defer planCmd.SetOut(nil)
+	require.NoError(t, planCmd.RunE(planCmd, nil))
+
+	out := buf.String()
+	assert.Contains(t, out, "Changes for tenant acme:")
+	assert.Contains(t, out, "~ update feature shop/checkout\n      enabled: true -> false\n")
+	assert.Contains(t, out, "+ create feature shop/search")
+	assert.Contains(t, out, "- delete feature shop/old")
+	assert.Contains(t, out, "Plan: 1 to create, 1 to update, 1 to delete")
+	assert.Equal(t, int32(0), atomic.LoadInt32(writes))
+}
+
+func TestApplyCmd_DeletionsNeedConfirmation(t *testing.T) {
+	writes := setupApplyTest(t, applyTestManifest)
+	manifestPrune = true
+
+	var buf bytes.Buffer
+	applyCmd.SetOut(&buf)
+	applyCmd.SetIn(bytes.NewBufferString("n\n"))
+	defer func() {
+		applyCmd.SetOut(nil)
+		applyCmd.SetIn(nil)
+	}()
+	require.NoError(t, applyCmd.RunE(applyCmd, nil))
+
+	assert.Contains(t, buf.String(), "including 1 deletion(s)?")
+	assert.Contains(t, buf.String(), "Cancelled")
+	assert.Equal(t, int32(0), atomic.LoadInt32(writes))
+}
+
+func TestApplyCmd_AppliesChanges(t *testing.T) {
+	writes := setupApplyTest(t, applyTestManifest)
+
+	var buf bytes.Buffer
+	applyCmd.SetOut(&buf)
+	defer applyCmd.SetOut(nil)
+	require.NoError(t, applyCmd.RunE(applyCmd, nil))
+
+	assert.Contains(t, buf.String(), "Apply complete: 1 created, 1 updated, 0 deleted")
+	assert.Equal(t, int32(2), atomic.LoadInt32(writes))
+
+	// The features of the project were saved before the changes
+	assert.Contains(t, buf.String(), "Snapshot saved: ")
+	snapshot, err := izanami.LastMutationSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, "apply", snapshot.Command)
+	require.Len(t, snapshot.Projects, 1)
+	assert.Len(t, snapshot.Projects[0].Features, 2)
+}
+
+func TestApplyCmd_ManifestTenantConflict(t *testing.T) {
+	setupApplyTest(t, "tenant: other\n"+applyTestManifest)
+
+	// Tenant given explicitly on the command line (merging the inherited flags first)
+	applyCmd.InheritedFlags()
+	require.NoError(t, applyCmd.Flags().Set("tenant", "acme"))
+	defer func() {
+		tenant = ""
+		applyCmd.Flags().Lookup("tenant").Changed = false
+	}()
+
+	err := applyCmd.RunE(applyCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with the manifest tenant other")
+}
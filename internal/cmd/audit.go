@@ -0,0 +1,610 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+)
+
+var (
+	auditTailFilters  []string
+	auditTailOut      string
+	auditTailRotate   string
+	auditTailKeep     int
+	auditTailInterval time.Duration
+	auditTailSince    string
+	auditTailCount    int
+
+	auditListUsers    []string
+	auditListProjects []string
+	auditListTypes    []string
+	auditListFeatures []string
+	auditListSince    string
+	auditListUntil    string
+	auditListOrder    string
+	auditListLimit    int
+	auditListCursor   int64
+	auditListAll      bool
+	auditListFollow   bool
+	auditListInterval time.Duration
+)
+
+// auditTailMinInterval is the minimum delay between two requests to the logs endpoint
+// (variable so tests can shorten it)
+var auditTailMinInterval = time.Second
+
+// auditLogsFetcher fetches one page of tenant logs as raw JSON
+type auditLogsFetcher func(ctx context.Context, opts *izanami.LogsRequest) ([]byte, error)
+
+// adminAuditCmd groups the audit log commands
+var adminAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Collect tenant audit events",
+	Long:  `Browse and collect audit events (feature changes, user actions, ...) of a tenant.`,
+}
+
+// adminAuditListCmd lists tenant audit events with filters
+var adminAuditListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List tenant audit events",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/logs"},
+	Long: `List the audit events of a tenant, newest first.
+
+Filters (repeatable or comma-separated, values of the same flag are combined):
+  --user, --project, --type, --feature
+
+--since and --until accept an ISO 8601 date-time (2024-01-31T12:00:00Z), a date
+(2024-01-31, midnight UTC) or a duration before now (30m, 24h, 7d).
+
+One page of --limit events is returned; when more events match, the cursor of
+the next page is printed to stderr. Use --all to fetch every page.
+
+With --follow, the last --limit matching events are printed oldest first, then
+new events are printed as they arrive (polled every --interval) until Ctrl+C.
+In this mode -o json prints one JSON event per line.
+
+With -o ndjson, events are printed one per line as each page is fetched, which
+suits piping --all listings into other tools.
+
+Examples:
+  # Recent events of a tenant
+  iz admin audit list --tenant my-tenant
+
+  # Feature deletions by a user during the last week
+  iz admin audit list --tenant my-tenant --user alice --type FEATURE_DELETED --since 7d
+
+  # Every event of a project in January, as JSON
+  iz admin audit list --tenant my-tenant --project shop --since 2024-01-01 --until 2024-02-01 --all -o json
+
+  # Next page
+  iz admin audit list --tenant my-tenant --cursor 12345
+
+  # Watch new events
+  iz admin audit list --tenant my-tenant --follow`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		opts := &izanami.LogsRequest{
+			Users:    strings.Join(auditListUsers, ","),
+			Projects: strings.Join(auditListProjects, ","),
+			Types:    strings.Join(auditListTypes, ","),
+			Features: strings.Join(auditListFeatures, ","),
+			Order:    auditListOrder,
+			Cursor:   auditListCursor,
+			Count:    auditListLimit,
+		}
+		var err error
+		if opts.Start, err = parseAuditTime("--since", auditListSince, now); err != nil {
+			return err
+		}
+		if opts.End, err = parseAuditTime("--until", auditListUntil, now); err != nil {
+			return err
+		}
+		if opts.Order != "asc" && opts.Order != "desc" {
+			return fmt.Errorf("invalid --order %q (expected asc or desc)", opts.Order)
+		}
+		if opts.Count <= 0 {
+			return fmt.Errorf("--limit must be positive")
+		}
+		if auditListFollow {
+			switch {
+			case auditListAll:
+				return fmt.Errorf("--follow cannot be combined with --all")
+			case opts.End != "":
+				return fmt.Errorf("--follow cannot be combined with --until")
+			case auditListInterval < auditTailMinInterval:
+				return fmt.Errorf("--interval must be at least %s", auditTailMinInterval)
+			}
+			// Start from the most recent events
+			opts.Order = "desc"
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		tenant := cfg.Tenant
+		fetch := func(ctx context.Context, opts *izanami.LogsRequest) ([]byte, error) {
+			return izanami.ListTenantLogs(client, ctx, tenant, opts, izanami.Identity)
+		}
+
+		if outputFormat == "ndjson" && !auditListFollow {
+			// Print each page as it is fetched
+			w := cmd.OutOrStdout()
+			next, err := walkAuditEvents(commandContext(), fetch, opts, auditListAll, func(page []json.RawMessage) error {
+				return output.PrintTo(w, page, output.NDJSON)
+			})
+			if err != nil {
+				return err
+			}
+			if next != 0 {
+				fmt.Fprintf(statusOut(cmd), "More events available: use --cursor %d for the next page\n", next)
+			}
+			return nil
+		}
+
+		events, next, err := listAuditEvents(commandContext(), fetch, opts, auditListAll)
+		if err != nil {
+			return err
+		}
+
+		if auditListFollow {
+			return followAuditEvents(cmd, fetch, opts, events)
+		}
+
+		if next != 0 {
+			fmt.Fprintf(statusOut(cmd), "More events available: use --cursor %d for the next page\n", next)
+		}
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), events, output.JSON)
+		}
+		views, err := auditTableViews(events)
+		if err != nil {
+			return err
+		}
+		return output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat))
+	},
+}
+
+// listAuditEvents fetches the first page of events, or every page when all is set.
+// It returns the cursor of the next page when more events may be available, or 0.
+func listAuditEvents(ctx context.Context, fetch auditLogsFetcher, opts *izanami.LogsRequest, all bool) ([]json.RawMessage, int64, error) {
+	events := []json.RawMessage{}
+	next, err := walkAuditEvents(ctx, fetch, opts, all, func(page []json.RawMessage) error {
+		events = append(events, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, next, nil
+}
+
+// walkAuditEvents passes each page of events to handle as it is fetched, like
+// listAuditEvents, and returns the cursor of the next page or 0
+func walkAuditEvents(ctx context.Context, fetch auditLogsFetcher, opts *izanami.LogsRequest, all bool, handle func(page []json.RawMessage) error) (int64, error) {
+	req := *opts
+	for {
+		raw, err := fetch(ctx, &req)
+		if err != nil {
+			return 0, err
+		}
+		page, err := parseAuditPage(raw)
+		if err != nil {
+			return 0, err
+		}
+		if err := handle(page); err != nil {
+			return 0, err
+		}
+		if len(page) == 0 || len(page) < req.Count {
+			return 0, nil
+		}
+
+		lastID, err := auditEventID(page[len(page)-1])
+		if err != nil {
+			return 0, err
+		}
+		if !all {
+			return lastID, nil
+		}
+		if lastID == req.Cursor {
+			// The server ignored the cursor, stop rather than loop forever
+			return 0, nil
+		}
+		req.Cursor = lastID
+	}
+}
+
+// followAuditEvents prints the given events oldest first, then polls for newer ones until Ctrl+C
+func followAuditEvents(cmd *cobra.Command, fetch auditLogsFetcher, opts *izanami.LogsRequest, events []json.RawMessage) error {
+	w := cmd.OutOrStdout()
+	printEvent := func(event json.RawMessage) error {
+		return printAuditEventLine(w, event)
+	}
+
+	printAuditEventHeader(w)
+	var lastEventID int64
+	for i := len(events) - 1; i >= 0; i-- {
+		eventID, err := auditEventID(events[i])
+		if err != nil {
+			return err
+		}
+		if err := printEvent(events[i]); err != nil {
+			return err
+		}
+		if eventID > lastEventID {
+			lastEventID = eventID
+		}
+	}
+
+	req := *opts
+	req.Order = "asc"
+	req.Cursor = lastEventID
+	if lastEventID == 0 && req.Start == "" {
+		req.Start = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	ctx, cancel := context.WithCancel(commandContext())
+	defer cancel()
+
+	// Handle Ctrl+C gracefully
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	fmt.Fprintln(statusOut(cmd), "Waiting for new events (Ctrl+C to stop)...")
+	_, err := pollAuditLogs(ctx, fetch, &req, auditListInterval, func(err error) {
+		fmt.Fprintf(statusOut(cmd), "Warning: failed to fetch audit events: %v\n", err)
+	}, printEvent)
+	if err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// printAuditEventHeader prints the column titles of printAuditEventLine, in table output only
+func printAuditEventHeader(w io.Writer) {
+	if outputFormat != "json" && outputFormat != "ndjson" {
+		fmt.Fprintf(w, "%-24s  %-20s  %-16s  %-16s  %s\n", "EMITTED AT", "TYPE", "USER", "PROJECT", "NAME")
+	}
+}
+
+// printAuditEventLine prints one audit event as a line: compact JSON with JSON
+// output, aligned columns otherwise
+func printAuditEventLine(w io.Writer, event json.RawMessage) error {
+	if outputFormat == "json" || outputFormat == "ndjson" {
+		_, err := fmt.Fprintf(w, "%s\n", compactAuditEvent(event))
+		return err
+	}
+	var parsed izanami.AuditEvent
+	if err := json.Unmarshal(event, &parsed); err != nil {
+		return fmt.Errorf("failed to parse audit event: %w", err)
+	}
+	view := parsed.ToTableView()
+	_, err := fmt.Fprintf(w, "%-24s  %-20s  %-16s  %-16s  %s\n", view.EmittedAt, view.Type, view.User, view.Project, view.Name)
+	return err
+}
+
+// auditTableViews converts raw audit events to table rows
+func auditTableViews(events []json.RawMessage) ([]izanami.AuditEventTableView, error) {
+	views := make([]izanami.AuditEventTableView, len(events))
+	for i, event := range events {
+		var parsed izanami.AuditEvent
+		if err := json.Unmarshal(event, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse audit event: %w", err)
+		}
+		views[i] = parsed.ToTableView()
+	}
+	return views, nil
+}
+
+// parseAuditTime converts an ISO 8601 date-time, a date or a duration before now
+// (Go syntax, plus "d" for days) to an ISO 8601 date-time
+func parseAuditTime(flag, value string, now time.Time) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	if d, err := parseDayDuration(value); err == nil {
+		return now.UTC().Add(-d).Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("invalid %s %q (expected an ISO 8601 date-time, a date or a duration such as 24h or 7d)", flag, value)
+}
+
+// adminAuditTailCmd polls the tenant logs and appends new events to a file
+var adminAuditTailCmd = &cobra.Command{
+	Use:         "tail",
+	Short:       "Continuously collect audit events to a file",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/logs"},
+	Long: `Poll the audit log of a tenant and write new events as newline-delimited JSON.
+
+This is a lightweight audit shipper for hosts without other log tooling: run it
+as a long-lived process and point your log collector at the output file.
+
+Filters (repeatable, values of the same key are combined):
+  type=<EVENT_TYPE>   e.g. FEATURE_CREATED, FEATURE_UPDATED, FEATURE_DELETED
+  user=<username>
+  feature=<feature-id>
+  project=<project-name>
+
+The logs endpoint is polled every --interval (at least 1s). When a full page
+of events is returned the next page is fetched right away, still respecting
+the 1s minimum delay between requests.
+
+With --rotate, the output file is renamed to <file>.1 (older files shifted to
+<file>.2, ...) once it reaches the given size; --keep rotated files are kept.
+
+Examples:
+  # Ship feature updates to a rotated file
+  iz admin audit tail --tenant my-tenant --filter type=FEATURE_UPDATED --out audit.ndjson --rotate 100MB
+
+  # Print all new events to stdout
+  iz admin audit tail --tenant my-tenant
+
+  # Also collect events emitted since a given date
+  iz admin audit tail --tenant my-tenant --since 2024-01-01T00:00:00Z --out audit.ndjson`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		opts, err := parseAuditFilters(auditTailFilters)
+		if err != nil {
+			return err
+		}
+		opts.Order = "asc"
+		opts.Count = auditTailCount
+		opts.Start = auditTailSince
+		if opts.Start == "" {
+			opts.Start = time.Now().UTC().Format(time.RFC3339)
+		}
+
+		if auditTailInterval < auditTailMinInterval {
+			return fmt.Errorf("--interval must be at least %s", auditTailMinInterval)
+		}
+
+		var maxSize int64
+		if auditTailRotate != "" {
+			if auditTailOut == "" {
+				return fmt.Errorf("--rotate requires --out")
+			}
+			maxSize, err = utils.ParseByteSize(auditTailRotate)
+			if err != nil {
+				return err
+			}
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = cmd.OutOrStdout()
+		if auditTailOut != "" {
+			file, err := utils.NewRotatingFile(auditTailOut, maxSize, auditTailKeep)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			w = file
+		}
+
+		ctx, cancel := context.WithCancel(commandContext())
+		defer cancel()
+
+		// Handle Ctrl+C gracefully
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			select {
+			case <-sigCh:
+				fmt.Fprintln(statusOut(cmd), "\nStopping audit tail...")
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		tenant := cfg.Tenant
+		fetch := func(ctx context.Context, opts *izanami.LogsRequest) ([]byte, error) {
+			return izanami.ListTenantLogs(client, ctx, tenant, opts, izanami.Identity)
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Collecting audit events of tenant %s (Ctrl+C to stop)...\n", tenant)
+		startTime := time.Now()
+		written, err := tailAuditLogs(ctx, fetch, opts, w, auditTailInterval, func(err error) {
+			fmt.Fprintf(statusOut(cmd), "Warning: failed to fetch audit events: %v\n", err)
+		})
+
+		fmt.Fprintf(statusOut(cmd), "Events written: %d (in %s)\n", written, time.Since(startTime).Round(time.Second))
+		if err != nil && err != context.Canceled {
+			return err
+		}
+		return nil
+	},
+}
+
+// parseAuditFilters converts key=value filters into log query parameters
+func parseAuditFilters(filters []string) (*izanami.LogsRequest, error) {
+	values := map[string][]string{}
+	for _, f := range filters {
+		key, value, ok := strings.Cut(f, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid filter %q (expected key=value)", f)
+		}
+		switch key {
+		case "type", "user", "feature", "project":
+			values[key] = append(values[key], value)
+		default:
+			return nil, fmt.Errorf("unknown filter key %q (supported: type, user, feature, project)", key)
+		}
+	}
+
+	return &izanami.LogsRequest{
+		Types:    strings.Join(values["type"], ","),
+		Users:    strings.Join(values["user"], ","),
+		Features: strings.Join(values["feature"], ","),
+		Projects: strings.Join(values["project"], ","),
+	}, nil
+}
+
+// tailAuditLogs polls the logs until ctx is cancelled, writing each new event as one JSON line.
+// Fetch errors are reported through onError and retried at the next poll.
+// It returns the number of events written.
+func tailAuditLogs(ctx context.Context, fetch auditLogsFetcher, opts *izanami.LogsRequest, w io.Writer, interval time.Duration, onError func(error)) (int, error) {
+	return pollAuditLogs(ctx, fetch, opts, interval, onError, func(event json.RawMessage) error {
+		if _, err := fmt.Fprintf(w, "%s\n", compactAuditEvent(event)); err != nil {
+			return fmt.Errorf("failed to write audit event: %w", err)
+		}
+		return nil
+	})
+}
+
+// pollAuditLogs polls the logs until ctx is cancelled, passing each new event to emit
+// in ascending order. It returns the number of events emitted.
+func pollAuditLogs(ctx context.Context, fetch auditLogsFetcher, opts *izanami.LogsRequest, interval time.Duration, onError func(error), emit func(event json.RawMessage) error) (int, error) {
+	written := 0
+	req := *opts
+	lastEventID := req.Cursor
+
+	for {
+		requestedAt := time.Now()
+		raw, err := fetch(ctx, &req)
+		pageFull := false
+		if err != nil {
+			if ctx.Err() != nil {
+				return written, ctx.Err()
+			}
+			onError(err)
+		} else {
+			events, err := parseAuditPage(raw)
+			if err != nil {
+				return written, err
+			}
+
+			for _, event := range events {
+				eventID, err := auditEventID(event)
+				if err != nil {
+					return written, err
+				}
+				// The cursor is inclusive on some servers, skip what was already written
+				if eventID <= lastEventID {
+					continue
+				}
+				if err := emit(event); err != nil {
+					return written, err
+				}
+				lastEventID = eventID
+				written++
+			}
+			req.Cursor = lastEventID
+			pageFull = req.Count > 0 && len(events) >= req.Count
+		}
+
+		// Catch up immediately on full pages, but never faster than the minimum interval
+		wait := interval
+		if pageFull {
+			wait = auditTailMinInterval
+		}
+		wait -= time.Since(requestedAt)
+
+		select {
+		case <-ctx.Done():
+			return written, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// parseAuditPage extracts the raw events of a logs response
+func parseAuditPage(raw []byte) ([]json.RawMessage, error) {
+	var page struct {
+		Events []json.RawMessage `json:"events"`
+	}
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse audit events: %w", err)
+	}
+	return page.Events, nil
+}
+
+// auditEventID returns the eventId of a raw audit event
+func auditEventID(event json.RawMessage) (int64, error) {
+	var header struct {
+		EventID int64 `json:"eventId"`
+	}
+	if err := json.Unmarshal(event, &header); err != nil {
+		return 0, fmt.Errorf("failed to parse audit event: %w", err)
+	}
+	return header.EventID, nil
+}
+
+// compactAuditEvent removes insignificant whitespace so each event fits on one line
+func compactAuditEvent(event json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, event); err != nil {
+		return event
+	}
+	return buf.Bytes()
+}
+
+func init() {
+	adminCmd.AddCommand(adminAuditCmd)
+	adminAuditCmd.AddCommand(adminAuditListCmd)
+	adminAuditCmd.AddCommand(adminAuditTailCmd)
+
+	adminAuditListCmd.Flags().StringSliceVar(&auditListUsers, "user", []string{}, "Filter by user (repeatable)")
+	adminAuditListCmd.Flags().StringSliceVar(&auditListProjects, "project", []string{}, "Filter by project (repeatable)")
+	adminAuditListCmd.Flags().StringSliceVar(&auditListTypes, "type", []string{}, "Filter by event type, e.g. FEATURE_UPDATED (repeatable)")
+	adminAuditListCmd.Flags().StringSliceVar(&auditListFeatures, "feature", []string{}, "Filter by feature ID (repeatable)")
+	adminAuditListCmd.Flags().StringVar(&auditListSince, "since", "", "Only events emitted after this date-time, date or duration ago (e.g. 24h, 7d)")
+	adminAuditListCmd.Flags().StringVar(&auditListUntil, "until", "", "Only events emitted before this date-time, date or duration ago")
+	adminAuditListCmd.Flags().StringVar(&auditListOrder, "order", "desc", "Sort order: asc or desc")
+	adminAuditListCmd.Flags().IntVar(&auditListLimit, "limit", 50, "Maximum number of events per page")
+	adminAuditListCmd.Flags().Int64Var(&auditListCursor, "cursor", 0, "Cursor of the page to fetch (printed when more events are available)")
+	adminAuditListCmd.Flags().BoolVar(&auditListAll, "all", false, "Fetch every page")
+	adminAuditListCmd.Flags().BoolVarP(&auditListFollow, "follow", "f", false, "Keep printing new events as they arrive")
+	adminAuditListCmd.Flags().DurationVar(&auditListInterval, "interval", 5*time.Second, "Polling interval with --follow (minimum 1s)")
+
+	adminAuditTailCmd.Flags().StringArrayVar(&auditTailFilters, "filter", []string{}, "Filter events as key=value (type, user, feature, project; repeatable)")
+	adminAuditTailCmd.Flags().StringVar(&auditTailOut, "out", "", "File to append events to (default: stdout)")
+	adminAuditTailCmd.Flags().StringVar(&auditTailRotate, "rotate", "", "Rotate the output file when it reaches this size (e.g. 100MB)")
+	adminAuditTailCmd.Flags().IntVar(&auditTailKeep, "keep", 5, "Number of rotated files to keep")
+	adminAuditTailCmd.Flags().DurationVar(&auditTailInterval, "interval", 10*time.Second, "Polling interval (minimum 1s)")
+	adminAuditTailCmd.Flags().StringVar(&auditTailSince, "since", "", "Collect events emitted since this ISO 8601 date (default: now)")
+	adminAuditTailCmd.Flags().IntVar(&auditTailCount, "count", 100, "Maximum number of events per request")
+}
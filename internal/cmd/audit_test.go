@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestParseAuditFilters(t *testing.T) {
+	opts, err := parseAuditFilters([]string{"type=FEATURE_UPDATED", "TYPE=FEATURE_CREATED", "user=admin", "project = shop"})
+	require.NoError(t, err)
+	assert.Equal(t, "FEATURE_UPDATED,FEATURE_CREATED", opts.Types)
+	assert.Equal(t, "admin", opts.Users)
+	assert.Equal(t, "shop", opts.Projects)
+	assert.Empty(t, opts.Features)
+
+	_, err = parseAuditFilters([]string{"type"})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = parseAuditFilters([]string{"color=red"})
+	assert.ErrorContains(t, err, "unknown filter key")
+}
+
+func TestTailAuditLogs(t *testing.T) {
+	defer func(d time.Duration) { auditTailMinInterval = d }(auditTailMinInterval)
+	auditTailMinInterval = time.Millisecond
+
+	pages := []string{
+		`{"events": [{"eventId": 1, "type": "FEATURE_UPDATED"}, {"eventId": 2, "type": "FEATURE_UPDATED"}]}`,
+		`{"events": [{"eventId": 2, "type": "FEATURE_UPDATED"}, {"eventId": 3,
+		  "type": "FEATURE_DELETED"}]}`,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var cursors []int64
+	calls := 0
+	fetch := func(ctx context.Context, opts *izanami.LogsRequest) ([]byte, error) {
+		cursors = append(cursors, opts.Cursor)
+		calls++
+		switch calls {
+		case 1:
+			return []byte(pages[0]), nil
+		case 2:
+			return nil, errors.New("connection refused")
+		case 3:
+			return []byte(pages[1]), nil
+		}
+		cancel()
+		return []byte(`{"events": []}`), nil
+	}
+
+	var out bytes.Buffer
+	var fetchErrors []error
+	written, err := tailAuditLogs(ctx, fetch, &izanami.LogsRequest{Order: "asc", Count: 2}, &out, time.Millisecond, func(err error) {
+		fetchErrors = append(fetchErrors, err)
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, written)
+	assert.Len(t, fetchErrors, 1)
+	assert.Equal(t, []int64{0, 2, 2, 3}, cursors)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"eventId":3,"type":"FEATURE_DELETED"}`, lines[2])
+}
+
+func TestParseAuditTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"2024-01-31T12:30:00+02:00", "2024-01-31T10:30:00Z"},
+		{"2024-01-31", "2024-01-31T00:00:00Z"},
+		{"90m", "2024-03-10T10:30:00Z"},
+		{"7d", "2024-03-03T12:00:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := parseAuditTime("--since", tt.value, now)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	_, err := parseAuditTime("--since", "yesterday", now)
+	assert.ErrorContains(t, err, "invalid --since")
+}
+
+func TestListAuditEvents_Pagination(t *testing.T) {
+	pages := map[int64]string{
+		0: `{"events": [{"eventId": 9}, {"eventId": 8}]}`,
+		8: `{"events": [{"eventId": 7}, {"eventId": 6}]}`,
+		6: `{"events": [{"eventId": 5}]}`,
+	}
+	var cursors []int64
+	fetch := func(ctx context.Context, opts *izanami.LogsRequest) ([]byte, error) {
+		cursors = append(cursors, opts.Cursor)
+		return []byte(pages[opts.Cursor]), nil
+	}
+
+	events, next, err := listAuditEvents(context.Background(), fetch, &izanami.LogsRequest{Order: "desc", Count: 2}, false)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int64(8), next)
+
+	cursors = nil
+	events, next, err = listAuditEvents(context.Background(), fetch, &izanami.LogsRequest{Order: "desc", Count: 2}, true)
+	require.NoError(t, err)
+	assert.Len(t, events, 5)
+	assert.Zero(t, next)
+	assert.Equal(t, []int64{0, 8, 6}, cursors)
+}
+
+func TestAdminAuditListCmd(t *testing.T) {
+	var query url.Values
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/logs", r.URL.Path)
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"events": [
+			{"eventId": 2, "type": "FEATURE_UPDATED", "user": "alice", "name": "checkout", "project": "shop", "emittedAt": "2024-01-02T00:00:00Z"},
+			{"eventId": 1, "type": "FEATURE_CREATED", "user": "alice", "name": "checkout", "project": "shop", "emittedAt": "2024-01-01T00:00:00Z"}
+		]}`)
+	})
+
+	defer func() {
+		auditListUsers, auditListTypes, auditListSince, auditListLimit = []string{}, []string{}, "", 50
+	}()
+	auditListUsers = []string{"alice"}
+	auditListTypes = []string{"FEATURE_CREATED", "FEATURE_UPDATED"}
+	auditListSince = "2024-01-01"
+	auditListLimit = 2
+
+	// Table output, with a hint for the next page
+	outputFormat = "table"
+	var buf bytes.Buffer
+	adminAuditListCmd.SetOut(&buf)
+	defer adminAuditListCmd.SetOut(nil)
+	require.NoError(t, adminAuditListCmd.RunE(adminAuditListCmd, nil))
+
+	assert.Equal(t, "alice", query.Get("users"))
+	assert.Equal(t, "FEATURE_CREATED,FEATURE_UPDATED", query.Get("types"))
+	assert.Equal(t, "2024-01-01T00:00:00Z", query.Get("start"))
+	assert.Equal(t, "desc", query.Get("order"))
+	assert.Equal(t, "2", query.Get("count"))
+	assert.Contains(t, buf.String(), "use --cursor 1 for the next page")
+	assert.Contains(t, buf.String(), "FEATURE_UPDATED")
+
+	// JSON output is an array of the raw events
+	outputFormat = "json"
+	auditListLimit = 10
+	buf.Reset()
+	require.NoError(t, adminAuditListCmd.RunE(adminAuditListCmd, nil))
+	var events []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &events))
+	assert.Len(t, events, 2)
+	assert.Equal(t, "FEATURE_UPDATED", events[0]["type"])
+
+	// NDJSON output prints one compact event per line
+	outputFormat = "ndjson"
+	buf.Reset()
+	require.NoError(t, adminAuditListCmd.RunE(adminAuditListCmd, nil))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], `{"eventId":2,"type":"FEATURE_UPDATED"`), lines[0])
+}
+
+func TestAdminAuditListCmd_FollowConflicts(t *testing.T) {
+	origCfg := cfg
+	defer func() {
+		cfg = origCfg
+		auditListFollow, auditListAll, auditListUntil = false, false, ""
+	}()
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost", Tenant: "acme"}
+	auditListFollow = true
+
+	auditListAll = true
+	assert.ErrorContains(t, adminAuditListCmd.RunE(adminAuditListCmd, nil), "--follow cannot be combined with --all")
+
+	auditListAll = false
+	auditListUntil = "1h"
+	assert.ErrorContains(t, adminAuditListCmd.RunE(adminAuditListCmd, nil), "--follow cannot be combined with --until")
+}
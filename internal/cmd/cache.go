@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// completionCacheTTL is how long shell completions reuse API responses when no cache-ttl is configured
+const completionCacheTTL = 60
+
+// cacheableCommands are the read-only commands allowed to serve responses from the cache
+var cacheableCommands = map[string]bool{
+	"list": true,
+	"get":  true,
+}
+
+// cacheCmd groups the local response cache commands
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local response cache",
+	Long: `Manage the on-disk cache of API responses.
+
+List and get commands can serve responses from a local cache to avoid slow
+round-trips to remote servers. Caching is disabled by default: enable it with
+--cache-ttl <seconds> or 'iz config set cache-ttl <seconds>', and bypass it for
+one command with --no-cache. Shell completions always cache responses for 60s
+unless a TTL is configured.
+
+Any create, update or delete made through the CLI clears the cache. API keys
+and webhooks are never cached, as they hold client secrets and webhook headers.
+'iz features check --cache' keeps its results apart; 'iz cache clear' removes
+them as well.
+
+Examples:
+  # List projects, reusing results up to 5 minutes old
+  iz admin projects list --cache-ttl 300
+
+  # Remove all cached responses
+  iz cache clear`,
+}
+
+// cacheClearCmd removes every cached response
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached responses",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := izanami.ClearResponseCache()
+		if err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+		checks, err := izanami.ClearCheckCache()
+		if err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+		fmt.Fprintf(statusOut(cmd), "Cache cleared: %d cached response(s) removed from %s\n", removed, izanami.ResponseCacheDir())
+		if checks > 0 {
+			fmt.Fprintf(statusOut(cmd), "%d cached feature check result(s) removed\n", checks)
+		}
+		return nil
+	},
+}
+
+// isCacheableCommand reports whether a command may serve responses from the cache
+func isCacheableCommand(cmd *cobra.Command) bool {
+	return cacheableCommands[cmd.Name()]
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestIsCacheableCommand(t *testing.T) {
+	assert.True(t, isCacheableCommand(&cobra.Command{Use: "list"}))
+	assert.True(t, isCacheableCommand(&cobra.Command{Use: "get <id>"}))
+	assert.False(t, isCacheableCommand(&cobra.Command{Use: "update <id>"}))
+	assert.False(t, isCacheableCommand(&cobra.Command{Use: "toggle"}))
+}
+
+func TestCacheClearCmd(t *testing.T) {
+	dir := t.TempDir()
+	originalCacheDir := izanami.GetCacheDir()
+	izanami.SetGetCacheDirFunc(func() string { return dir })
+	t.Cleanup(func() { izanami.SetGetCacheDirFunc(func() string { return originalCacheDir }) })
+
+	responses := filepath.Join(dir, "responses")
+	require.NoError(t, os.MkdirAll(responses, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(responses, "entry.json"), []byte("{}"), 0600))
+
+	var stderr bytes.Buffer
+	cacheClearCmd.SetOut(&stderr)
+	defer cacheClearCmd.SetOut(nil)
+
+	require.NoError(t, cacheClearCmd.RunE(cacheClearCmd, nil))
+	assert.Contains(t, stderr.String(), "1 cached response(s) removed")
+	assert.NoDirExists(t, responses)
+}
@@ -30,7 +30,7 @@ func formatClientKeysCount(clientKeys map[string]izanami.TenantClientKeysConfig)
 // promptClientCredentials interactively prompts for client-id and client-secret.
 // The secret is read with terminal echo disabled.
 func promptClientCredentials(cmd *cobra.Command, reader *bufio.Reader) (string, string, error) {
-	fmt.Fprintf(cmd.OutOrStderr(), "Client ID: ")
+	fmt.Fprintf(statusOut(cmd), "Client ID: ")
 	line, err := reader.ReadString('\n')
 	if err != nil && line == "" {
 		return "", "", fmt.Errorf("failed to read client ID: %w", err)
@@ -40,9 +40,9 @@ func promptClientCredentials(cmd *cobra.Command, reader *bufio.Reader) (string,
 		return "", "", fmt.Errorf("client ID cannot be empty")
 	}
 
-	fmt.Fprintf(cmd.OutOrStderr(), "Client Secret: ")
+	fmt.Fprintf(statusOut(cmd), "Client Secret: ")
 	secretBytes, err := term.ReadPassword(int(syscall.Stdin))
-	fmt.Fprintln(cmd.OutOrStderr()) // New line after password input
+	fmt.Fprintln(statusOut(cmd)) // New line after password input
 	if err != nil {
 		return "", "", fmt.Errorf("failed to read client secret: %w", err)
 	}
@@ -55,16 +55,19 @@ func promptClientCredentials(cmd *cobra.Command, reader *bufio.Reader) (string,
 }
 
 // confirmOverwrite prompts the user to confirm overwriting existing credentials.
-// Returns true if the user confirms, false otherwise.
+// Returns true if the user confirms, false otherwise; --yes confirms.
 func confirmOverwrite(cmd *cobra.Command, reader *bufio.Reader, label string) (bool, error) {
-	fmt.Fprintf(cmd.OutOrStderr(), "\nAlready has credentials for %s.\n", label)
-	fmt.Fprintf(cmd.OutOrStderr(), "Overwrite existing credentials? (y/N): ")
+	if assumeYes {
+		return true, nil
+	}
+	fmt.Fprintf(statusOut(cmd), "\nAlready has credentials for %s.\n", label)
+	fmt.Fprintf(statusOut(cmd), "Overwrite existing credentials? (y/N): ")
 	line, err := reader.ReadString('\n')
 	if err != nil && line == "" {
 		return false, fmt.Errorf("failed to read input: %w", err)
 	}
 	if strings.ToLower(strings.TrimSpace(line)) != "y" {
-		fmt.Fprintln(cmd.OutOrStderr(), "Aborted.")
+		fmt.Fprintln(statusOut(cmd), "Aborted.")
 		return false, nil
 	}
 	return true, nil
@@ -243,7 +246,7 @@ Security:
 			return fmt.Errorf("no active profile. Use 'iz profiles use <name>' to select a profile first")
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Adding credentials to profile: %s\n\n", profileName)
+		fmt.Fprintf(statusOut(cmd), "Adding credentials to profile: %s\n\n", profileName)
 
 		reader := bufio.NewReader(cmd.InOrStdin())
 
@@ -255,9 +258,9 @@ Security:
 			}
 		} else if clientSecret == "" {
 			// --client-id provided, prompt only for secret (hidden input)
-			fmt.Fprintf(cmd.OutOrStderr(), "Client Secret: ")
+			fmt.Fprintf(statusOut(cmd), "Client Secret: ")
 			secretBytes, err := term.ReadPassword(int(syscall.Stdin))
-			fmt.Fprintln(cmd.OutOrStderr())
+			fmt.Fprintln(statusOut(cmd))
 			if err != nil {
 				return fmt.Errorf("failed to read client secret: %w", err)
 			}
@@ -292,15 +295,15 @@ Security:
 		}
 
 		if len(projects) == 0 {
-			fmt.Fprintf(cmd.OutOrStderr(), "\nClient credentials saved to profile '%s' for tenant '%s'\n", profileName, tenant)
+			fmt.Fprintf(statusOut(cmd), "\nClient credentials saved to profile '%s' for tenant '%s'\n", profileName, tenant)
 		} else {
-			fmt.Fprintf(cmd.OutOrStderr(), "\nClient credentials saved to profile '%s' for tenant '%s', projects: %s\n", profileName, tenant, strings.Join(projects, ", "))
+			fmt.Fprintf(statusOut(cmd), "\nClient credentials saved to profile '%s' for tenant '%s', projects: %s\n", profileName, tenant, strings.Join(projects, ", "))
 		}
 
-		printSecurityWarning(cmd.OutOrStderr())
+		printSecurityWarning(statusOut(cmd))
 
-		fmt.Fprintf(cmd.OutOrStderr(), "\nYou can now use these credentials with:\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "  iz features check --tenant %s <feature-id>\n", tenant)
+		fmt.Fprintf(statusOut(cmd), "\nYou can now use these credentials with:\n")
+		fmt.Fprintf(statusOut(cmd), "  iz features check --tenant %s <feature-id>\n", tenant)
 
 		return nil
 	},
@@ -411,9 +414,9 @@ Examples:
 		}
 
 		if project == "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted credentials for tenant '%s' (client-id: %s)\n", tenant, clientID)
+			fmt.Fprintf(statusOut(cmd), "Deleted credentials for tenant '%s' (client-id: %s)\n", tenant, clientID)
 		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted credentials for project '%s/%s' (client-id: %s)\n", tenant, project, clientID)
+			fmt.Fprintf(statusOut(cmd), "Deleted credentials for project '%s/%s' (client-id: %s)\n", tenant, project, clientID)
 		}
 		return nil
 	},
@@ -486,14 +489,14 @@ Security:
 			return fmt.Errorf("--client-id is required when --client-secret is provided")
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Adding credentials to worker")
+		fmt.Fprintf(statusOut(cmd), "Adding credentials to worker")
 		if workerClientKeysWorker != "" {
-			fmt.Fprintf(cmd.OutOrStderr(), " '%s'", workerClientKeysWorker)
+			fmt.Fprintf(statusOut(cmd), " '%s'", workerClientKeysWorker)
 		} else {
-			fmt.Fprintf(cmd.OutOrStderr(), " (default)")
+			fmt.Fprintf(statusOut(cmd), " (default)")
 		}
-		fmt.Fprintln(cmd.OutOrStderr())
-		fmt.Fprintln(cmd.OutOrStderr())
+		fmt.Fprintln(statusOut(cmd))
+		fmt.Fprintln(statusOut(cmd))
 
 		reader := bufio.NewReader(cmd.InOrStdin())
 
@@ -506,9 +509,9 @@ Security:
 			}
 		} else if clientSecret == "" {
 			// --client-id provided, prompt only for secret (hidden input)
-			fmt.Fprintf(cmd.OutOrStderr(), "Client Secret: ")
+			fmt.Fprintf(statusOut(cmd), "Client Secret: ")
 			secretBytes, err := term.ReadPassword(int(syscall.Stdin))
-			fmt.Fprintln(cmd.OutOrStderr())
+			fmt.Fprintln(statusOut(cmd))
 			if err != nil {
 				return fmt.Errorf("failed to read client secret: %w", err)
 			}
@@ -551,12 +554,12 @@ Security:
 		}
 
 		if len(projects) == 0 {
-			fmt.Fprintf(cmd.OutOrStderr(), "\nClient credentials saved to worker '%s' for tenant '%s'\n", workerClientKeysWorker, tenant)
+			fmt.Fprintf(statusOut(cmd), "\nClient credentials saved to worker '%s' for tenant '%s'\n", workerClientKeysWorker, tenant)
 		} else {
-			fmt.Fprintf(cmd.OutOrStderr(), "\nClient credentials saved to worker '%s' for tenant '%s', projects: %s\n", workerClientKeysWorker, tenant, strings.Join(projects, ", "))
+			fmt.Fprintf(statusOut(cmd), "\nClient credentials saved to worker '%s' for tenant '%s', projects: %s\n", workerClientKeysWorker, tenant, strings.Join(projects, ", "))
 		}
 
-		printSecurityWarning(cmd.OutOrStderr())
+		printSecurityWarning(statusOut(cmd))
 
 		return nil
 	},
@@ -629,9 +632,9 @@ Examples:
 		}
 
 		if project == "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted credentials for tenant '%s' from worker %s\n", tenant, workerDisplay)
+			fmt.Fprintf(statusOut(cmd), "Deleted credentials for tenant '%s' from worker %s\n", tenant, workerDisplay)
 		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted credentials for '%s/%s' from worker %s\n", tenant, project, workerDisplay)
+			fmt.Fprintf(statusOut(cmd), "Deleted credentials for '%s/%s' from worker %s\n", tenant, project, workerDisplay)
 		}
 		return nil
 	},
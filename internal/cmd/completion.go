@@ -1,18 +1,37 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
 )
 
+var (
+	completionInstallDryRun bool
+)
+
+// completionShells lists the shells supported by the completion commands
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
 // completionCmd represents the completion command
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate shell completion script",
 	Long: `Generate shell completion script for the Izanami CLI.
 
-To load completions:
+The quickest way to set up completion is:
+
+  $ iz completion install
+
+To load completions manually:
 
 Bash:
 
@@ -62,22 +81,308 @@ PowerShell:
   # and source this file from your PowerShell profile.
 `,
 	DisableFlagsInUseLine: true,
-	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	ValidArgs:             completionShells,
 	Args:                  cobra.ExactValidArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		switch args[0] {
-		case "bash":
-			cmd.Root().GenBashCompletion(os.Stdout)
-		case "zsh":
-			cmd.Root().GenZshCompletion(os.Stdout)
-		case "fish":
-			cmd.Root().GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateCompletionScript(cmd.Root(), args[0], cmd.OutOrStdout())
+	},
+}
+
+// completionInstallCmd installs the completion script for the current shell
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish|powershell]",
+	Short: "Install shell completion for the current shell",
+	Long: `Detect your shell, write the completion script to the location it loads
+completions from, and check that the script loads.
+
+The shell is detected from $SHELL (or PowerShell on Windows). Pass the shell
+name explicitly to override detection. When the location cannot be written,
+the command to load the script from your shell startup file is shown instead.
+
+Tenant, project, feature, context and tag names are completed from the API
+with the active profile: the install checks that iz is in the PATH and that
+you are logged in.
+
+Install locations:
+  bash        ~/.local/share/bash-completion/completions/iz
+  zsh         ~/.zsh/completions/_iz (fpath is added to ~/.zshrc if needed)
+  fish        ~/.config/fish/completions/iz.fish
+  powershell  <config dir>/completion.ps1 (dot-sourced from your $PROFILE)
+
+Examples:
+  # Install for the detected shell
+  iz completion install
+
+  # Install for a specific shell
+  iz completion install zsh
+
+  # Show what would be written without touching any file
+  iz completion install --dry-run`,
+	ValidArgs: completionShells,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell := ""
+		if len(args) == 1 {
+			shell = args[0]
+		} else {
+			detected, err := detectShell(os.Getenv, runtime.GOOS)
+			if err != nil {
+				return err
+			}
+			shell = detected
+		}
+
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to determine home directory: %w", err)
 		}
+
+		target, err := completionInstallTargetFor(shell, home, os.Getenv, runtime.GOOS)
+		if err != nil {
+			return err
+		}
+
+		var script bytes.Buffer
+		if err := generateCompletionScript(cmd.Root(), shell, &script); err != nil {
+			return err
+		}
+
+		stderr := statusOut(cmd)
+		if completionInstallDryRun {
+			fmt.Fprintf(stderr, "Shell:  %s\n", shell)
+			fmt.Fprintf(stderr, "Script: %s\n", target.ScriptPath)
+			if target.RCPath != "" {
+				fmt.Fprintf(stderr, "Append to %s:\n%s\n", target.RCPath, target.RCSnippet)
+			}
+			return nil
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target.ScriptPath), 0755); err != nil {
+			printManualCompletionInstructions(stderr, shell)
+			return fmt.Errorf("failed to create completion directory: %w", err)
+		}
+		if err := os.WriteFile(target.ScriptPath, script.Bytes(), 0644); err != nil {
+			printManualCompletionInstructions(stderr, shell)
+			return fmt.Errorf("failed to write completion script: %w", err)
+		}
+		fmt.Fprintf(stderr, "✅ Wrote %s completion script to %s\n", shell, target.ScriptPath)
+
+		if target.RCPath != "" {
+			updated, err := ensureRCSnippet(target.RCPath, target.RCSnippet)
+			if err != nil {
+				fmt.Fprintf(stderr, "Add these lines to %s yourself:\n%s\n", target.RCPath, target.RCSnippet)
+				return err
+			}
+			if updated {
+				fmt.Fprintf(stderr, "✅ Updated %s\n", target.RCPath)
+			}
+		}
+
+		if err := verifyCompletionScript(shell, target.ScriptPath); err != nil {
+			fmt.Fprintf(stderr, "⚠️  Could not verify completion script: %v\n", err)
+		} else {
+			fmt.Fprintf(stderr, "✅ Verified that the completion script loads\n")
+		}
+
+		if ok, message := dynamicCompletionStatus(exec.LookPath, loadCompletionConfig()); ok {
+			fmt.Fprintf(stderr, "✅ %s\n", message)
+		} else {
+			fmt.Fprintf(stderr, "⚠️  %s\n", message)
+		}
+
+		fmt.Fprintln(stderr, "Start a new shell session to enable completion.")
+		return nil
 	},
 }
 
+// manualCompletionInstructions are the commands installing the completion by hand
+var manualCompletionInstructions = map[string]string{
+	"bash":       "echo 'source <(iz completion bash)' >> ~/.bashrc",
+	"zsh":        "echo 'source <(iz completion zsh)' >> ~/.zshrc",
+	"fish":       "iz completion fish > ~/.config/fish/completions/iz.fish",
+	"powershell": "Add-Content $PROFILE 'iz completion powershell | Out-String | Invoke-Expression'",
+}
+
+// printManualCompletionInstructions explains how to install the completion when
+// the install location cannot be written
+func printManualCompletionInstructions(w io.Writer, shell string) {
+	fmt.Fprintf(w, "Could not install the completion script. To load it from your shell startup file instead, run:\n  %s\n", manualCompletionInstructions[shell])
+}
+
+// dynamicCompletionStatus checks that resource names can be completed: the
+// completion scripts run 'iz __complete', which needs iz in the PATH, and the
+// resources are listed with the admin credentials of the active profile
+func dynamicCompletionStatus(lookPath func(string) (string, error), config *izanami.ResolvedConfig) (bool, string) {
+	if _, err := lookPath("iz"); err != nil {
+		return false, "iz is not in your PATH: commands and flags complete, but resource names need iz in the PATH"
+	}
+	if config == nil || config.LeaderURL == "" || config.ValidateAdminAuth() != nil {
+		return false, "Log in with 'iz login' to complete tenant, project, feature, context and tag names"
+	}
+	return true, fmt.Sprintf("Tenant, project, feature, context and tag names are completed from %s", config.LeaderURL)
+}
+
+// generateCompletionScript writes the completion script for the given shell
+func generateCompletionScript(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletion(w)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	}
+	return fmt.Errorf("unsupported shell: %s (supported: %s)", shell, strings.Join(completionShells, ", "))
+}
+
+// detectShell determines the user's shell from the environment
+func detectShell(getenv func(string) string, goos string) (string, error) {
+	if shellPath := getenv("SHELL"); shellPath != "" {
+		name := strings.TrimSuffix(filepath.Base(shellPath), ".exe")
+		switch name {
+		case "bash", "zsh", "fish":
+			return name, nil
+		case "pwsh", "powershell":
+			return "powershell", nil
+		}
+	}
+
+	// PowerShell sets PSModulePath; on Windows it is the only supported shell
+	if goos == "windows" || getenv("PSModulePath") != "" {
+		return "powershell", nil
+	}
+
+	return "", fmt.Errorf("could not detect your shell; specify it explicitly: iz completion install [%s]", strings.Join(completionShells, "|"))
+}
+
+// completionInstallTarget describes where a completion script is installed
+type completionInstallTarget struct {
+	ScriptPath string // File receiving the completion script
+	RCPath     string // Optional shell startup file that must load the script
+	RCSnippet  string // Lines to add to RCPath
+}
+
+// completionInstallTargetFor returns the install location for the given shell
+func completionInstallTargetFor(shell, home string, getenv func(string) string, goos string) (*completionInstallTarget, error) {
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch shell {
+	case "bash":
+		return &completionInstallTarget{
+			ScriptPath: filepath.Join(dataHome, "bash-completion", "completions", "iz"),
+		}, nil
+	case "zsh":
+		zdot := getenv("ZDOTDIR")
+		if zdot == "" {
+			zdot = home
+		}
+		dir := filepath.Join(zdot, ".zsh", "completions")
+		return &completionInstallTarget{
+			ScriptPath: filepath.Join(dir, "_iz"),
+			RCPath:     filepath.Join(zdot, ".zshrc"),
+			RCSnippet:  fmt.Sprintf("fpath=(%s $fpath)\nautoload -U compinit; compinit", dir),
+		}, nil
+	case "fish":
+		return &completionInstallTarget{
+			ScriptPath: filepath.Join(configHome, "fish", "completions", "iz.fish"),
+		}, nil
+	case "powershell":
+		profileDir := filepath.Join(configHome, "powershell")
+		scriptDir := filepath.Join(configHome, "iz")
+		if goos == "windows" {
+			profileDir = filepath.Join(home, "Documents", "PowerShell")
+			if appData := getenv("APPDATA"); appData != "" {
+				scriptDir = filepath.Join(appData, "iz")
+			}
+		}
+		scriptPath := filepath.Join(scriptDir, "completion.ps1")
+		return &completionInstallTarget{
+			ScriptPath: scriptPath,
+			RCPath:     filepath.Join(profileDir, "Microsoft.PowerShell_profile.ps1"),
+			RCSnippet:  fmt.Sprintf(". '%s'", scriptPath),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported shell: %s (supported: %s)", shell, strings.Join(completionShells, ", "))
+}
+
+// ensureRCSnippet appends the snippet to the startup file unless it is already present.
+// Returns true if the file was modified.
+func ensureRCSnippet(path, snippet string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	firstLine := strings.SplitN(snippet, "\n", 2)[0]
+	if strings.Contains(string(existing), firstLine) {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	prefix := ""
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		prefix = "\n"
+	}
+	if _, err := fmt.Fprintf(f, "%s\n# iz shell completion\n%s\n", prefix, snippet); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// verifyCompletionScript loads the installed script in the target shell to make sure it parses
+func verifyCompletionScript(shell, scriptPath string) error {
+	var name string
+	var args []string
+
+	switch shell {
+	case "bash":
+		name, args = "bash", []string{"-c", fmt.Sprintf("source '%s'", scriptPath)}
+	case "zsh":
+		name, args = "zsh", []string{"-c", fmt.Sprintf("autoload -U compinit; compinit -u; source '%s'", scriptPath)}
+	case "fish":
+		name, args = "fish", []string{"--no-execute", scriptPath}
+	case "powershell":
+		name = "pwsh"
+		if _, err := exec.LookPath(name); err != nil {
+			name = "powershell"
+		}
+		args = []string{"-NoProfile", "-Command", fmt.Sprintf(". '%s'", scriptPath)}
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s not found in PATH", name)
+	}
+
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(completionCmd)
+	completionCmd.AddCommand(completionInstallCmd)
+
+	completionInstallCmd.Flags().BoolVar(&completionInstallDryRun, "dry-run", false, "Show where the completion would be installed without writing files")
 }
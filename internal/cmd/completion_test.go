@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func envFrom(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		goos    string
+		want    string
+		wantErr bool
+	}{
+		{name: "bash", env: map[string]string{"SHELL": "/bin/bash"}, goos: "linux", want: "bash"},
+		{name: "zsh", env: map[string]string{"SHELL": "/usr/local/bin/zsh"}, goos: "darwin", want: "zsh"},
+		{name: "fish", env: map[string]string{"SHELL": "/usr/bin/fish"}, goos: "linux", want: "fish"},
+		{name: "pwsh as login shell", env: map[string]string{"SHELL": "/usr/bin/pwsh"}, goos: "linux", want: "powershell"},
+		{name: "windows without SHELL", env: map[string]string{}, goos: "windows", want: "powershell"},
+		{name: "PSModulePath set", env: map[string]string{"PSModulePath": "/opt/microsoft"}, goos: "linux", want: "powershell"},
+		{name: "unknown shell", env: map[string]string{"SHELL": "/bin/tcsh"}, goos: "linux", wantErr: true},
+		{name: "nothing set", env: map[string]string{}, goos: "linux", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectShell(envFrom(tt.env), tt.goos)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompletionInstallTargetFor(t *testing.T) {
+	home := filepath.Join("home", "user")
+	noEnv := envFrom(map[string]string{})
+
+	bash, err := completionInstallTargetFor("bash", home, noEnv, "linux")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "share", "bash-completion", "completions", "iz"), bash.ScriptPath)
+	assert.Empty(t, bash.RCPath)
+
+	zsh, err := completionInstallTargetFor("zsh", home, noEnv, "darwin")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".zsh", "completions", "_iz"), zsh.ScriptPath)
+	assert.Equal(t, filepath.Join(home, ".zshrc"), zsh.RCPath)
+	assert.Contains(t, zsh.RCSnippet, "fpath=(")
+
+	fish, err := completionInstallTargetFor("fish", home, envFrom(map[string]string{"XDG_CONFIG_HOME": "/xdg"}), "linux")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/xdg", "fish", "completions", "iz.fish"), fish.ScriptPath)
+
+	ps, err := completionInstallTargetFor("powershell", home, noEnv, "windows")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), ps.RCPath)
+	assert.Contains(t, ps.RCSnippet, ps.ScriptPath)
+
+	_, err = completionInstallTargetFor("tcsh", home, noEnv, "linux")
+	assert.Error(t, err)
+}
+
+func TestEnsureRCSnippet_Idempotent(t *testing.T) {
+	rcPath := filepath.Join(t.TempDir(), ".zshrc")
+	require.NoError(t, os.WriteFile(rcPath, []byte("export FOO=bar"), 0644))
+
+	snippet := "fpath=(/home/user/.zsh/completions $fpath)\nautoload -U compinit; compinit"
+
+	updated, err := ensureRCSnippet(rcPath, snippet)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	updated, err = ensureRCSnippet(rcPath, snippet)
+	require.NoError(t, err)
+	assert.False(t, updated, "snippet should not be appended twice")
+
+	content, err := os.ReadFile(rcPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "export FOO=bar\n"))
+	assert.Equal(t, 1, strings.Count(string(content), "fpath=("))
+}
+
+func TestDynamicCompletionStatus(t *testing.T) {
+	found := func(string) (string, error) { return "/usr/local/bin/iz", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+	loggedIn := &izanami.ResolvedConfig{LeaderURL: "https://izanami.example.com", JwtToken: "token"}
+
+	ok, message := dynamicCompletionStatus(found, loggedIn)
+	assert.True(t, ok)
+	assert.Contains(t, message, "completed from https://izanami.example.com")
+
+	ok, message = dynamicCompletionStatus(missing, loggedIn)
+	assert.False(t, ok)
+	assert.Contains(t, message, "not in your PATH")
+
+	ok, message = dynamicCompletionStatus(found, nil)
+	assert.False(t, ok)
+	assert.Contains(t, message, "iz login")
+
+	ok, _ = dynamicCompletionStatus(found, &izanami.ResolvedConfig{LeaderURL: "https://izanami.example.com"})
+	assert.False(t, ok)
+}
+
+func TestManualCompletionInstructions(t *testing.T) {
+	for _, shell := range completionShells {
+		assert.Contains(t, manualCompletionInstructions[shell], "iz completion "+shell, shell)
+	}
+}
@@ -30,6 +30,9 @@ type Completer struct {
 	// ListContexts fetches contexts from the API for a given tenant and optional project.
 	ListContexts func(cfg *izanami.ResolvedConfig, ctx context.Context, tenant, project string) ([]izanami.Context, error)
 
+	// ListFeatures fetches features from the API for a given tenant.
+	ListFeatures func(cfg *izanami.ResolvedConfig, ctx context.Context, tenant string) ([]izanami.Feature, error)
+
 	// Timeout for API calls. Defaults to completionTimeout if zero.
 	Timeout time.Duration
 }
@@ -41,6 +44,7 @@ var defaultCompleter = &Completer{
 	ListProjects: listProjectsAPI,
 	ListTags:     listTagsAPI,
 	ListContexts: listContextsAPI,
+	ListFeatures: listFeaturesAPI,
 	Timeout:      completionTimeout,
 }
 
@@ -81,6 +85,15 @@ func listContextsAPI(cfg *izanami.ResolvedConfig, ctx context.Context, tenant, p
 	return izanami.ListContexts(client, ctx, tenant, project, true, izanami.ParseContexts)
 }
 
+// listFeaturesAPI is the production implementation for listing features.
+func listFeaturesAPI(cfg *izanami.ResolvedConfig, ctx context.Context, tenant string) ([]izanami.Feature, error) {
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return izanami.ListFeatures(client, ctx, tenant, "", izanami.ParseFeatures)
+}
+
 // getTimeout returns the configured timeout or the default.
 func (c *Completer) getTimeout() time.Duration {
 	if c.Timeout == 0 {
@@ -109,7 +122,7 @@ func (c *Completer) CompleteTenantNames(cmd *cobra.Command, args []string, toCom
 	}
 
 	// Fetch tenants with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), c.getTimeout())
+	ctx, cancel := context.WithTimeout(commandContext(), c.getTimeout())
 	defer cancel()
 
 	tenants, err := c.ListTenants(cfg, ctx)
@@ -148,7 +161,7 @@ func (c *Completer) CompleteProjectNames(cmd *cobra.Command, args []string, toCo
 	}
 
 	// Fetch projects with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), c.getTimeout())
+	ctx, cancel := context.WithTimeout(commandContext(), c.getTimeout())
 	defer cancel()
 
 	projects, err := c.ListProjects(cfg, ctx, cfg.Tenant)
@@ -187,7 +200,7 @@ func (c *Completer) CompleteTagNames(cmd *cobra.Command, args []string, toComple
 	}
 
 	// Fetch tags with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), c.getTimeout())
+	ctx, cancel := context.WithTimeout(commandContext(), c.getTimeout())
 	defer cancel()
 
 	tags, err := c.ListTags(cfg, ctx, cfg.Tenant)
@@ -235,7 +248,7 @@ func (c *Completer) CompleteContextNames(cmd *cobra.Command, args []string, toCo
 	}
 
 	// Fetch contexts with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), c.getTimeout())
+	ctx, cancel := context.WithTimeout(commandContext(), c.getTimeout())
 	defer cancel()
 
 	contexts, err := c.ListContexts(cfg, ctx, cfg.Tenant, project)
@@ -247,6 +260,58 @@ func (c *Completer) CompleteContextNames(cmd *cobra.Command, args []string, toCo
 	return buildContextCompletions(contexts, toComplete), cobra.ShellCompDirectiveNoFileComp
 }
 
+// CompleteFeatureNames provides dynamic completion for feature names.
+// Requires tenant to be specified (via --tenant flag or profile). Features are
+// limited to the project when one is set; otherwise their project is shown.
+// Fails silently if tenant is not set or API is unreachable.
+func (c *Completer) CompleteFeatureNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Only complete the first argument
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg := c.LoadConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Tenant is required for feature listing
+	if cfg.Tenant == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Validate admin auth is configured
+	if err := cfg.ValidateAdminAuth(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Fetch features with timeout
+	ctx, cancel := context.WithTimeout(commandContext(), c.getTimeout())
+	defer cancel()
+
+	features, err := c.ListFeatures(cfg, ctx, cfg.Tenant)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var candidates []izanami.Feature
+	for _, f := range features {
+		if cfg.Project == "" || f.Project == cfg.Project {
+			candidates = append(candidates, f)
+		}
+	}
+
+	return buildCompletions(candidates, toComplete,
+		func(f izanami.Feature) string { return f.Name },
+		func(f izanami.Feature) string {
+			if cfg.Project != "" {
+				return f.Description
+			}
+			return f.Project
+		},
+	), cobra.ShellCompDirectiveNoFileComp
+}
+
 // buildContextCompletions flattens nested contexts and builds completions from paths.
 func buildContextCompletions(contexts []izanami.Context, toComplete string) []string {
 	var completions []string
@@ -327,6 +392,11 @@ func completeContextNames(cmd *cobra.Command, args []string, toComplete string)
 	return defaultCompleter.CompleteContextNames(cmd, args, toComplete)
 }
 
+// completeFeatureNames provides dynamic completion for feature names.
+func completeFeatureNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return defaultCompleter.CompleteFeatureNames(cmd, args, toComplete)
+}
+
 // completeConfigKeys provides completion for global config keys.
 // These are static keys that don't require API calls.
 func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -336,9 +406,13 @@ func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([
 
 	keys := []struct{ Name, Desc string }{
 		{"timeout", "Request timeout in seconds"},
+		{"retries", "Retries for failed idempotent requests (0 disables retries)"},
+		{"retry-max-wait", "Maximum wait between retries in seconds"},
+		{"cache-ttl", "Seconds list/get results are served from the local cache (0 disables)"},
 		{"verbose", "Verbose output (true/false)"},
 		{"output-format", "Default output format (table/json)"},
 		{"color", "Color output (auto/always/never)"},
+		{"proxy-url", "HTTP proxy for all requests (http, https or socks5 URL)"},
 	}
 
 	return buildCompletions(keys, toComplete,
@@ -385,6 +459,9 @@ func completeProfileKeys(cmd *cobra.Command, args []string, toComplete string) (
 		{"client-id", "Client ID for feature/event API"},
 		{"client-secret", "Client secret for feature/event API"},
 		{"default-worker", "Default worker name"},
+		{"ca-cert", "CA certificates file"},
+		{"client-cert", "Client certificate file"},
+		{"client-key", "Client private key file"},
 	}
 
 	return buildCompletions(keys, toComplete,
@@ -442,6 +519,38 @@ func RegisterFlagCompletions() {
 	rootCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return completeProjectNames(cmd, nil, toComplete)
 	})
+
+	// Register --profile flag completion globally
+	// This enables: iz --profile <TAB> admin features list
+	rootCmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeProfileNames(cmd, nil, toComplete)
+	})
+}
+
+// resourceFlagCompletions maps flag names to the dynamic completion of the
+// resource they name
+var resourceFlagCompletions = map[string]func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective){
+	"context": completeContextNames,
+	"tag":     completeTagNames,
+	"worker":  completeWorkerNames,
+}
+
+// registerResourceFlagCompletions registers dynamic completions for the local
+// --context, --tag and --worker flags of every command, which shadow the global
+// ones. It must run once all commands are added, so it is called from Execute.
+func registerResourceFlagCompletions(c *cobra.Command) {
+	for name, complete := range resourceFlagCompletions {
+		if flag := c.LocalFlags().Lookup(name); flag != nil {
+			// Fails harmlessly when the flag already has a completion
+			_ = c.RegisterFlagCompletionFunc(name, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				// Positional arguments do not matter when completing a flag value
+				return complete(cmd, nil, toComplete)
+			})
+		}
+	}
+	for _, child := range c.Commands() {
+		registerResourceFlagCompletions(child)
+	}
 }
 
 // loadCompletionConfig loads configuration for completion functions.
@@ -470,8 +579,15 @@ func loadCompletionConfig() *izanami.ResolvedConfig {
 		Project:   project,
 		Context:   contextPath,
 		Timeout:   timeout,
+		CacheTTL:  cacheTTL,
+		NoCache:   noCache,
 		Verbose:   false, // Never verbose during completion
 	})
 
+	// Completions hit the same lists on every <TAB>: cache them briefly by default
+	if cfg.CacheTTL == 0 && !noCache {
+		cfg.CacheTTL = completionCacheTTL
+	}
+
 	return cfg
 }
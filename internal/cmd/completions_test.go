@@ -748,6 +748,93 @@ func TestCompleter_CompleteContextNames(t *testing.T) {
 	}
 }
 
+func TestCompleter_CompleteFeatureNames(t *testing.T) {
+	features := []izanami.Feature{
+		{Name: "checkout", Project: "shop", Description: "New checkout"},
+		{Name: "search", Project: "shop"},
+		{Name: "checkout-v2", Project: "web"},
+	}
+	loadConfig := func(project string) func() *izanami.ResolvedConfig {
+		return func() *izanami.ResolvedConfig {
+			return &izanami.ResolvedConfig{
+				PersonalAccessToken:         "test-token",
+				PersonalAccessTokenUsername: "test-user",
+				LeaderURL:                   "http://localhost",
+				Tenant:                      "my-tenant",
+				Project:                     project,
+			}
+		}
+	}
+	listFeatures := func(cfg *izanami.ResolvedConfig, ctx context.Context, tenant string) ([]izanami.Feature, error) {
+		return features, nil
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		toComplete  string
+		project     string
+		wantResults []string
+	}{
+		{name: "all projects show the project", toComplete: "check", wantResults: []string{"checkout\tshop", "checkout-v2\tweb"}},
+		{name: "project shows descriptions", toComplete: "", project: "shop", wantResults: []string{"checkout\tNew checkout", "search"}},
+		{name: "only the first argument", args: []string{"checkout"}, project: "shop", wantResults: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Completer{
+				LoadConfig:   loadConfig(tt.project),
+				ListFeatures: listFeatures,
+				Timeout:      completionTimeout,
+			}
+
+			got, directive := c.CompleteFeatureNames(nil, tt.args, tt.toComplete)
+
+			if directive != cobra.ShellCompDirectiveNoFileComp {
+				t.Errorf("CompleteFeatureNames() directive = %v, want %v", directive, cobra.ShellCompDirectiveNoFileComp)
+			}
+
+			if len(got) != len(tt.wantResults) {
+				t.Errorf("CompleteFeatureNames() returned %d results, want %d", len(got), len(tt.wantResults))
+				return
+			}
+
+			for i, want := range tt.wantResults {
+				if got[i] != want {
+					t.Errorf("CompleteFeatureNames()[%d] = %q, want %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterResourceFlagCompletions(t *testing.T) {
+	root := &cobra.Command{Use: "iz"}
+	root.PersistentFlags().String("context", "", "")
+	child := &cobra.Command{Use: "check", Run: func(*cobra.Command, []string) {}}
+	child.Flags().String("context", "", "")
+	child.Flags().String("tag", "", "")
+	child.Flags().String("user", "", "")
+	root.AddCommand(child)
+
+	registerResourceFlagCompletions(root)
+	// Registering twice must not fail
+	registerResourceFlagCompletions(root)
+
+	for _, name := range []string{"context", "tag"} {
+		if _, ok := child.GetFlagCompletionFunc(name); !ok {
+			t.Errorf("--%s should have a completion", name)
+		}
+	}
+	if _, ok := root.GetFlagCompletionFunc("context"); !ok {
+		t.Error("global --context should have a completion")
+	}
+	if _, ok := child.GetFlagCompletionFunc("user"); ok {
+		t.Error("--user should not have a completion")
+	}
+}
+
 func TestCompleteConfigKeys(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -761,7 +848,7 @@ func TestCompleteConfigKeys(t *testing.T) {
 			name:          "returns all keys when empty",
 			args:          []string{},
 			toComplete:    "",
-			wantCount:     4,
+			wantCount:     8,
 			wantContains:  "timeout",
 			wantDirective: cobra.ShellCompDirectiveNoFileComp,
 		},
@@ -773,6 +860,14 @@ func TestCompleteConfigKeys(t *testing.T) {
 			wantContains:  "timeout",
 			wantDirective: cobra.ShellCompDirectiveNoFileComp,
 		},
+		{
+			name:          "filters retry keys",
+			args:          []string{},
+			toComplete:    "retr",
+			wantCount:     2,
+			wantContains:  "retries",
+			wantDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
 		{
 			name:          "filters output-format",
 			args:          []string{},
@@ -838,7 +933,7 @@ func TestCompleteProfileKeys(t *testing.T) {
 			name:          "returns all keys when empty",
 			args:          []string{},
 			toComplete:    "",
-			wantCount:     10,
+			wantCount:     13,
 			wantContains:  "tenant",
 			wantDirective: cobra.ShellCompDirectiveNoFileComp,
 		},
@@ -846,7 +941,7 @@ func TestCompleteProfileKeys(t *testing.T) {
 			name:          "filters client keys",
 			args:          []string{},
 			toComplete:    "client",
-			wantCount:     2,
+			wantCount:     4,
 			wantContains:  "client-id",
 			wantDirective: cobra.ShellCompDirectiveNoFileComp,
 		},
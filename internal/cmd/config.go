@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -17,10 +16,14 @@ import (
 
 // Global configuration keys and their descriptions (settable via 'iz config set')
 var globalConfigKeys = map[string]string{
-	"timeout":       "Request timeout in seconds",
-	"verbose":       "Verbose output (true/false)",
-	"output-format": "Default output format (table/json)",
-	"color":         "Color output (auto/always/never)",
+	"timeout":        "Request timeout in seconds",
+	"retries":        "Retries for failed idempotent requests (0 disables retries)",
+	"retry-max-wait": "Maximum wait between retries in seconds",
+	"cache-ttl":      "Seconds list/get results are served from the local cache (0 disables)",
+	"verbose":        "Verbose output (true/false)",
+	"output-format":  "Default output format (table/json)",
+	"color":          "Color output (auto/always/never)",
+	"proxy-url":      "HTTP proxy for all requests (http, https or socks5 URL)",
 }
 
 // Profile-specific configuration keys and their descriptions (settable via 'iz profiles set')
@@ -34,6 +37,9 @@ var profileConfigKeys = map[string]string{
 	"client-id":                      "Client ID for feature/event API",
 	"client-secret":                  "Client secret for feature/event API",
 	"default-worker":                 "Default worker name",
+	"ca-cert":                        "PEM file of CA certificates to trust",
+	"client-cert":                    "PEM client certificate for mutual TLS",
+	"client-key":                     "PEM private key of the client certificate",
 }
 
 // printValidConfigKeys prints all valid configuration keys categorized
@@ -141,21 +147,21 @@ var configSetCmd = &cobra.Command{
 		if err := izanami.SetConfigValue(key, value); err != nil {
 			// If invalid key, show valid keys
 			if strings.Contains(err.Error(), "invalid config key") {
-				fmt.Fprintf(cmd.OutOrStderr(), "Error: %v\n\n", err)
+				fmt.Fprintf(statusOut(cmd), "Error: %v\n\n", err)
 				printValidConfigKeys(cmd.OutOrStdout())
 				return fmt.Errorf("") // Return empty error since we already printed the message
 			}
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Set %s = %s\n", key, value)
+		fmt.Fprintf(statusOut(cmd), "✓ Set %s = %s\n", key, value)
 
 		// Warn about sensitive data storage
 		if izanami.SensitiveKeys[key] {
-			fmt.Fprintln(cmd.OutOrStdout(), "\n⚠️  SECURITY WARNING:")
-			fmt.Fprintln(cmd.OutOrStdout(), "   Tokens are stored in plaintext in the config file.")
-			fmt.Fprintln(cmd.OutOrStdout(), "   File permissions are set to 0600 (owner read/write only).")
-			fmt.Fprintln(cmd.OutOrStdout(), "   Never commit config.yaml to version control.")
+			fmt.Fprintln(statusOut(cmd), "\n⚠️  SECURITY WARNING:")
+			fmt.Fprintln(statusOut(cmd), "   Tokens are stored in plaintext in the config file.")
+			fmt.Fprintln(statusOut(cmd), "   File permissions are set to 0600 (owner read/write only).")
+			fmt.Fprintln(statusOut(cmd), "   Never commit config.yaml to version control.")
 		}
 
 		return nil
@@ -189,6 +195,8 @@ Example:
 		value := configValue.Value
 		if izanami.SensitiveKeys[key] && value != "" {
 			value = "<redacted>"
+		} else if key == izanami.ConfigKeyProxyURL && value != "" {
+			value = izanami.RedactProxyURL(value)
 		}
 
 		if configValue.Source == "not set" {
@@ -220,7 +228,7 @@ Example:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Removed %s from config file\n", key)
+		fmt.Fprintf(statusOut(cmd), "✓ Removed %s from config file\n", key)
 		return nil
 	},
 }
@@ -275,6 +283,8 @@ Use --show-secrets to display sensitive values.`,
 			// Show empty values as "(not set)"
 			if value == "" {
 				value = "(not set)"
+			} else if key == izanami.ConfigKeyProxyURL && !showSecrets {
+				value = izanami.RedactProxyURL(value)
 			}
 
 			globalTable.Append([]string{key, value, configValue.Source})
@@ -298,7 +308,7 @@ Use --show-secrets to display sensitive values.`,
 		// Load the active profile
 		profile, err := izanami.GetProfile(profileName)
 		if err != nil {
-			fmt.Fprintf(cmd.OutOrStderr(), "\nWarning: Could not load active profile '%s': %v\n", profileName, err)
+			fmt.Fprintf(statusOut(cmd), "\nWarning: Could not load active profile '%s': %v\n", profileName, err)
 			return nil
 		}
 
@@ -357,6 +367,9 @@ Use --show-secrets to display sensitive values.`,
 			{"context", profile.Context, "", false},
 			{"personal-access-token", profile.PersonalAccessToken, "", true},
 			{"personal-access-token-username", profile.PersonalAccessTokenUsername, "", false},
+			{"ca-cert", profile.CACertFile, "", false},
+			{"client-cert", profile.ClientCertFile, "", false},
+			{"client-key", profile.ClientKeyFile, "", false},
 		}
 
 		// Add profile settings to table
@@ -455,16 +468,16 @@ Use --defaults to create a config file with only default values (non-interactive
 		configDir := getConfigDirForDisplay()
 		configPath := filepath.Join(configDir, "config.yaml")
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Configuration file created at: %s\n", configPath)
-		fmt.Fprintln(cmd.OutOrStdout(), "\nNext steps:")
-		fmt.Fprintln(cmd.OutOrStdout(), "  1. Edit the config file and uncomment/set your values")
-		fmt.Fprintln(cmd.OutOrStdout(), "  2. Or use environment variables (IZ_LEADER_URL, IZ_CLIENT_ID, etc.)")
-		fmt.Fprintln(cmd.OutOrStdout(), "  3. Or use command-line flags (--url, --client-id, etc.)")
+		fmt.Fprintf(statusOut(cmd), "✓ Configuration file created at: %s\n", configPath)
+		fmt.Fprintln(statusOut(cmd), "\nNext steps:")
+		fmt.Fprintln(statusOut(cmd), "  1. Edit the config file and uncomment/set your values")
+		fmt.Fprintln(statusOut(cmd), "  2. Or use environment variables (IZ_LEADER_URL, IZ_CLIENT_ID, etc.)")
+		fmt.Fprintln(statusOut(cmd), "  3. Or use command-line flags (--url, --client-id, etc.)")
 
-		fmt.Fprintln(cmd.OutOrStdout(), "\n⚠️  SECURITY NOTICE:")
-		fmt.Fprintln(cmd.OutOrStdout(), "   - File permissions set to 0600 (owner read/write only)")
-		fmt.Fprintln(cmd.OutOrStdout(), "   - Tokens stored in plaintext - never commit to version control")
-		fmt.Fprintln(cmd.OutOrStdout(), "   - Add config.yaml to .gitignore if using git")
+		fmt.Fprintln(statusOut(cmd), "\n⚠️  SECURITY NOTICE:")
+		fmt.Fprintln(statusOut(cmd), "   - File permissions set to 0600 (owner read/write only)")
+		fmt.Fprintln(statusOut(cmd), "   - Tokens stored in plaintext (run 'iz config encrypt' to encrypt them) - never commit to version control")
+		fmt.Fprintln(statusOut(cmd), "   - Add config.yaml to .gitignore if using git")
 
 		return nil
 	},
@@ -496,18 +509,11 @@ Exit codes:
 		errors := izanami.ValidateConfigFile()
 
 		if len(errors) == 0 {
-			fmt.Fprintln(cmd.OutOrStdout(), "✓ Configuration is valid")
+			fmt.Fprintln(statusOut(cmd), "✓ Configuration is valid")
 			return nil
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✗ Configuration has %d error(s):\n\n", len(errors))
-		for _, err := range errors {
-			if err.Field == "general" {
-				fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", err.Message)
-			} else {
-				fmt.Fprintf(cmd.OutOrStdout(), "  - %s: %s\n", err.Field, err.Message)
-			}
-		}
+		printConfigValidationErrors(cmd, errors)
 
 		return fmt.Errorf("configuration has %d error(s)", len(errors))
 	},
@@ -529,18 +535,8 @@ Note: This does not affect environment variables or command-line flags.`,
 		}
 
 		// Ask for confirmation
-		fmt.Fprintf(cmd.OutOrStdout(), "This will delete: %s\n", izanami.GetConfigPath())
-		fmt.Fprint(cmd.OutOrStdout(), "Are you sure? (y/N): ")
-
-		reader := bufio.NewReader(cmd.InOrStdin())
-		response, err := reader.ReadString('\n')
-		if err != nil && err.Error() != "EOF" {
-			return fmt.Errorf("failed to read input: %w", err)
-		}
-		response = strings.ToLower(strings.TrimSpace(response))
-
-		if response != "y" && response != "yes" {
-			fmt.Fprintln(cmd.OutOrStdout(), "Cancelled")
+		fmt.Fprintf(statusOut(cmd), "This will delete: %s\n", izanami.GetConfigPath())
+		if !confirmAction(cmd, "Are you sure?") {
 			return nil
 		}
 
@@ -551,14 +547,14 @@ Note: This does not affect environment variables or command-line flags.`,
 		if err := backupFile(configPath, backupPath); err != nil {
 			return fmt.Errorf("failed to backup config file: %w", err)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Config backed up to: %s\n", backupPath)
+		fmt.Fprintf(statusOut(cmd), "✓ Config backed up to: %s\n", backupPath)
 
 		if err := izanami.ResetConfig(); err != nil {
 			return err
 		}
 
-		fmt.Fprintln(cmd.OutOrStdout(), "✓ Configuration file deleted")
-		fmt.Fprintln(cmd.OutOrStdout(), "Run 'iz config init' to create a new config file")
+		fmt.Fprintln(statusOut(cmd), "✓ Configuration file deleted")
+		fmt.Fprintln(statusOut(cmd), "Run 'iz config init' to create a new config file")
 
 		return nil
 	},
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+)
+
+// editFile opens a file in the user's editor (replaced in tests)
+var editFile = utils.EditFile
+
+// configEditCmd opens the config file in an editor
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in your editor",
+	Long: `Open the config file in $VISUAL or $EDITOR (vi, or notepad on Windows).
+
+The edited content is validated when the editor exits, and only saved when it is
+valid: YAML syntax, known keys and value types, and the checks of 'iz config validate'.
+When it is invalid, the editor is reopened with the errors listed at the top of the
+file. Exiting without changing the content cancels the edit, and the config file
+is left untouched.
+
+Encrypted secrets (see 'iz config encrypt') are shown and kept encrypted.
+
+Examples:
+  iz config edit
+
+  # With a specific editor
+  EDITOR="code --wait" iz config edit`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !izanami.ConfigExists() {
+			return fmt.Errorf("config file does not exist (run 'iz config init' to create one)")
+		}
+		original, err := os.ReadFile(izanami.GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		tmp, err := os.CreateTemp("", "iz-config-*.yaml")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		tmpPath := tmp.Name()
+		tmp.Close()
+		defer os.Remove(tmpPath)
+
+		content := original
+		var header []byte
+		var lastInvalid []byte
+		for {
+			if err := os.WriteFile(tmpPath, append(header, content...), 0600); err != nil {
+				return fmt.Errorf("failed to write temporary file: %w", err)
+			}
+			if err := editFile(tmpPath); err != nil {
+				return err
+			}
+			edited, err := os.ReadFile(tmpPath)
+			if err != nil {
+				return fmt.Errorf("failed to read edited file: %w", err)
+			}
+			edited = bytes.TrimPrefix(edited, header)
+
+			if bytes.Equal(edited, original) {
+				fmt.Fprintln(statusOut(cmd), "Edit cancelled, no changes made")
+				return nil
+			}
+
+			validationErrs := izanami.ValidateConfigData(edited)
+			if len(validationErrs) == 0 {
+				if err := izanami.WriteConfigData(edited); err != nil {
+					return err
+				}
+				fmt.Fprintf(statusOut(cmd), "✓ Configuration saved to %s\n", izanami.GetConfigPath())
+				return nil
+			}
+
+			// Reopening an unchanged invalid file gives up instead of looping
+			if bytes.Equal(edited, lastInvalid) {
+				printConfigValidationErrors(cmd, validationErrs)
+				return fmt.Errorf("edit cancelled, invalid configuration not saved")
+			}
+			fmt.Fprintf(statusOut(cmd), "Configuration has %d error(s), reopening the editor\n", len(validationErrs))
+			lastInvalid = edited
+			content = edited
+			header = configEditErrorHeader(validationErrs)
+		}
+	},
+}
+
+// configEditErrorHeader lists validation errors as YAML comments shown above the edited content
+func configEditErrorHeader(validationErrs []izanami.ValidationError) []byte {
+	var sb strings.Builder
+	sb.WriteString("# The configuration below was not saved, please fix these errors:\n")
+	for _, e := range validationErrs {
+		sb.WriteString(fmt.Sprintf("#   - %s\n", formatConfigValidationError(e)))
+	}
+	sb.WriteString("# Exit without changes to cancel the edit.\n#\n")
+	return []byte(sb.String())
+}
+
+// formatConfigValidationError formats a validation error, omitting the "general" field
+func formatConfigValidationError(e izanami.ValidationError) string {
+	if e.Field == "general" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// printConfigValidationErrors prints validation errors as a list
+func printConfigValidationErrors(cmd *cobra.Command, validationErrs []izanami.ValidationError) {
+	fmt.Fprintf(cmd.OutOrStdout(), "✗ Configuration has %d error(s):\n\n", len(validationErrs))
+	for _, e := range validationErrs {
+		fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", formatConfigValidationError(e))
+	}
+}
+
+func init() {
+	configCmd.AddCommand(configEditCmd)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEditor replaces editFile with a function applying edits in order, recording
+// the content shown to the user at each round
+func fakeEditor(t *testing.T, edits ...func(string) string) *[]string {
+	t.Helper()
+	shown := &[]string{}
+	orig := editFile
+	t.Cleanup(func() { editFile = orig })
+	editFile = func(path string) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		*shown = append(*shown, string(data))
+		require.Less(t, len(*shown)-1, len(edits), "editor opened too many times")
+		return os.WriteFile(path, []byte(edits[len(*shown)-1](string(data))), 0600)
+	}
+	return shown
+}
+
+func runConfigEdit(t *testing.T) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	cmd, cleanup := setupConfigCommand(&buf, nil, []string{"config", "edit"})
+	defer cleanup()
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestConfigEditCmd_SavesValidContent(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	createConfigTestFile(t, paths.configPath, nil, "")
+
+	fakeEditor(t, func(s string) string { return strings.Replace(s, "timeout: 30", "timeout: 60", 1) })
+
+	out, err := runConfigEdit(t)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Configuration saved")
+
+	data, err := os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "timeout: 60")
+}
+
+func TestConfigEditCmd_NoChangesCancels(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	createConfigTestFile(t, paths.configPath, nil, "")
+
+	fakeEditor(t, func(s string) string { return s })
+
+	out, err := runConfigEdit(t)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Edit cancelled")
+}
+
+func TestConfigEditCmd_ReopensOnInvalidContent(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	createConfigTestFile(t, paths.configPath, nil, "")
+	original, err := os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+
+	shown := fakeEditor(t,
+		func(s string) string { return strings.Replace(s, "timeout: 30", "timout: -1", 1) },
+		func(s string) string { return strings.Replace(s, "timout: -1", "timeout: -1", 1) },
+		func(s string) string { return strings.Replace(s, "timeout: -1", "timeout: 45", 1) },
+	)
+
+	_, err = runConfigEdit(t)
+	require.NoError(t, err)
+	require.Len(t, *shown, 3)
+	assert.Contains(t, (*shown)[1], "# The configuration below was not saved")
+	assert.Contains(t, (*shown)[1], "field timout not found")
+	assert.Contains(t, (*shown)[2], "timeout: Timeout must be a positive number")
+
+	data, err := os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "timeout: 45")
+	assert.NotContains(t, string(data), "# The configuration below was not saved")
+	assert.NotEqual(t, string(original), string(data))
+}
+
+func TestConfigEditCmd_UnchangedInvalidContentAborts(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	createConfigTestFile(t, paths.configPath, nil, "")
+	original, err := os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+
+	fakeEditor(t,
+		func(s string) string { return s + "color: [\n" },
+		func(s string) string { return s },
+	)
+
+	out, err := runConfigEdit(t)
+	assert.ErrorContains(t, err, "invalid configuration not saved")
+	assert.Contains(t, out, "yaml:")
+
+	data, err := os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(data))
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"golang.org/x/term"
+)
+
+// configEncryptCmd encrypts the secrets of the config file
+var configEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt secrets in the config file",
+	Long: `Encrypt the secrets stored in the config file with a passphrase.
+
+Personal access tokens, client secrets and JWT tokens are encrypted in place with
+AES-256-GCM, using a key derived from the passphrase (PBKDF2-SHA256). Other
+settings stay readable, so the file can still be inspected and edited.
+
+The passphrase is read from the IZ_CONFIG_KEY environment variable, or prompted.
+Once encrypted, secrets are decrypted transparently when the config is loaded:
+commands ask for the passphrase once per run unless IZ_CONFIG_KEY is set.
+Secrets added later with profile commands are encrypted as well.
+
+Examples:
+  # Encrypt with a prompted passphrase
+  iz config encrypt
+
+  # Non-interactive
+  IZ_CONFIG_KEY=... iz config encrypt`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !izanami.ConfigExists() {
+			return fmt.Errorf("config file does not exist")
+		}
+		passphrase, err := readConfigPassphrase(cmd, true)
+		if err != nil {
+			return err
+		}
+		count, err := izanami.EncryptConfigFile(passphrase)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No plaintext secrets to encrypt")
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Encrypted %d secret(s) in %s\n", count, izanami.GetConfigPath())
+		fmt.Fprintf(cmd.OutOrStdout(), "Set %s or enter the passphrase when prompted to use them\n", izanami.ConfigKeyEnvVar)
+		return nil
+	},
+}
+
+// configDecryptCmd decrypts the secrets of the config file
+var configDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decrypt secrets in the config file",
+	Long: `Decrypt the secrets encrypted by 'iz config encrypt' back to plaintext.
+
+The passphrase is read from the IZ_CONFIG_KEY environment variable, or prompted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !izanami.ConfigExists() {
+			return fmt.Errorf("config file does not exist")
+		}
+		if !izanami.ConfigFileEncrypted() {
+			fmt.Fprintln(cmd.OutOrStdout(), "Config file has no encrypted secrets")
+			return nil
+		}
+		passphrase, err := readConfigPassphrase(cmd, false)
+		if err != nil {
+			return err
+		}
+		count, err := izanami.DecryptConfigFile(passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Decrypted %d secret(s) in %s\n", count, izanami.GetConfigPath())
+		return nil
+	},
+}
+
+// readConfigPassphrase returns IZ_CONFIG_KEY, or prompts for the passphrase
+// (twice when confirm is set, to catch typos before encrypting)
+func readConfigPassphrase(cmd *cobra.Command, confirm bool) (string, error) {
+	if passphrase := os.Getenv(izanami.ConfigKeyEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+
+	reader := bufio.NewReader(cmd.InOrStdin())
+	fmt.Fprint(statusOut(cmd), "Passphrase: ")
+	passphrase, err := readHiddenInput(reader)
+	fmt.Fprintln(statusOut(cmd))
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	if confirm {
+		fmt.Fprint(statusOut(cmd), "Confirm passphrase: ")
+		confirmation, err := readHiddenInput(reader)
+		fmt.Fprintln(statusOut(cmd))
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if confirmation != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+// promptConfigPassphrase asks for the passphrase of an encrypted config file when
+// IZ_CONFIG_KEY is not set. Only prompts on a terminal, so that piped input is not consumed.
+func promptConfigPassphrase() (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("stdin is not a terminal, set %s", izanami.ConfigKeyEnvVar)
+	}
+	fmt.Fprint(processStderr, "Config passphrase: ")
+	passphrase, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(processStderr)
+	return string(passphrase), err
+}
+
+func init() {
+	configCmd.AddCommand(configEncryptCmd)
+	configCmd.AddCommand(configDecryptCmd)
+
+	izanami.SetConfigPassphraseFunc(promptConfigPassphrase)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestConfigEncryptDecryptCmd(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	t.Setenv(izanami.ConfigKeyEnvVar, "")
+	izanami.ResetConfigKeyCache()
+	t.Cleanup(izanami.ResetConfigKeyCache)
+
+	createConfigTestFile(t, paths.configPath, map[string]*izanami.Profile{
+		"prod": {LeaderURL: "http://localhost:9000", PersonalAccessToken: "pat-secret"},
+	}, "prod")
+
+	// Mismatched confirmation is refused
+	var buf bytes.Buffer
+	cmd, cleanup := setupConfigCommand(&buf, bytes.NewBufferString("s3cret\nother\n"), []string{"config", "encrypt"})
+	err := cmd.Execute()
+	cleanup()
+	assert.ErrorContains(t, err, "passphrases do not match")
+
+	buf.Reset()
+	cmd, cleanup = setupConfigCommand(&buf, bytes.NewBufferString("s3cret\ns3cret\n"), []string{"config", "encrypt"})
+	err = cmd.Execute()
+	cleanup()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Encrypted 1 secret(s)")
+
+	data, err := os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "pat-secret")
+
+	izanami.ResetConfigKeyCache()
+	t.Setenv(izanami.ConfigKeyEnvVar, "s3cret")
+	buf.Reset()
+	cmd, cleanup = setupConfigCommand(&buf, nil, []string{"config", "decrypt"})
+	err = cmd.Execute()
+	cleanup()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Decrypted 1 secret(s)")
+
+	data, err = os.ReadFile(paths.configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "pat-secret")
+}
@@ -15,17 +15,49 @@ import (
 // The prompt uses cmd.OutOrStdout() and cmd.InOrStdin() for testability.
 // Handles EOF gracefully for non-interactive environments.
 func confirmDeletion(cmd *cobra.Command, resourceType, resourceName string) bool {
-	fmt.Fprintf(cmd.OutOrStdout(), "Delete %s '%s'? (y/N): ", resourceType, resourceName)
+	return confirmAction(cmd, fmt.Sprintf("Delete %s '%s'?", resourceType, resourceName))
+}
+
+// confirmAction asks a yes/no question and returns true if the user types 'y'
+// or 'yes'. It uses the same input/output conventions as confirmDeletion.
+// With the global --yes flag, it confirms without asking.
+func confirmAction(cmd *cobra.Command, question string) bool {
+	if assumeYes {
+		return true
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s (y/N): ", question)
 	reader := bufio.NewReader(cmd.InOrStdin())
 	response, err := reader.ReadString('\n')
 	if err != nil && err != io.EOF {
-		fmt.Fprintf(cmd.OutOrStdout(), "Failed to read input: %v\n", err)
+		fmt.Fprintf(statusOut(cmd), "Failed to read input: %v\n", err)
 		return false
 	}
 	response = strings.ToLower(strings.TrimSpace(response))
 
-	if response != "y" {
-		fmt.Fprintln(cmd.OutOrStdout(), "Cancelled")
+	if response != "y" && response != "yes" {
+		fmt.Fprintln(statusOut(cmd), "Cancelled")
+		return false
+	}
+	return true
+}
+
+// confirmTyped asks the user to type expected to confirm a destructive action
+// and returns true only on an exact match. With the global --yes flag, it
+// confirms without asking.
+func confirmTyped(cmd *cobra.Command, question, expected string) bool {
+	if assumeYes {
+		return true
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\nType '%s' to confirm: ", question, expected)
+	reader := bufio.NewReader(cmd.InOrStdin())
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		fmt.Fprintf(statusOut(cmd), "Failed to read input: %v\n", err)
+		return false
+	}
+
+	if strings.TrimSpace(response) != expected {
+		fmt.Fprintln(statusOut(cmd), "Cancelled")
 		return false
 	}
 	return true
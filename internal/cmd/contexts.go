@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
@@ -64,7 +64,7 @@ By default, only shows root-level contexts. Use --all to show all nested context
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		// Uses global --project flag to filter by project
@@ -124,7 +124,7 @@ The context path should be the full hierarchical path, e.g.:
 
 		// List all contexts (without 'all' to get root + immediate children)
 		// Uses global --project flag
-		ctx := context.Background()
+		ctx := commandContext()
 		contexts, err := izanami.ListContexts(client, ctx, cfg.Tenant, cfg.Project, false, izanami.ParseContexts)
 		if err != nil {
 			return err
@@ -196,12 +196,12 @@ Examples:
 			}
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.CreateContext(ctx, cfg.Tenant, cfg.Project, contextName, contextParent, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Context created successfully: %s\n", contextName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgContextCreated, contextName))
 		return nil
 	},
 }
@@ -256,12 +256,12 @@ Examples:
 			"protected": protected,
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.UpdateContext(ctx, cfg.Tenant, contextPath, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Context updated successfully: %s\n", contextPath)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgContextUpdated, contextPath))
 		return nil
 	},
 }
@@ -301,12 +301,12 @@ The context path should be the full hierarchical path.`,
 		}
 
 		// Uses global --project flag
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.DeleteContext(ctx, cfg.Tenant, cfg.Project, contextPath); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Context deleted successfully: %s\n", contextPath)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgContextDeleted, contextPath))
 		return nil
 	},
 }
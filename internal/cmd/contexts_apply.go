@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	contextsApplyFile   string
+	contextsApplyDryRun bool
+)
+
+// contextsApplyCmd creates a context hierarchy from a template file
+var contextsApplyCmd = &cobra.Command{
+	Use:         "apply",
+	Short:       "Create a context hierarchy from a template file",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/projects/:project/contexts"},
+	Long: `Create a whole context hierarchy in a project (or globally with --global) from
+a YAML or JSON template, to give every new project the same context tree.
+
+  contexts:
+    - name: prod
+      protected: true
+      children:
+        - name: eu
+          protected: true
+        - name: us
+    - name: staging
+    - name: dev
+      children:
+        - name: preview
+
+Contexts that already exist are skipped, so applying a template again only
+creates what is missing; the protected status of existing contexts is not
+changed (see 'iz admin contexts protect'). Parents are created before their
+children, and the descendants of a context that cannot be created are skipped.
+
+Examples:
+  # Create the standard contexts of a new project
+  iz admin contexts apply -f contexts.yaml --project shop
+
+  # Show what would be created
+  iz admin contexts apply -f contexts.yaml --project shop --dry-run
+
+  # Global contexts, template read from stdin
+  cat contexts.yaml | iz admin contexts apply -f - --global`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if cfg.Project == "" && !contextGlobal {
+			return fmt.Errorf("either --project or --global is required")
+		}
+
+		var data []byte
+		var err error
+		if contextsApplyFile == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(contextsApplyFile)
+		}
+		if err != nil {
+			return err
+		}
+		template, err := izanami.ParseContextTemplate(data)
+		if err != nil {
+			return fmt.Errorf("invalid context template %s: %w", contextsApplyFile, err)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		project := cfg.Project
+		if contextGlobal {
+			project = ""
+		}
+		results, err := izanami.ApplyContextTemplate(client, commandContext(), cfg.Tenant, project, template, contextsApplyDryRun)
+		if err != nil {
+			return err
+		}
+		if err := output.PrintTo(cmd.OutOrStdout(), results, output.Format(outputFormat)); err != nil {
+			return err
+		}
+
+		counts := make(map[string]int)
+		for _, result := range results {
+			counts[result.Status]++
+		}
+		verb := "created"
+		if contextsApplyDryRun {
+			verb = "to create"
+		}
+		fmt.Fprintf(statusOut(cmd), "Contexts: %d %s, %d existing, %d failed, %d skipped\n",
+			counts[izanami.ContextTemplateCreated], verb, counts[izanami.ContextTemplateExists],
+			counts[izanami.ContextTemplateFailed], counts[izanami.ContextTemplateSkipped])
+		if failed := counts[izanami.ContextTemplateFailed]; failed > 0 {
+			return fmt.Errorf("%d context(s) could not be created", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	contextsCmd.AddCommand(contextsApplyCmd)
+
+	contextsApplyCmd.Flags().StringVarP(&contextsApplyFile, "file", "f", "", "Context template file (YAML or JSON, - for stdin)")
+	contextsApplyCmd.Flags().BoolVar(&contextGlobal, "global", false, "Create global contexts")
+	contextsApplyCmd.Flags().BoolVar(&contextsApplyDryRun, "dry-run", false, "Show the contexts to create without creating them")
+	_ = contextsApplyCmd.MarkFlagRequired("file")
+}
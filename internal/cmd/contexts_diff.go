@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var contextsDiffFormat string
+
+// contextsDiffCmd compares the overloads of two contexts
+var contextsDiffCmd = &cobra.Command{
+	Use:          "diff <from-context> <to-context>",
+	Short:        "Compare the feature overloads of two contexts",
+	Annotations:  map[string]string{"route": "GET /api/admin/tenants/:tenant/projects/:project/contexts"},
+	SilenceUsage: true,
+	Long: `Compare the feature overloads defined in two contexts of a tenant, e.g.
+staging and prod, to catch configuration drift between environments.
+
+Features overloaded in the second context only are shown as added, those
+overloaded in the first context only as removed, and features overloaded in
+both with a different enabled state, conditions or value as changed. Only the
+overloads defined in the contexts are compared, not those inherited from their
+parents. Use --project to compare the contexts of a single project.
+
+The command exits with a non-zero status when differences are found, so it can
+gate CI pipelines.
+
+Examples:
+  # Compare staging and prod overloads of every project
+  iz admin contexts diff staging prod --tenant my-tenant
+
+  # Nested contexts of one project, as a table
+  iz admin contexts diff prod/eu prod/us --tenant my-tenant --project shop --format table
+
+  # Machine-readable output
+  iz admin contexts diff staging prod --tenant my-tenant -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if contextsDiffFormat != diffFormatUnified && contextsDiffFormat != diffFormatTable {
+			return fmt.Errorf("invalid --format %q (must be %s or %s)", contextsDiffFormat, diffFormatUnified, diffFormatTable)
+		}
+		fromPath, toPath := strings.Trim(args[0], "/"), strings.Trim(args[1], "/")
+		if fromPath == toPath {
+			return fmt.Errorf("both sides of the diff are context %s", fromPath)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		ctx := commandContext()
+
+		projects := []string{cfg.Project}
+		if cfg.Project == "" {
+			list, err := izanami.ListProjects(client, ctx, cfg.Tenant, izanami.ParseProjects)
+			if err != nil {
+				return err
+			}
+			projects = projects[:0]
+			for _, p := range list {
+				projects = append(projects, p.Name)
+			}
+		}
+		trees := make(map[string][]izanami.Context, len(projects))
+		for _, project := range projects {
+			contexts, err := izanami.ListContexts(client, ctx, cfg.Tenant, project, true, izanami.ParseContexts)
+			if err != nil {
+				return fmt.Errorf("failed to list contexts of project %s: %w", project, err)
+			}
+			trees[project] = contexts
+		}
+
+		from, found, err := izanami.ContextOverloadSnapshots(trees, fromPath)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return notFoundError("context not found: %s", fromPath)
+		}
+		to, found, err := izanami.ContextOverloadSnapshots(trees, toPath)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return notFoundError("context not found: %s", toPath)
+		}
+
+		diffs := izanami.DiffFeatureSnapshots(from, to)
+		if diffs == nil {
+			diffs = []izanami.FeatureDiff{}
+		}
+
+		fromLabel, toLabel := "context "+fromPath, "context "+toPath
+		w := cmd.OutOrStdout()
+		switch {
+		case outputFormat == "json":
+			if err := output.PrintTo(w, FeaturesDiffResult{From: fromLabel, To: toLabel, Differences: diffs}, output.JSON); err != nil {
+				return err
+			}
+		case len(diffs) == 0:
+			fmt.Fprintf(w, "No differences between %s and %s\n", fromLabel, toLabel)
+		case contextsDiffFormat == diffFormatTable:
+			if err := output.PrintTo(w, featureDiffRows(diffs), output.Table); err != nil {
+				return err
+			}
+		default:
+			printUnifiedFeatureDiff(w, fromLabel, toLabel, diffs)
+		}
+
+		if len(diffs) > 0 {
+			return fmt.Errorf("%d overload(s) differ between %s and %s", len(diffs), fromLabel, toLabel)
+		}
+		return nil
+	},
+}
+
+func init() {
+	contextsCmd.AddCommand(contextsDiffCmd)
+
+	contextsDiffCmd.Flags().StringVar(&contextsDiffFormat, "format", diffFormatUnified, "Diff format: unified or table")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runContextsDiff runs iz admin contexts diff against projects shop and web,
+// or only project when set
+func runContextsDiff(t *testing.T, project, outFormat string, args ...string) (string, error) {
+	t.Helper()
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/projects":
+			io.WriteString(w, `[{"id":"p1","name":"shop"},{"id":"p2","name":"web"}]`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[
+				{"name":"staging","global":true,"overloads":[
+					{"name":"checkout","project":"shop","enabled":true},
+					{"name":"search","project":"shop","enabled":true}]},
+				{"name":"prod","global":true,"overloads":[
+					{"name":"checkout","project":"shop","enabled":false},
+					{"name":"banner","project":"shop","enabled":true}]}
+			]`)
+		case "/api/admin/tenants/acme/projects/web/contexts":
+			io.WriteString(w, `[
+				{"name":"staging","global":true,"overloads":[{"name":"home","project":"web","enabled":true}]},
+				{"name":"prod","global":true,"overloads":[{"name":"home","project":"web","enabled":true}]}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		contextsDiffFormat = diffFormatUnified
+		contextsDiffCmd.SetOut(nil)
+	})
+	cfg.Project = project
+	outputFormat = outFormat
+
+	var buf bytes.Buffer
+	contextsDiffCmd.SetOut(&buf)
+	err := contextsDiffCmd.RunE(contextsDiffCmd, args)
+	return buf.String(), err
+}
+
+func TestContextsDiff_Unified(t *testing.T) {
+	out, err := runContextsDiff(t, "", "table", "staging", "/prod")
+	require.Error(t, err, "differences make the command fail")
+	assert.EqualError(t, err, "3 overload(s) differ between context staging and context prod")
+
+	assert.Contains(t, out, "--- context staging\n+++ context prod\n")
+	assert.Contains(t, out, "+ shop/banner")
+	assert.Contains(t, out, "~ shop/checkout")
+	assert.Contains(t, out, "-   enabled: true")
+	assert.Contains(t, out, "+   enabled: false")
+	assert.Contains(t, out, "- shop/search")
+	assert.NotContains(t, out, "web/home", "identical overloads are not reported")
+	assert.Contains(t, out, "1 added, 1 removed, 1 changed")
+}
+
+func TestContextsDiff_Errors(t *testing.T) {
+	_, err := runContextsDiff(t, "", "table", "staging", "qa")
+	assert.EqualError(t, err, "context not found: qa")
+	assert.Equal(t, ExitNotFound, exitCode(err))
+
+	_, err = runContextsDiff(t, "", "table", "prod", "/prod/")
+	assert.ErrorContains(t, err, "both sides of the diff are context prod")
+}
+
+func TestContextsDiff_Project(t *testing.T) {
+	out, err := runContextsDiff(t, "web", "table", "staging", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "No differences between context staging and context prod\n", out)
+
+	out, err = runContextsDiff(t, "shop", "json", "staging", "prod")
+	require.Error(t, err)
+	assert.Contains(t, out, `"from": "context staging"`)
+	assert.Contains(t, out, `"status": "changed"`)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	contextOverloadFeature      string
+	contextOverloadEnabled      bool
+	contextOverloadData         string
+	contextOverloadPreserve     bool
+	contextOverloadsDeleteForce bool
+)
+
+// ContextOverloadView is the table representation of an overload in a context
+type ContextOverloadView struct {
+	Feature    string `json:"feature"`
+	Enabled    bool   `json:"enabled"`
+	ResultType string `json:"resultType"`
+	Conditions int    `json:"conditions"`
+}
+
+// contextsOverloadsCmd manages the feature overloads of one context
+var contextsOverloadsCmd = &cobra.Command{
+	Use:   "overloads",
+	Short: "Manage the feature overloads of a context",
+	Long: `Manage feature overloads from the point of view of a context.
+
+Works for both project contexts and global contexts: overloads always belong
+to a project, so --project is required in both cases.
+
+See also 'iz admin overloads', which manages the overloads of one feature.
+
+Examples:
+  # List the overloads defined in PROD
+  iz admin contexts overloads list PROD --project my-project
+
+  # Enable a feature in a nested context
+  iz admin contexts overloads set PROD/mobile --feature my-feature --enabled --project my-project
+
+  # Remove an overload
+  iz admin contexts overloads delete PROD --feature my-feature --project my-project`,
+}
+
+// contextsOverloadsListCmd lists the overloads defined in a context
+var contextsOverloadsListCmd = &cobra.Command{
+	Use:         "list <context-path>",
+	Short:       "List the overloads defined in a context",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/projects/:project/contexts"},
+	Long: `List the feature overloads defined in a context (not inherited from parents).
+
+Use --feature to show only the overload of one feature.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateContextOverloadScope(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureName, err := resolveOverloadFeatureName(ctx, client, contextOverloadFeature)
+		if err != nil {
+			return err
+		}
+
+		contexts, err := izanami.ListContexts(client, ctx, cfg.Tenant, cfg.Project, true, izanami.ParseContexts)
+		if err != nil {
+			return err
+		}
+
+		found := izanami.FindContextByPath(contexts, args[0])
+		if found == nil {
+			return fmt.Errorf("context not found: %s", args[0])
+		}
+
+		var overloads []izanami.FeatureOverload
+		for _, o := range found.Overloads {
+			if featureName == "" || o.Name == featureName {
+				overloads = append(overloads, o)
+			}
+		}
+
+		if outputFormat == "json" {
+			if overloads == nil {
+				overloads = []izanami.FeatureOverload{}
+			}
+			return output.PrintTo(cmd.OutOrStdout(), overloads, output.JSON)
+		}
+
+		if len(overloads) == 0 {
+			fmt.Fprintf(statusOut(cmd), "No overloads in context %s\n", args[0])
+			return nil
+		}
+		return output.PrintTo(cmd.OutOrStdout(), contextOverloadViews(overloads), output.Format(outputFormat))
+	},
+}
+
+// contextsOverloadsSetCmd creates or updates an overload in a context
+var contextsOverloadsSetCmd = &cobra.Command{
+	Use:         "set <context-path>",
+	Short:       "Create or update a feature overload in a context",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/contexts/:context/features/:name"},
+	Long: `Create or update the overload of a feature in a context.
+
+Use --enabled for a simple boolean overload, or --data for a full strategy
+(same format as 'iz admin overloads set').`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateContextOverloadScope(); err != nil {
+			return err
+		}
+		if contextOverloadFeature == "" {
+			return fmt.Errorf("feature is required (use --feature flag)")
+		}
+
+		var strategy interface{}
+		if contextOverloadData != "" {
+			if err := parseJSONData(contextOverloadData, &strategy); err != nil {
+				return fmt.Errorf("invalid JSON data: %w", err)
+			}
+		} else {
+			strategy = map[string]interface{}{
+				"enabled":    contextOverloadEnabled,
+				"resultType": "boolean",
+			}
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureName, err := resolveOverloadFeatureName(ctx, client, contextOverloadFeature)
+		if err != nil {
+			return err
+		}
+
+		if err := client.SetOverload(ctx, cfg.Tenant, cfg.Project, args[0], featureName, strategy, contextOverloadPreserve); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgOverloadSet, featureName, args[0]))
+		runHooks(cmd, izanami.HookOverloadSet, map[string]interface{}{"feature": featureName, "context": args[0]})
+		return nil
+	},
+}
+
+// contextsOverloadsDeleteCmd removes an overload from a context
+var contextsOverloadsDeleteCmd = &cobra.Command{
+	Use:         "delete <context-path>",
+	Short:       "Delete a feature overload from a context",
+	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:tenant/projects/:project/contexts/:context/features/:name"},
+	Args:        cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateContextOverloadScope(); err != nil {
+			return err
+		}
+		if contextOverloadFeature == "" {
+			return fmt.Errorf("feature is required (use --feature flag)")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureName, err := resolveOverloadFeatureName(ctx, client, contextOverloadFeature)
+		if err != nil {
+			return err
+		}
+
+		if !contextOverloadsDeleteForce {
+			if !confirmDeletion(cmd, "overload", fmt.Sprintf("%s in context %s", featureName, args[0])) {
+				return nil
+			}
+		}
+
+		if err := client.DeleteOverload(ctx, cfg.Tenant, cfg.Project, args[0], featureName, contextOverloadPreserve); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgOverloadDeleted, featureName, args[0]))
+		runHooks(cmd, izanami.HookOverloadDelete, map[string]interface{}{"feature": featureName, "context": args[0]})
+		return nil
+	},
+}
+
+// validateContextOverloadScope checks the tenant and project needed to address overloads
+func validateContextOverloadScope() error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+	if cfg.Project == "" {
+		return fmt.Errorf("project is required (use --project flag or IZ_PROJECT)")
+	}
+	return nil
+}
+
+// resolveOverloadFeatureName returns the feature name for a name or UUID (overloads are addressed by name)
+func resolveOverloadFeatureName(ctx context.Context, client *izanami.AdminClient, featureIDOrName string) (string, error) {
+	if !IsUUID(featureIDOrName) {
+		return featureIDOrName, nil
+	}
+	feature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureIDOrName, izanami.ParseFeature)
+	if err != nil {
+		return "", err
+	}
+	return feature.Name, nil
+}
+
+// contextOverloadViews converts overloads to table rows
+func contextOverloadViews(overloads []izanami.FeatureOverload) []ContextOverloadView {
+	views := make([]ContextOverloadView, len(overloads))
+	for i, o := range overloads {
+		resultType := o.ResultType
+		if resultType == "" {
+			resultType = "boolean"
+		}
+		views[i] = ContextOverloadView{
+			Feature:    o.Name,
+			Enabled:    o.Enabled,
+			ResultType: resultType,
+			Conditions: len(o.Conditions),
+		}
+	}
+	return views
+}
+
+func init() {
+	contextsCmd.AddCommand(contextsOverloadsCmd)
+	contextsOverloadsCmd.AddCommand(contextsOverloadsListCmd)
+	contextsOverloadsCmd.AddCommand(contextsOverloadsSetCmd)
+	contextsOverloadsCmd.AddCommand(contextsOverloadsDeleteCmd)
+
+	contextsOverloadsCmd.PersistentFlags().StringVar(&contextOverloadFeature, "feature", "", "Feature name or UUID")
+	contextsOverloadsCmd.PersistentFlags().BoolVar(&contextOverloadPreserve, "preserve-protected", false, "Preserve protected contexts")
+
+	contextsOverloadsSetCmd.Flags().BoolVar(&contextOverloadEnabled, "enabled", false, "Enable the feature in this context")
+	contextsOverloadsSetCmd.Flags().StringVar(&contextOverloadData, "data", "", "JSON strategy (from file with @file.json, stdin with -, or inline)")
+
+	contextsOverloadsDeleteCmd.Flags().BoolVarP(&contextOverloadsDeleteForce, "force", "f", false, "Skip confirmation prompt")
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+const contextOverloadFeatureID = "e878a149-df86-4f28-b1db-059580304e1e"
+
+// overloadRequest is a write received by the fake server of the context overload tests
+type overloadRequest struct {
+	Route string
+	Body  map[string]interface{}
+}
+
+// setupContextOverloadsTest serves the contexts PROD and PROD/mobile of project shop and
+// the feature checkout, recording the PUT and DELETE requests
+func setupContextOverloadsTest(t *testing.T) (*[]overloadRequest, *bytes.Buffer) {
+	t.Helper()
+	var writes []overloadRequest
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[{"name": "PROD", "overloads": [{"name": "banner", "enabled": false}],
+				"children": [{"name": "mobile", "overloads": [
+					{"name": "checkout", "enabled": true, "conditions": [{"rule": {"type": "All"}}]},
+					{"name": "banner", "enabled": true, "resultType": "string", "value": "hi"}]}]}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features/"+contextOverloadFeatureID:
+			io.WriteString(w, `{"id": "`+contextOverloadFeatureID+`", "name": "checkout", "project": "shop", "enabled": true}`)
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			req := overloadRequest{Route: r.Method + " " + r.URL.Path}
+			if r.Method == http.MethodPut {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req.Body))
+			}
+			writes = append(writes, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	cfg.Project = "shop"
+	outputFormat = "table"
+	var buf bytes.Buffer
+	commands := []*cobra.Command{contextsOverloadsListCmd, contextsOverloadsSetCmd, contextsOverloadsDeleteCmd}
+	for _, c := range commands {
+		c.SetOut(&buf)
+	}
+	t.Cleanup(func() {
+		contextOverloadFeature, contextOverloadData = "", ""
+		contextOverloadEnabled, contextOverloadPreserve, contextOverloadsDeleteForce = false, false, false
+		for _, c := range commands {
+			c.SetOut(nil)
+			c.SetIn(nil)
+		}
+	})
+	return &writes, &buf
+}
+
+func TestContextOverloadViews(t *testing.T) {
+	views := contextOverloadViews([]izanami.FeatureOverload{
+		{Name: "checkout", Enabled: true, Conditions: []izanami.ActivationCondition{{Rule: &izanami.ActivationRule{Type: "All"}}}},
+		{Name: "banner", ResultType: "string"},
+	})
+
+	assert.Equal(t, []ContextOverloadView{
+		{Feature: "checkout", Enabled: true, ResultType: "boolean", Conditions: 1},
+		{Feature: "banner", Enabled: false, ResultType: "string", Conditions: 0},
+	}, views)
+}
+
+func TestContextsOverloadsSetCmd_Enabled(t *testing.T) {
+	writes, buf := setupContextOverloadsTest(t)
+	contextOverloadFeature, contextOverloadEnabled = "checkout", true
+
+	require.NoError(t, contextsOverloadsSetCmd.RunE(contextsOverloadsSetCmd, []string{"PROD/mobile"}))
+	assert.Equal(t, []overloadRequest{{
+		Route: "PUT /api/admin/tenants/acme/projects/shop/contexts/PROD/mobile/features/checkout",
+		Body:  map[string]interface{}{"enabled": true, "resultType": "boolean"},
+	}}, *writes)
+	assert.Contains(t, buf.String(), "Overload set successfully: checkout in context PROD/mobile")
+}
+
+func TestContextsOverloadsSetCmd_Data(t *testing.T) {
+	writes, _ := setupContextOverloadsTest(t)
+	contextOverloadFeature = "banner"
+	contextOverloadData = `{"enabled": true, "resultType": "string", "value": "hello"}`
+
+	require.NoError(t, contextsOverloadsSetCmd.RunE(contextsOverloadsSetCmd, []string{"PROD"}))
+	require.Len(t, *writes, 1)
+	assert.Equal(t, "PUT /api/admin/tenants/acme/projects/shop/contexts/PROD/features/banner", (*writes)[0].Route)
+	assert.Equal(t, map[string]interface{}{"enabled": true, "resultType": "string", "value": "hello"}, (*writes)[0].Body)
+
+	contextOverloadData = `{"enabled": `
+	err := contextsOverloadsSetCmd.RunE(contextsOverloadsSetCmd, []string{"PROD"})
+	assert.ErrorContains(t, err, "invalid JSON data")
+	assert.Len(t, *writes, 1)
+}
+
+func TestContextsOverloadsSetCmd_ResolvesFeatureUUID(t *testing.T) {
+	writes, buf := setupContextOverloadsTest(t)
+	contextOverloadFeature, contextOverloadEnabled = contextOverloadFeatureID, true
+
+	require.NoError(t, contextsOverloadsSetCmd.RunE(contextsOverloadsSetCmd, []string{"PROD"}))
+	require.Len(t, *writes, 1)
+	assert.Equal(t, "PUT /api/admin/tenants/acme/projects/shop/contexts/PROD/features/checkout", (*writes)[0].Route)
+	assert.Contains(t, buf.String(), "Overload set successfully: checkout in context PROD")
+}
+
+func TestContextsOverloadsDeleteCmd_Force(t *testing.T) {
+	writes, buf := setupContextOverloadsTest(t)
+	contextOverloadFeature, contextOverloadsDeleteForce = "checkout", true
+
+	require.NoError(t, contextsOverloadsDeleteCmd.RunE(contextsOverloadsDeleteCmd, []string{"PROD/mobile"}))
+	assert.Equal(t, []overloadRequest{{Route: "DELETE /api/admin/tenants/acme/projects/shop/contexts/PROD/mobile/features/checkout"}}, *writes)
+	assert.NotContains(t, buf.String(), "(y/N)")
+	assert.Contains(t, buf.String(), "Overload deleted successfully: checkout from context PROD/mobile")
+}
+
+func TestContextsOverloadsDeleteCmd_Confirmation(t *testing.T) {
+	writes, buf := setupContextOverloadsTest(t)
+	contextOverloadFeature = contextOverloadFeatureID
+
+	contextsOverloadsDeleteCmd.SetIn(strings.NewReader("n\n"))
+	require.NoError(t, contextsOverloadsDeleteCmd.RunE(contextsOverloadsDeleteCmd, []string{"PROD"}))
+	assert.Contains(t, buf.String(), "Delete overload 'checkout in context PROD'? (y/N)")
+	assert.Contains(t, buf.String(), "Cancelled")
+	assert.Empty(t, *writes)
+
+	contextsOverloadsDeleteCmd.SetIn(strings.NewReader("y\n"))
+	require.NoError(t, contextsOverloadsDeleteCmd.RunE(contextsOverloadsDeleteCmd, []string{"PROD"}))
+	assert.Equal(t, []overloadRequest{{Route: "DELETE /api/admin/tenants/acme/projects/shop/contexts/PROD/features/checkout"}}, *writes)
+}
+
+func TestContextsOverloadsListCmd_NestedContext(t *testing.T) {
+	_, buf := setupContextOverloadsTest(t)
+
+	require.NoError(t, contextsOverloadsListCmd.RunE(contextsOverloadsListCmd, []string{"PROD/mobile"}))
+	assert.Regexp(t, `checkout\s+true\s+boolean\s+1`, buf.String())
+	assert.Regexp(t, `banner\s+true\s+string\s+0`, buf.String())
+
+	buf.Reset()
+	outputFormat = "json"
+	contextOverloadFeature = contextOverloadFeatureID
+	require.NoError(t, contextsOverloadsListCmd.RunE(contextsOverloadsListCmd, []string{"PROD/mobile"}))
+	var overloads []izanami.FeatureOverload
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &overloads))
+	require.Len(t, overloads, 1)
+	assert.Equal(t, "checkout", overloads[0].Name)
+}
+
+func TestContextsOverloadsListCmd_MissingContext(t *testing.T) {
+	_, buf := setupContextOverloadsTest(t)
+
+	err := contextsOverloadsListCmd.RunE(contextsOverloadsListCmd, []string{"PROD/web"})
+	assert.EqualError(t, err, "context not found: PROD/web")
+	assert.Empty(t, buf.String())
+}
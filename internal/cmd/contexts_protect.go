@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	contextsProtectRecursive   bool
+	contextsProtectConcurrency int
+)
+
+// contextsProtectCmd protects a global context and, with --recursive, its subtree
+var contextsProtectCmd = &cobra.Command{
+	Use:         "protect <context-path>",
+	Short:       "Protect a global context and its subtree",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/contexts/:path"},
+	Long: `Mark a global context as protected. With --recursive, all its descendants
+are protected too, parents before children, with --concurrency parallel
+updates per level.
+
+Contexts that are already protected are left untouched. The changed nodes are
+listed with a summary.
+
+Examples:
+  # Protect prod and every context below it
+  iz admin contexts protect prod --recursive
+
+  # Protect a single context
+  iz admin contexts protect prod/eu`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runContextsProtect(cmd, args[0], true)
+	},
+}
+
+// contextsUnprotectCmd unprotects a global context and, with --recursive, its subtree
+var contextsUnprotectCmd = &cobra.Command{
+	Use:         "unprotect <context-path>",
+	Short:       "Unprotect a global context and its subtree",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/contexts/:path"},
+	Long: `Remove the protected status of a global context. With --recursive, all its
+descendants are unprotected too, children before parents, with --concurrency
+parallel updates per level.
+
+Contexts that are not protected are left untouched. The changed nodes are
+listed with a summary.
+
+Examples:
+  # Unprotect staging and every context below it
+  iz admin contexts unprotect staging --recursive`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runContextsProtect(cmd, args[0], false)
+	},
+}
+
+// runContextsProtect sets the protected status of a context subtree and prints the changes
+func runContextsProtect(cmd *cobra.Command, contextPath string, protected bool) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	changes, err := izanami.SetContextProtection(client, commandContext(), cfg.Tenant, contextPath, protected, contextsProtectRecursive, contextsProtectConcurrency)
+	if err != nil {
+		return err
+	}
+	if err := output.PrintTo(cmd.OutOrStdout(), changes, output.Format(outputFormat)); err != nil {
+		return err
+	}
+
+	changed, failed := 0, 0
+	for _, change := range changes {
+		if change.Changed {
+			changed++
+		}
+		if change.Error != "" {
+			failed++
+		}
+	}
+	action := "unprotected"
+	if protected {
+		action = "protected"
+	}
+	fmt.Fprintf(statusOut(cmd), "Contexts %s: %d changed, %d unchanged, %d failed\n", action, changed, len(changes)-changed-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d context(s) could not be %s", failed, action)
+	}
+	return nil
+}
+
+func init() {
+	contextsCmd.AddCommand(contextsProtectCmd)
+	contextsCmd.AddCommand(contextsUnprotectCmd)
+
+	for _, c := range []*cobra.Command{contextsProtectCmd, contextsUnprotectCmd} {
+		c.ValidArgsFunction = completeContextNames
+		c.Flags().BoolVarP(&contextsProtectRecursive, "recursive", "r", false, "Also update all descendant contexts")
+		c.Flags().IntVar(&contextsProtectConcurrency, "concurrency", 10, "Number of updates run in parallel")
+	}
+}
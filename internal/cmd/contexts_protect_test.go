@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextsProtect(t *testing.T) {
+	var updates []string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			updates = append(updates, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.WriteString(w, `[{"name":"prod","protected":false,"global":true,"children":[{"name":"eu","protected":true,"global":true}]}]`)
+	})
+
+	t.Cleanup(func() {
+		contextsProtectRecursive, contextsProtectConcurrency = false, 10
+		contextsProtectCmd.SetOut(nil)
+	})
+	outputFormat = "json"
+	contextsProtectRecursive = true
+
+	var buf bytes.Buffer
+	contextsProtectCmd.SetOut(&buf)
+	require.NoError(t, contextsProtectCmd.RunE(contextsProtectCmd, []string{"prod"}))
+	assert.Contains(t, buf.String(), `"path": "prod/eu"`)
+	assert.Contains(t, buf.String(), "Contexts protected: 1 changed, 1 unchanged, 0 failed")
+	assert.Equal(t, []string{"/api/admin/tenants/acme/contexts/prod"}, updates)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	diffFromProfile string
+	diffToProfile   string
+	diffFromTenant  string
+	diffToTenant    string
+	diffFormat      string
+)
+
+// Diff output formats
+const (
+	diffFormatUnified = "unified"
+	diffFormatTable   = "table"
+)
+
+// diffCmd groups the commands comparing Izanami resources between two sources
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare resources between tenants or profiles",
+	Long: `Compare Izanami resources between two tenants on one server, or between
+the same tenant reached through two profiles (e.g. staging and prod).`,
+}
+
+// diffFeaturesCmd compares the features of two sources
+var diffFeaturesCmd = &cobra.Command{
+	Use:          "features",
+	Short:        "Compare features and their overloads between two sources",
+	Annotations:  map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	SilenceUsage: true,
+	Long: `Compare the features of two sources, including their context overloads, and
+print the features added, removed or changed in the second source.
+
+A source is a profile and a tenant. Each side defaults to the current profile
+and its tenant:
+  --from-tenant / --to-tenant    compare two tenants on the same server
+  --from-profile / --to-profile  compare the same tenant across two profiles
+
+Both can be combined. Features are matched by project and name; their IDs are
+ignored. Use --project to compare a single project.
+
+The command exits with code 8 when differences are found, so it can gate CI
+pipelines.
+
+Examples:
+  # Compare two tenants on the current server
+  iz diff features --from-tenant staging --to-tenant prod
+
+  # Compare the same tenant across two profiles
+  iz diff features --from-profile staging --to-profile prod
+
+  # Show changes as a table, for one project only
+  iz diff features --from-profile staging --to-profile prod --project billing --format table
+
+  # Machine-readable output
+  iz diff features --from-tenant staging --to-tenant prod -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffFromProfile == "" && diffToProfile == "" && diffFromTenant == "" && diffToTenant == "" {
+			return fmt.Errorf("specify the sources to compare with --from-tenant/--to-tenant or --from-profile/--to-profile")
+		}
+		if diffFormat != diffFormatUnified && diffFormat != diffFormatTable {
+			return fmt.Errorf("invalid --format %q (must be %s or %s)", diffFormat, diffFormatUnified, diffFormatTable)
+		}
+
+		from, err := newDiffSource(cmd, diffFromProfile, diffFromTenant)
+		if err != nil {
+			return err
+		}
+		to, err := newDiffSource(cmd, diffToProfile, diffToTenant)
+		if err != nil {
+			return err
+		}
+		if from.label == to.label {
+			return fmt.Errorf("both sides of the diff are %s", from.label)
+		}
+
+		ctx := commandContext()
+		fromFeatures, err := from.snapshot(ctx, project)
+		if err != nil {
+			return fmt.Errorf("%s: %w", from.label, err)
+		}
+		toFeatures, err := to.snapshot(ctx, project)
+		if err != nil {
+			return fmt.Errorf("%s: %w", to.label, err)
+		}
+
+		diffs := izanami.DiffFeatureSnapshots(fromFeatures, toFeatures)
+		if diffs == nil {
+			diffs = []izanami.FeatureDiff{}
+		}
+
+		w := cmd.OutOrStdout()
+		switch {
+		case outputFormat == "json":
+			if err := output.PrintTo(w, FeaturesDiffResult{From: from.label, To: to.label, Differences: diffs}, output.JSON); err != nil {
+				return err
+			}
+		case len(diffs) == 0:
+			fmt.Fprintf(w, "No differences between %s and %s\n", from.label, to.label)
+		case diffFormat == diffFormatTable:
+			if err := output.PrintTo(w, featureDiffRows(diffs), output.Table); err != nil {
+				return err
+			}
+		default:
+			printUnifiedFeatureDiff(w, from.label, to.label, diffs)
+		}
+
+		if len(diffs) > 0 {
+			return withExitCode(ExitDiff, fmt.Errorf("%d feature(s) differ between %s and %s", len(diffs), from.label, to.label))
+		}
+		return nil
+	},
+}
+
+// FeaturesDiffResult is the JSON output of iz diff features
+type FeaturesDiffResult struct {
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	Differences []izanami.FeatureDiff `json:"differences"`
+}
+
+// FeatureDiffRow is one line of the table diff output
+type FeatureDiffRow struct {
+	Status  string `json:"status"`
+	Feature string `json:"feature"`
+	Field   string `json:"field"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// diffSource is one side of a diff: a config resolved from a profile, with its tenant
+type diffSource struct {
+	config *izanami.ResolvedConfig
+	label  string
+}
+
+// newDiffSource resolves the config of a diff side. An empty profile uses the
+// current config; a non-empty tenant overrides the profile's tenant.
+func newDiffSource(cmd *cobra.Command, profile, tenantName string) (*diffSource, error) {
+	var sourceCfg izanami.ResolvedConfig
+	if profile == "" {
+		sourceCfg = *cfg
+	} else {
+		loaded, _, err := izanami.LoadConfigWithProfile(profile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load profile %s: %w", profile, err)
+		}
+		loaded.MergeWithFlags(globalFlagValues(cmd))
+		sourceCfg = *loaded
+	}
+	if tenantName != "" {
+		sourceCfg.Tenant = tenantName
+	}
+
+	if err := sourceCfg.ValidateTenant(); err != nil {
+		return nil, err
+	}
+	if err := checkSessionExpiry(statusOut(cmd), &sourceCfg, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := sourceCfg.ValidateAdminAuth(); err != nil {
+		return nil, err
+	}
+
+	label := "tenant " + sourceCfg.Tenant
+	if profile != "" {
+		label += " (profile " + profile + ")"
+	}
+	return &diffSource{config: &sourceCfg, label: label}, nil
+}
+
+// snapshot fetches the features of the source
+func (s *diffSource) snapshot(ctx context.Context, projectName string) (map[string]*izanami.FeatureSnapshot, error) {
+	client, err := izanami.NewAdminClient(s.config)
+	if err != nil {
+		return nil, err
+	}
+	return izanami.SnapshotFeatures(client, ctx, s.config.Tenant, projectName)
+}
+
+// printUnifiedFeatureDiff prints differences in a unified diff style:
+// "+" for added features, "-" for removed ones and "~" for changed ones with their fields
+func printUnifiedFeatureDiff(w io.Writer, fromLabel, toLabel string, diffs []izanami.FeatureDiff) {
+	fmt.Fprintf(w, "--- %s\n", fromLabel)
+	fmt.Fprintf(w, "+++ %s\n", toLabel)
+
+	counts := make(map[string]int)
+	for _, diff := range diffs {
+		counts[diff.Status]++
+		name := diff.Project + "/" + diff.Name
+		switch diff.Status {
+		case izanami.FeatureDiffAdded:
+			fmt.Fprintln(w, color.GreenString("+ %s", name))
+		case izanami.FeatureDiffRemoved:
+			fmt.Fprintln(w, color.RedString("- %s", name))
+		default:
+			fmt.Fprintln(w, color.YellowString("~ %s", name))
+			for _, change := range diff.Changes {
+				if change.From != "" {
+					fmt.Fprintln(w, color.RedString("-   %s: %s", change.Field, change.From))
+				}
+				if change.To != "" {
+					fmt.Fprintln(w, color.GreenString("+   %s: %s", change.Field, change.To))
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d added, %d removed, %d changed\n",
+		counts[izanami.FeatureDiffAdded], counts[izanami.FeatureDiffRemoved], counts[izanami.FeatureDiffChanged])
+}
+
+// featureDiffRows flattens differences into one row per changed field
+func featureDiffRows(diffs []izanami.FeatureDiff) []FeatureDiffRow {
+	var rows []FeatureDiffRow
+	for _, diff := range diffs {
+		name := diff.Project + "/" + diff.Name
+		if len(diff.Changes) == 0 {
+			rows = append(rows, FeatureDiffRow{Status: diff.Status, Feature: name})
+			continue
+		}
+		for _, change := range diff.Changes {
+			rows = append(rows, FeatureDiffRow{
+				Status:  diff.Status,
+				Feature: name,
+				Field:   change.Field,
+				From:    change.From,
+				To:      change.To,
+			})
+		}
+	}
+	return rows
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.AddCommand(diffFeaturesCmd)
+
+	diffFeaturesCmd.Flags().StringVar(&diffFromProfile, "from-profile", "", "Profile of the first source (default: current profile)")
+	diffFeaturesCmd.Flags().StringVar(&diffToProfile, "to-profile", "", "Profile of the second source (default: current profile)")
+	diffFeaturesCmd.Flags().StringVar(&diffFromTenant, "from-tenant", "", "Tenant of the first source (default: the profile's tenant)")
+	diffFeaturesCmd.Flags().StringVar(&diffToTenant, "to-tenant", "", "Tenant of the second source (default: the profile's tenant)")
+	diffFeaturesCmd.Flags().StringVar(&diffFormat, "format", diffFormatUnified, "Diff format: unified or table")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// diffTestServer serves the features of tenants "staging" and "prod", without contexts
+func diffTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/staging/features":
+			io.WriteString(w, `[{"id":"1","name":"checkout","project":"shop","enabled":true},{"id":"2","name":"legacy","project":"shop","enabled":true}]`)
+		case "/api/admin/tenants/prod/features":
+			io.WriteString(w, `[{"id":"9","name":"checkout","project":"shop","enabled":false},{"id":"8","name":"search","project":"shop","enabled":true}]`)
+		default:
+			io.WriteString(w, `[]`)
+		}
+	})
+	return server
+}
+
+// runDiffFeatures runs iz diff features between the staging and prod tenants
+func runDiffFeatures(t *testing.T, format, outFormat string) (string, error) {
+	t.Helper()
+	diffTestServer(t)
+
+	t.Cleanup(func() {
+		diffFromTenant, diffToTenant, diffFormat = "", "", diffFormatUnified
+	})
+	cfg.Tenant = "staging"
+	outputFormat = outFormat
+	diffFromTenant, diffToTenant, diffFormat = "staging", "prod", format
+
+	var buf bytes.Buffer
+	diffFeaturesCmd.SetOut(&buf)
+	defer diffFeaturesCmd.SetOut(nil)
+	err := diffFeaturesCmd.RunE(diffFeaturesCmd, nil)
+	return buf.String(), err
+}
+
+func TestDiffFeatures_Unified(t *testing.T) {
+	out, err := runDiffFeatures(t, diffFormatUnified, "table")
+	require.Error(t, err, "differences make the command fail")
+	assert.Contains(t, err.Error(), "3 feature(s) differ")
+	assert.Equal(t, ExitDiff, exitCode(err))
+
+	assert.Contains(t, out, "--- tenant staging\n+++ tenant prod\n")
+	assert.Contains(t, out, "~ shop/checkout\n-   enabled: true\n+   enabled: false\n")
+	assert.Contains(t, out, "- shop/legacy\n")
+	assert.Contains(t, out, "+ shop/search\n")
+	assert.Contains(t, out, "1 added, 1 removed, 1 changed")
+}
+
+func TestDiffFeatures_Table(t *testing.T) {
+	out, err := runDiffFeatures(t, diffFormatTable, "table")
+	require.Error(t, err)
+	assert.Regexp(t, `changed\s+shop/checkout\s+enabled\s+true\s+false`, out)
+	assert.Regexp(t, `added\s+shop/search`, out)
+}
+
+func TestDiffFeatures_JSON(t *testing.T) {
+	out, err := runDiffFeatures(t, diffFormatUnified, "json")
+	require.Error(t, err)
+
+	var result FeaturesDiffResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "tenant staging", result.From)
+	assert.Equal(t, "tenant prod", result.To)
+	require.Len(t, result.Differences, 3)
+	assert.Equal(t, izanami.FeatureDiffChanged, result.Differences[0].Status)
+}
+
+func TestDiffFeatures_RequiresSources(t *testing.T) {
+	err := diffFeaturesCmd.RunE(diffFeaturesCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--from-tenant/--to-tenant or --from-profile/--to-profile")
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Error formats of --error-format
+const (
+	ErrorFormatText       = "text"
+	ErrorFormatJSON       = "json"
+	ErrorFormatJSONStdout = "json-stdout"
+)
+
+// errorFormat is how failures are reported (--error-format, env: IZ_ERROR_FORMAT)
+var errorFormat string
+
+// errorTypes name the exit codes in JSON errors
+var errorTypes = map[int]string{
+	ExitError:       "error",
+	ExitInactive:    "inactive",
+	ExitAuth:        "auth",
+	ExitNotFound:    "not_found",
+	ExitEvaluation:  "evaluation",
+	ExitLint:        "lint",
+	ExitInterrupted: "interrupted",
+}
+
+// ErrorOutput is the JSON object printed for a failure with --error-format json
+type ErrorOutput struct {
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails describes a failed command
+type ErrorDetails struct {
+	// Code names the kind of failure; ExitCode is the matching process exit code
+	Code       string `json:"code"`
+	ExitCode   int    `json:"exitCode"`
+	Message    string `json:"message"`
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+	// ServerMessage is the error message of the API response, when there is one
+	ServerMessage string `json:"serverMessage,omitempty"`
+	RequestID     string `json:"requestId,omitempty"`
+}
+
+// newErrorOutput describes err as returned by a command
+func newErrorOutput(err error) *ErrorOutput {
+	code := exitCode(err)
+	details := ErrorDetails{Code: errorTypes[code], ExitCode: code, Message: err.Error()}
+	if details.Code == "" {
+		details.Code = errorTypes[ExitError]
+	}
+	var apiErr *izanami.APIError
+	if errors.As(err, &apiErr) {
+		details.HTTPStatus = apiErr.StatusCode
+		details.ServerMessage = apiErr.Message
+		details.RequestID = apiErr.RequestID
+	}
+	return &ErrorOutput{Error: details}
+}
+
+// validateErrorFormat checks the --error-format value
+func validateErrorFormat() error {
+	switch errorFormat {
+	case ErrorFormatText, ErrorFormatJSON, ErrorFormatJSONStdout:
+		return nil
+	}
+	return fmt.Errorf("invalid --error-format %q (expected text, json or json-stdout)", errorFormat)
+}
+
+// printCommandError reports the error a command failed with: "Error: ..." on
+// stderr, or a JSON error object on stderr or stdout for the json formats
+func printCommandError(stdout, stderr io.Writer, err error) {
+	var w io.Writer
+	switch errorFormat {
+	case ErrorFormatJSON:
+		w = stderr
+	case ErrorFormatJSONStdout:
+		w = stdout
+	default:
+		fmt.Fprintln(stderr, "Error:", err.Error())
+		return
+	}
+	data, _ := json.Marshal(newErrorOutput(err))
+	fmt.Fprintln(w, string(data))
+}
+
+// defaultErrorFormat returns the error format used when --error-format is not given
+func defaultErrorFormat() string {
+	if format := os.Getenv("IZ_ERROR_FORMAT"); format != "" {
+		return format
+	}
+	return ErrorFormatText
+}
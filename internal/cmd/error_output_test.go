@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestNewErrorOutput(t *testing.T) {
+	apiErr := &izanami.APIError{StatusCode: 404, Message: "Feature not found", RequestID: "req-1"}
+	out := newErrorOutput(fmt.Errorf("failed to get feature: %w", apiErr))
+	assert.Equal(t, ErrorDetails{
+		Code:          "not_found",
+		ExitCode:      ExitNotFound,
+		Message:       "failed to get feature: API error (404): Feature not found",
+		HTTPStatus:    404,
+		ServerMessage: "Feature not found",
+		RequestID:     "req-1",
+	}, out.Error)
+
+	out = newErrorOutput(inactiveError([]string{"checkout"}))
+	assert.Equal(t, "inactive", out.Error.Code)
+	assert.Equal(t, ExitInactive, out.Error.ExitCode)
+	assert.Zero(t, out.Error.HTTPStatus)
+
+	out = newErrorOutput(withExitCode(42, fmt.Errorf("custom")))
+	assert.Equal(t, "error", out.Error.Code)
+	assert.Equal(t, 42, out.Error.ExitCode)
+}
+
+func TestPrintCommandError(t *testing.T) {
+	original := errorFormat
+	defer func() { errorFormat = original }()
+	err := notFoundError("no feature named 'x'")
+
+	tests := []struct {
+		format     string
+		wantStdout bool
+	}{
+		{format: ErrorFormatText},
+		{format: ErrorFormatJSON},
+		{format: ErrorFormatJSONStdout, wantStdout: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			errorFormat = tt.format
+			var stdout, stderr bytes.Buffer
+			printCommandError(&stdout, &stderr, err)
+
+			if tt.format == ErrorFormatText {
+				assert.Equal(t, "Error: no feature named 'x'\n", stderr.String())
+				assert.Empty(t, stdout.String())
+				return
+			}
+			written, other := &stderr, &stdout
+			if tt.wantStdout {
+				written, other = &stdout, &stderr
+			}
+			assert.Empty(t, other.String())
+			var out ErrorOutput
+			require.NoError(t, json.Unmarshal(written.Bytes(), &out))
+			assert.Equal(t, "not_found", out.Error.Code)
+			assert.Equal(t, ExitNotFound, out.Error.ExitCode)
+		})
+	}
+
+	errorFormat = "yaml"
+	assert.ErrorContains(t, validateErrorFormat(), "invalid --error-format")
+}
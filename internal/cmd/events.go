@@ -103,7 +103,7 @@ Press Ctrl+C to stop watching.`,
 
 		resolveClientCredentials(cmd, cfg, eventsClientID, eventsClientSecret, projects)
 
-		ctx, cancel := context.WithCancel(context.Background())
+		ctx, cancel := context.WithCancel(commandContext())
 		defer cancel()
 
 		// Check if we need admin client for name resolution
@@ -161,7 +161,7 @@ Press Ctrl+C to stop watching.`,
 		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 		go func() {
 			<-sigCh
-			fmt.Fprintln(cmd.OutOrStderr(), "\n\nStopping event stream...")
+			fmt.Fprintln(statusOut(cmd), "\n\nStopping event stream...")
 			cancel()
 		}()
 
@@ -233,8 +233,8 @@ Press Ctrl+C to stop watching.`,
 			Payload:           payload,
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Connecting to Izanami event stream...\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "   Press Ctrl+C to stop\n\n")
+		fmt.Fprintf(statusOut(cmd), "Connecting to Izanami event stream...\n")
+		fmt.Fprintf(statusOut(cmd), "   Press Ctrl+C to stop\n\n")
 
 		startTime := time.Now()
 		eventCount := 0
@@ -284,9 +284,9 @@ Press Ctrl+C to stop watching.`,
 
 		// Show statistics when stopping
 		duration := time.Since(startTime)
-		fmt.Fprintf(cmd.OutOrStderr(), "\nStatistics:\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "   Events received: %d\n", eventCount)
-		fmt.Fprintf(cmd.OutOrStderr(), "   Duration: %s\n", duration.Round(time.Second))
+		fmt.Fprintf(statusOut(cmd), "\nStatistics:\n")
+		fmt.Fprintf(statusOut(cmd), "   Events received: %d\n", eventCount)
+		fmt.Fprintf(statusOut(cmd), "   Duration: %s\n", duration.Round(time.Second))
 
 		if err != nil && err != context.Canceled {
 			return fmt.Errorf("event stream error: %w", err)
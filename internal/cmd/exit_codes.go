@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Exit codes of iz. They are part of the CLI contract so scripts and CI pipelines
+// can tell failures apart; keep the README "Exit Codes" section in sync.
+const (
+	// ExitOK means the command succeeded
+	ExitOK = 0
+	// ExitError is any failure without a more specific code (usage, network, server errors)
+	ExitError = 1
+	// ExitInactive means --fail-on-false was set and a feature evaluated to false
+	ExitInactive = 2
+	// ExitAuth means credentials are missing, invalid, expired or lack the required rights
+	ExitAuth = 3
+	// ExitNotFound means a tenant, project, feature or other resource does not exist
+	ExitNotFound = 4
+	// ExitEvaluation means a feature check or test request failed
+	ExitEvaluation = 5
+	// ExitLint means features lint found problems at or above --fail-on, or
+	// features deps check found cycles or missing dependencies
+	ExitLint = 6
+	// ExitGuardrail means features validate-rollout found a rollout guardrail
+	// violated, without data or failing to evaluate
+	ExitGuardrail = 7
+	// ExitDiff means diff features found differences between the two sides
+	ExitDiff = 8
+	// ExitInterrupted means the command was interrupted with Ctrl+C (SIGINT) or SIGTERM
+	ExitInterrupted = 130
+)
+
+// exitCodeError gives an error a specific exit code
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode makes the command exit with code when it returns err
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+// notFoundError returns an error exiting with ExitNotFound
+func notFoundError(format string, args ...interface{}) error {
+	return withExitCode(ExitNotFound, fmt.Errorf(format, args...))
+}
+
+// evaluationError gives ExitEvaluation to errors of feature evaluation requests,
+// unless they already map to a more specific code (auth, not found)
+func evaluationError(err error) error {
+	if err == nil || exitCode(err) != ExitError {
+		return err
+	}
+	return withExitCode(ExitEvaluation, err)
+}
+
+// exitCode returns the process exit code for an error returned by a command
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	var authErr *izanami.AuthConfigError
+	if errors.As(err, &authErr) {
+		return ExitAuth
+	}
+	var apiErr *izanami.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ExitAuth
+		case http.StatusNotFound:
+			return ExitNotFound
+		}
+	}
+	return ExitError
+}
+
+// isInactiveValue reports whether a feature evaluation result means "inactive":
+// false, null, 0 or an empty, "false" or "0" string
+func isInactiveValue(active interface{}) bool {
+	switch v := active.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == "" || v == "false" || v == "0"
+	case float64:
+		return v == 0
+	case int:
+		return v == 0
+	}
+	return false
+}
+
+// inactiveError returns the --fail-on-false error for the given inactive features
+func inactiveError(features []string) error {
+	if len(features) == 1 {
+		return withExitCode(ExitInactive, fmt.Errorf("feature %s is inactive", features[0]))
+	}
+	return withExitCode(ExitInactive, fmt.Errorf("%d features are inactive: %v", len(features), features))
+}
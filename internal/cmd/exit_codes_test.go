@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain error", fmt.Errorf("boom"), ExitError},
+		{"unauthorized", &izanami.APIError{StatusCode: http.StatusUnauthorized}, ExitAuth},
+		{"forbidden wrapped", fmt.Errorf("failed to list: %w", &izanami.APIError{StatusCode: http.StatusForbidden}), ExitAuth},
+		{"not found", &izanami.APIError{StatusCode: http.StatusNotFound}, ExitNotFound},
+		{"server error", &izanami.APIError{StatusCode: http.StatusInternalServerError}, ExitError},
+		{"missing credentials", (&izanami.ResolvedConfig{LeaderURL: "http://localhost"}).ValidateAdminAuth(), ExitAuth},
+		{"explicit code", withExitCode(ExitInactive, fmt.Errorf("inactive")), ExitInactive},
+		{"interrupted", withExitCode(ExitInterrupted, fmt.Errorf("context canceled")), ExitInterrupted},
+		{"not found helper", notFoundError("no feature named %q", "x"), ExitNotFound},
+		{"evaluation", evaluationError(fmt.Errorf("script failed")), ExitEvaluation},
+		{"evaluation keeps auth", evaluationError(&izanami.APIError{StatusCode: http.StatusUnauthorized}), ExitAuth},
+		{"evaluation keeps not found", evaluationError(&izanami.APIError{StatusCode: http.StatusNotFound}), ExitNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.err))
+		})
+	}
+}
+
+func TestIsInactiveValue(t *testing.T) {
+	for _, v := range []interface{}{nil, false, "", "false", "0", float64(0), 0} {
+		assert.True(t, isInactiveValue(v), "%#v", v)
+	}
+	for _, v := range []interface{}{true, "variant-a", float64(3), 1} {
+		assert.False(t, isInactiveValue(v), "%#v", v)
+	}
+}
+
+func TestFailIfAnyInactive(t *testing.T) {
+	names := map[string]string{"f1": "checkout", "f2": "search", "f3": "banner"}
+
+	assert.NoError(t, failIfAnyInactive(names, map[string]interface{}{"f1": true, "f2": "v2"}, nil))
+
+	err := failIfAnyInactive(names, map[string]interface{}{"f1": true, "f2": false, "f3": nil}, nil)
+	assert.Equal(t, ExitInactive, exitCode(err))
+	assert.EqualError(t, err, "2 features are inactive: [banner search]")
+
+	err = failIfAnyInactive(names, map[string]interface{}{"f1": false, "f2": nil}, map[string]string{"f2": "script error"})
+	assert.Equal(t, ExitEvaluation, exitCode(err))
+	assert.Contains(t, err.Error(), "search: script error")
+}
+
+func TestFeaturesCheckCmd_FailOnFalse(t *testing.T) {
+	const featureID = "e878a149-df86-4f28-b1db-059580304e1e"
+	active := "false"
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/features/" + featureID:
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"name": "checkout", "project": "shop", "active": `+active+`}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	defer func() {
+		featureFailOnFalse = false
+	}()
+	cfg.JwtToken, cfg.ClientID, cfg.ClientSecret = "", "id", "secret"
+	outputFormat = "json"
+
+	var buf bytes.Buffer
+	featuresCheckCmd.SetOut(&buf)
+	defer featuresCheckCmd.SetOut(nil)
+
+	// Without the flag an inactive feature is not an error
+	require.NoError(t, featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID}))
+
+	featureFailOnFalse = true
+	err := featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID})
+	assert.Equal(t, ExitInactive, exitCode(err))
+	assert.Contains(t, buf.String(), `"active": false`)
+
+	active = "true"
+	assert.NoError(t, featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID}))
+
+	err = featuresCheckCmd.RunE(featuresCheckCmd, []string{"a1b2c3d4-0000-4000-8000-000000000000"})
+	assert.Equal(t, ExitNotFound, exitCode(err))
+}
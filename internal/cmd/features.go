@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
@@ -17,6 +19,12 @@ var (
 	featureEnabled      bool
 	featuresDeleteForce bool
 
+	// List pagination flags
+	featuresListLimit       int
+	featuresListPage        int
+	featuresListConcurrency int
+	featuresListArchived    bool
+
 	// Test command flags
 	featureTestDate      string   // Date for feature evaluation (ISO 8601)
 	featureTestFeatures  []string // Feature IDs for bulk testing
@@ -57,11 +65,65 @@ var featuresListCmd = &cobra.Command{
 
 The list endpoint supports filtering by:
   --tag: Filter by tag (server-side filtering by Izanami API)
-  --project: Filter by project (client-side filtering, use global --project flag)`,
+  --project: Filter by project (client-side filtering, use global --project flag)
+
+The Izanami API returns all features at once. --limit and --page cut the listing
+into pages on the client, and --concurrency N fetches features project by project
+with N parallel requests (merged back in project order), which is faster for
+tenants with thousands of features.
+
+With -o ndjson, one feature is printed per line as the response is received,
+which suits piping into other tools (jq, grep, ...).
+
+Features archived with 'iz admin features archive' are left out unless
+--archived (or --tag archived) is given.
+
+Examples:
+  # First 50 features
+  iz admin features list --tenant my-tenant --limit 50
+
+  # Third page of 100 features, fetching 8 projects at a time
+  iz admin features list --tenant my-tenant --limit 100 --page 3 --concurrency 8
+
+  # Stream features as JSON lines
+  iz admin features list --tenant my-tenant -o ndjson | jq -r .name
+
+  # Features of every accessible tenant, with a TENANT column
+  iz admin features list --tenants all
+
+  # Features owned by a team (metadata "owner", see 'iz admin features owners')
+  iz admin features list --tenant my-tenant --owner team-payments`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
+		if featuresListLimit < 0 {
+			return fmt.Errorf("--limit must be positive")
+		}
+		if featuresListPage < 1 {
+			return fmt.Errorf("--page must be at least 1")
+		}
+		if featuresListConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		if cmd.Flags().Changed("page") && featuresListLimit == 0 {
+			return fmt.Errorf("--page requires --limit")
+		}
+		if featuresOwner != "" && (featuresListLimit > 0 || featuresListConcurrency > 1) {
+			return fmt.Errorf("--owner cannot be used with --limit, --page or --concurrency")
+		}
+		if multiTenantRequested(cmd) {
+			if featuresListLimit > 0 || featuresListConcurrency > 1 {
+				return fmt.Errorf("--limit, --page and --concurrency cannot be used with --tenants")
+			}
+			return runAcrossTenants(cmd, func(ctx context.Context, client *izanami.AdminClient, tenant string) (interface{}, error) {
+				features, err := izanami.ListFeatures(client, ctx, tenant, featureTag, izanami.ParseFeatures)
+				if err != nil {
+					return nil, err
+				}
+				return filterFeaturesByOwner(filterListedFeatures(features), featuresOwner), nil
+			})
+		}
 		if err := cfg.ValidateTenant(); err != nil {
 			return err
 		}
@@ -71,7 +133,15 @@ The list endpoint supports filtering by:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+
+		if featuresListLimit > 0 || featuresListConcurrency > 1 {
+			return listFeaturePage(cmd, client, ctx)
+		}
+
+		if outputFormat == "ndjson" {
+			return streamFeatures(cmd, client, ctx)
+		}
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -79,6 +149,16 @@ The list endpoint supports filtering by:
 			if err != nil {
 				return err
 			}
+			if featuresOwner != "" {
+				if raw, err = izanami.FilterRawFeaturesByOwner(raw, featuresOwner); err != nil {
+					return err
+				}
+			}
+			if !listArchivedFeatures() {
+				if raw, err = izanami.FilterRawFeaturesArchived(raw, false); err != nil {
+					return err
+				}
+			}
 			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
 		}
 
@@ -91,24 +171,115 @@ The list endpoint supports filtering by:
 		// Client-side filtering by project (uses global --project flag)
 		// Note: Izanami API does not support project filtering on the list features endpoint,
 		// so we filter the results here on the client side
-		if cfg.Project != "" {
-			filtered := make([]izanami.Feature, 0, len(features))
-			for _, f := range features {
-				if f.Project == cfg.Project {
-					filtered = append(filtered, f)
-				}
-			}
-			features = filtered
-		}
+		features = filterFeaturesByOwner(filterListedFeatures(features), featuresOwner)
 
 		return output.PrintTo(cmd.OutOrStdout(), features, output.Format(outputFormat))
 	},
 }
 
-// featuresGetCmd gets a specific feature
+// listArchivedFeatures reports whether archived features are listed: with
+// --archived, or when listing the archived tag
+func listArchivedFeatures() bool {
+	return featuresListArchived || featureTag == izanami.ArchivedTag
+}
+
+// filterListedFeatures keeps the features of the --project project, without
+// archived features unless they are listed
+func filterListedFeatures(features []izanami.Feature) []izanami.Feature {
+	features = filterFeaturesByProject(features, cfg.Project)
+	if !listArchivedFeatures() {
+		features = izanami.FilterArchived(features, false)
+	}
+	return features
+}
+
+// filterFeaturesByOwner keeps the features of an owner, all of them when owner is empty
+func filterFeaturesByOwner(features []izanami.Feature, owner string) []izanami.Feature {
+	if owner == "" {
+		return features
+	}
+	filtered := make([]izanami.Feature, 0, len(features))
+	for _, f := range features {
+		if izanami.OwnedBy(f, owner) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// filterFeaturesByProject keeps the features of a project, all of them when project is empty
+func filterFeaturesByProject(features []izanami.Feature, project string) []izanami.Feature {
+	if project == "" {
+		return features
+	}
+	filtered := make([]izanami.Feature, 0, len(features))
+	for _, f := range features {
+		if f.Project == project {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// streamFeatures prints the features of the tenant as JSON lines while they are
+// received, keeping those of the --project project and --owner owner only,
+// without archived features unless they are listed
+func streamFeatures(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context) error {
+	w := cmd.OutOrStdout()
+	return izanami.StreamFeatures(client, ctx, cfg.Tenant, featureTag, func(item json.RawMessage) error {
+		var feature izanami.Feature
+		if err := json.Unmarshal(item, &feature); err != nil {
+			return fmt.Errorf("failed to parse feature: %w", err)
+		}
+		if (cfg.Project != "" && feature.Project != cfg.Project) || (featuresOwner != "" && !izanami.OwnedBy(feature, featuresOwner)) {
+			return nil
+		}
+		if !listArchivedFeatures() && izanami.IsArchived(feature) {
+			return nil
+		}
+		return output.WriteNDJSON(w, item)
+	})
+}
+
+// listFeaturePage prints one page of features fetched with ListFeaturePage
+func listFeaturePage(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context) error {
+	page, err := izanami.ListFeaturePage(client, ctx, cfg.Tenant, izanami.FeatureListOptions{
+		Tag:         featureTag,
+		Project:     cfg.Project,
+		Concurrency: featuresListConcurrency,
+		Limit:       featuresListLimit,
+		Page:        featuresListPage,
+
+		ExcludeArchived: !listArchivedFeatures(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "ndjson" {
+		return output.PrintTo(cmd.OutOrStdout(), page.Raw, output.NDJSON)
+	}
+	if outputFormat == "json" {
+		raw, err := json.Marshal(page.Raw)
+		if err != nil {
+			return err
+		}
+		return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
+	}
+
+	if err := output.PrintTo(cmd.OutOrStdout(), page.Features, output.Format(outputFormat)); err != nil {
+		return err
+	}
+	if featuresListLimit > 0 {
+		fmt.Fprintf(statusOut(cmd), "Page %d of %d (%d features)\n", page.Page, page.Pages, page.Total)
+	}
+	return nil
+}
+
+// featuresGetCmd gets one or more features
 var featuresGetCmd = &cobra.Command{
-	Use:         "get <feature-id-or-name>",
-	Short:       "Get a specific feature",
+	Use:         "get <feature-id-or-name>...",
+	Short:       "Get one or more features",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
 	Long: `Get detailed information about a specific feature, including all context overloads.
 
@@ -123,6 +294,12 @@ This command accepts either a feature UUID or feature name:
     - --project flag is optional (helps disambiguate if multiple features have same name)
     - If multiple features match, an error is returned
 
+With several features, or --from-file (one ID or name per line, blank lines and
+# comments ignored, - for stdin), the features are fetched concurrently and
+printed in one table, or one JSON array with --output json. Features that
+cannot be found do not stop the others: they are listed once the rest is
+printed, and the command exits with code 4.
+
 Examples:
   # Get feature by UUID
   iz admin features get e878a149-df86-4f28-b1db-059580304e1e --tenant my-tenant
@@ -131,9 +308,18 @@ Examples:
   iz admin features get my-feature --tenant my-tenant
 
   # Get feature by name with project disambiguation
-  iz admin features get my-feature --tenant my-tenant --project my-project`,
-	Args: cobra.ExactArgs(1),
+  iz admin features get my-feature --tenant my-tenant --project my-project
+
+  # Get several features at once
+  iz admin features get checkout search banner --tenant my-tenant --project shop
+
+  # Get the features listed in a file, as a JSON array
+  iz admin features get --from-file ids.txt --tenant my-tenant -o json`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || featuresGetFromFile != "" {
+			return runFeaturesBatchGet(cmd, args)
+		}
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
@@ -143,7 +329,7 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// Resolve feature ID or name to UUID
 		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
@@ -190,7 +376,10 @@ Examples:
   iz features create my-feature --project my-project --data @feature.json
 
   # Create from stdin
-  cat feature.json | iz features create my-feature --project my-project --data -`,
+  cat feature.json | iz features create my-feature --project my-project --data -
+
+  # Record the git branch, commit and author in the feature metadata
+  iz features create my-feature --project my-project --git-annotate`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.Validate(); err != nil {
@@ -257,13 +446,25 @@ Examples:
 			}
 		}
 
-		ctx := context.Background()
+		if annotation := gitAnnotation(cmd); annotation != nil {
+			if payloadMap, ok := payload.(map[string]interface{}); ok {
+				izanami.AnnotateFeatureMetadata(payloadMap, nil, annotation)
+			}
+		}
+
+		ctx := commandContext()
+		if payloadMap, ok := payload.(map[string]interface{}); ok {
+			if err := checkPayloadDependencies(cmd, client, ctx, payloadMap, "", cfg.Project); err != nil {
+				return err
+			}
+		}
 		created, err := client.CreateFeature(ctx, cfg.Tenant, cfg.Project, payload)
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Feature created successfully: %s\n", created.ID)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgFeatureCreated, created.ID))
+		runHooks(cmd, izanami.HookFeatureCreate, map[string]interface{}{"id": created.ID, "name": created.Name, "project": created.Project})
 		return output.PrintTo(cmd.OutOrStdout(), created, output.Format(outputFormat))
 	},
 }
@@ -349,35 +550,55 @@ Examples:
 
 			if len(missingFields) > 0 {
 				// Fetch current feature to show the user
-				ctx := context.Background()
+				ctx := commandContext()
 				currentFeature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.ParseFeature)
 				if err != nil {
 					return fmt.Errorf("missing required fields (%v) and failed to fetch current feature: %w", missingFields, err)
 				}
 
 				// Print current feature structure
-				fmt.Fprintf(cmd.OutOrStderr(), "❌ Missing required fields: %v\n\n", missingFields)
-				fmt.Fprintf(cmd.OutOrStderr(), "Current feature structure:\n")
-				output.PrintTo(cmd.OutOrStderr(), currentFeature, output.JSON)
-				fmt.Fprintf(cmd.OutOrStderr(), "\nPlease include all required fields in your update.\n")
+				fmt.Fprintf(statusOut(cmd), "❌ Missing required fields: %v\n\n", missingFields)
+				fmt.Fprintf(statusOut(cmd), "Current feature structure:\n")
+				output.PrintTo(statusOut(cmd), currentFeature, output.JSON)
+				fmt.Fprintf(statusOut(cmd), "\nPlease include all required fields in your update.\n")
 				return fmt.Errorf("missing required fields: %v", missingFields)
 			}
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+		if annotation := gitAnnotation(cmd); annotation != nil {
+			if updateMap, ok := updateData.(map[string]interface{}); ok {
+				// Keep the annotations of the previous changes
+				previous, err := izanami.GetFeatureMetadata(client, ctx, cfg.Tenant, featureID)
+				if err != nil {
+					return err
+				}
+				izanami.AnnotateFeatureMetadata(updateMap, previous, annotation)
+			}
+		}
+		if updateMap, ok := updateData.(map[string]interface{}); ok {
+			if err := checkPayloadDependencies(cmd, client, ctx, updateMap, featureID, ""); err != nil {
+				return err
+			}
+		}
 		if err := client.UpdateFeature(ctx, cfg.Tenant, featureID, updateData, false); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Feature updated successfully: %s\n", featureID)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgFeatureUpdated, featureID))
+		resource := map[string]interface{}{"id": featureID}
+		if updateMap, ok := updateData.(map[string]interface{}); ok {
+			resource["name"], resource["project"] = updateMap["name"], updateMap["project"]
+		}
+		runHooks(cmd, izanami.HookFeatureUpdate, resource)
 		return nil
 	},
 }
 
 // featuresDeleteCmd deletes a feature
 var featuresDeleteCmd = &cobra.Command{
-	Use:         "delete <feature-id-or-name>",
-	Short:       "Delete a feature",
+	Use:         "delete [feature-id-or-name]",
+	Short:       "Delete a feature, or the features matching filters",
 	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:tenant/features/:id"},
 	Long: `Delete a feature flag. This operation cannot be undone.
 
@@ -403,19 +624,43 @@ Examples:
   iz admin features delete my-feature --tenant my-tenant --project my-project
 
   # Delete without confirmation
-  iz admin features delete my-feature --tenant my-tenant --force`,
-	Args: cobra.ExactArgs(1),
+  iz admin features delete my-feature --tenant my-tenant --force
+
+Batch mode:
+  Without argument, the features matching --tag and/or --older-than (and the
+  global --project flag) are deleted. The deletion plan is printed first and
+  the number of features must be typed to confirm (or use --force). Deletions
+  run with --concurrency parallel requests and each result is reported.
+  --older-than uses the tenant audit log, like "features stale": features
+  without audit events match too.
+
+  # Show the plan only
+  iz admin features delete --tag deprecated --project legacy --older-than 180d --dry-run
+
+  # Delete the deprecated features of a project
+  iz admin features delete --tag deprecated --project legacy --older-than 180d`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
+		batch := featuresDeleteTag != "" || featuresDeleteOlderThan != ""
+		if len(args) == 0 {
+			if !batch {
+				return fmt.Errorf("a feature or a filter (--tag, --older-than) is required")
+			}
+			return runFeaturesBatchDelete(cmd)
+		}
+		if batch || featuresDeleteDryRun {
+			return fmt.Errorf("--tag, --older-than and --dry-run cannot be used with a feature argument")
+		}
 
 		client, err := izanami.NewAdminClient(cfg)
 		if err != nil {
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// Resolve feature ID or name to UUID
 		featureID, featureName, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
@@ -442,10 +687,11 @@ Examples:
 
 		// Show both name and ID when name was resolved
 		if featureName != "" {
-			fmt.Fprintf(cmd.OutOrStderr(), "Feature deleted successfully: %s (ID: %s)\n", featureName, featureID)
+			fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgFeatureDeletedWithID, featureName, featureID))
 		} else {
-			fmt.Fprintf(cmd.OutOrStderr(), "Feature deleted successfully: %s\n", featureID)
+			fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgFeatureDeleted, featureID))
 		}
+		runHooks(cmd, izanami.HookFeatureDelete, map[string]interface{}{"id": featureID, "name": featureName})
 		return nil
 	},
 }
@@ -503,12 +749,23 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+		if !noSnapshot {
+			features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures)
+			if err != nil {
+				return err
+			}
+			projects, moves := izanami.PatchedProjects(features, patches)
+			if err := saveMutationSnapshot(cmd, client, projects, moves); err != nil {
+				return err
+			}
+		}
 		if err := client.PatchFeatures(ctx, cfg.Tenant, patches); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Features patched successfully\n")
+		fmt.Fprintln(statusOut(cmd), errmsg.T(errmsg.MsgFeaturesPatched))
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"patches": patches})
 		return nil
 	},
 }
@@ -526,7 +783,7 @@ and verifying feature configuration before deployment.
 The --date flag defaults to "now" (current time). You can also specify an ISO 8601
 datetime (e.g., 2025-01-01T00:00:00Z) to test activation at a specific time.
 
-For WASM/script features, you can provide a JSON payload via --data.
+` + payloadFlagsHelp + `
 
 Examples:
   # Test feature evaluation (uses current time)
@@ -566,38 +823,96 @@ Examples:
 		featureID := args[0]
 		contextPath := ensureLeadingSlash(featureContextStr)
 
-		// Parse payload if provided
-		var payload string
-		if featureData != "" {
-			var payloadData interface{}
-			if err := parseJSONData(featureData, &payloadData); err != nil {
-				return fmt.Errorf("invalid JSON payload: %w", err)
-			}
-			payloadBytes, _ := marshalJSON(payloadData)
-			payload = string(payloadBytes)
+		payload, err := resolvePayload(cmd)
+		if err != nil {
+			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper
 		if outputFormat == "json" {
 			raw, err := izanami.TestFeature(client, ctx, cfg.Tenant, featureID, contextPath, featureUser, date, payload, izanami.Identity)
 			if err != nil {
+				return evaluationError(err)
+			}
+			if err := output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON); err != nil {
 				return err
 			}
-			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
+			if !featureFailOnFalse {
+				return nil
+			}
+			result, err := izanami.ParseFeatureTestResult(raw)
+			if err != nil {
+				return err
+			}
+			return failIfTestInactive(featureID, result)
 		}
 
 		// For table output, use ParseFeatureTestResult mapper
 		result, err := izanami.TestFeature(client, ctx, cfg.Tenant, featureID, contextPath, featureUser, date, payload, izanami.ParseFeatureTestResult)
 		if err != nil {
-			return err
+			return evaluationError(err)
 		}
 
-		return output.PrintTo(cmd.OutOrStdout(), result, output.Format(outputFormat))
+		if err := output.PrintTo(cmd.OutOrStdout(), result, output.Format(outputFormat)); err != nil {
+			return err
+		}
+		if !featureFailOnFalse {
+			return nil
+		}
+		return failIfTestInactive(featureID, result)
 	},
 }
 
+// failIfTestInactive applies --fail-on-false to a feature test result
+func failIfTestInactive(featureID string, result *izanami.FeatureTestResult) error {
+	return failIfTestResultsInactive(izanami.FeatureTestResults{featureID: *result})
+}
+
+// streamTestFeaturesBulk prints bulk test results as JSON lines while they are
+// received, then applies --fail-on-false
+func streamTestFeaturesBulk(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, request izanami.TestFeaturesAdminRequest) error {
+	w := cmd.OutOrStdout()
+	results := izanami.FeatureTestResults{}
+	err := izanami.StreamTestFeaturesBulk(client, ctx, cfg.Tenant, request, func(item json.RawMessage) error {
+		if featureFailOnFalse {
+			var result struct {
+				ID string `json:"id"`
+				izanami.FeatureTestResult
+			}
+			if err := json.Unmarshal(item, &result); err != nil {
+				return fmt.Errorf("failed to parse test result: %w", err)
+			}
+			results[result.ID] = result.FeatureTestResult
+		}
+		return output.WriteNDJSON(w, item)
+	})
+	if err != nil {
+		return evaluationError(err)
+	}
+	if !featureFailOnFalse {
+		return nil
+	}
+	return failIfTestResultsInactive(results)
+}
+
+// failIfTestResultsInactive applies --fail-on-false to feature test results
+func failIfTestResultsInactive(results izanami.FeatureTestResults) error {
+	names := make(map[string]string, len(results))
+	active := make(map[string]interface{}, len(results))
+	failed := make(map[string]string, len(results))
+	for id, result := range results {
+		names[id] = result.Name
+		if names[id] == "" {
+			names[id] = id
+		}
+		active[id] = result.Active
+		failed[id] = result.Error
+	}
+	return failIfAnyInactive(names, active, failed)
+}
+
 // featuresTestDefinitionCmd tests a feature definition without saving
 var featuresTestDefinitionCmd = &cobra.Command{
 	Use:         "test-definition",
@@ -651,7 +966,7 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper
 		if outputFormat == "json" {
@@ -708,7 +1023,10 @@ Examples:
   iz admin features test-bulk --projects proj1 --context /prod --user user123
 
   # Test with tag filters
-  iz admin features test-bulk --projects proj1 --one-tag-in beta,experimental`,
+  iz admin features test-bulk --projects proj1 --one-tag-in beta,experimental
+
+  # One result per line (with its feature "id"), printed as received
+  iz admin features test-bulk --projects proj1 --user user123 -o ndjson`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.Validate(); err != nil {
 			return err
@@ -732,7 +1050,7 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// Resolve feature names to UUIDs if project is available
 		resolvedFeatures, err := resolveFeaturesToUUIDs(ctx, client, cfg.Tenant, cfg.Project, featureTestFeatures, cfg.Verbose, cmd)
@@ -771,31 +1089,64 @@ Examples:
 			NoTagIn:   resolvedNoTagIn,
 		}
 
+		if outputFormat == "ndjson" {
+			return streamTestFeaturesBulk(cmd, client, ctx, request)
+		}
+
 		// For JSON output, use Identity mapper
 		if outputFormat == "json" {
 			raw, err := izanami.TestFeaturesBulk(client, ctx, cfg.Tenant, request, izanami.Identity)
 			if err != nil {
+				return evaluationError(err)
+			}
+			if err := output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON); err != nil {
 				return err
 			}
-			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
+			if !featureFailOnFalse {
+				return nil
+			}
+			results, err := izanami.ParseFeatureTestResults(raw)
+			if err != nil {
+				return err
+			}
+			return failIfTestResultsInactive(results)
 		}
 
 		// For table output, use ParseFeatureTestResults mapper
 		results, err := izanami.TestFeaturesBulk(client, ctx, cfg.Tenant, request, izanami.ParseFeatureTestResults)
 		if err != nil {
-			return err
+			return evaluationError(err)
 		}
 
 		// Convert to table view
 		tableView := results.ToTableView()
-		return output.PrintTo(cmd.OutOrStdout(), tableView, output.Table)
+		if err := output.PrintTo(cmd.OutOrStdout(), tableView, output.Table); err != nil {
+			return err
+		}
+		if !featureFailOnFalse {
+			return nil
+		}
+		return failIfTestResultsInactive(results)
 	},
 }
 
 func init() {
+	// Dynamic completion for feature name argument
+	featuresGetCmd.ValidArgsFunction = completeFeatureNames
+	featuresDeleteCmd.ValidArgsFunction = completeFeatureNames
+
+	// Get flags
+	featuresGetCmd.Flags().StringVar(&featuresGetFromFile, "from-file", "", "Get the features of this file, one ID or name per line (- for stdin)")
+	featuresGetCmd.Flags().IntVar(&featuresGetConcurrency, "concurrency", 10, "Number of features fetched in parallel (batch mode)")
+
 	// List flags
 	featuresListCmd.Flags().StringVar(&featureTag, "tag", "", "Filter by tag (server-side)")
 	// Project filtering uses global --project flag
+	featuresListCmd.Flags().IntVar(&featuresListLimit, "limit", 0, "Number of features per page (0 for all)")
+	featuresListCmd.Flags().IntVar(&featuresListPage, "page", 1, "Page to display with --limit (1-based)")
+	featuresListCmd.Flags().IntVar(&featuresListConcurrency, "concurrency", 1, "Fetch features project by project with this many parallel requests")
+	featuresListCmd.Flags().BoolVar(&featuresListArchived, "archived", false, "Include archived features")
+	addTenantsFlag(featuresListCmd)
 
 	// Create flags
 	// Project uses global --project flag
@@ -820,6 +1171,8 @@ func init() {
 	featuresTestCmd.Flags().StringVar(&featureTestDate, "date", "now", "Evaluation date (ISO 8601 format or 'now')")
 	featuresTestCmd.Flags().StringVar(&featureContextStr, "context", "", "Context path for evaluation")
 	featuresTestCmd.Flags().StringVar(&featureData, "data", "", "JSON payload for WASM features (from file with @file.json, stdin with -, or inline)")
+	addPayloadFlags(featuresTestCmd)
+	featuresTestCmd.Flags().BoolVar(&featureFailOnFalse, "fail-on-false", false, "Exit with code 2 when the feature is inactive")
 
 	// Test-definition flags
 	featuresTestDefinitionCmd.Flags().StringVar(&featureUser, "user", "", "User ID for evaluation")
@@ -836,6 +1189,7 @@ func init() {
 	featuresTestBulkCmd.Flags().StringSliceVar(&featureTestOneTagIn, "one-tag-in", []string{}, "Features must have at least one of these tags (comma-separated)")
 	featuresTestBulkCmd.Flags().StringSliceVar(&featureTestAllTagsIn, "all-tags-in", []string{}, "Features must have all of these tags (comma-separated)")
 	featuresTestBulkCmd.Flags().StringSliceVar(&featureTestNoTagIn, "no-tag-in", []string{}, "Features must not have any of these tags (comma-separated)")
+	featuresTestBulkCmd.Flags().BoolVar(&featureFailOnFalse, "fail-on-false", false, "Exit with code 2 when any feature is inactive")
 
 	// Register new subcommands with featuresCmd
 	featuresCmd.AddCommand(featuresPatchCmd)
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var featuresArchiveReason string
+
+// featuresArchiveCmd soft-deletes a feature
+var featuresArchiveCmd = &cobra.Command{
+	Use:         "archive <feature-id-or-name>",
+	Short:       "Archive a feature instead of deleting it",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Archive a feature: a reversible alternative to delete for flags that may still
+be referenced by old clients.
+
+The feature is disabled, tagged "archived" (the tag is created when missing) and
+its description is prefixed with the archive date, author and reason. Archived
+features are left out of 'iz admin features list' unless --archived is given.
+Restore a feature with 'iz admin features unarchive'.
+
+Examples:
+  iz admin features archive old-checkout --project shop --reason "replaced by checkout-v2"
+
+  # List archived features
+  iz admin features list --tag archived`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		info := izanami.ArchiveInfo{At: time.Now().UTC(), By: cfg.Username, Reason: featuresArchiveReason}
+		if info.By == "" {
+			info.By = cfg.PersonalAccessTokenUsername
+		}
+		archived, err := izanami.ArchiveFeature(client, ctx, cfg.Tenant, featureID, info)
+		if err != nil {
+			return err
+		}
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"id": featureID, "name": archived.Name, "project": archived.Project, "archived": true})
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), archived, output.JSON)
+		}
+		fmt.Fprintf(statusOut(cmd), "✅ Feature %s archived\n", archived.Name)
+		return nil
+	},
+}
+
+// featuresUnarchiveCmd restores an archived feature
+var featuresUnarchiveCmd = &cobra.Command{
+	Use:         "unarchive <feature-id-or-name>",
+	Short:       "Restore an archived feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Restore a feature archived with 'iz admin features archive': the archived tag
+and description prefix are removed, and the feature is enabled again if it was
+enabled when archived.
+
+Examples:
+  iz admin features unarchive old-checkout --project shop`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		restored, err := izanami.UnarchiveFeature(client, ctx, cfg.Tenant, featureID)
+		if err != nil {
+			return err
+		}
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"id": featureID, "name": restored.Name, "project": restored.Project, "archived": false})
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), restored, output.JSON)
+		}
+		state := "disabled"
+		if restored.Enabled {
+			state = "enabled"
+		}
+		fmt.Fprintf(statusOut(cmd), "✅ Feature %s restored (%s)\n", restored.Name, state)
+		return nil
+	},
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresArchiveCmd)
+	featuresCmd.AddCommand(featuresUnarchiveCmd)
+
+	featuresArchiveCmd.Flags().StringVar(&featuresArchiveReason, "reason", "", "Why the feature is archived")
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresBulkFile        string
+	featuresBulkDryRun      bool
+	featuresBulkSkipInvalid bool
+)
+
+// Bulk create row statuses
+const (
+	bulkStatusCreated = "created"
+	bulkStatusFailed  = "failed"
+	bulkStatusInvalid = "invalid"
+	bulkStatusValid   = "valid"
+)
+
+// featureCSVRow is a feature definition read from one CSV row
+type featureCSVRow struct {
+	Line        int
+	Name        string
+	Project     string
+	Enabled     bool
+	Tags        []string
+	Description string
+	Error       string // Validation error, empty if the row is valid
+}
+
+// BulkCreateResult is the per-row outcome of a bulk creation
+type BulkCreateResult struct {
+	Row     int    `json:"row"`
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Status  string `json:"status"`
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// featuresBulkCreateCmd creates features from a CSV file
+var featuresBulkCreateCmd = &cobra.Command{
+	Use:         "bulk-create",
+	Short:       "Create many features from a CSV file",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/projects/:project/features"},
+	Long: `Create features in bulk from a CSV file.
+
+The first line must be a header. Supported columns (case-insensitive, any order):
+  name         Feature name (required)
+  project      Project name (required unless --project is set)
+  enabled      true/false, yes/no, 1/0 (default: false)
+  tags         Tags separated by ';' or '|' (e.g. "beta;mobile")
+  description  Feature description
+
+All rows are validated before anything is created. If a row is invalid the
+command stops without creating anything, unless --skip-invalid is set.
+A per-row report is printed at the end; the command fails if any row failed.
+
+Example CSV:
+  name,project,enabled,tags,description
+  new-checkout,shop,false,beta;checkout,New checkout flow
+  dark-mode,web,true,,Dark theme
+
+Examples:
+  # Create features from a CSV file
+  iz admin features bulk-create -f features.csv
+
+  # Validate the file without creating anything
+  iz admin features bulk-create -f features.csv --dry-run
+
+  # Use a default project for rows without a project column
+  iz admin features bulk-create -f features.csv --project my-project
+
+  # Create the valid rows and report the invalid ones
+  iz admin features bulk-create -f features.csv --skip-invalid
+
+  # Read from stdin
+  cat features.csv | iz admin features bulk-create -f -`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		var reader io.Reader
+		if featuresBulkFile == "-" {
+			reader = cmd.InOrStdin()
+		} else {
+			f, err := os.Open(featuresBulkFile)
+			if err != nil {
+				return fmt.Errorf("failed to open CSV file: %w", err)
+			}
+			defer f.Close()
+			reader = f
+		}
+
+		rows, err := parseFeaturesCSV(reader, cfg.Project)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("CSV file contains no feature rows")
+		}
+
+		results := make([]BulkCreateResult, len(rows))
+		invalid := 0
+		for i, row := range rows {
+			results[i] = BulkCreateResult{Row: row.Line, Name: row.Name, Project: row.Project, Status: bulkStatusValid}
+			if row.Error != "" {
+				results[i].Status = bulkStatusInvalid
+				results[i].Message = row.Error
+				invalid++
+			}
+		}
+
+		// Stop before touching the server if validation failed (or if only validating)
+		if featuresBulkDryRun || (invalid > 0 && !featuresBulkSkipInvalid) {
+			if err := output.PrintTo(cmd.OutOrStdout(), results, output.Format(outputFormat)); err != nil {
+				return err
+			}
+			if invalid > 0 {
+				return fmt.Errorf("%d of %d rows are invalid; nothing was created (use --skip-invalid to create the valid rows)", invalid, len(rows))
+			}
+			fmt.Fprintf(statusOut(cmd), "All %d rows are valid (dry run, nothing created)\n", len(rows))
+			return nil
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		created, failed := 0, 0
+		for i, row := range rows {
+			if row.Error != "" {
+				continue
+			}
+			feature, err := client.CreateFeature(ctx, cfg.Tenant, row.Project, row.payload())
+			if err != nil {
+				results[i].Status = bulkStatusFailed
+				results[i].Message = err.Error()
+				failed++
+				continue
+			}
+			results[i].Status = bulkStatusCreated
+			results[i].ID = feature.ID
+			created++
+		}
+
+		if err := output.PrintTo(cmd.OutOrStdout(), results, output.Format(outputFormat)); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Created %d feature(s), %d failed, %d invalid\n", created, failed, invalid)
+		if failed > 0 || invalid > 0 {
+			return fmt.Errorf("%d of %d rows were not created", failed+invalid, len(rows))
+		}
+		return nil
+	},
+}
+
+// payload builds the feature creation body, matching 'features create'
+func (r featureCSVRow) payload() map[string]interface{} {
+	payload := map[string]interface{}{
+		"name":        r.Name,
+		"description": r.Description,
+		"enabled":     r.Enabled,
+		"resultType":  "boolean",
+		"conditions":  []interface{}{},
+		"metadata":    map[string]interface{}{},
+	}
+	if len(r.Tags) > 0 {
+		payload["tags"] = r.Tags
+	}
+	return payload
+}
+
+// parseFeaturesCSV reads feature rows from CSV and validates each of them.
+// Structural problems (no header, missing name column) are returned as errors;
+// row-level problems are recorded in featureCSVRow.Error.
+func parseFeaturesCSV(r io.Reader, defaultProject string) ([]featureCSVRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Validate row length ourselves to report per-row errors
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+		switch name {
+		case "name", "project", "enabled", "tags", "description":
+			columns[name] = i
+		default:
+			return nil, fmt.Errorf("unknown CSV column %q (supported: name, project, enabled, tags, description)", col)
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("CSV header must contain a 'name' column")
+	}
+	if _, ok := columns["project"]; !ok && defaultProject == "" {
+		return nil, fmt.Errorf("CSV header has no 'project' column; add one or use --project")
+	}
+
+	var rows []featureCSVRow
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		// Report the line number from the file (the reader skips empty lines)
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+
+		field := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		row := featureCSVRow{
+			Line:        line,
+			Name:        field("name"),
+			Project:     field("project"),
+			Description: field("description"),
+			Tags:        splitTags(field("tags")),
+		}
+		if row.Project == "" {
+			row.Project = defaultProject
+		}
+
+		var problems []string
+		if len(record) > len(header) {
+			problems = append(problems, fmt.Sprintf("expected %d columns, got %d", len(header), len(record)))
+		}
+		if row.Name == "" {
+			problems = append(problems, "name is required")
+		}
+		if row.Project == "" {
+			problems = append(problems, "project is required")
+		}
+		if raw := field("enabled"); raw != "" {
+			enabled, err := parseCSVBool(raw)
+			if err != nil {
+				problems = append(problems, err.Error())
+			}
+			row.Enabled = enabled
+		}
+		if row.Name != "" && row.Project != "" {
+			key := row.Project + "/" + row.Name
+			if first, dup := seen[key]; dup {
+				problems = append(problems, fmt.Sprintf("duplicate of row %d", first))
+			} else {
+				seen[key] = line
+			}
+		}
+		row.Error = strings.Join(problems, "; ")
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// isBlankRecord reports whether all fields of a CSV record are empty
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTags splits a tags cell on ';', '|' or ',' and drops empty entries
+func splitTags(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == '|' || r == ','
+	})
+	tags := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if t := strings.TrimSpace(p); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// parseCSVBool parses spreadsheet-style booleans
+func parseCSVBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "on":
+		return true, nil
+	case "no", "n", "off":
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid enabled value %q (use true/false)", value)
+	}
+	return b, nil
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresBulkCreateCmd)
+
+	featuresBulkCreateCmd.Flags().StringVarP(&featuresBulkFile, "file", "f", "", "CSV file to read (use - for stdin)")
+	featuresBulkCreateCmd.Flags().BoolVar(&featuresBulkDryRun, "dry-run", false, "Validate the file without creating features")
+	featuresBulkCreateCmd.Flags().BoolVar(&featuresBulkSkipInvalid, "skip-invalid", false, "Create valid rows even if some rows are invalid")
+	featuresBulkCreateCmd.MarkFlagRequired("file")
+}
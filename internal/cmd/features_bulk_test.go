@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeaturesCSV(t *testing.T) {
+	csvData := "\ufeffName,Project,Enabled,Tags,Description\n" +
+		"new-checkout,shop,true,beta;checkout,New checkout flow\n" +
+		"dark-mode,,no,,Dark theme\n" +
+		"\n" +
+		",shop,true,,\n" +
+		"broken,shop,maybe,,\n" +
+		"new-checkout,shop,false,,duplicate\n"
+
+	rows, err := parseFeaturesCSV(strings.NewReader(csvData), "web")
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "new-checkout", rows[0].Name)
+	assert.Equal(t, "shop", rows[0].Project)
+	assert.True(t, rows[0].Enabled)
+	assert.Equal(t, []string{"beta", "checkout"}, rows[0].Tags)
+	assert.Empty(t, rows[0].Error)
+
+	// Default project applies when the cell is empty
+	assert.Equal(t, "web", rows[1].Project)
+	assert.False(t, rows[1].Enabled)
+	assert.Empty(t, rows[1].Error)
+
+	// Blank line is skipped but line numbers stay accurate
+	assert.Equal(t, 5, rows[2].Line)
+	assert.Contains(t, rows[2].Error, "name is required")
+
+	assert.Contains(t, rows[3].Error, "invalid enabled value")
+	assert.Contains(t, rows[4].Error, "duplicate of row 2")
+}
+
+func TestParseFeaturesCSV_HeaderErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		defaultProject string
+		wantErr        string
+	}{
+		{name: "empty file", data: "", wantErr: "empty"},
+		{name: "unknown column", data: "name,owner\nfoo,bar\n", defaultProject: "p", wantErr: "unknown CSV column"},
+		{name: "missing name column", data: "project\nshop\n", wantErr: "'name' column"},
+		{name: "missing project without default", data: "name\nfoo\n", wantErr: "--project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFeaturesCSV(strings.NewReader(tt.data), tt.defaultProject)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseFeaturesCSV_TooManyColumns(t *testing.T) {
+	rows, err := parseFeaturesCSV(strings.NewReader("name,project\nfoo,shop,extra\n"), "")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Contains(t, rows[0].Error, "expected 2 columns, got 3")
+}
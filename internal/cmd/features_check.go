@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
@@ -23,12 +25,18 @@ var (
 	checkOneTagIn   []string
 	checkAllTagsIn  []string
 	checkNoTagIn    []string
+	// Exit with ExitInactive when a feature evaluates to false
+	featureFailOnFalse bool
+	// Local cache of check results
+	checkCacheFor        string
+	checkOfflineFallback string
 )
 
 // Root-level features command for client operations
 var rootFeaturesCmd = &cobra.Command{
-	Use:   "features",
-	Short: "Client feature operations",
+	Use:     "features",
+	Aliases: []string{"feature"},
+	Short:   "Client feature operations",
 	Long: `Client-facing feature operations using the /api/v2/features endpoint.
 
 This command provides client operations that don't require admin privileges,
@@ -62,11 +70,17 @@ This uses the client API (v2) to evaluate the feature, taking into account:
 - Time-based activation
 - Context-specific overrides
 
-Script Features:
-  For script-based features, you can provide a JSON payload via --data:
-    iz features check <uuid> --user user123 --data '{"customField": "value"}'
-    iz features check <uuid> --user user123 --data @payload.json
-  This will use POST /api/v2/features/{id} instead of GET.
+` + payloadFlagsHelp + `
+  With a payload, the check uses POST /api/v2/features/{id} instead of GET.
+
+Caching:
+  --cache 30s reuses a result checked less than 30s ago for the same feature,
+  user, context and payload, stored in the CLI cache dir (cleared by
+  'iz cache clear', bypassed by --no-cache). --offline-fallback decides the
+  result when the server is unreachable or answers with a 5xx error:
+    stale           the last known result, however old (results are cached)
+    default:false   a fixed value (false, true, a number or a string)
+  A warning is printed on stderr when a fallback is used.
 
 Examples:
   # Check feature by UUID
@@ -79,10 +93,35 @@ Examples:
   iz features check my-feature --tenant my-tenant --project my-project --user user123
 
   # Check script feature with payload
-  iz features check e878a149-df86-4f28-b1db-059580304e1e --data '{"age": 25}'`,
+  iz features check e878a149-df86-4f28-b1db-059580304e1e --data '{"age": 25}'
+  iz features check e878a149-df86-4f28-b1db-059580304e1e --payload-field age=25 --payload-field user.plan=pro
+
+  # Evaluate for every user of a file (one ID per line), 20 requests at a time
+  iz features check my-feature --tenant my-tenant --users-file users.txt --concurrency 20
+
+  # In a loop: reuse results for 30s, and the last known one if the server is down
+  iz features check my-feature --tenant my-tenant --user user123 --cache 30s --offline-fallback stale
+
+  # Treat the feature as off while the server is unreachable
+  iz features check e878a149-df86-4f28-b1db-059580304e1e --offline-fallback default:false --fail-on-false
+
+  # Only print the share of users per result
+  cat users.txt | iz features check my-feature --tenant my-tenant --users-file - --summary-only`,
 	Args:        cobra.ExactArgs(1),
 	Annotations: map[string]string{"uses-worker": "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if checkUsersFile != "" {
+			if checkCacheFor != "" || checkOfflineFallback != "" {
+				return fmt.Errorf("--cache and --offline-fallback cannot be used with --users-file")
+			}
+			if featureUser != "" {
+				return fmt.Errorf("--user and --users-file cannot be used together")
+			}
+			if checkUsersFile == "-" && (featureData == "-" || payloadStdin || payloadFile == "-") {
+				return fmt.Errorf("--users-file and --data cannot both read stdin")
+			}
+		}
+
 		// Build projects list for credential resolution (uses global --project flag)
 		var projects []string
 		if cfg.Project != "" {
@@ -91,73 +130,17 @@ Examples:
 
 		resolveClientCredentials(cmd, cfg, checkClientID, checkClientSecret, projects)
 
-		ctx := context.Background()
+		ctx := commandContext()
 		featureIDOrName := args[0]
-		var featureID string
-
-		// Determine if input is a UUID or name
-		if IsUUID(featureIDOrName) {
-			// UUID mode: use directly
-			featureID = featureIDOrName
-			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "Using feature UUID: %s\n", featureID)
-			}
-		} else {
-			// Name mode: need admin client to resolve feature name
-			if err := cfg.ValidateTenant(); err != nil {
-				return fmt.Errorf("feature name requires --tenant flag: %w", err)
-			}
-
-			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "Resolving feature name '%s' in tenant '%s'...\n", featureIDOrName, cfg.Tenant)
-			}
-
-			// Create admin client for name resolution (uses LeaderURL)
+		featureID := featureIDOrName
+		if !IsUUID(featureIDOrName) && !exactID {
+			// Name mode: need admin client to resolve feature name (uses LeaderURL)
 			adminClient, err := izanami.NewAdminClient(cfg)
 			if err != nil {
 				return fmt.Errorf("failed to create admin client for name resolution: %w", err)
 			}
-
-			// List all features for the tenant
-			features, err := izanami.ListFeatures(adminClient, ctx, cfg.Tenant, "", izanami.ParseFeatures)
-			if err != nil {
-				return fmt.Errorf("failed to list features: %w", err)
-			}
-
-			// Filter by name
-			var matches []izanami.Feature
-			for _, f := range features {
-				if f.Name == featureIDOrName {
-					matches = append(matches, f)
-				}
-			}
-
-			// Further filter by project if specified (uses global --project flag)
-			if cfg.Project != "" {
-				var projectMatches []izanami.Feature
-				for _, f := range matches {
-					if f.Project == cfg.Project {
-						projectMatches = append(projectMatches, f)
-					}
-				}
-				matches = projectMatches
-			}
-
-			// Validate matches
-			if len(matches) == 0 {
-				if cfg.Project != "" {
-					return fmt.Errorf("no feature named '%s' found in tenant '%s' and project '%s'", featureIDOrName, cfg.Tenant, cfg.Project)
-				}
-				return fmt.Errorf("no feature named '%s' found in tenant '%s'", featureIDOrName, cfg.Tenant)
-			}
-			if len(matches) > 1 {
-				return fmt.Errorf("multiple features named '%s' found (use --project to disambiguate or provide UUID instead)", featureIDOrName)
-			}
-
-			// Use the resolved UUID
-			featureID = matches[0].ID
-			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "Found feature ID: %s\n", featureID)
+			if featureID, _, err = resolveFeatureToUUID(ctx, adminClient, cfg, featureIDOrName, cmd); err != nil {
+				return err
 			}
 		}
 
@@ -175,32 +158,36 @@ Examples:
 		// Ensure context has leading slash if specified
 		contextPath = ensureLeadingSlash(contextPath)
 
-		// Parse payload if provided
-		var payload string
-		if featureData != "" {
-			var payloadData interface{}
-			if err := parseJSONData(featureData, &payloadData); err != nil {
-				return fmt.Errorf("invalid JSON payload: %w", err)
-			}
-			// Convert back to JSON string for the API call
-			payloadBytes, err := json.Marshal(payloadData)
-			if err != nil {
-				return fmt.Errorf("failed to serialize payload: %w", err)
-			}
-			payload = string(payloadBytes)
+		payload, err := resolvePayload(cmd)
+		if err != nil {
+			return err
 		}
 
-		// For JSON output, use Identity mapper for raw JSON
+		if checkUsersFile != "" {
+			return checkFeatureForUsers(cmd, checkClient, featureID, contextPath, payload)
+		}
+
+		raw, err := checkFeatureWithCache(cmd, checkClient, featureID, contextPath, payload)
+		if err != nil {
+			return evaluationError(err)
+		}
+
+		// For JSON output, print the raw JSON
 		if outputFormat == "json" {
-			raw, err := izanami.CheckFeature(checkClient, ctx, featureID, featureUser, contextPath, payload, izanami.Identity)
+			if err := output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON); err != nil {
+				return err
+			}
+			if !featureFailOnFalse {
+				return nil
+			}
+			result, err := izanami.ParseFeatureCheckResult(raw)
 			if err != nil {
 				return err
 			}
-			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
+			return failIfInactive(featureIDOrName, result.Active)
 		}
 
-		// For table output, use ParseFeatureCheckResult mapper
-		result, err := izanami.CheckFeature(checkClient, ctx, featureID, featureUser, contextPath, payload, izanami.ParseFeatureCheckResult)
+		result, err := izanami.ParseFeatureCheckResult(raw)
 		if err != nil {
 			return err
 		}
@@ -209,10 +196,88 @@ Examples:
 		result.Tenant = cfg.Tenant
 		result.ID = featureID
 
-		return output.PrintTo(cmd.OutOrStdout(), result, output.Format(outputFormat))
+		if err := output.PrintTo(cmd.OutOrStdout(), result, output.Format(outputFormat)); err != nil {
+			return err
+		}
+		if !featureFailOnFalse {
+			return nil
+		}
+		return failIfInactive(featureIDOrName, result.Active)
 	},
 }
 
+// checkFeatureWithCache evaluates a feature, going through the local check cache
+// when --cache or --offline-fallback is set, and returns the raw JSON result
+func checkFeatureWithCache(cmd *cobra.Command, client *izanami.FeatureCheckClient, featureID, contextPath, payload string) ([]byte, error) {
+	ctx := commandContext()
+	if checkCacheFor == "" && checkOfflineFallback == "" {
+		return izanami.CheckFeature(client, ctx, featureID, featureUser, contextPath, payload, izanami.Identity)
+	}
+
+	var opts izanami.CheckCacheOptions
+	if checkCacheFor != "" && !noCache {
+		ttl, err := parseDayDuration(checkCacheFor)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid --cache duration %q (e.g. 30s, 5m)", checkCacheFor)
+		}
+		opts.TTL = ttl
+	}
+	if checkOfflineFallback != "" {
+		fallback, err := izanami.ParseOfflineFallback(checkOfflineFallback)
+		if err != nil {
+			return nil, err
+		}
+		opts.Fallback = fallback
+	}
+
+	check, err := izanami.CheckFeatureCached(client, ctx, featureID, featureUser, contextPath, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	age := time.Since(check.StoredAt).Round(time.Second)
+	switch check.Source {
+	case izanami.CheckSourceCache:
+		if cfg.Verbose {
+			fmt.Fprintf(statusOut(cmd), "Using result cached %s ago\n", age)
+		}
+	case izanami.CheckSourceStale:
+		fmt.Fprintf(statusOut(cmd), "Warning: server unavailable (%v); using the last known result, from %s ago\n", check.ServerError, age)
+	case izanami.CheckSourceDefault:
+		fmt.Fprintf(statusOut(cmd), "Warning: server unavailable (%v); using the fallback value %s\n", check.ServerError, checkOfflineFallback)
+	}
+	return check.Raw, nil
+}
+
+// failIfInactive returns the --fail-on-false error when a feature evaluated to false
+func failIfInactive(feature string, active interface{}) error {
+	if isInactiveValue(active) {
+		return inactiveError([]string{feature})
+	}
+	return nil
+}
+
+// failIfAnyInactive returns the --fail-on-false error when some features of a bulk
+// evaluation evaluated to false, and an evaluation error when some failed
+func failIfAnyInactive(names map[string]string, active map[string]interface{}, failed map[string]string) error {
+	var inactive, errored []string
+	for id := range active {
+		if msg := failed[id]; msg != "" {
+			errored = append(errored, names[id]+": "+msg)
+		} else if isInactiveValue(active[id]) {
+			inactive = append(inactive, names[id])
+		}
+	}
+	sort.Strings(inactive)
+	sort.Strings(errored)
+	if len(errored) > 0 {
+		return withExitCode(ExitEvaluation, fmt.Errorf("%d evaluation(s) failed: %s", len(errored), strings.Join(errored, "; ")))
+	}
+	if len(inactive) > 0 {
+		return inactiveError(inactive)
+	}
+	return nil
+}
+
 // featuresCheckBulkCmd checks multiple features in a single request
 var featuresCheckBulkCmd = &cobra.Command{
 	Use:   "check-bulk",
@@ -233,8 +298,7 @@ The --features and --projects flags accept both UUIDs and names:
 Optionally, you can request activation conditions (--conditions) which allows
 offline re-evaluation of features without another API call.
 
-Script Features:
-  For script-based features, provide a JSON payload via --data to use POST method.
+` + payloadFlagsHelp + `
 
 Examples:
   # Check specific features by UUID
@@ -278,7 +342,7 @@ Examples:
 			return fmt.Errorf("at least one filter is required: --features or --projects")
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// Check if we need admin client for name resolution
 		needsAdminClient := false
@@ -365,7 +429,7 @@ Examples:
 			}
 
 			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "Resolving project names %v in tenant '%s'...\n", projectsToResolve, cfg.Tenant)
+				fmt.Fprintf(statusOut(cmd), "Resolving project names %v in tenant '%s'...\n", projectsToResolve, cfg.Tenant)
 			}
 
 			// List all projects for the tenant
@@ -385,10 +449,10 @@ Examples:
 				if id, found := nameToID[name]; found {
 					resolvedProjects = append(resolvedProjects, id)
 					if cfg.Verbose {
-						fmt.Fprintf(cmd.OutOrStderr(), "Resolved project '%s' to ID: %s\n", name, id)
+						fmt.Fprintf(statusOut(cmd), "Resolved project '%s' to ID: %s\n", name, id)
 					}
 				} else {
-					return fmt.Errorf("no project named '%s' found in tenant '%s'", name, cfg.Tenant)
+					return notFoundError("no project named '%s' found in tenant '%s'", name, cfg.Tenant)
 				}
 			}
 		}
@@ -399,7 +463,7 @@ Examples:
 		var featuresToResolve []string
 
 		for _, featureIDOrName := range checkFeatures {
-			if IsUUID(featureIDOrName) {
+			if IsUUID(featureIDOrName) || exactID {
 				// Already a UUID, use as-is
 				resolvedFeatures = append(resolvedFeatures, featureIDOrName)
 			} else {
@@ -415,7 +479,7 @@ Examples:
 			}
 
 			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "Resolving feature names %v in tenant '%s'...\n", featuresToResolve, cfg.Tenant)
+				fmt.Fprintf(statusOut(cmd), "Resolving feature names %v in tenant '%s'...\n", featuresToResolve, cfg.Tenant)
 			}
 
 			// List all features for the tenant
@@ -424,46 +488,35 @@ Examples:
 				return fmt.Errorf("failed to list features: %w", err)
 			}
 
-			// Build name->ID map
-			nameToID := make(map[string][]izanami.Feature)
+			// Resolve each name, within the projects if specified (use resolved project UUIDs)
+			projectSet := make(map[string]bool)
+			for _, p := range resolvedProjects {
+				projectSet[p] = true
+			}
+			var candidates []nameCandidate
 			for _, f := range allFeatures {
-				nameToID[f.Name] = append(nameToID[f.Name], f)
+				if len(projectSet) == 0 || projectSet[f.Project] {
+					candidates = append(candidates, nameCandidate{ID: f.ID, Name: f.Name, Scope: "project " + f.Project})
+				}
 			}
-
-			// Resolve each name
 			for _, name := range featuresToResolve {
-				matches := nameToID[name]
-
-				// Further filter by project if projects are specified (use resolved project UUIDs)
-				if len(resolvedProjects) > 0 {
-					var projectMatches []izanami.Feature
-					projectSet := make(map[string]bool)
-					for _, p := range resolvedProjects {
-						projectSet[p] = true
-					}
-					for _, f := range matches {
-						if projectSet[f.Project] {
-							projectMatches = append(projectMatches, f)
-						}
-					}
-					matches = projectMatches
-				}
+				matches := matchNameOrID(name, candidates)
 
 				// Validate matches
 				if len(matches) == 0 {
 					if len(resolvedProjects) > 0 {
-						return fmt.Errorf("no feature named '%s' found in tenant '%s' within specified projects %v", name, cfg.Tenant, resolvedProjects)
+						return notFoundError("no feature named '%s' found in tenant '%s' within specified projects %v", name, cfg.Tenant, resolvedProjects)
 					}
-					return fmt.Errorf("no feature named '%s' found in tenant '%s'", name, cfg.Tenant)
+					return notFoundError("no feature named '%s' found in tenant '%s'", name, cfg.Tenant)
 				}
 				if len(matches) > 1 {
-					return fmt.Errorf("multiple features named '%s' found (use --projects to disambiguate or provide UUID instead)", name)
+					return ambiguousNameError("feature", name, "use --projects to disambiguate or provide UUID instead", candidates, matches)
 				}
 
 				// Use the resolved UUID
-				resolvedFeatures = append(resolvedFeatures, matches[0].ID)
+				resolvedFeatures = append(resolvedFeatures, candidates[matches[0]].ID)
 				if cfg.Verbose {
-					fmt.Fprintf(cmd.OutOrStderr(), "Resolved feature '%s' to ID: %s\n", name, matches[0].ID)
+					fmt.Fprintf(statusOut(cmd), "Resolved feature '%s' to ID: %s\n", name, candidates[matches[0]].ID)
 				}
 			}
 		}
@@ -482,18 +535,9 @@ Examples:
 		// Ensure context has leading slash if specified
 		contextPath = ensureLeadingSlash(contextPath)
 
-		// Parse payload if provided
-		var payload string
-		if featureData != "" {
-			var payloadData interface{}
-			if err := parseJSONData(featureData, &payloadData); err != nil {
-				return fmt.Errorf("invalid JSON payload: %w", err)
-			}
-			payloadBytes, err := json.Marshal(payloadData)
-			if err != nil {
-				return fmt.Errorf("failed to serialize payload: %w", err)
-			}
-			payload = string(payloadBytes)
+		payload, err := resolvePayload(cmd)
+		if err != nil {
+			return err
 		}
 
 		// Build request with resolved UUIDs (features, projects, and tags)
@@ -514,23 +558,50 @@ Examples:
 		if outputFormat == "json" {
 			raw, err := izanami.CheckFeatures(checkClient, ctx, request, izanami.Identity)
 			if err != nil {
+				return evaluationError(err)
+			}
+			if err := output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON); err != nil {
 				return err
 			}
-			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
+			if !featureFailOnFalse {
+				return nil
+			}
+			results, err := izanami.ParseActivationsWithConditions(raw)
+			if err != nil {
+				return err
+			}
+			return failIfActivationsInactive(results)
 		}
 
 		// For table output, use ParseActivationsWithConditions mapper
 		results, err := izanami.CheckFeatures(checkClient, ctx, request, izanami.ParseActivationsWithConditions)
 		if err != nil {
-			return err
+			return evaluationError(err)
 		}
 
 		// Convert to table view for table format
 		tableView := results.ToTableView()
-		return output.PrintTo(cmd.OutOrStdout(), tableView, output.Table)
+		if err := output.PrintTo(cmd.OutOrStdout(), tableView, output.Table); err != nil {
+			return err
+		}
+		if !featureFailOnFalse {
+			return nil
+		}
+		return failIfActivationsInactive(results)
 	},
 }
 
+// failIfActivationsInactive applies --fail-on-false to check-bulk results
+func failIfActivationsInactive(results izanami.ActivationsWithConditions) error {
+	names := make(map[string]string, len(results))
+	active := make(map[string]interface{}, len(results))
+	for id, result := range results {
+		names[id] = result.Name
+		active[id] = result.Active
+	}
+	return failIfAnyInactive(names, active, nil)
+}
+
 // resolveTagNames resolves tag names to UUIDs
 // Supports mixing UUIDs and names; requires tenant for name resolution
 // Uses the dedicated GET /api/admin/tenants/:tenant/tags/:name endpoint for individual lookups
@@ -595,7 +666,7 @@ func resolveClientCredentials(cmd *cobra.Command, cfg *izanami.ResolvedConfig, f
 			cfg.ClientID = clientID
 			cfg.ClientSecret = clientSecret
 			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "Using client credentials from worker client-keys (tenant: %s)\n", tenant)
+				fmt.Fprintf(statusOut(cmd), "Using client credentials from worker client-keys (tenant: %s)\n", tenant)
 			}
 			return
 		}
@@ -608,9 +679,9 @@ func resolveClientCredentials(cmd *cobra.Command, cfg *izanami.ResolvedConfig, f
 		cfg.ClientSecret = clientSecret
 		if cfg.Verbose {
 			if len(projects) > 0 {
-				fmt.Fprintf(cmd.OutOrStderr(), "Using client credentials from config (tenant: %s, projects: %v)\n", tenant, projects)
+				fmt.Fprintf(statusOut(cmd), "Using client credentials from config (tenant: %s, projects: %v)\n", tenant, projects)
 			} else {
-				fmt.Fprintf(cmd.OutOrStderr(), "Using client credentials from config (tenant: %s)\n", tenant)
+				fmt.Fprintf(statusOut(cmd), "Using client credentials from config (tenant: %s)\n", tenant)
 			}
 		}
 	}
@@ -621,6 +692,7 @@ func init() {
 	rootCmd.AddCommand(rootFeaturesCmd)
 	rootFeaturesCmd.AddCommand(featuresCheckCmd)
 	rootFeaturesCmd.AddCommand(featuresCheckBulkCmd)
+	featuresCheckCmd.ValidArgsFunction = completeFeatureNames
 
 	// Check flags
 	featuresCheckCmd.Flags().StringVar(&featureUser, "user", "", "User ID for evaluation")
@@ -630,6 +702,13 @@ func init() {
 	featuresCheckCmd.Flags().StringVar(&checkClientSecret, "client-secret", "", "Client secret for feature/event API (env: IZ_CLIENT_SECRET)")
 	featuresCheckCmd.Flags().StringVar(&checkWorker, "worker", "", "Named worker for feature checks (env: IZ_WORKER)")
 	featuresCheckCmd.Flags().StringVar(&featureData, "data", "", "JSON payload for script features (from file with @file.json, stdin with -, or inline)")
+	addPayloadFlags(featuresCheckCmd)
+	featuresCheckCmd.Flags().StringVar(&checkUsersFile, "users-file", "", "Evaluate for each user of this file, one per line (- for stdin)")
+	featuresCheckCmd.Flags().IntVar(&checkConcurrency, "concurrency", 10, "Concurrent evaluations with --users-file")
+	featuresCheckCmd.Flags().BoolVar(&checkSummaryOnly, "summary-only", false, "With --users-file, only print the summary")
+	featuresCheckCmd.Flags().BoolVar(&featureFailOnFalse, "fail-on-false", false, "Exit with code 2 when the feature is inactive")
+	featuresCheckCmd.Flags().StringVar(&checkCacheFor, "cache", "", "Reuse a result checked less than this long ago (e.g. 30s, 5m)")
+	featuresCheckCmd.Flags().StringVar(&checkOfflineFallback, "offline-fallback", "", "Result when the server is unavailable: stale (last known result) or default:<value> (e.g. default:false)")
 	featuresCheckCmd.RegisterFlagCompletionFunc("worker", completeWorkerNames)
 
 	// Bulk check flags
@@ -646,5 +725,7 @@ func init() {
 	featuresCheckBulkCmd.Flags().StringVar(&checkClientSecret, "client-secret", "", "Client secret for feature/event API (env: IZ_CLIENT_SECRET)")
 	featuresCheckBulkCmd.Flags().StringVar(&checkWorker, "worker", "", "Named worker for feature checks (env: IZ_WORKER)")
 	featuresCheckBulkCmd.Flags().StringVar(&featureData, "data", "", "JSON payload for script features (from file with @file.json, stdin with -, or inline)")
+	addPayloadFlags(featuresCheckBulkCmd)
+	featuresCheckBulkCmd.Flags().BoolVar(&featureFailOnFalse, "fail-on-false", false, "Exit with code 2 when any feature is inactive")
 	featuresCheckBulkCmd.RegisterFlagCompletionFunc("worker", completeWorkerNames)
 }
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeaturesCheckCmd_OfflineFallback(t *testing.T) {
+	const featureID = "e878a149-df86-4f28-b1db-059580304e1e"
+	status := http.StatusOK
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		io.WriteString(w, `{"name": "checkout", "project": "shop", "active": true}`)
+	})
+
+	dir := t.TempDir()
+	originalCacheDir := izanami.GetCacheDir()
+	izanami.SetGetCacheDirFunc(func() string { return dir })
+	t.Cleanup(func() { izanami.SetGetCacheDirFunc(func() string { return originalCacheDir }) })
+
+	defer func() {
+		checkCacheFor, checkOfflineFallback, featureFailOnFalse = "", "", false
+	}()
+	cfg.JwtToken, cfg.ClientID, cfg.ClientSecret = "", "id", "secret"
+	outputFormat = "json"
+
+	var buf bytes.Buffer
+	featuresCheckCmd.SetOut(&buf)
+	defer featuresCheckCmd.SetOut(nil)
+
+	checkOfflineFallback = "stale"
+	require.NoError(t, featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID}))
+
+	status = http.StatusServiceUnavailable
+	buf.Reset()
+	require.NoError(t, featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID}))
+	assert.Contains(t, buf.String(), `"active": true`)
+	assert.Contains(t, buf.String(), "Warning: server unavailable")
+
+	checkOfflineFallback, featureFailOnFalse = "default:false", true
+	buf.Reset()
+	err := featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID})
+	assert.Equal(t, ExitInactive, exitCode(err))
+	assert.Contains(t, buf.String(), "using the fallback value default:false")
+
+	checkOfflineFallback = "later"
+	assert.ErrorContains(t, featuresCheckCmd.RunE(featuresCheckCmd, []string{featureID}), "invalid offline fallback")
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	checkUsersFile   string
+	checkConcurrency int
+	checkSummaryOnly bool
+)
+
+// maxUserLineLength is the longest user ID accepted in a users file
+const maxUserLineLength = 64 * 1024
+
+// UserCheckResult is the evaluation of a feature for one user
+type UserCheckResult struct {
+	User   string      `json:"user"`
+	Active interface{} `json:"active,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// UserCheckValueCount counts the users who got one evaluation result
+type UserCheckValueCount struct {
+	Value   string  `json:"value"`
+	Users   int     `json:"users"`
+	Percent float64 `json:"percent"`
+}
+
+// UserCheckSummary aggregates the evaluations of a feature for many users
+type UserCheckSummary struct {
+	Feature string                `json:"feature"`
+	Users   int                   `json:"users"`
+	Errors  int                   `json:"errors"`
+	Values  []UserCheckValueCount `json:"values"`
+}
+
+// UserCheckReport is the JSON output of a --users-file check
+type UserCheckReport struct {
+	Results []UserCheckResult `json:"results,omitempty"`
+	Summary UserCheckSummary  `json:"summary"`
+}
+
+// userCheckFunc evaluates a feature for one user
+type userCheckFunc func(ctx context.Context, user string) (interface{}, error)
+
+// checkFeatureForUsers evaluates a feature for every user of --users-file and prints
+// per-user results (unless --summary-only) followed by a summary
+func checkFeatureForUsers(cmd *cobra.Command, checkClient *izanami.FeatureCheckClient, featureID, contextPath, payload string) error {
+	var input io.Reader
+	if checkUsersFile == "-" {
+		input = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(checkUsersFile)
+		if err != nil {
+			return fmt.Errorf("failed to open users file: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	check := func(ctx context.Context, user string) (interface{}, error) {
+		result, err := izanami.CheckFeature(checkClient, ctx, featureID, user, contextPath, payload, izanami.ParseFeatureCheckResult)
+		if err != nil {
+			return nil, err
+		}
+		return result.Active, nil
+	}
+
+	out := cmd.OutOrStdout()
+	jsonOutput := outputFormat == "json"
+	var results []UserCheckResult
+	inactive := 0
+	if !jsonOutput && !checkSummaryOnly {
+		fmt.Fprintf(out, "%-40s  %s\n", "USER", "ACTIVE")
+	}
+	summary, err := evaluateUsers(commandContext(), input, checkConcurrency, check, func(result UserCheckResult) {
+		if result.Error == "" && isInactiveValue(result.Active) {
+			inactive++
+		}
+		switch {
+		case checkSummaryOnly:
+		case jsonOutput:
+			results = append(results, result)
+		case result.Error != "":
+			fmt.Fprintf(out, "%-40s  error: %s\n", result.User, result.Error)
+		default:
+			fmt.Fprintf(out, "%-40s  %v\n", result.User, result.Active)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	summary.Feature = featureID
+
+	if jsonOutput {
+		if err := output.PrintTo(out, UserCheckReport{Results: results, Summary: summary}, output.JSON); err != nil {
+			return err
+		}
+	} else {
+		printUserCheckSummary(out, summary)
+	}
+
+	if summary.Errors > 0 {
+		return withExitCode(ExitEvaluation, fmt.Errorf("%d of %d evaluation(s) failed", summary.Errors, summary.Users))
+	}
+	if featureFailOnFalse && inactive > 0 {
+		return withExitCode(ExitInactive, fmt.Errorf("feature %s is inactive for %d of %d user(s)", featureID, inactive, summary.Users))
+	}
+	return nil
+}
+
+// evaluateUsers reads one user per line (blank lines and # comments are skipped),
+// evaluates them with a pool of workers and passes the results to emit in input order
+func evaluateUsers(ctx context.Context, input io.Reader, concurrency int, check userCheckFunc, emit func(UserCheckResult)) (UserCheckSummary, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	type job struct {
+		index int
+		user  string
+	}
+	type done struct {
+		index  int
+		result UserCheckResult
+	}
+	jobs := make(chan job)
+	results := make(chan done)
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for j := range jobs {
+				result := UserCheckResult{User: j.user}
+				active, err := check(ctx, j.user)
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Active = active
+				}
+				results <- done{index: j.index, result: result}
+			}
+		}()
+	}
+
+	// Feed the workers while results are collected
+	var readErr error
+	go func() {
+		defer close(jobs)
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(make([]byte, 0, 4096), maxUserLineLength)
+		index := 0
+		for scanner.Scan() {
+			user := strings.TrimSpace(scanner.Text())
+			if user == "" || strings.HasPrefix(user, "#") {
+				continue
+			}
+			jobs <- job{index: index, user: user}
+			index++
+		}
+		readErr = scanner.Err()
+	}()
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	// Emit in input order, buffering results that complete early
+	summary := UserCheckSummary{}
+	counts := make(map[string]int)
+	pending := make(map[int]UserCheckResult)
+	next := 0
+	for d := range results {
+		pending[d.index] = d.result
+		for {
+			result, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			summary.Users++
+			if result.Error != "" {
+				summary.Errors++
+			} else {
+				counts[fmt.Sprint(result.Active)]++
+			}
+			emit(result)
+		}
+	}
+	if readErr != nil {
+		return summary, fmt.Errorf("failed to read users: %w", readErr)
+	}
+
+	for value, users := range counts {
+		summary.Values = append(summary.Values, UserCheckValueCount{
+			Value:   value,
+			Users:   users,
+			Percent: float64(users) * 100 / float64(summary.Users),
+		})
+	}
+	sort.Slice(summary.Values, func(i, j int) bool {
+		if summary.Values[i].Users != summary.Values[j].Users {
+			return summary.Values[i].Users > summary.Values[j].Users
+		}
+		return summary.Values[i].Value < summary.Values[j].Value
+	})
+	return summary, nil
+}
+
+// printUserCheckSummary prints the share of users per evaluation result
+func printUserCheckSummary(w io.Writer, summary UserCheckSummary) {
+	fmt.Fprintf(w, "\nEvaluated %d user(s)\n", summary.Users)
+	for _, value := range summary.Values {
+		fmt.Fprintf(w, "  %-10s %6d  (%.1f%%)\n", value.Value+":", value.Users, value.Percent)
+	}
+	if summary.Errors > 0 {
+		fmt.Fprintf(w, "  %-10s %6d  (%.1f%%)\n", "errors:", summary.Errors, float64(summary.Errors)*100/float64(summary.Users))
+	}
+}
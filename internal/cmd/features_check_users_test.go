@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateUsers_PreservesOrderAndSummarizes(t *testing.T) {
+	input := strings.NewReader("# users\nalice\n\nbob\n  carol  \ndave\n")
+	var running, maxRunning int32
+	check := func(ctx context.Context, user string) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&running, -1)
+		// Later users finish first to exercise reordering
+		time.Sleep(time.Duration(5-len(user)) * 5 * time.Millisecond)
+		return user != "bob", nil
+	}
+
+	var users []string
+	summary, err := evaluateUsers(context.Background(), input, 2, check, func(result UserCheckResult) {
+		users = append(users, result.User)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave"}, users)
+	assert.LessOrEqual(t, maxRunning, int32(2))
+	assert.Equal(t, 4, summary.Users)
+	assert.Equal(t, 0, summary.Errors)
+	require.Len(t, summary.Values, 2)
+	assert.Equal(t, UserCheckValueCount{Value: "true", Users: 3, Percent: 75}, summary.Values[0])
+	assert.Equal(t, UserCheckValueCount{Value: "false", Users: 1, Percent: 25}, summary.Values[1])
+}
+
+func TestEvaluateUsers_CountsErrors(t *testing.T) {
+	input := strings.NewReader("alice\nbob\n")
+	check := func(ctx context.Context, user string) (interface{}, error) {
+		if user == "bob" {
+			return nil, fmt.Errorf("boom")
+		}
+		return "variant-a", nil
+	}
+
+	var results []UserCheckResult
+	summary, err := evaluateUsers(context.Background(), input, 4, check, func(result UserCheckResult) {
+		results = append(results, result)
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "variant-a", results[0].Active)
+	assert.Equal(t, "boom", results[1].Error)
+	assert.Equal(t, 2, summary.Users)
+	assert.Equal(t, 1, summary.Errors)
+	assert.Equal(t, []UserCheckValueCount{{Value: "variant-a", Users: 1, Percent: 50}}, summary.Values)
+}
+
+func TestEvaluateUsers_EmptyInput(t *testing.T) {
+	summary, err := evaluateUsers(context.Background(), strings.NewReader("\n# nothing\n"), 3, func(ctx context.Context, user string) (interface{}, error) {
+		t.Fatalf("unexpected check for %q", user)
+		return nil, nil
+	}, func(UserCheckResult) {})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.Users)
+	assert.Empty(t, summary.Values)
+}
+
+func TestPrintUserCheckSummary(t *testing.T) {
+	var buf strings.Builder
+	printUserCheckSummary(&buf, UserCheckSummary{
+		Users:  4,
+		Errors: 1,
+		Values: []UserCheckValueCount{{Value: "true", Users: 2, Percent: 50}, {Value: "false", Users: 1, Percent: 25}},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Evaluated 4 user(s)")
+	assert.Contains(t, out, "true:")
+	assert.Contains(t, out, "(50.0%)")
+	assert.Contains(t, out, "errors:")
+	assert.Contains(t, out, "(25.0%)")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresDeleteTag         string
+	featuresDeleteOlderThan   string
+	featuresDeleteConcurrency int
+	featuresDeleteDryRun      bool
+)
+
+// runFeaturesBatchDelete deletes the features matching the delete filters
+func runFeaturesBatchDelete(cmd *cobra.Command) error {
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+	filter := izanami.FeatureDeleteFilter{Tag: featuresDeleteTag, Project: cfg.Project, Now: time.Now()}
+	if featuresDeleteOlderThan != "" {
+		age, err := parseDayDuration(featuresDeleteOlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		filter.OlderThan = age
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext()
+	plan, err := izanami.PlanFeatureDeletion(client, ctx, cfg.Tenant, filter)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" && featuresDeleteDryRun {
+		return output.PrintTo(cmd.OutOrStdout(), plan, output.JSON)
+	}
+	out := statusOut(cmd)
+	if len(plan) == 0 {
+		fmt.Fprintln(out, "No features match the filters")
+		return nil
+	}
+	printFeatureDeletionPlan(out, plan, filter.OlderThan > 0)
+	if featuresDeleteDryRun {
+		return nil
+	}
+
+	if !featuresDeleteForce {
+		count := strconv.Itoa(len(plan))
+		if !confirmTyped(cmd, fmt.Sprintf("Delete %s feature(s) from tenant %s? This cannot be undone.", count, cfg.Tenant), count) {
+			return nil
+		}
+	}
+
+	izanami.DeleteFeatures(client, ctx, cfg.Tenant, plan, featuresDeleteConcurrency)
+
+	if outputFormat == "json" {
+		if err := output.PrintTo(cmd.OutOrStdout(), plan, output.JSON); err != nil {
+			return err
+		}
+	}
+	failed := 0
+	for _, deletion := range plan {
+		if deletion.Error != "" {
+			failed++
+		}
+		if outputFormat != "json" {
+			if deletion.Deleted {
+				fmt.Fprintf(out, "%s %s/%s\n", color.GreenString("deleted"), deletion.Project, deletion.Name)
+			} else {
+				fmt.Fprintf(out, "%s  %s/%s: %s\n", color.RedString("failed"), deletion.Project, deletion.Name, deletion.Error)
+			}
+		}
+	}
+	fmt.Fprintf(out, "Deleted %d of %d feature(s), %d failed\n", len(plan)-failed, len(plan), failed)
+	for _, deletion := range plan {
+		if deletion.Deleted {
+			runHooks(cmd, izanami.HookFeatureDelete, map[string]interface{}{"id": deletion.ID, "name": deletion.Name, "project": deletion.Project})
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d feature(s) could not be deleted", failed)
+	}
+	return nil
+}
+
+// printFeatureDeletionPlan lists the features a batch deletion will delete,
+// with their last modification when filtered by age
+func printFeatureDeletionPlan(w io.Writer, plan []izanami.FeatureDeletion, withAge bool) {
+	fmt.Fprintf(w, "Deletion plan (%d feature(s)):\n", len(plan))
+	for _, deletion := range plan {
+		lastModified := ""
+		switch {
+		case !withAge:
+		case deletion.LastModified == "":
+			lastModified = " (never modified)"
+		default:
+			lastModified = fmt.Sprintf(" (last modified %s)", deletion.LastModified)
+		}
+		fmt.Fprintf(w, "  - %s/%s%s\n", deletion.Project, deletion.Name, lastModified)
+	}
+}
+
+func init() {
+	featuresDeleteCmd.Flags().StringVar(&featuresDeleteTag, "tag", "", "Delete the features with this tag (batch mode)")
+	featuresDeleteCmd.Flags().StringVar(&featuresDeleteOlderThan, "older-than", "", "Delete the features not modified for this age, e.g. 180d (batch mode)")
+	featuresDeleteCmd.Flags().IntVar(&featuresDeleteConcurrency, "concurrency", 10, "Number of deletions run in parallel (batch mode)")
+	featuresDeleteCmd.Flags().BoolVar(&featuresDeleteDryRun, "dry-run", false, "Print the deletion plan without deleting (batch mode)")
+}
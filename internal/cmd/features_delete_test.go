@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeaturesDeleteCmd_Batch(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/admin/tenants/acme/features":
+			assert.Equal(t, "deprecated", r.URL.Query().Get("tag"))
+			io.WriteString(w, `[{"id":"f1","name":"a","project":"legacy"},{"id":"f2","name":"b","project":"legacy"},{"id":"f3","name":"c","project":"web"}]`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	defer func() {
+		featuresDeleteTag, featuresDeleteDryRun = "", false
+	}()
+	cfg.Project = "legacy"
+	outputFormat = "table"
+	featuresDeleteTag = "deprecated"
+
+	run := func(input string) string {
+		var buf bytes.Buffer
+		featuresDeleteCmd.SetOut(&buf)
+		featuresDeleteCmd.SetIn(bytes.NewBufferString(input))
+		defer func() {
+			featuresDeleteCmd.SetOut(nil)
+			featuresDeleteCmd.SetIn(nil)
+		}()
+		require.NoError(t, featuresDeleteCmd.RunE(featuresDeleteCmd, nil))
+		return buf.String()
+	}
+
+	// A "y" is not the typed confirmation
+	out := run("y\n")
+	assert.Contains(t, out, "Deletion plan (2 feature(s)):\n  - legacy/a\n  - legacy/b\n")
+	assert.Contains(t, out, "Type '2' to confirm: ")
+	assert.Contains(t, out, "Cancelled")
+	assert.Empty(t, deleted)
+
+	out = run("2\n")
+	assert.ElementsMatch(t, []string{"/api/admin/tenants/acme/features/f1", "/api/admin/tenants/acme/features/f2"}, deleted)
+	assert.Contains(t, out, "deleted legacy/a")
+	assert.Contains(t, out, "Deleted 2 of 2 feature(s), 0 failed")
+}
+
+func TestFeaturesDeleteCmd_BatchDryRun(t *testing.T) {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			t.Errorf("dry run deleted %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"id":"f1","name":"a","project":"legacy"}]`)
+	})
+
+	defer func() {
+		featuresDeleteTag, featuresDeleteDryRun = "", false
+	}()
+	outputFormat = "json"
+	featuresDeleteTag, featuresDeleteDryRun = "deprecated", true
+
+	var buf bytes.Buffer
+	featuresDeleteCmd.SetOut(&buf)
+	defer featuresDeleteCmd.SetOut(nil)
+	require.NoError(t, featuresDeleteCmd.RunE(featuresDeleteCmd, nil))
+	assert.Contains(t, buf.String(), `"name": "a"`)
+}
+
+func TestFeaturesDeleteCmd_InvalidArgs(t *testing.T) {
+	origCfg := cfg
+	defer func() {
+		cfg = origCfg
+		featuresDeleteTag = ""
+	}()
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost", JwtToken: "token", Tenant: "acme"}
+
+	assert.ErrorContains(t, featuresDeleteCmd.RunE(featuresDeleteCmd, nil), "a feature or a filter")
+
+	featuresDeleteTag = "deprecated"
+	assert.ErrorContains(t, featuresDeleteCmd.RunE(featuresDeleteCmd, []string{"my-feature"}), "cannot be used with a feature argument")
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featureDependsOn   []string
+	featuresDepsFormat string
+)
+
+// featuresDepsCmd groups the commands about feature dependencies
+var featuresDepsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Show and check the dependencies between features",
+	Long: `Show and check the dependencies between features.
+
+A feature declares the features it depends on in its metadata ("dependsOn"),
+with --depends-on on 'iz admin features create' and 'update': names of features
+of the same project, or project/name for features of other projects.
+
+Creating or updating a feature fails when its dependencies would form a cycle.
+Enabling a feature whose dependencies are disabled, with 'create --enabled',
+'update' or 'toggle --enable', prints a warning.
+
+Examples:
+  iz admin features create new-checkout --project shop --depends-on payments-v2,auth/sso
+  iz admin features deps graph --tenant my-tenant --format mermaid
+  iz admin features deps check --tenant my-tenant`,
+}
+
+// featuresDepsGraphCmd prints the dependency graph
+var featuresDepsGraphCmd = &cobra.Command{
+	Use:         "graph",
+	Short:       "Print the dependency graph of the features as DOT or Mermaid",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	Long: `Print the dependencies between the features of a tenant, as a Graphviz DOT
+graph or a Mermaid flowchart. Disabled features are grey, missing dependencies
+dashed and the edges of cycles red. Only features with dependencies or
+dependents are shown; with --project, those of the project and their
+dependencies.
+
+Examples:
+  iz admin features deps graph --tenant my-tenant | dot -Tsvg > deps.svg
+  iz admin features deps graph --tenant my-tenant --project shop --format mermaid
+  iz admin features deps graph --tenant my-tenant -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if featuresDepsFormat != "dot" && featuresDepsFormat != "mermaid" {
+			return fmt.Errorf("invalid --format %q: must be dot or mermaid", featuresDepsFormat)
+		}
+		graph, err := loadDependencyGraph(cmd)
+		if err != nil {
+			return err
+		}
+		for _, cycle := range graph.Cycles {
+			fmt.Fprintf(statusOut(cmd), "Warning: dependency cycle %s -> %s\n", strings.Join(cycle, " -> "), cycle[0])
+		}
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), graph, output.JSON)
+		}
+		if featuresDepsFormat == "mermaid" {
+			return graph.WriteMermaid(cmd.OutOrStdout())
+		}
+		return graph.WriteDOT(cmd.OutOrStdout())
+	},
+}
+
+// featuresDepsCheckCmd fails on cycles and missing dependencies
+var featuresDepsCheckCmd = &cobra.Command{
+	Use:         "check",
+	Short:       "Check the dependencies for cycles, missing and disabled features",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	Long: `Check the dependencies between the features of a tenant (or of --project).
+
+Cycles and dependencies on features that do not exist are errors: the command
+exits with code 6 when it finds one. Enabled features depending on disabled
+features are reported as warnings.
+
+Examples:
+  iz admin features deps check --tenant my-tenant
+  iz admin features deps check --tenant my-tenant --project shop`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		graph, err := loadDependencyGraph(cmd)
+		if err != nil {
+			return err
+		}
+
+		w := cmd.OutOrStdout()
+		errors := 0
+		for _, cycle := range graph.Cycles {
+			fmt.Fprintf(w, "error: dependency cycle %s -> %s\n", strings.Join(cycle, " -> "), cycle[0])
+			errors++
+		}
+		warnings := 0
+		for _, e := range graph.Edges {
+			switch {
+			case e.Missing:
+				fmt.Fprintf(w, "error: %s depends on %s, which does not exist\n", e.From, e.To)
+				errors++
+			case graph.Features[e.From].Enabled && !graph.Features[e.To].Enabled:
+				fmt.Fprintf(w, "warning: %s is enabled but depends on %s, which is disabled\n", e.From, e.To)
+				warnings++
+			}
+		}
+
+		if errors > 0 {
+			return withExitCode(ExitLint, fmt.Errorf("dependency check failed: %d error(s), %d warning(s)", errors, warnings))
+		}
+		fmt.Fprintf(statusOut(cmd), "%d dependency(ies) checked: no cycles, %d warning(s)\n", len(graph.Edges), warnings)
+		return nil
+	},
+}
+
+// loadDependencyGraph lists the features of the tenant and builds their
+// dependency graph, restricted to --project when set
+func loadDependencyGraph(cmd *cobra.Command) (*izanami.DependencyGraph, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ValidateTenant(); err != nil {
+		return nil, err
+	}
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	features, err := izanami.ListFeatures(client, commandContext(), cfg.Tenant, "", izanami.ParseFeatures)
+	if err != nil {
+		return nil, err
+	}
+	graph := izanami.BuildDependencyGraph(features, nil)
+	if cfg.Project != "" {
+		graph.Edges = edgesOfProject(graph.Edges, cfg.Project)
+	}
+	return graph, nil
+}
+
+// edgesOfProject keeps the dependencies of the features of a project
+func edgesOfProject(edges []izanami.DependencyEdge, project string) []izanami.DependencyEdge {
+	kept := []izanami.DependencyEdge{}
+	for _, e := range edges {
+		if strings.HasPrefix(e.From, project+"/") {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// checkFeatureDependencies validates the dependencies of a feature about to be
+// created or updated: cycles fail, and enabling it while dependencies are
+// disabled prints a warning
+func checkFeatureDependencies(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, feature izanami.Feature, deps []string) error {
+	features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures)
+	if err != nil {
+		return fmt.Errorf("failed to check dependencies: %w", err)
+	}
+	for _, f := range features {
+		if feature.ID != "" && f.ID == feature.ID {
+			// An update payload may leave out the project
+			if feature.Project == "" {
+				feature.Project = f.Project
+			}
+			if feature.Name == "" {
+				feature.Name = f.Name
+			}
+		}
+	}
+	key := izanami.FeatureKey(feature.Project, feature.Name)
+	replaced := false
+	for i, f := range features {
+		if (feature.ID != "" && f.ID == feature.ID) || izanami.FeatureKey(f.Project, f.Name) == key {
+			features[i], replaced = feature, true
+		}
+	}
+	if !replaced {
+		features = append(features, feature)
+	}
+
+	graph := izanami.BuildDependencyGraph(features, map[string][]string{key: deps})
+	if err := graph.CycleError(); err != nil {
+		return err
+	}
+	if feature.Enabled {
+		printDependencyWarnings(cmd, graph.DependencyWarnings([]string{key}))
+	}
+	return nil
+}
+
+// checkPayloadDependencies applies --depends-on to a feature payload of create
+// or update, then checks the dependencies it declares. The feature is
+// identified by id (update) or by its name in project (create).
+func checkPayloadDependencies(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, payload map[string]interface{}, id, project string) error {
+	if cmd.Flags().Changed("depends-on") {
+		izanami.SetDependencies(payload, featureDependsOn)
+	}
+	metadata, _ := payload["metadata"].(map[string]interface{})
+	deps := izanami.DependenciesOf(metadata)
+	if len(deps) == 0 {
+		return nil
+	}
+
+	feature := izanami.Feature{ID: id, Project: project, Metadata: metadata}
+	feature.Name, _ = payload["name"].(string)
+	if p, ok := payload["project"].(string); ok && p != "" {
+		feature.Project = p
+	}
+	feature.Enabled, _ = payload["enabled"].(bool)
+	return checkFeatureDependencies(cmd, client, ctx, feature, deps)
+}
+
+// warnToggleDependencies prints a warning for each feature being enabled by a
+// toggle while one of its dependencies stays disabled. features must hold
+// every feature of the tenant.
+func warnToggleDependencies(cmd *cobra.Command, features []izanami.Feature, plan []TogglePlanRow) {
+	var enabling []string
+	for _, row := range plan {
+		if row.Target && row.Change {
+			enabling = append(enabling, izanami.FeatureKey(row.Project, row.Name))
+		}
+	}
+	printDependencyWarnings(cmd, izanami.BuildDependencyGraph(features, nil).DependencyWarnings(enabling))
+}
+
+// printDependencyWarnings prints dependency warnings to stderr
+func printDependencyWarnings(cmd *cobra.Command, warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(statusOut(cmd), "Warning: %s\n", warning)
+	}
+}
+
+// hasDependencies reports whether a feature of the list declares dependencies
+func hasDependencies(features []izanami.Feature) bool {
+	for _, f := range features {
+		if len(izanami.DependenciesOf(f.Metadata)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresDepsCmd)
+	featuresDepsCmd.AddCommand(featuresDepsGraphCmd)
+	featuresDepsCmd.AddCommand(featuresDepsCheckCmd)
+
+	featuresDepsGraphCmd.Flags().StringVar(&featuresDepsFormat, "format", "dot", "Graph format: dot or mermaid")
+
+	featuresCreateCmd.Flags().StringSliceVar(&featureDependsOn, "depends-on", nil, "Features this feature depends on: names of the project or project/name")
+	featuresUpdateCmd.Flags().StringSliceVar(&featureDependsOn, "depends-on", nil, "Replace the dependencies of the feature (empty to remove them)")
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const depsTestFeatures = `[
+	{"id":"f1","name":"checkout","project":"shop","enabled":false,"metadata":{"dependsOn":["payments"]}},
+	{"id":"f2","name":"payments","project":"shop","enabled":false},
+	{"id":"f3","name":"search","project":"shop","enabled":true,"metadata":{"dependsOn":"checkout,ghost"}}
+]`
+
+// setupDepsTest serves depsTestFeatures and records the methods of the
+// requests on features
+func setupDepsTest(t *testing.T, cmd *cobra.Command) (*bytes.Buffer, *[]string) {
+	var requests []string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, depsTestFeatures)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id":"f4","name":"new","project":"shop"}`)
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresDepsFormat, featureDependsOn = "dot", nil
+		cmd.SetOut(nil)
+	})
+	outputFormat = "table"
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return &out, &requests
+}
+
+func TestFeaturesDepsGraph(t *testing.T) {
+	out, _ := setupDepsTest(t, featuresDepsGraphCmd)
+	featuresDepsFormat = "mermaid"
+	require.NoError(t, featuresDepsGraphCmd.RunE(featuresDepsGraphCmd, nil))
+	assert.Contains(t, out.String(), "flowchart LR")
+	assert.Contains(t, out.String(), `["shop/ghost (missing)"]:::missing`)
+
+	featuresDepsFormat = "svg"
+	assert.ErrorContains(t, featuresDepsGraphCmd.RunE(featuresDepsGraphCmd, nil), "invalid --format")
+}
+
+func TestFeaturesDepsCheck(t *testing.T) {
+	out, _ := setupDepsTest(t, featuresDepsCheckCmd)
+	err := featuresDepsCheckCmd.RunE(featuresDepsCheckCmd, nil)
+	require.Error(t, err)
+	assert.Equal(t, ExitLint, exitCode(err))
+	assert.Contains(t, out.String(), "error: shop/search depends on shop/ghost, which does not exist")
+	assert.Contains(t, out.String(), "warning: shop/search is enabled but depends on shop/checkout, which is disabled")
+	assert.EqualError(t, err, "dependency check failed: 1 error(s), 1 warning(s)")
+}
+
+func TestFeaturesCreate_DependencyCycle(t *testing.T) {
+	_, requests := setupDepsTest(t, featuresCreateCmd)
+	cfg.Project = "shop"
+	t.Cleanup(func() { featuresCreateCmd.Flags().Lookup("depends-on").Changed = false })
+	require.NoError(t, featuresCreateCmd.Flags().Set("depends-on", "checkout"))
+
+	// checkout depends on payments: making payments depend on checkout is a cycle
+	err := featuresCreateCmd.RunE(featuresCreateCmd, []string{"payments"})
+	assert.EqualError(t, err, "dependency cycle: shop/checkout -> shop/payments -> shop/checkout")
+	for _, request := range *requests {
+		assert.False(t, strings.HasPrefix(request, "POST"), "the feature is not created")
+	}
+}
+
+func TestFeaturesToggle_WarnsAboutDisabledDependencies(t *testing.T) {
+	out, _ := setupDepsTest(t, featuresToggleCmd)
+	cfg.Project = "shop"
+	t.Cleanup(func() { featuresToggleEnable, featuresToggleDryRun = false, false })
+	featuresToggleEnable, featuresToggleDryRun = true, true
+
+	require.NoError(t, featuresToggleCmd.RunE(featuresToggleCmd, []string{"checkout"}))
+	assert.Contains(t, out.String(), "Warning: shop/checkout depends on shop/payments, which is disabled")
+
+	// Enabling the dependency with the feature silences the warning
+	out.Reset()
+	require.NoError(t, featuresToggleCmd.RunE(featuresToggleCmd, []string{"checkout", "payments"}))
+	assert.NotContains(t, out.String(), "Warning")
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+// featuresDescribeCmd renders a feature in a human-readable panel
+var featuresDescribeCmd = &cobra.Command{
+	Use:         "describe <feature-id-or-name>",
+	Short:       "Describe a feature in plain English",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
+	Long: `Describe a feature in a human-readable panel: status, result type, activation
+conditions explained in English, overloads per context, tags, and who created
+and last modified the feature according to the audit log.
+
+Conditions read like "active Mon–Fri 09:00–17:00 Europe/Paris for 25% of
+users". Overloads are looked up in the contexts of the feature project,
+global contexts included.
+
+Use "iz admin features get" for the raw feature, and -o json for the
+description as a document.
+
+Examples:
+  # Describe a feature by name
+  iz admin features describe checkout --tenant my-tenant --project shop
+
+  # Describe a feature by UUID, as JSON
+  iz admin features describe e878a149-df86-4f28-b1db-059580304e1e --tenant my-tenant -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		description, err := izanami.DescribeFeature(client, ctx, cfg.Tenant, featureID)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat != "table" {
+			return output.PrintTo(cmd.OutOrStdout(), description, output.Format(outputFormat))
+		}
+		printFeatureDescription(cmd.OutOrStdout(), description)
+		return nil
+	},
+}
+
+// printFeatureDescription displays a feature description as a panel
+func printFeatureDescription(w io.Writer, d *izanami.FeatureDescription) {
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "Feature: %s\n", d.Name)
+	fmt.Fprintf(w, "Project: %s\n", d.Project)
+	fmt.Fprintf(w, "ID: %s\n", d.ID)
+	fmt.Fprintf(w, "Status: %s\n", describeStatus(d.Enabled))
+	fmt.Fprintf(w, "Result type: %s\n", d.ResultType)
+	if d.Value != nil {
+		fmt.Fprintf(w, "Default value: %v\n", d.Value)
+	}
+	if d.Script != "" {
+		fmt.Fprintf(w, "Script: %s\n", d.Script)
+	}
+	if d.Description != "" {
+		fmt.Fprintf(w, "Description: %s\n", d.Description)
+	}
+	if len(d.Tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(d.Tags, ", "))
+	}
+	if d.CreatedAt != "" {
+		fmt.Fprintf(w, "Created: %s by %s\n", d.CreatedAt, d.CreatedBy)
+	}
+	if d.LastModified != "" {
+		fmt.Fprintf(w, "Last modified: %s by %s (%s)\n", d.LastModified, d.LastModifiedBy, d.LastEvent)
+	}
+
+	fmt.Fprintf(w, "\nConditions:\n")
+	fmt.Fprintf(w, "%s\n", strings.Repeat("=", 80))
+	printDescribedConditions(w, "", d.Enabled, d.Script, d.Conditions)
+
+	if len(d.Overloads) == 0 {
+		fmt.Fprintf(w, "\nNo overloads.\n")
+		return
+	}
+	fmt.Fprintf(w, "\nOverloads:\n")
+	fmt.Fprintf(w, "%s\n", strings.Repeat("=", 80))
+	for _, overload := range d.Overloads {
+		fmt.Fprintf(w, "\n┌─ Context: %s\n", overload.Context)
+		fmt.Fprintf(w, "│  Status: %s\n", describeStatus(overload.Enabled))
+		if overload.Value != nil {
+			fmt.Fprintf(w, "│  Default value: %v\n", overload.Value)
+		}
+		if overload.Script != "" {
+			fmt.Fprintf(w, "│  Script: %s\n", overload.Script)
+		}
+		printDescribedConditions(w, "│  ", overload.Enabled, overload.Script, overload.Conditions)
+		fmt.Fprintf(w, "└%s\n", strings.Repeat("─", 79))
+	}
+}
+
+// printDescribedConditions lists explained conditions, or what happens without any
+func printDescribedConditions(w io.Writer, prefix string, enabled bool, script string, conditions []string) {
+	switch {
+	case !enabled:
+		fmt.Fprintf(w, "%sinactive for everyone (disabled)\n", prefix)
+	case script != "":
+		fmt.Fprintf(w, "%sdecided by script %s\n", prefix, script)
+	case len(conditions) == 0:
+		fmt.Fprintf(w, "%sactive for all users\n", prefix)
+	default:
+		for i, condition := range conditions {
+			if i > 0 {
+				fmt.Fprintf(w, "%s  or\n", prefix)
+			}
+			fmt.Fprintf(w, "%s• %s\n", prefix, condition)
+		}
+	}
+}
+
+// describeStatus colors the enabled status of a feature or an overload
+func describeStatus(enabled bool) string {
+	if enabled {
+		return color.GreenString("enabled")
+	}
+	return color.RedString("disabled")
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresDescribeCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestPrintFeatureDescription(t *testing.T) {
+	var buf bytes.Buffer
+	printFeatureDescription(&buf, &izanami.FeatureDescription{
+		ID:             "f1",
+		Name:           "checkout",
+		Project:        "shop",
+		Enabled:        true,
+		ResultType:     "boolean",
+		Tags:           []string{"payments", "beta"},
+		Conditions:     []string{"active Mon–Fri 09:00–17:00 Europe/Paris for 25% of users", "active for user alice"},
+		LastModified:   "2024-03-04T00:00:00Z",
+		LastModifiedBy: "bob",
+		LastEvent:      "FEATURE_UPDATED",
+		Overloads: []izanami.OverloadDescription{
+			{Context: "prod", Enabled: false, Conditions: []string{}},
+			{Context: "staging", Enabled: true, Conditions: []string{}},
+		},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Feature: checkout\n")
+	assert.Contains(t, out, "Tags: payments, beta\n")
+	assert.Contains(t, out, "Last modified: 2024-03-04T00:00:00Z by bob (FEATURE_UPDATED)\n")
+	assert.Contains(t, out, "• active Mon–Fri 09:00–17:00 Europe/Paris for 25% of users\n  or\n• active for user alice\n")
+	assert.Contains(t, out, "┌─ Context: prod\n│  Status: disabled\n│  inactive for everyone (disabled)\n")
+	assert.Contains(t, out, "┌─ Context: staging\n│  Status: enabled\n│  active for all users\n")
+	assert.NotContains(t, out, "Created:")
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+var (
+	featureContextPath            string
+	featureContextPreserveProtect bool
+)
+
+// featuresEnableCmd enables a feature in a context
+var featuresEnableCmd = &cobra.Command{
+	Use:         "enable <feature-id-or-name>",
+	Short:       "Enable a feature in a context",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/contexts/:context/features/:name"},
+	Long: `Enable a feature in a context, without writing overload JSON.
+
+The overload of the feature in the context is created or updated with
+"enabled": true; everything else is kept. An existing overload keeps its
+conditions and value. A new overload starts from the strategy the feature has
+in the context: the overload of the closest parent context, or else the
+feature itself. The feature then behaves as before in the context, only enabled.
+
+To enable a feature in every context, use 'iz admin features toggle --enable'.
+
+Examples:
+  # Enable a feature in the prod/eu context only
+  iz admin features enable new-checkout --context prod/eu --project shop
+
+  # Disable it there again
+  iz admin features disable new-checkout --context prod/eu --project shop`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setFeatureEnabledInContext(cmd, args[0], true)
+	},
+}
+
+// featuresDisableCmd disables a feature in a context
+var featuresDisableCmd = &cobra.Command{
+	Use:         "disable <feature-id-or-name>",
+	Short:       "Disable a feature in a context",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/contexts/:context/features/:name"},
+	Long: `Disable a feature in a context, without writing overload JSON.
+
+The overload of the feature in the context is created or updated with
+"enabled": false; everything else is kept, as with 'iz admin features enable'.
+
+To disable a feature in every context, use 'iz admin features toggle --disable'.
+
+Examples:
+  # Disable a feature in the prod/eu context only
+  iz admin features disable new-checkout --context prod/eu --project shop`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setFeatureEnabledInContext(cmd, args[0], false)
+	},
+}
+
+// setFeatureEnabledInContext creates or updates the overload of a feature in
+// --context with the given enabled state, keeping the rest of its strategy
+func setFeatureEnabledInContext(cmd *cobra.Command, ref string, enabled bool) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+	contextPath := strings.Trim(featureContextPath, "/")
+	if contextPath == "" {
+		return fmt.Errorf("context is required (use --context flag)")
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := commandContext()
+	features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures)
+	if err != nil {
+		return err
+	}
+	selected, err := selectFeaturesForToggle(features, cfg.Project, []string{ref})
+	if err != nil {
+		return err
+	}
+	feature := selected[0]
+
+	strategy, from, err := izanami.ContextStrategy(client, ctx, cfg.Tenant, feature.Project, feature.ID, feature.Name, contextPath)
+	if err != nil {
+		return err
+	}
+	state, msg := "disabled", errmsg.MsgFeatureDisabledInContext
+	if enabled {
+		state, msg = "enabled", errmsg.MsgFeatureEnabledInContext
+	}
+	if from == contextPath && strategy["enabled"] == enabled {
+		fmt.Fprintf(statusOut(cmd), "Feature %s is already %s in context %s\n", feature.Name, state, contextPath)
+		return nil
+	}
+	strategy["enabled"] = enabled
+
+	if err := client.SetOverload(ctx, cfg.Tenant, feature.Project, contextPath, feature.Name, strategy, featureContextPreserveProtect); err != nil {
+		return err
+	}
+	fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(msg, feature.Name, contextPath))
+	switch {
+	case from == "":
+		fmt.Fprintf(statusOut(cmd), "New overload created from the strategy of the feature\n")
+	case from != contextPath:
+		fmt.Fprintf(statusOut(cmd), "New overload created from the overload of context %s\n", from)
+	}
+	runHooks(cmd, izanami.HookOverloadSet, map[string]interface{}{"feature": feature.Name, "context": contextPath, "enabled": enabled})
+	return nil
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresEnableCmd)
+	featuresCmd.AddCommand(featuresDisableCmd)
+
+	for _, c := range []*cobra.Command{featuresEnableCmd, featuresDisableCmd} {
+		c.ValidArgsFunction = completeFeatureNames
+		c.Flags().StringVar(&featureContextPath, "context", "", "Context path (e.g., PROD, PROD/mobile)")
+		c.Flags().BoolVar(&featureContextPreserveProtect, "preserve-protected", false, "Preserve protected contexts")
+		_ = c.MarkFlagRequired("context")
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesEnableDisable_Context(t *testing.T) {
+	contexts := `[{"name":"prod","overloads":[
+		{"name":"checkout","project":"shop","enabled":false,"resultType":"boolean","conditions":[{"rule":{"type":"UserPercentage","percentage":20}}]}
+	],"children":[{"name":"eu"}]},{"name":"dev"}]`
+	puts := map[string]map[string]interface{}{}
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"checkout","project":"shop","enabled":true}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features/f1":
+			io.WriteString(w, `{"id":"f1","name":"checkout","project":"shop","enabled":true,"resultType":"boolean","conditions":[{"rule":{"type":"UserList","users":["alice"]}}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, contexts)
+		case r.Method == http.MethodPut:
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			puts[r.URL.Path] = body
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featureContextPath = ""
+		featuresEnableCmd.SetOut(nil)
+		featuresDisableCmd.SetOut(nil)
+	})
+	var buf bytes.Buffer
+	featuresEnableCmd.SetOut(&buf)
+	featuresDisableCmd.SetOut(&buf)
+
+	// A new overload starts from the overload of the parent context
+	featureContextPath = "prod/eu"
+	require.NoError(t, featuresEnableCmd.RunE(featuresEnableCmd, []string{"checkout"}))
+	put := puts["/api/admin/tenants/acme/projects/shop/contexts/prod/eu/features/checkout"]
+	require.NotNil(t, put)
+	assert.Equal(t, true, put["enabled"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"rule": map[string]interface{}{"type": "UserPercentage", "percentage": float64(20)}}}, put["conditions"])
+	assert.Contains(t, buf.String(), "Feature enabled successfully: checkout in context prod/eu")
+	assert.Contains(t, buf.String(), "from the overload of context prod")
+
+	// An existing overload keeps its conditions
+	featureContextPath = "prod"
+	require.NoError(t, featuresEnableCmd.RunE(featuresEnableCmd, []string{"checkout"}))
+	put = puts["/api/admin/tenants/acme/projects/shop/contexts/prod/features/checkout"]
+	assert.Equal(t, true, put["enabled"])
+	assert.Len(t, put["conditions"], 1)
+
+	// Without overloads, the strategy of the feature is used
+	featureContextPath = "dev"
+	require.NoError(t, featuresDisableCmd.RunE(featuresDisableCmd, []string{"checkout"}))
+	put = puts["/api/admin/tenants/acme/projects/shop/contexts/dev/features/checkout"]
+	assert.Equal(t, false, put["enabled"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"rule": map[string]interface{}{"type": "UserList", "users": []interface{}{"alice"}}}}, put["conditions"])
+
+	// Nothing is written when the overload already has the state
+	buf.Reset()
+	delete(puts, "/api/admin/tenants/acme/projects/shop/contexts/prod/features/checkout")
+	featureContextPath = "prod"
+	require.NoError(t, featuresDisableCmd.RunE(featuresDisableCmd, []string{"checkout"}))
+	assert.Contains(t, buf.String(), "already disabled in context prod")
+	assert.NotContains(t, puts, "/api/admin/tenants/acme/projects/shop/contexts/prod/features/checkout")
+
+	featureContextPath = "prod/us"
+	assert.EqualError(t, featuresEnableCmd.RunE(featuresEnableCmd, []string{"checkout"}), "context 'prod/us' not found in project 'shop'")
+}
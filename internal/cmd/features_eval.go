@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	evalDefinitionFile string
+	evalUser           string
+	evalDate           string
+)
+
+// featuresEvalCmd evaluates a feature definition locally
+var featuresEvalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate a boolean feature definition offline",
+	Long: `Evaluate a boolean feature definition locally, without contacting Izanami.
+
+The definition uses the format of "iz admin features test-definition" (or the JSON
+of "iz admin features get"). The activation rules are implemented in the CLI:
+  - disabled features are inactive, enabled features without conditions are active
+  - otherwise the feature is active when any condition matches
+  - a condition matches when its period contains the date (begin, end, days and
+    hour windows, in the period timezone or UTC) and its rule matches the user
+  - UserList rules match listed users, UserPercentage rules hash the feature id
+    (or name when the definition has no id) with the user, as the server does
+
+Script (WASM) features and non-boolean result types are not supported.
+No configuration or credentials are needed, which makes this usable in unit tests
+and air-gapped environments.
+
+Examples:
+  # Evaluate for a user now
+  iz features eval --definition feature.json --user alice
+
+  # Evaluate at a given date
+  iz features eval --definition feature.json --user alice --date 2025-06-01T12:00:00Z
+
+  # Definition from stdin, failing when inactive
+  iz admin features get my-feature -o json | iz features eval --definition - --user alice --fail-on-false`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if evalDefinitionFile == "" {
+			return fmt.Errorf("--definition is required")
+		}
+
+		date := time.Now().UTC()
+		if evalDate != "now" {
+			parsed, err := parseScheduleTime(evalDate, time.UTC)
+			if err != nil {
+				return err
+			}
+			date = parsed
+		}
+
+		data, err := readInputFile(cmd, evalDefinitionFile)
+		if err != nil {
+			return err
+		}
+		var definition izanami.FeatureDefinition
+		if err := json.Unmarshal(data, &definition); err != nil {
+			return fmt.Errorf("invalid feature definition: %w", err)
+		}
+
+		result, err := izanami.EvaluateFeature(definition, izanami.EvaluationContext{User: evalUser, Date: date})
+		if err != nil {
+			return evaluationError(err)
+		}
+
+		if outputFormat == "json" {
+			if err := output.PrintTo(cmd.OutOrStdout(), result, output.JSON); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "%t\n", result.Active)
+			fmt.Fprintf(statusOut(cmd), "Reason: %s\n", result.Reason)
+		}
+
+		if featureFailOnFalse && !result.Active {
+			name := result.Name
+			if name == "" {
+				name = "(unnamed)"
+			}
+			return inactiveError([]string{name})
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootFeaturesCmd.AddCommand(featuresEvalCmd)
+
+	featuresEvalCmd.Flags().StringVar(&evalDefinitionFile, "definition", "", "Feature definition JSON file (- for stdin)")
+	featuresEvalCmd.Flags().StringVar(&evalUser, "user", "", "User ID for evaluation")
+	featuresEvalCmd.Flags().StringVar(&evalDate, "date", "now", "Evaluation date (ISO 8601 format or 'now')")
+	featuresEvalCmd.Flags().BoolVar(&featureFailOnFalse, "fail-on-false", false, "Exit with code 2 when the feature is inactive")
+}
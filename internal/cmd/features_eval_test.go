@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesEvalCmd(t *testing.T) {
+	origOutput := outputFormat
+	t.Cleanup(func() {
+		outputFormat = origOutput
+		evalDefinitionFile, evalUser, evalDate, featureFailOnFalse = "", "", "now", false
+	})
+	definition := `{"name": "beta", "enabled": true, "conditions": [{"rule": {"type": "UserList", "users": ["alice"]}}]}`
+
+	run := func(user string) (string, error) {
+		var buf bytes.Buffer
+		featuresEvalCmd.SetIn(strings.NewReader(definition))
+		featuresEvalCmd.SetOut(&buf)
+		defer featuresEvalCmd.SetIn(nil)
+		defer featuresEvalCmd.SetOut(nil)
+		evalDefinitionFile, evalUser, evalDate = "-", user, "2025-06-01"
+		err := featuresEvalCmd.RunE(featuresEvalCmd, nil)
+		return buf.String(), err
+	}
+
+	outputFormat = "table"
+	out, err := run("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "true\nReason: condition 1 matches (user list)\n", out)
+
+	outputFormat = "json"
+	featureFailOnFalse = true
+	out, err = run("bob")
+	require.Error(t, err)
+	assert.Equal(t, ExitInactive, exitCode(err))
+	assert.Contains(t, out, `"active": false`)
+	assert.Contains(t, out, `"reason": "no condition matches"`)
+}
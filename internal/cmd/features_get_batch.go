@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresGetFromFile    string
+	featuresGetConcurrency int
+)
+
+// runFeaturesBatchGet gets the features given as arguments and in --from-file
+// concurrently, prints those found in one table or JSON array, then reports
+// the ones that could not be fetched
+func runFeaturesBatchGet(cmd *cobra.Command, args []string) error {
+	refs, err := readArgsAndFile(cmd, args, featuresGetFromFile, "features")
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("no features to get: give feature IDs or names, or --from-file")
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if featuresGetConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := commandContext()
+
+	// Names are resolved against a single listing of the features
+	ids := make([]string, len(refs))
+	errs := make([]error, len(refs))
+	var features []izanami.Feature
+	for i, ref := range refs {
+		if IsUUID(ref) || exactID {
+			ids[i] = ref
+			continue
+		}
+		if features == nil {
+			if err := cfg.ValidateTenant(); err != nil {
+				return fmt.Errorf("feature name requires --tenant flag: %w", err)
+			}
+			if features, err = izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures); err != nil {
+				return fmt.Errorf("failed to list features: %w", err)
+			}
+		}
+		ids[i], errs[i] = findFeatureByName(features, ref, cfg.Project, cfg.Tenant)
+	}
+
+	raws := make([]json.RawMessage, len(refs))
+	sem := make(chan struct{}, featuresGetConcurrency)
+	var wg sync.WaitGroup
+	for i := range refs {
+		if errs[i] != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			raws[i], errs[i] = izanami.GetFeature(client, ctx, cfg.Tenant, ids[i], izanami.Identity)
+		}(i)
+	}
+	wg.Wait()
+
+	var found []json.RawMessage
+	var notFound []string
+	failed := 0
+	for i, ref := range refs {
+		switch {
+		case errs[i] == nil:
+			found = append(found, raws[i])
+		case exitCode(errs[i]) == ExitNotFound:
+			notFound = append(notFound, ref)
+		default:
+			failed++
+			fmt.Fprintf(statusOut(cmd), "Failed to get feature %s: %v\n", ref, errs[i])
+		}
+	}
+
+	if err := printBatchFeatures(cmd, found); err != nil {
+		return err
+	}
+
+	switch {
+	case failed > 0:
+		if len(notFound) > 0 {
+			fmt.Fprintf(statusOut(cmd), "Not found: %s\n", strings.Join(notFound, ", "))
+		}
+		return fmt.Errorf("%d of %d feature(s) could not be fetched", failed+len(notFound), len(refs))
+	case len(notFound) > 0:
+		return notFoundError("%d of %d feature(s) not found: %s", len(notFound), len(refs), strings.Join(notFound, ", "))
+	}
+	return nil
+}
+
+// printBatchFeatures prints the features fetched, in the order they were given:
+// raw JSON objects in an array for --output json, one row per feature otherwise
+func printBatchFeatures(cmd *cobra.Command, raws []json.RawMessage) error {
+	if outputFormat == "json" {
+		if raws == nil {
+			raws = []json.RawMessage{}
+		}
+		data, err := json.Marshal(raws)
+		if err != nil {
+			return err
+		}
+		return output.PrintRawJSON(cmd.OutOrStdout(), data, compactJSON)
+	}
+
+	features := make([]izanami.FeatureWithOverloads, 0, len(raws))
+	for _, raw := range raws {
+		feature, err := izanami.ParseFeature(raw)
+		if err != nil {
+			return err
+		}
+		features = append(features, *feature)
+	}
+	return output.PrintTo(cmd.OutOrStdout(), features, output.Format(outputFormat))
+}
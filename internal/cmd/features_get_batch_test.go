@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesGetCmd_Batch(t *testing.T) {
+	var listed int32
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			atomic.AddInt32(&listed, 1)
+			io.WriteString(w, `[{"id":"f1","name":"checkout","project":"shop","enabled":true},{"id":"f2","name":"search","project":"shop","enabled":false}]`)
+		case "/api/admin/tenants/acme/features/f1":
+			io.WriteString(w, `{"id":"f1","name":"checkout","project":"shop","enabled":true,"metadata":{"owner":"team-a"}}`)
+		case "/api/admin/tenants/acme/features/f2":
+			io.WriteString(w, `{"id":"f2","name":"search","project":"shop","enabled":false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"feature not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresGetFromFile, featuresGetConcurrency = "", 10
+		featuresGetCmd.SetOut(nil)
+	})
+	featuresGetConcurrency = 10
+	var buf bytes.Buffer
+	featuresGetCmd.SetOut(&buf)
+
+	// JSON output is an array of the raw features, in the order given
+	outputFormat = "json"
+	require.NoError(t, featuresGetCmd.RunE(featuresGetCmd, []string{"search", "checkout"}))
+	var features []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &features))
+	require.Len(t, features, 2)
+	assert.Equal(t, "search", features[0]["name"])
+	assert.Equal(t, map[string]interface{}{"owner": "team-a"}, features[1]["metadata"])
+	assert.Equal(t, int32(1), atomic.LoadInt32(&listed), "names are resolved with one listing")
+
+	// Missing features are reported after the others are printed
+	buf.Reset()
+	outputFormat = "table"
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# release 42\ncheckout\nunknown\n\nchecko\n"), 0600))
+	featuresGetFromFile = path
+	err := featuresGetCmd.RunE(featuresGetCmd, []string{"search"})
+	assert.EqualError(t, err, "2 of 4 feature(s) not found: unknown, checko")
+	assert.Equal(t, ExitNotFound, exitCode(err))
+	assert.Contains(t, buf.String(), "checkout")
+	assert.Contains(t, buf.String(), "search")
+
+	// A single feature from a file is fetched in batch mode too
+	buf.Reset()
+	outputFormat = "json"
+	require.NoError(t, os.WriteFile(path, []byte("checkout\n"), 0600))
+	require.NoError(t, featuresGetCmd.RunE(featuresGetCmd, nil))
+	assert.Contains(t, buf.String(), "[")
+
+	require.NoError(t, os.WriteFile(path, []byte("# nothing\n"), 0600))
+	assert.ErrorContains(t, featuresGetCmd.RunE(featuresGetCmd, nil), "no features to get")
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresGitAnnotate  bool
+	featuresHistoryLimit int
+	featuresHistoryDiff  bool
+)
+
+// featuresHistoryCmd shows the audit events of a feature with the commits that changed it
+var featuresHistoryCmd = &cobra.Command{
+	Use:         "history <feature-id>",
+	Short:       "Show who changed a feature, what and when",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/logs"},
+	Long: `Show the audit events of a feature, newest first: who changed it, when, the
+activation fields each event changed, and the git branch, commit and author of
+the changes made with git annotations.
+
+--diff prints, for each event, the fields that differ between its previous
+conditions and its conditions, per context ("prod/eu.enabled"), removed values
+prefixed by "-" and added ones by "+".
+
+With 'git-annotations: true' in the config (iz config set git-annotations true)
+or --git-annotate, 'iz admin features create' and 'update' run inside a git
+repository record the current branch, commit and commit author in the "git"
+entry of the feature metadata, which keeps the last 20 annotations. Each audit
+event is paired with the annotation made by the same user at the same time;
+changes made without annotations show "-".
+
+Examples:
+  iz admin features history my-feature-id --tenant my-tenant
+  iz admin features history my-feature-id --tenant my-tenant --diff
+  iz admin features history my-feature-id --tenant my-tenant --limit 10 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if featuresHistoryLimit <= 0 {
+			return fmt.Errorf("--limit must be positive")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		entries, err := izanami.FeatureHistory(client, commandContext(), cfg.Tenant, args[0], featuresHistoryLimit)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), entries, output.JSON)
+		}
+		if len(entries) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No audit events found for this feature")
+			return nil
+		}
+		if featuresHistoryDiff {
+			printFeatureHistoryDiff(cmd.OutOrStdout(), entries)
+			return nil
+		}
+		views := make([]izanami.FeatureHistoryTableView, len(entries))
+		for i, entry := range entries {
+			views[i] = entry.ToTableView()
+		}
+		return output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat))
+	},
+}
+
+// printFeatureHistoryDiff prints each history entry followed by the fields it
+// changed, in the style of iz diff features
+func printFeatureHistoryDiff(w io.Writer, entries []izanami.FeatureHistoryEntry) {
+	for i, entry := range entries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s %s by %s (event %d)", entry.EmittedAt, entry.Type, entry.User, entry.EventID)
+		if entry.Git != nil {
+			fmt.Fprintf(w, " [%s]", entry.ToTableView().Commit)
+		}
+		fmt.Fprintln(w)
+		if len(entry.Changes) == 0 {
+			fmt.Fprintln(w, "  (no activation change)")
+			continue
+		}
+		for _, change := range entry.Changes {
+			if change.From != "" {
+				fmt.Fprintln(w, color.RedString("-   %s: %s", change.Field, change.From))
+			}
+			if change.To != "" {
+				fmt.Fprintln(w, color.GreenString("+   %s: %s", change.Field, change.To))
+			}
+		}
+	}
+}
+
+// gitAnnotation returns the git annotation of a feature change, or nil when
+// annotations are disabled (git-annotations config, --git-annotate) or the
+// working directory is not in a git repository
+func gitAnnotation(cmd *cobra.Command) *izanami.GitAnnotation {
+	enabled := cfg.GitAnnotations
+	if cmd.Flags().Changed("git-annotate") {
+		enabled = featuresGitAnnotate
+	}
+	if !enabled {
+		return nil
+	}
+	annotation := izanami.ReadGitAnnotation(commandContext(), ".")
+	if annotation == nil {
+		if cmd.Flags().Changed("git-annotate") {
+			fmt.Fprintln(statusOut(cmd), "Warning: not in a git repository, the change is not annotated")
+		}
+		return nil
+	}
+	annotation.User = cfg.Username
+	if annotation.User == "" {
+		annotation.User = cfg.PersonalAccessTokenUsername
+	}
+	return annotation
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresHistoryCmd)
+
+	featuresHistoryCmd.Flags().IntVar(&featuresHistoryLimit, "limit", 50, "Number of audit events to show")
+	featuresHistoryCmd.Flags().BoolVar(&featuresHistoryDiff, "diff", false, "Show the fields changed by each event, before and after")
+	for _, c := range []*cobra.Command{featuresCreateCmd, featuresUpdateCmd} {
+		c.Flags().BoolVar(&featuresGitAnnotate, "git-annotate", false, "Record the git branch, commit and author in the feature metadata (default: git-annotations config)")
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestPrintFeatureHistoryDiff(t *testing.T) {
+	entries := []izanami.FeatureHistoryEntry{
+		{
+			AuditEvent: izanami.AuditEvent{EventID: 42, EmittedAt: "2026-10-01T10:00:00Z", Type: "FEATURE_UPDATED", User: "alice"},
+			Git:        &izanami.GitAnnotation{Commit: "0123456789abcdef"},
+			Changes:    []izanami.FieldChange{{Field: "enabled", From: "false", To: "true"}},
+		},
+		{
+			AuditEvent: izanami.AuditEvent{EventID: 41, EmittedAt: "2026-09-30T10:00:00Z", Type: "FEATURE_CREATED", User: "bob"},
+		},
+	}
+
+	var buf bytes.Buffer
+	printFeatureHistoryDiff(&buf, entries)
+
+	assert.Equal(t, `2026-10-01T10:00:00Z FEATURE_UPDATED by alice (event 42) [0123456789ab]
+-   enabled: false
++   enabled: true
+
+2026-09-30T10:00:00Z FEATURE_CREATED by bob (event 41)
+  (no activation change)
+`, buf.String())
+}
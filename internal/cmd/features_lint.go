@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresLintRules      map[string]string
+	featuresLintStaleAfter string
+	featuresLintFailOn     string
+)
+
+// featuresLintCmd checks feature definitions for common problems
+var featuresLintCmd = &cobra.Command{
+	Use:         "lint",
+	Short:       "Check features for common problems",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	Long: `Check the features of a tenant for common problems. Use the global --project
+flag to only check one project.
+
+Rules (default severity):
+  disabled-stale       (warning) Disabled boolean feature without conditions,
+                                 unchanged for --stale-after (audit log)
+  overlapping-hours    (error)   Hour periods of a condition overlap
+  percentage-bounds    (warning) Percentage rule at 0% or 100%
+  missing-description  (warning) Feature has no description
+  unknown-tag          (error)   Feature has a tag that does not exist
+  duplicate-name       (warning) Feature name is used in several projects
+
+Change severities with --rule name=error|warning|off (repeatable, or
+comma-separated). To keep them per profile, set them in the profile defaults
+section under "admin features lint".
+
+The command exits with code 6 when a finding is at or above --fail-on
+(default: error), so it can gate CI pipelines.
+
+Examples:
+  # Lint a tenant
+  iz admin features lint --tenant my-tenant
+
+  # Fail CI on warnings too, without the description rule
+  iz admin features lint --project shop --fail-on warning --rule missing-description=off
+
+  # Findings as JSON
+  iz admin features lint -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		staleAfter, err := parseDayDuration(featuresLintStaleAfter)
+		if err != nil {
+			return fmt.Errorf("invalid --stale-after: %w", err)
+		}
+		switch featuresLintFailOn {
+		case izanami.LintSeverityError, izanami.LintSeverityWarning, "never":
+		default:
+			return fmt.Errorf("invalid --fail-on %q (expected error, warning or never)", featuresLintFailOn)
+		}
+		if _, err := izanami.ResolveLintSeverities(featuresLintRules); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		findings, err := izanami.LintFeatures(client, commandContext(), cfg.Tenant, izanami.LintOptions{
+			Project:    cfg.Project,
+			Severities: featuresLintRules,
+			StaleAfter: staleAfter,
+			Now:        time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" {
+			if err := output.PrintTo(cmd.OutOrStdout(), findings, output.JSON); err != nil {
+				return err
+			}
+		} else if len(findings) > 0 {
+			views := make([]izanami.LintFindingTableView, len(findings))
+			for i, finding := range findings {
+				views[i] = finding.ToTableView()
+			}
+			if err := output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat)); err != nil {
+				return err
+			}
+		}
+
+		errors, warnings := izanami.CountLintFindings(findings)
+		if len(findings) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No problems found")
+		} else {
+			fmt.Fprintf(statusOut(cmd), "%d error(s), %d warning(s)\n", errors, warnings)
+		}
+		return lintFailure(errors, warnings, featuresLintFailOn)
+	},
+}
+
+// lintFailure returns the error failing the command when findings reach failOn
+func lintFailure(errors, warnings int, failOn string) error {
+	switch {
+	case failOn == izanami.LintSeverityError && errors > 0:
+		return withExitCode(ExitLint, fmt.Errorf("lint failed: %d error(s)", errors))
+	case failOn == izanami.LintSeverityWarning && errors+warnings > 0:
+		return withExitCode(ExitLint, fmt.Errorf("lint failed: %d error(s), %d warning(s)", errors, warnings))
+	}
+	return nil
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresLintCmd)
+
+	featuresLintCmd.Flags().StringToStringVar(&featuresLintRules, "rule", nil, "Rule severity as name=error|warning|off (repeatable)")
+	featuresLintCmd.Flags().StringVar(&featuresLintStaleAfter, "stale-after", "180d", "Age from which disabled features are reported by disabled-stale (e.g. 180d)")
+	featuresLintCmd.Flags().StringVar(&featuresLintFailOn, "fail-on", "error", "Exit with code 6 on findings of this severity or above: error, warning or never")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintFailure(t *testing.T) {
+	assert.NoError(t, lintFailure(0, 3, "error"))
+	assert.Equal(t, ExitLint, exitCode(lintFailure(1, 0, "error")))
+	assert.Equal(t, ExitLint, exitCode(lintFailure(0, 1, "warning")))
+	assert.NoError(t, lintFailure(2, 2, "never"))
+}
+
+func TestFeaturesLint(t *testing.T) {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"beta-ui","project":"shop","description":"Beta UI","enabled":true,"tags":["ghost"]}]`)
+		case "/api/admin/tenants/acme/tags":
+			io.WriteString(w, `[]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresLintRules, featuresLintFailOn = nil, "error"
+		featuresLintCmd.SetOut(nil)
+	})
+	outputFormat = "table"
+	featuresLintStaleAfter, featuresLintFailOn = "180d", "error"
+
+	var buf bytes.Buffer
+	featuresLintCmd.SetOut(&buf)
+	err := featuresLintCmd.RunE(featuresLintCmd, nil)
+	require.Error(t, err)
+	assert.Equal(t, ExitLint, exitCode(err))
+	assert.Contains(t, buf.String(), "tag ghost does not exist")
+	assert.Contains(t, buf.String(), "1 error(s), 0 warning(s)")
+
+	buf.Reset()
+	featuresLintRules = map[string]string{"unknown-tag": "warning"}
+	require.NoError(t, featuresLintCmd.RunE(featuresLintCmd, nil))
+	assert.Contains(t, buf.String(), "0 error(s), 1 warning(s)")
+
+	featuresLintRules = map[string]string{"unknown": "off"}
+	assert.EqualError(t, featuresLintCmd.RunE(featuresLintCmd, nil), `unknown lint rule "unknown"`)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesListCmd_NDJSON(t *testing.T) {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[
+			{"id": "f1", "name": "a", "project": "shop"},
+			{"id": "f2", "name": "b", "project": "web"},
+			{"id": "f3", "name": "c", "project": "shop"}
+		]`)
+	})
+	cfg.Project = "shop"
+	outputFormat = "ndjson"
+
+	var buf bytes.Buffer
+	featuresListCmd.SetOut(&buf)
+	defer featuresListCmd.SetOut(nil)
+	require.NoError(t, featuresListCmd.RunE(featuresListCmd, nil))
+
+	assert.Equal(t, `{"id":"f1","name":"a","project":"shop"}`+"\n"+`{"id":"f3","name":"c","project":"shop"}`+"\n", buf.String())
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+)
+
+var (
+	featuresMetricsFormat string
+	featuresMetricsFile   string
+)
+
+// featuresMetricsCmd exports the flag posture of a tenant for monitoring
+var featuresMetricsCmd = &cobra.Command{
+	Use:         "metrics",
+	Short:       "Export feature counts and server health as Prometheus metrics",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	Long: `Export the number of features by project, tag and state (enabled, disabled,
+archived) and the server health, in the Prometheus text exposition format, so
+that a cron job or a sidecar can feed them to existing dashboards.
+
+Metrics:
+  izanami_features_total{tenant}                  Features of the tenant
+  izanami_features{tenant,project,state}          Features by project and state
+  izanami_features_by_tag{tenant,tag,state}       Features by tag and state
+  izanami_up                                      1 when the server is healthy
+  izanami_health_latency_seconds                  Duration of the health check
+  izanami_build_info{version}                     Server version
+
+An unhealthy server is reported by izanami_up 0, not by a failure; listing the
+features must succeed. With --project, only the features of that project are
+counted. --file writes the metrics atomically, as the node_exporter textfile
+collector expects.
+
+Examples:
+  # Print the metrics
+  iz admin features metrics --tenant my-tenant --format prometheus
+
+  # Cron job feeding the node_exporter textfile collector
+  iz admin features metrics --tenant my-tenant --file /var/lib/node_exporter/izanami.prom
+
+  # Counts as JSON
+  iz admin features metrics --tenant my-tenant --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := featuresMetricsFormat
+		if !cmd.Flags().Changed("format") && outputFormat == "json" {
+			format = "json"
+		}
+		if format != "prometheus" && format != "json" {
+			return fmt.Errorf("invalid --format %q: must be prometheus or json", format)
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		ctx := commandContext()
+		features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures)
+		if err != nil {
+			return err
+		}
+		metrics := izanami.BuildFeatureMetrics(cfg.Tenant, filterFeaturesByProject(features, cfg.Project))
+
+		healthClient, err := newHealthClient(0)
+		if err != nil {
+			return err
+		}
+		sample := checkHealth(ctx, healthClient)
+		metrics.Up = sample.Up
+		metrics.HealthLatencyMs = sample.LatencyMs
+		metrics.Version = sample.Version
+
+		var buf bytes.Buffer
+		if format == "json" {
+			err = output.PrintTo(&buf, metrics, output.JSON)
+		} else {
+			err = metrics.WritePrometheus(&buf)
+		}
+		if err != nil {
+			return err
+		}
+		if featuresMetricsFile == "" {
+			_, err = cmd.OutOrStdout().Write(buf.Bytes())
+			return err
+		}
+		if err := utils.WriteFileAtomic(featuresMetricsFile, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write metrics: %w", err)
+		}
+		fmt.Fprintf(statusOut(cmd), "Metrics of %d feature(s) written to %s\n", metrics.Total, featuresMetricsFile)
+		return nil
+	},
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresMetricsCmd)
+
+	featuresMetricsCmd.Flags().StringVar(&featuresMetricsFormat, "format", "prometheus", "Metrics format: prometheus or json")
+	featuresMetricsCmd.Flags().StringVar(&featuresMetricsFile, "file", "", "Write the metrics to this file, atomically, instead of stdout")
+}
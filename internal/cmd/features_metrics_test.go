@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupFeaturesMetricsTest serves two features and a health status
+func setupFeaturesMetricsTest(t *testing.T, healthy bool) *bytes.Buffer {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"checkout","project":"shop","enabled":true,"tags":["pay"]},{"id":"f2","name":"banner","project":"web","enabled":false}]`)
+		case "/api/_health":
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, `{"database":true,"version":"2.9.0"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresMetricsFormat, featuresMetricsFile = "prometheus", ""
+		featuresMetricsCmd.SetOut(nil)
+	})
+	outputFormat = "table"
+
+	var buf bytes.Buffer
+	featuresMetricsCmd.SetOut(&buf)
+	return &buf
+}
+
+func TestFeaturesMetricsCmd_Prometheus(t *testing.T) {
+	buf := setupFeaturesMetricsTest(t, true)
+	require.NoError(t, featuresMetricsCmd.RunE(featuresMetricsCmd, nil))
+
+	out := buf.String()
+	assert.Contains(t, out, `izanami_features_total{tenant="acme"} 2`)
+	assert.Contains(t, out, `izanami_features{tenant="acme",project="shop",state="enabled"} 1`)
+	assert.Contains(t, out, `izanami_features{tenant="acme",project="web",state="disabled"} 1`)
+	assert.Contains(t, out, `izanami_features_by_tag{tenant="acme",tag="pay",state="enabled"} 1`)
+	assert.Contains(t, out, "izanami_up 1")
+	assert.Contains(t, out, `izanami_build_info{version="2.9.0"} 1`)
+}
+
+func TestFeaturesMetricsCmd_UnhealthyServerToFile(t *testing.T) {
+	buf := setupFeaturesMetricsTest(t, false)
+	featuresMetricsFile = filepath.Join(t.TempDir(), "izanami.prom")
+	require.NoError(t, featuresMetricsCmd.RunE(featuresMetricsCmd, nil))
+
+	data, err := os.ReadFile(featuresMetricsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "izanami_up 0")
+	assert.NotContains(t, string(data), "izanami_build_info")
+	assert.Contains(t, buf.String(), "Metrics of 2 feature(s) written to")
+}
+
+func TestFeaturesMetricsCmd_InvalidFormat(t *testing.T) {
+	setupFeaturesMetricsTest(t, true)
+	featuresMetricsFormat = "csv"
+	assert.ErrorContains(t, featuresMetricsCmd.RunE(featuresMetricsCmd, nil), `invalid --format "csv"`)
+}
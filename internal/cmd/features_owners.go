@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresOwner         string
+	featuresJira          string
+	featuresOwnersMissing bool
+)
+
+// featuresOwnersCmd reports the owners of the features of a tenant
+var featuresOwnersCmd = &cobra.Command{
+	Use:         "owners",
+	Short:       "Report feature owners",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	Long: `Report the owner and tracking issue of the features of a tenant (or of one
+project with --project), to keep every flag accountable.
+
+Ownership follows a metadata convention: the owning team is stored under the
+"owner" key of the feature metadata and its issue under "jira". Set them with
+'iz admin features owners set', and list the features of a team with
+'iz admin features list --owner <team>'.
+
+Examples:
+  # Owners of all features
+  iz admin features owners --tenant my-tenant
+
+  # Features nobody owns
+  iz admin features owners --tenant my-tenant --missing
+
+  # Assign a feature
+  iz admin features owners set checkout-v2 --project shop --owner team-payments --jira PAY-123`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		features, err := izanami.ListFeatures(client, commandContext(), cfg.Tenant, featureTag, izanami.ParseFeatures)
+		if err != nil {
+			return err
+		}
+		features = filterFeaturesByProject(features, cfg.Project)
+
+		owned := 0
+		ownerships := []izanami.FeatureOwnership{}
+		for _, feature := range features {
+			ownership := izanami.OwnershipOf(feature)
+			if ownership.Owner != "" {
+				owned++
+			}
+			if (featuresOwnersMissing && ownership.Owner != "") || (featuresOwner != "" && !izanami.OwnedBy(feature, featuresOwner)) {
+				continue
+			}
+			ownerships = append(ownerships, ownership)
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), ownerships, output.JSON)
+		}
+		if len(ownerships) > 0 {
+			views := make([]izanami.FeatureOwnershipTableView, len(ownerships))
+			for i, ownership := range ownerships {
+				views[i] = ownership.ToTableView()
+			}
+			if err := output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat)); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(statusOut(cmd), "%d of %d feature(s) without owner\n", len(features)-owned, len(features))
+		return nil
+	},
+}
+
+// featuresOwnersGetCmd shows the owner of a feature
+var featuresOwnersGetCmd = &cobra.Command{
+	Use:         "get <feature-id-or-name>",
+	Short:       "Show the owner of a feature",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
+	Args:        cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+		feature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.Unmarshal[izanami.Feature]())
+		if err != nil {
+			return err
+		}
+
+		ownership := izanami.OwnershipOf(feature)
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), ownership, output.JSON)
+		}
+		return output.PrintTo(cmd.OutOrStdout(), []izanami.FeatureOwnershipTableView{ownership.ToTableView()}, output.Format(outputFormat))
+	},
+}
+
+// featuresOwnersSetCmd sets the owner of a feature
+var featuresOwnersSetCmd = &cobra.Command{
+	Use:         "set <feature-id-or-name>",
+	Short:       "Set the owner of a feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Set the owning team and tracking issue of a feature in its metadata. Flags not
+given are left unchanged; an empty value removes the entry.
+
+Examples:
+  iz admin features owners set checkout-v2 --project shop --owner team-payments --jira PAY-123
+  iz admin features owners set checkout-v2 --project shop --jira ""`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		var owner, jira *string
+		if cmd.Flags().Changed("owner") {
+			owner = &featuresOwner
+		}
+		if cmd.Flags().Changed("jira") {
+			jira = &featuresJira
+		}
+		if owner == nil && jira == nil {
+			return fmt.Errorf("--owner or --jira is required")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+		ownership, err := izanami.SetFeatureOwnership(client, ctx, cfg.Tenant, featureID, owner, jira)
+		if err != nil {
+			return err
+		}
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"id": featureID, "name": ownership.Name, "project": ownership.Project})
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), ownership, output.JSON)
+		}
+		fmt.Fprintf(statusOut(cmd), "✅ Ownership of %s updated\n", ownership.Name)
+		return output.PrintTo(cmd.OutOrStdout(), []izanami.FeatureOwnershipTableView{ownership.ToTableView()}, output.Format(outputFormat))
+	},
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresOwnersCmd)
+	featuresOwnersCmd.AddCommand(featuresOwnersGetCmd)
+	featuresOwnersCmd.AddCommand(featuresOwnersSetCmd)
+
+	featuresOwnersCmd.Flags().StringVar(&featuresOwner, "owner", "", "Only show the features of this owner")
+	featuresOwnersCmd.Flags().BoolVar(&featuresOwnersMissing, "missing", false, "Only show the features without owner")
+	featuresOwnersCmd.Flags().StringVar(&featureTag, "tag", "", "Filter by tag (server-side)")
+	featuresOwnersCmd.MarkFlagsMutuallyExclusive("owner", "missing")
+	featuresOwnersSetCmd.Flags().StringVar(&featuresOwner, "owner", "", "Owning team (empty to remove)")
+	featuresOwnersSetCmd.Flags().StringVar(&featuresJira, "jira", "", "Tracking issue key (empty to remove)")
+	featuresListCmd.Flags().StringVar(&featuresOwner, "owner", "", "Only list the features of this owner (metadata \"owner\")")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesOwnersCmd_Missing(t *testing.T) {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[
+			{"id": "1", "name": "checkout", "project": "shop", "metadata": {"owner": "team-payments", "jira": "PAY-1"}},
+			{"id": "2", "name": "search", "project": "shop"},
+			{"id": "3", "name": "banner", "project": "web", "metadata": {}}
+		]`)
+	})
+
+	defer func() {
+		featuresOwnersMissing, featuresOwner = false, ""
+	}()
+	cfg.Project = "shop"
+	outputFormat = "table"
+	featuresOwnersMissing = true
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, featuresOwnersCmd.RunE(cmd, nil))
+
+	assert.Contains(t, out.String(), "search")
+	assert.NotContains(t, out.String(), "checkout")
+	assert.NotContains(t, out.String(), "banner")
+	assert.Contains(t, out.String(), "1 of 2 feature(s) without owner\n")
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresRenameDryRun bool
+	featuresRenameForce  bool
+)
+
+// featuresRenameCmd renames a feature and tracks the objects referencing it
+var featuresRenameCmd = &cobra.Command{
+	Use:         "rename <feature-id-or-name> <new-name>",
+	Short:       "Rename a feature and update its references",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Rename a feature, then check the objects of the tenant referencing it.
+
+References are listed before the rename:
+  overload  Overloads of the feature in project and global contexts. They
+            follow the feature; any overload lost by the rename is set again
+            under the new name.
+  webhook   Webhooks listening to the feature (by ID, kept as is), and webhooks
+            whose body template compares with the old name in a string literal,
+            which is rewritten. Other mentions of the old name are reported for
+            review.
+
+Clients evaluating the feature by name must be updated separately.
+
+Examples:
+  # List the objects a rename would affect
+  iz admin features rename old-checkout new-checkout --project shop --dry-run
+
+  # Rename without prompting
+  iz admin features rename old-checkout new-checkout --project shop --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		rename, err := izanami.PlanFeatureRename(client, ctx, cfg.Tenant, featureID, args[1])
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" && featuresRenameDryRun {
+			return output.PrintTo(cmd.OutOrStdout(), rename, output.JSON)
+		}
+		out := statusOut(cmd)
+		printFeatureRename(out, rename)
+		if featuresRenameDryRun {
+			return nil
+		}
+
+		if !featuresRenameForce {
+			if !confirmAction(cmd, fmt.Sprintf("Rename feature %s to %s?", rename.From, rename.To)) {
+				return nil
+			}
+		}
+
+		if err := rename.Apply(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Feature renamed: %s -> %s (%d reference(s) updated, %d restored, %d to review)\n",
+			rename.From, rename.To, rename.Count(izanami.FeatureRefUpdate), rename.Count(izanami.FeatureRefRestored), rename.Count(izanami.FeatureRefReview))
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"id": rename.FeatureID, "name": rename.To, "previousName": rename.From, "project": rename.Project})
+		return nil
+	},
+}
+
+// printFeatureRename shows a planned rename with the references found
+func printFeatureRename(w io.Writer, rename *izanami.FeatureRename) {
+	fmt.Fprintf(w, "Rename feature %s -> %s (project %s)\n", rename.From, rename.To, rename.Project)
+	if len(rename.References) == 0 {
+		fmt.Fprintln(w, "No overloads or webhooks reference the feature")
+		return
+	}
+
+	fmt.Fprintln(w, "References:")
+	for _, ref := range rename.References {
+		line := fmt.Sprintf("%-8s %-8s %s: %s", ref.Action, ref.Kind, ref.Name, ref.Detail)
+		switch ref.Action {
+		case izanami.FeatureRefUpdate:
+			fmt.Fprintln(w, color.YellowString("  ~ %s", line))
+		case izanami.FeatureRefReview:
+			fmt.Fprintln(w, color.RedString("  ! %s", line))
+		default:
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresRenameCmd)
+	featuresRenameCmd.ValidArgsFunction = completeFeatureNames
+	featuresRenameCmd.Flags().BoolVar(&featuresRenameDryRun, "dry-run", false, "List the affected objects without renaming")
+	featuresRenameCmd.Flags().BoolVarP(&featuresRenameForce, "force", "f", false, "Skip confirmation prompt")
+}
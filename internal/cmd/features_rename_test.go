@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesRename(t *testing.T) {
+	var writes []string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			io.WriteString(w, `{}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"11111111-1111-1111-1111-111111111111","name":"checkout","project":"shop","enabled":true}]`)
+		case "/api/admin/tenants/acme/features/11111111-1111-1111-1111-111111111111":
+			io.WriteString(w, `{"id":"11111111-1111-1111-1111-111111111111","name":"checkout","project":"shop","enabled":true}`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[]`)
+		case "/api/admin/tenants/acme/webhooks":
+			io.WriteString(w, `[{"id":"w1","name":"audit","url":"https://audit.example.com","enabled":true,"bodyTemplate":"checkout: {{payload.name}}"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresRenameDryRun, featuresRenameForce = false, false
+		featuresRenameCmd.SetOut(nil)
+	})
+	cfg.Project = "shop"
+	outputFormat = "table"
+
+	var buf bytes.Buffer
+	featuresRenameCmd.SetOut(&buf)
+	featuresRenameDryRun = true
+	require.NoError(t, featuresRenameCmd.RunE(featuresRenameCmd, []string{"checkout", "cart"}))
+	assert.Contains(t, buf.String(), "Rename feature checkout -> cart (project shop)")
+	assert.Contains(t, buf.String(), "review   webhook  audit: body template mentions checkout")
+	assert.Empty(t, writes)
+
+	buf.Reset()
+	featuresRenameDryRun, featuresRenameForce = false, true
+	require.NoError(t, featuresRenameCmd.RunE(featuresRenameCmd, []string{"checkout", "cart"}))
+	assert.Contains(t, buf.String(), "Feature renamed: checkout -> cart (0 reference(s) updated, 0 restored, 1 to review)")
+	assert.Equal(t, []string{"PUT /api/admin/tenants/acme/features/11111111-1111-1111-1111-111111111111"}, writes)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresRevertTo     int64
+	featuresRevertDryRun bool
+	featuresRevertForce  bool
+)
+
+// featuresRevertCmd restores a feature to its state after an audit event
+var featuresRevertCmd = &cobra.Command{
+	Use:         "revert <feature-id-or-name> --to <event-id>",
+	Short:       "Restore a feature to its state after an audit event",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Restore the activation of a feature to its state right after an audit event:
+enabled, conditions, result type and value, at the root and in every context.
+Overloads added since the event are deleted, those deleted since are set again.
+Name, description, tags and metadata are left as they are.
+
+The changes are previewed and confirmed before being applied. Find the event
+IDs with 'iz admin features history <feature>'.
+
+Examples:
+  # Preview the changes restoring the state after event 1234
+  iz admin features revert my-feature --project shop --to 1234 --dry-run
+
+  # Undo the last change: revert to the event before it
+  iz admin features history my-feature --project shop --limit 2
+  iz admin features revert my-feature --project shop --to <second event id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("to") {
+			return fmt.Errorf("--to is required (see 'iz admin features history %s')", args[0])
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		revert, err := izanami.PlanFeatureRevert(client, ctx, cfg.Tenant, featureID, featuresRevertTo)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" && featuresRevertDryRun {
+			return output.PrintTo(cmd.OutOrStdout(), revert, output.JSON)
+		}
+		out := statusOut(cmd)
+		printFeatureRevert(out, revert)
+		if len(revert.Changes) == 0 || featuresRevertDryRun {
+			return nil
+		}
+
+		if !featuresRevertForce {
+			if !confirmAction(cmd, fmt.Sprintf("Revert feature %s to event %d?", revert.Name, revert.EventID)) {
+				return nil
+			}
+		}
+
+		if err := revert.Apply(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "✅ Feature %s reverted to event %d (%d field(s) restored)\n", revert.Name, revert.EventID, len(revert.Changes))
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"id": revert.FeatureID, "name": revert.Name, "project": revert.Project, "revertedTo": revert.EventID})
+		return nil
+	},
+}
+
+// printFeatureRevert shows the changes of a planned revert
+func printFeatureRevert(w io.Writer, revert *izanami.FeatureRevert) {
+	fmt.Fprintf(w, "Revert feature %s (project %s) to event %d: %s by %s at %s\n",
+		revert.Name, revert.Project, revert.EventID, revert.EventType, revert.User, revert.EmittedAt)
+	if len(revert.Changes) == 0 {
+		fmt.Fprintln(w, "The feature is already in this state, nothing to revert")
+		return
+	}
+	for _, change := range revert.Changes {
+		if change.From != "" {
+			fmt.Fprintln(w, color.RedString("-   %s: %s", change.Field, change.From))
+		}
+		if change.To != "" {
+			fmt.Fprintln(w, color.GreenString("+   %s: %s", change.Field, change.To))
+		}
+	}
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresRevertCmd)
+	featuresRevertCmd.ValidArgsFunction = completeFeatureNames
+	featuresRevertCmd.Flags().Int64Var(&featuresRevertTo, "to", 0, "ID of the audit event to restore the state of (required)")
+	featuresRevertCmd.Flags().BoolVar(&featuresRevertDryRun, "dry-run", false, "Show the changes without applying them")
+	featuresRevertCmd.Flags().BoolVarP(&featuresRevertForce, "force", "f", false, "Skip confirmation prompt")
+}
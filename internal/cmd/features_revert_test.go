@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeaturesRevert_RequiresTo(t *testing.T) {
+	origCfg := cfg
+	t.Cleanup(func() { cfg = origCfg })
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost", JwtToken: "token", Timeout: 5, Tenant: "acme"}
+
+	err := featuresRevertCmd.RunE(featuresRevertCmd, []string{"checkout"})
+	assert.ErrorContains(t, err, "--to is required")
+}
+
+func TestPrintFeatureRevert(t *testing.T) {
+	revert := &izanami.FeatureRevert{
+		Name: "checkout", Project: "shop", EventID: 7, EventType: "FEATURE_UPDATED", User: "alice", EmittedAt: "2026-10-01T10:00:00Z",
+		Changes: []izanami.FieldChange{{Field: "enabled", From: "true", To: "false"}, {Field: "prod.enabled", From: "true"}},
+	}
+
+	var buf bytes.Buffer
+	printFeatureRevert(&buf, revert)
+	assert.Equal(t, `Revert feature checkout (project shop) to event 7: FEATURE_UPDATED by alice at 2026-10-01T10:00:00Z
+-   enabled: true
++   enabled: false
+-   prod.enabled: true
+`, buf.String())
+
+	buf.Reset()
+	revert.Changes = nil
+	printFeatureRevert(&buf, revert)
+	assert.Contains(t, buf.String(), "nothing to revert")
+}
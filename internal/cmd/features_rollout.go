@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	rolloutPercentage    int
+	rolloutUserAttribute string
+	rolloutRampTo        int
+	rolloutRampStep      int
+	rolloutRampInterval  time.Duration
+	rolloutRampDryRun    bool
+)
+
+// ruleTypeUserPercentage is the activation rule type used for percentage rollouts
+const ruleTypeUserPercentage = "UserPercentage"
+
+// RolloutStatus describes the percentage rollout of a feature
+type RolloutStatus struct {
+	Feature    string `json:"feature"`
+	Project    string `json:"project"`
+	Enabled    bool   `json:"enabled"`
+	Percentage string `json:"percentage"`
+}
+
+// featuresRolloutCmd groups the percentage rollout commands
+var featuresRolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Manage the percentage rollout of a feature",
+	Long: `Activate a feature for a percentage of users.
+
+Izanami assigns each user to a stable bucket computed from the user ID, so a
+user who sees the feature at 10% still sees it at 25%.
+
+Rollouts apply to the feature itself; use 'iz admin overloads set' to change
+the conditions of a feature in a context.
+
+Examples:
+  # Activate a feature for 25% of users
+  iz admin features rollout set my-feature --percentage 25 --project my-project
+
+  # Show the current rollout
+  iz admin features rollout status my-feature --project my-project
+
+  # Ramp up to 50% by steps of 10% every hour
+  iz admin features rollout ramp my-feature --to 50 --step 10 --interval 1h`,
+}
+
+// featuresRolloutSetCmd sets the rollout percentage of a feature
+var featuresRolloutSetCmd = &cobra.Command{
+	Use:         "set <feature-id-or-name>",
+	Short:       "Set the rollout percentage of a feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Activate a feature for a percentage of users.
+
+If the feature already has a percentage rule it is updated, otherwise a new
+condition is added. Other conditions (user lists, schedules) are preserved.
+The feature must be enabled for the rollout to take effect.
+
+Examples:
+  # Activate a feature for 25% of users
+  iz admin features rollout set my-feature --percentage 25 --project my-project`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := rejectRolloutContext(cmd); err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("percentage") {
+			return fmt.Errorf("--percentage is required")
+		}
+		if err := validateRolloutPercentage("--percentage", rolloutPercentage); err != nil {
+			return err
+		}
+		if rolloutUserAttribute != "id" {
+			return fmt.Errorf("unsupported --user-attribute %q: Izanami buckets users by user ID only", rolloutUserAttribute)
+		}
+
+		return setRolloutPercentage(cmd, args[0], rolloutPercentage)
+	},
+}
+
+// featuresRolloutStatusCmd shows the rollout percentage of a feature
+var featuresRolloutStatusCmd = &cobra.Command{
+	Use:         "status <feature-id-or-name>",
+	Short:       "Show the rollout percentage of a feature",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
+	Long: `Show whether a feature is enabled and for which percentage of users.
+
+Examples:
+  iz admin features rollout status my-feature --project my-project`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := rejectRolloutContext(cmd); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		feature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.ParseFeature)
+		if err != nil {
+			return err
+		}
+
+		status := RolloutStatus{
+			Feature:    feature.Name,
+			Project:    feature.Project,
+			Enabled:    feature.Enabled,
+			Percentage: describeRollout(feature.Conditions),
+		}
+		return output.PrintTo(cmd.OutOrStdout(), status, output.Format(outputFormat))
+	},
+}
+
+// featuresRolloutRampCmd gradually increases (or decreases) the rollout percentage
+var featuresRolloutRampCmd = &cobra.Command{
+	Use:         "ramp <feature-id-or-name>",
+	Short:       "Gradually change the rollout percentage",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Change the rollout percentage step by step, waiting between steps.
+
+The ramp starts from the current percentage (100 if the feature has no
+conditions, as it is active for every user, and 0 if its conditions have no
+percentage rule) and moves towards --to by --step every --interval. The CLI
+keeps running until the target is reached; press Ctrl+C to stop the ramp at
+the current step.
+
+Examples:
+  # Ramp up to 50% by steps of 10% every hour
+  iz admin features rollout ramp my-feature --to 50 --step 10 --interval 1h
+
+  # Show the planned steps without changing anything
+  iz admin features rollout ramp my-feature --to 100 --step 25 --dry-run
+
+  # Ramp down
+  iz admin features rollout ramp my-feature --to 0 --step 20 --interval 10m`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := rejectRolloutContext(cmd); err != nil {
+			return err
+		}
+		if err := validateRolloutPercentage("--to", rolloutRampTo); err != nil {
+			return err
+		}
+		if rolloutRampStep <= 0 {
+			return fmt.Errorf("--step must be positive")
+		}
+		if rolloutRampInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(commandContext())
+		defer cancel()
+
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		feature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.ParseFeature)
+		if err != nil {
+			return err
+		}
+
+		current := rampStartPercentage(feature.Conditions)
+		steps := rampSteps(current, rolloutRampTo, rolloutRampStep)
+		if len(steps) == 0 {
+			fmt.Fprintf(statusOut(cmd), "Feature %s is already at %d%%\n", feature.Name, current)
+			return nil
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Ramping %s from %d%% to %d%% in %d step(s), every %s\n", feature.Name, current, rolloutRampTo, len(steps), rolloutRampInterval)
+		if rolloutRampDryRun {
+			for i, p := range steps {
+				fmt.Fprintf(cmd.OutOrStdout(), "Step %d: %d%% (at +%s)\n", i+1, p, time.Duration(i)*rolloutRampInterval)
+			}
+			return nil
+		}
+
+		// Handle Ctrl+C gracefully
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			select {
+			case <-sigCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		for i, p := range steps {
+			if i > 0 {
+				select {
+				case <-ctx.Done():
+					fmt.Fprintf(statusOut(cmd), "\nRamp stopped at %d%%\n", steps[i-1])
+					return nil
+				case <-time.After(rolloutRampInterval):
+				}
+			}
+			if err := setRolloutPercentage(cmd, featureID, p); err != nil {
+				return fmt.Errorf("ramp failed at step %d (%d%%): %w", i+1, p, err)
+			}
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Ramp completed: %s is at %d%%\n", feature.Name, rolloutRampTo)
+		return nil
+	},
+}
+
+// setRolloutPercentage updates the percentage rule of a feature
+func setRolloutPercentage(cmd *cobra.Command, featureIDOrName string, percentage int) error {
+	return updateFeatureConditions(cmd, featureIDOrName, func(conditions []interface{}) ([]interface{}, error) {
+		return applyRolloutPercentage(conditions, percentage), nil
+	}, fmt.Sprintf("Rollout set to %d%% for feature", percentage))
+}
+
+// rejectRolloutContext fails when --context is given: rollouts change the
+// conditions of the feature itself, not of its overload in a context
+func rejectRolloutContext(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("context") {
+		return fmt.Errorf("--context is not supported: the rollout applies to the feature in every context without an overload (use 'iz admin overloads set' for a context)")
+	}
+	return nil
+}
+
+// validateRolloutPercentage checks that a percentage is between 0 and 100
+func validateRolloutPercentage(flag string, percentage int) error {
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("%s must be between 0 and 100", flag)
+	}
+	return nil
+}
+
+// applyRolloutPercentage updates every percentage rule, or adds one if there is none
+func applyRolloutPercentage(conditions []interface{}, percentage int) []interface{} {
+	updated := false
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rule, ok := condition["rule"].(map[string]interface{})
+		if !ok || rule["type"] != ruleTypeUserPercentage {
+			continue
+		}
+		rule["percentage"] = percentage
+		updated = true
+	}
+
+	if !updated {
+		conditions = append(conditions, map[string]interface{}{
+			"rule": map[string]interface{}{
+				"type":       ruleTypeUserPercentage,
+				"percentage": percentage,
+			},
+		})
+	}
+	return conditions
+}
+
+// currentRolloutPercentage returns the percentage of the first percentage rule
+func currentRolloutPercentage(conditions []izanami.ActivationCondition) (int, bool) {
+	for _, c := range conditions {
+		if c.Rule != nil && c.Rule.Type == ruleTypeUserPercentage {
+			return int(c.Rule.Percentage), true
+		}
+	}
+	return 0, false
+}
+
+// rampStartPercentage returns the percentage a ramp starts from: a feature
+// without conditions is active for every user
+func rampStartPercentage(conditions []izanami.ActivationCondition) int {
+	if len(conditions) == 0 {
+		return 100
+	}
+	p, _ := currentRolloutPercentage(conditions)
+	return p
+}
+
+// describeRollout summarizes which share of users the conditions target
+func describeRollout(conditions []izanami.ActivationCondition) string {
+	if p, ok := currentRolloutPercentage(conditions); ok {
+		return fmt.Sprintf("%d%%", p)
+	}
+	if len(conditions) == 0 {
+		return "100% (no conditions)"
+	}
+	return "no percentage rule"
+}
+
+// rampSteps lists the percentages to apply to go from one value to another
+func rampSteps(from, to, step int) []int {
+	var steps []int
+	for p := from; p != to; {
+		if p < to {
+			p = min(p+step, to)
+		} else {
+			p = max(p-step, to)
+		}
+		steps = append(steps, p)
+	}
+	return steps
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresRolloutCmd)
+	featuresRolloutCmd.AddCommand(featuresRolloutSetCmd)
+	featuresRolloutCmd.AddCommand(featuresRolloutStatusCmd)
+	featuresRolloutCmd.AddCommand(featuresRolloutRampCmd)
+	for _, c := range []*cobra.Command{featuresRolloutSetCmd, featuresRolloutStatusCmd, featuresRolloutRampCmd} {
+		c.ValidArgsFunction = completeFeatureNames
+	}
+
+	featuresRolloutSetCmd.Flags().IntVar(&rolloutPercentage, "percentage", 0, "Percentage of users for whom the feature is active (0-100)")
+	featuresRolloutSetCmd.Flags().StringVar(&rolloutUserAttribute, "user-attribute", "id", "User attribute used for bucketing (Izanami supports: id)")
+
+	featuresRolloutRampCmd.Flags().IntVar(&rolloutRampTo, "to", 100, "Target percentage (0-100)")
+	featuresRolloutRampCmd.Flags().IntVar(&rolloutRampStep, "step", 10, "Percentage change per step")
+	featuresRolloutRampCmd.Flags().DurationVar(&rolloutRampInterval, "interval", time.Hour, "Delay between steps")
+	featuresRolloutRampCmd.Flags().BoolVar(&rolloutRampDryRun, "dry-run", false, "Show the planned steps without applying them")
+}
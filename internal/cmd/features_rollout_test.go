@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestApplyRolloutPercentage_AddsRule(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{"rule": map[string]interface{}{"type": "UserList", "users": []interface{}{"alice"}}},
+	}
+
+	conditions := applyRolloutPercentage(existing, 25)
+
+	require.Len(t, conditions, 2)
+	rule := conditions[1].(map[string]interface{})["rule"].(map[string]interface{})
+	assert.Equal(t, ruleTypeUserPercentage, rule["type"])
+	assert.Equal(t, 25, rule["percentage"])
+}
+
+func TestApplyRolloutPercentage_UpdatesRule(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{
+			"period": map[string]interface{}{"timezone": "UTC"},
+			"rule":   map[string]interface{}{"type": "UserPercentage", "percentage": 10.0},
+		},
+	}
+
+	conditions := applyRolloutPercentage(existing, 50)
+
+	require.Len(t, conditions, 1)
+	condition := conditions[0].(map[string]interface{})
+	assert.Contains(t, condition, "period")
+	assert.Equal(t, 50, condition["rule"].(map[string]interface{})["percentage"])
+}
+
+func TestDescribeRollout(t *testing.T) {
+	assert.Equal(t, "100% (no conditions)", describeRollout(nil))
+	assert.Equal(t, "no percentage rule", describeRollout([]izanami.ActivationCondition{
+		{Rule: &izanami.ActivationRule{Type: "UserList", Users: []string{"alice"}}},
+	}))
+	assert.Equal(t, "30%", describeRollout([]izanami.ActivationCondition{
+		{Rule: &izanami.ActivationRule{Type: "UserPercentage", Percentage: 30}},
+	}))
+}
+
+func TestRampStartPercentage(t *testing.T) {
+	// Without conditions the feature is active for everyone: ramping from 0
+	// would cut it to 10% at once
+	assert.Equal(t, 100, rampStartPercentage(nil))
+	assert.Empty(t, rampSteps(rampStartPercentage(nil), 100, 10))
+	assert.Equal(t, []int{75, 50}, rampSteps(rampStartPercentage(nil), 50, 25))
+
+	assert.Equal(t, 0, rampStartPercentage([]izanami.ActivationCondition{
+		{Rule: &izanami.ActivationRule{Type: "UserList", Users: []string{"alice"}}},
+	}))
+	assert.Equal(t, 30, rampStartPercentage([]izanami.ActivationCondition{
+		{Rule: &izanami.ActivationRule{Type: "UserPercentage", Percentage: 30}},
+	}))
+}
+
+func TestRampSteps(t *testing.T) {
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, rampSteps(0, 50, 10))
+	assert.Equal(t, []int{50, 75, 100}, rampSteps(25, 100, 25))
+	assert.Equal(t, []int{40, 45}, rampSteps(30, 45, 10))
+	assert.Equal(t, []int{30, 10, 0}, rampSteps(50, 0, 20))
+	assert.Empty(t, rampSteps(40, 40, 10))
+}
+
+func TestRejectRolloutContext(t *testing.T) {
+	cmd := &cobra.Command{Use: "ramp"}
+	cmd.Flags().String("context", "", "")
+	assert.NoError(t, rejectRolloutContext(cmd))
+
+	require.NoError(t, cmd.Flags().Set("context", "prod"))
+	assert.ErrorContains(t, rejectRolloutContext(cmd), "--context is not supported")
+}
+
+func TestFeaturesRolloutCmd_SetIsASubcommand(t *testing.T) {
+	assert.False(t, featuresRolloutCmd.Runnable(), "rollout only groups its subcommands")
+	found, args, err := featuresRolloutCmd.Find([]string{"set", "status"})
+	require.NoError(t, err)
+	assert.Equal(t, featuresRolloutSetCmd, found)
+	assert.Equal(t, []string{"status"}, args, "a feature named status can be set")
+}
+
+func TestFeaturesRolloutRampCmd_NoConditionsStartsAt100(t *testing.T) {
+	const id = "11111111-1111-1111-1111-111111111111"
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET /api/admin/tenants/acme/features/"+id, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id":"`+id+`","name":"checkout","project":"shop","enabled":true,"conditions":[]}`)
+	})
+
+	t.Cleanup(func() {
+		rolloutRampTo, rolloutRampStep, rolloutRampDryRun = 100, 10, false
+		featuresRolloutRampCmd.SetOut(nil)
+	})
+	var buf bytes.Buffer
+	featuresRolloutRampCmd.SetOut(&buf)
+
+	rolloutRampTo, rolloutRampStep, rolloutRampDryRun = 100, 10, true
+	require.NoError(t, featuresRolloutRampCmd.RunE(featuresRolloutRampCmd, []string{id}))
+	assert.Contains(t, buf.String(), "Feature checkout is already at 100%")
+
+	// Ramping down starts from 100%, not from 0%
+	buf.Reset()
+	rolloutRampTo, rolloutRampStep = 50, 25
+	require.NoError(t, featuresRolloutRampCmd.RunE(featuresRolloutRampCmd, []string{id}))
+	assert.Contains(t, buf.String(), "from 100% to 50% in 2 step(s)")
+	assert.Contains(t, buf.String(), "Step 1: 75%")
+	assert.NotContains(t, buf.String(), "10%")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	scaffoldTemplate    string
+	scaffoldName        string
+	scaffoldDescription string
+	scaffoldParams      map[string]string
+	scaffoldDryRun      bool
+)
+
+// featuresScaffoldCmd creates a feature from a template
+var featuresScaffoldCmd = &cobra.Command{
+	Use:         "scaffold",
+	Short:       "Create a feature from a template",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/projects/:project/features"},
+	Long: `Create a feature from a template, filling its placeholders from flags.
+
+Built-in templates:
+  boolean-kill-switch   Enabled for everyone until turned off during an incident
+  percentage-rollout    Enabled for --set percentage=N % of users (default 10)
+  date-window           Enabled between --set from=<RFC 3339> and --set to=<RFC 3339>
+
+User-defined templates are YAML or JSON files of the config dir, in
+templates/features/<name>.yaml; they override built-in templates of the same
+name. A template declares its parameters and the feature JSON, whose strings
+may contain {{param}} placeholders. {{name}} and {{description}} are always
+available:
+
+  description: Feature of the checkout team
+  params:
+    percentage:
+      type: number       # string (default), number or boolean
+      default: "5"       # parameters without default are required
+  feature:
+    name: "{{name}}"
+    description: "{{description}}"
+    enabled: true
+    resultType: boolean
+    conditions:
+      - rule: {type: UserPercentage, percentage: "{{percentage}}"}
+    metadata: {owner: team-checkout}
+
+Examples:
+  # Create a kill switch
+  iz admin features scaffold --template boolean-kill-switch --name pay-disable-fallback --project payments
+
+  # Preview a 25% rollout without creating it
+  iz admin features scaffold --template percentage-rollout --name new-search --project shop --set percentage=25 --dry-run
+
+  # List the available templates
+  iz admin features scaffold templates`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if scaffoldTemplate == "" {
+			return fmt.Errorf("--template is required (see 'iz admin features scaffold templates')")
+		}
+		template, err := izanami.LoadFeatureTemplate(scaffoldTemplate)
+		if err != nil {
+			return err
+		}
+
+		values := map[string]string{izanami.FeatureTemplateParamName: scaffoldName}
+		for name, value := range scaffoldParams {
+			values[name] = value
+		}
+		if cmd.Flags().Changed("description") {
+			values[izanami.FeatureTemplateParamDescription] = scaffoldDescription
+		}
+		payload, err := template.Render(values)
+		if err != nil {
+			return err
+		}
+
+		if scaffoldDryRun {
+			return output.PrintTo(cmd.OutOrStdout(), payload, output.JSON)
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if cfg.Project == "" {
+			return fmt.Errorf("project is required (use --project flag or IZ_PROJECT)")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		if annotation := gitAnnotation(cmd); annotation != nil {
+			izanami.AnnotateFeatureMetadata(payload, nil, annotation)
+		}
+
+		created, err := client.CreateFeature(commandContext(), cfg.Tenant, cfg.Project, payload)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Feature created from template %s: %s\n", template.Name, created.ID)
+		runHooks(cmd, izanami.HookFeatureCreate, map[string]interface{}{"id": created.ID, "name": created.Name, "project": created.Project})
+		return output.PrintTo(cmd.OutOrStdout(), created, output.Format(outputFormat))
+	},
+}
+
+// featuresScaffoldTemplatesCmd lists the feature templates
+var featuresScaffoldTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List the feature templates",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := izanami.ListFeatureTemplates()
+		if err != nil {
+			return err
+		}
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), templates, output.JSON)
+		}
+		rows := make([][]string, len(templates))
+		for i, template := range templates {
+			rows[i] = []string{template.Name, template.Source, formatTemplateParams(template.Params), template.Description}
+		}
+		printGroupedTable(cmd.OutOrStdout(), []string{"NAME", "SOURCE", "PARAMETERS", "DESCRIPTION"}, rows)
+		return nil
+	},
+}
+
+// formatTemplateParams lists template parameters as "name" for required ones
+// and "name=default" for the others
+func formatTemplateParams(params map[string]izanami.FeatureTemplateParam) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		if params[name].Default != "" {
+			names[i] = name + "=" + params[name].Default
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresScaffoldCmd)
+	featuresScaffoldCmd.AddCommand(featuresScaffoldTemplatesCmd)
+
+	featuresScaffoldCmd.Flags().StringVar(&scaffoldTemplate, "template", "", "Template name (required)")
+	featuresScaffoldCmd.Flags().StringVar(&scaffoldName, "name", "", "Feature name (required)")
+	featuresScaffoldCmd.Flags().StringVar(&scaffoldDescription, "description", "", "Feature description")
+	featuresScaffoldCmd.Flags().StringToStringVar(&scaffoldParams, "set", nil, "Template parameter as name=value (repeatable)")
+	featuresScaffoldCmd.Flags().BoolVar(&scaffoldDryRun, "dry-run", false, "Print the generated feature JSON without creating it")
+	featuresScaffoldCmd.Flags().BoolVar(&featuresGitAnnotate, "git-annotate", false, "Record the git branch, commit and author in the feature metadata (default: git-annotations config)")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeaturesScaffoldCmd_DryRun(t *testing.T) {
+	originalConfigDir := izanami.GetConfigDir()
+	configDir := t.TempDir()
+	izanami.SetGetConfigDirFunc(func() string { return configDir })
+	defer izanami.SetGetConfigDirFunc(func() string { return originalConfigDir })
+	defer func() {
+		scaffoldTemplate, scaffoldName, scaffoldParams, scaffoldDryRun = "", "", nil, false
+	}()
+
+	var buf bytes.Buffer
+	featuresScaffoldCmd.SetOut(&buf)
+	defer featuresScaffoldCmd.SetOut(nil)
+
+	scaffoldTemplate, scaffoldName, scaffoldDryRun = "percentage-rollout", "new-search", true
+	scaffoldParams = map[string]string{"percentage": "25"}
+	require.NoError(t, featuresScaffoldCmd.RunE(featuresScaffoldCmd, nil))
+
+	var feature map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &feature))
+	assert.Equal(t, "new-search", feature["name"])
+	assert.Equal(t, "UserPercentage", feature["conditions"].([]interface{})[0].(map[string]interface{})["rule"].(map[string]interface{})["type"])
+
+	scaffoldTemplate = ""
+	assert.ErrorContains(t, featuresScaffoldCmd.RunE(featuresScaffoldCmd, nil), "--template is required")
+}
+
+func TestFormatTemplateParams(t *testing.T) {
+	assert.Equal(t, "-", formatTemplateParams(nil))
+	assert.Equal(t, "from, timezone=UTC", formatTemplateParams(map[string]izanami.FeatureTemplateParam{
+		"timezone": {Default: "UTC"},
+		"from":     {},
+	}))
+}
@@ -0,0 +1,428 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	scheduleBegin    string
+	scheduleEnd      string
+	scheduleDays     []string
+	scheduleHours    []string
+	scheduleTimezone string
+)
+
+// scheduleDayNames maps accepted day spellings to the names used by Izanami
+var scheduleDayNames = map[string]string{
+	"mon": "MONDAY", "monday": "MONDAY",
+	"tue": "TUESDAY", "tuesday": "TUESDAY",
+	"wed": "WEDNESDAY", "wednesday": "WEDNESDAY",
+	"thu": "THURSDAY", "thursday": "THURSDAY",
+	"fri": "FRIDAY", "friday": "FRIDAY",
+	"sat": "SATURDAY", "saturday": "SATURDAY",
+	"sun": "SUNDAY", "sunday": "SUNDAY",
+}
+
+// scheduleDayOrder is used to sort days from Monday to Sunday
+var scheduleDayOrder = []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+// ScheduleView is the table representation of the period of one activation condition
+type ScheduleView struct {
+	Condition int    `json:"condition"`
+	Begin     string `json:"begin"`
+	End       string `json:"end"`
+	Days      string `json:"days"`
+	Hours     string `json:"hours"`
+	Timezone  string `json:"timezone"`
+	Rule      string `json:"rule"`
+}
+
+// featuresScheduleCmd groups the time-based activation commands
+var featuresScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage time-based activation of a feature",
+	Long: `Manage the activation period of a feature without writing conditions JSON.
+
+A schedule restricts when a feature is active: between two dates, on some
+days of the week and/or during some hours of the day.
+
+If the feature has user targeting conditions, the schedule is applied to each
+of them so targeting rules are preserved.`,
+}
+
+// featuresScheduleShowCmd displays the schedule of a feature
+var featuresScheduleShowCmd = &cobra.Command{
+	Use:         "show <feature-id-or-name>",
+	Short:       "Show the schedule of a feature",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
+	Long: `Show the activation periods of a feature.
+
+Examples:
+  # Show the schedule of a feature
+  iz admin features schedule show my-feature --project my-project`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+		if err != nil {
+			return err
+		}
+
+		feature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.ParseFeature)
+		if err != nil {
+			return err
+		}
+
+		views := scheduleViews(feature.Conditions)
+		if len(views) == 0 {
+			fmt.Fprintf(statusOut(cmd), "Feature %s has no schedule\n", feature.Name)
+			return nil
+		}
+		return output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat))
+	},
+}
+
+// featuresScheduleSetCmd sets the schedule of a feature
+var featuresScheduleSetCmd = &cobra.Command{
+	Use:         "set <feature-id-or-name>",
+	Short:       "Set the schedule of a feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Set the activation period of a feature, replacing any existing schedule.
+
+Dates accept RFC 3339 ("2025-01-01T08:00:00Z"), "2025-01-01T08:00" or
+"2025-01-01". Dates without an offset are read in --timezone.
+
+Days accept short or full English names (mon, tuesday) and the shortcuts
+"weekdays" and "weekends". Hours use HH:MM-HH:MM and may be repeated.
+
+Examples:
+  # Active during business hours on weekdays
+  iz admin features schedule set my-feature --days weekdays --hours 09:00-17:00 --timezone Europe/Paris
+
+  # Active for the duration of a campaign
+  iz admin features schedule set black-friday --begin 2025-11-28 --end 2025-12-01
+
+  # Two time windows on Monday and Tuesday
+  iz admin features schedule set my-feature --days mon,tue --hours 08:00-12:00 --hours 14:00-18:00`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		period, err := buildSchedulePeriod(scheduleBegin, scheduleEnd, scheduleDays, scheduleHours, scheduleTimezone)
+		if err != nil {
+			return err
+		}
+
+		return updateFeatureConditions(cmd, args[0], func(conditions []interface{}) ([]interface{}, error) {
+			return applySchedule(conditions, period)
+		}, "Schedule set for feature")
+	},
+}
+
+// featuresScheduleClearCmd removes the schedule of a feature
+var featuresScheduleClearCmd = &cobra.Command{
+	Use:         "clear <feature-id-or-name>",
+	Short:       "Remove the schedule of a feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Long: `Remove the activation period of a feature. User targeting rules are kept.
+
+Examples:
+  # Remove the schedule of a feature
+  iz admin features schedule clear my-feature --project my-project`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		return updateFeatureConditions(cmd, args[0], func(conditions []interface{}) ([]interface{}, error) {
+			return clearSchedule(conditions), nil
+		}, "Schedule cleared for feature")
+	},
+}
+
+// updateFeatureConditions fetches a feature, rewrites its conditions and saves it.
+// The raw feature is used so fields unknown to the CLI are sent back unchanged.
+func updateFeatureConditions(cmd *cobra.Command, featureIDOrName string, update func([]interface{}) ([]interface{}, error), successMsg string) error {
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext()
+	featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, featureIDOrName, cmd)
+	if err != nil {
+		return err
+	}
+
+	raw, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.Identity)
+	if err != nil {
+		return err
+	}
+
+	var feature map[string]interface{}
+	if err := json.Unmarshal(raw, &feature); err != nil {
+		return fmt.Errorf("failed to parse feature: %w", err)
+	}
+
+	conditions, _ := feature["conditions"].([]interface{})
+	conditions, err = update(conditions)
+	if err != nil {
+		return err
+	}
+	feature["conditions"] = conditions
+
+	if err := client.UpdateFeature(ctx, cfg.Tenant, featureID, feature, false); err != nil {
+		return err
+	}
+
+	name, _ := feature["name"].(string)
+	if name == "" {
+		name = featureID
+	}
+	fmt.Fprintf(statusOut(cmd), "%s: %s\n", successMsg, name)
+	runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"id": featureID, "name": name, "project": feature["project"]})
+	return nil
+}
+
+// buildSchedulePeriod validates the schedule flags and builds the period
+func buildSchedulePeriod(begin, end string, days, hours []string, timezone string) (*izanami.FeaturePeriod, error) {
+	if begin == "" && end == "" && len(days) == 0 && len(hours) == 0 {
+		return nil, fmt.Errorf("at least one of --begin, --end, --days or --hours is required")
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	period := &izanami.FeaturePeriod{Timezone: timezone}
+
+	if begin != "" {
+		t, err := parseScheduleTime(begin, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --begin: %w", err)
+		}
+		period.Begin = &t
+	}
+	if end != "" {
+		t, err := parseScheduleTime(end, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --end: %w", err)
+		}
+		period.End = &t
+	}
+	if period.Begin != nil && period.End != nil && !period.End.After(*period.Begin) {
+		return nil, fmt.Errorf("--end must be after --begin")
+	}
+
+	if len(days) > 0 {
+		period.Days, err = parseScheduleDays(days)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, h := range hours {
+		hp, err := parseHourRange(h)
+		if err != nil {
+			return nil, err
+		}
+		period.HourPeriods = append(period.HourPeriods, hp)
+	}
+
+	return period, nil
+}
+
+// parseScheduleTime parses a date in one of the accepted layouts
+func parseScheduleTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a valid date (use RFC 3339, YYYY-MM-DDTHH:MM or YYYY-MM-DD)", value)
+}
+
+// parseScheduleDays normalizes day names and returns them from Monday to Sunday
+func parseScheduleDays(values []string) ([]string, error) {
+	selected := make(map[string]bool)
+	for _, v := range values {
+		day := strings.ToLower(strings.TrimSpace(v))
+		switch day {
+		case "":
+			continue
+		case "weekdays":
+			for _, d := range scheduleDayOrder[:5] {
+				selected[d] = true
+			}
+		case "weekends":
+			for _, d := range scheduleDayOrder[5:] {
+				selected[d] = true
+			}
+		default:
+			name, ok := scheduleDayNames[day]
+			if !ok {
+				return nil, fmt.Errorf("invalid day %q (use mon, tue, wed, thu, fri, sat, sun, weekdays or weekends)", v)
+			}
+			selected[name] = true
+		}
+	}
+
+	var days []string
+	for _, d := range scheduleDayOrder {
+		if selected[d] {
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+// parseHourRange parses an "HH:MM-HH:MM" range
+func parseHourRange(value string) (izanami.HourPeriod, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return izanami.HourPeriod{}, fmt.Errorf("invalid hours %q (use HH:MM-HH:MM)", value)
+	}
+	startTime, err := time.Parse("15:04", strings.TrimSpace(start))
+	if err != nil {
+		return izanami.HourPeriod{}, fmt.Errorf("invalid hours %q (use HH:MM-HH:MM)", value)
+	}
+	endTime, err := time.Parse("15:04", strings.TrimSpace(end))
+	if err != nil {
+		return izanami.HourPeriod{}, fmt.Errorf("invalid hours %q (use HH:MM-HH:MM)", value)
+	}
+	if !endTime.After(startTime) {
+		return izanami.HourPeriod{}, fmt.Errorf("invalid hours %q: end must be after start", value)
+	}
+	return izanami.HourPeriod{
+		StartTime: startTime.Format("15:04:05"),
+		EndTime:   endTime.Format("15:04:05"),
+	}, nil
+}
+
+// applySchedule sets the period on every condition, or adds a condition if there is none
+func applySchedule(conditions []interface{}, period *izanami.FeaturePeriod) ([]interface{}, error) {
+	data, err := json.Marshal(period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schedule: %w", err)
+	}
+
+	newPeriod := func() map[string]interface{} {
+		var p map[string]interface{}
+		_ = json.Unmarshal(data, &p)
+		return p
+	}
+
+	if len(conditions) == 0 {
+		return []interface{}{map[string]interface{}{"period": newPeriod()}}, nil
+	}
+
+	result := make([]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]interface{}); ok {
+			condition["period"] = newPeriod()
+			result = append(result, condition)
+		} else {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// clearSchedule removes the period of every condition and drops conditions left empty
+func clearSchedule(conditions []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			result = append(result, c)
+			continue
+		}
+		delete(condition, "period")
+		if rule, hasRule := condition["rule"]; hasRule && rule != nil {
+			result = append(result, condition)
+		}
+	}
+	return result
+}
+
+// scheduleViews lists the periods of the conditions for display
+func scheduleViews(conditions []izanami.ActivationCondition) []ScheduleView {
+	var views []ScheduleView
+	for i, c := range conditions {
+		if c.Period == nil {
+			continue
+		}
+		view := ScheduleView{
+			Condition: i + 1,
+			Begin:     "-",
+			End:       "-",
+			Days:      "all",
+			Hours:     "all day",
+			Timezone:  c.Period.Timezone,
+			Rule:      "all users",
+		}
+		if c.Period.Begin != nil {
+			view.Begin = c.Period.Begin.Format(time.RFC3339)
+		}
+		if c.Period.End != nil {
+			view.End = c.Period.End.Format(time.RFC3339)
+		}
+		if len(c.Period.Days) > 0 {
+			view.Days = strings.Join(c.Period.Days, ",")
+		}
+		if len(c.Period.HourPeriods) > 0 {
+			ranges := make([]string, len(c.Period.HourPeriods))
+			for j, h := range c.Period.HourPeriods {
+				ranges[j] = h.StartTime + "-" + h.EndTime
+			}
+			view.Hours = strings.Join(ranges, ",")
+		}
+		if c.Rule != nil && c.Rule.Type != "" {
+			view.Rule = c.Rule.Type
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresScheduleCmd)
+	featuresScheduleCmd.AddCommand(featuresScheduleShowCmd)
+	featuresScheduleCmd.AddCommand(featuresScheduleSetCmd)
+	featuresScheduleCmd.AddCommand(featuresScheduleClearCmd)
+	for _, c := range []*cobra.Command{featuresScheduleShowCmd, featuresScheduleSetCmd, featuresScheduleClearCmd} {
+		c.ValidArgsFunction = completeFeatureNames
+	}
+
+	featuresScheduleSetCmd.Flags().StringVar(&scheduleBegin, "begin", "", "Activation start date")
+	featuresScheduleSetCmd.Flags().StringVar(&scheduleEnd, "end", "", "Activation end date")
+	featuresScheduleSetCmd.Flags().StringSliceVar(&scheduleDays, "days", []string{}, "Days of the week (e.g. mon,tue or weekdays)")
+	featuresScheduleSetCmd.Flags().StringArrayVar(&scheduleHours, "hours", []string{}, "Time window HH:MM-HH:MM (repeatable)")
+	featuresScheduleSetCmd.Flags().StringVar(&scheduleTimezone, "timezone", "UTC", "IANA timezone of the schedule (e.g. Europe/Paris)")
+}
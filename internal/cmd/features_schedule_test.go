@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestBuildSchedulePeriod(t *testing.T) {
+	period, err := buildSchedulePeriod("2025-11-28", "2025-12-01T18:30", []string{"weekdays", "sun"}, []string{"09:00-12:00", "14:00-17:30"}, "Europe/Paris")
+	require.NoError(t, err)
+
+	loc, _ := time.LoadLocation("Europe/Paris")
+	assert.Equal(t, "Europe/Paris", period.Timezone)
+	assert.True(t, period.Begin.Equal(time.Date(2025, 11, 28, 0, 0, 0, 0, loc)))
+	assert.True(t, period.End.Equal(time.Date(2025, 12, 1, 18, 30, 0, 0, loc)))
+	assert.Equal(t, []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SUNDAY"}, period.Days)
+	assert.Equal(t, []izanami.HourPeriod{
+		{StartTime: "09:00:00", EndTime: "12:00:00"},
+		{StartTime: "14:00:00", EndTime: "17:30:00"},
+	}, period.HourPeriods)
+}
+
+func TestBuildSchedulePeriod_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		begin   string
+		end     string
+		days    []string
+		hours   []string
+		tz      string
+		wantErr string
+	}{
+		{name: "no flags", wantErr: "at least one of"},
+		{name: "bad timezone", days: []string{"mon"}, tz: "Mars/Olympus", wantErr: "invalid timezone"},
+		{name: "bad date", begin: "tomorrow", wantErr: "invalid --begin"},
+		{name: "end before begin", begin: "2025-02-01", end: "2025-01-01", wantErr: "--end must be after --begin"},
+		{name: "bad day", days: []string{"funday"}, wantErr: "invalid day"},
+		{name: "bad hours", hours: []string{"9h-17h"}, wantErr: "invalid hours"},
+		{name: "reversed hours", hours: []string{"17:00-09:00"}, wantErr: "end must be after start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildSchedulePeriod(tt.begin, tt.end, tt.days, tt.hours, tt.tz)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestApplySchedule(t *testing.T) {
+	period := &izanami.FeaturePeriod{Days: []string{"MONDAY"}, Timezone: "UTC"}
+
+	// No conditions: a single scheduled condition is added
+	conditions, err := applySchedule(nil, period)
+	require.NoError(t, err)
+	require.Len(t, conditions, 1)
+	p := conditions[0].(map[string]interface{})["period"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"MONDAY"}, p["days"])
+
+	// Existing conditions keep their rule
+	existing := []interface{}{
+		map[string]interface{}{"rule": map[string]interface{}{"type": "UserList", "users": []interface{}{"alice"}}},
+	}
+	conditions, err = applySchedule(existing, period)
+	require.NoError(t, err)
+	require.Len(t, conditions, 1)
+	condition := conditions[0].(map[string]interface{})
+	assert.Contains(t, condition, "rule")
+	assert.Contains(t, condition, "period")
+}
+
+func TestClearSchedule(t *testing.T) {
+	conditions := []interface{}{
+		map[string]interface{}{"period": map[string]interface{}{"timezone": "UTC"}},
+		map[string]interface{}{
+			"period": map[string]interface{}{"timezone": "UTC"},
+			"rule":   map[string]interface{}{"type": "UserPercentage", "percentage": 10},
+		},
+	}
+
+	result := clearSchedule(conditions)
+	require.Len(t, result, 1)
+	condition := result[0].(map[string]interface{})
+	assert.NotContains(t, condition, "period")
+	assert.Contains(t, condition, "rule")
+}
+
+func TestScheduleViews(t *testing.T) {
+	begin := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	views := scheduleViews([]izanami.ActivationCondition{
+		{Rule: &izanami.ActivationRule{Type: "All"}},
+		{
+			Period: &izanami.FeaturePeriod{
+				Begin:       &begin,
+				Days:        []string{"MONDAY", "FRIDAY"},
+				HourPeriods: []izanami.HourPeriod{{StartTime: "09:00:00", EndTime: "17:00:00"}},
+				Timezone:    "UTC",
+			},
+		},
+	})
+
+	require.Len(t, views, 1)
+	assert.Equal(t, 2, views[0].Condition)
+	assert.Equal(t, "2025-01-01T08:00:00Z", views[0].Begin)
+	assert.Equal(t, "-", views[0].End)
+	assert.Equal(t, "MONDAY,FRIDAY", views[0].Days)
+	assert.Equal(t, "09:00:00-17:00:00", views[0].Hours)
+	assert.Equal(t, "all users", views[0].Rule)
+}
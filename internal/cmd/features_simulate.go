@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	simulateUsersFile   string
+	simulateDate        string
+	simulateContext     string
+	simulateConcurrency int
+)
+
+// FeatureSimulation is the activation of a feature across the cohort, at the
+// simulated date and now
+type FeatureSimulation struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	// Users is the number of users evaluated at both dates without error
+	Users          int     `json:"users"`
+	Active         int     `json:"active"`
+	ActivePercent  float64 `json:"activePercent"`
+	Current        int     `json:"current"`
+	CurrentPercent float64 `json:"currentPercent"`
+	// Changed is the number of users whose activation differs from now
+	Changed int `json:"changed"`
+	Errors  int `json:"errors"`
+}
+
+// SimulationReport is the output of features simulate
+type SimulationReport struct {
+	Project  string              `json:"project"`
+	Context  string              `json:"context,omitempty"`
+	Date     string              `json:"date"`
+	Users    int                 `json:"users"`
+	Failed   int                 `json:"failed"`
+	Features []FeatureSimulation `json:"features"`
+}
+
+// simulationTestFunc evaluates the features of the project for one user at a
+// date ("" for now)
+type simulationTestFunc func(ctx context.Context, user, date string) (izanami.FeatureTestResults, error)
+
+// featuresSimulateCmd evaluates the features of a project for a cohort of users at a date
+var featuresSimulateCmd = &cobra.Command{
+	Use:         "simulate",
+	Short:       "Report feature activation for a cohort of users at a date",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/_test"},
+	Long: `Evaluate every feature of a project for a cohort of users at a future (or
+past) date, and compare with their activation now.
+
+For each user of --users-file (one per line, blank lines and # comments
+ignored, - for stdin), the features are tested in bulk at --date and now. The
+report gives, per feature, the percentage of the cohort it is active for at
+--date and now, and how many users would see a different result. Features
+whose activation changes are highlighted: a period starting or ending, a
+percentage rollout or user list edited since, or conditions on the date.
+
+A feature counts as active for a user when it evaluates to a value other than
+false, null, 0 or an empty, "false" or "0" string.
+
+Examples:
+  # What changes for the beta cohort on December 1st?
+  iz admin features simulate --project shop --users-file cohort.txt --date 2024-12-01T09:00
+
+  # In a context, as JSON
+  iz admin features simulate --project shop --users-file cohort.txt --date 2024-12-01 --context prod/eu -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if cfg.Project == "" {
+			return fmt.Errorf("project is required (use --project flag or IZ_PROJECT)")
+		}
+		at, err := parseScheduleTime(simulateDate, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --date: %w", err)
+		}
+		date := at.UTC().Format(time.RFC3339)
+		users, err := readArgsAndFile(cmd, nil, simulateUsersFile, "users")
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return fmt.Errorf("no users in %s", simulateUsersFile)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		ctx := commandContext()
+		projects, err := resolveProjectsToUUIDs(ctx, client, cfg.Tenant, []string{cfg.Project}, cfg.Verbose, cmd)
+		if err != nil {
+			return err
+		}
+
+		test := func(ctx context.Context, user, date string) (izanami.FeatureTestResults, error) {
+			request := izanami.TestFeaturesAdminRequest{User: user, Date: date, Projects: projects, Context: simulateContext}
+			if date == "" {
+				request.Date = nowISO8601()
+			}
+			return izanami.TestFeaturesBulk(client, ctx, cfg.Tenant, request, izanami.ParseFeatureTestResults)
+		}
+		report := simulateCohort(ctx, users, date, simulateConcurrency, test)
+		report.Project, report.Context = cfg.Project, simulateContext
+
+		if outputFormat == "json" {
+			if err := output.PrintTo(cmd.OutOrStdout(), report, output.JSON); err != nil {
+				return err
+			}
+		} else if err := printSimulationReport(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+		if report.Failed > 0 {
+			return withExitCode(ExitEvaluation, fmt.Errorf("%d of %d evaluation(s) failed", report.Failed, 2*report.Users))
+		}
+		return nil
+	},
+}
+
+// simulateCohort tests the features for every user at date and now, with a
+// pool of workers, and aggregates the results per feature
+func simulateCohort(ctx context.Context, users []string, date string, concurrency int, test simulationTestFunc) *SimulationReport {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// Index 2*i is user i at date, 2*i+1 user i now
+	results := make([]izanami.FeatureTestResults, 2*len(users))
+	errs := make([]error, 2*len(users))
+	jobs := make(chan int)
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for index := range jobs {
+				at := date
+				if index%2 == 1 {
+					at = ""
+				}
+				// Each worker writes its own index, no lock needed
+				results[index], errs[index] = test(ctx, users[index/2], at)
+			}
+		}()
+	}
+	for index := range results {
+		jobs <- index
+	}
+	close(jobs)
+	workers.Wait()
+
+	report := &SimulationReport{Date: date, Users: len(users), Features: []FeatureSimulation{}}
+	features := make(map[string]*FeatureSimulation)
+	feature := func(id string, result izanami.FeatureTestResult) *FeatureSimulation {
+		f, ok := features[id]
+		if !ok {
+			f = &FeatureSimulation{ID: id, Name: result.Name, Project: result.Project}
+			features[id] = f
+		}
+		return f
+	}
+	for i := range users {
+		simulated, current := results[2*i], results[2*i+1]
+		for _, err := range errs[2*i : 2*i+2] {
+			if err != nil {
+				report.Failed++
+			}
+		}
+		if errs[2*i] != nil || errs[2*i+1] != nil {
+			continue
+		}
+		for id, result := range simulated {
+			f := feature(id, result)
+			now, ok := current[id]
+			if result.Error != "" || !ok || now.Error != "" {
+				f.Errors++
+				continue
+			}
+			f.Users++
+			active, activeNow := !isInactiveValue(result.Active), !isInactiveValue(now.Active)
+			if active {
+				f.Active++
+			}
+			if activeNow {
+				f.Current++
+			}
+			if active != activeNow {
+				f.Changed++
+			}
+		}
+		// Features only evaluated now cannot be compared
+		for id, result := range current {
+			if _, ok := simulated[id]; !ok {
+				feature(id, result).Errors++
+			}
+		}
+	}
+
+	for _, f := range features {
+		if f.Users > 0 {
+			f.ActivePercent = 100 * float64(f.Active) / float64(f.Users)
+			f.CurrentPercent = 100 * float64(f.Current) / float64(f.Users)
+		}
+		report.Features = append(report.Features, *f)
+	}
+	sort.Slice(report.Features, func(i, j int) bool {
+		a, b := report.Features[i], report.Features[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// printSimulationReport prints one row per feature, highlighting the features
+// whose activation changes
+func printSimulationReport(w io.Writer, report *SimulationReport) error {
+	rows := make([][]string, 0, len(report.Features))
+	changed := 0
+	for _, f := range report.Features {
+		change := ""
+		if f.Changed > 0 {
+			changed++
+			change = color.YellowString("%d user(s)", f.Changed)
+		}
+		failed := ""
+		if f.Errors > 0 {
+			failed = color.RedString(strconv.Itoa(f.Errors))
+		}
+		rows = append(rows, []string{f.Name, f.Project, fmt.Sprintf("%.1f%%", f.ActivePercent), fmt.Sprintf("%.1f%%", f.CurrentPercent), change, failed})
+	}
+	header := []string{"FEATURE", "PROJECT", "ACTIVE AT " + report.Date, "ACTIVE NOW", "CHANGED", "ERRORS"}
+	if err := output.PrintRows(w, header, rows); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\n%d of %d feature(s) change activation for %d user(s)\n", changed, len(report.Features), report.Users)
+	return nil
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresSimulateCmd)
+
+	featuresSimulateCmd.Flags().StringVar(&simulateUsersFile, "users-file", "", "File of the cohort, one user ID per line (- for stdin)")
+	featuresSimulateCmd.Flags().StringVar(&simulateDate, "date", "", "Date to simulate (RFC 3339, YYYY-MM-DDTHH:MM or YYYY-MM-DD, local time)")
+	featuresSimulateCmd.Flags().StringVar(&simulateContext, "context", "", "Context path to evaluate in (e.g., PROD, PROD/mobile)")
+	featuresSimulateCmd.Flags().IntVar(&simulateConcurrency, "concurrency", 10, "Number of evaluations run in parallel")
+	_ = featuresSimulateCmd.MarkFlagRequired("users-file")
+	_ = featuresSimulateCmd.MarkFlagRequired("date")
+}
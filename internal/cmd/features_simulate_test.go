@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesSimulate(t *testing.T) {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/projects/shop":
+			io.WriteString(w, `{"id":"p1","name":"shop"}`)
+		case "/api/admin/tenants/acme/features/_test":
+			query := r.URL.Query()
+			assert.Equal(t, "p1", query.Get("projects"))
+			assert.Equal(t, "/prod", query.Get("context"))
+			user, simulated := query.Get("user"), strings.HasPrefix(query.Get("date"), "2024-12-01")
+			if user == "broken" && simulated {
+				w.WriteHeader(http.StatusInternalServerError)
+				io.WriteString(w, `{"message":"boom"}`)
+				return
+			}
+			// The banner starts on December 1st; checkout is for alice only
+			fmt.Fprintf(w, `{"f1":{"name":"banner","project":"shop","active":%t},"f2":{"name":"checkout","project":"shop","active":%t}}`, simulated, user == "alice")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		simulateUsersFile, simulateDate, simulateContext, simulateConcurrency = "", "", "", 10
+		featuresSimulateCmd.SetOut(nil)
+	})
+	cfg.Project = "shop"
+	var buf bytes.Buffer
+	featuresSimulateCmd.SetOut(&buf)
+
+	path := filepath.Join(t.TempDir(), "cohort.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# beta testers\nalice\nbob\ncarol\nalice\ndave\n"), 0600))
+	simulateUsersFile, simulateDate, simulateContext, simulateConcurrency = path, "2024-12-01T09:00:00Z", "prod", 3
+
+	outputFormat = "json"
+	require.NoError(t, featuresSimulateCmd.RunE(featuresSimulateCmd, nil))
+	var report SimulationReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, "2024-12-01T09:00:00Z", report.Date)
+	assert.Equal(t, 4, report.Users)
+	require.Len(t, report.Features, 2)
+	assert.Equal(t, FeatureSimulation{ID: "f1", Name: "banner", Project: "shop", Users: 4, Active: 4, ActivePercent: 100, Changed: 4}, report.Features[0])
+	assert.Equal(t, FeatureSimulation{ID: "f2", Name: "checkout", Project: "shop", Users: 4, Active: 1, ActivePercent: 25, Current: 1, CurrentPercent: 25}, report.Features[1])
+
+	// Failed evaluations are left out of the percentages and fail the command
+	buf.Reset()
+	outputFormat = "table"
+	require.NoError(t, os.WriteFile(path, []byte("alice\nbroken\n"), 0600))
+	err := featuresSimulateCmd.RunE(featuresSimulateCmd, nil)
+	assert.EqualError(t, err, "1 of 4 evaluation(s) failed")
+	assert.Equal(t, ExitEvaluation, exitCode(err))
+	assert.Contains(t, buf.String(), "ACTIVE AT 2024-12-01T09:00:00Z")
+	assert.Contains(t, buf.String(), "1 of 2 feature(s) change activation for 2 user(s)")
+
+	simulateDate = "tomorrow"
+	assert.ErrorContains(t, featuresSimulateCmd.RunE(featuresSimulateCmd, nil), "invalid --date")
+}
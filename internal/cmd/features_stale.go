@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresStaleOlderThan         string
+	featuresStaleSort              string
+	featuresStaleDeleteInteractive bool
+)
+
+// featuresStaleCmd reports features that were not modified for a while
+var featuresStaleCmd = &cobra.Command{
+	Use:         "stale",
+	Short:       "Report features not modified for a given time",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/logs"},
+	Long: `Report the features whose last change in the audit log is older than
+--older-than, to find flags that can be removed from code and from Izanami.
+
+The last modification of each feature comes from the tenant audit log. Features
+without any audit event (e.g. imported, or older than the log retention) are
+reported with an unknown age. The admin API does not expose evaluation times,
+so a stale feature may still be evaluated by applications.
+
+Use the global --project flag to only check one project.
+
+With --delete-interactive, each stale feature is offered for deletion
+(y: delete, n: keep, q: stop); the global --yes deletes them all.
+
+Examples:
+  # Features not changed for 90 days
+  iz admin features stale --tenant my-tenant
+
+  # Features of one project not changed for 6 months, by name
+  iz admin features stale --tenant my-tenant --project shop --older-than 180d --sort name
+
+  # Review stale features one by one and delete them
+  iz admin features stale --tenant my-tenant --delete-interactive
+
+  # Stale features of two tenants, checked in parallel
+  iz admin features stale --tenants shop,billing`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		age, err := parseDayDuration(featuresStaleOlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		switch featuresStaleSort {
+		case "age", "name", "project":
+		default:
+			return fmt.Errorf("invalid --sort %q (expected age, name or project)", featuresStaleSort)
+		}
+		if featuresStaleDeleteInteractive && outputFormat == "json" {
+			return fmt.Errorf("--delete-interactive cannot be used with JSON output")
+		}
+		if multiTenantRequested(cmd) {
+			if featuresStaleDeleteInteractive {
+				return fmt.Errorf("--delete-interactive cannot be used with --tenants")
+			}
+			return runAcrossTenants(cmd, func(ctx context.Context, client *izanami.AdminClient, tenant string) (interface{}, error) {
+				now := time.Now()
+				stale, err := izanami.FindStaleFeatures(client, ctx, tenant, cfg.Project, now.Add(-age), now)
+				if err != nil {
+					return nil, err
+				}
+				izanami.SortStaleFeatures(stale, featuresStaleSort)
+				if outputFormat == "json" {
+					return stale, nil
+				}
+				views := make([]izanami.StaleFeatureTableView, len(stale))
+				for i, feature := range stale {
+					views[i] = feature.ToTableView()
+				}
+				return views, nil
+			})
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		now := time.Now()
+		stale, err := izanami.FindStaleFeatures(client, ctx, cfg.Tenant, cfg.Project, now.Add(-age), now)
+		if err != nil {
+			return err
+		}
+		izanami.SortStaleFeatures(stale, featuresStaleSort)
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), stale, output.JSON)
+		}
+		if len(stale) == 0 {
+			fmt.Fprintf(statusOut(cmd), "No feature unchanged for more than %s\n", featuresStaleOlderThan)
+			return nil
+		}
+
+		views := make([]izanami.StaleFeatureTableView, len(stale))
+		for i, feature := range stale {
+			views[i] = feature.ToTableView()
+		}
+		if err := output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat)); err != nil {
+			return err
+		}
+		fmt.Fprintf(statusOut(cmd), "%d feature(s) unchanged for more than %s\n", len(stale), featuresStaleOlderThan)
+
+		if !featuresStaleDeleteInteractive {
+			return nil
+		}
+		return deleteStaleFeatures(cmd, client, stale)
+	},
+}
+
+// deleteStaleFeatures offers each stale feature for deletion
+func deleteStaleFeatures(cmd *cobra.Command, client *izanami.AdminClient, stale []izanami.StaleFeature) error {
+	out := cmd.OutOrStdout()
+	reader := bufio.NewReader(cmd.InOrStdin())
+	deleted := 0
+	for _, feature := range stale {
+		lastModified := "never modified"
+		if feature.LastModified != "" {
+			lastModified = "last modified " + feature.LastModified
+		}
+		// --yes deletes every stale feature without asking
+		if !assumeYes {
+			fmt.Fprintf(out, "Delete feature %s/%s (%s)? (y/N/q): ", feature.Project, feature.Name, lastModified)
+			response, err := reader.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+			answer := strings.ToLower(strings.TrimSpace(response))
+			if answer == "q" || (answer == "" && err == io.EOF) {
+				fmt.Fprintln(out)
+				break
+			}
+			if answer != "y" {
+				continue
+			}
+		}
+		if err := client.DeleteFeature(commandContext(), cfg.Tenant, feature.ID); err != nil {
+			return fmt.Errorf("failed to delete feature %s/%s: %w (%d deleted)", feature.Project, feature.Name, err, deleted)
+		}
+		deleted++
+		runHooks(cmd, izanami.HookFeatureDelete, map[string]interface{}{"id": feature.ID, "name": feature.Name, "project": feature.Project})
+	}
+	fmt.Fprintf(statusOut(cmd), "Deleted %d of %d stale feature(s)\n", deleted, len(stale))
+	return nil
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresStaleCmd)
+	addTenantsFlag(featuresStaleCmd)
+
+	featuresStaleCmd.Flags().StringVar(&featuresStaleOlderThan, "older-than", "90d", "Report features not modified for this long (e.g. 90d, 2160h)")
+	featuresStaleCmd.Flags().StringVar(&featuresStaleSort, "sort", "age", "Sort by age (oldest first), name or project")
+	featuresStaleCmd.Flags().BoolVar(&featuresStaleDeleteInteractive, "delete-interactive", false, "Offer each stale feature for deletion")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeaturesStaleCmd_DeleteInteractive(t *testing.T) {
+	var deleted []string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"a","project":"shop"},{"id":"f2","name":"b","project":"shop"},{"id":"f3","name":"c","project":"shop"}]`)
+		default:
+			io.WriteString(w, `{"events":[]}`)
+		}
+	})
+
+	defer func() {
+		featuresStaleOlderThan, featuresStaleSort, featuresStaleDeleteInteractive = "90d", "age", false
+	}()
+	outputFormat = "table"
+	featuresStaleOlderThan, featuresStaleSort, featuresStaleDeleteInteractive = "30d", "name", true
+
+	var buf bytes.Buffer
+	featuresStaleCmd.SetOut(&buf)
+	featuresStaleCmd.SetIn(bytes.NewBufferString("n\ny\nq\n"))
+	defer func() {
+		featuresStaleCmd.SetOut(nil)
+		featuresStaleCmd.SetIn(nil)
+	}()
+	require.NoError(t, featuresStaleCmd.RunE(featuresStaleCmd, nil))
+
+	assert.Equal(t, []string{"/api/admin/tenants/acme/features/f2"}, deleted)
+	assert.Contains(t, buf.String(), "3 feature(s) unchanged for more than 30d")
+	assert.Contains(t, buf.String(), "Delete feature shop/a (never modified)? (y/N/q): ")
+	assert.Contains(t, buf.String(), "Deleted 1 of 3 stale feature(s)")
+}
+
+func TestFeaturesStaleCmd_InvalidFlags(t *testing.T) {
+	origCfg := cfg
+	defer func() {
+		cfg = origCfg
+		featuresStaleOlderThan, featuresStaleSort = "90d", "age"
+	}()
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost", Tenant: "acme"}
+
+	featuresStaleOlderThan = "three months"
+	assert.ErrorContains(t, featuresStaleCmd.RunE(featuresStaleCmd, nil), "invalid --older-than")
+
+	featuresStaleOlderThan, featuresStaleSort = "90d", "size"
+	assert.ErrorContains(t, featuresStaleCmd.RunE(featuresStaleCmd, nil), "invalid --sort")
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresStatsSince       string
+	featuresStatsContexts    []string
+	featuresStatsSamples     int
+	featuresStatsConcurrency int
+)
+
+// statsSampleUserPrefix prefixes the synthetic user IDs of sampled evaluations
+const statsSampleUserPrefix = "iz-stats-sample-"
+
+// FeatureContextStats counts the sampled evaluations of a feature in one context
+type FeatureContextStats struct {
+	Context     string  `json:"context"`
+	Evaluations int     `json:"evaluations"`
+	True        int     `json:"true"`
+	False       int     `json:"false"`
+	Errors      int     `json:"errors"`
+	TrueRatio   float64 `json:"trueRatio"`
+}
+
+// FeatureStats is the output of features stats
+type FeatureStats struct {
+	Feature string `json:"feature"`
+	Since   string `json:"since"`
+	// Approximate is true when the counts come from sampled evaluations
+	// rather than from server-side statistics
+	Approximate  bool                  `json:"approximate"`
+	Changes      int                   `json:"changes"`
+	LastChange   string                `json:"lastChange,omitempty"`
+	LastChangeBy string                `json:"lastChangeBy,omitempty"`
+	Total        FeatureContextStats   `json:"total"`
+	Contexts     []FeatureContextStats `json:"contexts"`
+}
+
+// featuresStatsCmd reports approximate usage statistics of a feature
+var featuresStatsCmd = &cobra.Command{
+	Use:         "stats <feature-id>",
+	Short:       "Show usage statistics of a feature",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/features/:id/test"},
+	Long: `Show how a feature evaluated over a period: number of evaluations, true/false
+ratio and breakdown per context, plus the changes made to the feature, to help
+decide when a flag is safe to remove.
+
+Izanami does not expose evaluation statistics, so the figures are an
+approximation: the feature is evaluated for --samples synthetic users at dates
+spread evenly over the --since period, in each context. This reflects date
+periods, user lists and percentage rollouts, but not the real traffic of your
+applications. Changes come from the tenant audit log over the same period.
+
+Use "/" in --contexts for the evaluation without context. Without --contexts,
+only the root context is sampled.
+
+Examples:
+  # Usage over the last 7 days
+  iz admin features stats feat-id --tenant my-tenant
+
+  # Compare environments over 30 days with more samples
+  iz admin features stats feat-id --since 30d --contexts /,dev,prod --samples 500
+
+  # JSON output for scripts
+  iz admin features stats feat-id -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		period, err := parseDayDuration(featuresStatsSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		if featuresStatsSamples < 1 {
+			return fmt.Errorf("--samples must be at least 1")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		featureID := args[0]
+		now := time.Now().UTC()
+		since := now.Add(-period)
+
+		changes, err := izanami.ListFeatureChanges(client, ctx, cfg.Tenant, featureID, since)
+		if err != nil {
+			return fmt.Errorf("failed to list feature changes: %w", err)
+		}
+
+		users, dates := statsSamples(since, now, featuresStatsSamples)
+		test := func(ctx context.Context, user, contextPath string) (interface{}, error) {
+			result, err := izanami.TestFeature(client, ctx, cfg.Tenant, featureID, contextPath, user, dates[user], "", izanami.ParseFeatureTestResult)
+			if err != nil {
+				return nil, err
+			}
+			if result.Error != "" {
+				return nil, fmt.Errorf("%s", result.Error)
+			}
+			return result.Active, nil
+		}
+		matrix := evaluateMatrix(ctx, featureID, users, matrixContexts(featuresStatsContexts), featuresStatsConcurrency, test)
+
+		stats := buildFeatureStats(matrix, changes)
+		stats.Since = since.Format(time.RFC3339)
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), stats, output.JSON)
+		}
+		return printFeatureStats(cmd.OutOrStdout(), stats)
+	},
+}
+
+// statsSamples returns the synthetic users of the sampled evaluations and their
+// evaluation dates, spread evenly over the period
+func statsSamples(since, now time.Time, samples int) ([]string, map[string]string) {
+	users := make([]string, samples)
+	dates := make(map[string]string, samples)
+	step := now.Sub(since) / time.Duration(samples)
+	for i := range users {
+		users[i] = fmt.Sprintf("%s%d", statsSampleUserPrefix, i+1)
+		dates[users[i]] = since.Add(step*time.Duration(i) + step/2).Format(time.RFC3339)
+	}
+	return users, dates
+}
+
+// buildFeatureStats counts the matrix evaluations per context and summarizes the changes
+func buildFeatureStats(matrix *FeatureTestMatrix, changes []izanami.AuditEvent) *FeatureStats {
+	stats := &FeatureStats{
+		Feature:     matrix.Feature,
+		Approximate: true,
+		Changes:     len(changes),
+		Total:       FeatureContextStats{Context: "(all)"},
+		Contexts:    make([]FeatureContextStats, len(matrix.Contexts)),
+	}
+	if len(changes) > 0 {
+		stats.LastChange = changes[0].EmittedAt
+		stats.LastChangeBy = changes[0].User
+	}
+
+	for i, label := range matrix.Contexts {
+		stats.Contexts[i].Context = label
+	}
+	// Results are ordered by user, then by context
+	for index, cell := range matrix.Results {
+		stats.Contexts[index%len(matrix.Contexts)].record(cell)
+		stats.Total.record(cell)
+	}
+	return stats
+}
+
+// record counts one evaluation and updates the true ratio
+func (s *FeatureContextStats) record(cell FeatureTestMatrixCell) {
+	s.Evaluations++
+	switch {
+	case cell.Error != "":
+		s.Errors++
+	case isInactiveValue(cell.Active):
+		s.False++
+	default:
+		s.True++
+	}
+	if evaluated := s.True + s.False; evaluated > 0 {
+		s.TrueRatio = float64(s.True) / float64(evaluated)
+	}
+}
+
+// featureContextStatsView represents context statistics for table display
+type featureContextStatsView struct {
+	Context     string `json:"context"`
+	Evaluations int    `json:"evaluations"`
+	True        int    `json:"true"`
+	False       int    `json:"false"`
+	Errors      int    `json:"errors"`
+	TruePercent string `json:"true%"`
+}
+
+// printFeatureStats prints the summary followed by the per-context table
+func printFeatureStats(w io.Writer, stats *FeatureStats) error {
+	fmt.Fprintf(w, "Feature:     %s\n", stats.Feature)
+	fmt.Fprintf(w, "Since:       %s\n", stats.Since)
+	lastChange := ""
+	if stats.LastChange != "" {
+		lastChange = fmt.Sprintf(" (last %s by %s)", stats.LastChange, stats.LastChangeBy)
+	}
+	fmt.Fprintf(w, "Changes:     %d%s\n", stats.Changes, lastChange)
+	fmt.Fprintf(w, "Evaluations: %d (approximation from sampled evaluations)\n", stats.Total.Evaluations)
+	fmt.Fprintf(w, "True:        %s\n", formatStatsRatio(stats.Total))
+	fmt.Fprintln(w)
+
+	views := make([]featureContextStatsView, len(stats.Contexts))
+	for i, s := range stats.Contexts {
+		views[i] = featureContextStatsView{
+			Context:     s.Context,
+			Evaluations: s.Evaluations,
+			True:        s.True,
+			False:       s.False,
+			Errors:      s.Errors,
+			TruePercent: fmt.Sprintf("%.1f%%", s.TrueRatio*100),
+		}
+	}
+	return output.PrintTo(w, views, output.Table)
+}
+
+// formatStatsRatio formats the true count of statistics with its share of the
+// successful evaluations
+func formatStatsRatio(s FeatureContextStats) string {
+	return fmt.Sprintf("%d of %d (%.1f%%)", s.True, s.True+s.False, s.TrueRatio*100)
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresStatsCmd)
+
+	featuresStatsCmd.Flags().StringVar(&featuresStatsSince, "since", "7d", "Period to report on (e.g. 7d, 24h)")
+	featuresStatsCmd.Flags().StringSliceVar(&featuresStatsContexts, "contexts", nil, "Context paths to break down (comma-separated, / for the root context)")
+	featuresStatsCmd.Flags().IntVar(&featuresStatsSamples, "samples", 100, "Number of sampled evaluations per context")
+	featuresStatsCmd.Flags().IntVar(&featuresStatsConcurrency, "concurrency", 10, "Number of evaluations run in parallel")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsSamples(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	users, dates := statsSamples(since, since.Add(4*24*time.Hour), 4)
+
+	assert.Equal(t, []string{"iz-stats-sample-1", "iz-stats-sample-2", "iz-stats-sample-3", "iz-stats-sample-4"}, users)
+	assert.Equal(t, "2024-05-01T12:00:00Z", dates["iz-stats-sample-1"])
+	assert.Equal(t, "2024-05-04T12:00:00Z", dates["iz-stats-sample-4"])
+}
+
+// setupFeatureStatsServer answers feature tests as active in prod only for the
+// second half of the period, and never in dev
+func setupFeatureStatsServer(t *testing.T) {
+	t.Helper()
+	midpoint := time.Now().UTC().Add(-84 * time.Hour)
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/admin/tenants/acme/logs":
+			assert.Equal(t, "feat", r.URL.Query().Get("features"))
+			io.WriteString(w, `{"events":[{"eventId":2,"id":"feat","user":"alice","type":"FEATURE_UPDATED","emittedAt":"2024-05-02T10:00:00Z"},
+				{"eventId":1,"id":"feat","user":"bob","type":"FEATURE_UPDATED"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/api/admin/tenants/acme/features/feat/test"):
+			path := strings.TrimPrefix(r.URL.Path, "/api/admin/tenants/acme/features/feat/test")
+			date, err := time.Parse(time.RFC3339, r.URL.Query().Get("date"))
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(r.URL.Query().Get("user"), statsSampleUserPrefix))
+			active := path == "/prod" && date.After(midpoint)
+			fmt.Fprintf(w, `{"name": "feat", "active": %t, "project": "shop"}`, active)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresStatsContexts, featuresStatsSamples, featuresStatsSince = nil, 100, "7d"
+		featuresStatsCmd.SetOut(nil)
+	})
+}
+
+func TestFeaturesStatsCmd_JSON(t *testing.T) {
+	setupFeatureStatsServer(t)
+	outputFormat = "json"
+	featuresStatsContexts = []string{"prod", "dev"}
+	featuresStatsSamples = 10
+
+	var buf bytes.Buffer
+	featuresStatsCmd.SetOut(&buf)
+	require.NoError(t, featuresStatsCmd.RunE(featuresStatsCmd, []string{"feat"}))
+
+	var stats FeatureStats
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &stats))
+	assert.True(t, stats.Approximate)
+	assert.Equal(t, 2, stats.Changes)
+	assert.Equal(t, "alice", stats.LastChangeBy)
+	assert.Equal(t, FeatureContextStats{Context: "(all)", Evaluations: 20, True: 5, False: 15, TrueRatio: 0.25}, stats.Total)
+	assert.Equal(t, []FeatureContextStats{
+		{Context: "prod", Evaluations: 10, True: 5, False: 5, TrueRatio: 0.5},
+		{Context: "dev", Evaluations: 10, False: 10},
+	}, stats.Contexts)
+}
+
+func TestFeaturesStatsCmd_Table(t *testing.T) {
+	setupFeatureStatsServer(t)
+	outputFormat = "table"
+	featuresStatsContexts = []string{"prod"}
+	featuresStatsSamples = 4
+
+	var buf bytes.Buffer
+	featuresStatsCmd.SetOut(&buf)
+	require.NoError(t, featuresStatsCmd.RunE(featuresStatsCmd, []string{"feat"}))
+
+	out := buf.String()
+	assert.Contains(t, out, "Changes:     2 (last 2024-05-02T10:00:00Z by alice)")
+	assert.Contains(t, out, "Evaluations: 4 (approximation from sampled evaluations)")
+	assert.Contains(t, out, "True:        2 of 4 (50.0%)")
+	assert.Contains(t, out, "50.0%")
+
+	featuresStatsSince = "soon"
+	assert.ErrorContains(t, featuresStatsCmd.RunE(featuresStatsCmd, []string{"feat"}), "invalid --since")
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresTagFilters []string
+	featuresTagDryRun  bool
+	featuresTagForce   bool
+)
+
+// TagPlanRow describes the tags of one feature after a bulk tag change
+type TagPlanRow struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Project string   `json:"project"`
+	Tags    []string `json:"tags"`
+	Change  bool     `json:"change"`
+}
+
+// featureFilter is a --filter of the features to tag: key=value for an exact
+// match, key~value for a case-insensitive substring
+type featureFilter struct {
+	Key      string
+	Value    string
+	Contains bool
+}
+
+// featuresTagCmd groups the bulk tag commands
+var featuresTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove a tag on many features at once",
+	Long: `Add or remove a tag on the features matching --project and --filter, with a
+single batch request.
+
+Filters (repeatable, all must match):
+  name=checkout      Feature name is exactly "checkout"
+  name~checkout      Feature name contains "checkout" (case-insensitive)
+  tag=beta           Feature has the tag "beta" (tag~ matches part of a tag)
+  description~promo  Description contains "promo"
+
+At least one of --project or --filter is required. The matching features and
+their new tags are shown first, then a confirmation is requested (skip it with
+--force). Features that already have (or do not have) the tag are left
+untouched.
+
+Examples:
+  iz admin features tag add beta --project shop --filter name~checkout
+  iz admin features tag remove beta --tenant my-tenant --filter tag=beta --dry-run`,
+}
+
+// featuresTagAddCmd adds a tag to the matching features
+var featuresTagAddCmd = &cobra.Command{
+	Use:         "add <tag>",
+	Short:       "Add a tag to the matching features",
+	Annotations: map[string]string{"route": "PATCH /api/admin/tenants/:tenant/features"},
+	Long: `Add a tag to the features matching --project and --filter. The tag is created
+when it does not exist yet.
+
+Examples:
+  iz admin features tag add beta --project shop --filter name~checkout
+  iz admin features tag add q3-cleanup --tenant my-tenant --filter description~deprecated --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFeaturesTag(cmd, args[0], true)
+	},
+}
+
+// featuresTagRemoveCmd removes a tag from the matching features
+var featuresTagRemoveCmd = &cobra.Command{
+	Use:         "remove <tag>",
+	Short:       "Remove a tag from the matching features",
+	Annotations: map[string]string{"route": "PATCH /api/admin/tenants/:tenant/features"},
+	Long: `Remove a tag from the features matching --project and --filter. The tag itself
+is kept; delete it with 'iz admin tags delete'.
+
+Examples:
+  iz admin features tag remove beta --project shop --filter name~checkout
+  iz admin features tag remove beta --tenant my-tenant --filter tag=beta --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFeaturesTag(cmd, args[0], false)
+	},
+}
+
+// runFeaturesTag adds (or removes) a tag on the features selected by
+// --project and --filter
+func runFeaturesTag(cmd *cobra.Command, tag string, add bool) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(tag) == "" {
+		return fmt.Errorf("tag name cannot be empty")
+	}
+	filters, err := parseFeatureFilters(featuresTagFilters)
+	if err != nil {
+		return err
+	}
+	if len(filters) == 0 && cfg.Project == "" {
+		return fmt.Errorf("select features with --project or --filter")
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := commandContext()
+	features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures)
+	if err != nil {
+		return err
+	}
+
+	var selected []izanami.Feature
+	for _, f := range features {
+		if (cfg.Project == "" || f.Project == cfg.Project) && matchFeatureFilters(f, filters) {
+			selected = append(selected, f)
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(statusOut(cmd), "No matching features")
+		return nil
+	}
+
+	plan := buildTagPlan(selected, tag, add)
+	if err := output.PrintTo(cmd.OutOrStdout(), plan, output.Format(outputFormat)); err != nil {
+		return err
+	}
+
+	patches := buildTagPatches(plan)
+	action, question, state := "removed from", "Remove tag '"+tag+"' from", "absent from"
+	if add {
+		action, question, state = "added to", "Add tag '"+tag+"' to", "on"
+	}
+	if len(patches) == 0 {
+		fmt.Fprintf(statusOut(cmd), "Tag '%s' is already %s all %d feature(s)\n", tag, state, len(plan))
+		return nil
+	}
+	if featuresTagDryRun {
+		fmt.Fprintf(statusOut(cmd), "Dry run: tag '%s' would be %s %d feature(s)\n", tag, action, len(patches))
+		return nil
+	}
+	if !featuresTagForce {
+		if !confirmAction(cmd, fmt.Sprintf("%s %d feature(s)?", question, len(patches))) {
+			return nil
+		}
+	}
+
+	if add {
+		created, err := izanami.EnsureTag(client, ctx, cfg.Tenant, tag, "")
+		if err != nil {
+			return err
+		}
+		if created {
+			fmt.Fprintf(statusOut(cmd), "Tag '%s' created\n", tag)
+		}
+	}
+
+	var changed, projects []string
+	for _, row := range plan {
+		if row.Change {
+			changed = append(changed, row.ID)
+			projects = append(projects, row.Project)
+		}
+	}
+	if err := saveMutationSnapshot(cmd, client, projects, false); err != nil {
+		return err
+	}
+	if err := client.PatchFeatures(ctx, cfg.Tenant, patches); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(statusOut(cmd), "Tag '%s' %s %d feature(s)\n", tag, action, len(patches))
+	runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"ids": changed, "tag": tag, "tagAdded": add})
+	return nil
+}
+
+// parseFeatureFilters parses key=value and key~value feature filters
+func parseFeatureFilters(filters []string) ([]featureFilter, error) {
+	parsed := make([]featureFilter, 0, len(filters))
+	for _, f := range filters {
+		i := strings.IndexAny(f, "=~")
+		if i <= 0 || strings.TrimSpace(f[i+1:]) == "" {
+			return nil, fmt.Errorf("invalid filter %q (expected key=value or key~value)", f)
+		}
+		filter := featureFilter{
+			Key:      strings.ToLower(strings.TrimSpace(f[:i])),
+			Value:    strings.TrimSpace(f[i+1:]),
+			Contains: f[i] == '~',
+		}
+		switch filter.Key {
+		case "name", "tag", "description":
+		default:
+			return nil, fmt.Errorf("unknown filter key %q (supported: name, tag, description)", filter.Key)
+		}
+		parsed = append(parsed, filter)
+	}
+	return parsed, nil
+}
+
+// matches reports whether a value satisfies the filter
+func (f featureFilter) matches(value string) bool {
+	if f.Contains {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(f.Value))
+	}
+	return value == f.Value
+}
+
+// matchFeatureFilters reports whether a feature satisfies every filter
+func matchFeatureFilters(feature izanami.Feature, filters []featureFilter) bool {
+	for _, f := range filters {
+		var ok bool
+		switch f.Key {
+		case "name":
+			ok = f.matches(feature.Name)
+		case "description":
+			ok = f.matches(feature.Description)
+		case "tag":
+			ok = slices.ContainsFunc(feature.Tags, f.matches)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// buildTagPlan computes the tags of each selected feature after adding or
+// removing tag
+func buildTagPlan(features []izanami.Feature, tag string, add bool) []TagPlanRow {
+	plan := make([]TagPlanRow, len(features))
+	for i, f := range features {
+		tags := slices.Clone(f.Tags)
+		has := slices.Contains(tags, tag)
+		switch {
+		case add && !has:
+			tags = append(tags, tag)
+		case !add && has:
+			tags = slices.DeleteFunc(tags, func(t string) bool { return t == tag })
+		}
+		if tags == nil {
+			tags = []string{}
+		}
+		plan[i] = TagPlanRow{ID: f.ID, Name: f.Name, Project: f.Project, Tags: tags, Change: has != add}
+	}
+	return plan
+}
+
+// buildTagPatches creates the patch operations replacing the tags of the
+// features that change
+func buildTagPatches(plan []TagPlanRow) []izanami.FeaturePatch {
+	var patches []izanami.FeaturePatch
+	for _, row := range plan {
+		if row.Change {
+			patches = append(patches, izanami.FeaturePatch{
+				Op:    "replace",
+				Path:  "/" + row.ID + "/tags",
+				Value: row.Tags,
+			})
+		}
+	}
+	return patches
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresTagCmd)
+	featuresTagCmd.AddCommand(featuresTagAddCmd)
+	featuresTagCmd.AddCommand(featuresTagRemoveCmd)
+
+	for _, c := range []*cobra.Command{featuresTagAddCmd, featuresTagRemoveCmd} {
+		c.Flags().StringArrayVar(&featuresTagFilters, "filter", []string{}, "Select features as key=value or key~value (name, tag, description; repeatable)")
+		c.Flags().BoolVar(&featuresTagDryRun, "dry-run", false, "Show the plan without applying it")
+		c.Flags().BoolVarP(&featuresTagForce, "force", "f", false, "Skip confirmation prompt")
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestParseFeatureFilters(t *testing.T) {
+	filters, err := parseFeatureFilters([]string{"name~Checkout", "Tag=beta"})
+	require.NoError(t, err)
+	assert.Equal(t, []featureFilter{
+		{Key: "name", Value: "Checkout", Contains: true},
+		{Key: "tag", Value: "beta"},
+	}, filters)
+
+	_, err = parseFeatureFilters([]string{"name"})
+	assert.ErrorContains(t, err, "invalid filter")
+	_, err = parseFeatureFilters([]string{"owner=me"})
+	assert.ErrorContains(t, err, "unknown filter key")
+}
+
+func TestMatchFeatureFilters(t *testing.T) {
+	feature := izanami.Feature{Name: "new-checkout", Description: "Promo flow", Tags: []string{"beta", "mobile"}}
+	match := func(filters ...string) bool {
+		parsed, err := parseFeatureFilters(filters)
+		require.NoError(t, err)
+		return matchFeatureFilters(feature, parsed)
+	}
+	assert.True(t, match("name~CHECKOUT", "tag=beta"))
+	assert.True(t, match("description~promo", "tag~mob"))
+	assert.False(t, match("name=checkout"))
+	assert.False(t, match("name~checkout", "tag=alpha"))
+}
+
+func TestBuildTagPlan(t *testing.T) {
+	features := []izanami.Feature{
+		{ID: "f1", Name: "checkout", Project: "shop", Tags: []string{"beta"}},
+		{ID: "f2", Name: "checkout-v2", Project: "shop"},
+	}
+
+	plan := buildTagPlan(features, "beta", true)
+	assert.False(t, plan[0].Change)
+	assert.Equal(t, []string{"beta"}, plan[1].Tags)
+	assert.Equal(t, []izanami.FeaturePatch{{Op: "replace", Path: "/f2/tags", Value: []string{"beta"}}}, buildTagPatches(plan))
+	assert.Nil(t, features[1].Tags, "the features are left untouched")
+
+	plan = buildTagPlan(features, "beta", false)
+	assert.Equal(t, []izanami.FeaturePatch{{Op: "replace", Path: "/f1/tags", Value: []string{}}}, buildTagPatches(plan))
+}
+
+func TestFeaturesTagAdd(t *testing.T) {
+	var patches []izanami.FeaturePatch
+	var createdTag string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id":"f1","name":"checkout","project":"shop","enabled":true,"tags":["beta"]},
+				{"id":"f2","name":"checkout-v2","project":"shop","enabled":false,"tags":[]},
+				{"id":"f3","name":"checkout","project":"web","enabled":false,"tags":[]},
+				{"id":"f4","name":"search","project":"shop","enabled":false,"tags":[]}
+			]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/tags/beta":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/admin/tenants/acme/tags":
+			var tag izanami.Tag
+			json.NewDecoder(r.Body).Decode(&tag)
+			createdTag = tag.Name
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/admin/tenants/acme/features":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patches))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		featuresTagFilters, featuresTagDryRun, featuresTagForce = []string{}, false, false
+		noSnapshot = false
+		featuresTagAddCmd.SetOut(nil)
+	})
+	cfg.Project = "shop"
+	outputFormat = "table"
+	featuresTagFilters, noSnapshot = []string{"name~checkout"}, true
+
+	var buf bytes.Buffer
+	featuresTagAddCmd.SetOut(&buf)
+
+	featuresTagDryRun = true
+	require.NoError(t, featuresTagAddCmd.RunE(featuresTagAddCmd, []string{"beta"}))
+	assert.Contains(t, buf.String(), "Dry run: tag 'beta' would be added to 1 feature(s)")
+	assert.Nil(t, patches, "a dry run changes nothing")
+
+	featuresTagDryRun, featuresTagForce = false, true
+	require.NoError(t, featuresTagAddCmd.RunE(featuresTagAddCmd, []string{"beta"}))
+	assert.Equal(t, "beta", createdTag)
+	require.Len(t, patches, 1, "a single batch request for the features that change")
+	assert.Equal(t, "/f2/tags", patches[0].Path)
+	assert.Equal(t, []interface{}{"beta"}, patches[0].Value)
+	assert.Contains(t, buf.String(), "Tag 'beta' added to 1 feature(s)")
+}
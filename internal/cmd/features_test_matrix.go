@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	testMatrixContexts    []string
+	testMatrixUsers       []string
+	testMatrixConcurrency int
+)
+
+// rootContextLabel is the column label of the evaluation without context
+const rootContextLabel = "(root)"
+
+// FeatureTestMatrixCell is the evaluation of a feature for one user in one context
+type FeatureTestMatrixCell struct {
+	User    string      `json:"user"`
+	Context string      `json:"context"`
+	Active  interface{} `json:"active,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// FeatureTestMatrix is the JSON output of features test-matrix
+type FeatureTestMatrix struct {
+	Feature  string                  `json:"feature"`
+	Contexts []string                `json:"contexts"`
+	Users    []string                `json:"users"`
+	Results  []FeatureTestMatrixCell `json:"results"`
+}
+
+// matrixTestFunc evaluates a feature for one user in one context
+type matrixTestFunc func(ctx context.Context, user, contextPath string) (interface{}, error)
+
+// featuresTestMatrixCmd tests a feature for every combination of users and contexts
+var featuresTestMatrixCmd = &cobra.Command{
+	Use:         "test-matrix <feature-id>",
+	Short:       "Test a feature across contexts and users",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/features/:id/test"},
+	Long: `Test how a feature evaluates for every combination of --contexts and --users,
+running the evaluations in parallel, and print a pivot table with one row per user
+and one column per context.
+
+Use "/" in --contexts for the evaluation without context. Without --contexts, only
+the root context is tested; without --users, the feature is tested without a user.
+
+The --date flag defaults to "now" (current time). A script feature payload is
+sent with every evaluation.
+
+` + payloadFlagsHelp + `
+
+Output formats: table (default), json, csv.
+
+Examples:
+  # Compare environments for two users
+  iz admin features test-matrix feat-id --contexts dev,staging,prod/eu --users alice,bob
+
+  # Include the root context and export as CSV
+  iz admin features test-matrix feat-id --contexts /,prod,prod/eu --users alice -o csv
+
+  # Fail in CI when any combination is inactive
+  iz admin features test-matrix feat-id --contexts prod/eu,prod/us --fail-on-false`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		switch outputFormat {
+		case "table", "json", "csv":
+		default:
+			return fmt.Errorf("unsupported output format: %s (expected table, json or csv)", outputFormat)
+		}
+
+		date := featureTestDate
+		if date == "now" {
+			date = nowISO8601()
+		}
+
+		payload, err := resolvePayload(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		featureID := args[0]
+		contexts := matrixContexts(testMatrixContexts)
+		users := matrixUsers(testMatrixUsers)
+
+		test := func(ctx context.Context, user, contextPath string) (interface{}, error) {
+			result, err := izanami.TestFeature(client, ctx, cfg.Tenant, featureID, contextPath, user, date, payload, izanami.ParseFeatureTestResult)
+			if err != nil {
+				return nil, err
+			}
+			if result.Error != "" {
+				return nil, fmt.Errorf("%s", result.Error)
+			}
+			return result.Active, nil
+		}
+
+		matrix := evaluateMatrix(commandContext(), featureID, users, contexts, testMatrixConcurrency, test)
+
+		switch outputFormat {
+		case "json":
+			if err := output.PrintTo(cmd.OutOrStdout(), matrix, output.JSON); err != nil {
+				return err
+			}
+		case "csv":
+			if err := printTestMatrixCSV(cmd.OutOrStdout(), matrix); err != nil {
+				return err
+			}
+		default:
+			printTestMatrixTable(cmd.OutOrStdout(), matrix)
+		}
+
+		failed, inactive := 0, 0
+		for _, cell := range matrix.Results {
+			if cell.Error != "" {
+				failed++
+				if outputFormat == "table" {
+					fmt.Fprintf(statusOut(cmd), "Error for user %s in context %s: %s\n", matrixUserLabel(cell.User), cell.Context, cell.Error)
+				}
+			} else if isInactiveValue(cell.Active) {
+				inactive++
+			}
+		}
+		if failed > 0 {
+			return withExitCode(ExitEvaluation, fmt.Errorf("%d of %d evaluation(s) failed", failed, len(matrix.Results)))
+		}
+		if featureFailOnFalse && inactive > 0 {
+			return withExitCode(ExitInactive, fmt.Errorf("feature %s is inactive for %d of %d combination(s)", featureID, inactive, len(matrix.Results)))
+		}
+		return nil
+	},
+}
+
+// matrixContexts normalizes --contexts, "/" standing for the root context.
+// Duplicates are dropped and the root context is used when none is given.
+func matrixContexts(values []string) []string {
+	contexts := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		path := strings.Trim(strings.TrimSpace(value), "/")
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		contexts = append(contexts, path)
+	}
+	if len(contexts) == 0 {
+		contexts = append(contexts, "")
+	}
+	return contexts
+}
+
+// matrixUsers drops blank and duplicate --users, testing without a user when none is given
+func matrixUsers(values []string) []string {
+	users := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		user := strings.TrimSpace(value)
+		if user == "" || seen[user] {
+			continue
+		}
+		seen[user] = true
+		users = append(users, user)
+	}
+	if len(users) == 0 {
+		users = append(users, "")
+	}
+	return users
+}
+
+// evaluateMatrix runs test for every user and context with a pool of workers.
+// Results are ordered by user, then by context.
+func evaluateMatrix(ctx context.Context, featureID string, users, contexts []string, concurrency int, test matrixTestFunc) *FeatureTestMatrix {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	matrix := &FeatureTestMatrix{
+		Feature:  featureID,
+		Contexts: make([]string, len(contexts)),
+		Users:    users,
+		Results:  make([]FeatureTestMatrixCell, len(users)*len(contexts)),
+	}
+	for i, path := range contexts {
+		matrix.Contexts[i] = matrixContextLabel(path)
+	}
+
+	jobs := make(chan int)
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for index := range jobs {
+				user, path := users[index/len(contexts)], contexts[index%len(contexts)]
+				cell := FeatureTestMatrixCell{User: user, Context: matrixContextLabel(path)}
+				contextPath := ""
+				if path != "" {
+					contextPath = "/" + path
+				}
+				active, err := test(ctx, user, contextPath)
+				if err != nil {
+					cell.Error = err.Error()
+				} else {
+					cell.Active = active
+				}
+				// Each worker writes its own index, no lock needed
+				matrix.Results[index] = cell
+			}
+		}()
+	}
+	for index := range matrix.Results {
+		jobs <- index
+	}
+	close(jobs)
+	workers.Wait()
+	return matrix
+}
+
+// matrixContextLabel returns the column label of a normalized context path
+func matrixContextLabel(path string) string {
+	if path == "" {
+		return rootContextLabel
+	}
+	return path
+}
+
+// matrixUserLabel returns the row label of a user, "-" when testing without a user
+func matrixUserLabel(user string) string {
+	if user == "" {
+		return "-"
+	}
+	return user
+}
+
+// testMatrixRows returns the header and rows shared by table and CSV output
+func testMatrixRows(matrix *FeatureTestMatrix) ([]string, [][]string) {
+	header := append([]string{"user"}, matrix.Contexts...)
+
+	rows := make([][]string, 0, len(matrix.Users))
+	for i, user := range matrix.Users {
+		row := []string{matrixUserLabel(user)}
+		for _, cell := range matrix.Results[i*len(matrix.Contexts) : (i+1)*len(matrix.Contexts)] {
+			if cell.Error != "" {
+				row = append(row, "error")
+			} else {
+				row = append(row, fmt.Sprint(cell.Active))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+// printTestMatrixTable prints the matrix as an aligned pivot table
+func printTestMatrixTable(w io.Writer, matrix *FeatureTestMatrix) {
+	header, rows := testMatrixRows(matrix)
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetColumnSeparator("")
+	table.SetHeaderLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// printTestMatrixCSV prints the matrix as CSV with a header row
+func printTestMatrixCSV(w io.Writer, matrix *FeatureTestMatrix) error {
+	header, rows := testMatrixRows(matrix)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresTestMatrixCmd)
+
+	featuresTestMatrixCmd.Flags().StringSliceVar(&testMatrixContexts, "contexts", nil, "Context paths to test, one column each (comma-separated, / for the root context)")
+	featuresTestMatrixCmd.Flags().StringSliceVar(&testMatrixUsers, "users", nil, "User IDs to test, one row each (comma-separated)")
+	featuresTestMatrixCmd.Flags().IntVar(&testMatrixConcurrency, "concurrency", 10, "Number of evaluations run in parallel")
+	featuresTestMatrixCmd.Flags().StringVar(&featureTestDate, "date", "now", "Evaluation date (ISO 8601 format or 'now')")
+	featuresTestMatrixCmd.Flags().StringVar(&featureData, "data", "", "JSON payload for WASM features (from file with @file.json, stdin with -, or inline)")
+	addPayloadFlags(featuresTestMatrixCmd)
+	featuresTestMatrixCmd.Flags().BoolVar(&featureFailOnFalse, "fail-on-false", false, "Exit with code 2 when the feature is inactive for any combination")
+}
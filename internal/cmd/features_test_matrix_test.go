@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixContexts(t *testing.T) {
+	assert.Equal(t, []string{"", "prod", "prod/eu"}, matrixContexts([]string{"/", "prod", "/prod/eu/", "prod"}))
+	assert.Equal(t, []string{""}, matrixContexts(nil))
+}
+
+func TestMatrixUsers(t *testing.T) {
+	assert.Equal(t, []string{"alice", "bob"}, matrixUsers([]string{"alice", " ", "bob", "alice"}))
+	assert.Equal(t, []string{""}, matrixUsers(nil))
+}
+
+func TestEvaluateMatrix_OrdersByUserThenContext(t *testing.T) {
+	var running, maxRunning int32
+	test := func(ctx context.Context, user, contextPath string) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&running, -1)
+		time.Sleep(5 * time.Millisecond)
+		if user == "bob" && contextPath == "/prod" {
+			return nil, fmt.Errorf("boom")
+		}
+		return user + contextPath, nil
+	}
+
+	matrix := evaluateMatrix(context.Background(), "feat", []string{"alice", "bob"}, []string{"", "prod"}, 3, test)
+
+	assert.Equal(t, []string{rootContextLabel, "prod"}, matrix.Contexts)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	assert.Equal(t, []FeatureTestMatrixCell{
+		{User: "alice", Context: rootContextLabel, Active: "alice"},
+		{User: "alice", Context: "prod", Active: "alice/prod"},
+		{User: "bob", Context: rootContextLabel, Active: "bob"},
+		{User: "bob", Context: "prod", Error: "boom"},
+	}, matrix.Results)
+
+	header, rows := testMatrixRows(matrix)
+	assert.Equal(t, []string{"user", rootContextLabel, "prod"}, header)
+	assert.Equal(t, [][]string{{"alice", "alice", "alice/prod"}, {"bob", "bob", "error"}}, rows)
+}
+
+// setupTestMatrixServer answers feature tests as active only for alice outside dev
+func setupTestMatrixServer(t *testing.T) {
+	t.Helper()
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/api/admin/tenants/acme/features/feat/test")
+		if r.Method != http.MethodPost || path == r.URL.Path {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+			return
+		}
+		active := r.URL.Query().Get("user") == "alice" && path != "/dev"
+		fmt.Fprintf(w, `{"name": "feat", "active": %t, "project": "shop"}`, active)
+	})
+
+	t.Cleanup(func() {
+		testMatrixContexts, testMatrixUsers, featureFailOnFalse = nil, nil, false
+	})
+}
+
+func TestFeaturesTestMatrixCmd_Table(t *testing.T) {
+	setupTestMatrixServer(t)
+	outputFormat = "table"
+	testMatrixContexts = []string{"/", "dev", "prod/eu"}
+	testMatrixUsers = []string{"alice", "bob"}
+
+	var buf bytes.Buffer
+	featuresTestMatrixCmd.SetOut(&buf)
+	defer featuresTestMatrixCmd.SetOut(nil)
+	require.NoError(t, featuresTestMatrixCmd.RunE(featuresTestMatrixCmd, []string{"feat"}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"user", "(root)", "dev", "prod/eu"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"alice", "true", "false", "true"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"bob", "false", "false", "false"}, strings.Fields(lines[2]))
+}
+
+func TestFeaturesTestMatrixCmd_JSONFailOnFalse(t *testing.T) {
+	setupTestMatrixServer(t)
+	outputFormat = "json"
+	testMatrixContexts = []string{"prod"}
+	testMatrixUsers = []string{"alice", "bob"}
+	featureFailOnFalse = true
+
+	var buf bytes.Buffer
+	featuresTestMatrixCmd.SetOut(&buf)
+	defer featuresTestMatrixCmd.SetOut(nil)
+	err := featuresTestMatrixCmd.RunE(featuresTestMatrixCmd, []string{"feat"})
+	require.Error(t, err)
+	assert.Equal(t, ExitInactive, exitCode(err))
+	assert.Contains(t, err.Error(), "inactive for 1 of 2 combination(s)")
+
+	var matrix FeatureTestMatrix
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &matrix))
+	assert.Equal(t, []string{"prod"}, matrix.Contexts)
+	require.Len(t, matrix.Results, 2)
+	assert.Equal(t, true, matrix.Results[0].Active)
+	assert.Equal(t, "bob", matrix.Results[1].User)
+}
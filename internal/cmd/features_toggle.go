@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	featuresToggleEnable  bool
+	featuresToggleDisable bool
+	featuresToggleTag     string
+	featuresToggleDryRun  bool
+	featuresToggleForce   bool
+)
+
+// TogglePlanRow describes the change planned for one feature
+type TogglePlanRow struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Current bool   `json:"current"`
+	Target  bool   `json:"target"`
+	Change  bool   `json:"change"`
+}
+
+// featuresToggleCmd enables or disables many features at once
+var featuresToggleCmd = &cobra.Command{
+	Use:         "toggle [feature-id-or-name...]",
+	Short:       "Enable or disable many features at once",
+	Annotations: map[string]string{"route": "PATCH /api/admin/tenants/:tenant/features"},
+	Long: `Enable or disable a set of features with a single batch request.
+
+Features are selected by:
+  --tag       Features with this tag (server-side filter)
+  --project   Features in this project (global flag)
+  arguments   Explicit feature IDs or names
+
+Filters are combined: "--tag beta --project shop" selects beta features of the
+shop project. At least one selector is required on the command line: a
+project set in the profile, IZ_PROJECT or .iz.yaml only narrows the others.
+
+The matching features and the planned change are shown first, then a
+confirmation is requested (skip it with --force). Features already in the
+target state are left untouched.
+
+Examples:
+  # Enable every feature tagged "beta"
+  iz admin features toggle --tag beta --enable
+
+  # Disable all features of a project without prompting
+  iz admin features toggle --project shop --disable --force
+
+  # Toggle explicit features by name or ID
+  iz admin features toggle new-checkout dark-mode --enable --project shop
+
+  # Only show what would change
+  iz admin features toggle --tag beta --disable --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		if featuresToggleEnable == featuresToggleDisable {
+			return fmt.Errorf("exactly one of --enable or --disable is required")
+		}
+		// A project from the profile, IZ_PROJECT or .iz.yaml does not count:
+		// it would toggle the whole project by accident
+		if len(args) == 0 && featuresToggleTag == "" && !cmd.Flags().Changed("project") {
+			return fmt.Errorf("select features with --tag, --project or feature arguments")
+		}
+		enabled := featuresToggleEnable
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, featuresToggleTag, izanami.ParseFeatures)
+		if err != nil {
+			return err
+		}
+
+		selected, err := selectFeaturesForToggle(features, cfg.Project, args)
+		if err != nil {
+			return err
+		}
+		if len(selected) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No matching features")
+			return nil
+		}
+
+		plan := buildTogglePlan(selected, enabled)
+		if err := output.PrintTo(cmd.OutOrStdout(), plan, output.Format(outputFormat)); err != nil {
+			return err
+		}
+
+		patches := buildTogglePatches(plan)
+		action, question := "disable", "Disable"
+		if enabled {
+			action, question = "enable", "Enable"
+		}
+		if len(patches) == 0 {
+			fmt.Fprintf(statusOut(cmd), "All %d feature(s) are already %sd\n", len(plan), action)
+			return nil
+		}
+		if enabled && hasDependencies(selected) {
+			all := features
+			if featuresToggleTag != "" {
+				// Dependencies may lie outside of the tag
+				if all, err = izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures); err != nil {
+					return err
+				}
+			}
+			warnToggleDependencies(cmd, all, plan)
+		}
+		if featuresToggleDryRun {
+			fmt.Fprintf(statusOut(cmd), "Dry run: %d feature(s) would be %sd\n", len(patches), action)
+			return nil
+		}
+
+		if !featuresToggleForce {
+			if !confirmAction(cmd, fmt.Sprintf("%s %d feature(s)?", question, len(patches))) {
+				return nil
+			}
+		}
+
+		var changed, projects []string
+		for _, row := range plan {
+			if row.Change {
+				changed = append(changed, row.ID)
+				projects = append(projects, row.Project)
+			}
+		}
+		if err := saveMutationSnapshot(cmd, client, projects, false); err != nil {
+			return err
+		}
+		if err := client.PatchFeatures(ctx, cfg.Tenant, patches); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Features %sd successfully: %d\n", action, len(patches))
+		runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"ids": changed, "enabled": enabled})
+		return nil
+	},
+}
+
+// selectFeaturesForToggle filters features by project and, if given, by explicit IDs or names.
+// Every explicit identifier must match at least one feature.
+func selectFeaturesForToggle(features []izanami.Feature, project string, idsOrNames []string) ([]izanami.Feature, error) {
+	var candidates []izanami.Feature
+	for _, f := range features {
+		if project == "" || f.Project == project {
+			candidates = append(candidates, f)
+		}
+	}
+
+	if len(idsOrNames) == 0 {
+		return candidates, nil
+	}
+
+	nameCandidates := make([]nameCandidate, len(candidates))
+	for i, f := range candidates {
+		nameCandidates[i] = nameCandidate{ID: f.ID, Name: f.Name, Scope: "project " + f.Project}
+		if exactID {
+			nameCandidates[i].Name = ""
+		}
+	}
+
+	var selected []izanami.Feature
+	seen := make(map[string]bool)
+	for _, idOrName := range idsOrNames {
+		matches := matchNameOrID(idOrName, nameCandidates)
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no matching feature '%s'", idOrName)
+		}
+		if len(matches) > 1 {
+			return nil, ambiguousNameError("feature", idOrName, "use --project to disambiguate or provide UUID instead", nameCandidates, matches)
+		}
+		if f := candidates[matches[0]]; !seen[f.ID] {
+			seen[f.ID] = true
+			selected = append(selected, f)
+		}
+	}
+	return selected, nil
+}
+
+// buildTogglePlan describes the target state of each selected feature
+func buildTogglePlan(features []izanami.Feature, enabled bool) []TogglePlanRow {
+	plan := make([]TogglePlanRow, len(features))
+	for i, f := range features {
+		plan[i] = TogglePlanRow{
+			ID:      f.ID,
+			Name:    f.Name,
+			Project: f.Project,
+			Current: f.Enabled,
+			Target:  enabled,
+			Change:  f.Enabled != enabled,
+		}
+	}
+	return plan
+}
+
+// buildTogglePatches creates the patch operations for features that need to change
+func buildTogglePatches(plan []TogglePlanRow) []izanami.FeaturePatch {
+	var patches []izanami.FeaturePatch
+	for _, row := range plan {
+		if row.Change {
+			patches = append(patches, izanami.FeaturePatch{
+				Op:    "replace",
+				Path:  "/" + row.ID + "/enabled",
+				Value: row.Target,
+			})
+		}
+	}
+	return patches
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresToggleCmd)
+
+	featuresToggleCmd.Flags().BoolVar(&featuresToggleEnable, "enable", false, "Enable the selected features")
+	featuresToggleCmd.Flags().BoolVar(&featuresToggleDisable, "disable", false, "Disable the selected features")
+	featuresToggleCmd.Flags().StringVar(&featuresToggleTag, "tag", "", "Select features with this tag")
+	featuresToggleCmd.Flags().BoolVar(&featuresToggleDryRun, "dry-run", false, "Show the plan without applying it")
+	featuresToggleCmd.Flags().BoolVarP(&featuresToggleForce, "force", "f", false, "Skip confirmation prompt")
+	featuresToggleCmd.MarkFlagsMutuallyExclusive("enable", "disable")
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func toggleTestFeatures() []izanami.Feature {
+	return []izanami.Feature{
+		{ID: "11111111-1111-1111-1111-111111111111", Name: "checkout", Project: "shop", Enabled: false},
+		{ID: "22222222-2222-2222-2222-222222222222", Name: "search", Project: "shop", Enabled: true},
+		{ID: "33333333-3333-3333-3333-333333333333", Name: "checkout", Project: "web", Enabled: false},
+	}
+}
+
+func TestSelectFeaturesForToggle_ByProject(t *testing.T) {
+	selected, err := selectFeaturesForToggle(toggleTestFeatures(), "shop", nil)
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	assert.Equal(t, "checkout", selected[0].Name)
+	assert.Equal(t, "search", selected[1].Name)
+}
+
+func TestSelectFeaturesForToggle_ByNameAndID(t *testing.T) {
+	selected, err := selectFeaturesForToggle(toggleTestFeatures(), "shop", []string{"checkout", "22222222-2222-2222-2222-222222222222", "search"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2, "duplicates must be removed")
+	assert.Equal(t, "shop", selected[0].Project)
+}
+
+func TestSelectFeaturesForToggle_AmbiguousName(t *testing.T) {
+	_, err := selectFeaturesForToggle(toggleTestFeatures(), "", []string{"checkout"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "multiple features named 'checkout'")
+}
+
+func TestSelectFeaturesForToggle_UnknownFeature(t *testing.T) {
+	_, err := selectFeaturesForToggle(toggleTestFeatures(), "", []string{"missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no matching feature 'missing'")
+}
+
+func TestBuildTogglePatches_SkipsUnchanged(t *testing.T) {
+	features, err := selectFeaturesForToggle(toggleTestFeatures(), "shop", nil)
+	require.NoError(t, err)
+
+	plan := buildTogglePlan(features, true)
+	require.Len(t, plan, 2)
+	assert.True(t, plan[0].Change)
+	assert.False(t, plan[1].Change)
+
+	patches := buildTogglePatches(plan)
+	require.Len(t, patches, 1)
+	assert.Equal(t, "replace", patches[0].Op)
+	assert.Equal(t, "/11111111-1111-1111-1111-111111111111/enabled", patches[0].Path)
+	assert.Equal(t, true, patches[0].Value)
+}
+
+func TestFeaturesToggleCmd_RequiresExplicitSelector(t *testing.T) {
+	origCfg := cfg
+	t.Cleanup(func() {
+		cfg = origCfg
+		featuresToggleEnable = false
+	})
+	// The project comes from the profile, not from --project
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost:1", JwtToken: "token", Timeout: 5, Tenant: "acme", Project: "shop"}
+	featuresToggleEnable = true
+
+	err := featuresToggleCmd.RunE(featuresToggleCmd, nil)
+	assert.EqualError(t, err, "select features with --tag, --project or feature arguments")
+}
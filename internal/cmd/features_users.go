@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var featureUsersContext string
+
+// ruleTypeUserList is the activation rule type targeting explicit users
+const ruleTypeUserList = "UserList"
+
+// TargetedUser is a user targeted by a UserList rule
+type TargetedUser struct {
+	User      string `json:"user"`
+	Condition int    `json:"condition"`
+}
+
+// featuresUsersCmd groups the user targeting commands
+var featuresUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage the users targeted by a feature",
+	Long: `Manage the users of a feature's UserList rule without rewriting the feature.
+
+Users are added to the first UserList condition (one is created if needed) and
+removed from every UserList condition. Other conditions are left untouched.
+
+Use --context to edit the overload of the feature in a context instead of the
+feature itself (the overload must exist and --project is required).
+
+Examples:
+  # List targeted users
+  iz admin features users list my-feature --project my-project
+
+  # Target two more users
+  iz admin features users add my-feature alice bob --project my-project
+
+  # Stop targeting a user in the PROD overload
+  iz admin features users remove my-feature alice --project my-project --context PROD`,
+}
+
+// featuresUsersListCmd lists the targeted users of a feature
+var featuresUsersListCmd = &cobra.Command{
+	Use:         "list <feature-id-or-name>",
+	Short:       "List the users targeted by a feature",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
+	Args:        cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		var conditions []interface{}
+		if featureUsersContext != "" {
+			if err := validateOverloadScope(); err != nil {
+				return err
+			}
+			overload, _, err := fetchOverloadStrategy(ctx, client, args[0], featureUsersContext)
+			if err != nil {
+				return err
+			}
+			conditions, _ = overload["conditions"].([]interface{})
+		} else {
+			featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, args[0], cmd)
+			if err != nil {
+				return err
+			}
+			raw, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.Identity)
+			if err != nil {
+				return err
+			}
+			var feature map[string]interface{}
+			if err := json.Unmarshal(raw, &feature); err != nil {
+				return fmt.Errorf("failed to parse feature: %w", err)
+			}
+			conditions, _ = feature["conditions"].([]interface{})
+		}
+
+		users := targetedUsers(conditions)
+		if len(users) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No targeted users")
+			return nil
+		}
+		return output.PrintTo(cmd.OutOrStdout(), users, output.Format(outputFormat))
+	},
+}
+
+// featuresUsersAddCmd adds users to the UserList rule of a feature
+var featuresUsersAddCmd = &cobra.Command{
+	Use:         "add <feature-id-or-name> <user>...",
+	Short:       "Target users with a feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Args:        cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		added := 0
+		update := func(conditions []interface{}) ([]interface{}, error) {
+			conditions, added = addTargetedUsers(conditions, args[1:])
+			return conditions, nil
+		}
+		if err := updateTargetedUsers(cmd, args[0], update); err != nil {
+			return err
+		}
+		fmt.Fprintf(statusOut(cmd), "Users added: %d (%d already targeted)\n", added, len(args[1:])-added)
+		return nil
+	},
+}
+
+// featuresUsersRemoveCmd removes users from the UserList rules of a feature
+var featuresUsersRemoveCmd = &cobra.Command{
+	Use:         "remove <feature-id-or-name> <user>...",
+	Short:       "Stop targeting users with a feature",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/features/:id"},
+	Args:        cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		removed := 0
+		update := func(conditions []interface{}) ([]interface{}, error) {
+			conditions, removed = removeTargetedUsers(conditions, args[1:])
+			return conditions, nil
+		}
+		if err := updateTargetedUsers(cmd, args[0], update); err != nil {
+			return err
+		}
+		fmt.Fprintf(statusOut(cmd), "Users removed: %d\n", removed)
+		return nil
+	},
+}
+
+// updateTargetedUsers applies a conditions update to the feature or to its overload in --context
+func updateTargetedUsers(cmd *cobra.Command, featureIDOrName string, update func([]interface{}) ([]interface{}, error)) error {
+	if featureUsersContext == "" {
+		return updateFeatureConditions(cmd, featureIDOrName, update, "Feature updated")
+	}
+
+	if err := validateOverloadScope(); err != nil {
+		return err
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext()
+	strategy, featureName, err := fetchOverloadStrategy(ctx, client, featureIDOrName, featureUsersContext)
+	if err != nil {
+		return err
+	}
+
+	conditions, _ := strategy["conditions"].([]interface{})
+	conditions, err = update(conditions)
+	if err != nil {
+		return err
+	}
+	strategy["conditions"] = conditions
+
+	if err := client.SetOverload(ctx, cfg.Tenant, cfg.Project, featureUsersContext, featureName, strategy, false); err != nil {
+		return err
+	}
+	fmt.Fprintf(statusOut(cmd), "Overload updated: %s in context %s\n", featureName, featureUsersContext)
+	runHooks(cmd, izanami.HookOverloadSet, map[string]interface{}{"feature": featureName, "context": featureUsersContext})
+	return nil
+}
+
+// validateOverloadScope checks the flags needed to address an overload
+func validateOverloadScope() error {
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+	if cfg.Project == "" {
+		return fmt.Errorf("project is required with --context (use --project flag or IZ_PROJECT)")
+	}
+	return nil
+}
+
+// fetchOverloadStrategy returns the overload of a feature in a context as a strategy map,
+// along with the feature name (overloads are addressed by name).
+func fetchOverloadStrategy(ctx context.Context, client *izanami.AdminClient, featureIDOrName, contextPath string) (map[string]interface{}, string, error) {
+	featureName, err := resolveOverloadFeatureName(ctx, client, featureIDOrName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	raw, err := izanami.GetOverload(client, ctx, cfg.Tenant, cfg.Project, featureName, contextPath, izanami.Identity)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w (create it first with 'iz admin overloads set')", err)
+	}
+
+	var overload map[string]interface{}
+	if err := json.Unmarshal(raw, &overload); err != nil {
+		return nil, "", fmt.Errorf("failed to parse overload: %w", err)
+	}
+
+	// Keep only the strategy fields accepted by the overload endpoint
+	strategy := map[string]interface{}{
+		"enabled":    overload["enabled"],
+		"resultType": "boolean",
+		"conditions": overload["conditions"],
+	}
+	if resultType, ok := overload["resultType"].(string); ok && resultType != "" {
+		strategy["resultType"] = resultType
+	}
+	if value, ok := overload["value"]; ok {
+		strategy["value"] = value
+	}
+	return strategy, featureName, nil
+}
+
+// userListRule returns the UserList rule of a condition, if any
+func userListRule(c interface{}) (map[string]interface{}, bool) {
+	condition, ok := c.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	rule, ok := condition["rule"].(map[string]interface{})
+	if !ok || rule["type"] != ruleTypeUserList {
+		return nil, false
+	}
+	return rule, true
+}
+
+// ruleUsers returns the users of a UserList rule
+func ruleUsers(rule map[string]interface{}) []string {
+	raw, _ := rule["users"].([]interface{})
+	users := make([]string, 0, len(raw))
+	for _, u := range raw {
+		if s, ok := u.(string); ok {
+			users = append(users, s)
+		}
+	}
+	return users
+}
+
+// toInterfaceSlice converts users to the JSON representation of a rule
+func toInterfaceSlice(users []string) []interface{} {
+	result := make([]interface{}, len(users))
+	for i, u := range users {
+		result[i] = u
+	}
+	return result
+}
+
+// targetedUsers lists the users of every UserList rule
+func targetedUsers(conditions []interface{}) []TargetedUser {
+	var users []TargetedUser
+	for i, c := range conditions {
+		rule, ok := userListRule(c)
+		if !ok {
+			continue
+		}
+		for _, u := range ruleUsers(rule) {
+			users = append(users, TargetedUser{User: u, Condition: i + 1})
+		}
+	}
+	return users
+}
+
+// addTargetedUsers adds users to the first UserList rule, creating one if needed.
+// It returns the updated conditions and the number of users actually added.
+func addTargetedUsers(conditions []interface{}, users []string) ([]interface{}, int) {
+	existing := make(map[string]bool)
+	for _, u := range targetedUsers(conditions) {
+		existing[u.User] = true
+	}
+
+	var toAdd []string
+	for _, u := range users {
+		if !existing[u] {
+			existing[u] = true
+			toAdd = append(toAdd, u)
+		}
+	}
+	if len(toAdd) == 0 {
+		return conditions, 0
+	}
+
+	for _, c := range conditions {
+		if rule, ok := userListRule(c); ok {
+			rule["users"] = toInterfaceSlice(append(ruleUsers(rule), toAdd...))
+			return conditions, len(toAdd)
+		}
+	}
+
+	conditions = append(conditions, map[string]interface{}{
+		"rule": map[string]interface{}{
+			"type":  ruleTypeUserList,
+			"users": toInterfaceSlice(toAdd),
+		},
+	})
+	return conditions, len(toAdd)
+}
+
+// removeTargetedUsers removes users from every UserList rule.
+// Conditions whose user list becomes empty are kept: dropping the only condition
+// of an enabled feature would activate it for every user.
+// It returns the updated conditions and the number of removed entries.
+func removeTargetedUsers(conditions []interface{}, users []string) ([]interface{}, int) {
+	toRemove := make(map[string]bool, len(users))
+	for _, u := range users {
+		toRemove[u] = true
+	}
+
+	removed := 0
+	result := make([]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		rule, ok := userListRule(c)
+		if !ok {
+			result = append(result, c)
+			continue
+		}
+
+		current := ruleUsers(rule)
+		kept := make([]string, 0, len(current))
+		for _, u := range current {
+			if toRemove[u] {
+				removed++
+			} else {
+				kept = append(kept, u)
+			}
+		}
+		rule["users"] = toInterfaceSlice(kept)
+		result = append(result, c)
+	}
+	return result, removed
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresUsersCmd)
+	featuresUsersCmd.AddCommand(featuresUsersListCmd)
+	featuresUsersCmd.AddCommand(featuresUsersAddCmd)
+	featuresUsersCmd.AddCommand(featuresUsersRemoveCmd)
+	for _, c := range []*cobra.Command{featuresUsersListCmd, featuresUsersAddCmd, featuresUsersRemoveCmd} {
+		c.ValidArgsFunction = completeFeatureNames
+	}
+
+	featuresUsersCmd.PersistentFlags().StringVar(&featureUsersContext, "context", "", "Edit the overload of the feature in this context path (e.g. PROD/mobile)")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func userListCondition(users ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"rule": map[string]interface{}{"type": "UserList", "users": users},
+	}
+}
+
+func TestAddTargetedUsers_CreatesRule(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{"rule": map[string]interface{}{"type": "UserPercentage", "percentage": 10.0}},
+	}
+
+	conditions, added := addTargetedUsers(existing, []string{"alice", "bob", "alice"})
+
+	assert.Equal(t, 2, added)
+	require.Len(t, conditions, 2)
+	assert.Equal(t, userListCondition("alice", "bob"), conditions[1])
+}
+
+func TestAddTargetedUsers_MergesIntoExistingRule(t *testing.T) {
+	existing := []interface{}{userListCondition("alice")}
+
+	conditions, added := addTargetedUsers(existing, []string{"alice", "carol"})
+
+	assert.Equal(t, 1, added)
+	require.Len(t, conditions, 1)
+	assert.Equal(t, userListCondition("alice", "carol"), conditions[0])
+}
+
+func TestRemoveTargetedUsers(t *testing.T) {
+	period := map[string]interface{}{"period": map[string]interface{}{"timezone": "UTC"}}
+	existing := []interface{}{
+		userListCondition("alice", "bob"),
+		period,
+		userListCondition("bob"),
+	}
+
+	conditions, removed := removeTargetedUsers(existing, []string{"bob", "dave"})
+
+	assert.Equal(t, 2, removed)
+	require.Len(t, conditions, 3, "emptied user list must be kept")
+	assert.Equal(t, userListCondition("alice"), conditions[0])
+	assert.Equal(t, period, conditions[1])
+	assert.Equal(t, userListCondition([]interface{}{}...), conditions[2])
+}
+
+func TestRemoveTargetedUsers_OnlyUserOfOnlyCondition(t *testing.T) {
+	conditions, removed := removeTargetedUsers([]interface{}{userListCondition("alice")}, []string{"alice"})
+
+	// An empty conditions list would activate the feature for everyone
+	assert.Equal(t, 1, removed)
+	require.Len(t, conditions, 1)
+	assert.Equal(t, userListCondition([]interface{}{}...), conditions[0])
+}
+
+func TestTargetedUsers(t *testing.T) {
+	users := targetedUsers([]interface{}{
+		map[string]interface{}{"rule": map[string]interface{}{"type": "All"}},
+		userListCondition("alice", "bob"),
+	})
+
+	assert.Equal(t, []TargetedUser{{User: "alice", Condition: 2}, {User: "bob", Condition: 2}}, users)
+}
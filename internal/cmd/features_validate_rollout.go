@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	validateRolloutEnable bool
+	validateRolloutForce  bool
+)
+
+// RolloutValidation is the result of validate-rollout
+type RolloutValidation struct {
+	Feature    string                    `json:"feature"`
+	ID         string                    `json:"id"`
+	Project    string                    `json:"project"`
+	Passed     bool                      `json:"passed"`
+	Guardrails []izanami.GuardrailResult `json:"guardrails"`
+}
+
+// featuresValidateRolloutCmd checks the rollout guardrails before enabling a feature
+var featuresValidateRolloutCmd = &cobra.Command{
+	Use:          "validate-rollout <feature-id-or-name>",
+	Short:        "Check metrics guardrails before enabling a feature",
+	Annotations:  map[string]string{"route": "PATCH /api/admin/tenants/:tenant/features"},
+	SilenceUsage: true,
+	Long: `Evaluate the rollout guardrails of the config against Prometheus and print
+the result of each one. With --enable, the feature is enabled only when every
+guardrail holds.
+
+Guardrails are Prometheus instant queries whose value must stay within a min
+and/or max. A query that fails or returns no data fails its guardrail. For
+vector results, every series must hold. {{feature}} and {{project}} in a query
+are replaced by the feature checked, e.g. for metrics labelled by flag.
+
+Configure them in the config file:
+
+  rollout-guardrails:
+    prometheus-url: https://prometheus.example.com
+    headers:
+      Authorization: Bearer ${PROMETHEUS_TOKEN}
+    guardrails:
+      - name: error-rate
+        query: sum(rate(http_requests_total{status=~"5.."}[5m])) / sum(rate(http_requests_total[5m]))
+        max: 0.01
+      - name: p99-latency
+        query: histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))
+        max: 0.5
+
+The command exits with code 7 when a guardrail fails, so that it can gate a
+pipeline.
+
+Examples:
+  # Evaluate the guardrails only
+  iz admin features validate-rollout new-checkout --project shop
+
+  # Enable the feature if the guardrails hold
+  iz admin features validate-rollout new-checkout --project shop --enable --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		guardrails := cfg.RolloutGuardrails
+		if guardrails == nil || len(guardrails.Guardrails) == 0 {
+			return fmt.Errorf("no rollout guardrails configured (add rollout-guardrails to %s, see 'iz admin features validate-rollout --help')", izanami.GetConfigPath())
+		}
+		if errs := izanami.ValidateGuardrails(guardrails); len(errs) > 0 {
+			return fmt.Errorf("invalid rollout-guardrails: %s", errs[0].Message)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		ctx := commandContext()
+		features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, "", izanami.ParseFeatures)
+		if err != nil {
+			return err
+		}
+		selected, err := selectFeaturesForToggle(features, cfg.Project, args)
+		if err != nil {
+			return err
+		}
+		feature := selected[0]
+
+		httpClient := &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second}
+		results := izanami.EvaluateGuardrails(ctx, httpClient, guardrails, feature.Name, feature.Project)
+		validation := RolloutValidation{Feature: feature.Name, ID: feature.ID, Project: feature.Project, Passed: true, Guardrails: results}
+		failed := 0
+		for _, r := range results {
+			if !r.Passed() {
+				failed++
+				validation.Passed = false
+			}
+		}
+
+		if outputFormat == "json" {
+			if err := output.PrintTo(cmd.OutOrStdout(), validation, output.JSON); err != nil {
+				return err
+			}
+		} else if err := printGuardrailResults(cmd, results); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return withExitCode(ExitGuardrail, fmt.Errorf("%d of %d guardrail(s) failed: rollout of %s refused", failed, len(results), feature.Name))
+		}
+		fmt.Fprintf(statusOut(cmd), "All %d guardrail(s) hold for %s\n", len(results), feature.Name)
+		if !validateRolloutEnable {
+			return nil
+		}
+		return enableValidatedFeature(cmd, client, features, feature)
+	},
+}
+
+// printGuardrailResults prints the evaluation of each guardrail as a table
+func printGuardrailResults(cmd *cobra.Command, results []izanami.GuardrailResult) error {
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		value := "-"
+		if r.Value != nil {
+			value = strconv.FormatFloat(*r.Value, 'g', 6, 64)
+		}
+		rows[i] = []string{r.Name, value, r.Threshold, r.Status, r.Message}
+	}
+	return output.PrintRows(cmd.OutOrStdout(), []string{"GUARDRAIL", "VALUE", "THRESHOLD", "STATUS", "MESSAGE"}, rows)
+}
+
+// enableValidatedFeature enables a feature whose guardrails hold
+func enableValidatedFeature(cmd *cobra.Command, client *izanami.AdminClient, features []izanami.Feature, feature izanami.Feature) error {
+	if feature.Enabled {
+		fmt.Fprintf(statusOut(cmd), "Feature %s is already enabled\n", feature.Name)
+		return nil
+	}
+	plan := buildTogglePlan([]izanami.Feature{feature}, true)
+	if hasDependencies([]izanami.Feature{feature}) {
+		warnToggleDependencies(cmd, features, plan)
+	}
+	if !validateRolloutForce {
+		if !confirmAction(cmd, fmt.Sprintf("Enable feature %s?", feature.Name)) {
+			return nil
+		}
+	}
+	if err := client.PatchFeatures(commandContext(), cfg.Tenant, buildTogglePatches(plan)); err != nil {
+		return err
+	}
+	fmt.Fprintf(statusOut(cmd), "Feature %s enabled\n", feature.Name)
+	runHooks(cmd, izanami.HookFeatureUpdate, map[string]interface{}{"ids": []string{feature.ID}, "enabled": true})
+	return nil
+}
+
+func init() {
+	featuresCmd.AddCommand(featuresValidateRolloutCmd)
+
+	featuresValidateRolloutCmd.Flags().BoolVar(&validateRolloutEnable, "enable", false, "Enable the feature when every guardrail holds")
+	featuresValidateRolloutCmd.Flags().BoolVarP(&validateRolloutForce, "force", "f", false, "Skip confirmation prompt")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeaturesValidateRollout(t *testing.T) {
+	errorRate := "0.002"
+	var patches []izanami.FeaturePatch
+	server := setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/query":
+			assert.Equal(t, `errors{flag="checkout"}`, r.URL.Query().Get("query"))
+			io.WriteString(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1719824400,"`+errorRate+`"]}]}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"checkout","project":"shop","enabled":false}]`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/admin/tenants/acme/features":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patches))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	maxErrorRate := 0.01
+	t.Cleanup(func() {
+		validateRolloutEnable, validateRolloutForce = false, false
+		featuresValidateRolloutCmd.SetOut(nil)
+	})
+	cfg.RolloutGuardrails = &izanami.RolloutGuardrails{
+		PrometheusURL: server.URL,
+		Guardrails:    []izanami.Guardrail{{Name: "error-rate", Query: `errors{flag="{{feature}}"}`, Max: &maxErrorRate}},
+	}
+	outputFormat = "table"
+	var buf bytes.Buffer
+	featuresValidateRolloutCmd.SetOut(&buf)
+
+	// A violated guardrail refuses the rollout
+	errorRate = "0.05"
+	validateRolloutEnable, validateRolloutForce = true, true
+	err := featuresValidateRolloutCmd.RunE(featuresValidateRolloutCmd, []string{"checkout"})
+	assert.EqualError(t, err, "1 of 1 guardrail(s) failed: rollout of checkout refused")
+	assert.Equal(t, ExitGuardrail, exitCode(err))
+	assert.Contains(t, buf.String(), "violated")
+	assert.Nil(t, patches)
+
+	buf.Reset()
+	errorRate = "0.002"
+	require.NoError(t, featuresValidateRolloutCmd.RunE(featuresValidateRolloutCmd, []string{"checkout"}))
+	assert.Contains(t, buf.String(), "All 1 guardrail(s) hold for checkout")
+	assert.Equal(t, []izanami.FeaturePatch{{Op: "replace", Path: "/f1/enabled", Value: true}}, patches)
+
+	cfg.RolloutGuardrails = nil
+	assert.ErrorContains(t, featuresValidateRolloutCmd.RunE(featuresValidateRolloutCmd, []string{"checkout"}), "no rollout guardrails configured")
+}
@@ -2,14 +2,27 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
 
+var (
+	healthWatch       bool
+	healthWaitReady   bool
+	healthInterval    time.Duration
+	healthWaitTimeout time.Duration
+)
+
 // healthCmd represents the health command
 var healthCmd = &cobra.Command{
 	Use:         "health",
@@ -24,30 +37,18 @@ Exit codes:
   0 - Server is healthy (status: UP)
   1 - Server is unhealthy or unreachable`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if cfg == nil || cfg.LeaderURL == "" {
-			return fmt.Errorf("leader URL is required (set IZ_LEADER_URL or --url)")
-		}
-
-		// Create a minimal client just for health check (no auth required)
-		tempCfg := &izanami.ResolvedConfig{
-			LeaderURL:          cfg.LeaderURL,
-			Timeout:            cfg.Timeout,
-			Verbose:            cfg.Verbose,
-			InsecureSkipVerify: cfg.InsecureSkipVerify,
-		}
-
-		client, err := izanami.NewAdminClientNoAuth(tempCfg)
+		client, err := newHealthClient(cfg.Retries)
 		if err != nil {
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper for raw JSON
 		if outputFormat == "json" {
 			raw, err := izanami.Health(client, ctx, izanami.Identity)
 			if err != nil {
-				fmt.Fprintf(cmd.OutOrStderr(), "Error: %v\n", err)
+				fmt.Fprintf(statusOut(cmd), "Error: %v\n", err)
 				os.Exit(1)
 			}
 			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
@@ -56,12 +57,12 @@ Exit codes:
 		// For table output, use ParseHealthStatus mapper
 		health, err := izanami.Health(client, ctx, izanami.ParseHealthStatus)
 		if err != nil {
-			fmt.Fprintf(cmd.OutOrStderr(), "Error: %v\n", err)
+			fmt.Fprintf(statusOut(cmd), "Error: %v\n", err)
 			os.Exit(1)
 		}
 
 		if !health.Database {
-			fmt.Fprintf(cmd.OutOrStderr(), "Server is unhealthy: database check failed\n")
+			fmt.Fprintf(statusOut(cmd), "Server is unhealthy: database check failed\n")
 			output.PrintTo(cmd.OutOrStdout(), health, output.Format(outputFormat))
 			os.Exit(1)
 		}
@@ -77,6 +78,254 @@ Exit codes:
 	},
 }
 
+// adminHealthCmd checks health once, watches it, or waits until the server is ready
+var adminHealthCmd = &cobra.Command{
+	Use:         "health",
+	Short:       "Check, watch or wait for Izanami server health",
+	Annotations: map[string]string{"route": "GET /api/_health"},
+	Long: `Check the health of the Izanami server. No credentials are needed.
+
+The server is UP when it answers and its database check passes.
+
+Modes:
+  (default)     Check once; exits with 1 when the server is down
+  --watch       Poll every --interval and print one line per check, until Ctrl+C
+  --wait-ready  Poll every --interval until the server is up, for at most --timeout;
+                exits with 1 if it is still down, which makes it usable in
+                deployment scripts that must block until Izanami is up
+
+Note: on this command, --timeout is the maximum time --wait-ready waits
+(default 2m), not the HTTP request timeout.
+
+With --output json, each check is a JSON object with time, url, up, database,
+version, latencyMs and error; --watch prints one object per line.
+
+Examples:
+  # Check once
+  iz admin health
+
+  # Watch every 10 seconds
+  iz admin health --watch --interval 10s
+
+  # Block a deployment until Izanami is up
+  iz admin health --wait-ready --timeout 2m --interval 2s`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	// Health needs no credentials: only load the config, skipping the admin auth check
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCmd.PersistentPreRunE(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if healthWatch && healthWaitReady {
+			return fmt.Errorf("--watch and --wait-ready are mutually exclusive")
+		}
+		if healthInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		if healthWaitTimeout <= 0 {
+			return fmt.Errorf("--timeout must be positive")
+		}
+
+		// Polling modes retry by themselves: a failed check is just the next poll
+		retries := cfg.Retries
+		if healthWatch || healthWaitReady {
+			retries = 0
+		}
+		client, err := newHealthClient(retries)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(commandContext())
+		defer cancel()
+
+		// Handle Ctrl+C gracefully
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			select {
+			case <-sigCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		switch {
+		case healthWatch:
+			return watchHealth(ctx, cmd, client)
+		case healthWaitReady:
+			return waitHealthReady(ctx, cmd, client)
+		}
+
+		sample := checkHealth(ctx, client)
+		if err := printHealthSample(cmd.OutOrStdout(), sample); err != nil {
+			return err
+		}
+		if !sample.Up {
+			return fmt.Errorf("server is unhealthy: %s", sample.reason())
+		}
+		return nil
+	},
+}
+
+// healthSample is the result of one health check
+type healthSample struct {
+	Time      time.Time `json:"time"`
+	URL       string    `json:"url"`
+	Up        bool      `json:"up"`
+	Database  bool      `json:"database"`
+	Version   string    `json:"version,omitempty"`
+	LatencyMs int64     `json:"latencyMs"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// reason explains why a sample is down
+func (s healthSample) reason() string {
+	if s.Error != "" {
+		return s.Error
+	}
+	return "database check failed"
+}
+
+// newHealthClient creates a client without authentication for health checks
+func newHealthClient(retries int) (*izanami.AdminClient, error) {
+	if cfg == nil || cfg.LeaderURL == "" {
+		return nil, fmt.Errorf("leader URL is required (set IZ_LEADER_URL or --url)")
+	}
+
+	// Create a minimal client just for health check (no auth required)
+	tempCfg := &izanami.ResolvedConfig{
+		LeaderURL:          cfg.LeaderURL,
+		Timeout:            cfg.Timeout,
+		Retries:            retries,
+		RetryMaxWait:       cfg.RetryMaxWait,
+		Verbose:            cfg.Verbose,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		CACertFile:         cfg.CACertFile,
+		ClientCertFile:     cfg.ClientCertFile,
+		ClientKeyFile:      cfg.ClientKeyFile,
+		HTTPHeaders:        cfg.HTTPHeaders,
+		ProxyURL:           cfg.ProxyURL,
+	}
+	return izanami.NewAdminClientNoAuth(tempCfg)
+}
+
+// checkHealth runs one health check. Failures are recorded in the sample.
+func checkHealth(ctx context.Context, client *izanami.AdminClient) healthSample {
+	start := time.Now()
+	sample := healthSample{Time: start, URL: cfg.LeaderURL}
+	health, err := izanami.Health(client, ctx, izanami.ParseHealthStatus)
+	sample.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		sample.Error = err.Error()
+		return sample
+	}
+	sample.Database = health.Database
+	sample.Version = health.Version
+	sample.Up = health.Database
+	return sample
+}
+
+// healthStatusLabel returns the colorized UP/DOWN label of a sample
+func healthStatusLabel(sample healthSample) string {
+	if sample.Up {
+		return color.GreenString("UP")
+	}
+	return color.RedString("DOWN")
+}
+
+// printHealthSample prints the details of a health check
+func printHealthSample(w io.Writer, sample healthSample) error {
+	if outputFormat == "json" {
+		return output.PrintTo(w, sample, output.JSON)
+	}
+	fmt.Fprintf(w, "Status:   %s\n", healthStatusLabel(sample))
+	if sample.Error != "" {
+		fmt.Fprintf(w, "Error:    %s\n", sample.Error)
+	} else {
+		fmt.Fprintf(w, "Database: %v\n", sample.Database)
+	}
+	if sample.Version != "" {
+		fmt.Fprintf(w, "Version:  %s\n", sample.Version)
+	}
+	fmt.Fprintf(w, "URL:      %s\n", sample.URL)
+	fmt.Fprintf(w, "Latency:  %dms\n", sample.LatencyMs)
+	return nil
+}
+
+// printHealthLine prints a health check as one line (one JSON object per line in JSON mode)
+func printHealthLine(w io.Writer, sample healthSample) error {
+	if outputFormat == "json" {
+		data, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	line := fmt.Sprintf("%s  %-4s", sample.Time.Format("15:04:05"), healthStatusLabel(sample))
+	if sample.Error != "" {
+		line += "  error: " + sample.Error
+	} else {
+		line += fmt.Sprintf("  database=%v", sample.Database)
+		if sample.Version != "" {
+			line += "  version=" + sample.Version
+		}
+		line += fmt.Sprintf("  %dms", sample.LatencyMs)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// watchHealth prints a health check every interval until the context is cancelled
+func watchHealth(ctx context.Context, cmd *cobra.Command, client *izanami.AdminClient) error {
+	for {
+		sample := checkHealth(ctx, client)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := printHealthLine(cmd.OutOrStdout(), sample); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(healthInterval):
+		}
+	}
+}
+
+// waitHealthReady polls health every interval until the server is up or the
+// --timeout delay is over
+func waitHealthReady(ctx context.Context, cmd *cobra.Command, client *izanami.AdminClient) error {
+	ctx, cancel := context.WithTimeout(ctx, healthWaitTimeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		sample := checkHealth(ctx, client)
+		if sample.Up {
+			return printHealthSample(cmd.OutOrStdout(), sample)
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("Izanami at %s is not ready after %s: %s", cfg.LeaderURL, healthWaitTimeout, sample.reason())
+		}
+		fmt.Fprintf(statusOut(cmd), "Waiting for Izanami at %s (attempt %d): %s\n", cfg.LeaderURL, attempt, sample.reason())
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Izanami at %s is not ready after %s: %s", cfg.LeaderURL, healthWaitTimeout, sample.reason())
+		case <-time.After(healthInterval):
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(healthCmd)
+	adminCmd.AddCommand(adminHealthCmd)
+
+	adminHealthCmd.Flags().BoolVar(&healthWatch, "watch", false, "Poll health every --interval until interrupted")
+	adminHealthCmd.Flags().BoolVar(&healthWaitReady, "wait-ready", false, "Poll until the server is up, for at most --timeout")
+	adminHealthCmd.Flags().DurationVar(&healthInterval, "interval", 10*time.Second, "Delay between checks with --watch or --wait-ready")
+	adminHealthCmd.Flags().DurationVar(&healthWaitTimeout, "timeout", 2*time.Minute, "Maximum time to wait with --wait-ready")
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminHealthTest(t *testing.T, handler http.HandlerFunc) *bytes.Buffer {
+	t.Helper()
+	setupServerTest(t, handler)
+	cfg.JwtToken, cfg.Tenant = "", ""
+	t.Cleanup(func() {
+		healthWatch, healthWaitReady = false, false
+		healthInterval, healthWaitTimeout = 10*time.Second, 2*time.Minute
+		adminHealthCmd.SetOut(nil)
+	})
+	outputFormat = "table"
+
+	var buf bytes.Buffer
+	adminHealthCmd.SetOut(&buf)
+	return &buf
+}
+
+func TestAdminHealthCmd_Check(t *testing.T) {
+	database := "true"
+	buf := setupAdminHealthTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/_health", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"database": `+database+`, "version": "2.5.0"}`)
+	})
+
+	require.NoError(t, adminHealthCmd.RunE(adminHealthCmd, nil))
+	assert.Contains(t, buf.String(), "UP")
+	assert.Contains(t, buf.String(), "Version:  2.5.0")
+
+	database = "false"
+	buf.Reset()
+	err := adminHealthCmd.RunE(adminHealthCmd, nil)
+	assert.EqualError(t, err, "server is unhealthy: database check failed")
+	assert.Contains(t, buf.String(), "DOWN")
+
+	outputFormat = "json"
+	buf.Reset()
+	require.Error(t, adminHealthCmd.RunE(adminHealthCmd, nil))
+	assert.Contains(t, buf.String(), `"up": false`)
+	assert.Contains(t, buf.String(), `"version": "2.5.0"`)
+}
+
+func TestAdminHealthCmd_WaitReady(t *testing.T) {
+	var calls int32
+	buf := setupAdminHealthTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, `{"message": "starting"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"database": true}`)
+	})
+	healthWaitReady = true
+	healthInterval = 10 * time.Millisecond
+
+	require.NoError(t, adminHealthCmd.RunE(adminHealthCmd, nil))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, strings.Count(buf.String(), "Waiting for Izanami"))
+	assert.Contains(t, buf.String(), "UP")
+}
+
+func TestAdminHealthCmd_WaitReadyTimeout(t *testing.T) {
+	setupAdminHealthTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"database": false}`)
+	})
+	healthWaitReady = true
+	healthInterval = 10 * time.Millisecond
+	healthWaitTimeout = 50 * time.Millisecond
+
+	err := adminHealthCmd.RunE(adminHealthCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not ready after 50ms: database check failed")
+}
+
+func TestAdminHealthCmd_InvalidFlags(t *testing.T) {
+	setupAdminHealthTest(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	healthWatch, healthWaitReady = true, true
+	assert.EqualError(t, adminHealthCmd.RunE(adminHealthCmd, nil), "--watch and --wait-ready are mutually exclusive")
+
+	healthWatch, healthWaitReady = false, false
+	healthInterval = 0
+	assert.EqualError(t, adminHealthCmd.RunE(adminHealthCmd, nil), "--interval must be positive")
+}
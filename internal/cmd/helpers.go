@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -63,16 +67,44 @@ func parseJSONData(dataStr string, target interface{}) error {
 	return nil
 }
 
+// readInputFile reads a file, or the command's stdin when path is "-"
+func readInputFile(cmd *cobra.Command, path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// parseDayDuration parses a Go duration (e.g. 36h) or a number of days (e.g. 90d)
+func parseDayDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
 // nowISO8601 returns the current time in ISO 8601 format
 func nowISO8601() string {
 	return time.Now().UTC().Format(time.RFC3339)
 }
 
-// marshalJSON marshals data to JSON bytes
-func marshalJSON(data interface{}) ([]byte, error) {
-	return json.Marshal(data)
-}
-
 // resolveTagsToUUIDs converts tag names to UUIDs.
 // If a tag value is already a valid UUID, it's used as-is.
 // Otherwise, it's treated as a tag name and looked up via the admin API.
@@ -100,7 +132,7 @@ func resolveTagsToUUIDs(ctx context.Context, client *izanami.AdminClient, tenant
 		}
 
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Resolved tag %q to UUID %s\n", t, tag.ID)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Resolved tag %q to UUID %s\n", t, tag.ID)
 		}
 		resolved = append(resolved, tag.ID)
 	}
@@ -135,7 +167,7 @@ func resolveProjectsToUUIDs(ctx context.Context, client *izanami.AdminClient, te
 		}
 
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Resolved project %q to UUID %s\n", p, project.ID)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Resolved project %q to UUID %s\n", p, project.ID)
 		}
 		resolved = append(resolved, project.ID)
 	}
@@ -143,39 +175,29 @@ func resolveProjectsToUUIDs(ctx context.Context, client *izanami.AdminClient, te
 	return resolved, nil
 }
 
-// findFeatureByName finds a feature by name in a pre-fetched feature list.
+// findFeatureByName finds a feature by ID or name in a pre-fetched feature list.
 // Returns (uuid, error). If project is non-empty, filters by project.
-// Returns error if 0 matches or >1 matches without project filter.
+// Returns error if 0 matches or >1 matches, listing the matches.
 func findFeatureByName(features []izanami.Feature, name, project, tenant string) (string, error) {
-	var matches []izanami.Feature
+	var candidates []nameCandidate
 	for _, f := range features {
-		if f.Name == name {
-			matches = append(matches, f)
+		if project == "" || f.Project == project {
+			candidates = append(candidates, nameCandidate{ID: f.ID, Name: f.Name, Scope: "project " + f.Project})
 		}
 	}
-
-	// Filter by project if specified
-	if project != "" {
-		var projectMatches []izanami.Feature
-		for _, f := range matches {
-			if f.Project == project {
-				projectMatches = append(projectMatches, f)
-			}
-		}
-		matches = projectMatches
-	}
+	matches := matchNameOrID(name, candidates)
 
 	if len(matches) == 0 {
 		if project != "" {
-			return "", fmt.Errorf("no feature named '%s' found in tenant '%s' and project '%s'", name, tenant, project)
+			return "", notFoundError("no feature named '%s' found in tenant '%s' and project '%s'", name, tenant, project)
 		}
-		return "", fmt.Errorf("no feature named '%s' found in tenant '%s'", name, tenant)
+		return "", notFoundError("no feature named '%s' found in tenant '%s'", name, tenant)
 	}
 	if len(matches) > 1 {
-		return "", fmt.Errorf("multiple features named '%s' found (use --project to disambiguate or provide UUID instead)", name)
+		return "", ambiguousNameError("feature", name, "use --project to disambiguate or provide UUID instead", candidates, matches)
 	}
 
-	return matches[0].ID, nil
+	return candidates[matches[0]].ID, nil
 }
 
 // resolveFeatureToUUID resolves a feature identifier (UUID or name) to a UUID.
@@ -183,10 +205,10 @@ func findFeatureByName(features []izanami.Feature, name, project, tenant string)
 // If the input is already a UUID, returns (uuid, "", nil).
 // If the input is a name, requires tenant to be set. Project is optional for disambiguation.
 func resolveFeatureToUUID(ctx context.Context, client *izanami.AdminClient, cfg *izanami.ResolvedConfig, featureIDOrName string, cmd *cobra.Command) (string, string, error) {
-	// If it's already a UUID, return it directly
-	if IsUUID(featureIDOrName) {
+	// If it's already a UUID (or --exact-id is set), return it directly
+	if IsUUID(featureIDOrName) || exactID {
 		if cfg.Verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using feature UUID: %s\n", featureIDOrName)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Using feature ID: %s\n", featureIDOrName)
 		}
 		return featureIDOrName, "", nil
 	}
@@ -197,7 +219,7 @@ func resolveFeatureToUUID(ctx context.Context, client *izanami.AdminClient, cfg
 	}
 
 	if cfg.Verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Resolving feature name '%s' in tenant '%s'...\n", featureIDOrName, cfg.Tenant)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Resolving feature name '%s' in tenant '%s'...\n", featureIDOrName, cfg.Tenant)
 	}
 
 	// List all features for the tenant
@@ -213,7 +235,7 @@ func resolveFeatureToUUID(ctx context.Context, client *izanami.AdminClient, cfg
 	}
 
 	if cfg.Verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Resolved feature '%s' to UUID %s\n", featureIDOrName, id)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Resolved feature '%s' to UUID %s\n", featureIDOrName, id)
 	}
 	return id, featureIDOrName, nil
 }
@@ -236,8 +258,8 @@ func resolveFeaturesToUUIDs(ctx context.Context, client *izanami.AdminClient, te
 		}
 	}
 
-	// If all are UUIDs, return as-is
-	if !needsLookup {
+	// If all are UUIDs (or --exact-id is set), return as-is
+	if !needsLookup || exactID {
 		return features, nil
 	}
 
@@ -267,10 +289,49 @@ func resolveFeaturesToUUIDs(ctx context.Context, client *izanami.AdminClient, te
 		}
 
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Resolved feature %q to UUID %s\n", f, id)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Resolved feature %q to UUID %s\n", f, id)
 		}
 		resolved = append(resolved, id)
 	}
 
 	return resolved, nil
 }
+
+// readArgsAndFile returns args followed by the lines of a file (- for stdin),
+// without duplicates, blank lines or # comments. what names the file in errors,
+// e.g. "users" for "failed to open users file".
+func readArgsAndFile(cmd *cobra.Command, args []string, path, what string) ([]string, error) {
+	lines := append([]string{}, args...)
+	if path != "" {
+		var input io.Reader
+		if path == "-" {
+			input = cmd.InOrStdin()
+		} else {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open %s file: %w", what, err)
+			}
+			defer file.Close()
+			input = file
+		}
+		scanner := bufio.NewScanner(input)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s file: %w", what, err)
+		}
+	}
+
+	var values []string
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		value := strings.TrimSpace(line)
+		if value == "" || strings.HasPrefix(value, "#") || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values, nil
+}
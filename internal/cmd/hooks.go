@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// runHooks notifies the hooks of the config configured for event, after a
+// successful mutation. Failures are reported as warnings only: the change is
+// already applied.
+func runHooks(cmd *cobra.Command, event string, resource map[string]interface{}) {
+	if cfg == nil {
+		return
+	}
+	hooks := izanami.MatchHooks(cfg.Hooks, event)
+	if len(hooks) == 0 {
+		return
+	}
+
+	user := cfg.Username
+	if user == "" {
+		user = cfg.PersonalAccessTokenUsername
+	}
+	payload := &izanami.HookPayload{
+		Event:    event,
+		Time:     time.Now().UTC().Format(time.RFC3339),
+		URL:      cfg.LeaderURL,
+		Tenant:   cfg.Tenant,
+		Project:  cfg.Project,
+		User:     user,
+		Command:  cmd.CommandPath(),
+		Resource: resource,
+	}
+	for _, hook := range hooks {
+		target := hook.URL
+		if hook.Command != "" {
+			target = hook.Command
+		}
+		if err := izanami.RunHook(context.Background(), hook, payload); err != nil {
+			fmt.Fprintf(statusOut(cmd), "Warning: %s hook failed (%s): %v\n", event, target, err)
+		} else if cfg.Verbose {
+			fmt.Fprintf(statusOut(cmd), "[verbose] %s hook notified: %s\n", event, target)
+		}
+	}
+}
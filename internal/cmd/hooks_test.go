@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestRunHooks(t *testing.T) {
+	var received izanami.HookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+	cfg = &izanami.ResolvedConfig{
+		LeaderURL: "http://izanami",
+		Tenant:    "acme",
+		Username:  "alice",
+		Hooks: map[string][]izanami.HookConfig{
+			"feature.*":  {{URL: server.URL}},
+			"key.delete": {{URL: failing.URL}},
+		},
+	}
+
+	cmd := &cobra.Command{Use: "delete"}
+	var stderr bytes.Buffer
+	cmd.SetOut(&stderr)
+
+	runHooks(cmd, izanami.HookFeatureDelete, map[string]interface{}{"id": "f1"})
+	assert.Equal(t, izanami.HookFeatureDelete, received.Event)
+	assert.Equal(t, "alice", received.User)
+	assert.Equal(t, "acme", received.Tenant)
+	assert.Equal(t, "delete", received.Command)
+	assert.Empty(t, stderr.String())
+
+	runHooks(cmd, izanami.HookKeyDelete, map[string]interface{}{"name": "ci"})
+	assert.Contains(t, stderr.String(), "Warning: key.delete hook failed")
+}
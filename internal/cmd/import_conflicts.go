@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// --on-conflict values of V2 imports
+const (
+	onConflictSkip      = "skip"
+	onConflictOverwrite = "overwrite"
+	onConflictFail      = "fail"
+)
+
+// validateImportOnConflict checks the --on-conflict value
+func validateImportOnConflict() error {
+	importOnConflict = strings.ToLower(importOnConflict)
+	switch importOnConflict {
+	case "", onConflictSkip, onConflictOverwrite, onConflictFail:
+		return nil
+	}
+	return fmt.Errorf("invalid --on-conflict %q (expected skip, overwrite or fail)", importOnConflict)
+}
+
+// formatImportConflict describes a conflicting item
+func formatImportConflict(conflict izanami.ImportConflict) string {
+	if conflict.Description != "" {
+		return fmt.Sprintf("%s (%s): %s", conflict.Name, conflict.ID, conflict.Description)
+	}
+	return fmt.Sprintf("%s (%s)", conflict.Name, conflict.ID)
+}
+
+// resolveImportConflicts decides what to do with the conflicts of a V2 import,
+// from --on-conflict or by asking for each item, and imports the file again.
+// It returns the new result with its conflict strategy, or a nil result when
+// the user aborts.
+func resolveImportConflicts(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, filePath string, conflicted *izanami.ImportV2Response) (*izanami.ImportV2Response, string, error) {
+	var skipped []izanami.ImportConflict
+	strategy := ""
+	switch importOnConflict {
+	case onConflictFail:
+		if err := printImportConflicts(cmd, conflicted); err != nil {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("import has %d conflict(s)", len(conflicted.Conflicts))
+	case onConflictSkip:
+		strategy = "SKIP"
+	case onConflictOverwrite:
+		strategy = "OVERWRITE"
+	default:
+		var ok bool
+		strategy, skipped, ok = askImportConflicts(cmd, conflicted)
+		if !ok {
+			fmt.Fprintln(statusOut(cmd), "Import cancelled")
+			return nil, "", nil
+		}
+	}
+
+	if len(skipped) > 0 {
+		// Mixed choices: leave the skipped items out and overwrite the others
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read import file: %w", err)
+		}
+		filtered, removed, err := izanami.RemoveImportEntities(data, skipped)
+		if err != nil {
+			return nil, "", err
+		}
+		if removed < len(skipped) {
+			fmt.Fprintf(statusOut(cmd), "Warning: only %d of %d skipped item(s) found in %s\n", removed, len(skipped), filePath)
+		}
+		tmp, err := os.CreateTemp("", "iz-import-*.ndjson")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create import file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		_, err = tmp.Write(filtered)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to write import file: %w", err)
+		}
+		filePath = tmp.Name()
+	}
+
+	if outputFormat != "json" {
+		fmt.Fprintf(statusOut(cmd), "Importing again with conflict strategy %s...\n", strategy)
+	}
+	result, err := client.ImportV2(ctx, cfg.Tenant, filePath, izanami.ImportRequest{Conflict: strategy})
+	if err != nil {
+		if apiErr, ok := err.(*izanami.APIError); ok && apiErr.StatusCode == 409 {
+			if printErr := printImportConflicts(cmd, result); printErr != nil {
+				return nil, "", printErr
+			}
+			return nil, "", fmt.Errorf("import still has %d conflict(s)", len(result.Conflicts))
+		}
+		return nil, "", err
+	}
+	return result, strategy, nil
+}
+
+// askImportConflicts walks through the conflicts, asking to skip or overwrite
+// each item. It returns the conflict strategy of the new import and, when the
+// choices are mixed, the items to leave out of it; ok is false on abort.
+func askImportConflicts(cmd *cobra.Command, conflicted *izanami.ImportV2Response) (strategy string, skipped []izanami.ImportConflict, ok bool) {
+	out := statusOut(cmd)
+	reader := bufio.NewReader(cmd.InOrStdin())
+
+	if len(conflicted.Conflicts) == 0 {
+		// The server did not list the conflicting items: one choice for all
+		for _, msg := range conflicted.Messages {
+			fmt.Fprintf(out, "  • %s\n", msg)
+		}
+		choice, ok := askConflictChoice(reader, out, "Skip or overwrite the conflicting items? [s]kip, [o]verwrite, [q]uit: ")
+		if !ok || (choice != "s" && choice != "o") {
+			return "", nil, false
+		}
+		if choice == "s" {
+			return "SKIP", nil, true
+		}
+		return "OVERWRITE", nil, true
+	}
+
+	fmt.Fprintf(out, "⚠️  %d conflicting item(s)\n", len(conflicted.Conflicts))
+	all := ""
+	overwritten := 0
+	for i, conflict := range conflicted.Conflicts {
+		choice := all
+		for choice == "" {
+			fmt.Fprintf(out, "\n[%d/%d] %s\n", i+1, len(conflicted.Conflicts), formatImportConflict(conflict))
+			answer, ok := askConflictChoice(reader, out, "[s]kip, [o]verwrite, skip [a]ll remaining, overwrite a[l]l remaining, [q]uit: ")
+			if !ok || answer == "q" {
+				return "", nil, false
+			}
+			switch answer {
+			case "s", "o":
+				choice = answer
+			case "a":
+				all, choice = "s", "s"
+			case "l":
+				all, choice = "o", "o"
+			}
+		}
+		if choice == "s" {
+			skipped = append(skipped, conflict)
+		} else {
+			overwritten++
+		}
+	}
+
+	if overwritten == 0 {
+		return "SKIP", nil, true
+	}
+	return "OVERWRITE", skipped, true
+}
+
+// askConflictChoice prompts and reads a lowercase answer; ok is false at end of input
+func askConflictChoice(reader *bufio.Reader, out io.Writer, prompt string) (string, bool) {
+	fmt.Fprint(out, prompt)
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Fprintln(out)
+		return "", false
+	}
+	return strings.ToLower(strings.TrimSpace(line)), true
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const conflictingImport = `{"_type":"feature","row":{"id":"f1","name":"checkout"}}
+{"_type":"feature","row":{"id":"f2","name":"search"}}
+{"_type":"feature","row":{"id":"f3","name":"new-one"}}
+`
+
+// importRequest is an import received by setupImportConflictTest
+type importRequest struct {
+	conflict string
+	file     string
+}
+
+// setupImportConflictTest serves V2 imports: FAIL imports conflict on f1 and f2,
+// others succeed. It returns the received imports and the command to run.
+func setupImportConflictTest(t *testing.T, input string) (*[]importRequest, *cobra.Command, *bytes.Buffer) {
+	t.Helper()
+	var received []importRequest
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("export")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		conflict := r.URL.Query().Get("conflict")
+		received = append(received, importRequest{conflict: conflict, file: string(data)})
+		w.Header().Set("Content-Type", "application/json")
+		if conflict == "FAIL" {
+			w.WriteHeader(http.StatusConflict)
+			io.WriteString(w, `{"messages": ["2 conflicts"], "conflicts": [{"id": "f1", "name": "checkout"}, {"id": "f2", "name": "search"}]}`)
+			return
+		}
+		io.WriteString(w, `{"messages": ["imported"]}`)
+	})
+
+	t.Cleanup(func() {
+		importConflict, importOnConflict, importInteractive, importVersion = "FAIL", "", false, 0
+		noSnapshot = false
+	})
+	outputFormat = "table"
+	importConflict = "FAIL"
+	importVersion = 2
+	// The server only serves imports
+	noSnapshot = true
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader(input))
+	return &received, cmd, &out
+}
+
+func writeImportFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "export.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte(conflictingImport), 0600))
+	return path
+}
+
+func TestImportV2_InteractiveMixedChoices(t *testing.T) {
+	received, cmd, out := setupImportConflictTest(t, "s\no\n")
+	importInteractive = true
+
+	require.NoError(t, adminImportCmd.RunE(cmd, []string{writeImportFile(t)}))
+
+	require.Len(t, *received, 2)
+	assert.Equal(t, "OVERWRITE", (*received)[1].conflict)
+	assert.NotContains(t, (*received)[1].file, `"id":"f1"`)
+	assert.Contains(t, (*received)[1].file, `"id":"f2"`)
+	assert.Contains(t, (*received)[1].file, `"id":"f3"`)
+	assert.Contains(t, out.String(), "[1/2] checkout (f1)")
+	assert.Contains(t, out.String(), "Import completed successfully")
+}
+
+func TestImportV2_InteractiveSkipAll(t *testing.T) {
+	received, cmd, _ := setupImportConflictTest(t, "a\n")
+	importInteractive = true
+
+	require.NoError(t, adminImportCmd.RunE(cmd, []string{writeImportFile(t)}))
+
+	require.Len(t, *received, 2)
+	assert.Equal(t, "SKIP", (*received)[1].conflict)
+	assert.Equal(t, conflictingImport, (*received)[1].file)
+}
+
+func TestImportV2_InteractiveQuit(t *testing.T) {
+	received, cmd, out := setupImportConflictTest(t, "q\n")
+	importInteractive = true
+
+	require.NoError(t, adminImportCmd.RunE(cmd, []string{writeImportFile(t)}))
+
+	assert.Len(t, *received, 1)
+	assert.Contains(t, out.String(), "Import cancelled")
+}
+
+func TestImportV2_OnConflict(t *testing.T) {
+	received, cmd, _ := setupImportConflictTest(t, "")
+	importOnConflict = "Overwrite"
+
+	require.NoError(t, adminImportCmd.RunE(cmd, []string{writeImportFile(t)}))
+	require.Len(t, *received, 2)
+	assert.Equal(t, "OVERWRITE", (*received)[1].conflict)
+
+	importOnConflict = "fail"
+	err := adminImportCmd.RunE(cmd, []string{writeImportFile(t)})
+	assert.EqualError(t, err, "import has 2 conflict(s)")
+	assert.Len(t, *received, 3)
+
+	importOnConflict = "merge"
+	assert.Error(t, adminImportCmd.RunE(cmd, []string{writeImportFile(t)}))
+}
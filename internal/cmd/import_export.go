@@ -3,11 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
+	"golang.org/x/term"
 )
 
 var (
@@ -15,6 +18,11 @@ var (
 	importConflict string
 	importTimezone string
 	importVersion  int
+	importWait     bool
+	importPoll     time.Duration
+
+	importOnConflict  string
+	importInteractive bool
 )
 
 var adminExportCmd = &cobra.Command{
@@ -47,7 +55,7 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		data, err := client.Export(ctx, cfg.Tenant)
 		if err != nil {
 			return err
@@ -57,7 +65,7 @@ Examples:
 			if err := os.WriteFile(exportOutput, []byte(data), 0644); err != nil {
 				return fmt.Errorf("failed to write export file: %w", err)
 			}
-			fmt.Fprintf(cmd.OutOrStderr(), "Export written to: %s\n", exportOutput)
+			fmt.Fprintf(statusOut(cmd), "Export written to: %s\n", exportOutput)
 		} else {
 			fmt.Fprint(cmd.OutOrStdout(), data)
 		}
@@ -81,6 +89,16 @@ Conflict resolution strategies:
   - SKIP: Skip conflicting items
   - OVERWRITE: Overwrite existing items
 
+When a V2 import with FAIL finds conflicts, they are listed. To resolve them
+and import again instead, use --on-conflict skip|overwrite (or fail to exit
+with an error), or --interactive to choose skip or overwrite for each
+conflicting item. Items to skip are left out of the file sent again.
+
+V1 imports run in the background and return a job ID. With --wait, the
+command follows the job, showing the imported features, users, scripts and
+keys, and fails when the import fails. Without it, or after an interruption,
+follow the job with 'iz admin import status <import-id> --wait'.
+
 Examples:
   # Import Izanami v2 data
   iz admin import export.ndjson --version 2
@@ -88,8 +106,14 @@ Examples:
   # Import Izanami v1 data (migration from v1 to v2)
   iz admin import v1-export.ndjson --version 1 --timezone "Europe/Paris"
 
+  # Import Izanami v1 data and wait for the migration to finish
+  iz admin import v1-export.ndjson --version 1 --timezone "Europe/Paris" --wait
+
   # Import and overwrite conflicts
-  iz admin import export.ndjson --version 2 --conflict OVERWRITE`,
+  iz admin import export.ndjson --version 2 --conflict OVERWRITE
+
+  # Choose what to do with each conflicting item
+  iz admin import export.ndjson --version 2 --interactive`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.ValidateTenant(); err != nil {
@@ -101,7 +125,17 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+		// The import may touch any project of the tenant
+		if !noSnapshot && (importVersion == 1 || importVersion == 2) {
+			projects, err := izanami.TenantProjects(client, ctx, cfg.Tenant)
+			if err != nil {
+				return err
+			}
+			if err := saveMutationSnapshot(cmd, client, projects, true); err != nil {
+				return err
+			}
+		}
 
 		if importVersion == 2 {
 			return runImportV2(cmd, client, ctx, args[0])
@@ -114,6 +148,9 @@ Examples:
 }
 
 func runImportV2(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, filePath string) error {
+	if err := validateImportOnConflict(); err != nil {
+		return err
+	}
 	req := izanami.ImportRequest{
 		Conflict: importConflict,
 	}
@@ -122,56 +159,62 @@ func runImportV2(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Co
 
 	// Handle conflict case - result is populated even on conflict error
 	if apiErr, ok := err.(*izanami.APIError); ok && apiErr.StatusCode == 409 {
-		// JSON output: return the result directly
-		if outputFormat == "json" {
-			return output.PrintTo(cmd.OutOrStdout(), result, output.JSON)
+		if importOnConflict == "" && !importInteractive {
+			return printImportConflicts(cmd, result)
+		}
+		result, req.Conflict, err = resolveImportConflicts(cmd, client, ctx, filePath, result)
+		if err != nil || result == nil {
+			return err
 		}
+	} else if err != nil {
+		return err
+	}
+	runHooks(cmd, izanami.HookImportApply, map[string]interface{}{"file": filePath, "version": "v2", "conflict": req.Conflict})
 
-		// Table output: formatted display
-		fmt.Fprintf(cmd.OutOrStderr(), "⚠️  Import completed with conflicts\n\n")
+	// JSON output: return the result directly
+	if outputFormat == "json" {
+		return output.PrintTo(cmd.OutOrStdout(), result, output.JSON)
+	}
 
-		if len(result.Messages) > 0 {
-			fmt.Fprintf(cmd.OutOrStderr(), "Messages:\n")
-			for _, msg := range result.Messages {
-				fmt.Fprintf(cmd.OutOrStderr(), "  • %s\n", msg)
-			}
-		}
+	// Table output: formatted display
+	fmt.Fprintf(statusOut(cmd), "✅ Import completed successfully\n")
 
-		if len(result.Conflicts) > 0 {
-			fmt.Fprintf(cmd.OutOrStderr(), "\nConflicts:\n")
-			for _, conflict := range result.Conflicts {
-				if conflict.Description != "" {
-					fmt.Fprintf(cmd.OutOrStderr(), "  • %s (%s): %s\n", conflict.Name, conflict.ID, conflict.Description)
-				} else {
-					fmt.Fprintf(cmd.OutOrStderr(), "  • %s (%s)\n", conflict.Name, conflict.ID)
-				}
-			}
+	if len(result.Messages) > 0 {
+		fmt.Fprintf(statusOut(cmd), "\nMessages:\n")
+		for _, msg := range result.Messages {
+			fmt.Fprintf(statusOut(cmd), "  • %s\n", msg)
 		}
-
-		fmt.Fprintf(cmd.OutOrStderr(), "\nUse --conflict OVERWRITE or --conflict SKIP to handle conflicts.\n")
-		return nil // Not a fatal error, just informational
 	}
 
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
+// printImportConflicts displays the conflicts of a V2 import
+func printImportConflicts(cmd *cobra.Command, result *izanami.ImportV2Response) error {
 	// JSON output: return the result directly
 	if outputFormat == "json" {
 		return output.PrintTo(cmd.OutOrStdout(), result, output.JSON)
 	}
 
 	// Table output: formatted display
-	fmt.Fprintf(cmd.OutOrStderr(), "✅ Import completed successfully\n")
+	fmt.Fprintf(statusOut(cmd), "⚠️  Import completed with conflicts\n\n")
 
 	if len(result.Messages) > 0 {
-		fmt.Fprintf(cmd.OutOrStderr(), "\nMessages:\n")
+		fmt.Fprintf(statusOut(cmd), "Messages:\n")
 		for _, msg := range result.Messages {
-			fmt.Fprintf(cmd.OutOrStderr(), "  • %s\n", msg)
+			fmt.Fprintf(statusOut(cmd), "  • %s\n", msg)
 		}
 	}
 
-	return nil
+	if len(result.Conflicts) > 0 {
+		fmt.Fprintf(statusOut(cmd), "\nConflicts:\n")
+		for _, conflict := range result.Conflicts {
+			fmt.Fprintf(statusOut(cmd), "  • %s\n", formatImportConflict(conflict))
+		}
+	}
+
+	fmt.Fprintf(statusOut(cmd), "\nUse --conflict OVERWRITE or --conflict SKIP to handle conflicts, or --interactive to choose per item.\n")
+	return nil // Not a fatal error, just informational
 }
 
 func runImportV1(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, filePath string) error {
@@ -188,6 +231,14 @@ func runImportV1(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Co
 	if err != nil {
 		return err
 	}
+	runHooks(cmd, izanami.HookImportApply, map[string]interface{}{"file": filePath, "version": "v1", "conflict": importConflict, "importId": result.ID})
+
+	if importWait {
+		if outputFormat != "json" {
+			fmt.Fprintf(statusOut(cmd), "Import job started: %s\n", result.ID)
+		}
+		return waitForImport(cmd, client, ctx, result.ID)
+	}
 
 	// JSON output: return the result directly
 	if outputFormat == "json" {
@@ -195,20 +246,64 @@ func runImportV1(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Co
 	}
 
 	// Table output: formatted display
-	fmt.Fprintf(cmd.OutOrStderr(), "Import job started: %s\n", result.ID)
-	fmt.Fprintf(cmd.OutOrStderr(), "V1 imports run asynchronously. Use 'iz admin import-status %s' to check progress.\n", result.ID)
+	fmt.Fprintf(statusOut(cmd), "Import job started: %s\n", result.ID)
+	fmt.Fprintf(statusOut(cmd), "V1 imports run asynchronously. Use 'iz admin import status %s --wait' to follow it.\n", result.ID)
 
 	return nil
 }
 
+// waitForImport follows a V1 import until it ends, showing its progress, prints
+// its final status and fails when the import failed
+func waitForImport(cmd *cobra.Command, client *izanami.AdminClient, ctx context.Context, importID string) error {
+	var progress func(*izanami.ImportV1Status)
+	if outputFormat != "json" {
+		progress = newImportProgress(statusOut(cmd))
+	}
+	status, err := izanami.WaitForImport(client, ctx, cfg.Tenant, importID, importPoll, progress)
+	if err != nil {
+		if ctx.Err() != nil && outputFormat != "json" {
+			fmt.Fprintf(statusOut(cmd), "\nThe import keeps running. Resume with 'iz admin import status %s --wait'.\n", importID)
+		}
+		return err
+	}
+	return printImportStatus(cmd, status)
+}
+
+// newImportProgress returns a progress callback for WaitForImport. On a
+// terminal it redraws one line; otherwise it prints a line when counts change.
+func newImportProgress(w io.Writer) func(*izanami.ImportV1Status) {
+	file, ok := w.(*os.File)
+	inPlace := ok && term.IsTerminal(int(file.Fd()))
+	last := ""
+	return func(status *izanami.ImportV1Status) {
+		line := fmt.Sprintf("⏳ Importing... features: %d, users: %d, scripts: %d, keys: %d",
+			status.Features, status.Users, status.Scripts, status.Keys)
+		done := status.Status != izanami.ImportStatusPending
+		switch {
+		case inPlace && done:
+			if last != "" {
+				fmt.Fprint(w, "\r\033[K")
+			}
+		case inPlace:
+			fmt.Fprint(w, "\r\033[K"+line)
+		case !done && line != last:
+			fmt.Fprintln(w, line)
+		}
+		last = line
+	}
+}
+
+// adminImportStatusCmd shows or follows the status of an async V1 import
 var adminImportStatusCmd = &cobra.Command{
-	Use:         "import-status <import-id>",
+	Use:         "status <import-id>",
 	Short:       "Check status of async V1 import",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/_import/v1/:id"},
 	Long: `Check the status of an asynchronous V1 import operation.
 
 V1 imports run in the background. Use this command to check if the import
-has completed, and to see any errors or warnings.
+has completed, and to see any errors or warnings. With --wait, it follows a
+running import until it ends, e.g. after 'iz admin import --version 1' was
+interrupted, and fails when the import fails.
 
 Status values:
   - Pending: Import is still running
@@ -216,76 +311,115 @@ Status values:
   - Failed: Import failed (check errors)
 
 Examples:
-  iz admin import-status 550e8400-e29b-41d4-a716-446655440000`,
+  iz admin import status 550e8400-e29b-41d4-a716-446655440000
+  iz admin import status 550e8400-e29b-41d4-a716-446655440000 --wait`,
 	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := cfg.ValidateTenant(); err != nil {
-			return err
-		}
+	RunE: runImportStatus,
+}
 
-		client, err := izanami.NewAdminClient(cfg)
-		if err != nil {
-			return err
-		}
+// adminImportStatusLegacyCmd is the former name of 'import status'
+var adminImportStatusLegacyCmd = &cobra.Command{
+	Use:         "import-status <import-id>",
+	Short:       adminImportStatusCmd.Short,
+	Annotations: adminImportStatusCmd.Annotations,
+	Deprecated:  "use 'iz admin import status' instead",
+	Args:        cobra.ExactArgs(1),
+	RunE:        runImportStatus,
+}
 
-		ctx := context.Background()
-		status, err := client.GetImportStatus(ctx, cfg.Tenant, args[0])
-		if err != nil {
+func runImportStatus(cmd *cobra.Command, args []string) error {
+	if err := cfg.ValidateTenant(); err != nil {
+		return err
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext()
+	if importWait {
+		return waitForImport(cmd, client, ctx, args[0])
+	}
+	status, err := client.GetImportStatus(ctx, cfg.Tenant, args[0])
+	if err != nil {
+		return err
+	}
+	return printImportStatus(cmd, status)
+}
+
+// printImportStatus displays a V1 import status and returns an error when the import failed
+func printImportStatus(cmd *cobra.Command, status *izanami.ImportV1Status) error {
+	// JSON output: return the status directly
+	if outputFormat == "json" {
+		if err := output.PrintTo(cmd.OutOrStdout(), status, output.JSON); err != nil {
 			return err
 		}
+	} else {
+		writeImportStatus(statusOut(cmd), status)
+	}
 
-		// JSON output: return the status directly
-		if outputFormat == "json" {
-			return output.PrintTo(cmd.OutOrStdout(), status, output.JSON)
-		}
+	if status.Status == izanami.ImportStatusFailed {
+		return fmt.Errorf("import %s failed", status.ID)
+	}
+	return nil
+}
 
-		// Table output: formatted display
-		switch status.Status {
-		case "Success":
-			fmt.Fprintf(cmd.OutOrStderr(), "✅ Import completed successfully\n\n")
-			fmt.Fprintf(cmd.OutOrStderr(), "Imported:\n")
-			fmt.Fprintf(cmd.OutOrStderr(), "  • Features: %d\n", status.Features)
-			fmt.Fprintf(cmd.OutOrStderr(), "  • Users: %d\n", status.Users)
-			fmt.Fprintf(cmd.OutOrStderr(), "  • Scripts: %d\n", status.Scripts)
-			fmt.Fprintf(cmd.OutOrStderr(), "  • Keys: %d\n", status.Keys)
-
-			if len(status.IncompatibleScripts) > 0 {
-				fmt.Fprintf(cmd.OutOrStderr(), "\n⚠️  Incompatible scripts (not imported):\n")
-				for _, script := range status.IncompatibleScripts {
-					fmt.Fprintf(cmd.OutOrStderr(), "  • %s\n", script)
-				}
+// writeImportStatus writes a V1 import status in a human-readable form
+func writeImportStatus(w io.Writer, status *izanami.ImportV1Status) {
+	switch status.Status {
+	case izanami.ImportStatusSuccess:
+		fmt.Fprintf(w, "✅ Import completed successfully\n\n")
+		fmt.Fprintf(w, "Imported:\n")
+		fmt.Fprintf(w, "  • Features: %d\n", status.Features)
+		fmt.Fprintf(w, "  • Users: %d\n", status.Users)
+		fmt.Fprintf(w, "  • Scripts: %d\n", status.Scripts)
+		fmt.Fprintf(w, "  • Keys: %d\n", status.Keys)
+
+		if len(status.IncompatibleScripts) > 0 {
+			fmt.Fprintf(w, "\n⚠️  Incompatible scripts (not imported):\n")
+			for _, script := range status.IncompatibleScripts {
+				fmt.Fprintf(w, "  • %s\n", script)
 			}
+		}
 
-		case "Failed":
-			fmt.Fprintf(cmd.OutOrStderr(), "❌ Import failed\n\n")
-			if len(status.Errors) > 0 {
-				fmt.Fprintf(cmd.OutOrStderr(), "Errors:\n")
-				for _, err := range status.Errors {
-					fmt.Fprintf(cmd.OutOrStderr(), "  • %s\n", err)
-				}
+	case izanami.ImportStatusFailed:
+		fmt.Fprintf(w, "❌ Import failed\n\n")
+		if len(status.Errors) > 0 {
+			fmt.Fprintf(w, "Errors:\n")
+			for _, err := range status.Errors {
+				fmt.Fprintf(w, "  • %s\n", err)
 			}
-
-		case "Pending":
-			fmt.Fprintf(cmd.OutOrStderr(), "⏳ Import is still running...\n")
-			fmt.Fprintf(cmd.OutOrStderr(), "Run this command again to check progress.\n")
-
-		default:
-			fmt.Fprintf(cmd.OutOrStderr(), "Status: %s\n", status.Status)
 		}
 
-		return nil
-	},
+	case izanami.ImportStatusPending:
+		fmt.Fprintf(w, "⏳ Import is still running...\n")
+		fmt.Fprintf(w, "Run this command again, or with --wait, to follow progress.\n")
+
+	default:
+		fmt.Fprintf(w, "Status: %s\n", status.Status)
+	}
 }
 
 func init() {
 	// Import/Export
 	adminCmd.AddCommand(adminExportCmd)
 	adminCmd.AddCommand(adminImportCmd)
-	adminCmd.AddCommand(adminImportStatusCmd)
+	adminCmd.AddCommand(adminImportStatusLegacyCmd)
+	adminImportCmd.AddCommand(adminImportStatusCmd)
 
 	adminExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
 	adminImportCmd.Flags().IntVar(&importVersion, "version", 0, "Import version: 1 for v1 data migration, 2 for v2 data")
 	_ = adminImportCmd.MarkFlagRequired("version")
 	adminImportCmd.Flags().StringVar(&importConflict, "conflict", "FAIL", "Conflict resolution: FAIL, SKIP, OVERWRITE")
 	adminImportCmd.Flags().StringVar(&importTimezone, "timezone", "", "Timezone for time-based features (required for v1)")
+	adminImportCmd.Flags().StringVar(&importOnConflict, "on-conflict", "", "On v2 import conflicts, import again with skip or overwrite, or fail")
+	adminImportCmd.Flags().BoolVarP(&importInteractive, "interactive", "i", false, "On v2 import conflicts, choose skip or overwrite per item and import again")
+	adminImportCmd.MarkFlagsMutuallyExclusive("on-conflict", "interactive")
+	adminImportCmd.Flags().BoolVar(&importWait, "wait", false, "Wait for a v1 import to finish, showing its progress")
+	adminImportCmd.Flags().DurationVar(&importPoll, "poll-interval", izanami.DefaultImportPollInterval, "Delay between two status checks with --wait")
+	for _, statusCmd := range []*cobra.Command{adminImportStatusCmd, adminImportStatusLegacyCmd} {
+		statusCmd.Flags().BoolVar(&importWait, "wait", false, "Wait for the import to finish, showing its progress")
+		statusCmd.Flags().DurationVar(&importPoll, "poll-interval", izanami.DefaultImportPollInterval, "Delay between two status checks with --wait")
+	}
 }
@@ -213,15 +213,15 @@ func setupIntegrationTest(t *testing.T) *IntegrationTestEnv {
 	sessionsPath := filepath.Join(tempDir, ".izsessions")
 
 	// Override path functions to use temp directory
-	origConfigDir := izanami.GetConfigDir
-	origSessionsPath := izanami.GetSessionsPath
+	origConfigDir := izanami.GetConfigDir()
+	origSessionsPath := izanami.GetSessionsPath()
 
 	izanami.SetGetConfigDirFunc(func() string { return configDir })
 	izanami.SetGetSessionsPathFunc(func() string { return sessionsPath })
 
 	t.Cleanup(func() {
-		izanami.SetGetConfigDirFunc(origConfigDir)
-		izanami.SetGetSessionsPathFunc(origSessionsPath)
+		izanami.SetGetConfigDirFunc(func() string { return origConfigDir })
+		izanami.SetGetSessionsPathFunc(func() string { return origSessionsPath })
 	})
 
 	return &IntegrationTestEnv{
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+)
+
+var (
+	// commandCtx is the context of the running command, canceled on Ctrl+C
+	commandCtx = context.Background()
+	// interrupted is set once the user interrupted the command
+	interrupted atomic.Bool
+)
+
+// commandContext returns the context requests of the running command should use:
+// it is canceled on the first SIGINT or SIGTERM so pending requests stop
+func commandContext() context.Context {
+	return commandCtx
+}
+
+// watchInterrupts cancels the command context on the first SIGINT or SIGTERM.
+// A second signal exits immediately. The returned function stops watching.
+func watchInterrupts(w io.Writer) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	commandCtx = ctx
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+		case <-done:
+			return
+		}
+		interrupted.Store(true)
+		cancel()
+		select {
+		case <-sigCh:
+			fmt.Fprintln(w, "\nInterrupted again, exiting")
+			os.Exit(ExitInterrupted)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+		cancel()
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
+	"golang.org/x/term"
 )
 
 // redactAPIKeySecrets redacts the clientSecret field in API key JSON data (array)
@@ -55,13 +58,14 @@ func redactAPIKeySecretsSlice(keys []izanami.APIKey) {
 }
 
 var (
-	keyName         string
-	keyDescription  string
-	keyProjects     []string
-	keyEnabled      bool
-	keyAdmin        bool
-	keysDeleteForce bool
-	keysShowSecrets bool
+	keyName            string
+	keyDescription     string
+	keyProjects        []string
+	keyEnabled         bool
+	keyAdmin           bool
+	keysDeleteForce    bool
+	keysShowSecrets    bool
+	keysProvisionForce bool
 )
 
 const redactedSecret = "<redacted>"
@@ -92,8 +96,20 @@ var keysListCmd = &cobra.Command{
 	Short:       "List all API keys in a tenant",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/keys"},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if multiTenantRequested(cmd) {
+			return runAcrossTenants(cmd, func(ctx context.Context, client *izanami.AdminClient, tenant string) (interface{}, error) {
+				keys, err := izanami.ListAPIKeys(client, ctx, tenant, izanami.ParseAPIKeys)
+				if err != nil {
+					return nil, err
+				}
+				if !keysShowSecrets {
+					redactAPIKeySecretsSlice(keys)
+				}
+				return keys, nil
+			})
+		}
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -101,7 +117,7 @@ var keysListCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -131,7 +147,7 @@ var keysListCmd = &cobra.Command{
 		}
 
 		if len(keys) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No API keys found")
+			fmt.Fprintln(statusOut(cmd), "No API keys found")
 			return nil
 		}
 
@@ -141,15 +157,28 @@ var keysListCmd = &cobra.Command{
 
 // keysGetCmd gets a specific API key
 var keysGetCmd = &cobra.Command{
-	Use:         "get <name>",
-	Short:       "Get details of a specific API key by name",
+	Use:         "get <name-or-client-id>",
+	Short:       "Get details of a specific API key by name or client ID",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/keys"},
-	Args:        cobra.ExactArgs(1),
+	Long: `Get the details of an API key by name or client ID. The client secret is
+redacted.
+
+--show-secrets prints the client secret after confirmation (use --yes in
+scripts). Izanami only returns the secret of a key when it is created: when
+the server does not return it, the command fails with the steps to rotate the
+key instead.
+
+Examples:
+  iz admin keys get my-app-key --tenant my-tenant
+
+  # Print the client secret
+  iz admin keys get my-app-key --tenant my-tenant --show-secrets`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -157,23 +186,59 @@ var keysGetCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
-		// Note: GetAPIKeyByName doesn't have a dedicated endpoint, it filters from list
+		ctx := commandContext()
+		// Note: there is no endpoint for a single key, it is found in the list
 		// So raw JSON output isn't available for a single key
-		key, err := client.GetAPIKeyByName(ctx, cfg.Tenant, name)
+		key, err := resolveAPIKey(ctx, client, name)
 		if err != nil {
 			return err
 		}
-
-		// Redact secret unless --show-secrets is set
-		if !keysShowSecrets {
-			redactAPIKeySecret(key)
+		if keysShowSecrets {
+			return revealAPIKeySecret(cmd, key)
 		}
 
+		redactAPIKeySecret(key)
 		return output.PrintTo(cmd.OutOrStdout(), key, output.Format(outputFormat))
 	},
 }
 
+// revealAPIKeySecret prints the client secret of a key after confirmation, or
+// fails with the steps to rotate the key when the server did not return it.
+// A declined confirmation fails too, so that scripts never get an empty output
+// with exit code 0.
+func revealAPIKeySecret(cmd *cobra.Command, key *izanami.APIKey) error {
+	if key.ClientSecret == "" {
+		return fmt.Errorf("the server does not return the secret of existing API keys. To rotate key '%s':\n%s", key.Name, keyRotationSteps(cfg.Tenant, key))
+	}
+	if !assumeYes {
+		if in, ok := cmd.InOrStdin().(*os.File); ok && !term.IsTerminal(int(in.Fd())) {
+			return fmt.Errorf("printing the client secret of API key '%s' needs a confirmation: pass --yes when stdin is not a terminal", key.Name)
+		}
+		if !confirmAction(cmd, fmt.Sprintf("Print the client secret of API key '%s'?", key.Name)) {
+			return fmt.Errorf("client secret of API key '%s' not printed: confirmation declined (pass --yes to skip it)", key.Name)
+		}
+	}
+	if output.Format(outputFormat) == output.JSON {
+		return output.PrintTo(cmd.OutOrStdout(), key, output.JSON)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Client ID:     %s\n", key.ClientID)
+	fmt.Fprintf(cmd.OutOrStdout(), "Client Secret: %s\n", key.ClientSecret)
+	return nil
+}
+
+// keyRotationSteps returns the commands replacing a key by a new one with the
+// same rights
+func keyRotationSteps(tenant string, key *izanami.APIKey) string {
+	create := fmt.Sprintf("iz admin keys create %s-rotated --tenant %s", key.Name, tenant)
+	if len(key.Projects) > 0 {
+		create += " --projects " + strings.Join(key.Projects, ",")
+	}
+	if key.Admin {
+		create += " --admin"
+	}
+	return fmt.Sprintf("  1. %s\n  2. Update the clients with the new client ID and secret\n  3. iz admin keys delete %s --tenant %s", create, key.Name, tenant)
+}
+
 // keysCreateCmd creates a new API key
 var keysCreateCmd = &cobra.Command{
 	Use:         "create <name>",
@@ -184,7 +249,7 @@ var keysCreateCmd = &cobra.Command{
 		name := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -203,11 +268,12 @@ var keysCreateCmd = &cobra.Command{
 			keyData["projects"] = keyProjects
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		result, err := client.CreateAPIKey(ctx, cfg.Tenant, keyData)
 		if err != nil {
 			return err
 		}
+		runHooks(cmd, izanami.HookKeyCreate, map[string]interface{}{"name": result.Name, "projects": keyData["projects"]})
 
 		// Print the result with the secret
 		if output.Format(outputFormat) == output.JSON {
@@ -217,16 +283,16 @@ var keysCreateCmd = &cobra.Command{
 		}
 
 		// For table output, show important info
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ API key created successfully\n\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "Client ID:     %s\n", result.ClientID)
-		fmt.Fprintf(cmd.OutOrStderr(), "Client Secret: %s\n", result.ClientSecret)
-		fmt.Fprintf(cmd.OutOrStderr(), "Name:          %s\n", result.Name)
-		fmt.Fprintf(cmd.OutOrStderr(), "Enabled:       %t\n", result.Enabled)
-		fmt.Fprintf(cmd.OutOrStderr(), "Admin:         %t\n", result.Admin)
+		fmt.Fprintf(statusOut(cmd), "✅ %s\n\n", errors.T(errors.MsgAPIKeyCreated))
+		fmt.Fprintf(statusOut(cmd), "Client ID:     %s\n", result.ClientID)
+		fmt.Fprintf(statusOut(cmd), "Client Secret: %s\n", result.ClientSecret)
+		fmt.Fprintf(statusOut(cmd), "Name:          %s\n", result.Name)
+		fmt.Fprintf(statusOut(cmd), "Enabled:       %t\n", result.Enabled)
+		fmt.Fprintf(statusOut(cmd), "Admin:         %t\n", result.Admin)
 		if len(result.Projects) > 0 {
-			fmt.Fprintf(cmd.OutOrStderr(), "Projects:      %v\n", result.Projects)
+			fmt.Fprintf(statusOut(cmd), "Projects:      %v\n", result.Projects)
 		}
-		fmt.Fprintf(cmd.OutOrStderr(), "\n⚠️  IMPORTANT: Save the Client Secret - it won't be shown again!\n")
+		fmt.Fprintf(statusOut(cmd), "\n⚠️  IMPORTANT: Save the Client Secret - it won't be shown again!\n")
 
 		return nil
 	},
@@ -234,15 +300,15 @@ var keysCreateCmd = &cobra.Command{
 
 // keysUpdateCmd updates an API key
 var keysUpdateCmd = &cobra.Command{
-	Use:         "update <name>",
-	Short:       "Update an existing API key by name",
+	Use:         "update <name-or-client-id>",
+	Short:       "Update an existing API key by name or client ID",
 	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/keys/:name"},
 	Args:        cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		// Check if any update flags were provided
@@ -261,13 +327,14 @@ var keysUpdateCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
-		// Fetch current key by name to get clientID and existing values (API requires full object)
-		currentKey, err := client.GetAPIKeyByName(ctx, cfg.Tenant, name)
+		// Fetch current key by name or client ID to get existing values (API requires full object)
+		currentKey, err := resolveAPIKey(ctx, client, name)
 		if err != nil {
 			return fmt.Errorf("failed to get current key: %w", err)
 		}
+		name = currentKey.Name
 
 		// Start with current values
 		keyData := map[string]interface{}{
@@ -299,22 +366,33 @@ var keysUpdateCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ API key updated successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ %s\n", errors.T(errors.MsgAPIKeyUpdated))
+		runHooks(cmd, izanami.HookKeyUpdate, map[string]interface{}{"name": name})
 		return nil
 	},
 }
 
 // keysDeleteCmd deletes an API key
 var keysDeleteCmd = &cobra.Command{
-	Use:         "delete <name>",
-	Short:       "Delete an API key by name",
+	Use:         "delete <name-or-client-id>",
+	Short:       "Delete an API key by name or client ID",
 	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:tenant/keys/:name"},
 	Args:        cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		if name, err = resolveAPIKeyName(ctx, client, name); err != nil {
+			return err
 		}
 
 		// Confirm deletion unless --force is used
@@ -324,24 +402,19 @@ var keysDeleteCmd = &cobra.Command{
 			}
 		}
 
-		client, err := izanami.NewAdminClient(cfg)
-		if err != nil {
-			return err
-		}
-
-		ctx := context.Background()
 		if err := client.DeleteAPIKey(ctx, cfg.Tenant, name); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ API key deleted successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ %s\n", errors.T(errors.MsgAPIKeyDeleted))
+		runHooks(cmd, izanami.HookKeyDelete, map[string]interface{}{"name": name})
 		return nil
 	},
 }
 
 // keysUsersCmd lists users with rights on an API key
 var keysUsersCmd = &cobra.Command{
-	Use:         "users <client-id>",
+	Use:         "users <name-or-client-id>",
 	Short:       "List users with rights on an API key",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/keys/:name/users"},
 	Long: `List all users who have been granted rights to access a specific API key.
@@ -349,17 +422,18 @@ var keysUsersCmd = &cobra.Command{
 Shows each user's right level (Read, Write, Admin) and whether they are a tenant admin.
 
 Examples:
-  # List users for an API key
+  # List users for an API key, by name or client ID
+  iz admin keys users my-app-key --tenant my-tenant
   iz admin keys users my-client-id --tenant my-tenant
 
   # Output as JSON
-  iz admin keys users my-client-id --tenant my-tenant -o json`,
+  iz admin keys users my-app-key --tenant my-tenant -o json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		clientID := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -367,7 +441,14 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+		if !exactID {
+			key, err := resolveAPIKey(ctx, client, clientID)
+			if err != nil {
+				return err
+			}
+			clientID = key.ClientID
+		}
 
 		// For JSON output, use Identity mapper for raw JSON passthrough
 		if outputFormat == "json" {
@@ -385,7 +466,7 @@ Examples:
 		}
 
 		if len(users) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No users found for this API key")
+			fmt.Fprintln(statusOut(cmd), "No users found for this API key")
 			return nil
 		}
 
@@ -393,6 +474,102 @@ Examples:
 	},
 }
 
+// keysProvisionCmd creates a project-scoped API key and stores its credentials in the active profile
+var keysProvisionCmd = &cobra.Command{
+	Use:         "provision [name]",
+	Short:       "Create an API key and save it to the active profile",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/keys"},
+	Long: `Create an API key scoped to the given projects and store its client ID and
+secret in the active profile's client keys (tenant → project), so that
+'iz features check' and 'iz events watch' work right away.
+
+The key name defaults to iz-<project>[-<project>...]. Existing credentials of
+the profile for these projects are only replaced after confirmation, unless
+--force is set.
+
+Examples:
+  # Provision a key for one project
+  iz admin keys provision --tenant my-tenant --projects my-project
+
+  # Named key for several projects, replacing stored credentials
+  iz admin keys provision ci-checks --tenant my-tenant --projects web,mobile --force`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.Tenant == "" {
+			return errors.Errorf(errors.MsgTenantRequired)
+		}
+		if len(keyProjects) == 0 {
+			return fmt.Errorf("--projects is required")
+		}
+
+		// Credentials are saved to the active profile: check it before creating the key
+		activeProfile, err := izanami.GetActiveProfileName()
+		if err != nil {
+			return err
+		}
+		if activeProfile == "" {
+			return fmt.Errorf("no active profile. Use 'iz profiles use <name>' to select a profile first")
+		}
+		if profileName != "" && profileName != activeProfile {
+			return fmt.Errorf("credentials are saved to the active profile '%s', not '%s'. Run 'iz profiles use %s' first", activeProfile, profileName, profileName)
+		}
+		profile, err := izanami.GetProfile(activeProfile)
+		if err != nil {
+			return fmt.Errorf("failed to load active profile: %w", err)
+		}
+		if !keysProvisionForce && checkExistingKeys(profile.ClientKeys, cfg.Tenant, keyProjects) {
+			if !confirmAction(cmd, fmt.Sprintf("Profile '%s' already has credentials for '%s/%s'. Replace them?", activeProfile, cfg.Tenant, strings.Join(keyProjects, ","))) {
+				return nil
+			}
+		}
+
+		name := "iz-" + strings.Join(keyProjects, "-")
+		if len(args) > 0 {
+			name = args[0]
+		}
+		description := keyDescription
+		if description == "" {
+			description = fmt.Sprintf("Provisioned by iz for profile %s", activeProfile)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		result, err := client.CreateAPIKey(commandContext(), cfg.Tenant, map[string]interface{}{
+			"name":        name,
+			"description": description,
+			"enabled":     true,
+			"admin":       false,
+			"projects":    keyProjects,
+		})
+		if err != nil {
+			return err
+		}
+		runHooks(cmd, izanami.HookKeyCreate, map[string]interface{}{"name": result.Name, "projects": keyProjects})
+
+		if err := izanami.AddClientKeys(cfg.Tenant, keyProjects, result.ClientID, result.ClientSecret); err != nil {
+			// The secret cannot be retrieved again: show it so it can be saved manually
+			fmt.Fprintf(statusOut(cmd), "API key %s was created but could not be saved to the profile.\n", result.Name)
+			fmt.Fprintf(statusOut(cmd), "Client ID:     %s\n", result.ClientID)
+			fmt.Fprintf(statusOut(cmd), "Client Secret: %s\n", result.ClientSecret)
+			return fmt.Errorf("failed to save credentials: %w", err)
+		}
+
+		if output.Format(outputFormat) == output.JSON {
+			redactAPIKeySecret(result)
+			return output.PrintTo(cmd.OutOrStdout(), result, output.JSON)
+		}
+
+		fmt.Fprintf(statusOut(cmd), "✅ API key %s created\n", result.Name)
+		fmt.Fprintf(statusOut(cmd), "Client credentials saved to profile '%s' for tenant '%s', projects: %s\n", activeProfile, cfg.Tenant, strings.Join(keyProjects, ", "))
+		printSecurityWarning(statusOut(cmd))
+		fmt.Fprintf(statusOut(cmd), "\nYou can now use these credentials with:\n")
+		fmt.Fprintf(statusOut(cmd), "  iz features check --tenant %s --project %s <feature-id>\n", cfg.Tenant, keyProjects[0])
+		return nil
+	},
+}
+
 func init() {
 	adminCmd.AddCommand(keysCmd)
 
@@ -402,6 +579,7 @@ func init() {
 	keysCmd.AddCommand(keysUpdateCmd)
 	keysCmd.AddCommand(keysDeleteCmd)
 	keysCmd.AddCommand(keysUsersCmd)
+	keysCmd.AddCommand(keysProvisionCmd)
 
 	// Create flags
 	keysCreateCmd.Flags().StringVar(&keyDescription, "description", "", "Description of the API key")
@@ -416,10 +594,16 @@ func init() {
 	keysUpdateCmd.Flags().BoolVar(&keyEnabled, "enabled", true, "Whether the key is enabled")
 	keysUpdateCmd.Flags().BoolVar(&keyAdmin, "admin", false, "Whether this key has admin privileges")
 
+	// Provision flags
+	keysProvisionCmd.Flags().StringSliceVar(&keyProjects, "projects", []string{}, "Projects the key can access (required)")
+	keysProvisionCmd.Flags().StringVar(&keyDescription, "description", "", "Description of the API key")
+	keysProvisionCmd.Flags().BoolVarP(&keysProvisionForce, "force", "f", false, "Replace stored credentials without confirmation")
+
 	// Delete flags
 	keysDeleteCmd.Flags().BoolVarP(&keysDeleteForce, "force", "f", false, "Skip confirmation prompt")
 
 	// Show secrets flags
 	keysListCmd.Flags().BoolVar(&keysShowSecrets, "show-secrets", false, "Show client secrets (hidden by default)")
-	keysGetCmd.Flags().BoolVar(&keysShowSecrets, "show-secrets", false, "Show client secret (hidden by default)")
+	addTenantsFlag(keysListCmd)
+	keysGetCmd.Flags().BoolVar(&keysShowSecrets, "show-secrets", false, "Print the client secret after confirmation, or the steps to rotate the key")
 }
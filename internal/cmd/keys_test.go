@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// setupKeysProvisionTest serves key creation and returns the number of keys created
+func setupKeysProvisionTest(t *testing.T, activeProfile string) *int32 {
+	t.Helper()
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	createTestConfig(t, paths.configPath, map[string]*izanami.Profile{
+		"dev": {LeaderURL: "http://localhost:9000", Tenant: "acme"},
+	}, activeProfile)
+
+	var created int32
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /api/admin/tenants/acme/keys", r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, []interface{}{"web", "mobile"}, body["projects"])
+		assert.Equal(t, false, body["admin"])
+		atomic.AddInt32(&created, 1)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"name": "`+body["name"].(string)+`", "clientId": "cid", "clientSecret": "secret", "enabled": true, "projects": ["web", "mobile"]}`)
+	})
+
+	origProfile := profileName
+	t.Cleanup(func() {
+		profileName = origProfile
+		keyProjects, keysProvisionForce = []string{}, false
+	})
+	outputFormat = "table"
+	keyProjects = []string{"web", "mobile"}
+	return &created
+}
+
+func TestKeysProvisionCmd_SavesCredentials(t *testing.T) {
+	created := setupKeysProvisionTest(t, "dev")
+
+	var buf bytes.Buffer
+	keysProvisionCmd.SetOut(&buf)
+	defer keysProvisionCmd.SetOut(nil)
+	require.NoError(t, keysProvisionCmd.RunE(keysProvisionCmd, nil))
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(created))
+	assert.Contains(t, buf.String(), "API key iz-web-mobile created")
+	assert.NotContains(t, buf.String(), "secret\n")
+
+	profile, err := izanami.GetProfile("dev")
+	require.NoError(t, err)
+	for _, project := range []string{"web", "mobile"} {
+		keys := profile.ClientKeys["acme"].Projects[project]
+		assert.Equal(t, "cid", keys.ClientID, project)
+		assert.Equal(t, "secret", keys.ClientSecret, project)
+	}
+}
+
+func TestKeysProvisionCmd_ConfirmsReplacement(t *testing.T) {
+	created := setupKeysProvisionTest(t, "dev")
+	require.NoError(t, izanami.AddClientKeys("acme", []string{"web"}, "old-id", "old-secret"))
+
+	var buf bytes.Buffer
+	keysProvisionCmd.SetOut(&buf)
+	keysProvisionCmd.SetIn(bytes.NewBufferString("n\n"))
+	defer func() {
+		keysProvisionCmd.SetOut(nil)
+		keysProvisionCmd.SetIn(nil)
+	}()
+	require.NoError(t, keysProvisionCmd.RunE(keysProvisionCmd, []string{"ci"}))
+
+	assert.Contains(t, buf.String(), "already has credentials for 'acme/web,mobile'")
+	assert.Equal(t, int32(0), atomic.LoadInt32(created), "no key is created when cancelled")
+}
+
+func TestKeysProvisionCmd_RequiresActiveProfile(t *testing.T) {
+	created := setupKeysProvisionTest(t, "")
+
+	err := keysProvisionCmd.RunE(keysProvisionCmd, nil)
+	assert.ErrorContains(t, err, "no active profile")
+
+	profileName = "other"
+	createTestConfig(t, filepath.Join(izanami.GetConfigDir(), "config.yaml"), map[string]*izanami.Profile{"dev": {Tenant: "acme"}}, "dev")
+	err = keysProvisionCmd.RunE(keysProvisionCmd, nil)
+	assert.ErrorContains(t, err, "saved to the active profile 'dev', not 'other'")
+	assert.Equal(t, int32(0), atomic.LoadInt32(created))
+}
+
+// setupKeysGetRevealTest serves a key listing and answers the confirmation prompt
+func setupKeysGetRevealTest(t *testing.T, secret, answer string) *bytes.Buffer {
+	t.Helper()
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET /api/admin/tenants/acme/keys", r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"name": "ci", "clientId": "cid", "clientSecret": "`+secret+`", "enabled": true, "admin": true, "projects": ["web", "mobile"]}]`)
+	})
+
+	t.Cleanup(func() {
+		keysShowSecrets = false
+		keysGetCmd.SetOut(nil)
+		keysGetCmd.SetIn(nil)
+	})
+	outputFormat = "table"
+	keysShowSecrets = true
+
+	var buf bytes.Buffer
+	keysGetCmd.SetOut(&buf)
+	keysGetCmd.SetIn(bytes.NewBufferString(answer))
+	return &buf
+}
+
+func TestKeysGetCmd_RevealPrintsSecretAfterConfirmation(t *testing.T) {
+	buf := setupKeysGetRevealTest(t, "s3cret", "y\n")
+	require.NoError(t, keysGetCmd.RunE(keysGetCmd, []string{"ci"}))
+	assert.Contains(t, buf.String(), "Client Secret: s3cret")
+}
+
+func TestKeysGetCmd_RevealDeclined(t *testing.T) {
+	buf := setupKeysGetRevealTest(t, "s3cret", "n\n")
+	err := keysGetCmd.RunE(keysGetCmd, []string{"ci"})
+	assert.EqualError(t, err, "client secret of API key 'ci' not printed: confirmation declined (pass --yes to skip it)")
+	assert.NotContains(t, buf.String(), "s3cret")
+}
+
+func TestKeysGetCmd_RevealWithoutTerminalNeedsYes(t *testing.T) {
+	buf := setupKeysGetRevealTest(t, "s3cret", "")
+	stdin, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer stdin.Close()
+	keysGetCmd.SetIn(stdin)
+
+	err = keysGetCmd.RunE(keysGetCmd, []string{"ci"})
+	assert.ErrorContains(t, err, "pass --yes when stdin is not a terminal")
+	assert.Empty(t, buf.String(), "no prompt should be printed")
+
+	origYes := assumeYes
+	defer func() { assumeYes = origYes }()
+	assumeYes = true
+	require.NoError(t, keysGetCmd.RunE(keysGetCmd, []string{"ci"}))
+	assert.Contains(t, buf.String(), "Client Secret: s3cret")
+}
+
+func TestKeysGetCmd_RevealWithoutSecretPrintsRotationSteps(t *testing.T) {
+	setupKeysGetRevealTest(t, "", "y\n")
+	err := keysGetCmd.RunE(keysGetCmd, []string{"ci"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not return the secret")
+	assert.Contains(t, err.Error(), "iz admin keys create ci-rotated --tenant acme --projects web,mobile --admin")
+	assert.Contains(t, err.Error(), "iz admin keys delete ci --tenant acme")
+}
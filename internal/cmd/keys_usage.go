@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var keysUsageUnreferenced bool
+
+// keysUsageCmd reports what each API key gives access to and where it is used locally
+var keysUsageCmd = &cobra.Command{
+	Use:         "usage",
+	Short:       "Show the access and local references of API keys",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/keys"},
+	Long: `Show, for each API key of a tenant, the projects it can access (all for
+admin keys), when it was last used and which client-keys entries of the local
+profiles reference its client ID, to spot dead keys before rotating or
+deleting them.
+
+The last use date is only shown when the server exposes it. References are
+listed as profile[/worker]:tenant[/project].
+
+Examples:
+  # Usage report of all keys
+  iz admin keys usage --tenant my-tenant
+
+  # Keys no local profile references
+  iz admin keys usage --tenant my-tenant --unreferenced`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.Tenant == "" {
+			return errors.Errorf(errors.MsgTenantRequired)
+		}
+
+		profiles, _, err := izanami.ListProfiles()
+		if err != nil {
+			return fmt.Errorf("failed to load profiles: %w", err)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		usages, err := izanami.ListAPIKeyUsage(client, commandContext(), cfg.Tenant, profiles)
+		if err != nil {
+			return err
+		}
+		if keysUsageUnreferenced {
+			unreferenced := []izanami.APIKeyUsage{}
+			for _, usage := range usages {
+				if len(usage.References) == 0 {
+					unreferenced = append(unreferenced, usage)
+				}
+			}
+			usages = unreferenced
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), usages, output.JSON)
+		}
+		if len(usages) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No API keys found")
+			return nil
+		}
+		views := make([]izanami.APIKeyUsageTableView, len(usages))
+		for i, usage := range usages {
+			views[i] = usage.ToTableView()
+		}
+		return output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat))
+	},
+}
+
+func init() {
+	keysCmd.AddCommand(keysUsageCmd)
+
+	keysUsageCmd.Flags().BoolVar(&keysUsageUnreferenced, "unreferenced", false, "Only show keys no local profile references")
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+var (
+	logLevel  string
+	logFormat string
+	logFile   string
+
+	// openLogFile is the --log-file currently written to, closed when the command ends
+	openLogFile *os.File
+)
+
+// setupLogging enables HTTP tracing when any of the logging flags is set.
+// Logs go to stderr, or are appended to --log-file.
+func setupLogging(cmd *cobra.Command) error {
+	closeLogFile()
+	izanami.SetHTTPLogger(nil)
+
+	flags := cmd.Flags()
+	if !flags.Changed("log-level") && !flags.Changed("log-format") && !flags.Changed("log-file") {
+		return nil
+	}
+
+	var w io.Writer = cmd.ErrOrStderr()
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		openLogFile = f
+		w = f
+	}
+
+	logger, err := izanami.NewLogger(w, logLevel, logFormat)
+	if err != nil {
+		closeLogFile()
+		return err
+	}
+	izanami.SetHTTPLogger(logger)
+	return nil
+}
+
+// closeLogFile closes the --log-file opened by setupLogging, if any
+func closeLogFile() {
+	if openLogFile != nil {
+		openLogFile.Close()
+		openLogFile = nil
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", izanami.LogLevelInfo, "Log every API call at this level: debug (with redacted headers), info or warn (failures only)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", izanami.LogFormatText, "Log format: text or json")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append logs to this file instead of stderr")
+}
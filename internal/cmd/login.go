@@ -105,13 +105,13 @@ The missing value is resolved from the active session.`,
 				}
 				username = resolvedUser
 				if verbose {
-					fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using username from %s: %s\n", userSrc, username)
+					fmt.Fprintf(statusOut(cmd), "[verbose] Using username from %s: %s\n", userSrc, username)
 				}
 
 				// Auto-detect OIDC: if previous session used OIDC and no password flag, redirect
 				if prevAuth == izanami.AuthMethodOIDC && !cmd.Flags().Changed("password") {
 					if verbose {
-						fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Previous session used OIDC, redirecting to OIDC flow\n")
+						fmt.Fprintf(statusOut(cmd), "[verbose] Previous session used OIDC, redirecting to OIDC flow\n")
 					}
 					return runOIDCLogin(cmd, args)
 				}
@@ -128,7 +128,7 @@ The missing value is resolved from the active session.`,
 				}
 				loginBaseURL = resolved
 				if verbose {
-					fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using URL from %s: %s\n", source, loginBaseURL)
+					fmt.Fprintf(statusOut(cmd), "[verbose] Using URL from %s: %s\n", source, loginBaseURL)
 				}
 			}
 		case 0:
@@ -152,14 +152,14 @@ The missing value is resolved from the active session.`,
 			loginBaseURL = resolvedURL
 			username = resolvedUser
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using URL from %s: %s\n", urlSrc, loginBaseURL)
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using username from %s: %s\n", userSrc, username)
+				fmt.Fprintf(statusOut(cmd), "[verbose] Using URL from %s: %s\n", urlSrc, loginBaseURL)
+				fmt.Fprintf(statusOut(cmd), "[verbose] Using username from %s: %s\n", userSrc, username)
 			}
 
 			// Auto-detect OIDC: if previous session used OIDC and no password flag, redirect
 			if prevAuth == izanami.AuthMethodOIDC && !cmd.Flags().Changed("password") {
 				if verbose {
-					fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Previous session used OIDC, redirecting to OIDC flow\n")
+					fmt.Fprintf(statusOut(cmd), "[verbose] Previous session used OIDC, redirecting to OIDC flow\n")
 				}
 				return runOIDCLogin(cmd, args)
 			}
@@ -167,27 +167,27 @@ The missing value is resolved from the active session.`,
 
 		// Verbose: Log login attempt details
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Login attempt:\n")
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   URL: %s\n", loginBaseURL)
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   Username: %s\n", username)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Login attempt:\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose]   URL: %s\n", loginBaseURL)
+			fmt.Fprintf(statusOut(cmd), "[verbose]   Username: %s\n", username)
 		}
 
 		// Get password
 		password := loginPassword
 		if password == "" {
-			fmt.Fprintf(cmd.OutOrStderr(), "Password: ")
+			fmt.Fprintf(statusOut(cmd), "Password: ")
 			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
-			fmt.Fprintln(cmd.OutOrStderr()) // New line after password input
+			fmt.Fprintln(statusOut(cmd)) // New line after password input
 			if err != nil {
 				return fmt.Errorf("failed to read password: %w", err)
 			}
 			password = string(passwordBytes)
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   Password: <redacted> (%d chars)\n", len(password))
+				fmt.Fprintf(statusOut(cmd), "[verbose]   Password: <redacted> (%d chars)\n", len(password))
 			}
 		} else {
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   Password: <redacted from flag> (%d chars)\n", len(password))
+				fmt.Fprintf(statusOut(cmd), "[verbose]   Password: <redacted from flag> (%d chars)\n", len(password))
 			}
 		}
 
@@ -196,9 +196,9 @@ The missing value is resolved from the active session.`,
 		}
 
 		// Login to Izanami
-		fmt.Fprintf(cmd.OutOrStderr(), "Authenticating with %s...\n", loginBaseURL)
+		fmt.Fprintf(statusOut(cmd), "Authenticating with %s...\n", loginBaseURL)
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Sending POST request to %s/api/admin/login\n", loginBaseURL)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Sending POST request to %s/api/admin/login\n", loginBaseURL)
 		}
 
 		loginTimeout := timeout
@@ -208,14 +208,14 @@ The missing value is resolved from the active session.`,
 		token, err := performLogin(loginBaseURL, username, password, insecureSkipVerify, verbose, loginTimeout)
 		if err != nil {
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Login failed: %v\n", err)
+				fmt.Fprintf(statusOut(cmd), "[verbose] Login failed: %v\n", err)
 			}
 			return fmt.Errorf("login failed: %w", err)
 		}
 
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Login successful\n")
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Token received: <redacted> (%d chars)\n", len(token))
+			fmt.Fprintf(statusOut(cmd), "[verbose] Login successful\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] Token received: <redacted> (%d chars)\n", len(token))
 		}
 
 		// Determine profile and session name
@@ -234,7 +234,7 @@ The missing value is resolved from the active session.`,
 			return err
 		}
 
-		printLoginSuccess(cmd.OutOrStderr(), username, sessionName, profileName, profileCreated, profileUpdated, false)
+		printLoginSuccess(statusOut(cmd), username, sessionName, profileName, profileCreated, profileUpdated, false)
 
 		return nil
 	},
@@ -262,7 +262,7 @@ func resolveLoginDefaults(cmd *cobra.Command) (resolvedURL, urlSource, resolvedU
 	loadedCfg, _, err := izanami.LoadConfigWithProfile(profileName)
 	if err != nil {
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Could not load config for defaults: %v\n", err)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Could not load config for defaults: %v\n", err)
 		}
 		return
 	}
@@ -286,16 +286,30 @@ func performLogin(baseURL, username, password string, insecure bool, verboseMode
 	config := &izanami.ResolvedConfig{
 		LeaderURL:          baseURL,
 		Timeout:            timeoutSec,
+		Retries:            izanami.DefaultRetries,
 		Verbose:            verboseMode,
 		InsecureSkipVerify: insecure,
 	}
 
+	// Gateway and TLS settings of the config file and profile, --header and
+	// the TLS flags also apply to login
+	if resolved, _, err := izanami.LoadConfigWithProfile(profileName); err == nil {
+		config.HTTPHeaders, config.ProxyURL = resolved.HTTPHeaders, resolved.ProxyURL
+		config.CACertFile, config.ClientCertFile, config.ClientKeyFile = resolved.CACertFile, resolved.ClientCertFile, resolved.ClientKeyFile
+	}
+	config.MergeWithFlags(izanami.FlagValues{
+		HTTPHeaders:    httpHeaders,
+		CACertFile:     getValueWithEnvFallback(caCertFile, "IZ_CA_CERT"),
+		ClientCertFile: getValueWithEnvFallback(clientCertFile, "IZ_CLIENT_CERT"),
+		ClientKeyFile:  getValueWithEnvFallback(clientKeyFile, "IZ_CLIENT_KEY"),
+	})
+
 	client, err := izanami.NewAdminClientNoAuth(config)
 	if err != nil {
 		return "", err
 	}
 
-	ctx := context.Background()
+	ctx := commandContext()
 	token, err := client.Login(ctx, username, password)
 	if err != nil {
 		return "", err
@@ -483,11 +497,11 @@ func saveLoginSession(cmd *cobra.Command, baseURL, username, token, authMethod,
 	sessions, err := izanami.LoadSessions()
 	if err != nil {
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] No existing sessions found, creating new session store\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] No existing sessions found, creating new session store\n")
 		}
 		sessions = &izanami.Sessions{Sessions: make(map[string]*izanami.Session)}
 	} else if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Loaded %d existing sessions\n", len(sessions.Sessions))
+		fmt.Fprintf(statusOut(cmd), "[verbose] Loaded %d existing sessions\n", len(sessions.Sessions))
 	}
 
 	session := &izanami.Session{
@@ -505,7 +519,7 @@ func saveLoginSession(cmd *cobra.Command, baseURL, username, token, authMethod,
 			existing.AuthMethod = authMethod
 			existing.CreatedAt = session.CreatedAt
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Refreshed existing session: %s\n", name)
+				fmt.Fprintf(statusOut(cmd), "[verbose] Refreshed existing session: %s\n", name)
 			}
 		}
 	}
@@ -513,7 +527,7 @@ func saveLoginSession(cmd *cobra.Command, baseURL, username, token, authMethod,
 	sessions.AddSession(sessionName, session)
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Saving session to disk...\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Saving session to disk...\n")
 	}
 
 	if err := sessions.Save(); err != nil {
@@ -521,7 +535,7 @@ func saveLoginSession(cmd *cobra.Command, baseURL, username, token, authMethod,
 	}
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Session saved successfully\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Session saved successfully\n")
 	}
 
 	return nil
@@ -558,7 +572,7 @@ func printLoginSuccess(w io.Writer, username, sessionName, profileName string, p
 // suffix is appended to the session name (e.g., "session" or "oidc").
 func resolveProfileAndSession(cmd *cobra.Command, baseURL, username, suffix string) (profName, sessName string, profileCreated, profileUpdated bool, err error) {
 	profName, profileCreated, profileUpdated, err = determineProfileName(
-		cmd.InOrStdin(), cmd.OutOrStderr(), baseURL, username, profileName)
+		cmd.InOrStdin(), statusOut(cmd), baseURL, username, profileName)
 	if err != nil {
 		return
 	}
@@ -569,8 +583,8 @@ func resolveProfileAndSession(cmd *cobra.Command, baseURL, username, suffix stri
 	}
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Profile: %s (created: %v, updated: %v)\n", profName, profileCreated, profileUpdated)
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Session name: %s\n", sessName)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Profile: %s (created: %v, updated: %v)\n", profName, profileCreated, profileUpdated)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Session name: %s\n", sessName)
 	}
 
 	return
@@ -611,7 +625,7 @@ func resolveProfileAndSession(cmd *cobra.Command, baseURL, username, suffix stri
 func runOIDCLogin(cmd *cobra.Command, args []string) error {
 	// Verbose: Log OIDC login start
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] OIDC login flow initiated\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] OIDC login flow initiated\n")
 	}
 
 	// Get leader URL from args, flags, env, or active profile/session
@@ -619,14 +633,14 @@ func runOIDCLogin(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		oidcBaseURL = args[0]
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] URL source: command argument\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] URL source: command argument\n")
 		}
 	} else {
 		resolved, source, _, _, _ := resolveLoginDefaults(cmd)
 		if resolved != "" {
 			oidcBaseURL = resolved
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] URL source: %s\n", source)
+				fmt.Fprintf(statusOut(cmd), "[verbose] URL source: %s\n", source)
 			}
 		}
 	}
@@ -639,29 +653,29 @@ func runOIDCLogin(cmd *cobra.Command, args []string) error {
 	oidcBaseURL = strings.TrimSuffix(oidcBaseURL, "/")
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Base URL: %s\n", oidcBaseURL)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Base URL: %s\n", oidcBaseURL)
 	}
 
 	// If token provided via flag, skip browser flow entirely
 	// This is useful for scripting or when automatic polling isn't available
 	if loginToken != "" {
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Token provided via --token flag, skipping browser flow\n")
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Token: <redacted> (%d chars)\n", len(loginToken))
+			fmt.Fprintf(statusOut(cmd), "[verbose] Token provided via --token flag, skipping browser flow\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] Token: <redacted> (%d chars)\n", len(loginToken))
 		}
 		return saveOIDCSession(cmd, oidcBaseURL, loginToken)
 	}
 
 	// Check if server supports CLI OIDC authentication (state-based polling)
 	// This endpoint was added in Izanami server to support CLI tools
-	ctx := context.Background()
+	ctx := commandContext()
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Checking server support for CLI OIDC authentication...\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Probing: GET %s/api/admin/cli-login?state=check\n", oidcBaseURL)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Checking server support for CLI OIDC authentication...\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Probing: GET %s/api/admin/cli-login?state=check\n", oidcBaseURL)
 	}
 	if !auth.CheckServerSupport(ctx, oidcBaseURL) {
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Server does not support CLI OIDC (endpoint returned 404)\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] Server does not support CLI OIDC (endpoint returned 404)\n")
 		}
 		// Server doesn't support CLI auth - provide helpful error with workaround
 		return fmt.Errorf(`server does not support CLI OIDC authentication
@@ -676,7 +690,7 @@ Workaround: Authenticate manually and provide the token:
 	}
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Server supports CLI OIDC authentication\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Server supports CLI OIDC authentication\n")
 	}
 
 	// Generate cryptographically secure state for this authentication session
@@ -687,7 +701,7 @@ Workaround: Authenticate manually and provide the token:
 	}
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Generated state: %s (256-bit entropy)\n", state)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Generated state: %s (256-bit entropy)\n", state)
 	}
 
 	// Build CLI login URL with state parameter
@@ -695,14 +709,14 @@ Workaround: Authenticate manually and provide the token:
 	loginURL := fmt.Sprintf("%s/api/admin/cli-login?state=%s", oidcBaseURL, state)
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Login URL: %s\n", loginURL)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Login URL: %s\n", loginURL)
 	}
 
 	// IMPORTANT: Initiate login via HTTP request BEFORE opening browser
 	// This creates the pending auth on the server, avoiding a race condition where
 	// polling starts before the browser request creates the pending auth.
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Initiating login to create pending auth...\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Initiating login to create pending auth...\n")
 	}
 
 	redirectURL, err := initiateCliLogin(ctx, loginURL)
@@ -711,7 +725,7 @@ Workaround: Authenticate manually and provide the token:
 	}
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Pending auth created, redirect URL: %s\n", redirectURL)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Pending auth created, redirect URL: %s\n", redirectURL)
 	}
 
 	// Open browser to the OIDC provider (redirect URL) unless --no-browser flag is set
@@ -723,28 +737,28 @@ Workaround: Authenticate manually and provide the token:
 
 	if !loginNoBrowser {
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Opening browser (--no-browser: false)\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] Opening browser (--no-browser: false)\n")
 		}
-		fmt.Fprintln(cmd.OutOrStderr(), "Opening browser for OIDC authentication...")
+		fmt.Fprintln(statusOut(cmd), "Opening browser for OIDC authentication...")
 		if err := utils.OpenBrowser(browserURL); err != nil {
 			// Browser open failed - not fatal, user can manually visit URL
-			fmt.Fprintf(cmd.OutOrStderr(), "Warning: Could not open browser: %v\n", err)
+			fmt.Fprintf(statusOut(cmd), "Warning: Could not open browser: %v\n", err)
 			if verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Browser open error: %v\n", err)
+				fmt.Fprintf(statusOut(cmd), "[verbose] Browser open error: %v\n", err)
 			}
 		} else if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Browser opened successfully\n")
+			fmt.Fprintf(statusOut(cmd), "[verbose] Browser opened successfully\n")
 		}
 	} else if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Browser opening disabled (--no-browser: true)\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Browser opening disabled (--no-browser: true)\n")
 	}
 
 	// Print URL for manual access (in case browser doesn't open or user prefers it)
-	fmt.Fprintf(cmd.OutOrStderr(), "\nIf browser doesn't open, visit:\n  %s\n\n", browserURL)
+	fmt.Fprintf(statusOut(cmd), "\nIf browser doesn't open, visit:\n  %s\n\n", browserURL)
 
 	// Start spinner to indicate we're waiting for authentication
 	// The spinner animates if terminal supports it, otherwise shows static message
-	spinner := auth.NewSpinner(cmd.OutOrStderr(), "Waiting for authentication")
+	spinner := auth.NewSpinner(statusOut(cmd), "Waiting for authentication")
 	spinner.Start()
 
 	// Create token poller with configured interval
@@ -763,11 +777,11 @@ Workaround: Authenticate manually and provide the token:
 	}
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Polling configuration:\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   Endpoint: %s/api/admin/cli-token?state=%s\n", oidcBaseURL, state)
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   Poll interval: %v\n", pollInterval)
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose]   Timeout: %v\n", timeout)
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Starting polling loop...\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Polling configuration:\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose]   Endpoint: %s/api/admin/cli-token?state=%s\n", oidcBaseURL, state)
+		fmt.Fprintf(statusOut(cmd), "[verbose]   Poll interval: %v\n", pollInterval)
+		fmt.Fprintf(statusOut(cmd), "[verbose]   Timeout: %v\n", timeout)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Starting polling loop...\n")
 	}
 
 	token, err := poller.WaitForToken(ctx, timeout)
@@ -776,18 +790,18 @@ Workaround: Authenticate manually and provide the token:
 	if err != nil {
 		// Authentication failed - show error with helpful fallback instructions
 		if verbose {
-			fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Polling failed: %v\n", err)
+			fmt.Fprintf(statusOut(cmd), "[verbose] Polling failed: %v\n", err)
 		}
 		spinner.Error(fmt.Sprintf("Authentication failed: %v", err))
-		fmt.Fprintf(cmd.OutOrStderr(), "\nTip: You can manually provide a token using:\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "  iz login --oidc %s --token \"your-jwt-token\"\n", oidcBaseURL)
+		fmt.Fprintf(statusOut(cmd), "\nTip: You can manually provide a token using:\n")
+		fmt.Fprintf(statusOut(cmd), "  iz login --oidc %s --token \"your-jwt-token\"\n", oidcBaseURL)
 		return err
 	}
 
 	// Authentication successful!
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Polling successful, token received\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Token: <redacted> (%d chars)\n", len(token))
+		fmt.Fprintf(statusOut(cmd), "[verbose] Polling successful, token received\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Token: <redacted> (%d chars)\n", len(token))
 	}
 	spinner.Success("Authentication complete!")
 
@@ -798,16 +812,16 @@ Workaround: Authenticate manually and provide the token:
 // saveOIDCSession saves the OIDC session with the provided token
 func saveOIDCSession(cmd *cobra.Command, baseURL, token string) error {
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Saving OIDC session...\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Base URL: %s\n", baseURL)
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Token: <redacted> (%d chars)\n", len(token))
+		fmt.Fprintf(statusOut(cmd), "[verbose] Saving OIDC session...\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Base URL: %s\n", baseURL)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Token: <redacted> (%d chars)\n", len(token))
 	}
 
 	// Decode username from JWT
 	username := decodeJWTUsername(token)
 
 	if verbose {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Decoded username from JWT: %s\n", username)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Decoded username from JWT: %s\n", username)
 	}
 
 	// Determine profile and session name
@@ -826,7 +840,7 @@ func saveOIDCSession(cmd *cobra.Command, baseURL, token string) error {
 		return err
 	}
 
-	printLoginSuccess(cmd.OutOrStderr(), username, sessionName, profileName, profileCreated, profileUpdated, true)
+	printLoginSuccess(statusOut(cmd), username, sessionName, profileName, profileCreated, profileUpdated, true)
 
 	return nil
 }
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// messagesDir returns the directory of the message translations, one
+// <locale>.yaml file per locale
+func messagesDir() string {
+	return filepath.Join(izanami.GetConfigDir(), "messages")
+}
+
+// setupMessages loads the translations of the locale selected by IZ_LANG.
+// A missing or invalid catalog is reported on w, and messages stay in English.
+func setupMessages(w io.Writer) {
+	catalog, warnings, err := errmsg.LoadCatalog(messagesDir(), os.Getenv(errmsg.LangEnvVar))
+	if err != nil {
+		fmt.Fprintln(w, errmsg.Sprintf(errmsg.MsgMessagesNotLoaded, err))
+		catalog = nil
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "[warning] %s\n", warning)
+	}
+	errmsg.SetCatalog(catalog)
+}
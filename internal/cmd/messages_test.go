@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestSetupMessages(t *testing.T) {
+	originalConfigDir := izanami.GetConfigDir()
+	configDir := t.TempDir()
+	izanami.SetGetConfigDirFunc(func() string { return configDir })
+	t.Cleanup(func() {
+		izanami.SetGetConfigDirFunc(func() string { return originalConfigDir })
+		errmsg.SetCatalog(nil)
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "messages"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "messages", "fr.yaml"),
+		[]byte(`"Tag created successfully: %s": "Tag créé : %s"`+"\n"), 0600))
+
+	var buf bytes.Buffer
+	t.Setenv("IZ_LANG", "fr_FR.UTF-8")
+	setupMessages(&buf)
+	assert.Empty(t, buf.String())
+	assert.Equal(t, "Tag créé : beta", errmsg.Sprintf(errmsg.MsgTagCreated, "beta"))
+
+	// An unknown locale falls back to English with a warning
+	t.Setenv("IZ_LANG", "de")
+	setupMessages(&buf)
+	assert.Contains(t, buf.String(), `[warning] no messages for locale "de"`)
+	assert.Equal(t, "Tag created successfully: beta", errmsg.Sprintf(errmsg.MsgTagCreated, "beta"))
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+var (
+	mockServerReplay string
+	mockServerAddr   string
+)
+
+// mockServerCmd serves the responses recorded with --record
+var mockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Serve the responses recorded with --record, in place of Izanami",
+	Long: `Serve the HTTP responses recorded by 'iz --record', so that scripts built on the
+CLI can be tested without a live Izanami instance.
+
+Each request is answered by the first recorded interaction with the same
+method, path and query parameters that was not served yet (preferring the same
+body), then by the last one once all were served. Requests that were not
+recorded get a 404. Credentials are not recorded: any credentials are accepted.
+
+The server runs until interrupted (Ctrl+C). Point the CLI at it with --url.
+
+Examples:
+  # Record the interactions of a script
+  iz --record session.json admin features list --tenant my-tenant
+
+  # Replay them
+  iz mock-server --replay session.json --addr 127.0.0.1:9999 &
+  iz --url http://127.0.0.1:9999 admin features list --tenant my-tenant`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		recording, err := izanami.LoadRecording(mockServerReplay)
+		if err != nil {
+			return err
+		}
+		handler := izanami.NewReplayHandler(recording)
+		handler.OnUnmatched = func(req *http.Request) {
+			fmt.Fprintf(statusOut(cmd), "No recorded response for %s %s\n", req.Method, req.URL.RequestURI())
+		}
+
+		listener, err := net.Listen("tcp", mockServerAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", mockServerAddr, err)
+		}
+		server := &http.Server{Handler: handler}
+		ctx := commandContext()
+		go func() {
+			<-ctx.Done()
+			server.Close()
+		}()
+
+		fmt.Fprintf(statusOut(cmd), "Serving %d recorded interaction(s) on http://%s\n", len(recording.Interactions), listener.Addr())
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mockServerCmd)
+
+	mockServerCmd.Flags().StringVar(&mockServerReplay, "replay", "", "Recording written by --record")
+	mockServerCmd.Flags().StringVar(&mockServerAddr, "addr", "127.0.0.1:8787", "Address to listen on")
+	mockServerCmd.MarkFlagRequired("replay")
+}
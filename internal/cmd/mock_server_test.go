@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMockServerTest(t *testing.T, replay string) *bytes.Buffer {
+	origCtx := commandCtx
+	t.Cleanup(func() {
+		commandCtx = origCtx
+		mockServerReplay, mockServerAddr = "", "127.0.0.1:8787"
+		mockServerCmd.SetOut(nil)
+	})
+	mockServerReplay, mockServerAddr = replay, "127.0.0.1:0"
+
+	var buf bytes.Buffer
+	mockServerCmd.SetOut(&buf)
+	return &buf
+}
+
+func TestMockServerCmd_MissingRecording(t *testing.T) {
+	setupMockServerTest(t, filepath.Join(t.TempDir(), "missing.json"))
+	err := mockServerCmd.RunE(mockServerCmd, nil)
+	assert.ErrorContains(t, err, "failed to read recording")
+}
+
+func TestMockServerCmd_StopsWhenInterrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":1,"interactions":[{"request":{"method":"GET","path":"/api/_health"},"response":{"status":200}}]}`), 0600))
+	buf := setupMockServerTest(t, path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	commandCtx = ctx
+	require.NoError(t, mockServerCmd.RunE(mockServerCmd, nil))
+	assert.Contains(t, buf.String(), "Serving 1 recorded interaction(s) on http://127.0.0.1:")
+}
@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	// Multi-profile flags
+	targetProfiles []string
+	allProfiles    bool
+
+	// multiProfilePending is set once the command has been wrapped to run once per profile,
+	// so that PersistentPreRunE hooks skip loading a single config
+	multiProfilePending bool
+	// multiProfileRunning is set while the command runs for one of the target profiles
+	multiProfileRunning bool
+)
+
+// multiProfileCommands are the read-only commands that can run across several profiles
+var multiProfileCommands = map[string]bool{
+	"list":  true,
+	"get":   true,
+	"check": true,
+}
+
+// ProfileResult is the outcome of a command run against one profile
+type ProfileResult struct {
+	Profile string          `json:"profile"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// multiProfileRequested reports whether --profiles or --all-profiles was given
+func multiProfileRequested(cmd *cobra.Command) bool {
+	return !multiProfileRunning && (cmd.Flags().Changed("profiles") || allProfiles)
+}
+
+// prepareMultiProfileRun validates a multi-profile invocation and replaces the command's
+// RunE with one that runs the original command once per target profile
+func prepareMultiProfileRun(cmd *cobra.Command) error {
+	if !multiProfileCommands[cmd.Name()] || cmd.RunE == nil || skipsConfigLoading(cmd) {
+		return fmt.Errorf("--profiles and --all-profiles are only supported by read-only commands (list, get, check)")
+	}
+	if cmd.Flags().Changed("profiles") && allProfiles {
+		return fmt.Errorf("--profiles and --all-profiles are mutually exclusive")
+	}
+	if profileName != "" {
+		return fmt.Errorf("--profile cannot be combined with --profiles or --all-profiles")
+	}
+
+	profiles, err := resolveTargetProfiles()
+	if err != nil {
+		return err
+	}
+
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		defer func() { cmd.RunE = runE }()
+		return runAcrossProfiles(cmd, args, profiles, runE)
+	}
+	multiProfilePending = true
+	return nil
+}
+
+// resolveTargetProfiles returns the profiles selected by --profiles or --all-profiles
+func resolveTargetProfiles() ([]string, error) {
+	profiles, _, err := izanami.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	if allProfiles {
+		if len(profiles) == 0 {
+			return nil, fmt.Errorf("no profiles configured (use 'iz profiles add <name>' to create one)")
+		}
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range targetProfiles {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, exists := profiles[name]; !exists {
+			return nil, fmt.Errorf("profile '%s' not found", name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("--profiles requires at least one profile name")
+	}
+	return names, nil
+}
+
+// runAcrossProfiles runs the command once per profile with JSON output captured,
+// then prints the aggregated results keyed by profile
+func runAcrossProfiles(cmd *cobra.Command, args []string, profiles []string, runE func(*cobra.Command, []string) error) error {
+	out := cmd.OutOrStdout()
+	format := outputFormat
+	defer func() {
+		cmd.SetOut(out)
+		outputFormat = format
+		profileName = ""
+		multiProfilePending = false
+		multiProfileRunning = false
+	}()
+
+	multiProfilePending = false
+	multiProfileRunning = true
+
+	results := make([]ProfileResult, 0, len(profiles))
+	failed := 0
+	for _, name := range profiles {
+		result := runForProfile(cmd, args, name, runE)
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if err := printProfileResults(out, results, format); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("command failed for %d of %d profile(s)", failed, len(profiles))
+	}
+	return nil
+}
+
+// runForProfile loads the config of one profile and runs the command against it
+func runForProfile(cmd *cobra.Command, args []string, name string, runE func(*cobra.Command, []string) error) ProfileResult {
+	result := ProfileResult{Profile: name}
+
+	var buf bytes.Buffer
+	profileName = name
+	outputFormat = "json"
+	cmd.SetOut(&buf)
+
+	if err := runPersistentPreRun(cmd, args); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if err := runE(cmd, args); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	raw, err := extractJSON(buf.Bytes())
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Result = raw
+	return result
+}
+
+// runPersistentPreRun runs the closest PersistentPreRunE, as cobra does before RunE
+func runPersistentPreRun(cmd *cobra.Command, args []string) error {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.PersistentPreRunE != nil {
+			return c.PersistentPreRunE(cmd, args)
+		}
+	}
+	return nil
+}
+
+// extractJSON returns the JSON document printed by a command, skipping any
+// status or verbose lines written before it
+func extractJSON(data []byte) (json.RawMessage, error) {
+	for offset := 0; offset < len(data); {
+		line := bytes.TrimSpace(data[offset:])
+		if len(line) > 0 && (line[0] == '{' || line[0] == '[') && json.Valid(line) {
+			var compacted bytes.Buffer
+			if err := json.Compact(&compacted, line); err != nil {
+				return nil, err
+			}
+			return compacted.Bytes(), nil
+		}
+		next := bytes.IndexByte(data[offset:], '\n')
+		if next < 0 {
+			break
+		}
+		offset += next + 1
+	}
+	return nil, fmt.Errorf("command produced no JSON output")
+}
+
+// printProfileResults prints the results of every profile as JSON or as a single table
+// whose first column is the profile
+func printProfileResults(w io.Writer, results []ProfileResult, format string) error {
+	if format == "json" {
+		return output.PrintTo(w, results, output.JSON)
+	}
+
+	grouped := make([]groupedResult, len(results))
+	for i, result := range results {
+		grouped[i] = groupedResult{key: result.Profile, result: result.Result, err: result.Error}
+	}
+	header, rows := groupedRows("PROFILE", grouped)
+	return output.PrintRows(w, header, rows)
+}
+
+// groupedResult is the JSON result of a command run for one profile or tenant
+type groupedResult struct {
+	key    string
+	result json.RawMessage
+	err    string
+}
+
+// groupedRows flattens grouped results into a header whose first column is the
+// group and one row per result item, with an ERROR column when a group failed
+func groupedRows(column string, results []groupedResult) ([]string, [][]string) {
+	var columns []string
+	known := make(map[string]bool)
+	var rows []profileRow
+	hasErrors := false
+	for _, result := range results {
+		if result.err != "" {
+			hasErrors = true
+			rows = append(rows, profileRow{profile: result.key, err: result.err})
+			continue
+		}
+		items, keys := flattenResult(result.result)
+		for _, key := range keys {
+			if !known[key] {
+				known[key] = true
+				columns = append(columns, key)
+			}
+		}
+		if len(items) == 0 {
+			rows = append(rows, profileRow{profile: result.key})
+			continue
+		}
+		for _, item := range items {
+			rows = append(rows, profileRow{profile: result.key, values: item})
+		}
+	}
+
+	header := append([]string{column}, columns...)
+	if hasErrors {
+		header = append(header, "ERROR")
+	}
+	cells := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		line := []string{row.profile}
+		for _, column := range columns {
+			line = append(line, formatJSONCell(row.values[column]))
+		}
+		if hasErrors {
+			line = append(line, row.err)
+		}
+		cells = append(cells, line)
+	}
+	return header, cells
+}
+
+// printGroupedTable prints grouped rows as an aligned table
+func printGroupedTable(w io.Writer, header []string, rows [][]string) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetColumnSeparator("")
+	table.SetHeaderLine(false)
+	table.SetAutoWrapText(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// profileRow is one table row: a result item of a profile or tenant, or its error
+type profileRow struct {
+	profile string
+	values  map[string]json.RawMessage
+	err     string
+}
+
+// flattenResult turns a JSON result into table items and the scalar keys they contain,
+// in document order. Arrays produce one item per element.
+func flattenResult(raw json.RawMessage) ([]map[string]json.RawMessage, []string) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(raw, &elements); err != nil {
+		elements = []json.RawMessage{raw}
+	}
+
+	var items []map[string]json.RawMessage
+	var keys []string
+	seen := make(map[string]bool)
+	for _, element := range elements {
+		var item map[string]json.RawMessage
+		elementKeys := []string{"value"}
+		if err := json.Unmarshal(element, &item); err != nil {
+			item = map[string]json.RawMessage{"value": element}
+		} else {
+			elementKeys = orderedKeys(element)
+		}
+		for _, key := range elementKeys {
+			if !seen[key] && isScalarJSON(item[key]) {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, keys
+}
+
+// orderedKeys returns the keys of a JSON object in the order they appear in the document
+func orderedKeys(raw json.RawMessage) []string {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil {
+		return nil
+	}
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return keys
+		}
+		key, ok := token.(string)
+		if !ok {
+			return keys
+		}
+		keys = append(keys, key)
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return keys
+		}
+	}
+	return keys
+}
+
+// isScalarJSON reports whether a JSON value is a string, number, boolean or null
+func isScalarJSON(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] != '{' && trimmed[0] != '['
+}
+
+// formatJSONCell renders a JSON value for a table cell
+func formatJSONCell(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw)
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		return string(bytes.TrimSpace(raw))
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringSliceVar(&targetProfiles, "profiles", nil, "Run a read-only command against each of these profiles (comma-separated)")
+	rootCmd.PersistentFlags().BoolVar(&allProfiles, "all-profiles", false, "Run a read-only command against every configured profile")
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// setupMultiProfileCommand builds "iz get" sharing rootCmd's flags and PersistentPreRunE.
+// The get command prints the leader URL of the config loaded for the current profile.
+func setupMultiProfileCommand(t *testing.T, buf *bytes.Buffer, args []string) *cobra.Command {
+	t.Helper()
+
+	origFormat := outputFormat
+	t.Cleanup(func() {
+		targetProfiles = nil
+		allProfiles = false
+		profileName = ""
+		outputFormat = origFormat
+		cfg = nil
+		for _, name := range []string{"profiles", "all-profiles", "profile", "output"} {
+			if f := rootCmd.PersistentFlags().Lookup(name); f != nil {
+				f.Changed = false
+			}
+		}
+	})
+
+	root := &cobra.Command{Use: "iz", PersistentPreRunE: rootCmd.PersistentPreRunE, SilenceUsage: true, SilenceErrors: true}
+	root.PersistentFlags().AddFlagSet(rootCmd.PersistentFlags())
+	get := &cobra.Command{
+		Use: "get",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Tenant == "broken" {
+				return fmt.Errorf("tenant not found")
+			}
+			fmt.Fprintln(cmd.OutOrStderr(), "[verbose] status line")
+			fmt.Fprintf(cmd.OutOrStdout(), `{"url":%q,"tenant":%q,"enabled":true}`+"\n", cfg.LeaderURL, cfg.Tenant)
+			return nil
+		},
+	}
+	create := &cobra.Command{Use: "create", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	root.AddCommand(get, create)
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	return root
+}
+
+func setupMultiProfileConfig(t *testing.T) {
+	t.Helper()
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	createTestConfig(t, paths.configPath, map[string]*izanami.Profile{
+		"dev":     {LeaderURL: "http://dev.example.com", Tenant: "dev-tenant"},
+		"prod":    {LeaderURL: "http://prod.example.com", Tenant: "prod-tenant"},
+		"staging": {LeaderURL: "http://staging.example.com", Tenant: "broken"},
+	}, "dev")
+}
+
+func TestMultiProfile_JSONResultsKeyedByProfile(t *testing.T) {
+	setupMultiProfileConfig(t)
+
+	var buf bytes.Buffer
+	cmd := setupMultiProfileCommand(t, &buf, []string{"get", "--profiles", "prod,dev", "-o", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var results []ProfileResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results), buf.String())
+	require.Len(t, results, 2)
+	assert.Equal(t, "prod", results[0].Profile)
+	assert.JSONEq(t, `{"url":"http://prod.example.com","tenant":"prod-tenant","enabled":true}`, string(results[0].Result))
+	assert.Equal(t, "dev", results[1].Profile)
+	assert.JSONEq(t, `{"url":"http://dev.example.com","tenant":"dev-tenant","enabled":true}`, string(results[1].Result))
+}
+
+func TestMultiProfile_AllProfilesTableWithErrors(t *testing.T) {
+	setupMultiProfileConfig(t)
+
+	var buf bytes.Buffer
+	cmd := setupMultiProfileCommand(t, &buf, []string{"get", "--all-profiles"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed for 1 of 3 profile(s)")
+
+	out := buf.String()
+	assert.Contains(t, out, "PROFILE")
+	assert.Contains(t, out, "ERROR")
+	assert.Regexp(t, `dev\s+http://dev.example.com\s+dev-tenant\s+true`, out)
+	assert.Regexp(t, `prod\s+http://prod.example.com\s+prod-tenant\s+true`, out)
+	assert.Regexp(t, `staging\s+tenant not found`, out)
+}
+
+func TestMultiProfile_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unknown profile", []string{"get", "--profiles", "dev,qa"}, "profile 'qa' not found"},
+		{"write command", []string{"create", "--profiles", "dev"}, "only supported by read-only commands"},
+		{"both flags", []string{"get", "--profiles", "dev", "--all-profiles"}, "mutually exclusive"},
+		{"with --profile", []string{"get", "--profiles", "dev", "--profile", "prod"}, "cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupMultiProfileConfig(t)
+
+			var buf bytes.Buffer
+			cmd := setupMultiProfileCommand(t, &buf, tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestExtractJSON(t *testing.T) {
+	raw, err := extractJSON([]byte("Warning: something\n[verbose] more\n{\n  \"a\": 1\n}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(raw))
+
+	raw, err = extractJSON([]byte("[1, 2]"))
+	require.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(raw))
+
+	_, err = extractJSON([]byte("Feature deleted\n"))
+	assert.Error(t, err)
+}
+
+func TestFlattenResult(t *testing.T) {
+	items, keys := flattenResult(json.RawMessage(`[{"name":"a","enabled":true,"tags":["x"]},{"name":"b","id":"2"}]`))
+	require.Len(t, items, 2)
+	assert.Equal(t, []string{"name", "enabled", "id"}, keys, "nested values are not columns")
+
+	items, keys = flattenResult(json.RawMessage(`"hello"`))
+	require.Len(t, items, 1)
+	assert.Equal(t, []string{"value"}, keys)
+	assert.Equal(t, "hello", formatJSONCell(items[0]["value"]))
+
+	items, _ = flattenResult(json.RawMessage(`[]`))
+	assert.Empty(t, items)
+}
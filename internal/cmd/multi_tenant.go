@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+// multiTenantConcurrency is the number of tenants queried in parallel by --tenants
+const multiTenantConcurrency = 8
+
+// targetTenants holds --tenants: tenant names, or "all"
+var targetTenants []string
+
+// TenantResult is the outcome of a command run against one tenant
+type TenantResult struct {
+	Tenant string          `json:"tenant"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// tenantFetcher returns the result of a command for one tenant. It runs
+// concurrently for several tenants, so it must not read cfg.Tenant.
+type tenantFetcher func(ctx context.Context, client *izanami.AdminClient, tenant string) (interface{}, error)
+
+// addTenantsFlag registers --tenants on a list or report command
+func addTenantsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&targetTenants, "tenants", nil, "Run against these tenants (comma-separated), or all accessible tenants with \"all\"")
+	cmd.RegisterFlagCompletionFunc("tenants", completeTenantNames)
+}
+
+// multiTenantRequested reports whether --tenants was given
+func multiTenantRequested(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("tenants")
+}
+
+// runAcrossTenants runs fetch for every tenant of --tenants, several at a time,
+// and prints the results keyed by tenant: as JSON, or as one table or CSV whose
+// first column is the tenant
+func runAcrossTenants(cmd *cobra.Command, fetch tenantFetcher) error {
+	if multiProfileRunning {
+		return fmt.Errorf("--tenants cannot be combined with --profiles or --all-profiles")
+	}
+	if cmd.Flags().Changed("tenant") {
+		return fmt.Errorf("--tenant cannot be combined with --tenants")
+	}
+	switch outputFormat {
+	case "table", "json", "csv":
+	default:
+		return fmt.Errorf("unsupported output format with --tenants: %s (expected table, json or csv)", outputFormat)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+	ctx := commandContext()
+	tenants, err := resolveTargetTenants(ctx, client, targetTenants)
+	if err != nil {
+		return err
+	}
+
+	results := make([]TenantResult, len(tenants))
+	sem := make(chan struct{}, multiTenantConcurrency)
+	var wg sync.WaitGroup
+	for i, tenant := range tenants {
+		wg.Add(1)
+		go func(i int, tenant string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = TenantResult{Tenant: tenant}
+			value, err := fetch(ctx, client, tenant)
+			if err == nil {
+				results[i].Result, err = json.Marshal(value)
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, tenant)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if err := printTenantResults(cmd.OutOrStdout(), results, outputFormat); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("command failed for %d of %d tenant(s)", failed, len(tenants))
+	}
+	return nil
+}
+
+// resolveTargetTenants returns the tenants named in --tenants, or every tenant the
+// user can access for "all"
+func resolveTargetTenants(ctx context.Context, client *izanami.AdminClient, names []string) ([]string, error) {
+	if len(names) == 1 && strings.EqualFold(strings.TrimSpace(names[0]), "all") {
+		tenants, err := izanami.ListTenants(client, ctx, nil, izanami.ParseTenants)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tenants: %w", err)
+		}
+		all := make([]string, 0, len(tenants))
+		for _, tenant := range tenants {
+			all = append(all, tenant.Name)
+		}
+		if len(all) == 0 {
+			return nil, fmt.Errorf("no tenant accessible with these credentials")
+		}
+		sort.Strings(all)
+		return all, nil
+	}
+
+	var tenants []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if strings.EqualFold(name, "all") {
+			return nil, fmt.Errorf("--tenants all cannot be combined with tenant names")
+		}
+		seen[name] = true
+		tenants = append(tenants, name)
+	}
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("--tenants requires at least one tenant name, or all")
+	}
+	return tenants, nil
+}
+
+// printTenantResults prints the results of every tenant
+func printTenantResults(w io.Writer, results []TenantResult, format string) error {
+	if format == "json" {
+		return output.PrintTo(w, results, output.JSON)
+	}
+
+	grouped := make([]groupedResult, len(results))
+	for i, result := range results {
+		grouped[i] = groupedResult{key: result.Tenant, result: result.Result, err: result.Error}
+	}
+	header, rows := groupedRows("TENANT", grouped)
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		header[0] = "tenant"
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+	return output.PrintRows(w, header, rows)
+}
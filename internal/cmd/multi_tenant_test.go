@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// multiTenantServer serves the tenants acme, beta and broken, with one key in
+// acme and beta; listing the keys of broken fails
+func multiTenantServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants":
+			io.WriteString(w, `[{"name":"beta"},{"name":"acme"},{"name":"broken"}]`)
+		case "/api/admin/tenants/acme/keys":
+			io.WriteString(w, `[{"clientId":"id-a","clientSecret":"s3cret","name":"ci","enabled":true}]`)
+		case "/api/admin/tenants/beta/keys":
+			io.WriteString(w, `[{"clientId":"id-b","name":"web","enabled":false}]`)
+		case "/api/admin/tenants/broken/keys":
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `{"message":"forbidden"}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+}
+
+func setupMultiTenantKeysList(t *testing.T, tenants, format string) *bytes.Buffer {
+	t.Helper()
+	multiTenantServer(t)
+	t.Cleanup(func() {
+		targetTenants = nil
+		keysListCmd.Flags().Lookup("tenants").Changed = false
+		keysListCmd.SetOut(nil)
+	})
+	outputFormat = format
+	require.NoError(t, keysListCmd.Flags().Set("tenants", tenants))
+
+	var buf bytes.Buffer
+	keysListCmd.SetOut(&buf)
+	return &buf
+}
+
+func TestResolveTargetTenants(t *testing.T) {
+	multiTenantServer(t)
+	client, err := izanami.NewAdminClient(cfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tenants, err := resolveTargetTenants(ctx, client, []string{"all"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "beta", "broken"}, tenants)
+
+	tenants, err = resolveTargetTenants(ctx, client, []string{"beta", " acme", "beta"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"beta", "acme"}, tenants)
+
+	_, err = resolveTargetTenants(ctx, client, []string{"acme", "all"})
+	assert.ErrorContains(t, err, "cannot be combined")
+
+	_, err = resolveTargetTenants(ctx, client, []string{" "})
+	assert.ErrorContains(t, err, "at least one tenant")
+}
+
+func TestKeysListCmd_Tenants(t *testing.T) {
+	buf := setupMultiTenantKeysList(t, "acme,beta", "table")
+
+	require.NoError(t, keysListCmd.RunE(keysListCmd, nil))
+
+	out := buf.String()
+	assert.Contains(t, out, "TENANT")
+	assert.Regexp(t, `acme\s+id-a\s+<redacted>\s+ci`, out)
+	assert.Regexp(t, `beta\s+id-b\s+web`, out)
+	assert.NotContains(t, out, "s3cret")
+}
+
+func TestKeysListCmd_AllTenantsJSONWithFailure(t *testing.T) {
+	buf := setupMultiTenantKeysList(t, "all", "json")
+
+	err := keysListCmd.RunE(keysListCmd, nil)
+	assert.EqualError(t, err, "command failed for 1 of 3 tenant(s)")
+
+	var results []TenantResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results), buf.String())
+	require.Len(t, results, 3)
+	assert.Equal(t, "acme", results[0].Tenant)
+	assert.Contains(t, string(results[0].Result), `"clientId": "id-a"`)
+	assert.Equal(t, "broken", results[2].Tenant)
+	assert.Contains(t, results[2].Error, "forbidden")
+}
+
+func TestRunAcrossTenants_UnsupportedFormat(t *testing.T) {
+	setupMultiTenantKeysList(t, "acme", "ndjson")
+
+	assert.ErrorContains(t, keysListCmd.RunE(keysListCmd, nil), "unsupported output format with --tenants")
+}
+
+func TestRightsMatrixItems(t *testing.T) {
+	items := rightsMatrixItems(&izanami.RightsMatrix{
+		Resources: []izanami.RightsResource{{Type: izanami.RightsResourceProject, Name: "shop"}},
+		Users: []izanami.RightsMatrixUser{{
+			Username:    "alice",
+			TenantLevel: "Read",
+			Rights:      map[string]izanami.RightsCell{"project:shop": {Level: "Write"}},
+		}},
+	})
+	require.Len(t, items, 1)
+	assert.Equal(t, `{"user":"alice","level":"Read","project:shop":"Write"}`, string(items[0]))
+}
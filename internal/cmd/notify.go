@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+)
+
+// notifyMinInterval is the shortest polling interval accepted by 'iz notify'
+const notifyMinInterval = time.Second
+
+var (
+	notifyFeature   string
+	notifyInterval  time.Duration
+	notifyExec      string
+	notifyNoDesktop bool
+	notifyOnce      bool
+)
+
+// desktopNotify shows a desktop notification (replaced in tests)
+var desktopNotify = utils.Notify
+
+// notifyCmd watches a feature and notifies when its state changes
+var notifyCmd = &cobra.Command{
+	Use:         "notify",
+	Short:       "Notify when the state of a feature changes",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features/:id"},
+	Long: `Watch a feature and notify when its state changes: enabled or disabled, or
+its activation conditions edited. Useful during coordinated releases, when
+waiting for someone to flip a flag.
+
+The feature is polled every --interval with the admin API. Nothing is printed
+until the state changes; each change prints one line, shows a desktop
+notification (osascript on macOS, notify-send on Linux, PowerShell on Windows)
+unless --no-desktop, and runs the --exec command if any.
+
+The --exec command runs through the shell with these environment variables:
+  IZ_FEATURE                 Feature name
+  IZ_FEATURE_STATE           New state (e.g. "enabled", "disabled")
+  IZ_FEATURE_PREVIOUS_STATE  Previous state
+
+Examples:
+  # Wait for a flag to change, with a desktop notification
+  iz notify --feature checkout --tenant my-tenant --project shop
+
+  # Exit after the first change, e.g. to chain a deployment step
+  iz notify --feature checkout --project shop --once && ./deploy.sh
+
+  # Post changes to a chat channel instead of the desktop
+  iz notify --feature checkout --project shop --no-desktop \
+    --exec 'curl -s -d "$IZ_FEATURE is now $IZ_FEATURE_STATE" https://chat.example.com/hook'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		if notifyFeature == "" {
+			return fmt.Errorf("--feature is required")
+		}
+		if notifyInterval < notifyMinInterval {
+			return fmt.Errorf("--interval must be at least %s", notifyMinInterval)
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Ctrl+C cancels the command context and ends the watch
+		ctx := commandContext()
+		featureID, _, err := resolveFeatureToUUID(ctx, client, cfg, notifyFeature, cmd)
+		if err != nil {
+			return err
+		}
+		name := notifyFeature
+		fetch := func(ctx context.Context) (string, error) {
+			feature, err := izanami.GetFeature(client, ctx, cfg.Tenant, featureID, izanami.ParseFeature)
+			if err != nil {
+				return "", err
+			}
+			name = feature.Name
+			return featureState(feature), nil
+		}
+
+		err = watchFeatureState(ctx, fetch, notifyInterval, func(err error) {
+			fmt.Fprintf(statusOut(cmd), "Warning: failed to fetch feature: %v\n", err)
+		}, func(state string) {
+			fmt.Fprintf(statusOut(cmd), "Watching %s, currently %s (Ctrl+C to stop)...\n", name, state)
+		}, func(previous, state string) bool {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %s: %s -> %s\n", time.Now().Format(time.RFC3339), name, previous, state)
+			if !notifyNoDesktop {
+				if err := desktopNotify("Izanami: "+name, fmt.Sprintf("%s is now %s", name, state)); err != nil {
+					fmt.Fprintf(statusOut(cmd), "Warning: desktop notification failed: %v\n", err)
+				}
+			}
+			if notifyExec != "" {
+				if err := runNotifyExec(ctx, notifyExec, name, previous, state); err != nil {
+					fmt.Fprintf(statusOut(cmd), "Warning: --exec command failed: %v\n", err)
+				}
+			}
+			return notifyOnce
+		})
+		if err != nil && err != context.Canceled {
+			return err
+		}
+		return nil
+	},
+}
+
+// featureState summarizes the state of a feature: "disabled", "enabled" or
+// "enabled when <conditions>"
+func featureState(feature *izanami.FeatureWithOverloads) string {
+	if !feature.Enabled {
+		return "disabled"
+	}
+	if len(feature.Conditions) == 0 {
+		return "enabled"
+	}
+	conditions := make([]string, len(feature.Conditions))
+	for i, condition := range feature.Conditions {
+		conditions[i] = izanami.DescribeCondition(condition)
+	}
+	return "enabled (" + strings.Join(conditions, " or ") + ")"
+}
+
+// watchFeatureState polls a state every interval until ctx is done. The first
+// successful fetch is passed to start; each later change is passed to onChange,
+// which stops the watch by returning true. Fetch errors are reported to onError
+// and the watch goes on.
+func watchFeatureState(ctx context.Context, fetch func(ctx context.Context) (string, error), interval time.Duration, onError func(error), start func(state string), onChange func(previous, state string) bool) error {
+	var current string
+	known := false
+	for {
+		state, err := fetch(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			onError(err)
+		case !known:
+			current, known = state, true
+			start(state)
+		case state != current:
+			previous := current
+			current = state
+			if onChange(previous, state) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runNotifyExec runs the --exec command through the shell with the change in its environment
+func runNotifyExec(ctx context.Context, command, feature, previous, state string) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.Env = append(os.Environ(),
+		"IZ_FEATURE="+feature,
+		"IZ_FEATURE_STATE="+state,
+		"IZ_FEATURE_PREVIOUS_STATE="+previous,
+	)
+	if out, err := c.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+
+	notifyCmd.Flags().StringVar(&notifyFeature, "feature", "", "Feature name or UUID to watch (names use --project)")
+	notifyCmd.Flags().DurationVar(&notifyInterval, "interval", 5*time.Second, "Polling interval (minimum 1s)")
+	notifyCmd.Flags().StringVar(&notifyExec, "exec", "", "Shell command to run on each change")
+	notifyCmd.Flags().BoolVar(&notifyNoDesktop, "no-desktop", false, "Do not show desktop notifications")
+	notifyCmd.Flags().BoolVar(&notifyOnce, "once", false, "Exit after the first change")
+	notifyCmd.RegisterFlagCompletionFunc("feature", completeFeatureNames)
+}
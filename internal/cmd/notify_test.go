@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestFeatureState(t *testing.T) {
+	assert.Equal(t, "disabled", featureState(&izanami.FeatureWithOverloads{Conditions: []izanami.ActivationCondition{{}}}))
+	assert.Equal(t, "enabled", featureState(&izanami.FeatureWithOverloads{Enabled: true}))
+	assert.Equal(t, "enabled (active for 10% of users or active for user alice)", featureState(&izanami.FeatureWithOverloads{
+		Enabled: true,
+		Conditions: []izanami.ActivationCondition{
+			{Rule: &izanami.ActivationRule{Type: izanami.RuleTypeUserPercentage, Percentage: 10}},
+			{Rule: &izanami.ActivationRule{Type: izanami.RuleTypeUserList, Users: []string{"alice"}}},
+		},
+	}))
+}
+
+func TestWatchFeatureState(t *testing.T) {
+	states := []string{"disabled", "", "disabled", "enabled", "enabled", "disabled", "enabled"}
+	calls := 0
+	fetch := func(ctx context.Context) (string, error) {
+		state := states[calls]
+		calls++
+		if state == "" {
+			return "", errors.New("boom")
+		}
+		return state, nil
+	}
+
+	var started string
+	var errs []error
+	var changes [][2]string
+	err := watchFeatureState(context.Background(), fetch, time.Millisecond, func(err error) {
+		errs = append(errs, err)
+	}, func(state string) {
+		started = state
+	}, func(previous, state string) bool {
+		changes = append(changes, [2]string{previous, state})
+		return len(changes) == 2
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "disabled", started)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, [][2]string{{"disabled", "enabled"}, {"enabled", "disabled"}}, changes)
+	assert.Equal(t, 6, calls, "stops on the change accepted by onChange")
+}
+
+func TestWatchFeatureState_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := watchFeatureState(ctx, func(ctx context.Context) (string, error) {
+		cancel()
+		return "enabled", nil
+	}, time.Hour, func(error) {}, func(string) {}, func(string, string) bool { return false })
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunNotifyExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	out := filepath.Join(t.TempDir(), "change.txt")
+
+	require.NoError(t, runNotifyExec(context.Background(), `echo "$IZ_FEATURE $IZ_FEATURE_PREVIOUS_STATE $IZ_FEATURE_STATE" > "`+out+`"`, "checkout", "disabled", "enabled"))
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "checkout disabled enabled\n", string(data))
+
+	assert.ErrorContains(t, runNotifyExec(context.Background(), "echo nope; exit 2", "checkout", "a", "b"), "nope")
+}
+
+func TestNotifyCmd_InvalidFlags(t *testing.T) {
+	origCfg := cfg
+	defer func() {
+		cfg = origCfg
+		notifyFeature, notifyInterval = "", 5*time.Second
+	}()
+	cfg = &izanami.ResolvedConfig{LeaderURL: "http://localhost", JwtToken: "token", Tenant: "acme"}
+
+	assert.ErrorContains(t, notifyCmd.RunE(notifyCmd, nil), "--feature is required")
+
+	notifyFeature, notifyInterval = "checkout", 100*time.Millisecond
+	assert.ErrorContains(t, notifyCmd.RunE(notifyCmd, nil), "--interval must be at least 1s")
+}
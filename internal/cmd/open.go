@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+)
+
+var openNoBrowser bool
+
+// openBrowser opens a URL in the default browser (replaced in tests)
+var openBrowser = utils.OpenBrowser
+
+// openResourceType is a resource type of 'iz open'
+type openResourceType struct {
+	Name      string
+	TakesName bool
+	Desc      string
+}
+
+// openResourceTypes lists the resource types of 'iz open'
+var openResourceTypes = []openResourceType{
+	{"tenant", false, "Tenant home page (the default)"},
+	{"project", true, "Project page with its features"},
+	{"feature", true, "Feature in its project"},
+	{"contexts", false, "Contexts of the project, or global contexts without a project"},
+	{"keys", false, "API keys of the tenant"},
+	{"tag", true, "Features with a tag"},
+	{"webhooks", false, "Webhooks of the tenant"},
+	{"scripts", false, "WASM scripts of the tenant"},
+}
+
+// openCmd opens the web UI page of a resource
+var openCmd = &cobra.Command{
+	Use:   "open [type] [name]",
+	Short: "Open a resource in the Izanami web UI",
+	Long: `Open the Izanami web UI page of a resource in the default browser.
+
+The URL is built from the leader URL, tenant and project of the active profile
+(or --url, --tenant and --project), and printed. Use --no-browser to print it
+only.
+
+Types: tenant (default), project [name], feature <name>, contexts, keys,
+tag <name>, webhooks, scripts. "project" without a name opens the current
+project.
+
+Examples:
+  # Open the tenant home page
+  iz open
+
+  # Open a project and a feature of the current project
+  iz open project billing
+  iz open feature my-flag
+
+  # Print the URL of a feature in another project
+  iz open feature my-flag --project checkout --no-browser`,
+	Args: cobra.MaximumNArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return buildCompletions(openResourceTypes, toComplete,
+			func(t openResourceType) string { return t.Name },
+			func(t openResourceType) string { return t.Desc },
+		), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// No credentials needed: the browser session authenticates in the web UI
+		if cfg.LeaderURL == "" {
+			return fmt.Errorf("leader URL is required (set IZ_LEADER_URL or --url)")
+		}
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		link, err := resourceWebUIURL(cfg, args)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), link)
+
+		if !openNoBrowser {
+			if err := openBrowser(link); err != nil {
+				// Not fatal: the URL has been printed
+				fmt.Fprintf(statusOut(cmd), "Warning: Could not open browser: %v\n", err)
+			}
+		}
+		return nil
+	},
+}
+
+// resourceWebUIURL builds the web UI URL of the resource given as [type] [name]
+func resourceWebUIURL(config *izanami.ResolvedConfig, args []string) (string, error) {
+	resourceType, name := "tenant", ""
+	if len(args) > 0 {
+		resourceType = strings.ToLower(args[0])
+	}
+	if len(args) > 1 {
+		name = args[1]
+	}
+
+	takesName, known := false, false
+	for _, t := range openResourceTypes {
+		if t.Name == resourceType {
+			takesName, known = t.TakesName, true
+		}
+	}
+	if !known {
+		return "", fmt.Errorf("unknown type %q (expected tenant, project, feature, contexts, keys, tag, webhooks or scripts)", resourceType)
+	}
+	if !takesName && name != "" {
+		return "", fmt.Errorf("%s does not take a name", resourceType)
+	}
+
+	searchType := resourceType
+	switch resourceType {
+	case "project":
+		if name == "" {
+			name = config.Project
+		}
+		if name == "" {
+			return "", fmt.Errorf("project name required (or set --project)")
+		}
+	case "feature", "tag":
+		if name == "" {
+			return "", fmt.Errorf("%s name required", resourceType)
+		}
+	case "contexts":
+		searchType = "global_context"
+		if config.Project != "" {
+			searchType = "local_context"
+		}
+	case "keys", "webhooks", "scripts":
+		searchType = strings.TrimSuffix(resourceType, "s")
+	}
+	return izanami.WebUIURL(config.LeaderURL, config.Tenant, config.Project, searchType, name)
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().BoolVar(&openNoBrowser, "no-browser", false, "Only print the URL")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestResourceWebUIURL(t *testing.T) {
+	config := &izanami.ResolvedConfig{LeaderURL: "https://iz.example.com", Tenant: "acme", Project: "billing"}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "https://iz.example.com/tenants/acme"},
+		{[]string{"project"}, "https://iz.example.com/tenants/acme/projects/billing"},
+		{[]string{"project", "shop"}, "https://iz.example.com/tenants/acme/projects/shop"},
+		{[]string{"feature", "my-flag"}, "https://iz.example.com/tenants/acme/projects/billing?filter=my-flag"},
+		{[]string{"contexts"}, "https://iz.example.com/tenants/acme/projects/billing/contexts"},
+		{[]string{"Keys"}, "https://iz.example.com/tenants/acme/keys"},
+		{[]string{"webhooks"}, "https://iz.example.com/tenants/acme/webhooks"},
+	}
+	for _, tt := range tests {
+		got, err := resourceWebUIURL(config, tt.args)
+		require.NoError(t, err, tt.args)
+		assert.Equal(t, tt.want, got, tt.args)
+	}
+
+	_, err := resourceWebUIURL(config, []string{"feature"})
+	assert.ErrorContains(t, err, "feature name required")
+	_, err = resourceWebUIURL(config, []string{"keys", "k1"})
+	assert.ErrorContains(t, err, "keys does not take a name")
+	_, err = resourceWebUIURL(config, []string{"users"})
+	assert.ErrorContains(t, err, `unknown type "users"`)
+
+	noProject := &izanami.ResolvedConfig{LeaderURL: "https://iz.example.com", Tenant: "acme"}
+	got, err := resourceWebUIURL(noProject, []string{"contexts"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://iz.example.com/tenants/acme/contexts", got)
+	_, err = resourceWebUIURL(noProject, []string{"feature", "my-flag"})
+	assert.ErrorContains(t, err, "no project for feature")
+}
+
+func TestOpenCmd(t *testing.T) {
+	origCfg, origOpen := cfg, openBrowser
+	t.Cleanup(func() {
+		cfg, openBrowser, openNoBrowser = origCfg, origOpen, false
+		openCmd.SetOut(nil)
+	})
+	cfg = &izanami.ResolvedConfig{LeaderURL: "https://iz.example.com", Tenant: "acme"}
+
+	var opened []string
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return fmt.Errorf("no display")
+	}
+
+	var out bytes.Buffer
+	openCmd.SetOut(&out)
+	require.NoError(t, openCmd.RunE(openCmd, []string{"project", "shop"}))
+	assert.Equal(t, []string{"https://iz.example.com/tenants/acme/projects/shop"}, opened)
+	assert.Equal(t, "https://iz.example.com/tenants/acme/projects/shop\nWarning: Could not open browser: no display\n", out.String())
+
+	openNoBrowser = true
+	require.NoError(t, openCmd.RunE(openCmd, nil))
+	assert.Len(t, opened, 1)
+}
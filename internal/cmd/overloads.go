@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
@@ -135,12 +135,13 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.SetOverload(ctx, cfg.Tenant, cfg.Project, overloadContext, featureName, strategy, overloadPreserveProtect); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Overload set successfully: %s in context %s\n", featureName, overloadContext)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgOverloadSet, featureName, overloadContext))
+		runHooks(cmd, izanami.HookOverloadSet, map[string]interface{}{"feature": featureName, "context": overloadContext})
 		return nil
 	},
 }
@@ -187,7 +188,7 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper
 		if outputFormat == "json" {
@@ -257,12 +258,13 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.DeleteOverload(ctx, cfg.Tenant, cfg.Project, overloadContext, featureName, overloadPreserveProtect); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Overload deleted successfully: %s from context %s\n", featureName, overloadContext)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgOverloadDeleted, featureName, overloadContext))
+		runHooks(cmd, izanami.HookOverloadDelete, map[string]interface{}{"feature": featureName, "context": overloadContext})
 		return nil
 	},
 }
@@ -275,6 +277,11 @@ func init() {
 	overloadsCmd.AddCommand(overloadsGetCmd)
 	overloadsCmd.AddCommand(overloadsDeleteCmd)
 
+	// Dynamic completion for feature name argument
+	overloadsSetCmd.ValidArgsFunction = completeFeatureNames
+	overloadsGetCmd.ValidArgsFunction = completeFeatureNames
+	overloadsDeleteCmd.ValidArgsFunction = completeFeatureNames
+
 	// Set flags
 	overloadsSetCmd.Flags().StringVar(&overloadContext, "context", "", "Context path (e.g., PROD, PROD/mobile)")
 	overloadsSetCmd.Flags().BoolVar(&overloadEnabled, "enabled", true, "Enable the feature in this context")
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Script feature payload flags, shared by the check and test commands
+var (
+	payloadFile   string
+	payloadFields []string
+	payloadStdin  bool
+)
+
+// payloadFlagsHelp documents the payload flags in the Long help of check and test commands
+const payloadFlagsHelp = `Script Features:
+  Script (WASM) features receive a JSON object payload. Build it with:
+    --data '{"age": 25}'            inline JSON (@file.json or - for stdin also work)
+    --payload-file payload.json     a JSON file
+    --payload-stdin                 JSON read from stdin
+    --payload-field age=25          a field, repeatable; values are JSON when
+                                    valid (25, true, {"a":1}) and strings otherwise,
+                                    dotted keys build nested objects (user.plan=pro)
+  Fields are set on top of the JSON given by the other flags, which are
+  exclusive. The payload must be a JSON object.`
+
+// addPayloadFlags registers the payload flags on a check or test command, next to its --data flag
+func addPayloadFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&payloadFile, "payload-file", "", "JSON payload for script features, from a file")
+	cmd.Flags().StringArrayVar(&payloadFields, "payload-field", nil, "Payload field as key=value, repeatable (value is JSON when valid, a string otherwise)")
+	cmd.Flags().BoolVar(&payloadStdin, "payload-stdin", false, "Read the JSON payload for script features from stdin")
+	cmd.MarkFlagsMutuallyExclusive("data", "payload-file", "payload-stdin")
+}
+
+// resolvePayload builds the script feature payload from --data and the payload
+// flags, returning it as a JSON object string, or "" when none is given
+func resolvePayload(cmd *cobra.Command) (string, error) {
+	var base []byte
+	switch {
+	case featureData != "":
+		var data interface{}
+		if err := parseJSONData(featureData, &data); err != nil {
+			return "", fmt.Errorf("invalid JSON payload: %w", err)
+		}
+		base, _ = json.Marshal(data)
+	case payloadFile != "":
+		data, err := readInputFile(cmd, payloadFile)
+		if err != nil {
+			return "", err
+		}
+		base = data
+	case payloadStdin:
+		data, err := readInputFile(cmd, "-")
+		if err != nil {
+			return "", err
+		}
+		base = data
+	}
+	if base == nil && len(payloadFields) == 0 {
+		return "", nil
+	}
+
+	payload := map[string]interface{}{}
+	if base != nil {
+		var data interface{}
+		if err := json.Unmarshal(base, &data); err != nil {
+			return "", fmt.Errorf("invalid JSON payload: %w", err)
+		}
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("invalid JSON payload: script feature payloads must be a JSON object, got %s", jsonKind(data))
+		}
+		payload = object
+	}
+	for _, field := range payloadFields {
+		if err := setPayloadField(payload, field); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// setPayloadField sets a key=value --payload-field on payload
+func setPayloadField(payload map[string]interface{}, field string) error {
+	key, value, ok := strings.Cut(field, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("invalid --payload-field %q (expected key=value)", field)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		parsed = value
+	}
+
+	parts := strings.Split(key, ".")
+	object := payload
+	for i, part := range parts[:len(parts)-1] {
+		if part == "" {
+			return fmt.Errorf("invalid --payload-field key %q", key)
+		}
+		child, exists := object[part]
+		if !exists {
+			child = map[string]interface{}{}
+			object[part] = child
+		}
+		nested, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid --payload-field %q: %s is not an object", field, strings.Join(parts[:i+1], "."))
+		}
+		object = nested
+	}
+	last := parts[len(parts)-1]
+	if last == "" {
+		return fmt.Errorf("invalid --payload-field key %q", key)
+	}
+	object[last] = parsed
+	return nil
+}
+
+// jsonKind names the JSON type of a decoded value for error messages
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return "an object"
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePayload(t *testing.T) {
+	defer func() {
+		featureData, payloadFile, payloadFields, payloadStdin = "", "", nil, false
+	}()
+	cmd := &cobra.Command{}
+	file := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"age": 25, "user": {"plan": "free"}}`), 0600))
+
+	tests := []struct {
+		name    string
+		data    string
+		file    string
+		stdin   string
+		fields  []string
+		want    string
+		wantErr string
+	}{
+		{name: "none", want: ""},
+		{name: "inline data", data: `{"age": 25}`, want: `{"age": 25}`},
+		{name: "file", file: file, want: `{"age": 25, "user": {"plan": "free"}}`},
+		{name: "stdin", stdin: `{"country": "FR"}`, want: `{"country": "FR"}`},
+		{name: "fields only", fields: []string{"age=25", "vip=true", "name=alice", "code=\"007\""}, want: `{"age": 25, "vip": true, "name": "alice", "code": "007"}`},
+		{name: "fields on file", file: file, fields: []string{"user.plan=pro", "user.seats=3"}, want: `{"age": 25, "user": {"plan": "pro", "seats": 3}}`},
+		{name: "nested fields", fields: []string{"a.b.c=1"}, want: `{"a": {"b": {"c": 1}}}`},
+		{name: "not an object", data: `[1, 2]`, wantErr: "must be a JSON object, got an array"},
+		{name: "invalid json", stdin: `{`, wantErr: "invalid JSON payload"},
+		{name: "missing value", fields: []string{"age"}, wantErr: "expected key=value"},
+		{name: "empty key part", fields: []string{"a..b=1"}, wantErr: "invalid --payload-field key"},
+		{name: "field through a value", file: file, fields: []string{"age.years=2"}, wantErr: "age is not an object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			featureData, payloadFile, payloadFields, payloadStdin = tt.data, tt.file, tt.fields, tt.stdin != ""
+			cmd.SetIn(strings.NewReader(tt.stdin))
+
+			got, err := resolvePayload(cmd)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Empty(t, got)
+				return
+			}
+			assert.JSONEq(t, tt.want, got)
+		})
+	}
+}
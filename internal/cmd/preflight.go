@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// adminSkipPreflight disables the permission check run before admin changes
+var adminSkipPreflight bool
+
+// checkPermissions verifies, before a mutating admin command runs, that the
+// authenticated user holds the right its route requires, so that a missing
+// right is reported clearly instead of as a 403 from the server.
+//
+// The tenant and project default to the --tenant and --project flags;
+// commands naming them as an argument set the "tenantArg" or "projectArg"
+// annotation to the index of that argument. Dry runs, routes without a known
+// requirement and targets that cannot be determined are not checked, and a
+// failure to read the rights lets the command go.
+func checkPermissions(cmd *cobra.Command, args []string) error {
+	if adminSkipPreflight || cfg == nil || cfg.LeaderURL == "" {
+		return nil
+	}
+	requirement := izanami.RightRequirementForRoute(cmd.Annotations["route"])
+	if requirement == nil {
+		return nil
+	}
+	if dryRun, err := cmd.Flags().GetBool("dry-run"); err == nil && dryRun {
+		return nil
+	}
+
+	tenant := annotatedArg(cmd, args, "tenantArg", cfg.Tenant)
+	project := annotatedArg(cmd, args, "projectArg", cfg.Project)
+	switch requirement.Scope {
+	case izanami.RightScopeTenant:
+		if tenant == "" {
+			return nil
+		}
+	case izanami.RightScopeProject:
+		if tenant == "" || project == "" {
+			return nil
+		}
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return nil
+	}
+	user, err := client.GetCurrentUserRights(commandContext())
+	if err != nil {
+		if cfg.Verbose {
+			fmt.Fprintf(statusOut(cmd), "[verbose] Could not check your rights: %v\n", err)
+		}
+		return nil
+	}
+	if err := requirement.CheckRight(user, tenant, project); err != nil {
+		return fmt.Errorf("%s: %w (use --skip-preflight to try anyway)", cmd.CommandPath(), err)
+	}
+	return nil
+}
+
+// annotatedArg returns the argument whose index the annotation holds, or fallback
+func annotatedArg(cmd *cobra.Command, args []string, annotation, fallback string) string {
+	index, err := strconv.Atoi(cmd.Annotations[annotation])
+	if err != nil || index < 0 || index >= len(args) {
+		return fallback
+	}
+	return args[index]
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// profileDefaultsCommandPath returns the command path used as key of profile
+// defaults: the full command path without the root command name
+func profileDefaultsCommandPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != nil {
+		path = strings.TrimPrefix(path, root.Name())
+	}
+	return strings.TrimSpace(path)
+}
+
+// applyProfileDefaults sets the flags of the command that were not given on the
+// command line from the "defaults" section of the profile, as if they had been
+// given. Flags given explicitly always win. Unknown flags are reported as
+// warnings, so that a stale default does not break the command.
+func applyProfileDefaults(cmd *cobra.Command, profile *izanami.Profile) error {
+	if profile == nil {
+		return nil
+	}
+	path := profileDefaultsCommandPath(cmd)
+	defaults := profile.CommandDefaults(path)
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			fmt.Fprintf(statusOut(cmd), "Warning: profile default for '%s' ignored: unknown flag --%s\n", path, name)
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		// Set through the flag set so that the flag counts as given (Changed)
+		if err := cmd.Flags().Set(name, defaults[name]); err != nil {
+			return fmt.Errorf("invalid profile default for '%s' --%s: %w", path, name, err)
+		}
+		if verbose {
+			fmt.Fprintf(statusOut(cmd), "[verbose] Profile default: --%s=%s\n", name, defaults[name])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestApplyProfileDefaults(t *testing.T) {
+	root := &cobra.Command{Use: "iz"}
+	parent := &cobra.Command{Use: "features"}
+	var conditions bool
+	var ctx string
+	var users []string
+	check := &cobra.Command{Use: "check", Run: func(*cobra.Command, []string) {}}
+	check.Flags().BoolVar(&conditions, "conditions", false, "")
+	check.Flags().StringVar(&ctx, "context", "", "")
+	check.Flags().StringSliceVar(&users, "users", nil, "")
+	root.AddCommand(parent)
+	parent.AddCommand(check)
+
+	var buf bytes.Buffer
+	check.SetOut(&buf)
+	require.NoError(t, check.ParseFlags([]string{"--context", "dev"}))
+
+	profile := &izanami.Profile{Defaults: map[string]map[string]interface{}{
+		"features check": {"conditions": true, "context": "prod", "users": []interface{}{"alice", "bob"}, "unknown": 1},
+		"features list":  {"conditions": false},
+	}}
+	require.NoError(t, applyProfileDefaults(check, profile))
+
+	assert.True(t, conditions)
+	assert.Equal(t, "dev", ctx, "explicit flags win over profile defaults")
+	assert.Equal(t, []string{"alice", "bob"}, users)
+	assert.True(t, check.Flags().Changed("conditions"))
+	assert.Contains(t, buf.String(), "unknown flag --unknown")
+
+	profile.Defaults["features check"] = map[string]interface{}{"conditions": "maybe"}
+	conditions = false
+	check.Flags().Lookup("conditions").Changed = false
+	assert.ErrorContains(t, applyProfileDefaults(check, profile), "invalid profile default for 'features check' --conditions")
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -23,6 +24,9 @@ var profileSettableKeys = map[string]string{
 	"personal-access-token":          "Personal access token",
 	"personal-access-token-username": "Username for PAT authentication",
 	"default-worker":                 "Default worker name for feature checks",
+	"ca-cert":                        "PEM file of CA certificates to trust",
+	"client-cert":                    "PEM client certificate for mutual TLS",
+	"client-key":                     "PEM private key of the client certificate",
 }
 
 var (
@@ -31,8 +35,9 @@ var (
 
 // profileCmd represents the profiles command
 var profileCmd = &cobra.Command{
-	Use:   "profiles",
-	Short: "Manage environment profiles",
+	Use:     "profiles",
+	Aliases: []string{"profile"},
+	Short:   "Manage environment profiles",
 	Long: `Manage environment profiles for different Izanami servers.
 
 Profiles allow you to maintain separate configurations for different
@@ -43,6 +48,7 @@ Each profile can include:
   - Personal Access Token (PAT) for long-lived authentication
   - Default tenant, project, and context
   - Client credentials for feature checks
+  - CA and client certificates for private CAs and mutual TLS
 
 Note: JWT tokens (short-lived) are only stored in sessions via 'iz login',
 not in profiles. Use session references in profiles for JWT authentication.
@@ -245,20 +251,20 @@ Example:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Switched to profile '%s'\n", profileName)
+		fmt.Fprintf(statusOut(cmd), "✓ Switched to profile '%s'\n", profileName)
 
 		// Show brief profile info
 		profile, err := izanami.GetProfile(profileName)
 		if err == nil {
-			fmt.Fprintln(cmd.OutOrStdout())
+			fmt.Fprintln(statusOut(cmd))
 			if profile.Session != "" {
-				fmt.Fprintf(cmd.OutOrStdout(), "  Session: %s\n", profile.Session)
+				fmt.Fprintf(statusOut(cmd), "  Session: %s\n", profile.Session)
 			}
 			if profile.LeaderURL != "" {
-				fmt.Fprintf(cmd.OutOrStdout(), "  URL:     %s\n", profile.LeaderURL)
+				fmt.Fprintf(statusOut(cmd), "  URL:     %s\n", profile.LeaderURL)
 			}
 			if profile.Tenant != "" {
-				fmt.Fprintf(cmd.OutOrStdout(), "  Tenant:  %s\n", profile.Tenant)
+				fmt.Fprintf(statusOut(cmd), "  Tenant:  %s\n", profile.Tenant)
 			}
 		}
 
@@ -365,19 +371,19 @@ Examples:
 			isActive = true
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "\n✓ Profile '%s' created successfully\n", profileName)
+		fmt.Fprintf(statusOut(cmd), "\n✓ Profile '%s' created successfully\n", profileName)
 
 		if isActive {
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Set as active profile\n")
+			fmt.Fprintf(statusOut(cmd), "✓ Set as active profile\n")
 		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "\nSwitch to this profile with:\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "  iz profiles use %s\n", profileName)
+			fmt.Fprintf(statusOut(cmd), "\nSwitch to this profile with:\n")
+			fmt.Fprintf(statusOut(cmd), "  iz profiles use %s\n", profileName)
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "\nTo add client credentials for feature checks:\n")
-		fmt.Fprintf(cmd.OutOrStdout(), "  iz profiles client-keys add --tenant <tenant>\n")
-		fmt.Fprintf(cmd.OutOrStdout(), "\nTo add workers for split deployments:\n")
-		fmt.Fprintf(cmd.OutOrStdout(), "  iz profiles workers add <name> --url <worker-url>\n")
+		fmt.Fprintf(statusOut(cmd), "\nTo add client credentials for feature checks:\n")
+		fmt.Fprintf(statusOut(cmd), "  iz profiles client-keys add --tenant <tenant>\n")
+		fmt.Fprintf(statusOut(cmd), "\nTo add workers for split deployments:\n")
+		fmt.Fprintf(statusOut(cmd), "  iz profiles workers add <name> --url <worker-url>\n")
 
 		return nil
 	},
@@ -507,6 +513,21 @@ var profileSetCmd = &cobra.Command{
 			isSensitive = true
 		case "personal-access-token-username":
 			profile.PersonalAccessTokenUsername = value
+		case "ca-cert", "client-cert", "client-key":
+			// Store absolute paths so the profile works from any directory
+			path, err := filepath.Abs(value)
+			if err != nil {
+				return fmt.Errorf("invalid path %q: %w", value, err)
+			}
+			value = path
+			switch key {
+			case "ca-cert":
+				profile.CACertFile = path
+			case "client-cert":
+				profile.ClientCertFile = path
+			default:
+				profile.ClientKeyFile = path
+			}
 		}
 
 		// Save updated profile
@@ -519,14 +540,14 @@ var profileSetCmd = &cobra.Command{
 		if isSensitive {
 			displayValue = "<redacted>"
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated %s.%s = %s\n", profileName, key, displayValue)
+		fmt.Fprintf(statusOut(cmd), "✓ Updated %s.%s = %s\n", profileName, key, displayValue)
 
 		// Security warning for sensitive values
 		if isSensitive {
-			fmt.Fprintln(cmd.OutOrStdout(), "\n⚠️  SECURITY WARNING:")
-			fmt.Fprintln(cmd.OutOrStdout(), "   Credentials are stored in plaintext in the config file.")
-			fmt.Fprintln(cmd.OutOrStdout(), "   File permissions are set to 0600 (owner read/write only).")
-			fmt.Fprintln(cmd.OutOrStdout(), "   Never commit config.yaml to version control.")
+			fmt.Fprintln(statusOut(cmd), "\n⚠️  SECURITY WARNING:")
+			fmt.Fprintln(statusOut(cmd), "   Credentials are stored in plaintext in the config file.")
+			fmt.Fprintln(statusOut(cmd), "   File permissions are set to 0600 (owner read/write only).")
+			fmt.Fprintln(statusOut(cmd), "   Never commit config.yaml to version control.")
 		}
 
 		return nil
@@ -550,6 +571,9 @@ Valid keys:
   personal-access-token          Personal access token
   personal-access-token-username Username for PAT authentication
   default-worker                 Default worker name
+  ca-cert                        CA certificates file
+  client-cert                    Client certificate file
+  client-key                     Client private key file
 
 Examples:
   iz profiles unset project
@@ -602,6 +626,12 @@ Examples:
 			profile.PersonalAccessToken = ""
 		case "personal-access-token-username":
 			profile.PersonalAccessTokenUsername = ""
+		case "ca-cert":
+			profile.CACertFile = ""
+		case "client-cert":
+			profile.ClientCertFile = ""
+		case "client-key":
+			profile.ClientKeyFile = ""
 		}
 
 		// Save updated profile
@@ -609,7 +639,7 @@ Examples:
 			return fmt.Errorf("failed to update profile: %w", err)
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Removed %s from profile '%s'\n", key, profileName)
+		fmt.Fprintf(statusOut(cmd), "✓ Removed %s from profile '%s'\n", key, profileName)
 		return nil
 	},
 }
@@ -631,7 +661,7 @@ Example:
 		// Get active profile to warn if deleting it
 		activeProfile, err := izanami.GetActiveProfileName()
 		if err == nil && activeProfile == profileName {
-			fmt.Fprintf(cmd.OutOrStdout(), "⚠️  Warning: '%s' is currently the active profile\n", profileName)
+			fmt.Fprintf(statusOut(cmd), "⚠️  Warning: '%s' is currently the active profile\n", profileName)
 		}
 
 		// Confirm deletion unless --force is used
@@ -645,11 +675,11 @@ Example:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "✓ Profile '%s' deleted\n", profileName)
+		fmt.Fprintf(statusOut(cmd), "✓ Profile '%s' deleted\n", profileName)
 
 		if activeProfile == profileName {
-			fmt.Fprintln(cmd.OutOrStdout(), "\nNo active profile set. Switch to another profile with:")
-			fmt.Fprintln(cmd.OutOrStdout(), "  iz profiles use <name>")
+			fmt.Fprintln(statusOut(cmd), "\nNo active profile set. Switch to another profile with:")
+			fmt.Fprintln(statusOut(cmd), "  iz profiles use <name>")
 		}
 
 		return nil
@@ -745,6 +775,12 @@ func printProfile(w io.Writer, profile *izanami.Profile, showSecrets bool) {
 	if len(profile.ClientKeys) > 0 {
 		fmt.Fprintf(w, "  Client Keys:    %s\n", formatClientKeysCount(profile.ClientKeys))
 	}
+	if profile.CACertFile != "" {
+		fmt.Fprintf(w, "  CA Cert:        %s\n", profile.CACertFile)
+	}
+	if profile.ClientCertFile != "" {
+		fmt.Fprintf(w, "  Client Cert:    %s (key: %s)\n", profile.ClientCertFile, profile.ClientKeyFile)
+	}
 	if len(profile.Workers) > 0 {
 		workerNames := make([]string, 0, len(profile.Workers))
 		for wn := range profile.Workers {
@@ -763,4 +799,12 @@ func printProfile(w io.Writer, profile *izanami.Profile, showSecrets bool) {
 	} else if profile.DefaultWorker != "" {
 		fmt.Fprintf(w, "  Default Worker: %s\n", profile.DefaultWorker)
 	}
+	if len(profile.Defaults) > 0 {
+		paths := make([]string, 0, len(profile.Defaults))
+		for path := range profile.Defaults {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(w, "  Defaults for:   %s\n", strings.Join(paths, ", "))
+	}
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+var (
+	profileExportWithoutSecrets bool
+	profileExportFile           string
+)
+
+// profileExportCmd exports profiles as a shareable YAML snippet
+var profileExportCmd = &cobra.Command{
+	Use:   "export <name>...",
+	Short: "Export profiles as YAML",
+	Long: `Export one or more profiles as a YAML snippet that 'iz profiles import' reads.
+
+Use --without-secrets to share standard environments with a team: session
+references, personal access tokens and client keys are left out, while URLs,
+tenant, project, context and workers are kept. Each user then adds their own
+credentials after importing.
+
+Without --without-secrets, secrets are exported in plaintext (decrypted if the
+config file is encrypted).
+
+Examples:
+  # Share the staging and prod profiles
+  iz profiles export staging prod --without-secrets > team-profiles.yaml
+
+  # Write to a file
+  iz profiles export prod --without-secrets --file prod.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := izanami.ExportProfiles(args, profileExportWithoutSecrets)
+		if err != nil {
+			return err
+		}
+		if !profileExportWithoutSecrets {
+			fmt.Fprintln(statusOut(cmd), "⚠️  Warning: the export contains credentials, use --without-secrets to share it")
+		}
+
+		if profileExportFile == "" {
+			_, err := cmd.OutOrStdout().Write(data)
+			return err
+		}
+		if err := os.WriteFile(profileExportFile, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", profileExportFile, err)
+		}
+		fmt.Fprintf(statusOut(cmd), "✓ Exported %d profile(s) to %s\n", len(args), profileExportFile)
+		return nil
+	},
+}
+
+// profileImportCmd imports profiles from an exported YAML file
+var profileImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import profiles from YAML",
+	Long: `Import profiles exported with 'iz profiles export' (use - for stdin).
+
+New profiles are created. Profiles that already exist are merged: values from
+the file replace local ones, and local values missing from the file are kept,
+so importing a shared profile keeps your own credentials.
+
+Examples:
+  iz profiles import team-profiles.yaml
+
+  # Then add your credentials
+  iz profiles use prod
+  iz profiles set personal-access-token <token>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := readInputFile(cmd, args[0])
+		if err != nil {
+			return err
+		}
+
+		results, err := izanami.ImportProfiles(data)
+		for _, result := range results {
+			if result.Created {
+				fmt.Fprintf(statusOut(cmd), "✓ Profile '%s' created\n", result.Name)
+			} else {
+				fmt.Fprintf(statusOut(cmd), "✓ Profile '%s' updated (local values not in the file kept)\n", result.Name)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(statusOut(cmd), "\nImported %d profile(s). Switch with: iz profiles use <name>\n", len(results))
+		return nil
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profileImportCmd)
+
+	profileExportCmd.ValidArgsFunction = completeProfileNames
+
+	profileExportCmd.Flags().BoolVar(&profileExportWithoutSecrets, "without-secrets", false, "Leave out sessions, tokens and client keys")
+	profileExportCmd.Flags().StringVarP(&profileExportFile, "file", "f", "", "Write to a file instead of stdout")
+}
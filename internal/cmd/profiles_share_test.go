@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestProfileExportImportCmd(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	t.Cleanup(func() { profileExportWithoutSecrets, profileExportFile = false, "" })
+
+	createTestConfig(t, paths.configPath, map[string]*izanami.Profile{
+		"prod": {LeaderURL: "https://izanami.example.com", Tenant: "production", PersonalAccessToken: "alice-pat"},
+	}, "prod")
+
+	exportPath := filepath.Join(t.TempDir(), "team.yaml")
+	var buf bytes.Buffer
+	cmd, cleanup := setupProfileCommand(&buf, nil, []string{"profile", "export", "prod", "--without-secrets", "--file", exportPath})
+	err := cmd.Execute()
+	cleanup()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Exported 1 profile(s)")
+	assert.NotContains(t, buf.String(), "Warning")
+
+	// A teammate without the profile imports it
+	otherPaths := setupTestPaths(t)
+	overridePathFunctions(t, otherPaths)
+	buf.Reset()
+	cmd, cleanup = setupProfileCommand(&buf, nil, []string{"profiles", "import", exportPath})
+	err = cmd.Execute()
+	cleanup()
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Profile 'prod' created")
+
+	profile, err := izanami.GetProfile("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "https://izanami.example.com", profile.LeaderURL)
+	assert.Equal(t, "production", profile.Tenant)
+	assert.Empty(t, profile.PersonalAccessToken)
+}
+
+func TestProfileExportCmd_WarnsAboutSecrets(t *testing.T) {
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+	t.Cleanup(func() { profileExportWithoutSecrets, profileExportFile = false, "" })
+
+	createTestConfig(t, paths.configPath, map[string]*izanami.Profile{
+		"prod": {LeaderURL: "https://izanami.example.com", PersonalAccessToken: "alice-pat"},
+	}, "prod")
+
+	var buf bytes.Buffer
+	cmd, cleanup := setupProfileCommand(&buf, nil, []string{"profiles", "export", "prod"})
+	defer cleanup()
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "Warning: the export contains credentials")
+	assert.Contains(t, buf.String(), "personal-access-token: alice-pat")
+}
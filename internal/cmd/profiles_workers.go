@@ -84,15 +84,15 @@ Examples:
 		profile, err := izanami.GetProfile(profileName)
 		isDefault := err == nil && profile.DefaultWorker == name
 
-		fmt.Fprintf(cmd.OutOrStdout(), "Added worker '%s' to profile '%s'\n", name, profileName)
-		fmt.Fprintf(cmd.OutOrStdout(), "  URL: %s\n", url)
+		fmt.Fprintf(statusOut(cmd), "Added worker '%s' to profile '%s'\n", name, profileName)
+		fmt.Fprintf(statusOut(cmd), "  URL: %s\n", url)
 
 		if isDefault {
-			fmt.Fprintf(cmd.OutOrStdout(), "  Set as default worker\n")
+			fmt.Fprintf(statusOut(cmd), "  Set as default worker\n")
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "\nTo add client credentials for this worker:\n")
-		fmt.Fprintf(cmd.OutOrStdout(), "  iz profiles workers client-keys add --tenant <tenant> --worker %s\n", name)
+		fmt.Fprintf(statusOut(cmd), "\nTo add client credentials for this worker:\n")
+		fmt.Fprintf(statusOut(cmd), "  iz profiles workers client-keys add --tenant <tenant> --worker %s\n", name)
 
 		return nil
 	},
@@ -124,7 +124,7 @@ Examples:
 						return nil
 					}
 				}
-				fmt.Fprintf(cmd.OutOrStderr(), "Warning: '%s' was the default worker. Default worker cleared.\n", name)
+				fmt.Fprintf(statusOut(cmd), "Warning: '%s' was the default worker. Default worker cleared.\n", name)
 			}
 		}
 
@@ -132,7 +132,7 @@ Examples:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "Deleted worker '%s'\n", name)
+		fmt.Fprintf(statusOut(cmd), "Deleted worker '%s'\n", name)
 		return nil
 	},
 }
@@ -225,7 +225,7 @@ Example:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "Default worker set to '%s'\n", name)
+		fmt.Fprintf(statusOut(cmd), "Default worker set to '%s'\n", name)
 		return nil
 	},
 }
@@ -256,7 +256,7 @@ Example:
 		// Resolve worker
 		workers, defaultWorker := resolveWorkerFromProfile(resolvedProfile)
 		rw, err := izanami.ResolveWorker("", workers, defaultWorker, func(format string, a ...interface{}) {
-			fmt.Fprintf(cmd.OutOrStderr(), format, a...)
+			fmt.Fprintf(statusOut(cmd), format, a...)
 		})
 		if err != nil {
 			return err
@@ -1,18 +1,26 @@
 package cmd
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
 
 var (
 	// Project flags
-	projectDesc string
-	projectData string
+	projectDesc    string
+	projectData    string
+	projectNewName string
+	// Include nested features in projects get
+	projectWithFeatures bool
+	// Contexts created along with a project
+	projectWithContexts    []string
+	projectProtectContexts []string
 	// Delete confirmation flag
 	projectsDeleteForce bool
 )
@@ -37,7 +45,7 @@ var adminProjectsListCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -59,10 +67,15 @@ var adminProjectsListCmd = &cobra.Command{
 }
 
 var adminProjectsGetCmd = &cobra.Command{
-	Use:         "get <project-name>",
+	Use:         "get <project-name-or-id>",
 	Short:       "Get a specific project",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/projects/:project"},
-	Args:        cobra.ExactArgs(1),
+	Long: `Get a project. Use --with-features to include the features of the project.
+
+Examples:
+  iz admin projects get my-project --tenant my-tenant
+  iz admin projects get my-project --tenant my-tenant --with-features -o json`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.ValidateTenant(); err != nil {
 			return err
@@ -73,37 +86,88 @@ var adminProjectsGetCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+		projectName, err := resolveProjectName(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
-			raw, err := izanami.GetProject(client, ctx, cfg.Tenant, args[0], izanami.Identity)
+			raw, err := izanami.GetProject(client, ctx, cfg.Tenant, projectName, izanami.Identity)
 			if err != nil {
 				return err
 			}
+			if !projectWithFeatures {
+				if raw, err = withoutJSONField(raw, "features"); err != nil {
+					return err
+				}
+			}
 			return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
 		}
 
 		// For table output, use ParseProject mapper
-		project, err := izanami.GetProject(client, ctx, cfg.Tenant, args[0], izanami.ParseProject)
+		project, err := izanami.GetProject(client, ctx, cfg.Tenant, projectName, izanami.ParseProject)
 		if err != nil {
 			return err
 		}
 
-		return output.PrintTo(cmd.OutOrStdout(), project, output.Format(outputFormat))
+		features := project.Features
+		project.Features = nil
+		if err := output.PrintTo(cmd.OutOrStdout(), project, output.Format(outputFormat)); err != nil {
+			return err
+		}
+		if !projectWithFeatures {
+			return nil
+		}
+		if len(features) == 0 {
+			fmt.Fprintf(statusOut(cmd), "\nNo features in project %s\n", project.Name)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "\nFeatures (%d):\n", len(features))
+		return output.PrintTo(cmd.OutOrStdout(), features, output.Format(outputFormat))
 	},
 }
 
+// withoutJSONField removes a top-level field from a raw JSON object
+func withoutJSONField(raw []byte, field string) ([]byte, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if _, ok := object[field]; !ok {
+		return raw, nil
+	}
+	delete(object, field)
+	return json.Marshal(object)
+}
+
 var adminProjectsCreateCmd = &cobra.Command{
 	Use:         "create <project-name>",
 	Short:       "Create a new project",
 	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/projects"},
-	Args:        cobra.ExactArgs(1),
+	Long: `Create a new project.
+
+Use --with-contexts to also create the project's standard contexts, and
+--protect-contexts to mark some of them as protected.
+
+Examples:
+  # Create a project
+  iz admin projects create my-project --description "My project"
+
+  # Create a project with its environment contexts, prod being protected
+  iz admin projects create my-project --with-contexts prod,preprod,dev --protect-contexts prod`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := cfg.ValidateTenant(); err != nil {
 			return err
 		}
 
+		contexts, err := buildProjectContexts(projectWithContexts, projectProtectContexts)
+		if err != nil {
+			return err
+		}
+
 		client, err := izanami.NewAdminClient(cfg)
 		if err != nil {
 			return err
@@ -123,31 +187,77 @@ var adminProjectsCreateCmd = &cobra.Command{
 			}
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.CreateProject(ctx, cfg.Tenant, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Project created successfully: %s\n", projectName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgProjectCreated, projectName))
+		runHooks(cmd, izanami.HookProjectCreate, map[string]interface{}{"name": projectName})
+
+		for _, c := range contexts {
+			if err := client.CreateContext(ctx, cfg.Tenant, projectName, c["name"].(string), "", c); err != nil {
+				return fmt.Errorf("project %s was created but context %s could not be: %w", projectName, c["name"], err)
+			}
+			if c["protected"].(bool) {
+				fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgContextCreatedProtected, c["name"]))
+			} else {
+				fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgContextCreated, c["name"]))
+			}
+		}
 		return nil
 	},
 }
 
+// buildProjectContexts builds the creation payloads of the contexts requested with --with-contexts
+func buildProjectContexts(names, protected []string) ([]map[string]interface{}, error) {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid context name %q (nested contexts are not supported here)", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("context %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+
+	isProtected := make(map[string]bool, len(protected))
+	for _, name := range protected {
+		if !seen[name] {
+			return nil, fmt.Errorf("--protect-contexts: %q is not in --with-contexts", name)
+		}
+		isProtected[name] = true
+	}
+
+	contexts := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		contexts[i] = map[string]interface{}{
+			"name":      name,
+			"protected": isProtected[name],
+		}
+	}
+	return contexts, nil
+}
+
 var adminProjectsUpdateCmd = &cobra.Command{
-	Use:         "update <project-name>",
+	Use:         "update <project-name-or-id>",
 	Short:       "Update a project",
-	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project"},
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project", "projectArg": "0"},
 	Long: `Update a project's properties.
 
 You can provide the updated data via:
-  - --description flag (merged with existing data)
+  - --description and --name flags (other fields keep their current value)
   - --data flag with JSON data
-  - Both flags (--description takes precedence)
+  - Both (--description and --name take precedence)
 
 Examples:
   # Update description only
   iz admin projects update my-project --tenant my-tenant --description "New description"
 
+  # Rename a project
+  iz admin projects update my-project --tenant my-tenant --name shop
+
   # Update with JSON data
   iz admin projects update my-project --tenant my-tenant --data '{"name":"my-project","description":"Updated"}'
 
@@ -164,7 +274,10 @@ Examples:
 			return err
 		}
 
-		projectName := args[0]
+		projectName, err := resolveProjectName(commandContext(), client, args[0])
+		if err != nil {
+			return err
+		}
 		var data map[string]interface{}
 
 		// Parse JSON data if provided
@@ -186,31 +299,50 @@ Examples:
 
 		// Always set the name field
 		data["name"] = projectName
+		if cmd.Flags().Changed("name") {
+			if projectNewName == "" {
+				return fmt.Errorf("--name cannot be empty")
+			}
+			data["name"] = projectNewName
+		}
 
 		// Merge description flag if provided
 		if cmd.Flags().Changed("description") {
 			data["description"] = projectDesc
 		}
 
-		// Validate that we have at least name and description
+		ctx := commandContext()
+
+		if !cmd.Flags().Changed("data") && !cmd.Flags().Changed("name") && !cmd.Flags().Changed("description") {
+			return fmt.Errorf("nothing to update: use --name, --description or --data")
+		}
+
+		// Keep the current description when none is given
 		if _, hasDesc := data["description"]; !hasDesc {
-			return fmt.Errorf("description is required (use --description flag or --data)")
+			current, err := izanami.GetProject(client, ctx, cfg.Tenant, projectName, izanami.ParseProject)
+			if err != nil {
+				return err
+			}
+			data["description"] = current.Description
 		}
 
-		ctx := context.Background()
 		if err := client.UpdateProject(ctx, cfg.Tenant, projectName, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Project updated successfully: %s\n", projectName)
+		if name := data["name"]; name != projectName {
+			fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgProjectRenamed, projectName, name))
+			return nil
+		}
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgProjectUpdated, projectName))
 		return nil
 	},
 }
 
 var adminProjectsDeleteCmd = &cobra.Command{
-	Use:         "delete <project-name>",
+	Use:         "delete <project-name-or-id>",
 	Short:       "Delete a project",
-	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:tenant/projects/:project"},
+	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:tenant/projects/:project", "projectArg": "0"},
 	Long:        `Delete a project. WARNING: This will delete all features in the project.`,
 	Args:        cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -218,7 +350,16 @@ var adminProjectsDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		projectName := args[0]
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		projectName, err := resolveProjectName(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
 
 		// Confirm deletion unless --force is used
 		if !projectsDeleteForce {
@@ -227,23 +368,18 @@ var adminProjectsDeleteCmd = &cobra.Command{
 			}
 		}
 
-		client, err := izanami.NewAdminClient(cfg)
-		if err != nil {
-			return err
-		}
-
-		ctx := context.Background()
 		if err := client.DeleteProject(ctx, cfg.Tenant, projectName); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Project deleted successfully: %s\n", projectName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgProjectDeleted, projectName))
+		runHooks(cmd, izanami.HookProjectDelete, map[string]interface{}{"name": projectName})
 		return nil
 	},
 }
 
 var adminProjectsLogsCmd = &cobra.Command{
-	Use:         "logs <project-name>",
+	Use:         "logs <project-name-or-id>",
 	Short:       "View project event logs",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/projects/:project/logs"},
 	Long: `View event logs for a project. Shows audit events like feature changes, user actions, etc.
@@ -271,12 +407,14 @@ Examples:
 			return err
 		}
 
-		projectName := args[0]
-
 		client, err := izanami.NewAdminClient(cfg)
 		if err != nil {
 			return err
 		}
+		projectName, err := resolveProjectName(commandContext(), client, args[0])
+		if err != nil {
+			return err
+		}
 
 		opts := &izanami.LogsRequest{
 			Order:    logsOrder,
@@ -290,7 +428,7 @@ Examples:
 			Total:    logsTotal,
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -328,8 +466,12 @@ func init() {
 
 	adminProjectsCreateCmd.Flags().StringVar(&projectDesc, "description", "", "Project description")
 	adminProjectsCreateCmd.Flags().StringVar(&projectData, "data", "", "JSON project data")
+	adminProjectsCreateCmd.Flags().StringSliceVar(&projectWithContexts, "with-contexts", []string{}, "Contexts to create in the new project (comma-separated)")
+	adminProjectsCreateCmd.Flags().StringSliceVar(&projectProtectContexts, "protect-contexts", []string{}, "Contexts from --with-contexts to mark as protected")
 	adminProjectsUpdateCmd.Flags().StringVar(&projectDesc, "description", "", "Project description")
 	adminProjectsUpdateCmd.Flags().StringVar(&projectData, "data", "", "JSON project data")
+	adminProjectsUpdateCmd.Flags().StringVar(&projectNewName, "name", "", "New project name")
+	adminProjectsGetCmd.Flags().BoolVar(&projectWithFeatures, "with-features", false, "Include the features of the project")
 	adminProjectsDeleteCmd.Flags().BoolVarP(&projectsDeleteForce, "force", "f", false, "Skip confirmation prompt")
 
 	// Project logs
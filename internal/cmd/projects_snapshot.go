@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	snapshotOutput     string
+	restoreFile        string
+	restoreInto        string
+	restorePrune       bool
+	restoreDryRun      bool
+	restoreAutoApprove bool
+)
+
+// adminProjectsSnapshotCmd captures the features, overloads and tags of a project
+var adminProjectsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <project>",
+	Short: "Save the features, overloads and tags of a project",
+	Long: `Save the features of a project with their overloads, the project contexts and
+the tags used by the features to a JSON snapshot, to restore them later with
+'iz admin projects restore'. Unlike 'iz admin export', only one project is
+captured.
+
+Here -o/--output is the snapshot file (default: stdout), not the output format.
+
+Examples:
+  # Snapshot a project before a risky change
+  iz admin projects snapshot billing -o billing.json
+
+  # Print the snapshot
+  iz admin projects snapshot billing`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		snapshot, err := izanami.SnapshotProject(client, commandContext(), cfg.Tenant, args[0])
+		if err != nil {
+			return err
+		}
+
+		if snapshotOutput == "" || snapshotOutput == "-" {
+			return output.PrintTo(cmd.OutOrStdout(), snapshot, output.JSON)
+		}
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize snapshot: %w", err)
+		}
+		if err := os.WriteFile(snapshotOutput, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot file: %w", err)
+		}
+		fmt.Fprintf(statusOut(cmd), "Snapshot of project %s written to: %s (%d feature(s), %d overload(s))\n",
+			snapshot.Project, snapshotOutput, len(snapshot.Features), len(snapshot.Overloads))
+		return nil
+	},
+}
+
+// adminProjectsRestoreCmd restores a project snapshot
+var adminProjectsRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a project snapshot",
+	Long: `Restore a snapshot taken with 'iz admin projects snapshot': create missing
+tags, contexts, features and overloads, and update those that differ. The
+target project must exist; --into restores into another project than the one
+of the snapshot.
+
+With --prune, features, project contexts and overloads missing from the
+snapshot are deleted, rolling the project back to the snapshot. Tags are never
+deleted, as they are shared by the tenant. Changes are shown before they are
+applied; deletions require confirmation unless --auto-approve is set.
+
+Examples:
+  # Show what a restore would change
+  iz admin projects restore -f billing.json --dry-run
+
+  # Roll the project back to the snapshot
+  iz admin projects restore -f billing.json --prune
+
+  # Copy a project snapshot into another project
+  iz admin projects restore -f billing.json --into billing-staging`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		var data []byte
+		var err error
+		if restoreFile == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(restoreFile)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		snapshot, err := izanami.ParseProjectSnapshot(data)
+		if err != nil {
+			return err
+		}
+		project := restoreInto
+		if project == "" {
+			project = snapshot.Project
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		ctx := commandContext()
+		plan, err := izanami.BuildRestorePlan(client, ctx, cfg.Tenant, snapshot, project, restorePrune)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" && restoreDryRun {
+			return output.PrintTo(cmd.OutOrStdout(), plan, output.JSON)
+		}
+		out := statusOut(cmd)
+		printPlan(out, plan)
+		if restoreDryRun || len(plan.Changes) == 0 {
+			return nil
+		}
+		if plan.Count(izanami.PlanDelete) > 0 && !restoreAutoApprove {
+			if !confirmAction(cmd, fmt.Sprintf("Restore project %s, including %d deletion(s)?", project, plan.Count(izanami.PlanDelete))) {
+				return nil
+			}
+		}
+
+		applied := 0
+		err = plan.Apply(ctx, func(change izanami.PlannedChange) {
+			applied++
+			if cfg.Verbose {
+				fmt.Fprintf(out, "[verbose] %s %s %s\n", change.Action, change.Kind, change.Name)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("%w (%d of %d change(s) applied)", err, applied, len(plan.Changes))
+		}
+		fmt.Fprintf(out, "Restore complete: %d created, %d updated, %d deleted\n",
+			plan.Count(izanami.PlanCreate), plan.Count(izanami.PlanUpdate), plan.Count(izanami.PlanDelete))
+		return nil
+	},
+}
+
+func init() {
+	adminProjectsCmd.AddCommand(adminProjectsSnapshotCmd)
+	adminProjectsCmd.AddCommand(adminProjectsRestoreCmd)
+	adminProjectsSnapshotCmd.ValidArgsFunction = completeProjectNames
+
+	adminProjectsSnapshotCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "Snapshot file to write (default: stdout)")
+	adminProjectsRestoreCmd.Flags().StringVarP(&restoreFile, "file", "f", "", "Snapshot file to restore (- for stdin)")
+	adminProjectsRestoreCmd.Flags().StringVar(&restoreInto, "into", "", "Project to restore into (default: the snapshot's project)")
+	adminProjectsRestoreCmd.Flags().BoolVar(&restorePrune, "prune", false, "Delete features, contexts and overloads missing from the snapshot")
+	adminProjectsRestoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Show the changes without applying them")
+	adminProjectsRestoreCmd.Flags().BoolVar(&restoreAutoApprove, "auto-approve", false, "Apply deletions without confirmation")
+	adminProjectsRestoreCmd.MarkFlagRequired("file")
+	adminProjectsRestoreCmd.RegisterFlagCompletionFunc("into", completeProjectNames)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectsSnapshotAndRestore(t *testing.T) {
+	enabled := "true"
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/projects/shop":
+			io.WriteString(w, `{"name":"shop"}`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[]`)
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"checkout","project":"shop","enabled":`+enabled+`,"resultType":"boolean"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		snapshotOutput, restoreFile, restoreDryRun = "", "", false
+		adminProjectsSnapshotCmd.SetOut(nil)
+		adminProjectsRestoreCmd.SetOut(nil)
+	})
+	outputFormat = "table"
+
+	snapshotOutput = filepath.Join(t.TempDir(), "shop.json")
+	var buf bytes.Buffer
+	adminProjectsSnapshotCmd.SetOut(&buf)
+	require.NoError(t, adminProjectsSnapshotCmd.RunE(adminProjectsSnapshotCmd, []string{"shop"}))
+	assert.Contains(t, buf.String(), "written to: "+snapshotOutput+" (1 feature(s), 0 overload(s))")
+	data, err := os.ReadFile(snapshotOutput)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name": "checkout"`)
+
+	// The feature was disabled after the snapshot
+	enabled = "false"
+	restoreFile, restoreDryRun = snapshotOutput, true
+	buf.Reset()
+	adminProjectsRestoreCmd.SetOut(&buf)
+	require.NoError(t, adminProjectsRestoreCmd.RunE(adminProjectsRestoreCmd, nil))
+	assert.Contains(t, buf.String(), "update feature shop/checkout")
+	assert.Contains(t, buf.String(), "enabled: false -> true")
+}
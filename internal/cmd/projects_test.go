@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProjectContexts(t *testing.T) {
+	contexts, err := buildProjectContexts([]string{"prod", "preprod", "dev"}, []string{"prod"})
+	require.NoError(t, err)
+	require.Len(t, contexts, 3)
+	assert.Equal(t, map[string]interface{}{"name": "prod", "protected": true}, contexts[0])
+	assert.Equal(t, map[string]interface{}{"name": "dev", "protected": false}, contexts[2])
+
+	contexts, err = buildProjectContexts(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, contexts)
+}
+
+func TestBuildProjectContexts_Errors(t *testing.T) {
+	_, err := buildProjectContexts([]string{"prod", "prod"}, nil)
+	assert.ErrorContains(t, err, "listed twice")
+
+	_, err = buildProjectContexts([]string{"prod/eu"}, nil)
+	assert.ErrorContains(t, err, "invalid context name")
+
+	_, err = buildProjectContexts([]string{"dev"}, []string{"prod"})
+	assert.ErrorContains(t, err, "not in --with-contexts")
+}
+
+// setupProjectsServer serves one project with a feature, recording the body of PUT requests
+func setupProjectsServer(t *testing.T) *map[string]interface{} {
+	t.Helper()
+	var updated map[string]interface{}
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/admin/tenants/acme/projects/shop":
+			io.WriteString(w, `{"id": "p1", "name": "shop", "description": "Shop front",
+				"features": [{"id": "f1", "name": "one-click", "project": "shop", "enabled": true}]}`)
+		case "PUT /api/admin/tenants/acme/projects/shop":
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &updated))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		projectNewName, projectDesc, projectData, projectWithFeatures = "", "", "", false
+		for _, name := range []string{"name", "description", "data"} {
+			adminProjectsUpdateCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+	return &updated
+}
+
+func TestAdminProjectsGetCmd_WithFeatures(t *testing.T) {
+	setupProjectsServer(t)
+	outputFormat = "json"
+
+	var buf bytes.Buffer
+	adminProjectsGetCmd.SetOut(&buf)
+	defer adminProjectsGetCmd.SetOut(nil)
+	require.NoError(t, adminProjectsGetCmd.RunE(adminProjectsGetCmd, []string{"shop"}))
+
+	var project map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &project))
+	assert.Equal(t, "shop", project["name"])
+	assert.NotContains(t, project, "features")
+
+	buf.Reset()
+	projectWithFeatures = true
+	require.NoError(t, adminProjectsGetCmd.RunE(adminProjectsGetCmd, []string{"shop"}))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &project))
+	assert.Len(t, project["features"], 1)
+
+	buf.Reset()
+	outputFormat = "table"
+	require.NoError(t, adminProjectsGetCmd.RunE(adminProjectsGetCmd, []string{"shop"}))
+	assert.Contains(t, buf.String(), "Features (1):")
+	assert.Contains(t, buf.String(), "one-click")
+}
+
+func TestAdminProjectsUpdateCmd_Rename(t *testing.T) {
+	updated := setupProjectsServer(t)
+	require.NoError(t, adminProjectsUpdateCmd.Flags().Set("name", "store"))
+
+	var buf bytes.Buffer
+	adminProjectsUpdateCmd.SetOut(&buf)
+	defer adminProjectsUpdateCmd.SetOut(nil)
+	require.NoError(t, adminProjectsUpdateCmd.RunE(adminProjectsUpdateCmd, []string{"shop"}))
+
+	assert.Equal(t, map[string]interface{}{"name": "store", "description": "Shop front"}, *updated)
+	assert.Contains(t, buf.String(), "Project renamed successfully: shop -> store")
+}
+
+func TestAdminProjectsUpdateCmd_NothingToUpdate(t *testing.T) {
+	setupProjectsServer(t)
+	err := adminProjectsUpdateCmd.RunE(adminProjectsUpdateCmd, []string{"shop"})
+	assert.ErrorContains(t, err, "nothing to update")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// recordFile is the --record file, empty when recording is disabled
+var recordFile string
+
+// commandRecorder records the HTTP interactions of the command for --record
+var commandRecorder *izanami.Recorder
+
+// setupRecording records the HTTP interactions of every client for --record.
+// Multi-profile runs keep the recorder of the first profile.
+func setupRecording() {
+	if recordFile == "" || commandRecorder != nil {
+		return
+	}
+	commandRecorder = izanami.NewRecorder()
+	izanami.SetRecorder(commandRecorder)
+}
+
+// saveRecording writes the recorded interactions to the --record file, also
+// when the command failed, so that failures can be replayed too
+func saveRecording() {
+	if commandRecorder == nil {
+		return
+	}
+	if err := commandRecorder.Save(recordFile, time.Now()); err != nil {
+		fmt.Fprintf(processStderr, "Warning: failed to save recording to %s: %v\n", recordFile, err)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "Record the HTTP interactions of the command to this file, for 'iz mock-server --replay'")
+}
@@ -1,12 +1,9 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -22,7 +19,7 @@ var resetCmd = &cobra.Command{
 This will:
   1. Backup existing files with timestamps
   2. Delete config file (~/.config/iz/config.yaml)
-  3. Delete sessions file (~/.izsessions)
+  3. Delete sessions file (~/.izsessions, %APPDATA%\iz\sessions.yaml on Windows)
 
 Backups are created with format: {filename}.backup.{timestamp}
 Example: config.yaml.backup.20250119_143025
@@ -45,28 +42,18 @@ Note: This does not affect environment variables or command-line flags.`,
 		}
 
 		// Show what will be deleted
-		fmt.Fprintln(cmd.OutOrStdout(), "The following files will be backed up and deleted:")
+		fmt.Fprintln(statusOut(cmd), "The following files will be backed up and deleted:")
 		if configExists {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", configPath)
+			fmt.Fprintf(statusOut(cmd), "  - %s\n", configPath)
 		}
 		if sessionsExists {
-			fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", sessionsPath)
+			fmt.Fprintf(statusOut(cmd), "  - %s\n", sessionsPath)
 		}
-		fmt.Fprintln(cmd.OutOrStdout())
+		fmt.Fprintln(statusOut(cmd))
 
 		// Ask for confirmation unless --force is used
 		if !force {
-			fmt.Fprint(cmd.OutOrStdout(), "Are you sure? (y/N): ")
-			reader := bufio.NewReader(cmd.InOrStdin())
-			response, err := reader.ReadString('\n')
-			if err != nil && err != io.EOF {
-				// Only return error for actual read failures, not EOF
-				return fmt.Errorf("failed to read input: %w", err)
-			}
-			response = strings.ToLower(strings.TrimSpace(response))
-
-			if response != "y" && response != "yes" {
-				fmt.Fprintln(cmd.OutOrStdout(), "Cancelled")
+			if !confirmAction(cmd, "Are you sure?") {
 				return nil
 			}
 		}
@@ -80,12 +67,12 @@ Note: This does not affect environment variables or command-line flags.`,
 			if err := backupFile(configPath, backupPath); err != nil {
 				return fmt.Errorf("failed to backup config file: %w", err)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Config backed up to: %s\n", backupPath)
+			fmt.Fprintf(statusOut(cmd), "✓ Config backed up to: %s\n", backupPath)
 
 			if err := os.Remove(configPath); err != nil {
 				return fmt.Errorf("failed to delete config file: %w", err)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Config deleted: %s\n", configPath)
+			fmt.Fprintf(statusOut(cmd), "✓ Config deleted: %s\n", configPath)
 		}
 
 		// Backup and delete sessions file
@@ -94,19 +81,19 @@ Note: This does not affect environment variables or command-line flags.`,
 			if err := backupFile(sessionsPath, backupPath); err != nil {
 				return fmt.Errorf("failed to backup sessions file: %w", err)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Sessions backed up to: %s\n", backupPath)
+			fmt.Fprintf(statusOut(cmd), "✓ Sessions backed up to: %s\n", backupPath)
 
 			if err := os.Remove(sessionsPath); err != nil {
 				return fmt.Errorf("failed to delete sessions file: %w", err)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Sessions deleted: %s\n", sessionsPath)
+			fmt.Fprintf(statusOut(cmd), "✓ Sessions deleted: %s\n", sessionsPath)
 		}
 
-		fmt.Fprintln(cmd.OutOrStdout(), "\n✓ Reset complete!")
-		fmt.Fprintln(cmd.OutOrStdout(), "\nNext steps:")
-		fmt.Fprintln(cmd.OutOrStdout(), "  1. Run 'iz login' to authenticate")
-		fmt.Fprintln(cmd.OutOrStdout(), "  2. Run 'iz config init' to create a new config (optional)")
-		fmt.Fprintln(cmd.OutOrStdout(), "\nYour backups are preserved in case you need to restore.")
+		fmt.Fprintln(statusOut(cmd), "\n✓ Reset complete!")
+		fmt.Fprintln(statusOut(cmd), "\nNext steps:")
+		fmt.Fprintln(statusOut(cmd), "  1. Run 'iz login' to authenticate")
+		fmt.Fprintln(statusOut(cmd), "  2. Run 'iz config init' to create a new config (optional)")
+		fmt.Fprintln(statusOut(cmd), "\nYour backups are preserved in case you need to restore.")
 
 		return nil
 	},
@@ -120,9 +120,9 @@ func setupTestPaths(t *testing.T) *testPaths {
 func overridePathFunctions(t *testing.T, paths *testPaths) {
 	t.Helper()
 
-	// Save original functions
-	originalGetConfigDir := izanami.GetConfigDir
-	originalGetSessionsPath := izanami.GetSessionsPath
+	// Save the original paths (restoring GetConfigDir itself would make it call itself)
+	originalGetConfigDir := izanami.GetConfigDir()
+	originalGetSessionsPath := izanami.GetSessionsPath()
 
 	// Override with test-specific paths
 	izanami.SetGetConfigDirFunc(func() string {
@@ -134,8 +134,8 @@ func overridePathFunctions(t *testing.T, paths *testPaths) {
 
 	// Restore on cleanup
 	t.Cleanup(func() {
-		izanami.SetGetConfigDirFunc(originalGetConfigDir)
-		izanami.SetGetSessionsPathFunc(originalGetSessionsPath)
+		izanami.SetGetConfigDirFunc(func() string { return originalGetConfigDir })
+		izanami.SetGetSessionsPathFunc(func() string { return originalGetSessionsPath })
 	})
 }
 
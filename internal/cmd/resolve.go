@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// nameCandidate is a resource that a name-or-ID argument may designate
+type nameCandidate struct {
+	ID    string
+	Name  string
+	Scope string // Where the resource lives, e.g. "project shop" for a feature
+}
+
+// matchNameOrID returns the indexes of the candidates ref designates: the
+// candidate with this ID, else every candidate with this name
+func matchNameOrID(ref string, candidates []nameCandidate) []int {
+	var named []int
+	for i, c := range candidates {
+		if c.ID == ref {
+			return []int{i}
+		}
+		if c.Name == ref {
+			named = append(named, i)
+		}
+	}
+	return named
+}
+
+// ambiguousNameError lists the candidates sharing a name, so that the user
+// can pick one by ID. hint tells how to narrow the lookup down.
+func ambiguousNameError(kind, name, hint string, candidates []nameCandidate, matches []int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "multiple %ss named '%s' found (%s):", kind, name, hint)
+	for _, i := range matches {
+		fmt.Fprintf(&b, "\n  %s", candidates[i].ID)
+		if candidates[i].Scope != "" {
+			fmt.Fprintf(&b, "  (%s)", candidates[i].Scope)
+		}
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+// resolveWebhook finds a webhook by ID or name. With --exact-id, only the ID
+// is compared.
+func resolveWebhook(ctx context.Context, client *izanami.AdminClient, ref string) (*izanami.WebhookFull, error) {
+	webhooks, err := izanami.ListWebhooks(client, ctx, cfg.Tenant, izanami.ParseWebhooks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch webhooks: %w", err)
+	}
+
+	candidates := make([]nameCandidate, len(webhooks))
+	for i, w := range webhooks {
+		candidates[i] = nameCandidate{ID: w.ID, Name: w.Name}
+		if exactID {
+			candidates[i].Name = ""
+		}
+	}
+	matches := matchNameOrID(ref, candidates)
+	switch len(matches) {
+	case 0:
+		return nil, notFoundError("webhook '%s' not found", ref)
+	case 1:
+		return &webhooks[matches[0]], nil
+	default:
+		return nil, ambiguousNameError("webhook", ref, "provide the ID instead", candidates, matches)
+	}
+}
+
+// resolveAPIKey finds an API key by name or client ID. With --exact-id, only
+// the name is compared, as the API addresses keys by name.
+func resolveAPIKey(ctx context.Context, client *izanami.AdminClient, ref string) (*izanami.APIKey, error) {
+	if exactID {
+		return client.GetAPIKeyByName(ctx, cfg.Tenant, ref)
+	}
+	keys, err := izanami.ListAPIKeys(client, ctx, cfg.Tenant, izanami.ParseAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// A name wins over a client ID: the API addresses keys by name
+	for i := range keys {
+		if keys[i].Name == ref {
+			return &keys[i], nil
+		}
+	}
+	for i := range keys {
+		if keys[i].ClientID == ref {
+			return &keys[i], nil
+		}
+	}
+	return nil, notFoundError("API key '%s' not found (by name or client ID)", ref)
+}
+
+// resolveAPIKeyName returns the name of the API key designated by a name or
+// client ID. With --exact-id, ref is used as the name without a lookup.
+func resolveAPIKeyName(ctx context.Context, client *izanami.AdminClient, ref string) (string, error) {
+	if exactID {
+		return ref, nil
+	}
+	key, err := resolveAPIKey(ctx, client, ref)
+	if err != nil {
+		return "", err
+	}
+	return key.Name, nil
+}
+
+// resolveProjectName returns the name of the project designated by a name or
+// ID, as the API addresses projects by name. Only UUIDs are looked up, and
+// not with --exact-id.
+func resolveProjectName(ctx context.Context, client *izanami.AdminClient, ref string) (string, error) {
+	if exactID || !IsUUID(ref) {
+		return ref, nil
+	}
+	projects, err := izanami.ListProjects(client, ctx, cfg.Tenant, izanami.ParseProjects)
+	if err != nil {
+		return "", fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, p := range projects {
+		if p.ID == ref {
+			return p.Name, nil
+		}
+	}
+	// A project may be named like a UUID
+	return ref, nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestMatchNameOrID(t *testing.T) {
+	candidates := []nameCandidate{
+		{ID: "a1", Name: "checkout"},
+		{ID: "checkout", Name: "legacy"},
+		{ID: "b2", Name: "checkout"},
+	}
+	assert.Equal(t, []int{1}, matchNameOrID("checkout", candidates), "an ID wins over names")
+	assert.Equal(t, []int{1}, matchNameOrID("legacy", candidates))
+	assert.Equal(t, []int{2}, matchNameOrID("b2", candidates))
+	assert.Empty(t, matchNameOrID("missing", candidates))
+}
+
+func TestFindFeatureByName_Ambiguous(t *testing.T) {
+	features := []izanami.Feature{
+		{ID: "11111111-1111-1111-1111-111111111111", Name: "checkout", Project: "shop"},
+		{ID: "33333333-3333-3333-3333-333333333333", Name: "checkout", Project: "web"},
+		{ID: "legacy-id", Name: "search", Project: "web"},
+	}
+
+	_, err := findFeatureByName(features, "checkout", "", "acme")
+	assert.EqualError(t, err, "multiple features named 'checkout' found (use --project to disambiguate or provide UUID instead):\n"+
+		"  11111111-1111-1111-1111-111111111111  (project shop)\n"+
+		"  33333333-3333-3333-3333-333333333333  (project web)")
+
+	id, err := findFeatureByName(features, "checkout", "web", "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "33333333-3333-3333-3333-333333333333", id)
+
+	// Features imported with custom IDs are found by ID too
+	id, err = findFeatureByName(features, "legacy-id", "", "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-id", id)
+
+	_, err = findFeatureByName(features, "missing", "", "acme")
+	assert.Equal(t, ExitNotFound, exitCode(err))
+}
+
+// setupResolveTest serves webhooks, keys and projects of tenant acme
+func setupResolveTest(t *testing.T) *izanami.AdminClient {
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/webhooks":
+			io.WriteString(w, `[{"id":"w1","name":"slack","url":"https://hooks.example.com"},{"id":"slack-id","name":"teams","url":"https://hooks.example.com"}]`)
+		case "/api/admin/tenants/acme/keys":
+			io.WriteString(w, `[{"name":"app","clientId":"app_abc","enabled":true}]`)
+		case "/api/admin/tenants/acme/projects":
+			io.WriteString(w, `[{"id":"44444444-4444-4444-4444-444444444444","name":"shop"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+
+	t.Cleanup(func() { exactID = false })
+	client, err := izanami.NewAdminClient(cfg)
+	require.NoError(t, err)
+	return client
+}
+
+func TestResolveWebhook(t *testing.T) {
+	client := setupResolveTest(t)
+	ctx := context.Background()
+
+	webhook, err := resolveWebhook(ctx, client, "slack")
+	require.NoError(t, err)
+	assert.Equal(t, "w1", webhook.ID)
+
+	webhook, err = resolveWebhook(ctx, client, "slack-id")
+	require.NoError(t, err)
+	assert.Equal(t, "teams", webhook.Name)
+
+	exactID = true
+	_, err = resolveWebhook(ctx, client, "slack")
+	assert.Equal(t, ExitNotFound, exitCode(err), "--exact-id skips the name lookup")
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	client := setupResolveTest(t)
+	ctx := context.Background()
+
+	key, err := resolveAPIKey(ctx, client, "app_abc")
+	require.NoError(t, err)
+	assert.Equal(t, "app", key.Name)
+
+	name, err := resolveAPIKeyName(ctx, client, "app")
+	require.NoError(t, err)
+	assert.Equal(t, "app", name)
+
+	_, err = resolveAPIKeyName(ctx, client, "other")
+	assert.Equal(t, ExitNotFound, exitCode(err))
+
+	exactID = true
+	name, err = resolveAPIKeyName(ctx, client, "app_abc")
+	require.NoError(t, err)
+	assert.Equal(t, "app_abc", name)
+}
+
+func TestResolveProjectName(t *testing.T) {
+	client := setupResolveTest(t)
+	ctx := context.Background()
+
+	name, err := resolveProjectName(ctx, client, "44444444-4444-4444-4444-444444444444")
+	require.NoError(t, err)
+	assert.Equal(t, "shop", name)
+
+	name, err = resolveProjectName(ctx, client, "shop")
+	require.NoError(t, err)
+	assert.Equal(t, "shop", name)
+
+	exactID = true
+	name, err = resolveProjectName(ctx, client, "44444444-4444-4444-4444-444444444444")
+	require.NoError(t, err)
+	assert.Equal(t, "44444444-4444-4444-4444-444444444444", name)
+}
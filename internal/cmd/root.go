@@ -11,6 +11,8 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+	"github.com/webskin/izanami-go-cli/internal/utils"
 	"golang.org/x/term"
 )
 
@@ -23,11 +25,32 @@ var (
 	project            string
 	contextPath        string
 	timeout            int
+	retries            int
+	retryMaxWait       int
+	cacheTTL           int
+	noCache            bool
 	verbose            bool
 	quiet              bool
+	assumeYes          bool
+	exactID            bool
 	outputFormat       string
 	compactJSON        bool
 	insecureSkipVerify bool
+	caCertFile         string
+	clientCertFile     string
+	clientKeyFile      string
+	httpHeaderFlags    []string
+	tableColumns       []string
+	tableWide          bool
+	tableSortBy        string
+	tableReverse       bool
+
+	// Custom headers parsed from --header
+	httpHeaders map[string]string
+
+	// processStderr is the standard error of the process, used for errors and
+	// passphrase prompts, which --quiet keeps
+	processStderr = os.Stderr
 
 	// Global config
 	cfg           *izanami.ResolvedConfig
@@ -64,33 +87,74 @@ Examples:
   # Admin: Create a new project
   iz admin projects create new-project --description "My new project"
 
+Windows PowerShell:
+  # Quote @file arguments ('@' is the splatting operator in PowerShell)
+  iz admin features create my-feature --data '@feature.json'
+
+  # Wrap inline JSON in single quotes so double quotes are kept
+  iz admin features patch --data '[{"op":"replace","path":"/id/enabled","value":false}]'
+
+  # Set environment variables for the current session
+  $env:IZ_TENANT = "my-tenant"
+
 For more information, visit: https://github.com/MAIF/izanami`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// --quiet and --verbose are mutually exclusive
 		if quiet && verbose {
 			return fmt.Errorf("--quiet and --verbose are mutually exclusive")
 		}
-		if quiet {
-			cmd.SetOut(io.Discard)
+		if err := validateErrorFormat(); err != nil {
+			return err
+		}
+		// IZ_LANG selects the locale of the messages of every command
+		setupMessages(processStderr)
+		// Scripts reading JSON errors get the error object alone, without the usage
+		if errorFormat != ErrorFormatText {
+			cmd.SilenceUsage = true
 		}
 
-		// Skip config loading for commands that don't need it
-		skipCommands := []string{"completion", "version", "help", "login", "logout", "sessions", "config", "profiles", "reset"}
-		for _, skip := range skipCommands {
-			if cmd.Name() == skip || cmd.Parent() != nil && cmd.Parent().Name() == skip {
-				return nil
-			}
+		// HTTP tracing applies to every command, including login
+		if err := setupLogging(cmd); err != nil {
+			return err
 		}
+		// The trace context of the environment is propagated by every command
+		setupTracing()
+		// --record captures the interactions of every command
+		setupRecording()
 
+		// Custom headers apply to every command, including login
 		var err error
+		if httpHeaders, err = izanami.ParseHTTPHeaders(httpHeaderFlags); err != nil {
+			return err
+		}
+
+		// Table options apply to every list, including multi-profile tables
+		output.SetTableOptions(output.TableOptions{
+			Columns: tableColumns,
+			Wide:    tableWide,
+			SortBy:  tableSortBy,
+			Reverse: tableReverse,
+			Width:   terminalWidth(cmd.OutOrStdout()),
+		})
+
+		// --profiles/--all-profiles: the command runs later once per profile
+		if multiProfileRequested(cmd) {
+			return prepareMultiProfileRun(cmd)
+		}
+
+		// Skip config loading for commands that don't need it
+		if skipsConfigLoading(cmd) {
+			return nil
+		}
 
 		// Load config with profile support
 		// Priority order:
 		// 1. Command-line flags (applied later)
 		// 2. Environment variables (handled by viper)
-		// 3. Profile settings (if --profile specified or active profile exists)
-		// 4. Session settings (loaded via profile's session reference)
-		// 5. Top-level config (fallback)
+		// 3. Project-local .iz.yaml (tenant, project, context)
+		// 4. Profile settings (if --profile specified or active profile exists)
+		// 5. Session settings (loaded via profile's session reference)
+		// 6. Top-level config (fallback)
 
 		// Load config via profile system (profiles load their referenced sessions)
 		if profileName != "" {
@@ -105,19 +169,24 @@ For more information, visit: https://github.com/MAIF/izanami`,
 			return fmt.Errorf("failed to load config: %w (use 'iz login' to authenticate)", err)
 		}
 
+		// Per-command flag defaults of the profile fill flags that were not given
+		if err := applyProfileDefaults(cmd, activeProfile); err != nil {
+			return err
+		}
+
 		// Command-line flags override everything (highest priority)
 		// Environment variables override profile settings but are overridden by flags
-		cfg.MergeWithFlags(izanami.FlagValues{
-			LeaderURL:          getValueWithEnvFallback(leaderURL, "IZ_LEADER_URL"),
-			ClientID:           getValueWithEnvFallback("", "IZ_CLIENT_ID"),
-			ClientSecret:       getValueWithEnvFallback("", "IZ_CLIENT_SECRET"),
-			Tenant:             getValueWithEnvFallback(tenant, "IZ_TENANT"),
-			Project:            getValueWithEnvFallback(project, "IZ_PROJECT"),
-			Context:            getValueWithEnvFallback(contextPath, "IZ_CONTEXT"),
-			Timeout:            timeout,
-			Verbose:            verbose,
-			InsecureSkipVerify: insecureSkipVerify,
-		})
+		cfg.MergeWithFlags(globalFlagValues(cmd))
+
+		// Per-command timeouts of the config apply unless --timeout was given
+		commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+		if timeout == 0 {
+			if commandTimeout := cfg.CommandTimeout(commandPath); commandTimeout > 0 {
+				cfg.Timeout = commandTimeout
+			}
+		}
+		cfg.ApplyCommandRateLimit(commandPath)
+		enableTraceExport(cmd)
 
 		// Resolve worker: only read --worker flag for commands that use workers
 		// (annotated with "uses-worker": "true"). Config commands define their own
@@ -130,7 +199,7 @@ For more information, visit: https://github.com/MAIF/izanami`,
 		workers, defaultWorker := resolveWorkerFromProfile(activeProfile)
 		rw, err := izanami.ResolveWorker(workerFlag, workers, defaultWorker, func(format string, a ...interface{}) {
 			if cfg.Verbose {
-				fmt.Fprintf(cmd.OutOrStderr(), format, a...)
+				fmt.Fprintf(statusOut(cmd), format, a...)
 			}
 		})
 		if err != nil {
@@ -155,11 +224,11 @@ For more information, visit: https://github.com/MAIF/izanami`,
 			logAuthenticationMode(cmd, cfg)
 			// Also log active profile if any
 			if profileName != "" {
-				fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using profile: %s (from --profile flag)\n", profileName)
+				fmt.Fprintf(statusOut(cmd), "[verbose] Using profile: %s (from --profile flag)\n", profileName)
 			} else {
 				activeProfile, err := izanami.GetActiveProfileName()
 				if err == nil && activeProfile != "" {
-					fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Using profile: %s (active profile)\n", activeProfile)
+					fmt.Fprintf(statusOut(cmd), "[verbose] Using profile: %s (active profile)\n", activeProfile)
 				}
 			}
 		}
@@ -168,10 +237,37 @@ For more information, visit: https://github.com/MAIF/izanami`,
 	},
 }
 
+// statusOut is where commands write status lines, progress, warnings and hints:
+// statusOut(cmd), or nowhere with --quiet. Data goes to cmd.OutOrStdout()
+// and errors to processStderr, so --quiet keeps both.
+func statusOut(cmd *cobra.Command) io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	return cmd.OutOrStderr()
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
+// The process exit code follows the contract documented in exit_codes.go.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	registerResourceFlagCompletions(rootCmd)
+	stopWatchingInterrupts := watchInterrupts(processStderr)
+	// Errors are printed below, in the --error-format format
+	rootCmd.SilenceErrors = true
+	err := rootCmd.Execute()
+	stopWatchingInterrupts()
+	if err != nil && interrupted.Load() {
+		if errorFormat == ErrorFormatText {
+			fmt.Fprintln(processStderr, "Interrupted: pending requests were canceled, changes already applied are kept")
+		}
+		err = withExitCode(ExitInterrupted, err)
+	}
+	finishTracing(err)
+	saveRecording()
+	closeLogFile()
+	if err != nil {
+		printCommandError(os.Stdout, processStderr, err)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -183,16 +279,74 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&project, "project", "", "Default project (env: IZ_PROJECT)")
 	rootCmd.PersistentFlags().StringVar(&contextPath, "context", "", "Default context path (env: IZ_CONTEXT)")
 	rootCmd.PersistentFlags().IntVar(&timeout, "timeout", 0, "Request timeout in seconds (default: 30)")
+	rootCmd.PersistentFlags().IntVar(&retries, "retries", 0, "Retries for failed idempotent requests, 0 to disable (default: 3)")
+	rootCmd.PersistentFlags().IntVar(&retryMaxWait, "retry-max-wait", 0, "Maximum wait between retries in seconds (default: 30)")
+	rootCmd.PersistentFlags().IntVar(&cacheTTL, "cache-ttl", 0, "Serve list/get results from the local cache for this many seconds (default: 0, disabled)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Bypass the local response cache")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
-	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress all output (exit code only)")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: json or table")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print data and errors, without progress, status or hints")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to every confirmation prompt")
+	rootCmd.PersistentFlags().BoolVar(&exactID, "exact-id", false, "Use feature, project, key and webhook arguments as given, without looking them up by name or ID")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: json, ndjson or table")
+	rootCmd.PersistentFlags().StringSliceVar(&tableColumns, "columns", nil, "Table columns to show, in order (e.g. id,name,enabled)")
+	rootCmd.PersistentFlags().BoolVar(&tableWide, "wide", false, "Show every table column, without wrapping")
+	rootCmd.PersistentFlags().StringVar(&tableSortBy, "sort-by", "", "Sort table rows by this column")
+	rootCmd.PersistentFlags().BoolVar(&tableReverse, "reverse", false, "Reverse the order of table rows")
 	rootCmd.PersistentFlags().BoolVar(&compactJSON, "compact", false, "Output compact JSON (no pretty-printing)")
+	rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", defaultErrorFormat(), "Failure output: text, json (JSON object on stderr) or json-stdout (env: IZ_ERROR_FORMAT)")
 	rootCmd.PersistentFlags().BoolVarP(&insecureSkipVerify, "insecure", "k", false, "Skip TLS certificate verification (insecure)")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "Same as --insecure")
+	rootCmd.PersistentFlags().StringVar(&caCertFile, "ca-cert", "", "PEM file of CA certificates to trust, for private CAs (env: IZ_CA_CERT)")
+	rootCmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", "", "PEM client certificate for mutual TLS (env: IZ_CLIENT_CERT)")
+	rootCmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", "", "PEM private key of --client-cert (env: IZ_CLIENT_KEY)")
+	rootCmd.PersistentFlags().StringArrayVarP(&httpHeaderFlags, "header", "H", nil, "Extra HTTP header sent with every request, as \"Name: value\" (repeatable)")
 
 	// Register dynamic flag completions (must be after flags are defined)
 	RegisterFlagCompletions()
 }
 
+// globalFlagValues returns the global flags, with environment fallbacks, to merge over a loaded config
+func globalFlagValues(cmd *cobra.Command) izanami.FlagValues {
+	return izanami.FlagValues{
+		LeaderURL:          getValueWithEnvFallback(leaderURL, "IZ_LEADER_URL"),
+		ClientID:           getValueWithEnvFallback("", "IZ_CLIENT_ID"),
+		ClientSecret:       getValueWithEnvFallback("", "IZ_CLIENT_SECRET"),
+		Tenant:             getValueWithEnvFallback(tenant, "IZ_TENANT"),
+		Project:            getValueWithEnvFallback(project, "IZ_PROJECT"),
+		Context:            getValueWithEnvFallback(contextPath, "IZ_CONTEXT"),
+		Timeout:            timeout,
+		Retries:            retriesFlag(cmd),
+		RetryMaxWait:       retryMaxWait,
+		CacheTTL:           cacheTTL,
+		NoCache:            noCache || !isCacheableCommand(cmd),
+		Verbose:            verbose,
+		InsecureSkipVerify: insecureSkipVerify,
+		CACertFile:         getValueWithEnvFallback(caCertFile, "IZ_CA_CERT"),
+		ClientCertFile:     getValueWithEnvFallback(clientCertFile, "IZ_CLIENT_CERT"),
+		ClientKeyFile:      getValueWithEnvFallback(clientKeyFile, "IZ_CLIENT_KEY"),
+		HTTPHeaders:        httpHeaders,
+	}
+}
+
+// skipsConfigLoading reports whether a command runs without loading the Izanami config
+func skipsConfigLoading(cmd *cobra.Command) bool {
+	skipCommands := []string{"completion", "version", "help", "login", "logout", "sessions", "config", "profiles", "reset", "cache", "snapshots", "render-template", "eval", "self-update", "mock-server"}
+	for _, skip := range skipCommands {
+		if cmd.Name() == skip || cmd.Parent() != nil && cmd.Parent().Name() == skip {
+			return true
+		}
+	}
+	return false
+}
+
+// retriesFlag returns the --retries value when it was explicitly set, nil otherwise
+func retriesFlag(cmd *cobra.Command) *int {
+	if !cmd.Flags().Changed("retries") {
+		return nil
+	}
+	return &retries
+}
+
 // GetConfig returns the global configuration
 func GetConfig() *izanami.ResolvedConfig {
 	return cfg
@@ -233,7 +387,25 @@ var configFields = []configFieldInfo{
 	{key: "worker-url", getValue: func(c *izanami.ResolvedConfig) string { return c.WorkerURL }},
 	{key: "worker-name", getValue: func(c *izanami.ResolvedConfig) string { return c.WorkerName }},
 	{key: "timeout", flagName: "timeout", getValue: func(c *izanami.ResolvedConfig) string { return strconv.Itoa(c.Timeout) }},
+	{key: "retries", flagName: "retries", getValue: func(c *izanami.ResolvedConfig) string { return strconv.Itoa(c.Retries) }},
+	{key: "retry-max-wait", flagName: "retry-max-wait", getValue: func(c *izanami.ResolvedConfig) string { return strconv.Itoa(c.RetryMaxWait) }},
+	{key: "cache-ttl", flagName: "cache-ttl", getValue: func(c *izanami.ResolvedConfig) string { return strconv.Itoa(c.CacheTTL) }},
+	{key: "max-requests-per-second", getValue: func(c *izanami.ResolvedConfig) string {
+		return strconv.FormatFloat(c.MaxRequestsPerSecond, 'f', -1, 64)
+	}},
+	{key: "max-concurrency", getValue: func(c *izanami.ResolvedConfig) string { return strconv.Itoa(c.MaxConcurrency) }},
 	{key: "insecure", flagName: "insecure", getValue: func(c *izanami.ResolvedConfig) string { return strconv.FormatBool(c.InsecureSkipVerify) }},
+	{key: "ca-cert", flagName: "ca-cert", envVar: "IZ_CA_CERT", getValue: func(c *izanami.ResolvedConfig) string { return c.CACertFile }},
+	{key: "client-cert", flagName: "client-cert", envVar: "IZ_CLIENT_CERT", getValue: func(c *izanami.ResolvedConfig) string { return c.ClientCertFile }},
+	{key: "client-key", flagName: "client-key", envVar: "IZ_CLIENT_KEY", getValue: func(c *izanami.ResolvedConfig) string { return c.ClientKeyFile }},
+	{key: "proxy-url", getValue: func(c *izanami.ResolvedConfig) string {
+		if c.ProxyURL == "" {
+			return ""
+		}
+		return izanami.RedactProxyURL(c.ProxyURL)
+	}},
+	// Header values may hold gateway credentials: only their names are shown
+	{key: "http-headers", flagName: "header", getValue: func(c *izanami.ResolvedConfig) string { return headerNames(c.HTTPHeaders) }},
 }
 
 // logEffectiveConfig prints each effective config value with its source in verbose mode.
@@ -278,7 +450,7 @@ func logEffectiveConfig(cmd *cobra.Command, cfg *izanami.ResolvedConfig) {
 			displayValue = izanami.RedactedValue
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Config: %s=%s (source: %s)\n", field.key, displayValue, source)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Config: %s=%s (source: %s)\n", field.key, displayValue, source)
 	}
 }
 
@@ -294,13 +466,21 @@ func determineConfigSource(cmd *cobra.Command, field configFieldInfo, cfg *izana
 	if field.flagName != "" && cmd.Flags().Changed(field.flagName) {
 		return "flag"
 	}
+	if field.key == "insecure" && cmd.Flags().Changed("insecure-skip-verify") {
+		return "flag"
+	}
 
 	// 2. Env var set?
 	if field.envVar != "" && os.Getenv(field.envVar) != "" {
 		return "env"
 	}
 
-	// 3. Session? (leader-url and jwt-token can come from session)
+	// 3. Project-local .iz.yaml?
+	if cfg.LocalConfig.Value(field.key) != "" {
+		return "local " + cfg.LocalConfig.Path
+	}
+
+	// 4. Session? (leader-url and jwt-token can come from session)
 	if session != nil {
 		switch field.key {
 		case "leader-url":
@@ -310,7 +490,7 @@ func determineConfigSource(cmd *cobra.Command, field configFieldInfo, cfg *izana
 		}
 	}
 
-	// 4. Profile?
+	// 5. Profile?
 	if profile != nil {
 		profileValue := getProfileFieldValue(profile, field.key)
 		if profileValue != "" && profileValue != "false" {
@@ -318,14 +498,14 @@ func determineConfigSource(cmd *cobra.Command, field configFieldInfo, cfg *izana
 		}
 	}
 
-	// 5. Global config keys: use GetConfigValue to distinguish file/env/default
-	if izanami.GlobalConfigKeys[field.key] {
+	// 6. Global config keys: use GetConfigValue to distinguish file/env/default
+	if izanami.GlobalConfigKeys[field.key] || field.key == izanami.ConfigKeyHTTPHeaders {
 		if cv, err := izanami.GetConfigValue(field.key); err == nil {
 			return cv.Source
 		}
 	}
 
-	// 6. Fallback
+	// 7. Fallback
 	return "default"
 }
 
@@ -359,11 +539,27 @@ func getProfileFieldValue(profile *izanami.Profile, key string) string {
 		return profile.Context
 	case "insecure":
 		return strconv.FormatBool(profile.InsecureSkipVerify)
+	case "ca-cert":
+		return profile.CACertFile
+	case "client-cert":
+		return profile.ClientCertFile
+	case "client-key":
+		return profile.ClientKeyFile
 	default:
 		return ""
 	}
 }
 
+// headerNames returns the sorted names of custom headers, comma-separated
+func headerNames(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
 // logEnvironmentVariables prints all IZ_* environment variables in verbose mode,
 // redacting values for sensitive variables.
 func logEnvironmentVariables(cmd *cobra.Command) {
@@ -375,7 +571,7 @@ func logEnvironmentVariables(cmd *cobra.Command) {
 	}
 
 	if len(izVars) == 0 {
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Environment: no IZ_* variables set\n")
+		fmt.Fprintf(statusOut(cmd), "[verbose] Environment: no IZ_* variables set\n")
 		return
 	}
 
@@ -385,7 +581,7 @@ func logEnvironmentVariables(cmd *cobra.Command) {
 		if sensitiveEnvVars[name] {
 			value = izanami.RedactedValue
 		}
-		fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Environment: %s=%s\n", name, value)
+		fmt.Fprintf(statusOut(cmd), "[verbose] Environment: %s=%s\n", name, value)
 	}
 }
 
@@ -409,7 +605,7 @@ func logAuthenticationMode(cmd *cobra.Command, cfg *izanami.ResolvedConfig) {
 		clientAuth = "none"
 	}
 
-	fmt.Fprintf(cmd.OutOrStderr(), "[verbose] Authentication - Admin operations: %s, Feature checks: %s\n", adminAuth, clientAuth)
+	fmt.Fprintf(statusOut(cmd), "[verbose] Authentication - Admin operations: %s, Feature checks: %s\n", adminAuth, clientAuth)
 }
 
 // resolveWorkerFromProfile extracts workers and defaultWorker from the active profile.
@@ -429,6 +625,20 @@ func getValueWithEnvFallback(flagValue, envVar string) string {
 	return os.Getenv(envVar)
 }
 
+// terminalWidth returns the width of the terminal w writes to, 0 when it is
+// not a terminal (tables are then never wrapped)
+func terminalWidth(w io.Writer) int {
+	file, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 // configureColorOutput configures color output based on the color setting
 func configureColorOutput(colorSetting string) {
 	switch colorSetting {
@@ -438,8 +648,12 @@ func configureColorOutput(colorSetting string) {
 	case "always":
 		// Force colors even if not a TTY
 		color.NoColor = false
+		utils.EnableVirtualTerminal(os.Stdout)
+		utils.EnableVirtualTerminal(os.Stderr)
 	case "auto", "":
-		// Auto-detect: enable colors only if stdout is a terminal
-		color.NoColor = !term.IsTerminal(int(os.Stdout.Fd()))
+		// Auto-detect: enable colors only if stdout is a terminal that understands
+		// ANSI sequences (legacy Windows consoles need virtual terminal mode enabled)
+		color.NoColor = !term.IsTerminal(int(os.Stdout.Fd())) || !utils.EnableVirtualTerminal(os.Stdout)
+		utils.EnableVirtualTerminal(os.Stderr)
 	}
 }
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	scheduleAddAt      string
+	scheduleAddCommand string
+	scheduleListAll    bool
+	scheduleRunDryRun  bool
+)
+
+// runScheduledCommand runs the iz binary with the arguments of a job and
+// returns its combined output and exit code. Replaced in tests.
+var runScheduledCommand = func(ctx context.Context, args []string) ([]byte, int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to locate the iz executable: %w", err)
+	}
+	out, err := exec.CommandContext(ctx, executable, args...).CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return out, exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return out, 0, err
+	}
+	return out, 0, nil
+}
+
+// scheduleCmd groups the commands managing the local queue of scheduled changes
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run iz commands at a given time, e.g. to flip flags",
+	Long: `Queue iz commands to run at a given time, without server-side scheduling.
+
+Jobs are stored in the schedule directory of the config dir. 'iz schedule run'
+executes the jobs that are due; call it periodically from cron or a CI
+scheduler. A job runs with the profile active when it was added (or the one
+given with --profile), and with --yes, as nobody is there to confirm.
+
+Examples:
+  # Turn a flag on at 9:00 on July 1st (local time)
+  iz schedule add --at 2024-07-01T09:00 --cmd 'admin features toggle launch-banner --enable --project shop'
+
+  # Run the due jobs every minute
+  * * * * * iz schedule run
+
+  iz schedule list
+  iz schedule cancel 20240701-0700-features-toggle`,
+}
+
+// scheduleAddCmd queues a command
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Queue an iz command to run at a given time",
+	Long: `Queue an iz command to run at a given time.
+
+--at accepts RFC 3339 dates or YYYY-MM-DDTHH:MM in local time. --cmd is the
+command line without the leading "iz", quoted as in a shell. It is checked
+against the known commands, but its flags are only validated when it runs.
+
+Examples:
+  iz schedule add --at 2024-07-01T09:00 --cmd 'admin features toggle launch-banner --enable --project shop'
+  iz schedule add --at 2024-07-15T18:00:00+02:00 --cmd 'admin features toggle launch-banner --disable --project shop' --profile prod`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if scheduleAddAt == "" || strings.TrimSpace(scheduleAddCommand) == "" {
+			return fmt.Errorf("--at and --cmd are required")
+		}
+		now := time.Now()
+		at, err := parseScheduleTime(scheduleAddAt, time.Local)
+		if err != nil {
+			return err
+		}
+		if !at.After(now) {
+			return fmt.Errorf("%s is in the past", at.Format(time.RFC3339))
+		}
+		jobArgs, err := splitCommandLine(scheduleAddCommand)
+		if err != nil {
+			return err
+		}
+		if len(jobArgs) > 0 && jobArgs[0] == "iz" {
+			jobArgs = jobArgs[1:]
+		}
+		name, err := scheduledCommandName(cmd.Root(), jobArgs)
+		if err != nil {
+			return err
+		}
+
+		profile := profileName
+		if profile == "" {
+			profile, _ = izanami.GetActiveProfileName()
+		}
+		job, err := izanami.AddScheduledJob(at, name, jobArgs, profile, now)
+		if err != nil {
+			return err
+		}
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), job, output.JSON)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Scheduled %s for %s: %s\n", job.ID, at.Format(time.RFC3339), job.Command())
+		return nil
+	},
+}
+
+// scheduleListCmd lists the queued jobs
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the scheduled jobs, by due time",
+	Long: `List the pending jobs, by due time. --all also lists the jobs that ran, failed
+or were cancelled.
+
+Examples:
+  iz schedule list
+  iz schedule list --all -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jobs, err := izanami.ListScheduledJobs()
+		if err != nil {
+			return err
+		}
+		if outputFormat == "json" {
+			if !scheduleListAll {
+				jobs = slices.DeleteFunc(jobs, func(j *izanami.ScheduledJob) bool { return j.Status != izanami.JobPending })
+			}
+			return output.PrintTo(cmd.OutOrStdout(), jobs, output.JSON)
+		}
+		summaries := []izanami.ScheduledJobSummary{}
+		for _, job := range jobs {
+			if scheduleListAll || job.Status == izanami.JobPending {
+				summaries = append(summaries, job.Summary())
+			}
+		}
+		if len(summaries) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No scheduled jobs")
+			return nil
+		}
+		return output.PrintTo(cmd.OutOrStdout(), summaries, output.Format(outputFormat))
+	},
+}
+
+// scheduleCancelCmd cancels pending jobs
+var scheduleCancelCmd = &cobra.Command{
+	Use:   "cancel <job-id>...",
+	Short: "Cancel pending jobs",
+	Long: `Cancel pending jobs. Cancelled jobs are kept, and listed with --all.
+
+Examples:
+  iz schedule cancel 20240701-0700-features-toggle`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, id := range args {
+			job, err := izanami.LoadScheduledJob(id)
+			if err != nil {
+				return notFoundError("%s", err)
+			}
+			if job.Status != izanami.JobPending {
+				return fmt.Errorf("job %s is %s, only pending jobs can be cancelled", id, job.Status)
+			}
+			job.Status = izanami.JobCancelled
+			if err := izanami.SaveScheduledJob(job); err != nil {
+				return err
+			}
+			fmt.Fprintf(statusOut(cmd), "Cancelled %s\n", id)
+		}
+		return nil
+	},
+}
+
+// scheduleRunCmd runs the due jobs
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the jobs that are due",
+	Long: `Run the pending jobs that are due, oldest first, each as a separate iz
+process. Meant to be called periodically, e.g. every minute from cron; jobs
+missed while it was not running are run on the next call.
+
+Each job is marked done or failed with its exit code and the end of its output
+(see 'iz schedule list --all -o json'). The command fails when a job fails, so
+that cron reports it, and refuses to run while another run is in progress.
+
+Examples:
+  # crontab entry
+  * * * * * iz schedule run >> ~/iz-schedule.log 2>&1
+
+  # Show the due jobs without running them
+  iz schedule run --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if scheduleRunDryRun {
+			due, err := izanami.DueScheduledJobs(time.Now())
+			if err != nil {
+				return err
+			}
+			if len(due) == 0 {
+				fmt.Fprintln(statusOut(cmd), "No jobs due")
+			}
+			for _, job := range due {
+				fmt.Fprintf(out, "Would run %s: %s\n", job.ID, job.Command())
+			}
+			return nil
+		}
+
+		unlock, err := izanami.LockScheduledJobs()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		due, err := izanami.DueScheduledJobs(time.Now())
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No jobs due")
+			return nil
+		}
+
+		failed := 0
+		for _, job := range due {
+			job.Status = izanami.JobRunning
+			if err := izanami.SaveScheduledJob(job); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Running %s: %s\n", job.ID, job.Command())
+			result, exitCode, err := runScheduledCommand(commandContext(), scheduledJobArgs(job))
+			if err != nil {
+				result, exitCode = append(result, []byte(err.Error())...), 1
+			}
+			job.Finish(time.Now(), result, exitCode)
+			if err := izanami.SaveScheduledJob(job); err != nil {
+				return err
+			}
+			if job.Status == izanami.JobFailed {
+				failed++
+				fmt.Fprintf(out, "Job %s failed with exit code %d: %s\n", job.ID, exitCode, lastLine(job.Output))
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d scheduled job(s) failed", failed, len(due))
+		}
+		fmt.Fprintf(out, "%d job(s) run\n", len(due))
+		return nil
+	},
+}
+
+// scheduledCommandName checks that args designate an iz command that can be
+// scheduled and returns its name for job IDs, e.g. "features-toggle"
+func scheduledCommandName(root *cobra.Command, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("--cmd is empty")
+	}
+	found, _, err := root.Find(args)
+	if err != nil || found == root || !found.Runnable() {
+		return "", fmt.Errorf("unknown command %q (see 'iz commands')", strings.Join(args, " "))
+	}
+	if found.HasParent() && (found == scheduleCmd || found.Parent() == scheduleCmd) {
+		return "", fmt.Errorf("schedule commands cannot be scheduled")
+	}
+	path := strings.TrimPrefix(found.CommandPath(), root.Name()+" ")
+	return strings.ReplaceAll(strings.TrimPrefix(path, "admin "), " ", "-"), nil
+}
+
+// scheduledJobArgs returns the arguments a job runs with: its own, with its
+// profile unless it selects one, and --yes
+func scheduledJobArgs(job *izanami.ScheduledJob) []string {
+	args := slices.Clone(job.Args)
+	if job.Profile != "" && !slices.ContainsFunc(args, func(a string) bool {
+		return a == "-p" || a == "--profile" || strings.HasPrefix(a, "--profile=")
+	}) {
+		args = append(args, "--profile", job.Profile)
+	}
+	return append(args, "--yes")
+}
+
+// splitCommandLine splits a command line into arguments as a POSIX shell
+// would, honoring single quotes, double quotes and backslash escapes
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", line)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// lastLine returns the last line of a text
+func lastLine(text string) string {
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+		return text[i+1:]
+	}
+	return text
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleCancelCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+
+	scheduleAddCmd.Flags().StringVar(&scheduleAddAt, "at", "", "When to run the command (RFC 3339 or YYYY-MM-DDTHH:MM, local time)")
+	scheduleAddCmd.Flags().StringVar(&scheduleAddCommand, "cmd", "", "Command line to run, without the leading 'iz'")
+	scheduleListCmd.Flags().BoolVar(&scheduleListAll, "all", false, "Also list the jobs that ran, failed or were cancelled")
+	scheduleRunCmd.Flags().BoolVar(&scheduleRunDryRun, "dry-run", false, "Show the due jobs without running them")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	args, err := splitCommandLine(`admin features toggle 'launch banner' --enable --data "{\"a\": 1}" a\ b`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin", "features", "toggle", "launch banner", "--enable", "--data", `{"a": 1}`, "a b"}, args)
+
+	args, err = splitCommandLine(`  x  ''  `)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", ""}, args)
+
+	_, err = splitCommandLine(`toggle 'launch`)
+	assert.ErrorContains(t, err, "unterminated")
+}
+
+func TestScheduledCommandName(t *testing.T) {
+	name, err := scheduledCommandName(rootCmd, []string{"admin", "features", "toggle", "banner", "--enable"})
+	require.NoError(t, err)
+	assert.Equal(t, "features-toggle", name)
+
+	_, err = scheduledCommandName(rootCmd, []string{"admin", "featurez"})
+	assert.ErrorContains(t, err, "unknown command")
+	_, err = scheduledCommandName(rootCmd, []string{"schedule", "run"})
+	assert.ErrorContains(t, err, "cannot be scheduled")
+}
+
+func TestScheduledJobArgs(t *testing.T) {
+	job := &izanami.ScheduledJob{Args: []string{"admin", "features", "toggle", "banner"}, Profile: "prod"}
+	assert.Equal(t, []string{"admin", "features", "toggle", "banner", "--profile", "prod", "--yes"}, scheduledJobArgs(job))
+
+	job.Args = []string{"admin", "features", "toggle", "banner", "-p", "sandbox"}
+	assert.Equal(t, []string{"admin", "features", "toggle", "banner", "-p", "sandbox", "--yes"}, scheduledJobArgs(job))
+}
+
+func TestSchedule_AddRunCancel(t *testing.T) {
+	originalConfigDir := izanami.GetConfigDir()
+	configDir := t.TempDir()
+	izanami.SetGetConfigDirFunc(func() string { return configDir })
+
+	var ran [][]string
+	originalRunner, origOutput := runScheduledCommand, outputFormat
+	runScheduledCommand = func(ctx context.Context, args []string) ([]byte, int, error) {
+		ran = append(ran, args)
+		if args[3] == "broken" {
+			return []byte("Error: feature not found\n"), 4, nil
+		}
+		return []byte("Feature enabled\n"), 0, nil
+	}
+	t.Cleanup(func() {
+		runScheduledCommand, outputFormat = originalRunner, origOutput
+		scheduleAddAt, scheduleAddCommand, scheduleListAll, scheduleRunDryRun = "", "", false, false
+		izanami.SetGetConfigDirFunc(func() string { return originalConfigDir })
+		for _, c := range []*cobra.Command{scheduleAddCmd, scheduleListCmd, scheduleCancelCmd, scheduleRunCmd} {
+			c.SetOut(nil)
+		}
+	})
+	outputFormat = "table"
+
+	var buf bytes.Buffer
+	for _, c := range []*cobra.Command{scheduleAddCmd, scheduleListCmd, scheduleCancelCmd, scheduleRunCmd} {
+		c.SetOut(&buf)
+	}
+
+	scheduleAddAt, scheduleAddCommand = "2000-01-01T09:00", "admin features toggle banner --enable"
+	assert.ErrorContains(t, scheduleAddCmd.RunE(scheduleAddCmd, nil), "is in the past")
+
+	// Jobs are added in the future, then made due
+	scheduleAddAt = time.Now().Add(time.Hour).Format(time.RFC3339)
+	for _, line := range []string{"iz admin features toggle banner --enable", "admin features toggle broken --enable", "admin features toggle search --disable"} {
+		scheduleAddCommand = line
+		require.NoError(t, scheduleAddCmd.RunE(scheduleAddCmd, nil))
+	}
+	assert.Contains(t, buf.String(), ": iz admin features toggle banner --enable\n")
+
+	jobs, err := izanami.ListScheduledJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	require.NoError(t, scheduleCancelCmd.RunE(scheduleCancelCmd, []string{jobs[2].ID}))
+	assert.ErrorContains(t, scheduleCancelCmd.RunE(scheduleCancelCmd, []string{jobs[2].ID}), "only pending jobs can be cancelled")
+	for _, job := range jobs[:2] {
+		job.At = time.Now().Add(-time.Minute).Format(time.RFC3339)
+		require.NoError(t, izanami.SaveScheduledJob(job))
+	}
+
+	buf.Reset()
+	scheduleRunDryRun = true
+	require.NoError(t, scheduleRunCmd.RunE(scheduleRunCmd, nil))
+	assert.Contains(t, buf.String(), "Would run "+jobs[0].ID)
+	assert.Empty(t, ran, "a dry run runs nothing")
+
+	scheduleRunDryRun = false
+	err = scheduleRunCmd.RunE(scheduleRunCmd, nil)
+	assert.EqualError(t, err, "1 of 2 scheduled job(s) failed")
+	require.Len(t, ran, 2)
+	assert.Equal(t, "--yes", ran[0][len(ran[0])-1])
+	assert.Contains(t, buf.String(), "failed with exit code 4: Error: feature not found")
+
+	jobs, err = izanami.ListScheduledJobs()
+	require.NoError(t, err)
+	assert.Equal(t, izanami.JobDone, jobs[0].Status)
+	assert.Equal(t, "Feature enabled", jobs[0].Output)
+	assert.Equal(t, izanami.JobFailed, jobs[1].Status)
+	assert.Equal(t, izanami.JobCancelled, jobs[2].Status)
+
+	// Jobs that ran are not run again, and are listed with --all only
+	buf.Reset()
+	require.NoError(t, scheduleRunCmd.RunE(scheduleRunCmd, nil))
+	assert.Len(t, ran, 2)
+	assert.Contains(t, buf.String(), "No jobs due")
+
+	buf.Reset()
+	require.NoError(t, scheduleListCmd.RunE(scheduleListCmd, nil))
+	assert.Contains(t, buf.String(), "No scheduled jobs")
+	buf.Reset()
+	scheduleListAll = true
+	require.NoError(t, scheduleListCmd.RunE(scheduleListCmd, nil))
+	assert.Contains(t, buf.String(), "cancelled")
+	assert.Contains(t, buf.String(), "failed")
+}
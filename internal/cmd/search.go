@@ -1,7 +1,8 @@
 package cmd
 
 import (
-	"context"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
@@ -10,15 +11,35 @@ import (
 
 var (
 	searchFilters []string
+	searchTypes   []string
+	searchOpen    bool
 )
 
+// searchTypeFilters maps --type values to the server search filters
+var searchTypeFilters = map[string]string{
+	"feature":        "feature",
+	"project":        "project",
+	"key":            "key",
+	"tag":            "tag",
+	"script":         "script",
+	"webhook":        "webhook",
+	"global-context": "global_context",
+	"local-context":  "local_context",
+}
+
 var adminSearchCmd = &cobra.Command{
 	Use:         "search <query>",
 	Short:       "Global search across resources",
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/search"},
 	Long: `Search across all resources in Izanami (or within a specific tenant).
 
-Available filters: PROJECT, FEATURE, KEY, TAG, SCRIPT, GLOBAL_CONTEXT, LOCAL_CONTEXT, WEBHOOK
+Use --type to restrict the search to resource types: feature, project, key,
+tag, script, webhook, global-context, local-context. --filter accepts the raw
+server filters (PROJECT, FEATURE, KEY, TAG, SCRIPT, GLOBAL_CONTEXT,
+LOCAL_CONTEXT, WEBHOOK).
+
+With --open, only the web UI link of the top result is printed, ready to be
+opened in a browser.
 
 Examples:
   # Search globally
@@ -27,20 +48,28 @@ Examples:
   # Search within a tenant
   iz admin search "auth" --tenant my-tenant
 
-  # Search with filters
-  iz admin search "user" --filter FEATURE,PROJECT`,
+  # Search features and projects only
+  iz admin search "user" --type feature,project
+
+  # Open the top result in a browser
+  xdg-open "$(iz admin search checkout --type feature --open)"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		filters, err := searchRequestFilters(searchFilters, searchTypes)
+		if err != nil {
+			return err
+		}
+
 		client, err := izanami.NewAdminClient(cfg)
 		if err != nil {
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper for raw JSON
-		if outputFormat == "json" {
-			raw, err := izanami.Search(client, ctx, cfg.Tenant, args[0], searchFilters, izanami.Identity)
+		if outputFormat == "json" && !searchOpen {
+			raw, err := izanami.Search(client, ctx, cfg.Tenant, args[0], filters, izanami.Identity)
 			if err != nil {
 				return err
 			}
@@ -48,18 +77,45 @@ Examples:
 		}
 
 		// For table output, use ParseSearchResults mapper and convert to table view
-		results, err := izanami.Search(client, ctx, cfg.Tenant, args[0], searchFilters, izanami.ParseSearchResults)
+		results, err := izanami.Search(client, ctx, cfg.Tenant, args[0], filters, izanami.ParseSearchResults)
 		if err != nil {
 			return err
 		}
 
+		if searchOpen {
+			if len(results) == 0 {
+				return fmt.Errorf("no results for %q", args[0])
+			}
+			link, err := results[0].WebUIURL(cfg.LeaderURL, cfg.Tenant)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), link)
+			return nil
+		}
+
 		tableViews := izanami.SearchResultsToTableView(results)
 		return output.PrintTo(cmd.OutOrStdout(), tableViews, output.Format(outputFormat))
 	},
 }
 
+// searchRequestFilters combines --filter values with the server filters of --type values
+func searchRequestFilters(filters, types []string) ([]string, error) {
+	combined := append([]string{}, filters...)
+	for _, t := range types {
+		filter, ok := searchTypeFilters[strings.ToLower(t)]
+		if !ok {
+			return nil, fmt.Errorf("invalid --type %q (expected feature, project, key, tag, script, webhook, global-context or local-context)", t)
+		}
+		combined = append(combined, filter)
+	}
+	return combined, nil
+}
+
 func init() {
 	// Search
 	adminCmd.AddCommand(adminSearchCmd)
 	adminSearchCmd.Flags().StringSliceVar(&searchFilters, "filter", []string{}, "Filter by resource type (PROJECT, FEATURE, KEY, TAG, SCRIPT, GLOBAL_CONTEXT, LOCAL_CONTEXT, WEBHOOK)")
+	adminSearchCmd.Flags().StringSliceVar(&searchTypes, "type", []string{}, "Restrict to resource types (feature, project, key, tag, script, webhook, global-context, local-context)")
+	adminSearchCmd.Flags().BoolVar(&searchOpen, "open", false, "Print the web UI link of the top result instead of the results")
 }
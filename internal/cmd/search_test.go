@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminSearchTest(t *testing.T, body string) *bytes.Buffer {
+	t.Helper()
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/search", r.URL.Path)
+		assert.Equal(t, "checkout", r.URL.Query().Get("query"))
+		assert.Equal(t, []string{"feature", "global_context"}, r.URL.Query()["filter"])
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	})
+
+	t.Cleanup(func() {
+		searchTypes, searchOpen = []string{}, false
+		adminSearchCmd.SetOut(nil)
+	})
+	outputFormat = "table"
+	searchTypes = []string{"feature", "global-context"}
+
+	var buf bytes.Buffer
+	adminSearchCmd.SetOut(&buf)
+	return &buf
+}
+
+func TestAdminSearchCmd_Types(t *testing.T) {
+	buf := setupAdminSearchTest(t, `[{"type":"feature","name":"checkout-v2","path":[{"type":"project","name":"shop"}]}]`)
+
+	require.NoError(t, adminSearchCmd.RunE(adminSearchCmd, []string{"checkout"}))
+	assert.Contains(t, buf.String(), "checkout-v2")
+	assert.Contains(t, buf.String(), "shop")
+
+	searchTypes = []string{"user"}
+	assert.ErrorContains(t, adminSearchCmd.RunE(adminSearchCmd, []string{"checkout"}), `invalid --type "user"`)
+}
+
+func TestAdminSearchCmd_Open(t *testing.T) {
+	buf := setupAdminSearchTest(t, `[{"type":"feature","name":"checkout-v2","path":[{"type":"project","name":"shop"}]},
+		{"type":"feature","name":"checkout-v1","path":[{"type":"project","name":"shop"}]}]`)
+	searchOpen = true
+
+	require.NoError(t, adminSearchCmd.RunE(adminSearchCmd, []string{"checkout"}))
+	assert.Equal(t, cfg.LeaderURL+"/tenants/acme/projects/shop?filter=checkout-v2\n", buf.String())
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/update"
+)
+
+var selfUpdateForce bool
+
+// selfUpdateCmd replaces the running binary with the latest release
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update iz to the latest release",
+	Long: `Download the latest iz release from GitHub and replace the running binary.
+
+The release archive for this platform is verified against the SHA-256 listed
+in the release checksums.txt before anything is replaced; a download or
+verification failure leaves the current binary untouched. Replacing a binary
+in a system directory may require running the command with sudo.
+
+Set 'disable-self-update: true' in config.yaml (iz config set
+disable-self-update true) when iz is installed by a package manager, which
+should do the updates instead.
+
+Development builds are not replaced unless --force is given.
+
+Examples:
+  # Update to the latest release
+  iz self-update
+
+  # Only check for a new release
+  iz version --check-update`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loaded, err := loadOptionalConfig(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if loaded.DisableSelfUpdate {
+			return fmt.Errorf("self-update is disabled by '%s' in the config; update iz the way it was installed", izanami.ConfigKeyDisableSelfUpdate)
+		}
+		if update.IsDevelopmentBuild(Version) && !selfUpdateForce {
+			return fmt.Errorf("iz %s is a development build; use --force to replace it with the latest release", Version)
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("cannot locate the iz binary: %w", err)
+		}
+		if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+			executable = resolved
+		}
+
+		client := updateHTTPClient(loaded)
+		ctx := commandContext()
+		release, err := update.LatestRelease(ctx, client)
+		if err != nil {
+			return err
+		}
+		if !update.IsDevelopmentBuild(Version) && update.CompareVersions(release.Version(), Version) <= 0 && !selfUpdateForce {
+			fmt.Fprintf(statusOut(cmd), "iz %s is up to date.\n", Version)
+			return nil
+		}
+
+		fmt.Fprintf(statusOut(cmd), "Downloading iz %s...\n", release.Version())
+		if err := update.Apply(ctx, client, release, executable); err != nil {
+			return fmt.Errorf("update failed, %s was left unchanged: %w", executable, err)
+		}
+		fmt.Fprintf(statusOut(cmd), "Updated iz %s -> %s (%s)\n", Version, release.Version(), executable)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Install the latest release even when up to date or running a development build")
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var serverInfoRefresh bool
+
+// CapabilityStatus is a server capability with whether the server has it
+type CapabilityStatus struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinVersion  string `json:"minVersion"`
+	// Available is nil when the server does not report its version
+	Available *bool `json:"available"`
+}
+
+// ServerInfoOutput is the output of 'iz admin server-info'
+type ServerInfoOutput struct {
+	Server       *izanami.ServerInfo `json:"server"`
+	Capabilities []CapabilityStatus  `json:"capabilities"`
+}
+
+// serverInfoCmd prints the detected server version and capabilities
+var serverInfoCmd = &cobra.Command{
+	Use:         "server-info",
+	Short:       "Show the Izanami server version and its capabilities",
+	Annotations: map[string]string{"route": "GET /api/_health"},
+	Long: `Show the version of the Izanami server and which version-dependent endpoints
+it supports.
+
+The version is read from the health endpoint and cached for an hour per
+server. Admin commands use it to fail fast with "requires Izanami server >= X"
+instead of a 404 on servers too old for them. When the server does not
+report its version, capabilities are unknown and commands are not blocked.
+
+Examples:
+  iz admin server-info
+  iz admin server-info --refresh -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newHealthClient(cfg.Retries)
+		if err != nil {
+			return err
+		}
+		info, err := izanami.DetectServerInfo(client, commandContext(), serverInfoRefresh)
+		if err != nil {
+			return err
+		}
+
+		result := &ServerInfoOutput{Server: info, Capabilities: make([]CapabilityStatus, 0, len(izanami.ServerCapabilities))}
+		for i := range izanami.ServerCapabilities {
+			capability := &izanami.ServerCapabilities[i]
+			status := CapabilityStatus{Name: capability.Name, Description: capability.Description, MinVersion: capability.MinVersion}
+			if supported, known := info.Supports(capability); known {
+				status.Available = &supported
+			}
+			result.Capabilities = append(result.Capabilities, status)
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), result, output.JSON)
+		}
+		printServerInfo(cmd.OutOrStdout(), result)
+		return nil
+	},
+}
+
+// printServerInfo displays the server version and capabilities
+func printServerInfo(w io.Writer, result *ServerInfoOutput) {
+	version := result.Server.Version
+	if version == "" {
+		version = "unknown (not reported by the server)"
+	}
+	fmt.Fprintf(w, "Server:      %s\n", result.Server.URL)
+	fmt.Fprintf(w, "Version:     %s\n", version)
+	fmt.Fprintf(w, "Compatible:  %s\n", serverCompatibility(result.Server.Version))
+	fmt.Fprintf(w, "Detected at: %s\n\n", result.Server.DetectedAt.Local().Format(time.RFC3339))
+
+	rows := make([][]string, 0, len(result.Capabilities))
+	for _, capability := range result.Capabilities {
+		status := "unknown"
+		if capability.Available != nil {
+			status = "no"
+			if *capability.Available {
+				status = "yes"
+			}
+		}
+		rows = append(rows, []string{capability.Name, capability.Description, ">= " + capability.MinVersion, status})
+	}
+	printGroupedTable(w, []string{"CAPABILITY", "DESCRIPTION", "REQUIRES", "AVAILABLE"}, rows)
+}
+
+// checkServerCapability fails an admin command whose route needs a newer server
+// than the one configured. Detection errors are ignored: the command then runs
+// and reports the server error itself.
+func checkServerCapability(cmd *cobra.Command) error {
+	capability := izanami.CapabilityForRoute(cmd.Annotations["route"])
+	if capability == nil || cfg == nil || cfg.LeaderURL == "" {
+		return nil
+	}
+	client, err := newHealthClient(0)
+	if err != nil {
+		return nil
+	}
+	info, err := izanami.DetectServerInfo(client, commandContext(), false)
+	if err != nil {
+		if cfg.Verbose {
+			fmt.Fprintf(statusOut(cmd), "[verbose] Could not detect the server version: %v\n", err)
+		}
+		return nil
+	}
+	return info.RequireCapability(capability, cmd.CommandPath())
+}
+
+func init() {
+	adminCmd.AddCommand(serverInfoCmd)
+
+	serverInfoCmd.Flags().BoolVar(&serverInfoRefresh, "refresh", false, "Ask the server again instead of using the cached version")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestCheckServerCapability(t *testing.T) {
+	dir := t.TempDir()
+	originalCacheDir := izanami.GetCacheDir()
+	izanami.SetGetCacheDirFunc(func() string { return dir })
+	t.Cleanup(func() { izanami.SetGetCacheDirFunc(func() string { return originalCacheDir }) })
+
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/_health", r.URL.Path)
+		json.NewEncoder(w).Encode(izanami.HealthStatus{Database: true, Version: "2.0.0"})
+	})
+	cfg.JwtToken, cfg.Tenant = "", ""
+
+	webhooks := &cobra.Command{Use: "list", Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/webhooks"}}
+	err := checkServerCapability(webhooks)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires Izanami server >= ")
+
+	features := &cobra.Command{Use: "list", Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"}}
+	assert.NoError(t, checkServerCapability(features))
+}
+
+func TestPrintServerInfo(t *testing.T) {
+	available := false
+	var buf bytes.Buffer
+	printServerInfo(&buf, &ServerInfoOutput{
+		Server: &izanami.ServerInfo{URL: "http://izanami", Version: "2.1.0"},
+		Capabilities: []CapabilityStatus{
+			{Name: "webhooks", Description: "Webhooks", MinVersion: "2.2.0", Available: &available},
+			{Name: "search", Description: "Global search", MinVersion: "2.3.0"},
+		},
+	})
+	output := buf.String()
+	assert.Contains(t, output, "Version:     2.1.0")
+	assert.Contains(t, output, "Compatible:  compatible")
+	assert.Regexp(t, `webhooks\s+Webhooks\s+>= 2.2.0\s+no`, output)
+	assert.Regexp(t, `search\s+Global search\s+>= 2.3.0\s+unknown`, output)
+}
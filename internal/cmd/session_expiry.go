@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// sessionExpiryWarning is how long before the session token expires admin commands start warning
+const sessionExpiryWarning = 10 * time.Minute
+
+// checkSessionExpiry detects an expired or expiring session token before any request is sent,
+// instead of letting the server answer with an opaque 401.
+//
+// When a personal access token is also configured, an expired session token is dropped and
+// the command transparently authenticates with the PAT. Otherwise an expired session is an
+// error and a session about to expire produces a warning on w.
+func checkSessionExpiry(w io.Writer, c *izanami.ResolvedConfig, now time.Time) error {
+	if c.JwtToken == "" {
+		return nil
+	}
+	exp, ok := izanami.JWTExpiry(c.JwtToken)
+	if !ok {
+		return nil
+	}
+
+	remaining := exp.Sub(now)
+	if remaining > sessionExpiryWarning {
+		return nil
+	}
+
+	if c.PersonalAccessToken != "" {
+		if remaining <= 0 {
+			c.JwtToken = ""
+			if c.Verbose {
+				fmt.Fprintf(w, "[verbose] Session expired at %s, authenticating with the personal access token\n", exp.Local().Format(time.RFC3339))
+			}
+		}
+		return nil
+	}
+
+	if remaining <= 0 {
+		return withExitCode(ExitAuth, errmsg.Errorf(errmsg.MsgSessionExpired, exp.Local().Format("2006-01-02 15:04:05"), "iz login"))
+	}
+	fmt.Fprintf(w, "Warning: session expires in %s (at %s), use 'iz login' to renew it\n",
+		remaining.Round(time.Second), exp.Local().Format("15:04:05"))
+	return nil
+}
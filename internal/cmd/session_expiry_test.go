@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// jwtExpiringAt builds an unsigned JWT expiring at exp
+func jwtExpiringAt(exp time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"admin","exp":%d}`, exp.Unix())))
+	return header + "." + payload + ".sig"
+}
+
+func TestCheckSessionExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("valid session", func(t *testing.T) {
+		var out bytes.Buffer
+		c := &izanami.ResolvedConfig{JwtToken: jwtExpiringAt(now.Add(time.Hour))}
+		require.NoError(t, checkSessionExpiry(&out, c, now))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("session about to expire warns", func(t *testing.T) {
+		var out bytes.Buffer
+		c := &izanami.ResolvedConfig{JwtToken: jwtExpiringAt(now.Add(5 * time.Minute))}
+		require.NoError(t, checkSessionExpiry(&out, c, now))
+		assert.Contains(t, out.String(), "session expires in 5m0s")
+		assert.Contains(t, out.String(), "iz login")
+	})
+
+	t.Run("expired session fails", func(t *testing.T) {
+		var out bytes.Buffer
+		c := &izanami.ResolvedConfig{JwtToken: jwtExpiringAt(now.Add(-time.Minute))}
+		err := checkSessionExpiry(&out, c, now)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "session expired at")
+		assert.Contains(t, err.Error(), "iz login")
+	})
+
+	t.Run("expired session falls back to PAT", func(t *testing.T) {
+		var out bytes.Buffer
+		c := &izanami.ResolvedConfig{
+			JwtToken:                    jwtExpiringAt(now.Add(-time.Minute)),
+			PersonalAccessToken:         "pat",
+			PersonalAccessTokenUsername: "admin",
+		}
+		require.NoError(t, checkSessionExpiry(&out, c, now))
+		assert.Empty(t, c.JwtToken, "expired token should be dropped")
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("token without expiry is left alone", func(t *testing.T) {
+		var out bytes.Buffer
+		c := &izanami.ResolvedConfig{JwtToken: "opaque"}
+		require.NoError(t, checkSessionExpiry(&out, c, now))
+		assert.Equal(t, "opaque", c.JwtToken)
+		assert.Empty(t, out.String())
+	})
+}
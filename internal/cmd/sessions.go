@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -12,21 +14,54 @@ import (
 
 var (
 	sessionsDeleteForce bool
+	sessionsPruneForce  bool
+	sessionsPruneDryRun bool
 )
 
+// Session statuses shown by 'iz sessions list/show'
+const (
+	sessionStatusValid     = "valid"
+	sessionStatusExpired   = "expired"
+	sessionStatusLoggedOut = "logged out"
+	sessionStatusUnknown   = "unknown"
+)
+
+// SessionView is the display representation of a saved session
+type SessionView struct {
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Username  string   `json:"username"`
+	Status    string   `json:"status"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	Age       string   `json:"age"`
+	Profiles  []string `json:"profiles"`
+}
+
 // sessionsCmd represents the sessions command
 var sessionsCmd = &cobra.Command{
-	Use:   "sessions",
-	Short: "Manage authentication sessions",
-	Long: `Manage saved authentication sessions.
+	Use:     "sessions",
+	Aliases: []string{"session"},
+	Short:   "Manage authentication sessions",
+	Long: `Manage saved authentication sessions (~/.izsessions, or sessions.yaml in the
+config directory on Windows).
 
 Sessions store your JWT tokens from login. Sessions are referenced by profiles,
 and you control which session is used by switching profiles.
 
 Examples:
-  # List all sessions
+  # List all sessions with their expiry status
   iz sessions list
 
+  # Show one session and the profiles using it
+  iz sessions show prod-session
+
+  # Rename a session (profiles referencing it are updated)
+  iz sessions rename old-name new-name
+
+  # Delete expired and logged-out sessions
+  iz sessions prune
+
   # Delete a session
   iz sessions delete old-session
 
@@ -45,36 +80,220 @@ var sessionsListCmd = &cobra.Command{
 		}
 
 		if len(sessions.Sessions) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), errors.MsgNoSavedSessions)
+			fmt.Fprintln(statusOut(cmd), errors.T(errors.MsgNoSavedSessions))
 			return nil
 		}
 
-		// Format for display
-		type SessionDisplay struct {
-			Name      string `json:"name"`
-			URL       string `json:"url"`
-			Username  string `json:"username"`
-			CreatedAt string `json:"created_at"`
-			Age       string `json:"age"`
+		references := sessionReferences()
+		names := make([]string, 0, len(sessions.Sessions))
+		for name := range sessions.Sessions {
+			names = append(names, name)
 		}
+		sort.Strings(names)
 
-		var displays []SessionDisplay
+		views := make([]SessionView, 0, len(names))
+		for _, name := range names {
+			views = append(views, newSessionView(name, sessions.Sessions[name], references[name], time.Now()))
+		}
+
+		return output.PrintTo(cmd.OutOrStdout(), views, output.Format(outputFormat))
+	},
+}
+
+// sessionsShowCmd shows the details of a session
+var sessionsShowCmd = &cobra.Command{
+	Use:   "show <session-name>",
+	Short: "Show a saved session",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, err := izanami.LoadSessions()
+		if err != nil {
+			return err
+		}
+
+		session, err := sessions.GetSession(args[0])
+		if err != nil {
+			return err
+		}
+
+		view := newSessionView(args[0], session, sessionReferences()[args[0]], time.Now())
+		return output.PrintTo(cmd.OutOrStdout(), view, output.Format(outputFormat))
+	},
+}
+
+// sessionsRenameCmd renames a session and updates the profiles referencing it
+var sessionsRenameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "Rename a saved session",
+	Long: `Rename a saved session.
+
+Profiles referencing the session are updated to use the new name.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldName, newName := args[0], args[1]
+
+		sessions, err := izanami.LoadSessions()
+		if err != nil {
+			return err
+		}
+
+		session, err := sessions.GetSession(oldName)
+		if err != nil {
+			return err
+		}
+		if _, exists := sessions.Sessions[newName]; exists {
+			return fmt.Errorf("session '%s' already exists", newName)
+		}
+
+		sessions.AddSession(newName, session)
+		delete(sessions.Sessions, oldName)
+		if err := sessions.Save(); err != nil {
+			return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToSaveSessions), err)
+		}
+
+		for _, profileName := range sessionReferences()[oldName] {
+			profile, err := izanami.GetProfile(profileName)
+			if err != nil {
+				return err
+			}
+			profile.Session = newName
+			if err := izanami.AddProfile(profileName, profile); err != nil {
+				return fmt.Errorf("failed to update profile '%s': %w", profileName, err)
+			}
+			fmt.Fprintf(statusOut(cmd), "   Updated profile: %s\n", profileName)
+		}
+
+		fmt.Fprintf(statusOut(cmd), "✅ Renamed session: %s → %s\n", oldName, newName)
+		return nil
+	},
+}
+
+// sessionsPruneCmd deletes expired and logged-out sessions
+var sessionsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete expired and logged-out sessions",
+	Long: `Delete the sessions whose token has expired or was removed by 'iz logout'.
+
+Sessions whose token carries no expiry are kept. Profiles referencing a pruned
+session keep their reference: log in again to create a new token.
+
+Examples:
+  # Show what would be deleted
+  iz sessions prune --dry-run
+
+  # Delete without confirmation
+  iz sessions prune --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, err := izanami.LoadSessions()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var stale []string
 		for name, session := range sessions.Sessions {
-			age := formatAge(time.Since(session.CreatedAt))
+			status, _ := sessionStatus(session, now)
+			if status == sessionStatusExpired || status == sessionStatusLoggedOut {
+				stale = append(stale, name)
+			}
+		}
+		sort.Strings(stale)
+
+		if len(stale) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No expired sessions")
+			return nil
+		}
+
+		references := sessionReferences()
+		for _, name := range stale {
+			status, _ := sessionStatus(sessions.Sessions[name], now)
+			line := fmt.Sprintf("%s (%s)", name, status)
+			if profiles := references[name]; len(profiles) > 0 {
+				line += fmt.Sprintf(", used by profile(s): %s", strings.Join(profiles, ", "))
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), line)
+		}
+
+		if sessionsPruneDryRun {
+			fmt.Fprintf(statusOut(cmd), "Dry run: %d session(s) would be deleted\n", len(stale))
+			return nil
+		}
+
+		if !sessionsPruneForce {
+			if !confirmAction(cmd, fmt.Sprintf("Delete %d session(s)?", len(stale))) {
+				return nil
+			}
+		}
 
-			displays = append(displays, SessionDisplay{
-				Name:      name,
-				URL:       session.URL,
-				Username:  session.Username,
-				CreatedAt: session.CreatedAt.Format("2006-01-02 15:04:05"),
-				Age:       age,
-			})
+		for _, name := range stale {
+			delete(sessions.Sessions, name)
+		}
+		if err := sessions.Save(); err != nil {
+			return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToSaveSessions), err)
 		}
 
-		return output.PrintTo(cmd.OutOrStdout(), displays, output.Format(outputFormat))
+		fmt.Fprintf(statusOut(cmd), "✅ Deleted %d session(s)\n", len(stale))
+		return nil
 	},
 }
 
+// sessionStatus returns the status of a session token and its expiry time, if known
+func sessionStatus(session *izanami.Session, now time.Time) (string, time.Time) {
+	if session.JwtToken == "" {
+		return sessionStatusLoggedOut, time.Time{}
+	}
+	exp, ok := session.ExpiresAt()
+	if !ok {
+		return sessionStatusUnknown, time.Time{}
+	}
+	if !now.Before(exp) {
+		return sessionStatusExpired, exp
+	}
+	return sessionStatusValid, exp
+}
+
+// newSessionView builds the display representation of a session
+func newSessionView(name string, session *izanami.Session, profiles []string, now time.Time) SessionView {
+	status, exp := sessionStatus(session, now)
+	view := SessionView{
+		Name:     name,
+		URL:      session.URL,
+		Username: session.Username,
+		Status:   status,
+		Profiles: profiles,
+	}
+	if view.Profiles == nil {
+		view.Profiles = []string{}
+	}
+	if !exp.IsZero() {
+		view.ExpiresAt = exp.Local().Format("2006-01-02 15:04:05")
+	}
+	if !session.CreatedAt.IsZero() {
+		view.CreatedAt = session.CreatedAt.Format("2006-01-02 15:04:05")
+		view.Age = formatAge(now.Sub(session.CreatedAt))
+	}
+	return view
+}
+
+// sessionReferences maps each session name to the sorted names of the profiles referencing it
+func sessionReferences() map[string][]string {
+	references := map[string][]string{}
+	profiles, _, err := izanami.ListProfiles()
+	if err != nil {
+		return references
+	}
+	for name, profile := range profiles {
+		if profile != nil && profile.Session != "" {
+			references[profile.Session] = append(references[profile.Session], name)
+		}
+	}
+	for _, names := range references {
+		sort.Strings(names)
+	}
+	return references
+}
+
 // sessionsDeleteCmd deletes a session
 var sessionsDeleteCmd = &cobra.Command{
 	Use:   "delete <session-name>",
@@ -100,10 +319,13 @@ var sessionsDeleteCmd = &cobra.Command{
 		}
 
 		if err := sessions.Save(); err != nil {
-			return fmt.Errorf("%s: %w", errors.MsgFailedToSaveSessions, err)
+			return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToSaveSessions), err)
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Deleted session: %s\n", sessionName)
+		fmt.Fprintf(statusOut(cmd), "✅ Deleted session: %s\n", sessionName)
+		if profiles := sessionReferences()[sessionName]; len(profiles) > 0 {
+			fmt.Fprintf(statusOut(cmd), "   Warning: still referenced by profile(s): %s (use 'iz login' to create a new session)\n", strings.Join(profiles, ", "))
+		}
 
 		return nil
 	},
@@ -152,11 +374,11 @@ You will need to login again to use this session.`,
 		session.CreatedAt = time.Time{} // Zero time
 
 		if err := sessions.Save(); err != nil {
-			return fmt.Errorf("%s: %w", errors.MsgFailedToSaveSessions, err)
+			return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToSaveSessions), err)
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Logged out from session: %s\n", profile.Session)
-		fmt.Fprintf(cmd.OutOrStderr(), "   Use 'iz login %s %s' to login again\n", session.URL, session.Username)
+		fmt.Fprintf(statusOut(cmd), "✅ Logged out from session: %s\n", profile.Session)
+		fmt.Fprintf(statusOut(cmd), "   Use 'iz login %s %s' to login again\n", session.URL, session.Username)
 
 		return nil
 	},
@@ -192,8 +414,15 @@ func init() {
 	rootCmd.AddCommand(logoutCmd)
 
 	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsShowCmd)
+	sessionsCmd.AddCommand(sessionsRenameCmd)
+	sessionsCmd.AddCommand(sessionsPruneCmd)
 	sessionsCmd.AddCommand(sessionsDeleteCmd)
 
 	// Delete flags
 	sessionsDeleteCmd.Flags().BoolVarP(&sessionsDeleteForce, "force", "f", false, "Skip confirmation prompt")
+
+	// Prune flags
+	sessionsPruneCmd.Flags().BoolVarP(&sessionsPruneForce, "force", "f", false, "Skip confirmation prompt")
+	sessionsPruneCmd.Flags().BoolVar(&sessionsPruneDryRun, "dry-run", false, "List the sessions that would be deleted without deleting them")
 }
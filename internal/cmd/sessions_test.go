@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// runSessionsCmd runs a sessions subcommand and returns its combined output
+func runSessionsCmd(t *testing.T, c *cobra.Command, args []string, input string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	c.SetOut(&buf)
+	c.SetIn(bytes.NewBufferString(input))
+	defer func() {
+		c.SetOut(nil)
+		c.SetIn(nil)
+	}()
+	err := c.RunE(c, args)
+	return buf.String(), err
+}
+
+// setupSessionsTest creates sessions in several states and profiles referencing them
+func setupSessionsTest(t *testing.T) *testPaths {
+	t.Helper()
+	paths := setupTestPaths(t)
+	overridePathFunctions(t, paths)
+
+	createTestSessions(t, paths.sessionsPath, map[string]*izanami.Session{
+		"valid":      {URL: "http://prod", Username: "alice", JwtToken: jwtExpiringAt(time.Now().Add(time.Hour)), CreatedAt: time.Now()},
+		"expired":    {URL: "http://old", Username: "bob", JwtToken: jwtExpiringAt(time.Now().Add(-time.Hour)), CreatedAt: time.Now().Add(-48 * time.Hour)},
+		"logged-out": {URL: "http://dev", Username: "carol"},
+		"opaque":     {URL: "http://other", Username: "dave", JwtToken: "opaque-token", CreatedAt: time.Now()},
+	})
+	createTestConfig(t, paths.configPath, map[string]*izanami.Profile{
+		"prod":    {Session: "valid"},
+		"staging": {Session: "valid"},
+		"legacy":  {Session: "expired"},
+	}, "prod")
+	return paths
+}
+
+func TestSessionStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		session *izanami.Session
+		want    string
+	}{
+		{"valid", &izanami.Session{JwtToken: jwtExpiringAt(now.Add(time.Hour))}, sessionStatusValid},
+		{"expired", &izanami.Session{JwtToken: jwtExpiringAt(now.Add(-time.Hour))}, sessionStatusExpired},
+		{"logged out", &izanami.Session{}, sessionStatusLoggedOut},
+		{"no exp claim", &izanami.Session{JwtToken: "opaque"}, sessionStatusUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := sessionStatus(tt.session, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSessionsList_ShowsStatusAndProfiles(t *testing.T) {
+	setupSessionsTest(t)
+	outputFormat = "json"
+	defer func() { outputFormat = "table" }()
+
+	out, err := runSessionsCmd(t, sessionsListCmd, nil, "")
+	require.NoError(t, err)
+
+	var views []SessionView
+	require.NoError(t, json.Unmarshal([]byte(out), &views))
+	require.Len(t, views, 4)
+
+	byName := map[string]SessionView{}
+	for _, v := range views {
+		byName[v.Name] = v
+	}
+	assert.Equal(t, "expired", views[0].Name, "sessions are sorted by name")
+	assert.Equal(t, sessionStatusValid, byName["valid"].Status)
+	assert.Equal(t, []string{"prod", "staging"}, byName["valid"].Profiles)
+	assert.NotEmpty(t, byName["valid"].ExpiresAt)
+	assert.Equal(t, sessionStatusExpired, byName["expired"].Status)
+	assert.Equal(t, sessionStatusLoggedOut, byName["logged-out"].Status)
+	assert.Equal(t, []string{}, byName["logged-out"].Profiles)
+	assert.Equal(t, sessionStatusUnknown, byName["opaque"].Status)
+}
+
+func TestSessionsShow(t *testing.T) {
+	setupSessionsTest(t)
+
+	out, err := runSessionsCmd(t, sessionsShowCmd, []string{"valid"}, "")
+	require.NoError(t, err)
+	assert.Contains(t, out, "http://prod")
+	assert.Contains(t, out, "alice")
+	assert.Contains(t, out, "prod")
+
+	_, err = runSessionsCmd(t, sessionsShowCmd, []string{"missing"}, "")
+	assert.Error(t, err)
+}
+
+func TestSessionsRename_UpdatesProfiles(t *testing.T) {
+	setupSessionsTest(t)
+
+	_, err := runSessionsCmd(t, sessionsRenameCmd, []string{"valid", "production"}, "")
+	require.NoError(t, err)
+
+	sessions, err := izanami.LoadSessions()
+	require.NoError(t, err)
+	assert.Contains(t, sessions.Sessions, "production")
+	assert.NotContains(t, sessions.Sessions, "valid")
+
+	for _, name := range []string{"prod", "staging"} {
+		profile, err := izanami.GetProfile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "production", profile.Session)
+	}
+	legacy, err := izanami.GetProfile("legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "expired", legacy.Session)
+}
+
+func TestSessionsRename_RejectsExistingName(t *testing.T) {
+	setupSessionsTest(t)
+
+	_, err := runSessionsCmd(t, sessionsRenameCmd, []string{"valid", "expired"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestSessionsPrune(t *testing.T) {
+	setupSessionsTest(t)
+
+	t.Run("dry run keeps everything", func(t *testing.T) {
+		sessionsPruneDryRun = true
+		defer func() { sessionsPruneDryRun = false }()
+
+		out, err := runSessionsCmd(t, sessionsPruneCmd, nil, "")
+		require.NoError(t, err)
+		assert.Contains(t, out, "expired (expired), used by profile(s): legacy")
+		assert.Contains(t, out, "logged-out (logged out)")
+
+		sessions, err := izanami.LoadSessions()
+		require.NoError(t, err)
+		assert.Len(t, sessions.Sessions, 4)
+	})
+
+	t.Run("declined confirmation keeps everything", func(t *testing.T) {
+		_, err := runSessionsCmd(t, sessionsPruneCmd, nil, "n\n")
+		require.NoError(t, err)
+
+		sessions, err := izanami.LoadSessions()
+		require.NoError(t, err)
+		assert.Len(t, sessions.Sessions, 4)
+	})
+
+	t.Run("deletes expired and logged-out sessions", func(t *testing.T) {
+		_, err := runSessionsCmd(t, sessionsPruneCmd, nil, "y\n")
+		require.NoError(t, err)
+
+		sessions, err := izanami.LoadSessions()
+		require.NoError(t, err)
+		assert.Len(t, sessions.Sessions, 2)
+		assert.Contains(t, sessions.Sessions, "valid")
+		assert.Contains(t, sessions.Sessions, "opaque", "sessions without expiry are kept")
+	})
+}
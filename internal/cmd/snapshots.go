@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	noSnapshot          bool
+	snapshotsPruneKeep  int
+	snapshotsPruneOlder string
+	rollbackLast        bool
+	rollbackDryRun      bool
+	rollbackForce       bool
+)
+
+// snapshotsCmd groups the commands managing the snapshots saved before bulk changes
+var snapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Manage the snapshots saved before bulk changes",
+	Long: `Manage the snapshots saved before bulk changes.
+
+Before changing anything, 'iz admin features toggle', 'iz admin features patch',
+'iz admin import' and 'iz apply' save the features and overloads of the projects
+they affect in the snapshots directory of the config dir. 'iz rollback' restores
+them. --no-snapshot skips the snapshot.
+
+Examples:
+  iz snapshots list
+  iz snapshots show 20261016-093012-features-toggle
+  iz snapshots prune --keep 20 --older-than 30d`,
+}
+
+// snapshotsListCmd lists the saved snapshots
+var snapshotsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the saved snapshots, newest first",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshots, err := izanami.ListMutationSnapshots()
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 && outputFormat != "json" {
+			fmt.Fprintln(statusOut(cmd), "No snapshots")
+			return nil
+		}
+		summaries := make([]izanami.MutationSnapshotSummary, len(snapshots))
+		for i, snapshot := range snapshots {
+			summaries[i] = snapshot.Summary()
+		}
+		return output.PrintTo(cmd.OutOrStdout(), summaries, output.Format(outputFormat))
+	},
+}
+
+// snapshotsShowCmd prints a saved snapshot
+var snapshotsShowCmd = &cobra.Command{
+	Use:   "show <snapshot-id>",
+	Short: "Show a saved snapshot",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshot, err := izanami.LoadMutationSnapshot(args[0])
+		if err != nil {
+			return err
+		}
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), snapshot, output.JSON)
+		}
+
+		w := cmd.OutOrStdout()
+		fmt.Fprintf(w, "Snapshot %s: %s on tenant %s of %s, at %s\n", snapshot.ID, snapshot.Command, snapshot.Tenant, snapshot.LeaderURL, snapshot.CreatedAt)
+		if snapshot.RolledBackAt != "" {
+			fmt.Fprintf(w, "Rolled back at %s\n", snapshot.RolledBackAt)
+		}
+		var rows [][]string
+		for _, project := range snapshot.Projects {
+			overloads := make(map[string]int)
+			for _, overload := range project.Overloads {
+				overloads[overload.Feature]++
+			}
+			for _, feature := range project.Features {
+				rows = append(rows, []string{project.Project, feature.Name, fmt.Sprint(feature.Enabled), fmt.Sprint(overloads[feature.Name])})
+			}
+		}
+		if len(rows) == 0 {
+			fmt.Fprintln(w, "No features")
+			return nil
+		}
+		return output.PrintRows(w, []string{"PROJECT", "FEATURE", "ENABLED", "OVERLOADS"}, rows)
+	},
+}
+
+// snapshotsPruneCmd deletes old snapshots
+var snapshotsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old snapshots",
+	Long: `Delete the snapshots beyond the --keep newest ones and those older than
+--older-than.
+
+Examples:
+  iz snapshots prune --keep 20
+  iz snapshots prune --older-than 30d`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("keep") && snapshotsPruneOlder == "" {
+			return fmt.Errorf("--keep or --older-than is required")
+		}
+		keep := -1
+		if cmd.Flags().Changed("keep") {
+			if snapshotsPruneKeep < 0 {
+				return fmt.Errorf("--keep must not be negative")
+			}
+			keep = snapshotsPruneKeep
+		}
+		var olderThan time.Duration
+		if snapshotsPruneOlder != "" {
+			var err error
+			if olderThan, err = parseDayDuration(snapshotsPruneOlder); err != nil {
+				return err
+			}
+		}
+
+		deleted, err := izanami.PruneMutationSnapshots(keep, olderThan, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(statusOut(cmd), "Deleted %d snapshot(s)\n", len(deleted))
+		return nil
+	},
+}
+
+// rollbackCmd restores a snapshot saved before a bulk change
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback [snapshot-id]",
+	Short: "Undo a bulk change by restoring the snapshot saved before it",
+	Long: `Restore the features and overloads saved before a bulk change ('iz admin
+features toggle', 'patch', 'iz admin import' or 'iz apply').
+
+Features and overloads changed since the snapshot are restored, and those
+deleted since are created again. For imports, applies and patches moving
+features, the features and overloads created by the change are deleted.
+Contexts and tags are left as they are. The changes are shown and confirmed
+before being applied; once done, the snapshot is marked as rolled back and
+'--last' moves on to the previous one.
+
+The snapshot must be restored to the server it was taken on: use the same
+--profile or --url.
+
+Examples:
+  # Preview the rollback of the last bulk change
+  iz rollback --last --dry-run
+
+  # Roll back a given snapshot
+  iz rollback 20261016-093012-features-toggle`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if rollbackLast == (len(args) == 1) {
+			return fmt.Errorf("give either a snapshot ID or --last")
+		}
+		var snapshot *izanami.MutationSnapshot
+		var err error
+		if rollbackLast {
+			snapshot, err = izanami.LastMutationSnapshot()
+		} else {
+			snapshot, err = izanami.LoadMutationSnapshot(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+		ctx := commandContext()
+		plan, err := izanami.BuildRollbackPlan(client, ctx, snapshot)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" && rollbackDryRun {
+			return output.PrintTo(cmd.OutOrStdout(), plan, output.JSON)
+		}
+		out := statusOut(cmd)
+		fmt.Fprintf(out, "Rollback of %s (%s at %s)\n", snapshot.ID, snapshot.Command, snapshot.CreatedAt)
+		if len(plan.Changes) == 0 {
+			fmt.Fprintf(out, "No changes: the features of tenant %s match the snapshot\n", plan.Tenant)
+			return nil
+		}
+		printPlan(out, plan)
+		if rollbackDryRun {
+			return nil
+		}
+		if !rollbackForce {
+			if !confirmAction(cmd, fmt.Sprintf("Roll back %d change(s)?", len(plan.Changes))) {
+				return nil
+			}
+		}
+
+		applied := 0
+		err = plan.Apply(ctx, func(change izanami.PlannedChange) {
+			applied++
+			if cfg.Verbose {
+				fmt.Fprintf(out, "[verbose] %s %s %s\n", change.Action, change.Kind, change.Name)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("%w (%d of %d change(s) rolled back)", err, applied, len(plan.Changes))
+		}
+		if err := izanami.MarkRolledBack(snapshot, time.Now()); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Rollback complete: %d created, %d updated, %d deleted\n",
+			plan.Count(izanami.PlanCreate), plan.Count(izanami.PlanUpdate), plan.Count(izanami.PlanDelete))
+		return nil
+	},
+}
+
+// saveMutationSnapshot saves the projects a bulk command is about to change,
+// unless --no-snapshot is set. prune marks changes that may create features.
+func saveMutationSnapshot(cmd *cobra.Command, client *izanami.AdminClient, projects []string, prune bool) error {
+	if noSnapshot {
+		return nil
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	snapshot, err := izanami.SaveMutationSnapshot(client, commandContext(), cfg.Tenant, command, projects, prune)
+	if err != nil {
+		return fmt.Errorf("%w (use --no-snapshot to change without a snapshot)", err)
+	}
+	fmt.Fprintf(statusOut(cmd), "Snapshot saved: %s (undo with 'iz rollback --last')\n", snapshot.ID)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotsCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	snapshotsCmd.AddCommand(snapshotsListCmd)
+	snapshotsCmd.AddCommand(snapshotsShowCmd)
+	snapshotsCmd.AddCommand(snapshotsPruneCmd)
+
+	snapshotsPruneCmd.Flags().IntVar(&snapshotsPruneKeep, "keep", 0, "Number of newest snapshots to keep")
+	snapshotsPruneCmd.Flags().StringVar(&snapshotsPruneOlder, "older-than", "", "Delete the snapshots older than this (e.g. 30d, 12h)")
+
+	rollbackCmd.Flags().BoolVar(&rollbackLast, "last", false, "Roll back the last bulk change not rolled back yet")
+	rollbackCmd.Flags().BoolVar(&rollbackDryRun, "dry-run", false, "Show the changes without applying them")
+	rollbackCmd.Flags().BoolVarP(&rollbackForce, "force", "f", false, "Skip confirmation prompt")
+
+	// Bulk changes save a snapshot first
+	for _, c := range []*cobra.Command{featuresToggleCmd, featuresPatchCmd, adminImportCmd, applyCmd} {
+		c.Flags().BoolVar(&noSnapshot, "no-snapshot", false, "Do not save a snapshot for 'iz rollback' before changing")
+	}
+}
@@ -1,18 +1,19 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
 
 var (
 	// Tag flags
-	tagDesc string
-	tagData string
+	tagDesc    string
+	tagData    string
+	tagNewName string
 	// Delete confirmation flag
 	tagsDeleteForce bool
 )
@@ -37,7 +38,7 @@ var adminTagsListCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -73,7 +74,7 @@ var adminTagsGetCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -123,16 +124,125 @@ var adminTagsCreateCmd = &cobra.Command{
 			}
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.CreateTag(ctx, cfg.Tenant, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Tag created successfully: %s\n", tagName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgTagCreated, tagName))
 		return nil
 	},
 }
 
+var adminTagsUpdateCmd = &cobra.Command{
+	Use:         "update <tag-name>",
+	Short:       "Update a tag",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/tags/:name"},
+	Long: `Update the description of a tag, or rename it with --name.
+Fields that are not given keep their current value.
+
+Examples:
+  # Change the description
+  iz admin tags update checkout --description "Checkout funnel" --tenant my-tenant
+
+  # Rename a tag
+  iz admin tags update checkout --name payment --tenant my-tenant`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		tagName := args[0]
+		ctx := commandContext()
+		var data interface{}
+
+		if cmd.Flags().Changed("data") {
+			if err := parseJSONData(tagData, &data); err != nil {
+				return err
+			}
+		} else {
+			if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("description") {
+				return fmt.Errorf("nothing to update: use --name, --description or --data")
+			}
+			current, err := izanami.GetTag(client, ctx, cfg.Tenant, tagName, izanami.ParseTag)
+			if err != nil {
+				return err
+			}
+			body := map[string]interface{}{
+				"name":        current.Name,
+				"description": current.Description,
+			}
+			if cmd.Flags().Changed("name") {
+				body["name"] = tagNewName
+			}
+			if cmd.Flags().Changed("description") {
+				body["description"] = tagDesc
+			}
+			data = body
+		}
+
+		if err := client.UpdateTag(ctx, cfg.Tenant, tagName, data); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgTagUpdated, tagName))
+		return nil
+	},
+}
+
+var adminTagsFeaturesCmd = &cobra.Command{
+	Use:         "features <tag-name>",
+	Short:       "List the features carrying a tag",
+	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/features"},
+	Long: `List the features carrying a tag. Use the global --project flag to only show
+the features of one project.
+
+Examples:
+  iz admin tags features checkout --tenant my-tenant
+  iz admin tags features checkout --tenant my-tenant --project shop -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.ValidateTenant(); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		tagName := args[0]
+		ctx := commandContext()
+
+		// Fail clearly on unknown tags rather than printing an empty list
+		if _, err := izanami.GetTag(client, ctx, cfg.Tenant, tagName, izanami.Identity); err != nil {
+			return err
+		}
+
+		features, err := izanami.ListFeatures(client, ctx, cfg.Tenant, tagName, izanami.ParseFeatures)
+		if err != nil {
+			return err
+		}
+		if cfg.Project != "" {
+			filtered := make([]izanami.Feature, 0, len(features))
+			for _, f := range features {
+				if f.Project == cfg.Project {
+					filtered = append(filtered, f)
+				}
+			}
+			features = filtered
+		}
+
+		return output.PrintTo(cmd.OutOrStdout(), features, output.Format(outputFormat))
+	},
+}
+
 var adminTagsDeleteCmd = &cobra.Command{
 	Use:         "delete <tag-name>",
 	Short:       "Delete a tag",
@@ -157,12 +267,12 @@ var adminTagsDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.DeleteTag(ctx, cfg.Tenant, tagName); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Tag deleted successfully: %s\n", tagName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgTagDeleted, tagName))
 		return nil
 	},
 }
@@ -173,13 +283,20 @@ func init() {
 	adminTagsCmd.AddCommand(adminTagsListCmd)
 	adminTagsCmd.AddCommand(adminTagsGetCmd)
 	adminTagsCmd.AddCommand(adminTagsCreateCmd)
+	adminTagsCmd.AddCommand(adminTagsUpdateCmd)
 	adminTagsCmd.AddCommand(adminTagsDeleteCmd)
+	adminTagsCmd.AddCommand(adminTagsFeaturesCmd)
 
 	// Dynamic completion for tag name argument
 	adminTagsGetCmd.ValidArgsFunction = completeTagNames
+	adminTagsUpdateCmd.ValidArgsFunction = completeTagNames
 	adminTagsDeleteCmd.ValidArgsFunction = completeTagNames
+	adminTagsFeaturesCmd.ValidArgsFunction = completeTagNames
 
 	adminTagsCreateCmd.Flags().StringVar(&tagDesc, "description", "", "Tag description")
 	adminTagsCreateCmd.Flags().StringVar(&tagData, "data", "", "JSON tag data")
+	adminTagsUpdateCmd.Flags().StringVar(&tagNewName, "name", "", "New tag name")
+	adminTagsUpdateCmd.Flags().StringVar(&tagDesc, "description", "", "New tag description")
+	adminTagsUpdateCmd.Flags().StringVar(&tagData, "data", "", "JSON tag data")
 	adminTagsDeleteCmd.Flags().BoolVarP(&tagsDeleteForce, "force", "f", false, "Skip confirmation prompt")
 }
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTagsServer serves one tag and two tagged features, recording the body of PUT requests
+func setupTagsServer(t *testing.T) *map[string]interface{} {
+	t.Helper()
+	var updated map[string]interface{}
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/admin/tenants/acme/tags/checkout":
+			io.WriteString(w, `{"id": "t1", "name": "checkout", "description": "Checkout funnel"}`)
+		case "PUT /api/admin/tenants/acme/tags/checkout":
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &updated))
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /api/admin/tenants/acme/features":
+			assert.Equal(t, "checkout", r.URL.Query().Get("tag"))
+			io.WriteString(w, `[{"id": "f1", "name": "new-checkout", "project": "shop", "enabled": true},
+				{"id": "f2", "name": "one-click", "project": "web", "enabled": false}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		tagNewName, tagDesc = "", ""
+		for _, name := range []string{"name", "description"} {
+			adminTagsUpdateCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+	return &updated
+}
+
+func TestAdminTagsUpdateCmd_KeepsUnsetFields(t *testing.T) {
+	updated := setupTagsServer(t)
+	require.NoError(t, adminTagsUpdateCmd.Flags().Set("name", "payment"))
+
+	var buf bytes.Buffer
+	adminTagsUpdateCmd.SetOut(&buf)
+	defer adminTagsUpdateCmd.SetOut(nil)
+	require.NoError(t, adminTagsUpdateCmd.RunE(adminTagsUpdateCmd, []string{"checkout"}))
+
+	assert.Equal(t, map[string]interface{}{"name": "payment", "description": "Checkout funnel"}, *updated)
+	assert.Contains(t, buf.String(), "Tag updated successfully: checkout")
+}
+
+func TestAdminTagsUpdateCmd_NothingToUpdate(t *testing.T) {
+	setupTagsServer(t)
+	err := adminTagsUpdateCmd.RunE(adminTagsUpdateCmd, []string{"checkout"})
+	assert.ErrorContains(t, err, "nothing to update")
+}
+
+func TestAdminTagsFeaturesCmd(t *testing.T) {
+	setupTagsServer(t)
+	outputFormat = "json"
+	cfg.Project = "shop"
+
+	var buf bytes.Buffer
+	adminTagsFeaturesCmd.SetOut(&buf)
+	defer adminTagsFeaturesCmd.SetOut(nil)
+	require.NoError(t, adminTagsFeaturesCmd.RunE(adminTagsFeaturesCmd, []string{"checkout"}))
+
+	var features []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &features))
+	require.Len(t, features, 1)
+	assert.Equal(t, "new-checkout", features[0]["name"])
+
+	// Unknown tags fail instead of listing nothing
+	err := adminTagsFeaturesCmd.RunE(adminTagsFeaturesCmd, []string{"unknown"})
+	assert.Error(t, err)
+}
@@ -1,10 +1,11 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 	"github.com/webskin/izanami-go-cli/internal/izanami"
 	"github.com/webskin/izanami-go-cli/internal/output"
 )
@@ -15,6 +16,9 @@ var (
 	tenantData string
 	// Delete confirmation flag
 	tenantsDeleteForce bool
+	// Export command flags
+	tenantsExportOutput         string
+	tenantsExportIncludeSecrets bool
 	// Logs command flags
 	logsOrder    string
 	logsUsers    string
@@ -45,7 +49,7 @@ var adminTenantsListCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -86,7 +90,7 @@ var adminTenantsGetCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -111,7 +115,15 @@ var adminTenantsCreateCmd = &cobra.Command{
 	Use:         "create <tenant-name>",
 	Short:       "Create a new tenant",
 	Annotations: map[string]string{"route": "POST /api/admin/tenants"},
-	Args:        cobra.ExactArgs(1),
+	Long: `Create a new tenant. With -o json, the created tenant is printed.
+
+Examples:
+  # Create a tenant
+  iz admin tenants create my-tenant --description "My tenant"
+
+  # Create a tenant and print it as JSON
+  iz admin tenants create my-tenant --description "My tenant" -o json`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := izanami.NewAdminClient(cfg)
 		if err != nil {
@@ -132,24 +144,36 @@ var adminTenantsCreateCmd = &cobra.Command{
 			}
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.CreateTenant(ctx, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Tenant created successfully: %s\n", tenantName)
-		return nil
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgTenantCreated, tenantName))
+		if outputFormat != "json" {
+			return nil
+		}
+		if dataMap, ok := data.(map[string]interface{}); ok {
+			if name, ok := dataMap["name"].(string); ok && name != "" {
+				tenantName = name
+			}
+		}
+		raw, err := izanami.GetTenant(client, ctx, tenantName, izanami.Identity)
+		if err != nil {
+			return err
+		}
+		return output.PrintRawJSON(cmd.OutOrStdout(), raw, compactJSON)
 	},
 }
 
 var adminTenantsUpdateCmd = &cobra.Command{
 	Use:         "update <tenant-name>",
 	Short:       "Update a tenant",
-	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:name"},
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:name", "tenantArg": "0"},
 	Long: `Update a tenant's properties.
 
 You can provide the updated data via:
-  - --description flag (merged with existing data)
+  - --description flag (other fields keep their current value)
   - --data flag with JSON data
   - Both flags (--description takes precedence)
 
@@ -197,17 +221,26 @@ Examples:
 			data["description"] = tenantDesc
 		}
 
-		// Validate that we have at least name and description
+		if !cmd.Flags().Changed("data") && !cmd.Flags().Changed("description") {
+			return fmt.Errorf("nothing to update: use --description or --data")
+		}
+
+		ctx := commandContext()
+
+		// Keep the current description when none is given
 		if _, hasDesc := data["description"]; !hasDesc {
-			return fmt.Errorf("description is required (use --description flag or --data)")
+			current, err := izanami.GetTenant(client, ctx, tenantName, izanami.ParseTenant)
+			if err != nil {
+				return err
+			}
+			data["description"] = current.Description
 		}
 
-		ctx := context.Background()
 		if err := client.UpdateTenant(ctx, tenantName, data); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Tenant updated successfully: %s\n", tenantName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgTenantUpdated, tenantName))
 		return nil
 	},
 }
@@ -215,7 +248,7 @@ Examples:
 var adminTenantsDeleteCmd = &cobra.Command{
 	Use:         "delete <tenant-name>",
 	Short:       "Delete a tenant",
-	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:name"},
+	Annotations: map[string]string{"route": "DELETE /api/admin/tenants/:name", "tenantArg": "0"},
 	Long:        `Delete a tenant. WARNING: This will delete all projects, features, and data in the tenant.`,
 	Args:        cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -233,12 +266,12 @@ var adminTenantsDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.DeleteTenant(ctx, tenantName); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "Tenant deleted successfully: %s\n", tenantName)
+		fmt.Fprintln(statusOut(cmd), errmsg.Sprintf(errmsg.MsgTenantDeleted, tenantName))
 		return nil
 	},
 }
@@ -288,7 +321,7 @@ Examples:
 			Total:    logsTotal,
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -309,6 +342,74 @@ Examples:
 	},
 }
 
+var adminTenantsExportCmd = &cobra.Command{
+	Use:         "export <tenant-name>",
+	Short:       "Back up an entire tenant",
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/_export"},
+	Long: `Export a full tenant backup as newline-delimited JSON.
+
+The backup contains projects, features, overloads, contexts, tags, API keys
+metadata, webhooks and user rights. The file can be restored with
+'iz admin import <file> --version 2'.
+
+Secrets (API key client secrets, webhook header values, ...) are stripped by
+default. Use --include-secrets to keep them; the resulting file is then written
+with owner-only permissions.
+
+Examples:
+  # Back up a tenant to a file
+  iz admin tenants export my-tenant --output my-tenant.ndjson
+
+  # Nightly backup from CI, with timestamped file name
+  iz admin tenants export my-tenant --output "backup-$(date +%F).ndjson"
+
+  # Same in PowerShell
+  iz admin tenants export my-tenant --output "backup-$(Get-Date -Format yyyy-MM-dd).ndjson"
+
+  # Full backup including secrets
+  iz admin tenants export my-tenant --include-secrets --output my-tenant.ndjson
+
+  # Write to stdout
+  iz admin tenants export my-tenant`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenantName := args[0]
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		data, err := client.Export(ctx, tenantName)
+		if err != nil {
+			return err
+		}
+
+		if !tenantsExportIncludeSecrets {
+			data, err = izanami.RedactExportSecrets(data)
+			if err != nil {
+				return err
+			}
+		}
+
+		if tenantsExportOutput == "" {
+			fmt.Fprint(cmd.OutOrStdout(), data)
+			return nil
+		}
+
+		perm := os.FileMode(0644)
+		if tenantsExportIncludeSecrets {
+			perm = 0600
+		}
+		if err := os.WriteFile(tenantsExportOutput, []byte(data), perm); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+		fmt.Fprintf(statusOut(cmd), "Tenant %s exported to: %s\n", tenantName, tenantsExportOutput)
+		return nil
+	},
+}
+
 func init() {
 	// Tenants
 	adminCmd.AddCommand(adminTenantsCmd)
@@ -330,6 +431,12 @@ func init() {
 	adminTenantsUpdateCmd.Flags().StringVar(&tenantData, "data", "", "JSON tenant data")
 	adminTenantsDeleteCmd.Flags().BoolVarP(&tenantsDeleteForce, "force", "f", false, "Skip confirmation prompt")
 
+	// Tenant export
+	adminTenantsCmd.AddCommand(adminTenantsExportCmd)
+	adminTenantsExportCmd.ValidArgsFunction = completeTenantNames
+	adminTenantsExportCmd.Flags().StringVarP(&tenantsExportOutput, "output", "o", "", "Output file (default: stdout)")
+	adminTenantsExportCmd.Flags().BoolVar(&tenantsExportIncludeSecrets, "include-secrets", false, "Keep secrets (client secrets, webhook headers) in the export")
+
 	// Tenant logs
 	adminTenantsCmd.AddCommand(adminTenantsLogsCmd)
 	adminTenantsLogsCmd.Flags().StringVar(&logsOrder, "order", "", "Sort order: asc or desc")
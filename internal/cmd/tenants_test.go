@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTenantsServer serves one tenant, recording the body of POST and PUT requests
+func setupTenantsServer(t *testing.T) *map[string]interface{} {
+	t.Helper()
+	var received map[string]interface{}
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/admin/tenants/acme":
+			io.WriteString(w, `{"name": "acme", "description": "Acme corp", "projects": [], "tags": []}`)
+		case "POST /api/admin/tenants":
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &received))
+			w.WriteHeader(http.StatusCreated)
+			w.Write(data)
+		case "PUT /api/admin/tenants/acme":
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &received))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		tenantDesc, tenantData = "", ""
+		for _, c := range []*cobra.Command{adminTenantsCreateCmd, adminTenantsUpdateCmd} {
+			for _, name := range []string{"description", "data"} {
+				c.Flags().Lookup(name).Changed = false
+			}
+		}
+	})
+	return &received
+}
+
+func TestAdminTenantsCreateCmd_JSONOutput(t *testing.T) {
+	received := setupTenantsServer(t)
+	outputFormat = "json"
+	require.NoError(t, adminTenantsCreateCmd.Flags().Set("description", "Acme corp"))
+
+	var buf bytes.Buffer
+	adminTenantsCreateCmd.SetOut(&buf)
+	defer adminTenantsCreateCmd.SetOut(nil)
+	require.NoError(t, adminTenantsCreateCmd.RunE(adminTenantsCreateCmd, []string{"acme"}))
+
+	assert.Equal(t, map[string]interface{}{"name": "acme", "description": "Acme corp"}, *received)
+
+	// The status line and the JSON share the command output in tests
+	status, body, _ := strings.Cut(buf.String(), "\n")
+	assert.Equal(t, "Tenant created successfully: acme", status)
+	var tenant map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &tenant))
+	assert.Equal(t, "acme", tenant["name"])
+	assert.Contains(t, tenant, "projects")
+}
+
+func TestAdminTenantsUpdateCmd_KeepsDescription(t *testing.T) {
+	received := setupTenantsServer(t)
+	require.NoError(t, adminTenantsUpdateCmd.Flags().Set("data", `{"name": "acme"}`))
+
+	var buf bytes.Buffer
+	adminTenantsUpdateCmd.SetOut(&buf)
+	defer adminTenantsUpdateCmd.SetOut(nil)
+	require.NoError(t, adminTenantsUpdateCmd.RunE(adminTenantsUpdateCmd, []string{"acme"}))
+
+	assert.Equal(t, map[string]interface{}{"name": "acme", "description": "Acme corp"}, *received)
+	assert.Contains(t, buf.String(), "Tenant updated successfully: acme")
+}
+
+func TestAdminTenantsUpdateCmd_NothingToUpdate(t *testing.T) {
+	setupTenantsServer(t)
+	err := adminTenantsUpdateCmd.RunE(adminTenantsUpdateCmd, []string{"acme"})
+	assert.ErrorContains(t, err, "nothing to update")
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// setupServerTest starts a fake Izanami server answering with handler and points
+// the global config at it, with a JWT for tenant acme. The server is closed and
+// cfg and outputFormat are restored when the test ends, so tests only adjust the
+// fields they need (cfg.Project, cfg.Tenant...) after the call.
+func setupServerTest(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	origCfg, origOutput := cfg, outputFormat
+	t.Cleanup(func() { cfg, outputFormat = origCfg, origOutput })
+	cfg = &izanami.ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 5, Tenant: "acme"}
+	return server
+}
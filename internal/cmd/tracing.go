@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Environment variables carrying the W3C trace context of the calling process,
+// as set by instrumented CI systems
+const (
+	envTraceparent = "TRACEPARENT"
+	envTracestate  = "TRACESTATE"
+)
+
+// traceExportTimeout bounds the export of the spans when the command ends
+const traceExportTimeout = 5 * time.Second
+
+// commandTracer propagates the trace context of the command, nil before the pre-run
+var commandTracer *izanami.Tracer
+
+// setupTracing sends the trace context of the environment (TRACEPARENT,
+// TRACESTATE) with every API request. Multi-profile runs keep the tracer of
+// the first profile, so that all their spans are exported together.
+func setupTracing() {
+	if commandTracer != nil && commandTracer.Exporting() {
+		return
+	}
+	commandTracer = izanami.NewTracer(os.Getenv(envTraceparent), os.Getenv(envTracestate))
+	izanami.SetTracer(commandTracer)
+}
+
+// enableTraceExport records a span for the command and each API call when the
+// config sets otel-endpoint
+func enableTraceExport(cmd *cobra.Command) {
+	if commandTracer == nil || cfg == nil || cfg.OtelEndpoint == "" || commandTracer.Exporting() {
+		return
+	}
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	commandTracer.EnableExport(cfg.OtelEndpoint, "iz "+commandPath, map[string]interface{}{
+		"iz.command":    commandPath,
+		"iz.tenant":     cfg.Tenant,
+		"iz.leader_url": cfg.LeaderURL,
+		"iz.version":    Version,
+	})
+}
+
+// finishTracing ends the command span and exports the recorded spans. A
+// collector failure is reported as a warning: it does not fail the command.
+func finishTracing(err error) {
+	if commandTracer == nil || !commandTracer.Exporting() {
+		return
+	}
+	commandTracer.Finish(err)
+	ctx, cancel := context.WithTimeout(context.Background(), traceExportTimeout)
+	defer cancel()
+	if exportErr := commandTracer.Export(ctx, &http.Client{}); exportErr != nil && !quiet {
+		fmt.Fprintf(processStderr, "Warning: failed to export traces to %s: %v\n", cfg.OtelEndpoint, exportErr)
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/tui"
+)
+
+// uiCmd starts the interactive feature browser
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Browse tenants, projects and features interactively",
+	Long: `Start an interactive terminal UI to browse tenants → projects → features.
+
+From the feature list you can toggle a feature's enabled state and view its
+context overloads. Requires admin authentication (iz login or a PAT).
+
+If a tenant is configured (--tenant, IZ_TENANT or profile), browsing starts
+at the project list of that tenant.
+
+Key bindings:
+  ↑/↓ (k/j)     Move the cursor
+  enter (l)     Open the selected tenant / project, or show feature overloads
+  space (t)     Toggle the selected feature
+  o             Show overloads of the selected feature
+  esc (h)       Go back
+  r             Refresh
+  q, ctrl+c     Quit
+
+Examples:
+  # Browse everything
+  iz ui
+
+  # Start in a specific tenant
+  iz ui --tenant my-tenant`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkSessionExpiry(statusOut(cmd), cfg, time.Now()); err != nil {
+			return err
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		return tui.Run(commandContext(), tui.NewClientBackend(client), cfg.Tenant,
+			tea.WithInput(cmd.InOrStdin()),
+			tea.WithOutput(cmd.OutOrStdout()),
+			tea.WithAltScreen(),
+		)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,6 +27,7 @@ var (
 	usersDeleteForce  bool
 	usersInviteFile   string
 	usersSearchCount  int
+	userInteractive   bool
 )
 
 // usersCmd represents the users command
@@ -70,7 +70,15 @@ var usersListCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
+
+		// For NDJSON output, print users as they are received
+		if outputFormat == "ndjson" {
+			w := cmd.OutOrStdout()
+			return izanami.StreamUsers(client, ctx, func(item json.RawMessage) error {
+				return output.WriteNDJSON(w, item)
+			})
+		}
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -88,7 +96,7 @@ var usersListCmd = &cobra.Command{
 		}
 
 		if len(users) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No users found")
+			fmt.Fprintln(statusOut(cmd), "No users found")
 			return nil
 		}
 
@@ -110,7 +118,7 @@ var usersGetCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -128,7 +136,7 @@ var usersGetCmd = &cobra.Command{
 		}
 
 		// For table output, use custom fancy display
-		return printUserDetails(cmd.OutOrStderr(), user)
+		return printUserDetails(statusOut(cmd), user)
 	},
 }
 
@@ -137,12 +145,15 @@ var usersCreateCmd = &cobra.Command{
 	Use:         "create <username>",
 	Short:       "Create a new user",
 	Annotations: map[string]string{"route": "POST /api/admin/users"},
-	Args:        cobra.ExactArgs(1),
+	Args:        cobra.RangeArgs(0, 1),
 	Long: `Create a new user with specified properties.
 
 You can provide user rights either via:
   1. Individual flags (--admin, --tenant-right, --project-right)
   2. A JSON file with --rights-file
+  3. The --interactive wizard, which prompts for each field (password input is
+     hidden), offers tenants and projects in numbered menus and shows the
+     payload before creating the user. Other flags are used as defaults.
 
 Examples:
   # Create an admin user
@@ -153,8 +164,36 @@ Examples:
     --tenant-right "tenant1:Admin" --tenant-right "tenant2:Write"
 
   # Create a user from JSON file
-  iz admin users create bob --email bob@example.com --password secret123 --rights-file user-rights.json`,
+  iz admin users create bob --email bob@example.com --password secret123 --rights-file user-rights.json
+
+  # Create a user step by step
+  iz admin users create --interactive`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if userInteractive {
+			if userRightsFile != "" || userTenantRight != "" || userProjectRight != "" {
+				return fmt.Errorf("--interactive cannot be used with --rights-file, --tenant-right or --project-right")
+			}
+			client, err := izanami.NewAdminClient(cfg)
+			if err != nil {
+				return err
+			}
+			username := ""
+			if len(args) == 1 {
+				username = args[0]
+			}
+			userData, err := runUserWizard(cmd, client, username)
+			if err != nil || userData == nil {
+				return err
+			}
+			result, err := client.CreateUser(commandContext(), userData)
+			if err != nil {
+				return err
+			}
+			return printCreatedUser(cmd, result)
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("accepts 1 arg(s), received %d", len(args))
+		}
 		username := args[0]
 
 		if userEmail == "" {
@@ -245,29 +284,34 @@ Examples:
 			}
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		result, err := client.CreateUser(ctx, userData)
 		if err != nil {
 			return err
 		}
 
-		if output.Format(outputFormat) == output.JSON {
-			encoder := json.NewEncoder(cmd.OutOrStdout())
-			encoder.SetIndent("", "  ")
-			return encoder.Encode(result)
-		}
+		return printCreatedUser(cmd, result)
+	},
+}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ User created successfully\n\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "Username: %s\n", result.Username)
-		fmt.Fprintf(cmd.OutOrStderr(), "Email:    %s\n", result.Email)
-		fmt.Fprintf(cmd.OutOrStderr(), "Admin:    %t\n", result.Admin)
-		fmt.Fprintf(cmd.OutOrStderr(), "Type:     %s\n", result.UserType)
-		if result.DefaultTenant != nil && *result.DefaultTenant != "" {
-			fmt.Fprintf(cmd.OutOrStderr(), "Default Tenant: %s\n", *result.DefaultTenant)
-		}
+// printCreatedUser prints the user returned by users create
+func printCreatedUser(cmd *cobra.Command, result *izanami.User) error {
+	if output.Format(outputFormat) == output.JSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
 
-		return nil
-	},
+	fmt.Fprintf(statusOut(cmd), "✅ User created successfully\n\n")
+	fmt.Fprintf(statusOut(cmd), "Username: %s\n", result.Username)
+	fmt.Fprintf(statusOut(cmd), "Email:    %s\n", result.Email)
+	fmt.Fprintf(statusOut(cmd), "Admin:    %t\n", result.Admin)
+	fmt.Fprintf(statusOut(cmd), "Type:     %s\n", result.UserType)
+	if result.DefaultTenant != nil && *result.DefaultTenant != "" {
+		fmt.Fprintf(statusOut(cmd), "Default Tenant: %s\n", *result.DefaultTenant)
+	}
+
+	return nil
 }
 
 // usersUpdateCmd updates user information
@@ -309,23 +353,53 @@ Examples:
 			updateData["defaultTenant"] = userDefaultTenant
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.UpdateUser(ctx, username, updateData); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ User updated successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ User updated successfully\n")
 		return nil
 	},
 }
 
 // usersDeleteCmd deletes a user
 var usersDeleteCmd = &cobra.Command{
-	Use:         "delete <username>",
-	Short:       "Delete a user",
+	Use:         "delete <username>...",
+	Short:       "Delete users",
 	Annotations: map[string]string{"route": "DELETE /api/admin/users/:user"},
-	Args:        cobra.ExactArgs(1),
+	Long: `Delete a user. This operation cannot be undone.
+
+Izanami has no deactivated state: offboarding a user deletes it, with all its
+rights.
+
+Bulk mode:
+  With several usernames, --from-file (one username per line, blank lines and
+  # comments ignored, - for stdin), --dry-run or --transfer-owner-to, the users
+  are fetched first and their rights listed. The number of users must be typed
+  to confirm (or use --force). --dry-run only shows the rights and owned
+  features that would be removed.
+
+  --transfer-owner-to sets the "owner" metadata of the features owned by the
+  deleted users (see 'iz admin features owners') to another user or team before
+  they are deleted. Features are searched in --tenant, or in every tenant the
+  deleted users have rights on. Webhooks carry no ownership metadata and are
+  left as is.
+
+Examples:
+  # Delete a user
+  iz admin users delete johndoe
+
+  # Show what offboarding a list of users would remove
+  iz admin users delete --from-file leavers.txt --dry-run
+
+  # Hand their features over to a team, then delete them
+  iz admin users delete --from-file leavers.txt --transfer-owner-to team-payments`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || usersDeleteFromFile != "" || usersDeleteDryRun || usersDeleteTransferTo != "" {
+			return runUsersBatchDelete(cmd, args)
+		}
 		username := args[0]
 
 		// Confirm deletion unless --force is used
@@ -340,12 +414,12 @@ var usersDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.DeleteUser(ctx, username); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ User deleted successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ User deleted successfully\n")
 		return nil
 	},
 }
@@ -414,12 +488,12 @@ Examples:
 			return fmt.Errorf("no rights specified (use --admin, --tenant-right, or --rights-file)")
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.UpdateUserRights(ctx, username, rightsData); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ User rights updated successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ User rights updated successfully\n")
 		return nil
 	},
 }
@@ -438,14 +512,14 @@ var usersSearchCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		usernames, err := client.SearchUsers(ctx, query, usersSearchCount)
 		if err != nil {
 			return err
 		}
 
 		if len(usernames) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No users found")
+			fmt.Fprintln(statusOut(cmd), "No users found")
 			return nil
 		}
 
@@ -464,7 +538,7 @@ var usersListForTenantCmd = &cobra.Command{
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/users"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -472,14 +546,14 @@ var usersListForTenantCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		users, err := client.ListUsersForTenant(ctx, cfg.Tenant)
 		if err != nil {
 			return err
 		}
 
 		if len(users) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No users found for this tenant")
+			fmt.Fprintln(statusOut(cmd), "No users found for this tenant")
 			return nil
 		}
 
@@ -497,7 +571,7 @@ var usersGetForTenantCmd = &cobra.Command{
 		username := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -505,7 +579,7 @@ var usersGetForTenantCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		user, err := client.GetUserForTenant(ctx, cfg.Tenant, username)
 		if err != nil {
 			return err
@@ -536,7 +610,7 @@ Examples:
 		username := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -563,12 +637,12 @@ Examples:
 			return fmt.Errorf("no rights specified (use --tenant-right or --rights-file)")
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.UpdateUserTenantRights(ctx, cfg.Tenant, username, rightsData); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ User tenant rights updated successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ User tenant rights updated successfully\n")
 		return nil
 	},
 }
@@ -593,7 +667,7 @@ Example:
   iz admin users invite-to-tenant --tenant my-tenant --invite-file invitations.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		if usersInviteFile == "" {
@@ -615,12 +689,12 @@ Example:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.InviteUsersToTenant(ctx, cfg.Tenant, invitations); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Users invited to tenant successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ Users invited to tenant successfully\n")
 		return nil
 	},
 }
@@ -639,7 +713,7 @@ var usersListForProjectCmd = &cobra.Command{
 		project := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -647,14 +721,14 @@ var usersListForProjectCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		users, err := client.ListUsersForProject(ctx, cfg.Tenant, project)
 		if err != nil {
 			return err
 		}
 
 		if len(users) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No users found for this project")
+			fmt.Fprintln(statusOut(cmd), "No users found for this project")
 			return nil
 		}
 
@@ -666,7 +740,7 @@ var usersListForProjectCmd = &cobra.Command{
 var usersUpdateProjectRightsCmd = &cobra.Command{
 	Use:         "update-project-rights <username> <project>",
 	Short:       "Update user's rights for a specific project",
-	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/users/:user/rights"},
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/users/:user/rights", "projectArg": "1"},
 	Args:        cobra.ExactArgs(2),
 	Long: `Update user's rights for a specific project.
 
@@ -684,7 +758,7 @@ Examples:
 		project := args[1]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -711,12 +785,12 @@ Examples:
 			return fmt.Errorf("no rights specified (use --project-right or --rights-file)")
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.UpdateUserProjectRights(ctx, cfg.Tenant, project, username, rightsData); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ User project rights updated successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ User project rights updated successfully\n")
 		return nil
 	},
 }
@@ -725,7 +799,7 @@ Examples:
 var usersInviteToProjectCmd = &cobra.Command{
 	Use:         "invite-to-project <project>",
 	Short:       "Invite multiple users to a project with specified rights",
-	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/projects/:project/users/_invitation"},
+	Annotations: map[string]string{"route": "POST /api/admin/tenants/:tenant/projects/:project/users/_invitation", "projectArg": "0"},
 	Args:        cobra.ExactArgs(1),
 	Long: `Invite multiple users to a project (bulk operation).
 
@@ -744,7 +818,7 @@ Example:
 		project := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		if usersInviteFile == "" {
@@ -766,12 +840,12 @@ Example:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		if err := client.InviteUsersToProject(ctx, cfg.Tenant, project, invitations); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Users invited to project successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ Users invited to project successfully\n")
 		return nil
 	},
 }
@@ -808,6 +882,7 @@ func init() {
 	usersCreateCmd.Flags().StringVar(&userRightsFile, "rights-file", "", "Path to JSON file containing user rights")
 	usersCreateCmd.Flags().StringVar(&userTenantRight, "tenant-right", "", "Tenant right (format: tenant:level)")
 	usersCreateCmd.Flags().StringVar(&userProjectRight, "project-right", "", "Project right (format: tenant:project:level)")
+	usersCreateCmd.Flags().BoolVar(&userInteractive, "interactive", false, "Prompt for each field and right interactively")
 
 	// Update command flags
 	usersUpdateCmd.Flags().StringVar(&userEmail, "email", "", "New email address")
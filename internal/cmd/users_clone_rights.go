@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	usersCloneRightsDryRun bool
+	usersCloneRightsForce  bool
+)
+
+// usersCloneRightsCmd copies the rights of a user to another user
+var usersCloneRightsCmd = &cobra.Command{
+	Use:         "clone-rights <from-user> <to-user>",
+	Short:       "Copy the rights of a user to another user",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/users/:user/rights"},
+	Long: `Copy the rights of a user to another user, e.g. to onboard a new team member
+with the same access as a colleague.
+
+Tenant, project, key and webhook rights are copied, with the tenant default
+rights. Rights are only added or raised: a right the target user already has
+at a higher level is kept. The global admin status is never copied; use
+'iz admin users update-rights <user> --admin' for that.
+
+All tenants the source user has rights on are copied unless --tenant limits the
+copy to one tenant. The rights that will be granted are shown before anything
+is changed.
+
+Examples:
+  # Preview, confirm and copy all rights of alice to bob
+  iz admin users clone-rights alice bob
+
+  # Only rights on one tenant, without confirmation
+  iz admin users clone-rights alice bob --tenant my-tenant --force
+
+  # Only show what would be granted
+  iz admin users clone-rights alice bob --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := args[0], args[1]
+		if from == to {
+			return fmt.Errorf("source and target users must be different")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := commandContext()
+		source, err := izanami.GetUser(client, ctx, from, izanami.ParseUser)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", from, err)
+		}
+		target, err := izanami.GetUser(client, ctx, to, izanami.ParseUser)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", to, err)
+		}
+
+		tenant := ""
+		if cmd.Flags().Changed("tenant") {
+			tenant = cfg.Tenant
+			if _, ok := source.Rights.Tenants[tenant]; !ok {
+				return fmt.Errorf("user %s has no rights on tenant %s", from, tenant)
+			}
+		}
+
+		plan := izanami.PlanRightsClone(source, target, tenant)
+		if source.Admin && !target.Admin {
+			fmt.Fprintf(statusOut(cmd), "Note: %s is a global admin; admin status is not copied\n", from)
+		}
+		if len(plan.Changes) == 0 {
+			fmt.Fprintf(statusOut(cmd), "%s already has all the rights of %s\n", to, from)
+			return nil
+		}
+
+		if outputFormat == "table" {
+			printRightChanges(cmd.OutOrStdout(), plan.Changes)
+		} else if err := output.PrintTo(cmd.OutOrStdout(), plan, output.Format(outputFormat)); err != nil {
+			return err
+		}
+
+		tenants := make([]string, 0, len(plan.Tenants))
+		for name := range plan.Tenants {
+			tenants = append(tenants, name)
+		}
+		sort.Strings(tenants)
+
+		if usersCloneRightsDryRun {
+			fmt.Fprintf(statusOut(cmd), "Dry run: %d right(s) would be granted to %s on %d tenant(s)\n", len(plan.Changes), to, len(tenants))
+			return nil
+		}
+		if !usersCloneRightsForce {
+			if !confirmAction(cmd, fmt.Sprintf("Grant %d right(s) to %s?", len(plan.Changes), to)) {
+				return nil
+			}
+		}
+
+		for i, name := range tenants {
+			if err := client.UpdateUserTenantRights(ctx, name, to, izanami.TenantRightUpdate(plan.Tenants[name])); err != nil {
+				if i > 0 {
+					return fmt.Errorf("failed to update rights on tenant %s (rights on %d tenant(s) before it were updated): %w", name, i, err)
+				}
+				return fmt.Errorf("failed to update rights on tenant %s: %w", name, err)
+			}
+		}
+
+		fmt.Fprintf(statusOut(cmd), "✅ Granted %d right(s) of %s to %s\n", len(plan.Changes), from, to)
+		return nil
+	},
+}
+
+// printRightChanges displays the rights granted by a clone as a table
+func printRightChanges(w io.Writer, changes []izanami.RightChange) {
+	rows := make([][]string, len(changes))
+	for i, change := range changes {
+		current := change.Current
+		if current == "" {
+			current = "-"
+		}
+		rows[i] = []string{change.Tenant, change.Kind, change.Name, current, change.Granted}
+	}
+	printGroupedTable(w, []string{"TENANT", "RIGHT", "NAME", "CURRENT", "GRANTED"}, rows)
+}
+
+func init() {
+	usersCmd.AddCommand(usersCloneRightsCmd)
+
+	usersCloneRightsCmd.Flags().BoolVar(&usersCloneRightsDryRun, "dry-run", false, "Show the rights that would be granted without changing them")
+	usersCloneRightsCmd.Flags().BoolVarP(&usersCloneRightsForce, "force", "f", false, "Skip confirmation prompt")
+}
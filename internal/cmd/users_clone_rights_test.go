@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestUsersCloneRightsCmd(t *testing.T) {
+	var updates []string
+	var body map[string]interface{}
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/users/alice":
+			json.NewEncoder(w).Encode(izanami.User{Username: "alice", Rights: izanami.UserRights{Tenants: map[string]izanami.TenantRight{
+				"acme": {Level: "Write", Projects: map[string]izanami.ProjectRight{"shop": {Level: "Admin"}}},
+			}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/users/bob":
+			json.NewEncoder(w).Encode(izanami.User{Username: "bob"})
+		case r.Method == http.MethodPut:
+			updates = append(updates, r.URL.Path)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer func() { usersCloneRightsDryRun, usersCloneRightsForce = false, false }()
+	outputFormat = "table"
+
+	var buf bytes.Buffer
+	usersCloneRightsCmd.SetOut(&buf)
+	defer usersCloneRightsCmd.SetOut(nil)
+
+	usersCloneRightsDryRun = true
+	require.NoError(t, usersCloneRightsCmd.RunE(usersCloneRightsCmd, []string{"alice", "bob"}))
+	assert.Regexp(t, `acme\s+project\s+shop\s+-\s+Admin`, buf.String())
+	assert.Contains(t, buf.String(), "Dry run: 2 right(s) would be granted to bob on 1 tenant(s)")
+	assert.Empty(t, updates)
+
+	usersCloneRightsDryRun, usersCloneRightsForce = false, true
+	require.NoError(t, usersCloneRightsCmd.RunE(usersCloneRightsCmd, []string{"alice", "bob"}))
+	assert.Equal(t, []string{"/api/admin/tenants/acme/users/bob"}, updates)
+	assert.Equal(t, "Write", body["level"])
+	assert.Equal(t, map[string]interface{}{"shop": map[string]interface{}{"level": "Admin"}}, body["projects"])
+
+	assert.ErrorContains(t, usersCloneRightsCmd.RunE(usersCloneRightsCmd, []string{"alice", "alice"}), "must be different")
+}
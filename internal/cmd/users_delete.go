@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	usersDeleteFromFile   string
+	usersDeleteDryRun     bool
+	usersDeleteTransferTo string
+)
+
+// runUsersBatchDelete deletes the users given as arguments and in --from-file,
+// after listing their rights and transferring the features they own
+func runUsersBatchDelete(cmd *cobra.Command, args []string) error {
+	if usersDeleteFromFile == "-" && !usersDeleteForce && !assumeYes && !usersDeleteDryRun {
+		return fmt.Errorf("--from-file - reads stdin, which is then unavailable for confirmation: use --force, --yes or --dry-run")
+	}
+	usernames, err := readUsernames(cmd, args, usersDeleteFromFile)
+	if err != nil {
+		return err
+	}
+	if len(usernames) == 0 {
+		return fmt.Errorf("no users to delete: give usernames or --from-file")
+	}
+	transferTo := strings.TrimSpace(usersDeleteTransferTo)
+	for _, username := range usernames {
+		if transferTo != "" && strings.EqualFold(username, transferTo) {
+			return fmt.Errorf("cannot transfer ownership to %s, which is being deleted", transferTo)
+		}
+	}
+
+	client, err := izanami.NewAdminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext()
+	removals := make([]izanami.UserRemoval, len(usernames))
+	var tenants []string
+	seenTenants := make(map[string]bool)
+	for i, username := range usernames {
+		user, err := izanami.GetUser(client, ctx, username, izanami.ParseUser)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", username, err)
+		}
+		removals[i] = izanami.UserRemoval{
+			Username:      user.Username,
+			Admin:         user.Admin,
+			Rights:        izanami.UserRightsOf(user),
+			OwnedFeatures: []izanami.OwnedFeature{},
+		}
+		for _, tenant := range izanami.UserTenants(user) {
+			if !seenTenants[tenant] {
+				seenTenants[tenant] = true
+				tenants = append(tenants, tenant)
+			}
+		}
+	}
+
+	// Owned features are looked up for dry runs and transfers only
+	if usersDeleteDryRun || transferTo != "" {
+		sort.Strings(tenants)
+		if cmd.Flags().Changed("tenant") && cfg.Tenant != "" {
+			tenants = []string{cfg.Tenant}
+		}
+		owned, err := izanami.FindOwnedFeatures(client, ctx, tenants, usernames)
+		if err != nil {
+			return fmt.Errorf("failed to look up owned features: %w", err)
+		}
+		for i := range removals {
+			if features := owned[usernames[i]]; features != nil {
+				removals[i].OwnedFeatures = features
+			}
+		}
+	}
+
+	if outputFormat == "json" && usersDeleteDryRun {
+		return output.PrintTo(cmd.OutOrStdout(), removals, output.JSON)
+	}
+	out := statusOut(cmd)
+	printUserRemovals(out, removals, usersDeleteDryRun || transferTo != "")
+	if usersDeleteDryRun {
+		fmt.Fprintf(out, "Dry run: %d user(s) would be deleted\n", len(removals))
+		return nil
+	}
+
+	if !usersDeleteForce {
+		count := strconv.Itoa(len(removals))
+		if !confirmTyped(cmd, fmt.Sprintf("Delete %s user(s)? This cannot be undone.", count), count) {
+			return nil
+		}
+	}
+
+	if transferTo != "" {
+		for _, removal := range removals {
+			transferred, err := izanami.TransferFeatureOwnership(client, ctx, removal.OwnedFeatures, transferTo)
+			if err != nil {
+				return fmt.Errorf("failed to transfer the features of %s to %s (%d transferred, no user deleted): %w", removal.Username, transferTo, transferred, err)
+			}
+			if transferred > 0 {
+				fmt.Fprintf(out, "%s %d feature(s) of %s to %s\n", color.GreenString("transferred"), transferred, removal.Username, transferTo)
+			}
+		}
+	}
+
+	failed := 0
+	for _, removal := range removals {
+		if err := client.DeleteUser(ctx, removal.Username); err != nil {
+			failed++
+			fmt.Fprintf(out, "%s  %s: %v\n", color.RedString("failed"), removal.Username, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s %s\n", color.GreenString("deleted"), removal.Username)
+	}
+	fmt.Fprintf(out, "Deleted %d of %d user(s), %d failed\n", len(removals)-failed, len(removals), failed)
+	if failed > 0 {
+		return fmt.Errorf("%d user(s) could not be deleted", failed)
+	}
+	return nil
+}
+
+// readUsernames returns the usernames of args followed by those of a file (one
+// per line, - for stdin), without duplicates, blank lines or # comments
+func readUsernames(cmd *cobra.Command, args []string, path string) ([]string, error) {
+	return readArgsAndFile(cmd, args, path, "users")
+}
+
+// printUserRemovals lists the rights, and optionally the owned features, of
+// the users about to be deleted
+func printUserRemovals(w io.Writer, removals []izanami.UserRemoval, withFeatures bool) {
+	for _, removal := range removals {
+		admin := ""
+		if removal.Admin {
+			admin = ", global admin"
+		}
+		fmt.Fprintf(w, "%s (%d right(s)%s)\n", removal.Username, len(removal.Rights), admin)
+		if len(removal.Rights) > 0 {
+			rows := make([][]string, len(removal.Rights))
+			for i, right := range removal.Rights {
+				rows[i] = []string{right.Tenant, right.Kind, right.Name, right.Level}
+			}
+			printGroupedTable(w, []string{"TENANT", "RIGHT", "NAME", "LEVEL"}, rows)
+		}
+		if withFeatures && len(removal.OwnedFeatures) > 0 {
+			fmt.Fprintf(w, "  Owns %d feature(s):\n", len(removal.OwnedFeatures))
+			for _, feature := range removal.OwnedFeatures {
+				fmt.Fprintf(w, "  - %s/%s/%s\n", feature.Tenant, feature.Project, feature.Name)
+			}
+		}
+	}
+}
+
+func init() {
+	usersDeleteCmd.Flags().StringVar(&usersDeleteFromFile, "from-file", "", "Delete the users of this file, one username per line (- for stdin)")
+	usersDeleteCmd.Flags().BoolVar(&usersDeleteDryRun, "dry-run", false, "List the rights and owned features of the users without deleting them")
+	usersDeleteCmd.Flags().StringVar(&usersDeleteTransferTo, "transfer-owner-to", "", "Set this owner on the features owned by the deleted users first")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestReadUsernames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leavers.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# leavers\nbob\n\n  carol  \nalice\n"), 0600))
+
+	usernames, err := readUsernames(usersDeleteCmd, []string{"alice"}, path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, usernames)
+
+	_, err = readUsernames(usersDeleteCmd, nil, filepath.Join(t.TempDir(), "missing.txt"))
+	assert.ErrorContains(t, err, "failed to open users file")
+}
+
+func TestUsersDeleteCmd_Batch(t *testing.T) {
+	var deleted, updated []string
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/users/alice":
+			json.NewEncoder(w).Encode(izanami.User{Username: "alice", Rights: izanami.UserRights{Tenants: map[string]izanami.TenantRight{
+				"acme": {Level: "Write", Projects: map[string]izanami.ProjectRight{"shop": {Level: "Admin"}}},
+			}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/users/bob":
+			json.NewEncoder(w).Encode(izanami.User{Username: "bob"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id": "f1", "name": "checkout", "project": "shop", "metadata": {"owner": "alice"}}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features/f1":
+			io.WriteString(w, `{"id": "f1", "name": "checkout", "project": "shop", "metadata": {"owner": "alice"}}`)
+		case r.Method == http.MethodPut:
+			updated = append(updated, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer func() {
+		usersDeleteFromFile, usersDeleteDryRun, usersDeleteTransferTo, usersDeleteForce = "", false, "", false
+	}()
+	outputFormat = "table"
+
+	var buf bytes.Buffer
+	usersDeleteCmd.SetOut(&buf)
+	usersDeleteCmd.SetErr(&buf)
+	defer func() {
+		usersDeleteCmd.SetOut(nil)
+		usersDeleteCmd.SetErr(nil)
+		usersDeleteCmd.SetIn(nil)
+	}()
+
+	usersDeleteDryRun = true
+	require.NoError(t, usersDeleteCmd.RunE(usersDeleteCmd, []string{"alice", "bob"}))
+	assert.Contains(t, buf.String(), "alice (2 right(s))")
+	assert.Regexp(t, `acme\s+project\s+shop\s+Admin`, buf.String())
+	assert.Contains(t, buf.String(), "- acme/shop/checkout")
+	assert.Contains(t, buf.String(), "Dry run: 2 user(s) would be deleted")
+	assert.Empty(t, deleted)
+
+	// Typed confirmation, then ownership transfer before deletion
+	buf.Reset()
+	usersDeleteDryRun, usersDeleteTransferTo = false, "team-payments"
+	usersDeleteCmd.SetIn(strings.NewReader("2\n"))
+	require.NoError(t, usersDeleteCmd.RunE(usersDeleteCmd, []string{"alice", "bob"}))
+	assert.Equal(t, []string{"/api/admin/tenants/acme/features/f1"}, updated)
+	assert.Equal(t, []string{"/api/admin/users/alice", "/api/admin/users/bob"}, deleted)
+	assert.Contains(t, buf.String(), "Deleted 2 of 2 user(s), 0 failed")
+
+	assert.ErrorContains(t, usersDeleteCmd.RunE(usersDeleteCmd, []string{"team-payments"}), "being deleted")
+	usersDeleteTransferTo = ""
+	usersDeleteFromFile = "-"
+	assert.ErrorContains(t, usersDeleteCmd.RunE(usersDeleteCmd, nil), "use --force, --yes or --dry-run")
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+)
+
+var (
+	usersGrantLevel     string
+	usersGrantExpiresIn string
+	usersRevokeDryRun   bool
+	usersGrantsListAll  bool
+)
+
+// usersGrantCmd grants a project right for a limited time
+var usersGrantCmd = &cobra.Command{
+	Use:         "grant <username>",
+	Short:       "Grant a project right for a limited time",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/users/:user/rights"},
+	Long: `Grant a user a right on a project for a limited time, e.g. write access for
+the length of an incident.
+
+Izanami rights do not expire, so the grant is recorded in the CLI config
+directory (grants.yaml) with the right the user had before. Run
+'iz admin users revoke-expired' (e.g. from cron) to give expired grants back
+their previous right, and 'iz admin users grants list' to see outstanding
+grants. A grant can only raise a right; granting again moves the expiry.
+
+Durations accept Go units or days: 30m, 8h, 2d.
+
+Examples:
+  # Write access to a project for 8 hours
+  iz admin users grant johndoe --tenant my-tenant --project shop --level Write --expires-in 8h
+
+  # Later, e.g. from cron
+  iz admin users revoke-expired`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.Tenant == "" {
+			return errors.Errorf(errors.MsgTenantRequired)
+		}
+		if cfg.Project == "" {
+			return fmt.Errorf("--project is required")
+		}
+		expiresIn, err := parseDayDuration(usersGrantExpiresIn)
+		if err != nil || expiresIn <= 0 {
+			return fmt.Errorf("--expires-in must be a positive duration (e.g. 8h, 2d)")
+		}
+
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		grantedBy := cfg.Username
+		if grantedBy == "" {
+			grantedBy = cfg.PersonalAccessTokenUsername
+		}
+		now := time.Now().UTC()
+		grant, err := izanami.GrantTemporaryProjectRight(client, commandContext(), izanami.TemporaryGrant{
+			URL:       cfg.LeaderURL,
+			Tenant:    cfg.Tenant,
+			Project:   cfg.Project,
+			User:      args[0],
+			Level:     usersGrantLevel,
+			GrantedBy: grantedBy,
+			GrantedAt: now,
+			ExpiresAt: now.Add(expiresIn),
+		})
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), grant, output.JSON)
+		}
+		previous := grant.Previous
+		if previous == "" {
+			previous = "no right"
+		}
+		fmt.Fprintf(statusOut(cmd), "✅ Granted %s on %s to %s until %s (reverts to %s)\n",
+			grant.Level, grant.Project, grant.User, grant.ExpiresAt.Local().Format(time.RFC3339), previous)
+		return nil
+	},
+}
+
+// usersRevokeExpiredCmd reverts expired temporary grants
+var usersRevokeExpiredCmd = &cobra.Command{
+	Use:         "revoke-expired",
+	Short:       "Revert expired temporary grants",
+	Annotations: map[string]string{"route": "PUT /api/admin/tenants/:tenant/projects/:project/users/:user/rights"},
+	Long: `Give users back the project right they had before each expired temporary grant
+made on the current server with 'iz admin users grant', and forget the grants.
+
+A right changed since it was granted is left as is. Grants that fail to revert
+are kept and retried on the next run. Meant to run periodically, e.g. from cron.
+
+Examples:
+  iz admin users revoke-expired
+  iz admin users revoke-expired --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := izanami.NewAdminClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		revocations, err := izanami.RevokeExpiredGrants(client, commandContext(), time.Now(), usersRevokeDryRun)
+		if err != nil {
+			return err
+		}
+		if outputFormat == "json" {
+			if err := output.PrintTo(cmd.OutOrStdout(), revocations, output.JSON); err != nil {
+				return err
+			}
+		} else if len(revocations) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No expired grants")
+			return nil
+		} else {
+			printGrantRevocations(cmd.OutOrStdout(), revocations, usersRevokeDryRun)
+		}
+
+		failed := 0
+		for _, revocation := range revocations {
+			if revocation.Error != "" {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to revert %d of %d expired grant(s)", failed, len(revocations))
+		}
+		return nil
+	},
+}
+
+// usersGrantsCmd groups temporary grant commands
+var usersGrantsCmd = &cobra.Command{
+	Use:   "grants",
+	Short: "Manage temporary grants",
+}
+
+// usersGrantsListCmd lists outstanding temporary grants
+var usersGrantsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List outstanding temporary grants",
+	Long: `List the temporary grants recorded by 'iz admin users grant' on the current
+server and not reverted yet, soonest expiry first. Expired grants are marked;
+revert them with 'iz admin users revoke-expired'.
+
+Examples:
+  iz admin users grants list
+  iz admin users grants list --all-servers -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		grants, err := izanami.LoadGrants()
+		if err != nil {
+			return err
+		}
+		if !usersGrantsListAll {
+			grants = izanami.GrantsForURL(grants, cfg.LeaderURL)
+		}
+		if outputFormat == "json" {
+			if grants == nil {
+				grants = []izanami.TemporaryGrant{}
+			}
+			return output.PrintTo(cmd.OutOrStdout(), grants, output.JSON)
+		}
+		if len(grants) == 0 {
+			fmt.Fprintln(statusOut(cmd), "No temporary grants")
+			return nil
+		}
+		printTemporaryGrants(cmd.OutOrStdout(), grants, time.Now())
+		return nil
+	},
+}
+
+// printTemporaryGrants displays temporary grants as a table
+func printTemporaryGrants(w io.Writer, grants []izanami.TemporaryGrant, now time.Time) {
+	rows := make([][]string, len(grants))
+	for i, grant := range grants {
+		expires := grant.ExpiresAt.Local().Format("2006-01-02 15:04")
+		if grant.Expired(now) {
+			expires += " (expired)"
+		} else {
+			expires += " (in " + grant.ExpiresAt.Sub(now).Round(time.Minute).String() + ")"
+		}
+		previous := grant.Previous
+		if previous == "" {
+			previous = "-"
+		}
+		rows[i] = []string{grant.User, grant.Tenant, grant.Project, grant.Level, previous, expires, grant.GrantedBy}
+	}
+	printGroupedTable(w, []string{"USER", "TENANT", "PROJECT", "LEVEL", "PREVIOUS", "EXPIRES", "GRANTED BY"}, rows)
+}
+
+// printGrantRevocations displays the outcome of reverting expired grants
+func printGrantRevocations(w io.Writer, revocations []izanami.GrantRevocation, dryRun bool) {
+	rows := make([][]string, len(revocations))
+	for i, revocation := range revocations {
+		grant := revocation.Grant
+		outcome := "removed"
+		if revocation.RestoredTo != "" {
+			outcome = "restored to " + revocation.RestoredTo
+		}
+		switch {
+		case revocation.Error != "":
+			outcome = "failed: " + revocation.Error
+		case revocation.Skipped != "":
+			outcome = "left as is: " + revocation.Skipped
+		case dryRun:
+			outcome = "would be " + outcome
+		}
+		rows[i] = []string{grant.User, grant.Tenant, grant.Project, grant.Level, outcome}
+	}
+	printGroupedTable(w, []string{"USER", "TENANT", "PROJECT", "GRANTED", "OUTCOME"}, rows)
+}
+
+func init() {
+	usersCmd.AddCommand(usersGrantCmd)
+	usersCmd.AddCommand(usersRevokeExpiredCmd)
+	usersCmd.AddCommand(usersGrantsCmd)
+	usersGrantsCmd.AddCommand(usersGrantsListCmd)
+
+	usersGrantCmd.Flags().StringVar(&usersGrantLevel, "level", "", "Project right to grant: Read, Update, Write or Admin")
+	usersGrantCmd.Flags().StringVar(&usersGrantExpiresIn, "expires-in", "", "How long the right lasts (e.g. 30m, 8h, 2d)")
+	usersGrantCmd.MarkFlagRequired("level")
+	usersGrantCmd.MarkFlagRequired("expires-in")
+	usersRevokeExpiredCmd.Flags().BoolVar(&usersRevokeDryRun, "dry-run", false, "Show the grants that would be reverted without changing them")
+	usersGrantsListCmd.Flags().BoolVar(&usersGrantsListAll, "all-servers", false, "List the grants made on every server, not only the current one")
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func TestPrintTemporaryGrants(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	grants := []izanami.TemporaryGrant{
+		{User: "alice", Tenant: "acme", Project: "shop", Level: "Write", Previous: "Read", GrantedBy: "admin", ExpiresAt: now.Add(-time.Hour)},
+		{User: "bob", Tenant: "acme", Project: "shop", Level: "Admin", ExpiresAt: now.Add(90 * time.Minute)},
+	}
+
+	var buf bytes.Buffer
+	printTemporaryGrants(&buf, grants, now)
+	out := buf.String()
+	assert.Regexp(t, `alice\s+acme\s+shop\s+Write\s+Read\s+.*\(expired\)\s+admin`, out)
+	assert.Regexp(t, `bob\s+acme\s+shop\s+Admin\s+-\s+.*\(in 1h30m0s\)`, out)
+}
+
+func TestPrintGrantRevocations(t *testing.T) {
+	grant := izanami.TemporaryGrant{User: "alice", Tenant: "acme", Project: "shop", Level: "Write"}
+	revocations := []izanami.GrantRevocation{
+		{Grant: grant, RestoredTo: "Read"},
+		{Grant: grant},
+		{Grant: grant, Skipped: `right changed to "Admin" since the grant`},
+		{Grant: grant, Error: "boom"},
+	}
+
+	var buf bytes.Buffer
+	printGrantRevocations(&buf, revocations, false)
+	out := buf.String()
+	assert.Contains(t, out, "restored to Read")
+	assert.Contains(t, out, "removed")
+	assert.Contains(t, out, "left as is")
+	assert.Contains(t, out, "failed: boom")
+
+	buf.Reset()
+	printGrantRevocations(&buf, revocations[:1], true)
+	assert.Contains(t, buf.String(), "would be restored to Read")
+}
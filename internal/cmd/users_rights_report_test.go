@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+func testRightsMatrix() *izanami.RightsMatrix {
+	return &izanami.RightsMatrix{
+		Tenant: "acme",
+		Resources: []izanami.RightsResource{
+			{Type: "project", Name: "shop"},
+			{Type: "key", Name: "backend"},
+		},
+		Users: []izanami.RightsMatrixUser{
+			{Username: "ann", TenantLevel: "Read", Rights: map[string]izanami.RightsCell{
+				"project:shop": {Level: "Write", Source: izanami.RightSourceExplicit},
+				"key:backend":  {Level: "Read", Source: izanami.RightSourceDefault},
+			}},
+			{Username: "root", Admin: true, TenantLevel: "Read", Rights: map[string]izanami.RightsCell{
+				"project:shop": {Level: "Admin", Source: izanami.RightSourceAdmin},
+				"key:backend":  {Level: "Admin", Source: izanami.RightSourceAdmin},
+			}},
+			{Username: "zoe", TenantLevel: "Read", Rights: map[string]izanami.RightsCell{}},
+		},
+	}
+}
+
+func TestPrintRightsMatrixCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printRightsMatrixCSV(&buf, testRightsMatrix()))
+
+	assert.Equal(t, "user,tenant,project:shop,key:backend\n"+
+		"ann,Read,Write,Read*\n"+
+		"root,Admin!,Admin!,Admin!\n"+
+		"zoe,Read,,\n", buf.String())
+}
+
+func TestPrintRightsMatrixTable(t *testing.T) {
+	var buf bytes.Buffer
+	printRightsMatrixTable(&buf, testRightsMatrix())
+
+	out := buf.String()
+	assert.Contains(t, out, "project:shop")
+	assert.Contains(t, out, "Read*")
+	assert.Contains(t, out, "Admin!")
+}
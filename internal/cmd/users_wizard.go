@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"golang.org/x/term"
+)
+
+var (
+	// Right levels offered by the users create wizard
+	wizardTenantLevels  = []string{"Read", "Write", "Admin"}
+	wizardProjectLevels = []string{"Read", "Update", "Write", "Admin"}
+)
+
+// readHiddenInput reads a secret without echo when stdin is a terminal,
+// or a plain line otherwise (e.g. piped input in scripts and tests)
+var readHiddenInput = func(reader *bufio.Reader) (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		secret, err := term.ReadPassword(int(syscall.Stdin))
+		return string(secret), err
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// userWizard prompts for the fields of a new user
+type userWizard struct {
+	client *izanami.AdminClient
+	reader *bufio.Reader
+	out    io.Writer
+}
+
+// runUserWizard interactively builds the payload of users create. Flag values are
+// offered as defaults. Returns nil when the user cancels at the final confirmation.
+func runUserWizard(cmd *cobra.Command, client *izanami.AdminClient, username string) (map[string]interface{}, error) {
+	w := &userWizard{client: client, reader: bufio.NewReader(cmd.InOrStdin()), out: statusOut(cmd)}
+	ctx := commandContext()
+
+	username, err := w.ask("Username", username, true)
+	if err != nil {
+		return nil, err
+	}
+	email, err := w.ask("Email", userEmail, true)
+	if err != nil {
+		return nil, err
+	}
+	password, err := w.askPassword()
+	if err != nil {
+		return nil, err
+	}
+	admin, err := w.confirm("Global admin", userAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	userData := map[string]interface{}{
+		"username": username,
+		"email":    email,
+		"password": password,
+		"admin":    admin,
+	}
+	if userType != "" {
+		userData["userType"] = userType
+	}
+
+	tenants, err := izanami.ListTenants(client, ctx, nil, izanami.ParseTenants)
+	if err != nil {
+		return nil, err
+	}
+	tenantNames := make([]string, len(tenants))
+	for i, tenant := range tenants {
+		tenantNames[i] = tenant.Name
+	}
+
+	if len(tenantNames) > 0 {
+		def := userDefaultTenant
+		if def == "" {
+			def = "(none)"
+		}
+		index, err := w.selectOption("Default tenant", append([]string{"(none)"}, tenantNames...), def)
+		if err != nil {
+			return nil, err
+		}
+		if index > 0 {
+			userData["defaultTenant"] = tenantNames[index-1]
+		}
+	}
+
+	// Admins have every right, so only ask for rights of regular users
+	if !admin && len(tenantNames) > 0 {
+		rights, err := w.askRights(ctx, tenantNames)
+		if err != nil {
+			return nil, err
+		}
+		if len(rights) > 0 {
+			userData["rights"] = map[string]interface{}{"tenants": rights}
+		}
+	}
+
+	preview := make(map[string]interface{}, len(userData))
+	for key, value := range userData {
+		preview[key] = value
+	}
+	preview["password"] = "********"
+	payload, err := json.MarshalIndent(preview, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(w.out, "\nUser to create:\n%s\n\n", payload)
+
+	ok, err := w.confirm("Create this user", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		fmt.Fprintln(w.out, "Cancelled")
+		return nil, nil
+	}
+	return userData, nil
+}
+
+// askRights walks through tenant and project right assignment
+func (w *userWizard) askRights(ctx context.Context, tenantNames []string) (map[string]interface{}, error) {
+	rights := make(map[string]interface{})
+	for {
+		more, err := w.confirm("Add a tenant right", false)
+		if err != nil || !more {
+			return rights, err
+		}
+
+		index, err := w.selectOption("Tenant", tenantNames, "")
+		if err != nil {
+			return nil, err
+		}
+		tenant := tenantNames[index]
+		index, err = w.selectOption("Right on tenant "+tenant, wizardTenantLevels, "Read")
+		if err != nil {
+			return nil, err
+		}
+		tenantRight := map[string]interface{}{"level": wizardTenantLevels[index]}
+
+		projects, err := izanami.ListProjects(w.client, ctx, tenant, izanami.ParseProjects)
+		if err != nil {
+			return nil, err
+		}
+		projectNames := make([]string, len(projects))
+		for i, project := range projects {
+			projectNames[i] = project.Name
+		}
+
+		projectRights := make(map[string]interface{})
+		for len(projectNames) > 0 {
+			more, err := w.confirm("Add a project right in "+tenant, false)
+			if err != nil {
+				return nil, err
+			}
+			if !more {
+				break
+			}
+			index, err := w.selectOption("Project", projectNames, "")
+			if err != nil {
+				return nil, err
+			}
+			project := projectNames[index]
+			index, err = w.selectOption("Right on project "+project, wizardProjectLevels, "Read")
+			if err != nil {
+				return nil, err
+			}
+			projectRights[project] = map[string]interface{}{"level": wizardProjectLevels[index]}
+		}
+		if len(projectRights) > 0 {
+			tenantRight["projects"] = projectRights
+		}
+		rights[tenant] = tenantRight
+	}
+}
+
+// readLine reads one trimmed line, failing when the input ends
+func (w *userWizard) readLine() (string, error) {
+	line, err := w.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", fmt.Errorf("input ended before the user was complete")
+		}
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ask prompts for a value, returning the default on empty input
+func (w *userWizard) ask(label, def string, required bool) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", label)
+		}
+		value, err := w.readLine()
+		if err != nil {
+			return "", err
+		}
+		if value == "" {
+			value = def
+		}
+		if value != "" || !required {
+			return value, nil
+		}
+		fmt.Fprintf(w.out, "%s is required\n", label)
+	}
+}
+
+// askPassword prompts twice for a hidden password, or uses --password when given
+func (w *userWizard) askPassword() (string, error) {
+	if userPassword != "" {
+		return userPassword, nil
+	}
+	for {
+		fmt.Fprint(w.out, "Password: ")
+		password, err := readHiddenInput(w.reader)
+		fmt.Fprintln(w.out)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		if password == "" {
+			fmt.Fprintln(w.out, "Password is required")
+			continue
+		}
+		fmt.Fprint(w.out, "Confirm password: ")
+		confirmation, err := readHiddenInput(w.reader)
+		fmt.Fprintln(w.out)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		if confirmation == password {
+			return password, nil
+		}
+		fmt.Fprintln(w.out, "Passwords do not match")
+	}
+}
+
+// confirm asks a yes/no question
+func (w *userWizard) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		fmt.Fprintf(w.out, "%s? (%s): ", question, hint)
+		answer, err := w.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// selectOption shows a numbered menu and returns the index of the chosen option,
+// which can be entered by number or by name
+func (w *userWizard) selectOption(label string, options []string, def string) (int, error) {
+	defIndex := -1
+	fmt.Fprintf(w.out, "%s:\n", label)
+	for i, option := range options {
+		fmt.Fprintf(w.out, "  %d) %s\n", i+1, option)
+		if option == def {
+			defIndex = i
+		}
+	}
+	for {
+		if defIndex >= 0 {
+			fmt.Fprintf(w.out, "Choice [%d]: ", defIndex+1)
+		} else {
+			fmt.Fprint(w.out, "Choice: ")
+		}
+		answer, err := w.readLine()
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" && defIndex >= 0 {
+			return defIndex, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		for i, option := range options {
+			if option == answer {
+				return i, nil
+			}
+		}
+		fmt.Fprintf(w.out, "Enter a number between 1 and %d\n", len(options))
+	}
+}
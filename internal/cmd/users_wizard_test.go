@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupUserWizardServer serves two tenants with projects, recording the created user
+func setupUserWizardServer(t *testing.T) *map[string]interface{} {
+	t.Helper()
+	var created map[string]interface{}
+	setupServerTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/admin/tenants":
+			io.WriteString(w, `[{"name": "acme"}, {"name": "globex"}]`)
+		case "GET /api/admin/tenants/acme/projects":
+			io.WriteString(w, `[{"name": "shop"}, {"name": "blog"}]`)
+		case "POST /api/admin/users":
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &created))
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"username": "jane", "email": "jane@example.com", "admin": false, "userType": "INTERNAL"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "not found"}`)
+		}
+	})
+
+	t.Cleanup(func() {
+		userInteractive, userEmail, userPassword = false, "", ""
+	})
+	userInteractive = true
+	return &created
+}
+
+func TestUsersCreateCmd_Interactive(t *testing.T) {
+	created := setupUserWizardServer(t)
+
+	input := strings.Join([]string{
+		"jane",             // username
+		"jane@example.com", // email
+		"s3cret",           // password
+		"wrong",            // confirmation mismatch
+		"s3cret",           // password again
+		"s3cret",           // confirmation
+		"",                 // not admin
+		"acme",             // default tenant by name
+		"y",                // add a tenant right
+		"1",                // acme
+		"2",                // Write
+		"y",                // add a project right
+		"4",                // out of range, asked again
+		"shop",             // project by name
+		"",                 // default Read
+		"n",                // no more project rights
+		"n",                // no more tenant rights
+		"y",                // create
+	}, "\n") + "\n"
+
+	var buf bytes.Buffer
+	usersCreateCmd.SetOut(&buf)
+	usersCreateCmd.SetIn(strings.NewReader(input))
+	defer usersCreateCmd.SetOut(nil)
+	defer usersCreateCmd.SetIn(nil)
+	require.NoError(t, usersCreateCmd.RunE(usersCreateCmd, nil))
+
+	assert.Equal(t, map[string]interface{}{
+		"username":      "jane",
+		"email":         "jane@example.com",
+		"password":      "s3cret",
+		"admin":         false,
+		"userType":      "INTERNAL",
+		"defaultTenant": "acme",
+		"rights": map[string]interface{}{
+			"tenants": map[string]interface{}{
+				"acme": map[string]interface{}{
+					"level":    "Write",
+					"projects": map[string]interface{}{"shop": map[string]interface{}{"level": "Read"}},
+				},
+			},
+		},
+	}, *created)
+
+	out := buf.String()
+	assert.Contains(t, out, "Passwords do not match")
+	assert.Contains(t, out, "Enter a number between 1 and 2")
+	assert.Contains(t, out, `"password": "********"`)
+	assert.NotContains(t, out, `"password": "s3cret"`)
+	assert.Contains(t, out, "User created successfully")
+}
+
+func TestUsersCreateCmd_InteractiveCancelled(t *testing.T) {
+	created := setupUserWizardServer(t)
+	userEmail, userPassword = "jane@example.com", "s3cret"
+
+	// Username, email default, admin, default tenant default, then decline creation
+	input := "jane\n\ny\n\nn\n"
+	var buf bytes.Buffer
+	usersCreateCmd.SetOut(&buf)
+	usersCreateCmd.SetIn(strings.NewReader(input))
+	defer usersCreateCmd.SetOut(nil)
+	defer usersCreateCmd.SetIn(nil)
+	require.NoError(t, usersCreateCmd.RunE(usersCreateCmd, nil))
+
+	assert.Nil(t, *created)
+	assert.Contains(t, buf.String(), "Cancelled")
+	assert.NotContains(t, buf.String(), "Add a tenant right")
+}
+
+func TestUsersCreateCmd_InteractiveInputEnds(t *testing.T) {
+	setupUserWizardServer(t)
+	usersCreateCmd.SetIn(strings.NewReader("jane\n"))
+	usersCreateCmd.SetOut(io.Discard)
+	defer usersCreateCmd.SetIn(nil)
+	defer usersCreateCmd.SetOut(nil)
+
+	err := usersCreateCmd.RunE(usersCreateCmd, nil)
+	assert.ErrorContains(t, err, "input ended")
+}
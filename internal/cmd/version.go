@@ -1,10 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+	"github.com/webskin/izanami-go-cli/internal/output"
+	"github.com/webskin/izanami-go-cli/internal/update"
 )
 
 var (
@@ -16,20 +25,207 @@ var (
 	BuildDate = "unknown"
 )
 
+// TestedServerVersion is the Izanami version the CLI is developed and tested
+// against (see docs/unsafe-izanami-openapi.yaml)
+const TestedServerVersion = "2.13.0"
+
+// versionServerTimeout bounds the server and release lookups of 'iz version'
+const versionServerTimeout = 5 * time.Second
+
+var versionCheckUpdate bool
+
+// VersionInfo is the output of 'iz version'
+type VersionInfo struct {
+	Version   string             `json:"version"`
+	Commit    string             `json:"commit"`
+	BuildDate string             `json:"buildDate"`
+	Go        string             `json:"go"`
+	Platform  string             `json:"platform"`
+	Server    *ServerVersionInfo `json:"server,omitempty"`
+	Update    *UpdateInfo        `json:"update,omitempty"`
+}
+
+// ServerVersionInfo describes the configured Izanami server
+type ServerVersionInfo struct {
+	URL           string `json:"url"`
+	Version       string `json:"version,omitempty"`
+	Compatibility string `json:"compatibility"`
+	Error         string `json:"error,omitempty"`
+}
+
+// UpdateInfo is the result of --check-update
+type UpdateInfo struct {
+	Latest    string `json:"latest,omitempty"`
+	Available bool   `json:"available"`
+	URL       string `json:"url,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Long:  `Display the version of the Izanami CLI, along with build information.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Fprintf(cmd.OutOrStdout(), "iz version %s\n", Version)
-		fmt.Fprintf(cmd.OutOrStdout(), "  Commit:    %s\n", GitCommit)
-		fmt.Fprintf(cmd.OutOrStdout(), "  Built:     %s\n", BuildDate)
-		fmt.Fprintf(cmd.OutOrStdout(), "  Go:        %s\n", runtime.Version())
-		fmt.Fprintf(cmd.OutOrStdout(), "  Platform:  %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	Long: `Display the version of the Izanami CLI, along with build information.
+
+When a leader URL is configured, the version of the Izanami server is shown
+with its compatibility: iz targets Izanami 2.x and is tested against
+` + TestedServerVersion + `.
+
+With --check-update, the latest release is looked up on GitHub. Use
+'iz self-update' to install it.
+
+Examples:
+  iz version
+  iz version --check-update
+  iz version -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := &VersionInfo{
+			Version:   Version,
+			Commit:    GitCommit,
+			BuildDate: BuildDate,
+			Go:        runtime.Version(),
+			Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		}
+
+		// version skips config loading: a missing or broken config only hides the server
+		loaded, err := loadOptionalConfig(cmd)
+		if err == nil && loaded.LeaderURL != "" {
+			info.Server = serverVersionInfo(loaded)
+		}
+		if versionCheckUpdate {
+			info.Update = checkUpdate(commandContext(), updateHTTPClient(loaded))
+		}
+
+		if outputFormat == "json" {
+			return output.PrintTo(cmd.OutOrStdout(), info, output.JSON)
+		}
+		printVersionInfo(cmd.OutOrStdout(), info)
+		return nil
 	},
 }
 
+// loadOptionalConfig loads the config for commands that skip config loading,
+// merged with the global flags
+func loadOptionalConfig(cmd *cobra.Command) (*izanami.ResolvedConfig, error) {
+	loaded, _, err := izanami.LoadConfigWithProfile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	loaded.MergeWithFlags(globalFlagValues(cmd))
+	return loaded, nil
+}
+
+// serverVersionInfo asks the server its version with the unauthenticated
+// health endpoint
+func serverVersionInfo(loaded *izanami.ResolvedConfig) *ServerVersionInfo {
+	info := &ServerVersionInfo{URL: loaded.LeaderURL}
+	client, err := izanami.NewAdminClientNoAuth(&izanami.ResolvedConfig{
+		LeaderURL:          loaded.LeaderURL,
+		Timeout:            int(versionServerTimeout / time.Second),
+		InsecureSkipVerify: loaded.InsecureSkipVerify,
+		CACertFile:         loaded.CACertFile,
+		ClientCertFile:     loaded.ClientCertFile,
+		ClientKeyFile:      loaded.ClientKeyFile,
+		HTTPHeaders:        loaded.HTTPHeaders,
+		ProxyURL:           loaded.ProxyURL,
+	})
+	if err == nil {
+		var health *izanami.HealthStatus
+		if health, err = izanami.Health(client, commandContext(), izanami.ParseHealthStatus); err == nil {
+			info.Version = health.Version
+		}
+	}
+	if err != nil {
+		info.Compatibility = "unknown"
+		info.Error = err.Error()
+		return info
+	}
+	info.Compatibility = serverCompatibility(info.Version)
+	return info
+}
+
+// serverCompatibility tells whether the CLI supports an Izanami server version
+func serverCompatibility(version string) string {
+	switch {
+	case version == "":
+		return "unknown (the server does not report its version)"
+	case !strings.HasPrefix(strings.TrimPrefix(version, "v"), "2."):
+		return "unsupported (iz targets Izanami 2.x)"
+	case update.CompareVersions(version, TestedServerVersion) > 0:
+		return "compatible (newer than the tested " + TestedServerVersion + ")"
+	default:
+		return "compatible"
+	}
+}
+
+// checkUpdate looks up the latest release and whether it is newer than this build
+func checkUpdate(ctx context.Context, client *http.Client) *UpdateInfo {
+	ctx, cancel := context.WithTimeout(ctx, versionServerTimeout)
+	defer cancel()
+
+	release, err := update.LatestRelease(ctx, client)
+	if err != nil {
+		return &UpdateInfo{Error: err.Error()}
+	}
+	return &UpdateInfo{
+		Latest:    release.Version(),
+		Available: !update.IsDevelopmentBuild(Version) && update.CompareVersions(release.Version(), Version) > 0,
+		URL:       release.HTMLURL,
+	}
+}
+
+// updateHTTPClient returns the HTTP client for GitHub requests, through the
+// configured proxy if any
+func updateHTTPClient(loaded *izanami.ResolvedConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if loaded != nil && loaded.ProxyURL != "" {
+		if proxyURL, err := izanami.ParseProxyURL(loaded.ProxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// printVersionInfo displays version information
+func printVersionInfo(w io.Writer, info *VersionInfo) {
+	fmt.Fprintf(w, "iz version %s\n", info.Version)
+	fmt.Fprintf(w, "  Commit:    %s\n", info.Commit)
+	fmt.Fprintf(w, "  Built:     %s\n", info.BuildDate)
+	fmt.Fprintf(w, "  Go:        %s\n", info.Go)
+	fmt.Fprintf(w, "  Platform:  %s\n", info.Platform)
+
+	if server := info.Server; server != nil {
+		host := server.URL
+		if parsed, err := url.Parse(server.URL); err == nil && parsed.Host != "" {
+			host = parsed.Host
+		}
+		switch {
+		case server.Error != "":
+			fmt.Fprintf(w, "  Server:    %s (unreachable: %s)\n", host, server.Error)
+		case server.Version != "":
+			fmt.Fprintf(w, "  Server:    %s (Izanami %s, %s)\n", host, server.Version, server.Compatibility)
+		default:
+			fmt.Fprintf(w, "  Server:    %s (%s)\n", host, server.Compatibility)
+		}
+	}
+
+	if updateInfo := info.Update; updateInfo != nil {
+		switch {
+		case updateInfo.Error != "":
+			fmt.Fprintf(w, "\nCould not check for updates: %s\n", updateInfo.Error)
+		case updateInfo.Available:
+			fmt.Fprintf(w, "\nA new version is available: %s (%s)\n", updateInfo.Latest, updateInfo.URL)
+			fmt.Fprintf(w, "Run 'iz self-update' to install it.\n")
+		case update.IsDevelopmentBuild(info.Version):
+			fmt.Fprintf(w, "\nLatest release: %s (this is a development build)\n", updateInfo.Latest)
+		default:
+			fmt.Fprintf(w, "\niz is up to date.\n")
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().BoolVar(&versionCheckUpdate, "check-update", false, "Check GitHub for a newer release")
 }
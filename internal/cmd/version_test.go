@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webskin/izanami-go-cli/internal/update"
+)
+
+func TestServerCompatibility(t *testing.T) {
+	assert.Equal(t, "compatible", serverCompatibility("2.5.0"))
+	assert.Equal(t, "compatible", serverCompatibility(TestedServerVersion))
+	assert.Contains(t, serverCompatibility("2.99.0"), "newer than the tested")
+	assert.Contains(t, serverCompatibility("1.11.0"), "unsupported")
+	assert.Contains(t, serverCompatibility(""), "unknown")
+}
+
+func TestCheckUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(update.Release{TagName: "v1.5.0", HTMLURL: "https://example.com/v1.5.0"})
+	}))
+	defer server.Close()
+	defer func(url, version string) { update.APIURL, Version = url, version }(update.APIURL, Version)
+	update.APIURL = server.URL
+
+	Version = "1.4.0"
+	info := checkUpdate(context.Background(), server.Client())
+	assert.True(t, info.Available)
+	assert.Equal(t, "1.5.0", info.Latest)
+
+	Version = "1.5.0"
+	assert.False(t, checkUpdate(context.Background(), server.Client()).Available)
+
+	var buf bytes.Buffer
+	printVersionInfo(&buf, &VersionInfo{Version: "1.4.0", Update: &UpdateInfo{Latest: "1.5.0", Available: true, URL: "https://example.com/v1.5.0"}})
+	assert.Contains(t, buf.String(), "A new version is available: 1.5.0")
+	assert.Contains(t, buf.String(), "iz self-update")
+}
@@ -1,9 +1,9 @@
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/webskin/izanami-go-cli/internal/errors"
@@ -24,6 +24,9 @@ var (
 	webhookBodyTemplate string
 	webhookData         string
 	webhooksDeleteForce bool
+
+	webhookTemplateFile  string
+	webhookTemplateEvent string
 )
 
 // webhooksCmd represents the webhooks command
@@ -56,7 +59,7 @@ var webhooksListCmd = &cobra.Command{
 	Annotations: map[string]string{"route": "GET /api/admin/tenants/:tenant/webhooks"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -64,7 +67,7 @@ var webhooksListCmd = &cobra.Command{
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
 		// For JSON output, use Identity mapper to get raw JSON
 		if outputFormat == "json" {
@@ -82,7 +85,7 @@ var webhooksListCmd = &cobra.Command{
 		}
 
 		if len(webhooks) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No webhooks found")
+			fmt.Fprintln(statusOut(cmd), "No webhooks found")
 			return nil
 		}
 
@@ -115,7 +118,7 @@ Examples:
 		webhookIDOrName := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -123,27 +126,14 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
-		// Fetch all webhooks and filter by ID or name
-		webhooks, err := izanami.ListWebhooks(client, ctx, cfg.Tenant, izanami.ParseWebhooks)
+		// Resolve the webhook by ID or name
+		found, err := resolveWebhook(ctx, client, webhookIDOrName)
 		if err != nil {
 			return err
 		}
 
-		// Find the webhook with matching ID or name
-		var found *izanami.WebhookFull
-		for i := range webhooks {
-			if webhooks[i].ID == webhookIDOrName || webhooks[i].Name == webhookIDOrName {
-				found = &webhooks[i]
-				break
-			}
-		}
-
-		if found == nil {
-			return fmt.Errorf("webhook '%s' not found", webhookIDOrName)
-		}
-
 		// For JSON output
 		if outputFormat == "json" {
 			encoder := json.NewEncoder(cmd.OutOrStdout())
@@ -183,7 +173,7 @@ Examples:
 		name := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -227,6 +217,9 @@ Examples:
 				data["user"] = webhookUser
 			}
 			if webhookBodyTemplate != "" {
+				if err := validateBodyTemplate(webhookBodyTemplate); err != nil {
+					return err
+				}
 				data["bodyTemplate"] = webhookBodyTemplate
 			}
 			if webhookHeaders != "" {
@@ -240,7 +233,7 @@ Examples:
 			webhookPayload = data
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 		result, err := client.CreateWebhook(ctx, cfg.Tenant, webhookPayload)
 		if err != nil {
 			return err
@@ -254,12 +247,12 @@ Examples:
 		}
 
 		// For table output, show important info
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Webhook created successfully\n\n")
-		fmt.Fprintf(cmd.OutOrStderr(), "ID:      %s\n", result.ID)
-		fmt.Fprintf(cmd.OutOrStderr(), "Name:    %s\n", result.Name)
-		fmt.Fprintf(cmd.OutOrStderr(), "URL:     %s\n", result.URL)
-		fmt.Fprintf(cmd.OutOrStderr(), "Enabled: %t\n", result.Enabled)
-		fmt.Fprintf(cmd.OutOrStderr(), "Global:  %t\n", result.Global)
+		fmt.Fprintf(statusOut(cmd), "✅ %s\n\n", errors.T(errors.MsgWebhookCreated))
+		fmt.Fprintf(statusOut(cmd), "ID:      %s\n", result.ID)
+		fmt.Fprintf(statusOut(cmd), "Name:    %s\n", result.Name)
+		fmt.Fprintf(statusOut(cmd), "URL:     %s\n", result.URL)
+		fmt.Fprintf(statusOut(cmd), "Enabled: %t\n", result.Enabled)
+		fmt.Fprintf(statusOut(cmd), "Global:  %t\n", result.Global)
 
 		return nil
 	},
@@ -290,7 +283,7 @@ Examples:
 		webhookIDOrName := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -298,25 +291,12 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
-		// Fetch all webhooks to find the one to update (needed for both ID/name lookup and merge)
-		webhooks, err := izanami.ListWebhooks(client, ctx, cfg.Tenant, izanami.ParseWebhooks)
+		// Resolve the webhook by ID or name
+		current, err := resolveWebhook(ctx, client, webhookIDOrName)
 		if err != nil {
-			return fmt.Errorf("failed to fetch webhooks: %w", err)
-		}
-
-		// Find the webhook with matching ID or name
-		var current *izanami.WebhookFull
-		for i := range webhooks {
-			if webhooks[i].ID == webhookIDOrName || webhooks[i].Name == webhookIDOrName {
-				current = &webhooks[i]
-				break
-			}
-		}
-
-		if current == nil {
-			return fmt.Errorf("webhook '%s' not found", webhookIDOrName)
+			return err
 		}
 
 		// Use the actual ID for the API call
@@ -332,45 +312,7 @@ Examples:
 		} else {
 
 			// Build update data starting from current values
-			data := map[string]interface{}{
-				"name":    current.Name,
-				"url":     current.URL,
-				"enabled": current.Enabled,
-				"global":  current.Global,
-			}
-
-			// Include optional fields if they have values
-			if current.Description != "" {
-				data["description"] = current.Description
-			}
-			if len(current.Features) > 0 {
-				// Extract feature IDs from the feature refs
-				featureIDs := make([]string, len(current.Features))
-				for i, f := range current.Features {
-					featureIDs[i] = f.ID
-				}
-				data["features"] = featureIDs
-			}
-			if len(current.Projects) > 0 {
-				// Extract project IDs from the project refs
-				projectIDs := make([]string, len(current.Projects))
-				for i, p := range current.Projects {
-					projectIDs[i] = p.ID
-				}
-				data["projects"] = projectIDs
-			}
-			if current.Context != "" {
-				data["context"] = current.Context
-			}
-			if current.User != "" {
-				data["user"] = current.User
-			}
-			if current.BodyTemplate != "" {
-				data["bodyTemplate"] = current.BodyTemplate
-			}
-			if len(current.Headers) > 0 {
-				data["headers"] = current.Headers
-			}
+			data := current.UpdateBody()
 
 			// Override with any changed flags
 			if cmd.Flags().Changed("url") {
@@ -398,6 +340,9 @@ Examples:
 				data["global"] = webhookGlobal
 			}
 			if cmd.Flags().Changed("body-template") {
+				if err := validateBodyTemplate(webhookBodyTemplate); err != nil {
+					return err
+				}
 				data["bodyTemplate"] = webhookBodyTemplate
 			}
 			if cmd.Flags().Changed("headers") {
@@ -415,7 +360,7 @@ Examples:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Webhook updated successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ %s\n", errors.T(errors.MsgWebhookUpdated))
 		return nil
 	},
 }
@@ -440,7 +385,7 @@ Examples:
 		webhookIDOrName := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -448,27 +393,14 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
-		// Resolve webhook ID from name if needed
-		webhookID := webhookIDOrName
-		webhooks, err := izanami.ListWebhooks(client, ctx, cfg.Tenant, izanami.ParseWebhooks)
+		// Resolve the webhook by ID or name
+		found, err := resolveWebhook(ctx, client, webhookIDOrName)
 		if err != nil {
-			return fmt.Errorf("failed to fetch webhooks: %w", err)
-		}
-
-		var found *izanami.WebhookFull
-		for i := range webhooks {
-			if webhooks[i].ID == webhookIDOrName || webhooks[i].Name == webhookIDOrName {
-				found = &webhooks[i]
-				break
-			}
-		}
-
-		if found == nil {
-			return fmt.Errorf("webhook '%s' not found", webhookIDOrName)
+			return err
 		}
-		webhookID = found.ID
+		webhookID := found.ID
 
 		// Confirm deletion unless --force is used
 		if !webhooksDeleteForce {
@@ -481,7 +413,7 @@ Examples:
 			return err
 		}
 
-		fmt.Fprintf(cmd.OutOrStderr(), "✅ Webhook deleted successfully\n")
+		fmt.Fprintf(statusOut(cmd), "✅ %s\n", errors.T(errors.MsgWebhookDeleted))
 		return nil
 	},
 }
@@ -510,7 +442,7 @@ Examples:
 		webhookIDOrName := args[0]
 
 		if cfg.Tenant == "" {
-			return fmt.Errorf(errors.MsgTenantRequired)
+			return errors.Errorf(errors.MsgTenantRequired)
 		}
 
 		client, err := izanami.NewAdminClient(cfg)
@@ -518,24 +450,12 @@ Examples:
 			return err
 		}
 
-		ctx := context.Background()
+		ctx := commandContext()
 
-		// Resolve webhook ID from name if needed
-		webhooks, err := izanami.ListWebhooks(client, ctx, cfg.Tenant, izanami.ParseWebhooks)
+		// Resolve the webhook by ID or name
+		found, err := resolveWebhook(ctx, client, webhookIDOrName)
 		if err != nil {
-			return fmt.Errorf("failed to fetch webhooks: %w", err)
-		}
-
-		var found *izanami.WebhookFull
-		for i := range webhooks {
-			if webhooks[i].ID == webhookIDOrName || webhooks[i].Name == webhookIDOrName {
-				found = &webhooks[i]
-				break
-			}
-		}
-
-		if found == nil {
-			return fmt.Errorf("webhook '%s' not found", webhookIDOrName)
+			return err
 		}
 		webhookID := found.ID
 
@@ -555,7 +475,7 @@ Examples:
 		}
 
 		if len(users) == 0 {
-			fmt.Fprintln(cmd.OutOrStderr(), "No users found for this webhook")
+			fmt.Fprintln(statusOut(cmd), "No users found for this webhook")
 			return nil
 		}
 
@@ -563,6 +483,85 @@ Examples:
 	},
 }
 
+// webhooksRenderTemplateCmd renders a body template locally against a sample event
+var webhooksRenderTemplateCmd = &cobra.Command{
+	Use:   "render-template",
+	Short: "Render a webhook body template against a sample event",
+	Long: `Render a webhook body template locally, without contacting the server, to check
+its syntax and preview the body sent for an event.
+
+Templates use the Handlebars syntax: {{payload.name}} (HTML-escaped),
+{{{payload.name}}} (raw), {{#if}}, {{#unless}}, {{#each}}, {{#with}} with
+{{else}}, Mustache-style sections and comments. Syntax errors are reported with
+their line and column; values missing from the event are listed as warnings.
+
+Without --event, a built-in sample FEATURE_UPDATED event is used.
+
+Examples:
+  # Preview a template with the built-in sample event
+  iz admin webhooks render-template --file body.hbs
+
+  # Render against a captured event
+  iz admin webhooks render-template --file body.hbs --event event.json
+
+  # Read the template from stdin
+  cat body.hbs | iz admin webhooks render-template --file -`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	// Rendering is local: no server or credentials are needed
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCmd.PersistentPreRunE(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source, err := readInputFile(cmd, webhookTemplateFile)
+		if err != nil {
+			return err
+		}
+		tmpl, err := izanami.ParseWebhookTemplate(string(source))
+		if err != nil {
+			return fmt.Errorf("invalid template %s: %w", webhookTemplateFile, err)
+		}
+
+		eventData := []byte(izanami.SampleWebhookEvent)
+		if webhookTemplateEvent != "" {
+			if eventData, err = readInputFile(cmd, webhookTemplateEvent); err != nil {
+				return err
+			}
+		}
+		event, err := izanami.DecodeTemplateEvent(eventData)
+		if err != nil {
+			return err
+		}
+
+		body, missing, err := tmpl.Render(event)
+		if err != nil {
+			return fmt.Errorf("failed to render template %s: %w", webhookTemplateFile, err)
+		}
+		for _, name := range missing {
+			fmt.Fprintf(statusOut(cmd), "Warning: {{%s}} is not defined in the event and renders as empty\n", name)
+		}
+		if trimmed := strings.TrimSpace(body); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			if !json.Valid([]byte(trimmed)) {
+				fmt.Fprintln(statusOut(cmd), "Warning: the rendered body looks like JSON but is not valid JSON (use {{{...}}} to avoid HTML escaping)")
+			}
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), body)
+		if !strings.HasSuffix(body, "\n") {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+		return nil
+	},
+}
+
+// validateBodyTemplate rejects body templates with syntax errors before they reach the server
+func validateBodyTemplate(template string) error {
+	if _, err := izanami.ParseWebhookTemplate(template); err != nil {
+		return fmt.Errorf("invalid --body-template: %w (preview it with 'iz admin webhooks render-template')", err)
+	}
+	return nil
+}
+
 func init() {
 	adminCmd.AddCommand(webhooksCmd)
 
@@ -572,6 +571,11 @@ func init() {
 	webhooksCmd.AddCommand(webhooksUpdateCmd)
 	webhooksCmd.AddCommand(webhooksDeleteCmd)
 	webhooksCmd.AddCommand(webhooksUsersCmd)
+	webhooksCmd.AddCommand(webhooksRenderTemplateCmd)
+
+	webhooksRenderTemplateCmd.Flags().StringVar(&webhookTemplateFile, "file", "", "Template file (- for stdin)")
+	webhooksRenderTemplateCmd.Flags().StringVar(&webhookTemplateEvent, "event", "", "Sample event JSON file (default: built-in FEATURE_UPDATED event)")
+	webhooksRenderTemplateCmd.MarkFlagRequired("file")
 
 	// Create flags
 	webhooksCreateCmd.Flags().StringVar(&webhookURL, "url", "", "Webhook URL (required)")
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooksRenderTemplateCmd(t *testing.T) {
+	dir := t.TempDir()
+	event := filepath.Join(dir, "event.json")
+	require.NoError(t, os.WriteFile(event, []byte(`{"type": "FEATURE_CREATED", "payload": {"name": "search"}}`), 0600))
+
+	defer func() { webhookTemplateFile, webhookTemplateEvent = "", "" }()
+
+	var buf bytes.Buffer
+	webhooksRenderTemplateCmd.SetOut(&buf)
+	webhooksRenderTemplateCmd.SetIn(bytes.NewBufferString(`{"text": "{{payload.name}} {{type}} {{user}}"}`))
+	defer func() {
+		webhooksRenderTemplateCmd.SetOut(nil)
+		webhooksRenderTemplateCmd.SetIn(nil)
+	}()
+
+	webhookTemplateFile, webhookTemplateEvent = "-", event
+	require.NoError(t, webhooksRenderTemplateCmd.RunE(webhooksRenderTemplateCmd, nil))
+	assert.Contains(t, buf.String(), "Warning: {{user}} is not defined in the event")
+	assert.Contains(t, buf.String(), `{"text": "search FEATURE_CREATED "}`)
+
+	// Objects inserted inside a JSON string break the body
+	buf.Reset()
+	webhooksRenderTemplateCmd.SetIn(bytes.NewBufferString(`{"text": "{{{payload}}}"}`))
+	require.NoError(t, webhooksRenderTemplateCmd.RunE(webhooksRenderTemplateCmd, nil))
+	assert.Contains(t, buf.String(), "is not valid JSON")
+
+	// Syntax errors fail with their position
+	bad := filepath.Join(dir, "bad.hbs")
+	require.NoError(t, os.WriteFile(bad, []byte("{{#if a}}\n{{/each}}"), 0600))
+	webhookTemplateFile = bad
+	err := webhooksRenderTemplateCmd.RunE(webhooksRenderTemplateCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2, column 1: {{/each}} does not close {{#if}}")
+}
+
+func TestValidateBodyTemplate(t *testing.T) {
+	assert.NoError(t, validateBodyTemplate(`{"name": "{{payload.name}}"}`))
+	assert.ErrorContains(t, validateBodyTemplate(`{"name": "{{payload.name}"}`), "invalid --body-template")
+}
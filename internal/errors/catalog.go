@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LangEnvVar selects the locale of the messages, e.g. IZ_LANG=fr
+const LangEnvVar = "IZ_LANG"
+
+// Catalog maps the English messages of this package to their translation.
+//
+// Messages are fmt templates: a translation takes the same arguments as the
+// English message, and may reorder them with explicit indexes, e.g.
+// "%[2]s: %[1]s".
+type Catalog map[string]string
+
+// active is the catalog of the selected locale; nil for English
+var active Catalog
+
+// T returns the message in the selected locale, or msg itself when it has no
+// translation
+func T(msg string) string {
+	if translated, ok := active[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Errorf formats a message of the catalog in the selected locale, as
+// fmt.Errorf does (%w wraps an error)
+func Errorf(msg string, args ...interface{}) error {
+	return fmt.Errorf(T(msg), args...)
+}
+
+// Sprintf formats a message of the catalog in the selected locale
+func Sprintf(msg string, args ...interface{}) string {
+	return fmt.Sprintf(T(msg), args...)
+}
+
+// SetCatalog selects the catalog used by T; nil restores English
+func SetCatalog(catalog Catalog) {
+	active = catalog
+}
+
+// LoadCatalog reads the translations of a locale from dir/<locale>.yaml, a map
+// of English messages to their translation. The locale is tried as is, then
+// without its territory ("fr_FR.UTF-8" reads fr_FR.yaml, else fr.yaml).
+// Translations that do not take the same arguments as their English message
+// are dropped and reported in warnings. English ("", "en", "C") loads nothing.
+func LoadCatalog(dir, locale string) (catalog Catalog, warnings []string, err error) {
+	locale, _, _ = strings.Cut(locale, ".")
+	candidates := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "_"); ok {
+		candidates = append(candidates, lang)
+	}
+	if candidates[len(candidates)-1] == "" || candidates[len(candidates)-1] == "en" || locale == "C" || locale == "POSIX" {
+		return nil, nil, nil
+	}
+
+	for _, name := range candidates {
+		path := filepath.Join(dir, name+".yaml")
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read messages file: %w", err)
+		}
+		return ParseCatalog(data, path)
+	}
+	return nil, nil, fmt.Errorf("no messages for locale %q (add %s)", locale, filepath.Join(dir, candidates[len(candidates)-1]+".yaml"))
+}
+
+// ParseCatalog parses a YAML map of English messages to their translation.
+// path is only used in errors and warnings.
+func ParseCatalog(data []byte, path string) (Catalog, []string, error) {
+	var raw map[string]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("invalid messages file %s: %w", path, err)
+	}
+	catalog := make(Catalog, len(raw))
+	var warnings []string
+	for msg, translated := range raw {
+		if templateArgs(msg) != templateArgs(translated) {
+			warnings = append(warnings, fmt.Sprintf("%s: translation of %q does not take the same arguments, ignored", path, msg))
+			continue
+		}
+		catalog[msg] = translated
+	}
+	return catalog, warnings, nil
+}
+
+// templateArgs returns the number of arguments a fmt template takes,
+// accounting for explicit indexes such as %[2]s
+func templateArgs(template string) int {
+	count, next := 0, 0
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+		i++
+		// Flags, width and precision
+		for i < len(template) && strings.IndexByte("+-# 0123456789.*", template[i]) >= 0 {
+			if template[i] == '*' {
+				next++
+			}
+			i++
+		}
+		if i >= len(template) || template[i] == '%' {
+			continue
+		}
+		if template[i] == '[' {
+			end := strings.IndexByte(template[i:], ']')
+			if end < 0 {
+				continue
+			}
+			if n, err := strconv.Atoi(template[i+1 : i+end]); err == nil {
+				next = n - 1
+			}
+			i += end + 1
+		}
+		next++
+		count = max(count, next)
+	}
+	return count
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateArgs(t *testing.T) {
+	assert.Equal(t, 0, templateArgs("no active session"))
+	assert.Equal(t, 0, templateArgs("100%% done"))
+	assert.Equal(t, 2, templateArgs(MsgWorkerNotFound))
+	assert.Equal(t, 3, templateArgs(MsgServerAppearsDown))
+	assert.Equal(t, 2, templateArgs("%[2]s: %[1]s"))
+	assert.Equal(t, 2, templateArgs("%-10s %5.2f"))
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fr.yaml"), []byte(`
+"session '%s' not found": "session '%s' introuvable"
+"worker '%s' not found in profile '%s'": "worker '%[1]s' absent du profil '%[2]s'"
+"Feature created successfully: %s": "Feature créée"
+`), 0600))
+
+	catalog, warnings, err := LoadCatalog(dir, "fr_FR.UTF-8")
+	require.NoError(t, err)
+	assert.Len(t, catalog, 2)
+	require.Len(t, warnings, 1, "translations must take the same arguments")
+	assert.Contains(t, warnings[0], "Feature created successfully")
+
+	for _, locale := range []string{"", "en", "en_US.UTF-8", "C"} {
+		catalog, _, err := LoadCatalog(dir, locale)
+		require.NoError(t, err)
+		assert.Nil(t, catalog, locale)
+	}
+
+	_, _, err = LoadCatalog(dir, "de")
+	assert.ErrorContains(t, err, `no messages for locale "de"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "es.yaml"), []byte("- not a map"), 0600))
+	_, _, err = LoadCatalog(dir, "es")
+	assert.ErrorContains(t, err, "invalid messages file")
+}
+
+func TestTranslate(t *testing.T) {
+	t.Cleanup(func() { SetCatalog(nil) })
+	cause := stderrors.New("disk full")
+
+	assert.Equal(t, "session 'dev' not found", Sprintf(MsgSessionNotFound, "dev"))
+
+	SetCatalog(Catalog{
+		MsgSessionNotFound:         "session '%s' introuvable",
+		MsgWorkerNotFound:          "worker '%[1]s' absent du profil '%[2]s'",
+		MsgFailedToWriteConfigFile: "échec d'écriture de la configuration : %w",
+	})
+	assert.Equal(t, "session 'dev' introuvable", Sprintf(MsgSessionNotFound, "dev"))
+	assert.Equal(t, "worker 'eu' absent du profil 'prod'", Errorf(MsgWorkerNotFound, "eu", "prod").Error())
+	err := Errorf(MsgFailedToWriteConfigFile, cause)
+	assert.ErrorIs(t, err, cause, "%w still wraps the error")
+	assert.Equal(t, MsgNoActiveSession, T(MsgNoActiveSession), "untranslated messages stay in English")
+}
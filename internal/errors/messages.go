@@ -1,6 +1,8 @@
 package errors
 
-// Common error messages used across the application
+// Common messages used across the application. They are the keys of the
+// translations of the message catalog (see catalog.go); print them through
+// T, Errorf or Sprintf so that IZ_LANG applies.
 const (
 	// MsgTenantRequired is the error message when tenant is not specified
 	MsgTenantRequired = "tenant is required (use --tenant flag or set IZ_TENANT)"
@@ -24,6 +26,7 @@ const (
 	MsgLoginRequestFailed   = "login request failed"
 	MsgLoginFailed          = "login failed (status %d): invalid credentials"
 	MsgNoJWTTokenInResponse = "no JWT token in login response"
+	MsgSessionExpired       = "session expired at %s (use '%s' to authenticate again)"
 
 	// Feature error messages
 	MsgFailedToListFeatures          = "failed to list features"
@@ -77,6 +80,7 @@ const (
 	MsgFailedToListTags  = "failed to list tags"
 	MsgFailedToGetTag    = "failed to get tag"
 	MsgFailedToCreateTag = "failed to create tag"
+	MsgFailedToUpdateTag = "failed to update tag"
 	MsgFailedToDeleteTag = "failed to delete tag"
 
 	// Webhook error messages
@@ -104,6 +108,9 @@ const (
 	MsgEventStreamReturnedStatus    = "event stream returned status %d"
 	MsgErrorReadingEventStream      = "error reading event stream"
 
+	// Connectivity error messages
+	MsgServerAppearsDown = "server %s appears down since %s (%d consecutive failures); skipping request"
+
 	// Utility error messages
 	MsgFailedToCheckHealth = "failed to check health"
 	MsgFailedToSearch      = "failed to search"
@@ -124,4 +131,38 @@ const (
 	MsgDefaultWorkerNotFound    = "[warning] default-worker '%s' not found in profile '%s'; falling back to standalone mode"
 	MsgWorkerAlreadyExists      = "worker '%s' already exists in profile '%s'. Use --force to overwrite"
 	MsgNoActiveProfileForWorker = "no active profile. Use 'iz profiles use <name>' to select a profile first"
+
+	// Success messages
+	MsgFeatureCreated           = "Feature created successfully: %s"
+	MsgFeatureUpdated           = "Feature updated successfully: %s"
+	MsgFeatureDeleted           = "Feature deleted successfully: %s"
+	MsgFeatureDeletedWithID     = "Feature deleted successfully: %s (ID: %s)"
+	MsgFeaturesPatched          = "Features patched successfully"
+	MsgFeatureEnabledInContext  = "Feature enabled successfully: %s in context %s"
+	MsgFeatureDisabledInContext = "Feature disabled successfully: %s in context %s"
+	MsgContextCreated           = "Context created successfully: %s"
+	MsgContextCreatedProtected  = "Context created successfully: %s (protected)"
+	MsgContextUpdated           = "Context updated successfully: %s"
+	MsgContextDeleted           = "Context deleted successfully: %s"
+	MsgOverloadSet              = "Overload set successfully: %s in context %s"
+	MsgOverloadDeleted          = "Overload deleted successfully: %s from context %s"
+	MsgProjectCreated           = "Project created successfully: %s"
+	MsgProjectRenamed           = "Project renamed successfully: %s -> %v"
+	MsgProjectUpdated           = "Project updated successfully: %s"
+	MsgProjectDeleted           = "Project deleted successfully: %s"
+	MsgAPIKeyCreated            = "API key created successfully"
+	MsgAPIKeyUpdated            = "API key updated successfully"
+	MsgAPIKeyDeleted            = "API key deleted successfully"
+	MsgTagCreated               = "Tag created successfully: %s"
+	MsgTagUpdated               = "Tag updated successfully: %s"
+	MsgTagDeleted               = "Tag deleted successfully: %s"
+	MsgTenantCreated            = "Tenant created successfully: %s"
+	MsgTenantUpdated            = "Tenant updated successfully: %s"
+	MsgTenantDeleted            = "Tenant deleted successfully: %s"
+	MsgWebhookCreated           = "Webhook created successfully"
+	MsgWebhookUpdated           = "Webhook updated successfully"
+	MsgWebhookDeleted           = "Webhook deleted successfully"
+
+	// Message catalog warnings
+	MsgMessagesNotLoaded = "[warning] %v; messages are shown in English"
 )
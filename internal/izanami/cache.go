@@ -0,0 +1,189 @@
+package izanami
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// responseCacheDirName is the sub-directory of the cache dir holding cached API responses
+const responseCacheDirName = "responses"
+
+// CacheHeader is added to responses served from the cache
+const CacheHeader = "X-Iz-Cache"
+
+// uncachedPath matches the API key and webhook endpoints, whose responses hold
+// client secrets and webhook headers (often auth tokens) that must never be
+// written to disk
+var uncachedPath = regexp.MustCompile(`^/api/admin/tenants/[^/]+/(keys|webhooks)(/|$)`)
+
+// cachedResponse is the on-disk representation of a cached GET response
+type cachedResponse struct {
+	URL      string      `json:"url"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"storedAt"`
+}
+
+// cachingTransport serves successful GET responses from an on-disk cache.
+//
+// Entries are keyed by URL and credentials, so users sharing a machine never see
+// each other's data. API keys and webhooks are never cached. Any other request (create, update, delete) clears the whole
+// cache, even when caching is disabled for the current command, so that a later
+// list never shows data older than the last change made through the CLI.
+type cachingTransport struct {
+	base http.RoundTripper
+	dir  string
+	ttl  time.Duration // 0 disables reading and storing entries
+	now  func() time.Time
+}
+
+// newCachingTransport wraps base with a response cache stored in the CLI cache directory
+func newCachingTransport(base http.RoundTripper, ttl time.Duration) *cachingTransport {
+	return &cachingTransport{
+		base: base,
+		dir:  ResponseCacheDir(),
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// ResponseCacheDir returns the directory holding cached API responses
+func ResponseCacheDir() string {
+	return filepath.Join(getCacheDir(), responseCacheDirName)
+}
+
+// ClearResponseCache removes every cached API response and returns the number of removed entries
+func ClearResponseCache() (int, error) {
+	dir := ResponseCacheDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil && req.Method != http.MethodHead && req.Method != http.MethodOptions {
+			t.invalidate()
+		}
+		return resp, err
+	}
+
+	if t.ttl <= 0 || uncachedPath.MatchString(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	path := filepath.Join(t.dir, cacheKey(req)+".json")
+	if entry, ok := t.load(path); ok {
+		return entry.toResponse(req), nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Session cookies are never written to disk
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	// A cache that cannot be written must never fail the command
+	_ = t.store(path, &cachedResponse{
+		URL:      req.URL.String(),
+		Status:   resp.StatusCode,
+		Header:   header,
+		Body:     body,
+		StoredAt: t.now(),
+	})
+	return resp, nil
+}
+
+// load returns the cached entry at path if it exists and is still fresh
+func (t *cachingTransport) load(path string) (*cachedResponse, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if t.now().Sub(entry.StoredAt) > t.ttl {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// store writes an entry to the cache with owner-only permissions
+func (t *cachingTransport) store(path string, entry *cachedResponse) error {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// invalidate drops every cached response
+func (t *cachingTransport) invalidate() {
+	if _, err := os.Stat(t.dir); err == nil {
+		_ = os.RemoveAll(t.dir)
+	}
+}
+
+// cacheKey identifies a GET request by URL and credentials
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	io.WriteString(h, req.URL.String())
+	io.WriteString(h, "\n"+req.Header.Get("Authorization"))
+	io.WriteString(h, "\n"+req.Header.Get("Cookie"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// toResponse rebuilds an HTTP response from a cached entry
+func (e *cachedResponse) toResponse(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(CacheHeader, "HIT")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,193 @@
+package izanami
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempCacheDir points the CLI cache directory to a temporary directory
+func useTempCacheDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	previous := getCacheDir
+	SetGetCacheDirFunc(func() string { return dir })
+	t.Cleanup(func() { SetGetCacheDirFunc(previous) })
+	return dir
+}
+
+// cachingServer answers every request with status and counts the GET requests received
+func cachingServer(t *testing.T, status int) (*httptest.Server, *int32) {
+	t.Helper()
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&gets, 1)
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, `{"hits":"`+r.Method+`"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &gets
+}
+
+// doRequest sends a request through the transport and returns the response body
+func doRequest(t *testing.T, transport http.RoundTripper, method, url, cookie string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	require.NoError(t, err)
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestCachingTransport_ServesFreshEntries(t *testing.T) {
+	useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+
+	first, body1 := doRequest(t, transport, http.MethodGet, server.URL+"/api/admin/tenants", "token=a")
+	second, body2 := doRequest(t, transport, http.MethodGet, server.URL+"/api/admin/tenants", "token=a")
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(gets))
+	assert.Equal(t, body1, body2)
+	assert.Empty(t, first.Header.Get(CacheHeader))
+	assert.Equal(t, "HIT", second.Header.Get(CacheHeader))
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+}
+
+func TestCachingTransport_ExpiredEntriesAreRefetched(t *testing.T) {
+	useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+	transport.now = func() time.Time { return now }
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "")
+	now = now.Add(2 * time.Minute)
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+}
+
+func TestCachingTransport_KeyedByCredentials(t *testing.T) {
+	useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "token=alice")
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "token=bob")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+}
+
+func TestCachingTransport_ErrorsAreNotCached(t *testing.T) {
+	useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusInternalServerError)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "")
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+}
+
+func TestCachingTransport_WritesInvalidate(t *testing.T) {
+	useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	reader := newCachingTransport(http.DefaultTransport, time.Minute)
+	// A write made with caching disabled must still invalidate
+	writer := newCachingTransport(http.DefaultTransport, 0)
+
+	doRequest(t, reader, http.MethodGet, server.URL+"/api/test", "")
+	doRequest(t, writer, http.MethodPost, server.URL+"/api/test", "")
+	doRequest(t, reader, http.MethodGet, server.URL+"/api/test", "")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+}
+
+func TestCachingTransport_APIKeysAreNotCached(t *testing.T) {
+	dir := useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/admin/tenants/acme/keys", "")
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/admin/tenants/acme/keys", "")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+	_, err := os.Stat(filepath.Join(dir, responseCacheDirName))
+	assert.True(t, os.IsNotExist(err), "client secrets must not be written to disk")
+}
+
+func TestCachingTransport_WebhooksAreNotCached(t *testing.T) {
+	dir := useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/admin/tenants/acme/webhooks", "")
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/admin/tenants/acme/webhooks", "")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+	_, err := os.Stat(filepath.Join(dir, responseCacheDirName))
+	assert.True(t, os.IsNotExist(err), "webhook headers must not be written to disk")
+}
+
+func TestCachingTransport_ZeroTTLDisablesCaching(t *testing.T) {
+	dir := useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+	transport := newCachingTransport(http.DefaultTransport, 0)
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "")
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/test", "")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(gets))
+	assert.NoDirExists(t, dir+"/"+responseCacheDirName)
+}
+
+func TestClearResponseCache(t *testing.T) {
+	useTempCacheDir(t)
+	server, _ := cachingServer(t, http.StatusOK)
+	transport := newCachingTransport(http.DefaultTransport, time.Minute)
+
+	removed, err := ClearResponseCache()
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed, "missing cache dir is not an error")
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/a", "")
+	doRequest(t, transport, http.MethodGet, server.URL+"/b", "")
+
+	removed, err = ClearResponseCache()
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	_, err = os.Stat(ResponseCacheDir())
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAdminClient_UsesResponseCache(t *testing.T) {
+	useTempCacheDir(t)
+	server, gets := cachingServer(t, http.StatusOK)
+
+	client, err := NewAdminClientNoAuth(&ResolvedConfig{LeaderURL: server.URL, Timeout: 5, CacheTTL: 60})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.http.R().Get("/api/admin/tenants")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(gets))
+}
@@ -0,0 +1,192 @@
+package izanami
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// FEATURE CHECK CACHE
+// ============================================================================
+
+// checkCacheDirName is the sub-directory of the cache dir holding feature check results
+const checkCacheDirName = "checks"
+
+// Offline fallback modes of feature checks
+const (
+	// OfflineFallbackStale uses the last known result, however old
+	OfflineFallbackStale = "stale"
+	// OfflineFallbackDefault uses a fixed value
+	OfflineFallbackDefault = "default"
+)
+
+// Sources of a feature check result
+const (
+	CheckSourceServer  = "server"
+	CheckSourceCache   = "cache"
+	CheckSourceStale   = "stale"
+	CheckSourceDefault = "default"
+)
+
+// OfflineFallback is what a feature check returns when the server is unavailable
+type OfflineFallback struct {
+	Mode string
+	// Default is the JSON "active" value of OfflineFallbackDefault
+	Default json.RawMessage
+}
+
+// ParseOfflineFallback parses an --offline-fallback policy: "stale" or
+// "default:<value>", where value is JSON (false, true, 0, "off") or a bare string
+func ParseOfflineFallback(policy string) (*OfflineFallback, error) {
+	if policy == OfflineFallbackStale {
+		return &OfflineFallback{Mode: OfflineFallbackStale}, nil
+	}
+	value, ok := strings.CutPrefix(policy, OfflineFallbackDefault+":")
+	if !ok || value == "" {
+		return nil, fmt.Errorf("invalid offline fallback %q (expected stale or default:<value>, e.g. default:false)", policy)
+	}
+	raw := json.RawMessage(value)
+	if !json.Valid(raw) {
+		raw, _ = json.Marshal(value)
+	}
+	return &OfflineFallback{Mode: OfflineFallbackDefault, Default: raw}, nil
+}
+
+// CheckCacheOptions controls the local cache of feature check results
+type CheckCacheOptions struct {
+	// TTL is how long a cached result is reused without asking the server, 0 to always ask
+	TTL      time.Duration
+	Fallback *OfflineFallback
+}
+
+// CachedCheck is a feature check result with where it came from
+type CachedCheck struct {
+	Raw    []byte
+	Source string
+	// StoredAt is when a result served from the cache was fetched from the server
+	StoredAt time.Time
+	// ServerError is the error that made the check fall back, for stale and default results
+	ServerError error
+}
+
+// checkCacheEntry is the on-disk representation of a cached check result
+type checkCacheEntry struct {
+	Feature  string          `json:"feature"`
+	Result   json.RawMessage `json:"result"`
+	StoredAt time.Time       `json:"storedAt"`
+}
+
+// CheckCacheDir returns the directory holding cached feature check results
+func CheckCacheDir() string {
+	return filepath.Join(getCacheDir(), checkCacheDirName)
+}
+
+// ClearCheckCache removes every cached feature check result and returns the number of removed entries
+func ClearCheckCache() (int, error) {
+	dir := CheckCacheDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// CheckFeatureCached checks a feature like CheckFeature, reusing a result cached
+// less than opts.TTL ago. Results are cached when a TTL or a stale fallback is
+// set. When the server is unreachable or fails with a 5xx error, the fallback
+// policy decides the result; other errors (auth, unknown feature) are returned.
+func CheckFeatureCached(c *FeatureCheckClient, ctx context.Context, featureID, user, contextPath, payload string, opts CheckCacheOptions) (*CachedCheck, error) {
+	path := filepath.Join(CheckCacheDir(), c.checkCacheKey(featureID, user, contextPath, payload)+".json")
+	now := time.Now()
+
+	entry, cached := loadCheckCacheEntry(path, featureID)
+	if cached && opts.TTL > 0 && now.Sub(entry.StoredAt) <= opts.TTL {
+		return &CachedCheck{Raw: entry.Result, Source: CheckSourceCache, StoredAt: entry.StoredAt}, nil
+	}
+
+	raw, err := c.checkFeatureRaw(ctx, featureID, user, contextPath, payload)
+	if err == nil {
+		if opts.TTL > 0 || (opts.Fallback != nil && opts.Fallback.Mode == OfflineFallbackStale) {
+			// A cache that cannot be written must never fail the command
+			_ = storeCheckCacheEntry(path, &checkCacheEntry{Feature: featureID, Result: raw, StoredAt: now.UTC()})
+		}
+		return &CachedCheck{Raw: raw, Source: CheckSourceServer}, nil
+	}
+
+	if opts.Fallback == nil || !isServerUnavailable(err) {
+		return nil, err
+	}
+	switch opts.Fallback.Mode {
+	case OfflineFallbackStale:
+		if !cached {
+			return nil, fmt.Errorf("%w (no cached result to fall back to)", err)
+		}
+		return &CachedCheck{Raw: entry.Result, Source: CheckSourceStale, StoredAt: entry.StoredAt, ServerError: err}, nil
+	default:
+		result, _ := json.Marshal(map[string]json.RawMessage{"active": opts.Fallback.Default})
+		return &CachedCheck{Raw: result, Source: CheckSourceDefault, ServerError: err}, nil
+	}
+}
+
+// isServerUnavailable reports whether err means the server could not answer:
+// network errors, open circuit or 5xx responses, but not a canceled command
+func isServerUnavailable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// checkCacheKey identifies a feature check by server, credentials and parameters
+func (c *FeatureCheckClient) checkCacheKey(featureID, user, contextPath, payload string) string {
+	h := sha256.New()
+	for _, part := range []string{c.config.GetWorkerURL(), c.config.ClientID, c.config.ClientSecret, featureID, user, normalizeContextPath(contextPath), payload} {
+		io.WriteString(h, part+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadCheckCacheEntry reads a cached check result of feature, whatever its age
+func loadCheckCacheEntry(path, feature string) (*checkCacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry checkCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Feature != feature || len(entry.Result) == 0 {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// storeCheckCacheEntry writes a check result to the cache with owner-only permissions
+func storeCheckCacheEntry(path string, entry *checkCacheEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
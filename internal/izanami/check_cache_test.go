@@ -0,0 +1,126 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOfflineFallback(t *testing.T) {
+	fallback, err := ParseOfflineFallback("stale")
+	require.NoError(t, err)
+	assert.Equal(t, OfflineFallbackStale, fallback.Mode)
+
+	fallback, err = ParseOfflineFallback("default:false")
+	require.NoError(t, err)
+	assert.Equal(t, OfflineFallbackDefault, fallback.Mode)
+	assert.JSONEq(t, `false`, string(fallback.Default))
+
+	fallback, err = ParseOfflineFallback("default:off")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"off"`, string(fallback.Default))
+
+	for _, invalid := range []string{"", "fresh", "default", "default:"} {
+		_, err := ParseOfflineFallback(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// checkCacheServer answers feature checks with status and counts the requests received
+func checkCacheServer(t *testing.T, status *int32) (*FeatureCheckClient, *int32) {
+	t.Helper()
+	var requests int32
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		code := int(atomic.LoadInt32(status))
+		w.WriteHeader(code)
+		if code == http.StatusOK {
+			io.WriteString(w, `{"name":"checkout","project":"shop","active":true}`)
+		} else {
+			io.WriteString(w, `{"message":"unavailable"}`)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewFeatureCheckClient(&ResolvedConfig{LeaderURL: server.URL, ClientID: "id", ClientSecret: "secret", Timeout: 5})
+	require.NoError(t, err)
+	return client, &requests
+}
+
+func TestCheckFeatureCached_TTL(t *testing.T) {
+	useTempCacheDir(t)
+	status := int32(http.StatusOK)
+	client, requests := checkCacheServer(t, &status)
+	ctx := context.Background()
+	opts := CheckCacheOptions{TTL: time.Minute}
+
+	check, err := CheckFeatureCached(client, ctx, "f1", "alice", "", "", opts)
+	require.NoError(t, err)
+	assert.Equal(t, CheckSourceServer, check.Source)
+
+	check, err = CheckFeatureCached(client, ctx, "f1", "alice", "", "", opts)
+	require.NoError(t, err)
+	assert.Equal(t, CheckSourceCache, check.Source)
+	assert.JSONEq(t, `{"name":"checkout","project":"shop","active":true}`, string(check.Raw))
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	// Another user is another check
+	_, err = CheckFeatureCached(client, ctx, "f1", "bob", "", "", opts)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+
+	// Without a TTL nothing is cached
+	n, err := ClearCheckCache()
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, err = CheckFeatureCached(client, ctx, "f1", "alice", "", "", CheckCacheOptions{})
+	require.NoError(t, err)
+	assert.NoDirExists(t, CheckCacheDir())
+}
+
+func TestCheckFeatureCached_OfflineFallback(t *testing.T) {
+	useTempCacheDir(t)
+	status := int32(http.StatusOK)
+	client, _ := checkCacheServer(t, &status)
+	ctx := context.Background()
+	stale := CheckCacheOptions{Fallback: &OfflineFallback{Mode: OfflineFallbackStale}}
+
+	// Without a known result there is nothing to fall back to
+	atomic.StoreInt32(&status, http.StatusServiceUnavailable)
+	_, err := CheckFeatureCached(client, ctx, "f1", "", "", "", stale)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no cached result")
+
+	atomic.StoreInt32(&status, http.StatusOK)
+	check, err := CheckFeatureCached(client, ctx, "f1", "", "", "", stale)
+	require.NoError(t, err)
+	assert.Equal(t, CheckSourceServer, check.Source)
+
+	atomic.StoreInt32(&status, http.StatusBadGateway)
+	check, err = CheckFeatureCached(client, ctx, "f1", "", "", "", stale)
+	require.NoError(t, err)
+	assert.Equal(t, CheckSourceStale, check.Source)
+	assert.Error(t, check.ServerError)
+	assert.False(t, check.StoredAt.IsZero())
+
+	fallback, err := ParseOfflineFallback("default:false")
+	require.NoError(t, err)
+	check, err = CheckFeatureCached(client, ctx, "f1", "", "", "", CheckCacheOptions{Fallback: fallback})
+	require.NoError(t, err)
+	assert.Equal(t, CheckSourceDefault, check.Source)
+	var result FeatureCheckResult
+	require.NoError(t, json.Unmarshal(check.Raw, &result))
+	assert.Equal(t, false, result.Active)
+
+	// Client errors are not outages
+	atomic.StoreInt32(&status, http.StatusUnauthorized)
+	_, err = CheckFeatureCached(client, ctx, "f1", "", "", "", stale)
+	assert.Error(t, err)
+}
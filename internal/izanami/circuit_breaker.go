@@ -0,0 +1,182 @@
+package izanami
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+)
+
+// Circuit breaker defaults.
+// A server is considered down after circuitFailureThreshold consecutive failures
+// occurring within circuitFailureWindow. While the circuit is open, requests to
+// that server fail immediately; one probe request is allowed through every
+// circuitCooldown so the CLI recovers as soon as the server is back.
+const (
+	circuitFailureThreshold = 5
+	circuitFailureWindow    = 60 * time.Second
+	circuitCooldown         = 30 * time.Second
+)
+
+// CircuitOpenError is returned when a request is short-circuited because the
+// target server failed repeatedly in the recent past.
+type CircuitOpenError struct {
+	BaseURL   string    // Server the circuit applies to
+	DownSince time.Time // Time of the first failure in the current streak
+	Failures  int       // Number of consecutive failures observed
+}
+
+func (e *CircuitOpenError) Error() string {
+	return errmsg.Sprintf(errmsg.MsgServerAppearsDown, e.BaseURL, e.DownSince.Format("15:04:05"), e.Failures)
+}
+
+// circuitBreaker tracks consecutive failures for a single base URL
+type circuitBreaker struct {
+	mu           sync.Mutex
+	baseURL      string
+	threshold    int
+	window       time.Duration
+	cooldown     time.Duration
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	now          func() time.Time
+}
+
+// circuitBreakers holds one breaker per base URL so that every client created
+// during a command (e.g. in a bulk loop) shares the same failure history.
+var (
+	circuitBreakersMu sync.Mutex
+	circuitBreakers   = map[string]*circuitBreaker{}
+)
+
+// newCircuitBreaker creates a circuit breaker with the default thresholds
+func newCircuitBreaker(baseURL string) *circuitBreaker {
+	return &circuitBreaker{
+		baseURL:   baseURL,
+		threshold: circuitFailureThreshold,
+		window:    circuitFailureWindow,
+		cooldown:  circuitCooldown,
+		now:       time.Now,
+	}
+}
+
+// getCircuitBreaker returns the shared circuit breaker for a base URL
+func getCircuitBreaker(baseURL string) *circuitBreaker {
+	key := strings.TrimRight(baseURL, "/")
+
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+
+	cb, ok := circuitBreakers[key]
+	if !ok {
+		cb = newCircuitBreaker(key)
+		circuitBreakers[key] = cb
+	}
+	return cb
+}
+
+// resetCircuitBreakers clears all circuit breaker state (used by tests)
+func resetCircuitBreakers() {
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+	circuitBreakers = map[string]*circuitBreaker{}
+}
+
+// allow returns a CircuitOpenError if the circuit is open and the cooldown has not elapsed.
+// Once the cooldown elapses, a single probe request is let through.
+func (cb *circuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.openedAt.IsZero() {
+		return nil
+	}
+
+	now := cb.now()
+	if now.Sub(cb.openedAt) >= cb.cooldown {
+		// Half-open: let this request probe the server, keep others failing fast
+		cb.openedAt = now
+		return nil
+	}
+
+	return &CircuitOpenError{
+		BaseURL:   cb.baseURL,
+		DownSince: cb.firstFailure,
+		Failures:  cb.failures,
+	}
+}
+
+// recordSuccess closes the circuit and resets the failure streak
+func (cb *circuitBreaker) recordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	cb.firstFailure = time.Time{}
+	cb.openedAt = time.Time{}
+}
+
+// recordFailure registers a failure and opens the circuit when the threshold is reached
+func (cb *circuitBreaker) recordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+
+	// Start a new streak if the previous one is outside the window (and the circuit is closed)
+	if cb.failures == 0 || (cb.openedAt.IsZero() && now.Sub(cb.firstFailure) > cb.window) {
+		cb.failures = 0
+		cb.firstFailure = now
+	}
+
+	cb.failures++
+
+	if cb.failures >= cb.threshold {
+		cb.openedAt = now
+	}
+}
+
+// isServerFailure reports whether a response indicates the server is unavailable
+func isServerFailure(resp *resty.Response) bool {
+	return resp != nil && resp.StatusCode() >= 500
+}
+
+// enableCircuitBreaker installs the circuit breaker hooks on a resty client.
+// Hooks run once per logical request (after retries), so a single request
+// that exhausts its retries counts as one failure.
+func enableCircuitBreaker(client *resty.Client, cb *circuitBreaker) {
+	client.OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
+		return cb.allow()
+	})
+
+	client.OnSuccess(func(c *resty.Client, resp *resty.Response) {
+		if isServerFailure(resp) {
+			cb.recordFailure()
+			return
+		}
+		cb.recordSuccess()
+	})
+
+	client.OnError(func(req *resty.Request, err error) {
+		var openErr *CircuitOpenError
+		if errors.As(err, &openErr) {
+			return
+		}
+		// User cancellation says nothing about server health
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		var respErr *resty.ResponseError
+		if errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.RawResponse != nil && !isServerFailure(respErr.Response) {
+			// A response was received; the server is reachable
+			cb.recordSuccess()
+			return
+		}
+		cb.recordFailure()
+	})
+}
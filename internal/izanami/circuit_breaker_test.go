@@ -0,0 +1,131 @@
+package izanami
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 4, 0, 0, time.UTC)
+	cb := newCircuitBreaker("http://localhost:9000")
+	cb.now = func() time.Time { return now }
+
+	for i := 0; i < circuitFailureThreshold-1; i++ {
+		cb.recordFailure()
+		assert.NoError(t, cb.allow(), "circuit should stay closed below threshold")
+	}
+
+	cb.recordFailure()
+	err := cb.allow()
+	require.Error(t, err)
+
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, circuitFailureThreshold, openErr.Failures)
+	assert.Equal(t, now, openErr.DownSince)
+	assert.Contains(t, err.Error(), "appears down since 12:04:00")
+	assert.Contains(t, err.Error(), "http://localhost:9000")
+}
+
+func TestCircuitBreaker_FailuresOutsideWindowDoNotAccumulate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cb := newCircuitBreaker("http://localhost:9000")
+	cb.now = func() time.Time { return now }
+
+	for i := 0; i < circuitFailureThreshold-1; i++ {
+		cb.recordFailure()
+	}
+
+	now = now.Add(circuitFailureWindow + time.Second)
+	cb.recordFailure()
+
+	assert.NoError(t, cb.allow())
+	assert.Equal(t, 1, cb.failures)
+}
+
+func TestCircuitBreaker_SuccessResets(t *testing.T) {
+	cb := newCircuitBreaker("http://localhost:9000")
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		cb.recordFailure()
+	}
+	require.Error(t, cb.allow())
+
+	cb.recordSuccess()
+	assert.NoError(t, cb.allow())
+	assert.Equal(t, 0, cb.failures)
+}
+
+func TestCircuitBreaker_HalfOpenAfterCooldown(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cb := newCircuitBreaker("http://localhost:9000")
+	cb.now = func() time.Time { return now }
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		cb.recordFailure()
+	}
+	require.Error(t, cb.allow())
+
+	now = now.Add(circuitCooldown)
+	assert.NoError(t, cb.allow(), "one probe request should be allowed after cooldown")
+	assert.Error(t, cb.allow(), "subsequent requests should still fail fast")
+}
+
+func TestGetCircuitBreaker_SharedPerBaseURL(t *testing.T) {
+	resetCircuitBreakers()
+	defer resetCircuitBreakers()
+
+	a := getCircuitBreaker("http://localhost:9000")
+	b := getCircuitBreaker("http://localhost:9000/")
+	c := getCircuitBreaker("http://localhost:9001")
+
+	assert.Same(t, a, b)
+	assert.NotSame(t, a, c)
+}
+
+func TestAdminClient_CircuitBreakerFailsFast(t *testing.T) {
+	resetCircuitBreakers()
+	defer resetCircuitBreakers()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &ResolvedConfig{
+		LeaderURL: server.URL,
+		Username:  "test-user",
+		JwtToken:  "test-token",
+		Timeout:   5,
+		Retries:   DefaultRetries,
+	}
+	client, err := NewAdminClient(config)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := 0; i < circuitFailureThreshold; i++ {
+		err := client.DeleteFeature(ctx, "tenant", "feature")
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr), "expected API error before circuit opens, got %v", err)
+	}
+
+	callsBefore := atomic.LoadInt32(&calls)
+	start := time.Now()
+	err = client.DeleteFeature(ctx, "tenant", "feature")
+	require.Error(t, err)
+
+	var openErr *CircuitOpenError
+	assert.True(t, errors.As(err, &openErr), "expected circuit open error, got %v", err)
+	assert.Equal(t, callsBefore, atomic.LoadInt32(&calls), "server should not be called while circuit is open")
+	assert.Less(t, time.Since(start), retryBaseWait, "an open circuit should fail without retry backoff")
+}
@@ -37,6 +37,7 @@ type APIError struct {
 	StatusCode int    // HTTP status code
 	Message    string // Error message from the API
 	RawBody    string // Raw response body for debugging
+	RequestID  string // Request ID of the response, when the server or a proxy sets one
 }
 
 func (e *APIError) Error() string {
@@ -58,7 +59,7 @@ func NewAdminClient(config *ResolvedConfig) (*AdminClient, error) {
 // Use this for operations that don't require authentication (e.g., health checks).
 func NewAdminClientNoAuth(config *ResolvedConfig) (*AdminClient, error) {
 	if config.LeaderURL == "" {
-		return nil, fmt.Errorf(errmsg.MsgLeaderURLRequired)
+		return nil, errmsg.Errorf(errmsg.MsgLeaderURLRequired)
 	}
 
 	return newAdminClientInternal(config)
@@ -77,15 +78,25 @@ func copyConfig(config *ResolvedConfig) *ResolvedConfig {
 		Project:                     config.Project,
 		Context:                     config.Context,
 		Timeout:                     config.Timeout,
+		Retries:                     config.Retries,
+		RetryMaxWait:                config.RetryMaxWait,
+		CacheTTL:                    config.CacheTTL,
 		Verbose:                     config.Verbose,
 		OutputFormat:                config.OutputFormat,
 		Color:                       config.Color,
 		Username:                    config.Username,
 		AuthMethod:                  config.AuthMethod,
 		InsecureSkipVerify:          config.InsecureSkipVerify,
+		CACertFile:                  config.CACertFile,
+		ClientCertFile:              config.ClientCertFile,
+		ClientKeyFile:               config.ClientKeyFile,
 		WorkerURL:                   config.WorkerURL,
 		WorkerName:                  config.WorkerName,
 		WorkerSource:                config.WorkerSource,
+		HTTPHeaders:                 mergeHTTPHeaders(config.HTTPHeaders, nil),
+		ProxyURL:                    config.ProxyURL,
+		MaxRequestsPerSecond:        config.MaxRequestsPerSecond,
+		MaxConcurrency:              config.MaxConcurrency,
 	}
 	if config.ClientKeys != nil {
 		cp.ClientKeys = make(map[string]TenantClientKeysConfig, len(config.ClientKeys))
@@ -104,26 +115,16 @@ func copyConfig(config *ResolvedConfig) *ResolvedConfig {
 
 // newHTTPClient creates a configured resty HTTP client.
 // This is shared between AdminClient and FeatureCheckClient.
-func newHTTPClient(baseURL string, timeout int, insecureSkipVerify bool) *resty.Client {
+func newHTTPClient(baseURL string, timeout int, insecureSkipVerify bool, retry RetryPolicy) *resty.Client {
 	client := resty.New().
 		SetBaseURL(baseURL).
-		SetTimeout(time.Duration(timeout) * time.Second).
-		SetRetryCount(3).
-		SetRetryWaitTime(1 * time.Second).
-		SetRetryMaxWaitTime(5 * time.Second).
-		AddRetryCondition(func(r *resty.Response, err error) bool {
-			// IMPORTANT: Only retry on network errors or 5xx for idempotent methods (GET, HEAD)
-			// This prevents duplicate operations from POST/PUT/DELETE retries.
-			// Non-idempotent methods (POST, PUT, DELETE, PATCH) are NOT retried to avoid
-			// creating duplicate resources or applying the same modification multiple times.
-			if r == nil {
-				// Network error, safe to retry
-				return err != nil
-			}
-			method := r.Request.Method
-			isIdempotent := method == http.MethodGet || method == http.MethodHead
-			return isIdempotent && (err != nil || r.StatusCode() >= 500)
-		})
+		SetTimeout(time.Duration(timeout) * time.Second)
+
+	configureRetries(client, retry)
+
+	if logger := getHTTPLogger(); logger != nil {
+		enableHTTPTracing(client, logger)
+	}
 
 	// Configure TLS to skip certificate verification if requested
 	if insecureSkipVerify {
@@ -132,6 +133,9 @@ func newHTTPClient(baseURL string, timeout int, insecureSkipVerify bool) *resty.
 		})
 	}
 
+	// Fail fast when the server has been failing repeatedly (e.g. in bulk loops)
+	enableCircuitBreaker(client, getCircuitBreaker(baseURL))
+
 	return client
 }
 
@@ -139,7 +143,14 @@ func newHTTPClient(baseURL string, timeout int, insecureSkipVerify bool) *resty.
 func newAdminClientInternal(config *ResolvedConfig) (*AdminClient, error) {
 	configCopy := copyConfig(config)
 
-	httpClient := newHTTPClient(configCopy.LeaderURL, configCopy.Timeout, configCopy.InsecureSkipVerify)
+	httpClient := newHTTPClient(configCopy.LeaderURL, configCopy.Timeout, configCopy.InsecureSkipVerify, retryPolicyFromConfig(configCopy))
+	if err := configureTransport(httpClient, configCopy); err != nil {
+		return nil, err
+	}
+
+	// Installed last: the TLS settings above require the default *http.Transport.
+	// Cached responses are not rate limited, traced nor recorded.
+	httpClient.SetTransport(newCachingTransport(rateLimitTransport(traceTransport(recordTransport(httpClient.GetClient().Transport)), configCopy), time.Duration(configCopy.CacheTTL)*time.Second))
 
 	izClient := &AdminClient{
 		http:             httpClient,
@@ -358,6 +369,7 @@ func sensitiveHeadersMap() map[string]bool {
 		"x-api-key":             true,
 		"authentication":        true,
 		"www-authenticate":      true,
+		"proxy-authorization":   true,
 	}
 }
 
@@ -394,19 +406,41 @@ func (c *AdminClient) handleError(resp *resty.Response) error {
 // parseAPIError parses error responses and returns a structured APIError.
 // This is shared between AdminClient and FeatureCheckClient.
 func parseAPIError(resp *resty.Response) error {
-	rawBody := string(resp.Body())
+	err := apiErrorFromBody(resp.StatusCode(), resp.Body())
+	if apiErr, ok := err.(*APIError); ok {
+		apiErr.RequestID = requestIDOf(resp.Header())
+	}
+	return err
+}
+
+// requestIDHeaders are the response headers carrying a request ID, most common first
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Trace-Id"}
+
+// requestIDOf returns the request ID of a response, empty when it has none
+func requestIDOf(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// apiErrorFromBody builds an APIError from the status code and body of an error response
+func apiErrorFromBody(statusCode int, body []byte) error {
+	rawBody := string(body)
 
 	var errResp ErrorResponse
-	if err := json.Unmarshal(resp.Body(), &errResp); err == nil && errResp.Message != "" {
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
 		return &APIError{
-			StatusCode: resp.StatusCode(),
+			StatusCode: statusCode,
 			Message:    errResp.Message,
 			RawBody:    rawBody,
 		}
 	}
 
 	return &APIError{
-		StatusCode: resp.StatusCode(),
+		StatusCode: statusCode,
 		Message:    rawBody,
 		RawBody:    rawBody,
 	}
@@ -435,11 +469,11 @@ func (c *AdminClient) Login(ctx context.Context, username, password string) (str
 		Post("/api/admin/login")
 
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", errmsg.MsgLoginRequestFailed, err)
+		return "", fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgLoginRequestFailed), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf(errmsg.MsgLoginFailed, resp.StatusCode())
+		return "", errmsg.Errorf(errmsg.MsgLoginFailed, resp.StatusCode())
 	}
 
 	// Extract JWT token from Set-Cookie header
@@ -449,5 +483,5 @@ func (c *AdminClient) Login(ctx context.Context, username, password string) (str
 		}
 	}
 
-	return "", fmt.Errorf(errmsg.MsgNoJWTTokenInResponse)
+	return "", errmsg.Errorf(errmsg.MsgNoJWTTokenInResponse)
 }
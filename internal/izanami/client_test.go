@@ -460,3 +460,22 @@ func TestAPIError_Interface(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "404")
 }
+
+func TestAPIError_RequestID(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "boom"}`))
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 5})
+	require.NoError(t, err)
+	_, err = ListTenants(client, context.Background(), nil, ParseTenants)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "boom", apiErr.Message)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+}
@@ -1,7 +1,10 @@
 package izanami
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -10,6 +13,8 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+	"gopkg.in/yaml.v3"
 )
 
 // Config key constants
@@ -24,12 +29,27 @@ const (
 	ConfigKeyProject                     = "project"
 	ConfigKeyContext                     = "context"
 	ConfigKeyTimeout                     = "timeout"
+	ConfigKeyRetries                     = "retries"
+	ConfigKeyRetryMaxWait                = "retry-max-wait"
+	ConfigKeyCacheTTL                    = "cache-ttl"
 	ConfigKeyVerbose                     = "verbose"
 	ConfigKeyOutputFormat                = "output-format"
 	ConfigKeyColor                       = "color"
 	ConfigKeyClientKeys                  = "client-keys"
 	ConfigKeyProfiles                    = "profiles"
 	ConfigKeyDefaultWorker               = "default-worker"
+	ConfigKeyHTTPHeaders                 = "http-headers"
+	ConfigKeyProxyURL                    = "proxy-url"
+	ConfigKeyHooks                       = "hooks"
+	ConfigKeyTimeouts                    = "timeouts"
+	ConfigKeyDisableSelfUpdate           = "disable-self-update"
+	ConfigKeyMaxRequestsPerSecond        = "max-requests-per-second"
+	ConfigKeyMaxConcurrency              = "max-concurrency"
+	ConfigKeyRateLimits                  = "rate-limits"
+	ConfigKeyGitAnnotations              = "git-annotations"
+	ConfigKeyStrictEnv                   = "strict-env"
+	ConfigKeyOtelEndpoint                = "otel-endpoint"
+	ConfigKeyRolloutGuardrails           = "rollout-guardrails"
 )
 
 // Display constants
@@ -47,12 +67,36 @@ type WorkerConfig struct {
 // This is what gets serialized/deserialized from the YAML config file.
 // For the resolved runtime state used by commands, see ResolvedConfig.
 type Config struct {
-	Timeout       int                 `yaml:"timeout" mapstructure:"timeout"`
-	Verbose       bool                `yaml:"verbose" mapstructure:"verbose"`
-	OutputFormat  string              `yaml:"output-format" mapstructure:"output-format"`
-	Color         string              `yaml:"color" mapstructure:"color"`
-	ActiveProfile string              `yaml:"active_profile,omitempty" mapstructure:"active_profile"`
-	Profiles      map[string]*Profile `yaml:"profiles,omitempty" mapstructure:"profiles"`
+	Timeout      int                     `yaml:"timeout" mapstructure:"timeout"`
+	Timeouts     map[string]int          `yaml:"timeouts,omitempty" mapstructure:"timeouts"`
+	Retries      int                     `yaml:"retries" mapstructure:"retries"`
+	RetryMaxWait int                     `yaml:"retry-max-wait" mapstructure:"retry-max-wait"`
+	CacheTTL     int                     `yaml:"cache-ttl" mapstructure:"cache-ttl"`
+	Verbose      bool                    `yaml:"verbose" mapstructure:"verbose"`
+	OutputFormat string                  `yaml:"output-format" mapstructure:"output-format"`
+	Color        string                  `yaml:"color" mapstructure:"color"`
+	HTTPHeaders  map[string]string       `yaml:"http-headers,omitempty" mapstructure:"http-headers"`
+	ProxyURL     string                  `yaml:"proxy-url,omitempty" mapstructure:"proxy-url"`
+	Hooks        map[string][]HookConfig `yaml:"hooks,omitempty" mapstructure:"hooks"`
+	// Client-side request limits; 0 disables a limit
+	MaxRequestsPerSecond float64              `yaml:"max-requests-per-second" mapstructure:"max-requests-per-second"`
+	MaxConcurrency       int                  `yaml:"max-concurrency" mapstructure:"max-concurrency"`
+	RateLimits           map[string]RateLimit `yaml:"rate-limits,omitempty" mapstructure:"rate-limits"`
+	// GitAnnotations records the git branch, commit and author of the working
+	// directory in the metadata of features created or updated from a git repository
+	GitAnnotations bool `yaml:"git-annotations,omitempty" mapstructure:"git-annotations"`
+	// StrictEnv makes ${NAME} references to unset environment variables an error
+	// instead of an empty value
+	StrictEnv bool `yaml:"strict-env,omitempty" mapstructure:"strict-env"`
+	// OtelEndpoint is the OTLP/HTTP collector receiving a span for each command
+	// and API call; empty disables span export
+	OtelEndpoint string `yaml:"otel-endpoint,omitempty" mapstructure:"otel-endpoint"`
+	// RolloutGuardrails are the Prometheus checks of 'iz admin features validate-rollout'
+	RolloutGuardrails *RolloutGuardrails `yaml:"rollout-guardrails,omitempty" mapstructure:"rollout-guardrails"`
+	// DisableSelfUpdate turns off 'iz self-update', e.g. when iz is installed by a package manager
+	DisableSelfUpdate bool                `yaml:"disable-self-update,omitempty" mapstructure:"disable-self-update"`
+	ActiveProfile     string              `yaml:"active_profile,omitempty" mapstructure:"active_profile"`
+	Profiles          map[string]*Profile `yaml:"profiles,omitempty" mapstructure:"profiles"`
 }
 
 // ResolvedConfig holds the fully resolved configuration for a CLI invocation.
@@ -60,10 +104,26 @@ type Config struct {
 // Commands should use this type, not Config.
 type ResolvedConfig struct {
 	// Global settings (from Config file)
-	Timeout      int
-	Verbose      bool
-	OutputFormat string
-	Color        string
+	Timeout           int
+	Retries           int
+	RetryMaxWait      int // seconds
+	CacheTTL          int // seconds, 0 disables the response cache
+	Verbose           bool
+	OutputFormat      string
+	Color             string
+	HTTPHeaders       map[string]string // extra headers sent with every request
+	ProxyURL          string
+	Hooks             map[string][]HookConfig // commands and URLs notified after mutations, by event
+	Timeouts          map[string]int          // request timeouts in seconds by command ("import", "features check")
+	DisableSelfUpdate bool
+	GitAnnotations    bool
+	OtelEndpoint      string             // OTLP/HTTP collector of the command spans, empty when disabled
+	RolloutGuardrails *RolloutGuardrails // metrics checked before a rollout, nil when not configured
+
+	// Client-side request limits shared by all clients; 0 disables a limit
+	MaxRequestsPerSecond float64
+	MaxConcurrency       int
+	RateLimits           map[string]RateLimit // limit overrides by command ("admin features delete")
 
 	// Resolved from profile/session/flags/env
 	LeaderURL                   string
@@ -79,12 +139,18 @@ type ResolvedConfig struct {
 	ClientKeys                  map[string]TenantClientKeysConfig
 	AuthMethod                  string
 	InsecureSkipVerify          bool
+	CACertFile                  string
+	ClientCertFile              string
+	ClientKeyFile               string
 
 	// Worker resolution (set by cmd layer after ResolveWorker)
 	WorkerURL        string
 	WorkerName       string
 	WorkerSource     string // source from ResolveWorker: "flag", "env-name", "env-url", "default", "standalone"
 	WorkerClientKeys map[string]TenantClientKeysConfig
+
+	// Project-local .iz.yaml found from the working directory, nil when none
+	LocalConfig *LocalConfig
 }
 
 // ResolvedWorker holds the result of worker resolution.
@@ -99,10 +165,25 @@ type ResolvedWorker struct {
 // copying global settings.
 func NewResolvedConfig(fileConfig *Config) *ResolvedConfig {
 	return &ResolvedConfig{
-		Timeout:      fileConfig.Timeout,
-		Verbose:      fileConfig.Verbose,
-		OutputFormat: fileConfig.OutputFormat,
-		Color:        fileConfig.Color,
+		Timeout:           fileConfig.Timeout,
+		Retries:           fileConfig.Retries,
+		RetryMaxWait:      fileConfig.RetryMaxWait,
+		CacheTTL:          fileConfig.CacheTTL,
+		Verbose:           fileConfig.Verbose,
+		OutputFormat:      fileConfig.OutputFormat,
+		Color:             fileConfig.Color,
+		HTTPHeaders:       mergeHTTPHeaders(fileConfig.HTTPHeaders, nil),
+		ProxyURL:          fileConfig.ProxyURL,
+		Hooks:             fileConfig.Hooks,
+		Timeouts:          fileConfig.Timeouts,
+		DisableSelfUpdate: fileConfig.DisableSelfUpdate,
+		GitAnnotations:    fileConfig.GitAnnotations,
+		OtelEndpoint:      fileConfig.OtelEndpoint,
+		RolloutGuardrails: fileConfig.RolloutGuardrails,
+
+		MaxRequestsPerSecond: fileConfig.MaxRequestsPerSecond,
+		MaxConcurrency:       fileConfig.MaxConcurrency,
+		RateLimits:           fileConfig.RateLimits,
 	}
 }
 
@@ -130,8 +211,12 @@ type Profile struct {
 	Context                     string                            `yaml:"context,omitempty" mapstructure:"context"`                                               // Default context for this profile
 	ClientKeys                  map[string]TenantClientKeysConfig `yaml:"client-keys,omitempty" mapstructure:"client-keys"`                                       // Profile-specific hierarchical client keys
 	InsecureSkipVerify          bool                              `yaml:"insecure-skip-verify,omitempty" mapstructure:"insecure-skip-verify"`                     // Skip TLS certificate verification
+	CACertFile                  string                            `yaml:"ca-cert,omitempty" mapstructure:"ca-cert"`                                               // PEM file of CA certificates trusted in addition to the system ones
+	ClientCertFile              string                            `yaml:"client-cert,omitempty" mapstructure:"client-cert"`                                       // PEM client certificate for mutual TLS
+	ClientKeyFile               string                            `yaml:"client-key,omitempty" mapstructure:"client-key"`                                         // PEM private key of the client certificate
 	DefaultWorker               string                            `yaml:"default-worker,omitempty" mapstructure:"default-worker"`                                 // Default worker name
 	Workers                     map[string]*WorkerConfig          `yaml:"workers,omitempty" mapstructure:"workers"`                                               // Named worker instances
+	Defaults                    map[string]map[string]interface{} `yaml:"defaults,omitempty" mapstructure:"defaults"`                                             // Flag defaults per command path (e.g. "features check")
 }
 
 // FlagValues holds command-line flag values for merging with config
@@ -146,16 +231,26 @@ type FlagValues struct {
 	Project                     string
 	Context                     string
 	Timeout                     int
+	Retries                     *int // nil when not set, so that 0 can disable retries
+	RetryMaxWait                int
+	CacheTTL                    int
+	NoCache                     bool
 	Verbose                     bool
 	OutputFormat                string
 	Color                       string
 	InsecureSkipVerify          bool
+	CACertFile                  string
+	ClientCertFile              string
+	ClientKeyFile               string
+	HTTPHeaders                 map[string]string // override the http-headers of the config by name
+	ProxyURL                    string
 }
 
 // LoadConfig loads configuration from multiple sources:
 // 1. Config file (~/.config/iz/config.yaml or platform-equivalent)
 // 2. Environment variables (IZ_*)
 // 3. Command-line flags (set by cobra, highest priority)
+// Secrets encrypted with EncryptConfigFile are decrypted transparently.
 func LoadConfig() (*Config, error) {
 	// Repair file permissions on every load (protects users upgrading from older versions)
 	repairConfigPermissions()
@@ -175,9 +270,15 @@ func LoadConfig() (*Config, error) {
 
 	// Set defaults
 	v.SetDefault(ConfigKeyTimeout, 30)
+	v.SetDefault(ConfigKeyRetries, DefaultRetries)
+	v.SetDefault(ConfigKeyRetryMaxWait, DefaultRetryMaxWait)
+	v.SetDefault(ConfigKeyCacheTTL, 0)
+	v.SetDefault(ConfigKeyMaxRequestsPerSecond, DefaultMaxRequestsPerSecond)
+	v.SetDefault(ConfigKeyMaxConcurrency, DefaultMaxConcurrency)
 	v.SetDefault(ConfigKeyVerbose, false)
 	v.SetDefault(ConfigKeyOutputFormat, "table")
 	v.SetDefault(ConfigKeyColor, "auto")
+	v.SetDefault(ConfigKeyStrictEnv, false)
 
 	// Read config file if it exists (ignore if not found)
 	if err := v.ReadInConfig(); err != nil {
@@ -192,6 +293,16 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Decrypt secrets encrypted with 'iz config encrypt'
+	if err := decryptConfigSecrets(config); err != nil {
+		return nil, err
+	}
+
+	// Expand ${NAME} references to environment variables
+	if err := expandConfigEnv(config); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -227,6 +338,18 @@ func (c *ResolvedConfig) MergeWithFlags(flags FlagValues) {
 	if flags.Timeout > 0 {
 		c.Timeout = flags.Timeout
 	}
+	if flags.Retries != nil {
+		c.Retries = *flags.Retries
+	}
+	if flags.RetryMaxWait > 0 {
+		c.RetryMaxWait = flags.RetryMaxWait
+	}
+	if flags.CacheTTL > 0 {
+		c.CacheTTL = flags.CacheTTL
+	}
+	if flags.NoCache {
+		c.CacheTTL = 0
+	}
 	if flags.Verbose {
 		c.Verbose = flags.Verbose
 	}
@@ -239,6 +362,34 @@ func (c *ResolvedConfig) MergeWithFlags(flags FlagValues) {
 	if flags.InsecureSkipVerify {
 		c.InsecureSkipVerify = flags.InsecureSkipVerify
 	}
+	if flags.CACertFile != "" {
+		c.CACertFile = flags.CACertFile
+	}
+	if flags.ClientCertFile != "" {
+		c.ClientCertFile = flags.ClientCertFile
+	}
+	if flags.ClientKeyFile != "" {
+		c.ClientKeyFile = flags.ClientKeyFile
+	}
+	if len(flags.HTTPHeaders) > 0 {
+		c.HTTPHeaders = mergeHTTPHeaders(c.HTTPHeaders, flags.HTTPHeaders)
+	}
+	if flags.ProxyURL != "" {
+		c.ProxyURL = flags.ProxyURL
+	}
+}
+
+// CommandTimeout returns the timeout configured in Timeouts for a command path
+// ("admin features check"), 0 when none is. The most specific key wins: the
+// full path, then shorter trailing paths down to the command name ("check").
+func (c *ResolvedConfig) CommandTimeout(path string) int {
+	words := strings.Fields(path)
+	for i := range words {
+		if timeout, ok := c.Timeouts[strings.Join(words[i:], " ")]; ok {
+			return timeout
+		}
+	}
+	return 0
 }
 
 // Validate checks if required configuration is present
@@ -257,12 +408,21 @@ func (c *ResolvedConfig) Validate() error {
 	hasUserAuth := c.JwtToken != "" || (c.PersonalAccessTokenUsername != "" && c.PersonalAccessToken != "")
 
 	if !hasClientAuth && !hasUserAuth {
-		return fmt.Errorf("authentication required: either client-id/client-secret, jwt-token, or personal-access-token with personal-access-token-username must be set")
+		return &AuthConfigError{Message: "authentication required: either client-id/client-secret, jwt-token, or personal-access-token with personal-access-token-username must be set"}
 	}
 
 	return nil
 }
 
+// AuthConfigError reports missing or incomplete credentials in the resolved configuration
+type AuthConfigError struct {
+	Message string
+}
+
+func (e *AuthConfigError) Error() string {
+	return e.Message
+}
+
 // ValidateAdminAuth checks if admin authentication is configured
 func (c *ResolvedConfig) ValidateAdminAuth() error {
 	if c.LeaderURL == "" {
@@ -274,12 +434,12 @@ func (c *ResolvedConfig) ValidateAdminAuth() error {
 	hasJwtAuth := c.JwtToken != ""
 
 	if !hasPatAuth && !hasJwtAuth {
-		return fmt.Errorf("admin operations require authentication: use 'iz login' for JWT, or set IZ_JWT_TOKEN, or set IZ_PERSONAL_ACCESS_TOKEN (with IZ_PERSONAL_ACCESS_TOKEN_USERNAME)")
+		return &AuthConfigError{Message: "admin operations require authentication: use 'iz login' for JWT, or set IZ_JWT_TOKEN, or set IZ_PERSONAL_ACCESS_TOKEN (with IZ_PERSONAL_ACCESS_TOKEN_USERNAME)"}
 	}
 
 	// If using PAT, username is required (for Basic auth)
 	if hasPatAuth && c.PersonalAccessTokenUsername == "" {
-		return fmt.Errorf("personal-access-token-username required when using personal access token (set IZ_PERSONAL_ACCESS_TOKEN_USERNAME or --personal-access-token-username)")
+		return &AuthConfigError{Message: "personal-access-token-username required when using personal access token (set IZ_PERSONAL_ACCESS_TOKEN_USERNAME or --personal-access-token-username)"}
 	}
 
 	return nil
@@ -288,7 +448,7 @@ func (c *ResolvedConfig) ValidateAdminAuth() error {
 // ValidateTenant checks if a tenant is configured (required for most operations)
 func (c *ResolvedConfig) ValidateTenant() error {
 	if c.Tenant == "" {
-		return fmt.Errorf(errors.MsgTenantRequired)
+		return errors.Errorf(errors.MsgTenantRequired)
 	}
 	return nil
 }
@@ -305,12 +465,26 @@ func (c *ResolvedConfig) ValidateClientAuth() error {
 	}
 
 	if c.ClientID == "" || c.ClientSecret == "" {
-		return fmt.Errorf("client credentials required: set IZ_CLIENT_ID and IZ_CLIENT_SECRET, or configure client-keys in your profile")
+		return &AuthConfigError{Message: "client credentials required: set IZ_CLIENT_ID and IZ_CLIENT_SECRET, or configure client-keys in your profile"}
 	}
 
 	return nil
 }
 
+// homeDir returns the user's home directory.
+// HOME (USERPROFILE on Windows) takes precedence so it can be overridden by wrappers and tests.
+func homeDir() string {
+	envVar := "HOME"
+	if runtime.GOOS == "windows" {
+		envVar = "USERPROFILE"
+	}
+	if home := os.Getenv(envVar); home != "" {
+		return home
+	}
+	home, _ := os.UserHomeDir()
+	return home
+}
+
 // getConfigDir is a variable that returns the platform-specific config directory
 // It's a variable (not a function) to allow tests to override it
 var getConfigDir = func() string {
@@ -318,20 +492,53 @@ var getConfigDir = func() string {
 
 	switch runtime.GOOS {
 	case "windows":
-		configDir = filepath.Join(os.Getenv("APPDATA"), "iz")
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			appData = filepath.Join(homeDir(), "AppData", "Roaming")
+		}
+		configDir = filepath.Join(appData, "iz")
 	case "darwin":
-		configDir = filepath.Join(os.Getenv("HOME"), ".config", "iz")
+		configDir = filepath.Join(homeDir(), ".config", "iz")
 	default: // linux and others
 		if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
 			configDir = filepath.Join(xdgConfig, "iz")
 		} else {
-			configDir = filepath.Join(os.Getenv("HOME"), ".config", "iz")
+			configDir = filepath.Join(homeDir(), ".config", "iz")
 		}
 	}
 
 	return configDir
 }
 
+// getCacheDir is a variable that returns the platform-specific cache directory
+// (%LOCALAPPDATA%\iz on Windows, $XDG_CACHE_HOME/iz or ~/.cache/iz elsewhere).
+// It's a variable (not a function) to allow tests to override it
+var getCacheDir = func() string {
+	switch runtime.GOOS {
+	case "windows":
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			localAppData = filepath.Join(homeDir(), "AppData", "Local")
+		}
+		return filepath.Join(localAppData, "iz")
+	default:
+		if xdgCache := os.Getenv("XDG_CACHE_HOME"); xdgCache != "" {
+			return filepath.Join(xdgCache, "iz")
+		}
+		return filepath.Join(homeDir(), ".cache", "iz")
+	}
+}
+
+// SetGetCacheDirFunc allows tests to override the cache directory resolution
+func SetGetCacheDirFunc(fn func() string) {
+	getCacheDir = fn
+}
+
+// GetCacheDir returns the platform-specific cache directory
+func GetCacheDir() string {
+	return getCacheDir()
+}
+
 // SetGetConfigDirFunc allows tests to override the config directory resolution
 func SetGetConfigDirFunc(fn func() string) {
 	getConfigDir = fn
@@ -346,7 +553,7 @@ func GetConfigDir() string {
 func InitConfigFile() error {
 	configDir := getConfigDir()
 	if err := os.MkdirAll(configDir, 0700); err != nil {
-		return fmt.Errorf(errors.MsgFailedToCreateConfigDir, err)
+		return errors.Errorf(errors.MsgFailedToCreateConfigDir, err)
 	}
 
 	configPath := filepath.Join(configDir, "config.yaml")
@@ -418,10 +625,20 @@ type ConfigValue struct {
 // GlobalConfigKeys defines keys that can be set via 'iz config set'
 // These are stored in the top-level config.yaml and apply to all profiles
 var GlobalConfigKeys = map[string]bool{
-	ConfigKeyTimeout:      true,
-	ConfigKeyVerbose:      true,
-	ConfigKeyOutputFormat: true,
-	ConfigKeyColor:        true,
+	ConfigKeyTimeout:              true,
+	ConfigKeyRetries:              true,
+	ConfigKeyRetryMaxWait:         true,
+	ConfigKeyCacheTTL:             true,
+	ConfigKeyVerbose:              true,
+	ConfigKeyOutputFormat:         true,
+	ConfigKeyColor:                true,
+	ConfigKeyProxyURL:             true,
+	ConfigKeyDisableSelfUpdate:    true,
+	ConfigKeyMaxRequestsPerSecond: true,
+	ConfigKeyMaxConcurrency:       true,
+	ConfigKeyGitAnnotations:       true,
+	ConfigKeyStrictEnv:            true,
+	ConfigKeyOtelEndpoint:         true,
 }
 
 // ProfileConfigKeys defines keys that are profile-specific
@@ -448,12 +665,27 @@ var ValidConfigKeys = map[string]bool{
 	ConfigKeyProject:                     true,
 	ConfigKeyContext:                     true,
 	ConfigKeyTimeout:                     true,
+	ConfigKeyRetries:                     true,
+	ConfigKeyRetryMaxWait:                true,
+	ConfigKeyCacheTTL:                    true,
 	ConfigKeyVerbose:                     true,
 	ConfigKeyOutputFormat:                true,
 	ConfigKeyColor:                       true,
 	ConfigKeyClientKeys:                  true,
 	ConfigKeyProfiles:                    true,
 	ConfigKeyDefaultWorker:               true,
+	ConfigKeyHTTPHeaders:                 true,
+	ConfigKeyProxyURL:                    true,
+	ConfigKeyHooks:                       true,
+	ConfigKeyTimeouts:                    true,
+	ConfigKeyDisableSelfUpdate:           true,
+	ConfigKeyMaxRequestsPerSecond:        true,
+	ConfigKeyMaxConcurrency:              true,
+	ConfigKeyRateLimits:                  true,
+	ConfigKeyGitAnnotations:              true,
+	ConfigKeyStrictEnv:                   true,
+	ConfigKeyOtelEndpoint:                true,
+	ConfigKeyRolloutGuardrails:           true,
 }
 
 // SensitiveKeys defines which keys contain sensitive information
@@ -506,7 +738,7 @@ func repairConfigPermissions() {
 // GetConfigValue gets a single configuration value with its source
 func GetConfigValue(key string) (*ConfigValue, error) {
 	if !ValidConfigKeys[key] {
-		return nil, fmt.Errorf(errors.MsgInvalidConfigKey, key)
+		return nil, errors.Errorf(errors.MsgInvalidConfigKey, key)
 	}
 
 	// Repair permissions before reading
@@ -522,6 +754,11 @@ func GetConfigValue(key string) (*ConfigValue, error) {
 
 	// Set defaults
 	v.SetDefault(ConfigKeyTimeout, 30)
+	v.SetDefault(ConfigKeyRetries, DefaultRetries)
+	v.SetDefault(ConfigKeyRetryMaxWait, DefaultRetryMaxWait)
+	v.SetDefault(ConfigKeyCacheTTL, 0)
+	v.SetDefault(ConfigKeyMaxRequestsPerSecond, DefaultMaxRequestsPerSecond)
+	v.SetDefault(ConfigKeyMaxConcurrency, DefaultMaxConcurrency)
 	v.SetDefault(ConfigKeyVerbose, false)
 	v.SetDefault(ConfigKeyOutputFormat, "table")
 	v.SetDefault(ConfigKeyColor, "auto")
@@ -581,7 +818,7 @@ func SetConfigValue(key, value string) error {
 		if ProfileConfigKeys[key] {
 			return fmt.Errorf("'%s' is a profile-specific setting. Use 'iz profiles set %s <value>' instead", key, key)
 		}
-		return fmt.Errorf(errors.MsgInvalidConfigKey, key)
+		return errors.Errorf(errors.MsgInvalidConfigKey, key)
 	}
 
 	configPath := GetConfigPath()
@@ -589,7 +826,7 @@ func SetConfigValue(key, value string) error {
 
 	// Create config directory if it doesn't exist
 	if err := os.MkdirAll(configDir, 0700); err != nil {
-		return fmt.Errorf(errors.MsgFailedToCreateConfigDir, err)
+		return errors.Errorf(errors.MsgFailedToCreateConfigDir, err)
 	}
 
 	v := viper.New()
@@ -599,7 +836,7 @@ func SetConfigValue(key, value string) error {
 	// Read existing config if it exists
 	if _, err := os.Stat(configPath); err == nil {
 		if err := v.ReadInConfig(); err != nil {
-			return fmt.Errorf(errors.MsgFailedToReadConfigFile, err)
+			return errors.Errorf(errors.MsgFailedToReadConfigFile, err)
 		}
 	}
 
@@ -610,7 +847,7 @@ func SetConfigValue(key, value string) error {
 	if err := v.WriteConfig(); err != nil {
 		// If config doesn't exist, create it
 		if err := v.SafeWriteConfig(); err != nil {
-			return fmt.Errorf(errors.MsgFailedToWriteConfigFile, err)
+			return errors.Errorf(errors.MsgFailedToWriteConfigFile, err)
 		}
 	}
 
@@ -625,7 +862,7 @@ func SetConfigValue(key, value string) error {
 // UnsetConfigValue removes a configuration value from the config file
 func UnsetConfigValue(key string) error {
 	if !ValidConfigKeys[key] {
-		return fmt.Errorf(errors.MsgInvalidConfigKey, key)
+		return errors.Errorf(errors.MsgInvalidConfigKey, key)
 	}
 
 	configPath := GetConfigPath()
@@ -641,7 +878,7 @@ func UnsetConfigValue(key string) error {
 
 	// Read existing config
 	if err := v.ReadInConfig(); err != nil {
-		return fmt.Errorf(errors.MsgFailedToReadConfigFile, err)
+		return errors.Errorf(errors.MsgFailedToReadConfigFile, err)
 	}
 
 	// Get all settings
@@ -697,6 +934,18 @@ func ResetConfig() error {
 	return nil
 }
 
+// WriteConfigData replaces the config file with raw YAML content (validate it first
+// with ValidateConfigData). The file is written atomically with 0600 permissions.
+func WriteConfigData(data []byte) error {
+	if err := os.MkdirAll(getConfigDir(), 0700); err != nil {
+		return errors.Errorf(errors.MsgFailedToCreateConfigDir, err)
+	}
+	if err := utils.WriteFileAtomic(GetConfigPath(), data, 0600); err != nil {
+		return errors.Errorf(errors.MsgFailedToWriteConfigFile, err)
+	}
+	return nil
+}
+
 // ValidationError represents a configuration validation error
 type ValidationError struct {
 	Field   string
@@ -708,17 +957,42 @@ type ValidationError struct {
 // as they are stored in profiles, not the global config file.
 // Use ValidateProfile to validate profile settings.
 func ValidateConfigFile() []ValidationError {
-	var errs []ValidationError
-
 	fileConfig, err := LoadConfig()
 	if err != nil {
-		errs = append(errs, ValidationError{
+		return []ValidationError{{
 			Field:   "general",
 			Message: fmt.Sprintf("Failed to load config: %v", err),
-		})
-		return errs
+		}}
 	}
 
+	return validateConfig(fileConfig)
+}
+
+// ValidateConfigData validates config.yaml content before it is saved. On top of
+// the checks of ValidateConfigFile, the YAML is decoded strictly against the config
+// schema: unknown keys and values of the wrong type are reported with their line.
+func ValidateConfigData(data []byte) []ValidationError {
+	fileConfig := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(fileConfig); err != nil && err != io.EOF {
+		if typeErr, ok := err.(*yaml.TypeError); ok {
+			errs := make([]ValidationError, 0, len(typeErr.Errors))
+			for _, msg := range typeErr.Errors {
+				errs = append(errs, ValidationError{Field: "schema", Message: msg})
+			}
+			return errs
+		}
+		return []ValidationError{{Field: "yaml", Message: err.Error()}}
+	}
+
+	return validateConfig(fileConfig)
+}
+
+// validateConfig checks the values of the global settings
+func validateConfig(fileConfig *Config) []ValidationError {
+	var errs []ValidationError
+
 	// Validate timeout (must be positive if set)
 	if fileConfig.Timeout < 0 {
 		errs = append(errs, ValidationError{
@@ -727,6 +1001,60 @@ func ValidateConfigFile() []ValidationError {
 		})
 	}
 
+	for _, command := range sortedKeys(fileConfig.Timeouts) {
+		if fileConfig.Timeouts[command] <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "timeouts",
+				Message: fmt.Sprintf("Timeout of %q must be a positive number of seconds", command),
+			})
+		}
+	}
+
+	// Validate retry settings (0 retries disables retrying)
+	if fileConfig.Retries < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "retries",
+			Message: "Retries must be zero or a positive number",
+		})
+	}
+	if fileConfig.RetryMaxWait < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "retry-max-wait",
+			Message: "Retry max wait must be a positive number of seconds",
+		})
+	}
+
+	// Validate request limits (0 disables a limit)
+	if fileConfig.MaxRequestsPerSecond < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "max-requests-per-second",
+			Message: "Max requests per second must be zero or a positive number",
+		})
+	}
+	if fileConfig.MaxConcurrency < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "max-concurrency",
+			Message: "Max concurrency must be zero or a positive number",
+		})
+	}
+	for _, command := range sortedKeys(fileConfig.RateLimits) {
+		limit := fileConfig.RateLimits[command]
+		if limit.MaxRequestsPerSecond < 0 || limit.MaxConcurrency < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "rate-limits",
+				Message: fmt.Sprintf("Rate limits of %q must be positive numbers", command),
+			})
+		}
+	}
+
+	// Validate cache TTL (0 disables the response cache)
+	if fileConfig.CacheTTL < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "cache-ttl",
+			Message: "Cache TTL must be zero or a positive number of seconds",
+		})
+	}
+
 	// Validate output format
 	if fileConfig.OutputFormat != "" && fileConfig.OutputFormat != "table" && fileConfig.OutputFormat != "json" {
 		errs = append(errs, ValidationError{
@@ -743,6 +1071,39 @@ func ValidateConfigFile() []ValidationError {
 		})
 	}
 
+	// Validate gateway settings
+	if fileConfig.ProxyURL != "" {
+		if _, err := ParseProxyURL(fileConfig.ProxyURL); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   "proxy-url",
+				Message: "Proxy URL must be an absolute http, https or socks5 URL",
+			})
+		}
+	}
+	if fileConfig.OtelEndpoint != "" {
+		if u, err := url.Parse(fileConfig.OtelEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, ValidationError{
+				Field:   "otel-endpoint",
+				Message: "OTLP endpoint must be an absolute http or https URL (e.g. http://localhost:4318)",
+			})
+		}
+	}
+	headerNames := make([]string, 0, len(fileConfig.HTTPHeaders))
+	for name := range fileConfig.HTTPHeaders {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		if !validHeaderName(name) {
+			errs = append(errs, ValidationError{
+				Field:   "http-headers",
+				Message: fmt.Sprintf("Invalid header name %q", name),
+			})
+		}
+	}
+	errs = append(errs, ValidateHooks(fileConfig.Hooks)...)
+	errs = append(errs, ValidateGuardrails(fileConfig.RolloutGuardrails)...)
+
 	return errs
 }
 
@@ -1056,6 +1417,16 @@ func (c *ResolvedConfig) MergeWithProfile(profile *Profile) {
 	if profile.InsecureSkipVerify && !c.InsecureSkipVerify {
 		c.InsecureSkipVerify = profile.InsecureSkipVerify
 	}
+	// TLS files: profile value unless set via flag or env
+	if profile.CACertFile != "" && c.CACertFile == "" {
+		c.CACertFile = profile.CACertFile
+	}
+	if profile.ClientCertFile != "" && c.ClientCertFile == "" {
+		c.ClientCertFile = profile.ClientCertFile
+	}
+	if profile.ClientKeyFile != "" && c.ClientKeyFile == "" {
+		c.ClientKeyFile = profile.ClientKeyFile
+	}
 
 	// Merge ClientKeys if profile has them and config doesn't
 	if profile.ClientKeys != nil && len(profile.ClientKeys) > 0 {
@@ -1076,9 +1447,10 @@ func (c *ResolvedConfig) MergeWithProfile(profile *Profile) {
 // Priority order:
 // 1. Command-line flags (handled by caller via MergeWithFlags)
 // 2. Environment variables (handled by viper)
-// 3. Profile settings
-// 4. Session settings (for auth)
-// 5. Top-level config (fallback)
+// 3. Project-local .iz.yaml (tenant, project and context only)
+// 4. Profile settings
+// 5. Session settings (for auth)
+// 6. Top-level config (fallback)
 func LoadConfigWithProfile(profileName string) (*ResolvedConfig, *Profile, error) {
 	// Load base config first
 	fileConfig, err := LoadConfig()
@@ -1101,9 +1473,20 @@ func LoadConfigWithProfile(profileName string) (*ResolvedConfig, *Profile, error
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to load profile '%s': %w", profileName, err)
 		}
+		// Fetch op:// and vault: credentials from their secret managers
+		if err := resolveProfileSecrets(activeProfile); err != nil {
+			return nil, nil, fmt.Errorf("profile '%s': %w", profileName, err)
+		}
 		resolved.MergeWithProfile(activeProfile)
 	}
 
+	// Project-local defaults override the profile, but not env vars or flags
+	local, err := FindLocalConfig(".")
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved.MergeWithLocalConfig(local)
+
 	return resolved, activeProfile, nil
 }
 
@@ -1114,7 +1497,7 @@ func SetActiveProfile(profileName string) error {
 
 	// Create config directory if it doesn't exist
 	if err := os.MkdirAll(configDir, 0700); err != nil {
-		return fmt.Errorf(errors.MsgFailedToCreateConfigDir, err)
+		return errors.Errorf(errors.MsgFailedToCreateConfigDir, err)
 	}
 
 	v := viper.New()
@@ -1124,7 +1507,7 @@ func SetActiveProfile(profileName string) error {
 	// Read existing config if it exists
 	if _, err := os.Stat(configPath); err == nil {
 		if err := v.ReadInConfig(); err != nil {
-			return fmt.Errorf(errors.MsgFailedToReadConfigFile, err)
+			return errors.Errorf(errors.MsgFailedToReadConfigFile, err)
 		}
 	}
 
@@ -1167,7 +1550,7 @@ func SetActiveProfile(profileName string) error {
 	newV.Set("profiles", profilesMap)
 
 	if err := newV.WriteConfigAs(configPath); err != nil {
-		return fmt.Errorf(errors.MsgFailedToWriteConfigFile, err)
+		return errors.Errorf(errors.MsgFailedToWriteConfigFile, err)
 	}
 
 	// Ensure secure file permissions
@@ -1192,7 +1575,7 @@ func AddProfile(name string, profile *Profile) error {
 
 	// Create config directory if it doesn't exist
 	if err := os.MkdirAll(configDir, 0700); err != nil {
-		return fmt.Errorf(errors.MsgFailedToCreateConfigDir, err)
+		return errors.Errorf(errors.MsgFailedToCreateConfigDir, err)
 	}
 
 	v := viper.New()
@@ -1202,10 +1585,25 @@ func AddProfile(name string, profile *Profile) error {
 	// Read existing config if it exists
 	if _, err := os.Stat(configPath); err == nil {
 		if err := v.ReadInConfig(); err != nil {
-			return fmt.Errorf(errors.MsgFailedToReadConfigFile, err)
+			return errors.Errorf(errors.MsgFailedToReadConfigFile, err)
 		}
 	}
 
+	// Keep the ${NAME} references of the stored profile instead of their values
+	profile, err := withEnvReferences(profile, v, name)
+	if err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	// Keep an encrypted config file encrypted
+	if ConfigFileEncrypted() {
+		encrypted, err := encryptedProfileCopy(profile)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt profile secrets: %w", err)
+		}
+		profile = encrypted
+	}
+
 	// Get or create profiles map
 	profilesMap := v.GetStringMap(ConfigKeyProfiles)
 	if profilesMap == nil {
@@ -1244,12 +1642,24 @@ func AddProfile(name string, profile *Profile) error {
 	if profile.InsecureSkipVerify {
 		profileMap["insecure-skip-verify"] = profile.InsecureSkipVerify
 	}
+	if profile.CACertFile != "" {
+		profileMap["ca-cert"] = profile.CACertFile
+	}
+	if profile.ClientCertFile != "" {
+		profileMap["client-cert"] = profile.ClientCertFile
+	}
+	if profile.ClientKeyFile != "" {
+		profileMap["client-key"] = profile.ClientKeyFile
+	}
 	if profile.DefaultWorker != "" {
 		profileMap["default-worker"] = profile.DefaultWorker
 	}
 	if profile.Workers != nil && len(profile.Workers) > 0 {
 		profileMap["workers"] = profile.Workers
 	}
+	if len(profile.Defaults) > 0 {
+		profileMap["defaults"] = profile.Defaults
+	}
 
 	profilesMap[name] = profileMap
 
@@ -1289,7 +1699,7 @@ func AddProfile(name string, profile *Profile) error {
 	newV.Set("profiles", profilesMap)
 
 	if err := newV.WriteConfigAs(configPath); err != nil {
-		return fmt.Errorf(errors.MsgFailedToWriteConfigFile, err)
+		return errors.Errorf(errors.MsgFailedToWriteConfigFile, err)
 	}
 
 	// Ensure secure file permissions
@@ -1319,7 +1729,7 @@ func DeleteProfile(name string) error {
 
 	// Read existing config
 	if err := v.ReadInConfig(); err != nil {
-		return fmt.Errorf(errors.MsgFailedToReadConfigFile, err)
+		return errors.Errorf(errors.MsgFailedToReadConfigFile, err)
 	}
 
 	// Get profiles map
@@ -1452,7 +1862,7 @@ func AddWorker(name string, worker *WorkerConfig, force bool) error {
 		return err
 	}
 	if profileName == "" {
-		return fmt.Errorf(errors.MsgNoActiveProfileForWorker)
+		return errors.Errorf(errors.MsgNoActiveProfileForWorker)
 	}
 
 	profile, err := GetProfile(profileName)
@@ -1465,7 +1875,7 @@ func AddWorker(name string, worker *WorkerConfig, force bool) error {
 	}
 
 	if _, exists := profile.Workers[name]; exists && !force {
-		return fmt.Errorf(errors.MsgWorkerAlreadyExists, name, profileName)
+		return errors.Errorf(errors.MsgWorkerAlreadyExists, name, profileName)
 	}
 
 	profile.Workers[name] = worker
@@ -1490,7 +1900,7 @@ func DeleteWorker(name string) error {
 		return err
 	}
 	if profileName == "" {
-		return fmt.Errorf(errors.MsgNoActiveProfileForWorker)
+		return errors.Errorf(errors.MsgNoActiveProfileForWorker)
 	}
 
 	profile, err := GetProfile(profileName)
@@ -1499,11 +1909,11 @@ func DeleteWorker(name string) error {
 	}
 
 	if profile.Workers == nil {
-		return fmt.Errorf(errors.MsgWorkerNotFound, name, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, name, profileName)
 	}
 
 	if _, exists := profile.Workers[name]; !exists {
-		return fmt.Errorf(errors.MsgWorkerNotFound, name, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, name, profileName)
 	}
 
 	delete(profile.Workers, name)
@@ -1527,7 +1937,7 @@ func SetDefaultWorker(name string) error {
 		return err
 	}
 	if profileName == "" {
-		return fmt.Errorf(errors.MsgNoActiveProfileForWorker)
+		return errors.Errorf(errors.MsgNoActiveProfileForWorker)
 	}
 
 	profile, err := GetProfile(profileName)
@@ -1536,11 +1946,11 @@ func SetDefaultWorker(name string) error {
 	}
 
 	if profile.Workers == nil || len(profile.Workers) == 0 {
-		return fmt.Errorf(errors.MsgNoWorkersConfigured, profileName)
+		return errors.Errorf(errors.MsgNoWorkersConfigured, profileName)
 	}
 
 	if _, exists := profile.Workers[name]; !exists {
-		return fmt.Errorf(errors.MsgWorkerNotFound, name, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, name, profileName)
 	}
 
 	profile.DefaultWorker = name
@@ -1579,11 +1989,11 @@ func AddWorkerClientKeys(workerName, tenant string, projects []string, clientID,
 	}
 
 	if profile.Workers == nil {
-		return fmt.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
 	}
 	worker, exists := profile.Workers[workerName]
 	if !exists {
-		return fmt.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
 	}
 
 	// Initialize ClientKeys map if nil
@@ -1636,11 +2046,11 @@ func ListWorkerClientKeys(workerName string) (map[string]TenantClientKeysConfig,
 	}
 
 	if profile.Workers == nil {
-		return nil, workerName, fmt.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
+		return nil, workerName, errors.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
 	}
 	worker, exists := profile.Workers[workerName]
 	if !exists {
-		return nil, workerName, fmt.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
+		return nil, workerName, errors.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
 	}
 
 	return worker.ClientKeys, workerName, nil
@@ -1670,11 +2080,11 @@ func DeleteWorkerClientKeys(workerName, tenant, project string) error {
 	}
 
 	if profile.Workers == nil {
-		return fmt.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
 	}
 	worker, exists := profile.Workers[workerName]
 	if !exists {
-		return fmt.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
+		return errors.Errorf(errors.MsgWorkerNotFound, workerName, profileName)
 	}
 
 	if worker.ClientKeys == nil {
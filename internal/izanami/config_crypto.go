@@ -0,0 +1,394 @@
+package izanami
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// CONFIG ENCRYPTION AT REST
+// ============================================================================
+
+// ConfigKeyEnvVar is the environment variable holding the config encryption passphrase
+const ConfigKeyEnvVar = "IZ_CONFIG_KEY"
+
+const (
+	// encryptedValuePrefix marks a secret encrypted by EncryptConfigFile.
+	// The rest of the value is base64(salt | nonce | AES-256-GCM ciphertext).
+	encryptedValuePrefix = "enc:v1:"
+	configSaltSize       = 16
+	configKDFIterations  = 600000
+)
+
+// secretConfigKeys are the YAML keys whose values are encrypted, wherever they
+// appear in config.yaml (profiles, client keys, worker client keys)
+var secretConfigKeys = map[string]bool{
+	ConfigKeyPersonalAccessToken: true,
+	ConfigKeyClientSecret:        true,
+	ConfigKeyJwtToken:            true,
+}
+
+// configPassphraseFunc asks for the passphrase when IZ_CONFIG_KEY is not set.
+// The cmd layer installs a terminal prompt; nil means no prompt is possible.
+var configPassphraseFunc func() (string, error)
+
+// SetConfigPassphraseFunc sets the function used to prompt for the config passphrase
+func SetConfigPassphraseFunc(fn func() (string, error)) {
+	configPassphraseFunc = fn
+}
+
+// configKeys caches the passphrase and derived keys for the process, so that the
+// passphrase is asked once and the (slow) key derivation runs once per salt
+var configKeys = struct {
+	sync.Mutex
+	passphrase string
+	salt       []byte            // salt used for new encrypted values
+	derived    map[string][]byte // salt -> key, for the cached passphrase
+}{}
+
+// ResetConfigKeyCache forgets the cached passphrase and derived keys
+func ResetConfigKeyCache() {
+	configKeys.Lock()
+	defer configKeys.Unlock()
+	configKeys.passphrase = ""
+	configKeys.salt = nil
+	configKeys.derived = nil
+}
+
+// IsEncryptedConfigValue reports whether a config value was encrypted by EncryptConfigFile
+func IsEncryptedConfigValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// configPassphrase returns the cached passphrase, IZ_CONFIG_KEY or the prompted one
+func configPassphrase() (string, error) {
+	configKeys.Lock()
+	cached := configKeys.passphrase
+	configKeys.Unlock()
+	if cached != "" {
+		return cached, nil
+	}
+
+	passphrase := os.Getenv(ConfigKeyEnvVar)
+	if passphrase == "" {
+		if configPassphraseFunc == nil {
+			return "", fmt.Errorf("config file contains encrypted secrets: set %s to the passphrase", ConfigKeyEnvVar)
+		}
+		var err error
+		if passphrase, err = configPassphraseFunc(); err != nil {
+			return "", fmt.Errorf("failed to read config passphrase: %w", err)
+		}
+		if passphrase == "" {
+			return "", fmt.Errorf("config passphrase cannot be empty")
+		}
+	}
+	useConfigPassphrase(passphrase)
+	return passphrase, nil
+}
+
+// useConfigPassphrase caches a passphrase, dropping keys derived from another one
+func useConfigPassphrase(passphrase string) {
+	configKeys.Lock()
+	defer configKeys.Unlock()
+	if configKeys.passphrase != passphrase {
+		configKeys.passphrase = passphrase
+		configKeys.salt = nil
+		configKeys.derived = nil
+	}
+}
+
+// deriveConfigKey returns the AES-256 key of the cached passphrase for a salt
+func deriveConfigKey(salt []byte) ([]byte, error) {
+	configKeys.Lock()
+	defer configKeys.Unlock()
+	if key, ok := configKeys.derived[string(salt)]; ok {
+		return key, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, configKeys.passphrase, salt, configKDFIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	if configKeys.derived == nil {
+		configKeys.derived = make(map[string][]byte)
+	}
+	configKeys.derived[string(salt)] = key
+	if configKeys.salt == nil {
+		// Reuse the salt of existing values for new ones: one derivation per process
+		configKeys.salt = append([]byte(nil), salt...)
+	}
+	return key, nil
+}
+
+// encryptConfigValue encrypts a secret with the cached passphrase
+func encryptConfigValue(plaintext string) (string, error) {
+	configKeys.Lock()
+	salt := configKeys.salt
+	configKeys.Unlock()
+	if salt == nil {
+		salt = make([]byte, configSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+	}
+	key, err := deriveConfigKey(salt)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newConfigGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	payload := append(append(append([]byte(nil), salt...), nonce...), gcm.Seal(nil, nonce, []byte(plaintext), nil)...)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(payload), nil
+}
+
+// decryptConfigValue decrypts a value produced by encryptConfigValue
+func decryptConfigValue(value string) (string, error) {
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	if len(payload) < configSaltSize {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	key, err := deriveConfigKey(payload[:configSaltSize])
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newConfigGCM(key)
+	if err != nil {
+		return "", err
+	}
+	rest := payload[configSaltSize:]
+	if len(rest) < gcm.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt config secrets: wrong passphrase or corrupted value")
+	}
+	return string(plaintext), nil
+}
+
+func newConfigGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// decryptSecretValue decrypts a value when it is encrypted, asking for the passphrase if needed
+func decryptSecretValue(value string) (string, error) {
+	if !IsEncryptedConfigValue(value) {
+		return value, nil
+	}
+	if _, err := configPassphrase(); err != nil {
+		return "", err
+	}
+	return decryptConfigValue(value)
+}
+
+// encryptSecretValue encrypts a plaintext value, leaving empty and encrypted
+// values, ${NAME} environment references and secret provider references as is
+func encryptSecretValue(value string) (string, error) {
+	if value == "" || IsEncryptedConfigValue(value) || hasEnvReference(value) || IsSecretReference(value) {
+		return value, nil
+	}
+	if _, err := configPassphrase(); err != nil {
+		return "", err
+	}
+	return encryptConfigValue(value)
+}
+
+// transformClientKeySecrets applies fn to every client secret of a client keys map
+func transformClientKeySecrets(keys map[string]TenantClientKeysConfig, fn func(string) (string, error)) error {
+	for tenant, tenantKeys := range keys {
+		secret, err := fn(tenantKeys.ClientSecret)
+		if err != nil {
+			return err
+		}
+		tenantKeys.ClientSecret = secret
+		if tenantKeys.Projects != nil {
+			projects := make(map[string]ProjectClientKeysConfig, len(tenantKeys.Projects))
+			for project, projectKeys := range tenantKeys.Projects {
+				if projectKeys.ClientSecret, err = fn(projectKeys.ClientSecret); err != nil {
+					return err
+				}
+				projects[project] = projectKeys
+			}
+			tenantKeys.Projects = projects
+		}
+		keys[tenant] = tenantKeys
+	}
+	return nil
+}
+
+// transformProfileSecrets applies fn to the personal access token and all client secrets of a profile
+func transformProfileSecrets(profile *Profile, fn func(string) (string, error)) error {
+	var err error
+	if profile.PersonalAccessToken, err = fn(profile.PersonalAccessToken); err != nil {
+		return err
+	}
+	if err := transformClientKeySecrets(profile.ClientKeys, fn); err != nil {
+		return err
+	}
+	for _, worker := range profile.Workers {
+		if worker != nil {
+			if err := transformClientKeySecrets(worker.ClientKeys, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decryptConfigSecrets decrypts the encrypted secrets of a loaded config in place
+func decryptConfigSecrets(config *Config) error {
+	for name, profile := range config.Profiles {
+		if profile == nil {
+			continue
+		}
+		if err := transformProfileSecrets(profile, decryptSecretValue); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// encryptedProfileCopy returns a copy of a profile with its secrets encrypted,
+// used to keep an encrypted config file encrypted when a profile is saved
+func encryptedProfileCopy(profile *Profile) (*Profile, error) {
+	data, err := yaml.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	clone := &Profile{}
+	if err := yaml.Unmarshal(data, clone); err != nil {
+		return nil, err
+	}
+	if err := transformProfileSecrets(clone, encryptSecretValue); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// ConfigFileEncrypted reports whether the config file contains encrypted secrets
+func ConfigFileEncrypted() bool {
+	data, err := os.ReadFile(GetConfigPath())
+	return err == nil && bytes.Contains(data, []byte(encryptedValuePrefix))
+}
+
+// EncryptConfigFile encrypts the plaintext secrets of the config file (personal access
+// tokens, client secrets and JWT tokens) with AES-256-GCM, using a key derived from
+// the passphrase. Other settings stay readable. Returns the number of encrypted values.
+func EncryptConfigFile(passphrase string) (int, error) {
+	return rewriteConfigSecrets(passphrase, func(value string) (string, error) {
+		if IsEncryptedConfigValue(value) {
+			// Already encrypted: check that it uses the same passphrase
+			if _, err := decryptConfigValue(value); err != nil {
+				return "", err
+			}
+			return value, nil
+		}
+		return encryptSecretValue(value)
+	})
+}
+
+// DecryptConfigFile decrypts the encrypted secrets of the config file back to plaintext.
+// Returns the number of decrypted values.
+func DecryptConfigFile(passphrase string) (int, error) {
+	return rewriteConfigSecrets(passphrase, decryptSecretValue)
+}
+
+// rewriteConfigSecrets applies fn to every secret value of the config file and
+// writes it back, keeping comments and key order
+func rewriteConfigSecrets(passphrase string, fn func(string) (string, error)) (int, error) {
+	if passphrase == "" {
+		return 0, fmt.Errorf("passphrase cannot be empty")
+	}
+	configPath := GetConfigPath()
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return 0, errors.Errorf(errors.MsgFailedToReadConfigFile, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return 0, errors.Errorf(errors.MsgFailedToReadConfigFile, err)
+	}
+
+	useConfigPassphrase(passphrase)
+	changed := 0
+	if err := walkSecretNodes(&root, func(node *yaml.Node) error {
+		value, err := fn(node.Value)
+		if err != nil {
+			return err
+		}
+		if value != node.Value {
+			node.Value = value
+			node.Style = 0
+			changed++
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return 0, err
+	}
+	if err := utils.WriteFileAtomic(configPath, buf.Bytes(), 0600); err != nil {
+		return 0, errors.Errorf(errors.MsgFailedToWriteConfigFile, err)
+	}
+	return changed, nil
+}
+
+// walkSecretNodes calls fn on every scalar value of a secret key in a YAML tree
+func walkSecretNodes(node *yaml.Node, fn func(*yaml.Node) error) error {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if secretConfigKeys[key.Value] && value.Kind == yaml.ScalarNode && value.Tag != "!!null" {
+				if err := fn(value); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := walkSecretNodes(value, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, child := range node.Content {
+		if err := walkSecretNodes(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package izanami
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const encryptionTestConfig = `# Izanami CLI Configuration
+timeout: 30
+active_profile: prod
+profiles:
+  prod:
+    leader-url: http://localhost:9000
+    personal-access-token-username: admin
+    personal-access-token: pat-secret
+    client-keys:
+      tenant1:
+        client-id: tenant-id
+        client-secret: tenant-secret
+        projects:
+          project1:
+            client-id: project-id
+            client-secret: project-secret
+`
+
+// setupEncryptionTest writes a config file with secrets in a temp config dir
+func setupEncryptionTest(t *testing.T) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	originalGetConfigDir := getConfigDir
+	originalPassphraseFunc := configPassphraseFunc
+	t.Cleanup(func() {
+		getConfigDir = originalGetConfigDir
+		configPassphraseFunc = originalPassphraseFunc
+		ResetConfigKeyCache()
+	})
+	getConfigDir = func() string { return tempDir }
+	configPassphraseFunc = nil
+	ResetConfigKeyCache()
+	t.Setenv(ConfigKeyEnvVar, "")
+
+	configPath := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(encryptionTestConfig), 0600))
+	return configPath
+}
+
+func TestEncryptConfigFile_RoundTrip(t *testing.T) {
+	configPath := setupEncryptionTest(t)
+
+	count, err := EncryptConfigFile("s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	content := string(data)
+	assert.NotContains(t, content, "pat-secret")
+	assert.NotContains(t, content, "tenant-secret")
+	assert.NotContains(t, content, "project-secret")
+	// Non-secret values and comments are kept
+	assert.Contains(t, content, "# Izanami CLI Configuration")
+	assert.Contains(t, content, "client-id: tenant-id")
+	assert.Contains(t, content, "personal-access-token-username: admin")
+	assert.True(t, ConfigFileEncrypted())
+
+	// Encrypting again is a no-op
+	count, err = EncryptConfigFile("s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// LoadConfig decrypts transparently with IZ_CONFIG_KEY
+	ResetConfigKeyCache()
+	t.Setenv(ConfigKeyEnvVar, "s3cret")
+	config, err := LoadConfig()
+	require.NoError(t, err)
+	profile := config.Profiles["prod"]
+	assert.Equal(t, "pat-secret", profile.PersonalAccessToken)
+	assert.Equal(t, "tenant-secret", profile.ClientKeys["tenant1"].ClientSecret)
+	assert.Equal(t, "project-secret", profile.ClientKeys["tenant1"].Projects["project1"].ClientSecret)
+
+	ResetConfigKeyCache()
+	count, err = DecryptConfigFile("s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.False(t, ConfigFileEncrypted())
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "personal-access-token: pat-secret")
+}
+
+func TestEncryptConfigFile_WrongPassphrase(t *testing.T) {
+	setupEncryptionTest(t)
+
+	_, err := EncryptConfigFile("s3cret")
+	require.NoError(t, err)
+
+	ResetConfigKeyCache()
+	_, err = DecryptConfigFile("wrong")
+	assert.ErrorContains(t, err, "wrong passphrase")
+
+	// Mixing passphrases in one file is refused
+	ResetConfigKeyCache()
+	_, err = EncryptConfigFile("other")
+	assert.ErrorContains(t, err, "wrong passphrase")
+
+	ResetConfigKeyCache()
+	t.Setenv(ConfigKeyEnvVar, "wrong")
+	_, err = LoadConfig()
+	assert.ErrorContains(t, err, "profile 'prod'")
+}
+
+func TestLoadConfig_EncryptedWithoutPassphrase(t *testing.T) {
+	setupEncryptionTest(t)
+
+	_, err := EncryptConfigFile("s3cret")
+	require.NoError(t, err)
+
+	ResetConfigKeyCache()
+	_, err = LoadConfig()
+	assert.ErrorContains(t, err, ConfigKeyEnvVar)
+
+	// The prompt is used when IZ_CONFIG_KEY is not set
+	prompts := 0
+	configPassphraseFunc = func() (string, error) {
+		prompts++
+		return "s3cret", nil
+	}
+	_, err = LoadConfig()
+	require.NoError(t, err)
+	_, err = LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 1, prompts, "passphrase is asked once per process")
+}
+
+func TestAddProfile_KeepsConfigEncrypted(t *testing.T) {
+	configPath := setupEncryptionTest(t)
+
+	_, err := EncryptConfigFile("s3cret")
+	require.NoError(t, err)
+
+	profile, err := GetProfile("prod")
+	require.NoError(t, err)
+	profile.PersonalAccessToken = "new-pat"
+	require.NoError(t, AddProfile("prod", profile))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "new-pat")
+	assert.NotContains(t, string(data), "tenant-secret")
+
+	ResetConfigKeyCache()
+	t.Setenv(ConfigKeyEnvVar, "s3cret")
+	profile, err = GetProfile("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "new-pat", profile.PersonalAccessToken)
+	assert.Equal(t, "tenant-secret", profile.ClientKeys["tenant1"].ClientSecret)
+}
@@ -0,0 +1,185 @@
+package izanami
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// ENVIRONMENT VARIABLE REFERENCES
+// ============================================================================
+
+// envReference matches a ${NAME} reference to an environment variable, or the
+// $${ escape of a literal ${
+var envReference = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// UndefinedEnvError reports config values referencing unset environment
+// variables in strict-env mode
+type UndefinedEnvError struct {
+	// References are "key: NAME" pairs, sorted
+	References []string
+}
+
+func (e *UndefinedEnvError) Error() string {
+	return fmt.Sprintf("config references undefined environment variables (%s is set): %s",
+		ConfigKeyStrictEnv, strings.Join(e.References, ", "))
+}
+
+// hasEnvReference reports whether a config value references an environment variable
+func hasEnvReference(value string) bool {
+	return strings.Contains(value, "${")
+}
+
+// expandEnvReferences replaces the ${NAME} references of a value with the
+// environment variables, unset ones expanding to "". It returns the names of
+// the unset variables.
+func expandEnvReferences(value string) (string, []string) {
+	if !hasEnvReference(value) {
+		return value, nil
+	}
+	var missing []string
+	expanded := envReference.ReplaceAllStringFunc(value, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		name := match[2 : len(match)-1]
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return env
+	})
+	return expanded, missing
+}
+
+// expandConfigEnv expands the environment variable references of every string
+// value of the config, profiles included, except hook commands. In strict
+// mode, references to unset variables are an error.
+func expandConfigEnv(config *Config) error {
+	var undefined []string
+	walkConfigStrings(reflect.ValueOf(config), "", func(path, value string) string {
+		// Hook commands run in a shell, which expands their variables itself
+		if strings.HasPrefix(path, ConfigKeyHooks+".") && strings.HasSuffix(path, ".command") {
+			return value
+		}
+		expanded, missing := expandEnvReferences(value)
+		for _, name := range missing {
+			undefined = append(undefined, path+": "+name)
+		}
+		return expanded
+	})
+	if config.StrictEnv && len(undefined) > 0 {
+		sort.Strings(undefined)
+		return &UndefinedEnvError{References: undefined}
+	}
+	return nil
+}
+
+// withEnvReferences returns a copy of a profile about to be saved where the
+// values loaded from a ${NAME} or secret provider reference of the raw profile
+// in the config file are set back to the reference, so that expanded or
+// resolved secrets are never written
+func withEnvReferences(profile *Profile, raw *viper.Viper, name string) (*Profile, error) {
+	stored := &Profile{}
+	if sub := raw.Sub(ConfigKeyProfiles + "." + name); sub != nil {
+		if err := sub.Unmarshal(stored); err != nil {
+			return profile, nil
+		}
+	}
+	references := make(map[string]string)
+	walkConfigStrings(reflect.ValueOf(stored), "", func(path, value string) string {
+		if hasEnvReference(value) || IsSecretReference(value) {
+			references[path] = value
+		}
+		return value
+	})
+	if len(references) == 0 {
+		return profile, nil
+	}
+
+	data, err := yaml.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	clone := &Profile{}
+	if err := yaml.Unmarshal(data, clone); err != nil {
+		return nil, err
+	}
+	walkConfigStrings(reflect.ValueOf(clone), "", func(path, value string) string {
+		if reference, ok := references[path]; ok {
+			expanded, _ := expandEnvReferences(reference)
+			if expanded == value {
+				return reference
+			}
+			if secret, ok := cachedSecretValue(expanded); ok && secret == value {
+				return reference
+			}
+		}
+		return value
+	})
+	return clone, nil
+}
+
+// walkConfigStrings calls fn on every string reachable from v (struct fields,
+// pointers, maps, slices and interfaces), replacing it with the result. Paths
+// join the yaml names of fields and the keys of maps with dots.
+func walkConfigStrings(v reflect.Value, path string, fn func(path, value string) string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			walkConfigStrings(v.Elem(), path, fn)
+		}
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		// The value held by an interface is not settable: walk a copy
+		concrete := reflect.New(v.Elem().Type()).Elem()
+		concrete.Set(v.Elem())
+		walkConfigStrings(concrete, path, fn)
+		v.Set(concrete)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			walkConfigStrings(v.Field(i), joinConfigPath(path, name), fn)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			// Map values are not settable: walk a copy and store it back
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			walkConfigStrings(elem, joinConfigPath(path, fmt.Sprint(key.Interface())), fn)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkConfigStrings(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(fn(path, v.String()))
+		}
+	}
+}
+
+// joinConfigPath appends a key to a dotted config path
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,109 @@
+package izanami
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envTestConfig = `timeout: 30
+active_profile: prod
+proxy-url: http://${IZ_TEST_PROXY_HOST}:3128
+hooks:
+  "*":
+    - command: echo ${IZ_HOOK_EVENT}
+profiles:
+  prod:
+    leader-url: https://${IZ_TEST_HOST}
+    personal-access-token-username: admin
+    personal-access-token: ${IZ_TEST_PAT}
+    client-keys:
+      tenant1:
+        client-id: tenant-id
+        client-secret: ${IZ_TEST_SECRET}
+    defaults:
+      features check:
+        user: $${literal}
+`
+
+// setupEnvConfig writes a config file with environment references in a temp config dir
+func setupEnvConfig(t *testing.T, config string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	originalGetConfigDir := getConfigDir
+	t.Cleanup(func() { getConfigDir = originalGetConfigDir })
+	getConfigDir = func() string { return tempDir }
+
+	configPath := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0600))
+	return configPath
+}
+
+func TestLoadConfig_ExpandsEnvReferences(t *testing.T) {
+	setupEnvConfig(t, envTestConfig)
+	t.Setenv("IZ_TEST_PROXY_HOST", "proxy.internal")
+	t.Setenv("IZ_TEST_HOST", "izanami.example.com")
+	t.Setenv("IZ_TEST_PAT", "pat-from-vault")
+	t.Setenv("IZ_TEST_SECRET", "secret-from-vault")
+
+	config, err := LoadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://proxy.internal:3128", config.ProxyURL)
+	assert.Equal(t, "echo ${IZ_HOOK_EVENT}", config.Hooks["*"][0].Command)
+	profile := config.Profiles["prod"]
+	assert.Equal(t, "https://izanami.example.com", profile.LeaderURL)
+	assert.Equal(t, "pat-from-vault", profile.PersonalAccessToken)
+	assert.Equal(t, "secret-from-vault", profile.ClientKeys["tenant1"].ClientSecret)
+	assert.Equal(t, "${literal}", profile.Defaults["features check"]["user"])
+}
+
+func TestLoadConfig_StrictEnv(t *testing.T) {
+	setupEnvConfig(t, "strict-env: true\n"+envTestConfig)
+	t.Setenv("IZ_TEST_PROXY_HOST", "proxy.internal")
+	t.Setenv("IZ_TEST_HOST", "izanami.example.com")
+	os.Unsetenv("IZ_TEST_PAT")
+	os.Unsetenv("IZ_TEST_SECRET")
+
+	_, err := LoadConfig()
+	var undefinedErr *UndefinedEnvError
+	require.ErrorAs(t, err, &undefinedErr)
+	assert.Equal(t, []string{
+		"profiles.prod.client-keys.tenant1.client-secret: IZ_TEST_SECRET",
+		"profiles.prod.personal-access-token: IZ_TEST_PAT",
+	}, undefinedErr.References)
+}
+
+func TestLoadConfig_UnsetEnvExpandsEmpty(t *testing.T) {
+	setupEnvConfig(t, envTestConfig)
+	os.Unsetenv("IZ_TEST_PAT")
+
+	config, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, config.Profiles["prod"].PersonalAccessToken)
+}
+
+func TestAddProfile_KeepsEnvReferences(t *testing.T) {
+	configPath := setupEnvConfig(t, envTestConfig)
+	t.Setenv("IZ_TEST_HOST", "izanami.example.com")
+	t.Setenv("IZ_TEST_PAT", "pat-from-vault")
+	t.Setenv("IZ_TEST_SECRET", "secret-from-vault")
+
+	profile, err := GetProfile("prod")
+	require.NoError(t, err)
+	profile.Tenant = "acme"
+	profile.LeaderURL = "https://other.example.com"
+	require.NoError(t, AddProfile("prod", profile))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "${IZ_TEST_PAT}")
+	assert.Contains(t, string(data), "${IZ_TEST_SECRET}")
+	assert.NotContains(t, string(data), "vault")
+	// A changed value replaces its reference
+	assert.Contains(t, string(data), "https://other.example.com")
+	assert.Equal(t, "pat-from-vault", profile.PersonalAccessToken)
+}
@@ -0,0 +1,99 @@
+package izanami
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// PROJECT-LOCAL CONFIG
+// ============================================================================
+
+// LocalConfigFileName is the per-repository config file, looked up from the
+// working directory and its parents
+const LocalConfigFileName = ".iz.yaml"
+
+// LocalConfig holds the per-repository defaults of a .iz.yaml file. Only the
+// targeted tenant, project and context can be set: servers and credentials
+// stay in the user's config, so that a cloned repository cannot redirect them.
+type LocalConfig struct {
+	Tenant  string `yaml:"tenant,omitempty"`
+	Project string `yaml:"project,omitempty"`
+	Context string `yaml:"context,omitempty"`
+	// Path is the file the config was read from
+	Path string `yaml:"-"`
+}
+
+// Value returns the value of a config key set by the local config, "" when unset
+func (l *LocalConfig) Value(key string) string {
+	if l == nil {
+		return ""
+	}
+	switch key {
+	case ConfigKeyTenant:
+		return l.Tenant
+	case ConfigKeyProject:
+		return l.Project
+	case ConfigKeyContext:
+		return l.Context
+	}
+	return ""
+}
+
+// FindLocalConfig reads the nearest .iz.yaml in dir or its parents, like git
+// finds its repository. It returns nil when there is none.
+func FindLocalConfig(dir string) (*LocalConfig, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil
+	}
+	for {
+		path := filepath.Join(dir, LocalConfigFileName)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return ParseLocalConfig(data, path)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// ParseLocalConfig parses the content of the .iz.yaml at path. Unknown keys are
+// rejected to catch typos and unsupported settings.
+func ParseLocalConfig(data []byte, path string) (*LocalConfig, error) {
+	local := &LocalConfig{Path: path}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(local); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid %s (only tenant, project and context can be set): %w", path, err)
+	}
+	return local, nil
+}
+
+// MergeWithLocalConfig applies the defaults of a local config. They override
+// the config file and profile, but not environment variables; flags are
+// merged afterwards by MergeWithFlags.
+func (c *ResolvedConfig) MergeWithLocalConfig(local *LocalConfig) {
+	if local == nil {
+		return
+	}
+	c.LocalConfig = local
+	for key, field := range map[string]*string{ConfigKeyTenant: &c.Tenant, ConfigKeyProject: &c.Project, ConfigKeyContext: &c.Context} {
+		if value := local.Value(key); value != "" && os.Getenv("IZ_"+strings.ToUpper(key)) == "" {
+			*field = value
+		}
+	}
+}
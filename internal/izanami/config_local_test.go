@@ -0,0 +1,60 @@
+package izanami
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindLocalConfig_Parents(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "checkout", "cmd")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	path := filepath.Join(root, "services", LocalConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte("tenant: acme\nproject: shop\n"), 0644))
+
+	local, err := FindLocalConfig(nested)
+	require.NoError(t, err)
+	require.NotNil(t, local)
+	assert.Equal(t, &LocalConfig{Tenant: "acme", Project: "shop", Path: path}, local)
+
+	// The nearest file wins
+	closer := filepath.Join(root, "services", "checkout", LocalConfigFileName)
+	require.NoError(t, os.WriteFile(closer, []byte("project: checkout\n"), 0644))
+	local, err = FindLocalConfig(nested)
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", local.Project)
+	assert.Empty(t, local.Tenant)
+
+	local, err = FindLocalConfig(root)
+	require.NoError(t, err)
+	assert.Nil(t, local)
+}
+
+func TestParseLocalConfig_RejectsUnknownKeys(t *testing.T) {
+	_, err := ParseLocalConfig([]byte("leader-url: https://evil.example.com\n"), ".iz.yaml")
+	assert.ErrorContains(t, err, "only tenant, project and context can be set")
+
+	local, err := ParseLocalConfig(nil, ".iz.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, local.Tenant)
+}
+
+func TestMergeWithLocalConfig(t *testing.T) {
+	t.Setenv("IZ_TENANT", "")
+	t.Setenv("IZ_PROJECT", "from-env")
+	cfg := &ResolvedConfig{Tenant: "profile-tenant", Project: "profile-project", Context: "prod"}
+
+	cfg.MergeWithLocalConfig(&LocalConfig{Tenant: "acme", Project: "shop", Path: "/repo/.iz.yaml"})
+
+	assert.Equal(t, "acme", cfg.Tenant)
+	assert.Equal(t, "profile-project", cfg.Project, "environment variables win over the local config")
+	assert.Equal(t, "prod", cfg.Context)
+	assert.Equal(t, "/repo/.iz.yaml", cfg.LocalConfig.Path)
+
+	cfg.MergeWithLocalConfig(nil)
+	assert.Equal(t, "acme", cfg.Tenant)
+}
@@ -0,0 +1,157 @@
+package izanami
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// EXTERNAL SECRET PROVIDERS
+// ============================================================================
+
+// SecretProvider resolves references to secrets kept in an external secret
+// manager, so that profile credentials never need to be stored in config.yaml
+type SecretProvider interface {
+	// Name identifies the provider in error messages
+	Name() string
+	// Matches reports whether a config value is a reference handled by the provider
+	Matches(value string) bool
+	// Resolve returns the secret a reference points to
+	Resolve(reference string) (string, error)
+}
+
+// secretProviders are the providers tried, in order, for each credential value
+var secretProviders = []SecretProvider{
+	onePasswordProvider{},
+	vaultProvider{},
+}
+
+// runSecretCommand runs a secret manager CLI and returns its standard output.
+// Tests replace it to avoid depending on installed CLIs.
+var runSecretCommand = func(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s not found in PATH", name)
+	}
+	var stdout, stderr bytes.Buffer
+	c := exec.Command(name, args...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", name, msg)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return stdout.String(), nil
+}
+
+// resolvedSecrets caches resolved references for the process: the config is
+// loaded several times per command, and secret managers may prompt the user
+var resolvedSecrets = struct {
+	sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// onePasswordProvider resolves op://vault/item/field references with the 1Password CLI
+type onePasswordProvider struct{}
+
+func (onePasswordProvider) Name() string { return "1Password" }
+
+func (onePasswordProvider) Matches(value string) bool {
+	return strings.HasPrefix(value, "op://")
+}
+
+func (onePasswordProvider) Resolve(reference string) (string, error) {
+	if strings.Count(strings.TrimPrefix(reference, "op://"), "/") < 2 {
+		return "", fmt.Errorf("invalid reference, expected op://vault/item/field")
+	}
+	out, err := runSecretCommand("op", "read", "--no-newline", reference)
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// vaultProvider resolves vault:secret/path#key references with the Vault CLI,
+// which reads VAULT_ADDR, VAULT_TOKEN and the other Vault settings itself
+type vaultProvider struct{}
+
+func (vaultProvider) Name() string { return "Vault" }
+
+func (vaultProvider) Matches(value string) bool {
+	return strings.HasPrefix(value, "vault:")
+}
+
+func (vaultProvider) Resolve(reference string) (string, error) {
+	path, key, ok := strings.Cut(strings.TrimPrefix(reference, "vault:"), "#")
+	if !ok || path == "" || key == "" {
+		return "", fmt.Errorf("invalid reference, expected vault:secret/path#key")
+	}
+	out, err := runSecretCommand("vault", "kv", "get", "-field="+key, path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+// secretProviderFor returns the provider handling a config value, nil when
+// the value is not a secret reference
+func secretProviderFor(value string) SecretProvider {
+	for _, provider := range secretProviders {
+		if provider.Matches(value) {
+			return provider
+		}
+	}
+	return nil
+}
+
+// IsSecretReference reports whether a config value references a secret of an
+// external secret provider (op://... or vault:...)
+func IsSecretReference(value string) bool {
+	return secretProviderFor(value) != nil
+}
+
+// resolveSecretValue returns the secret a reference points to, and any other
+// value as is
+func resolveSecretValue(value string) (string, error) {
+	provider := secretProviderFor(value)
+	if provider == nil {
+		return value, nil
+	}
+
+	resolvedSecrets.Lock()
+	defer resolvedSecrets.Unlock()
+	if secret, ok := resolvedSecrets.values[value]; ok {
+		return secret, nil
+	}
+	secret, err := provider.Resolve(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s secret %s: %w", provider.Name(), value, err)
+	}
+	if secret == "" {
+		return "", fmt.Errorf("%s secret %s is empty", provider.Name(), value)
+	}
+	resolvedSecrets.values[value] = secret
+	return secret, nil
+}
+
+// cachedSecretValue returns the value a reference resolved to in this
+// process, if it was resolved
+func cachedSecretValue(reference string) (string, bool) {
+	resolvedSecrets.Lock()
+	defer resolvedSecrets.Unlock()
+	secret, ok := resolvedSecrets.values[reference]
+	return secret, ok
+}
+
+// resolveProfileSecrets replaces the secret references of the personal access
+// token and client secrets of a profile with the secrets they point to
+func resolveProfileSecrets(profile *Profile) error {
+	if profile == nil {
+		return nil
+	}
+	return transformProfileSecrets(profile, resolveSecretValue)
+}
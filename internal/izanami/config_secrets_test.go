@@ -0,0 +1,162 @@
+package izanami
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secretsTestConfig = `active_profile: prod
+profiles:
+  prod:
+    leader-url: https://izanami.example.com
+    personal-access-token-username: ci-bot
+    personal-access-token: op://Ops/izanami-prod/token
+    client-keys:
+      tenant1:
+        client-id: tenant-id
+        client-secret: vault:secret/iz/${IZ_TEST_ENV}#client-secret
+`
+
+// stubSecretCommands replaces the secret manager CLIs with a map of outputs by
+// command line, clearing the resolved secrets cache. It returns the commands run.
+func stubSecretCommands(t *testing.T, outputs map[string]string) *[]string {
+	t.Helper()
+	var calls []string
+	original := runSecretCommand
+	t.Cleanup(func() {
+		runSecretCommand = original
+		resolvedSecrets.Lock()
+		resolvedSecrets.values = make(map[string]string)
+		resolvedSecrets.Unlock()
+	})
+	resolvedSecrets.Lock()
+	resolvedSecrets.values = make(map[string]string)
+	resolvedSecrets.Unlock()
+
+	runSecretCommand = func(name string, args ...string) (string, error) {
+		line := name + " " + strings.Join(args, " ")
+		calls = append(calls, line)
+		out, ok := outputs[line]
+		if !ok {
+			return "", fmt.Errorf("%s: item not found", name)
+		}
+		return out, nil
+	}
+	return &calls
+}
+
+func TestIsSecretReference(t *testing.T) {
+	assert.True(t, IsSecretReference("op://vault/item/field"))
+	assert.True(t, IsSecretReference("vault:secret/iz#token"))
+	assert.False(t, IsSecretReference("plain-secret"))
+	assert.False(t, IsSecretReference("${IZ_PAT}"))
+	assert.False(t, IsSecretReference(""))
+}
+
+func TestResolveSecretValue(t *testing.T) {
+	calls := stubSecretCommands(t, map[string]string{
+		"op read --no-newline op://Ops/item/token": "op-token",
+		"vault kv get -field=pat secret/iz":        "vault-token\n",
+	})
+
+	secret, err := resolveSecretValue("op://Ops/item/token")
+	require.NoError(t, err)
+	assert.Equal(t, "op-token", secret)
+
+	secret, err = resolveSecretValue("vault:secret/iz#pat")
+	require.NoError(t, err)
+	assert.Equal(t, "vault-token", secret)
+
+	// Plain values are returned as is, resolved references come from the cache
+	secret, err = resolveSecretValue("plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", secret)
+	_, err = resolveSecretValue("op://Ops/item/token")
+	require.NoError(t, err)
+	assert.Len(t, *calls, 2)
+}
+
+func TestResolveSecretValue_Errors(t *testing.T) {
+	stubSecretCommands(t, map[string]string{
+		"vault kv get -field=empty secret/iz": "\n",
+	})
+
+	tests := []struct {
+		reference string
+		want      string
+	}{
+		{"op://Ops/item", "expected op://vault/item/field"},
+		{"vault:secret/iz", "expected vault:secret/path#key"},
+		{"op://Ops/missing/token", "failed to resolve 1Password secret op://Ops/missing/token: op: item not found"},
+		{"vault:secret/iz#empty", "Vault secret vault:secret/iz#empty is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			_, err := resolveSecretValue(tt.reference)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoadConfigWithProfile_ResolvesSecretReferences(t *testing.T) {
+	setupEnvConfig(t, secretsTestConfig)
+	t.Setenv("IZ_TEST_ENV", "prod")
+	stubSecretCommands(t, map[string]string{
+		"op read --no-newline op://Ops/izanami-prod/token": "pat-from-1password",
+		"vault kv get -field=client-secret secret/iz/prod": "secret-from-vault\n",
+	})
+
+	resolved, profile, err := LoadConfigWithProfile("")
+	require.NoError(t, err)
+	assert.Equal(t, "pat-from-1password", resolved.PersonalAccessToken)
+	assert.Equal(t, "secret-from-vault", resolved.ClientKeys["tenant1"].ClientSecret)
+	assert.Equal(t, "pat-from-1password", profile.PersonalAccessToken)
+
+	// Other commands reading the profile see the references
+	stored, err := GetProfile("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "op://Ops/izanami-prod/token", stored.PersonalAccessToken)
+}
+
+func TestLoadConfigWithProfile_SecretReferenceError(t *testing.T) {
+	setupEnvConfig(t, secretsTestConfig)
+	stubSecretCommands(t, nil)
+
+	_, _, err := LoadConfigWithProfile("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profile 'prod': failed to resolve 1Password secret")
+}
+
+func TestAddProfile_KeepsSecretReferences(t *testing.T) {
+	configPath := setupEnvConfig(t, secretsTestConfig)
+	t.Setenv("IZ_TEST_ENV", "prod")
+	stubSecretCommands(t, map[string]string{
+		"op read --no-newline op://Ops/izanami-prod/token": "pat-from-1password",
+		"vault kv get -field=client-secret secret/iz/prod": "secret-from-vault",
+	})
+
+	_, profile, err := LoadConfigWithProfile("")
+	require.NoError(t, err)
+	profile.Tenant = "acme"
+	require.NoError(t, AddProfile("prod", profile))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "op://Ops/izanami-prod/token")
+	assert.Contains(t, string(data), "vault:secret/iz/${IZ_TEST_ENV}#client-secret")
+	assert.NotContains(t, string(data), "pat-from-1password")
+	assert.NotContains(t, string(data), "secret-from-vault")
+	assert.Contains(t, string(data), "acme")
+}
+
+func TestEncryptSecretValue_KeepsSecretReferences(t *testing.T) {
+	value, err := encryptSecretValue("op://Ops/item/token")
+	require.NoError(t, err)
+	assert.Equal(t, "op://Ops/item/token", value)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -662,3 +663,59 @@ func TestSetDefaultWorker_NonExistent(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestGetCacheDir_XDG(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("XDG_CACHE_HOME is not used on Windows")
+	}
+
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	assert.Equal(t, filepath.Join(cacheHome, "iz"), GetCacheDir())
+
+	home := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("HOME", home)
+	assert.Equal(t, filepath.Join(home, ".cache", "iz"), GetCacheDir())
+}
+
+func TestValidateConfigData(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		fields []string
+	}{
+		{"valid", "timeout: 30\ncolor: auto\nprofiles:\n  dev:\n    leader-url: http://localhost:9000\n", nil},
+		{"empty", "", nil},
+		{"unknown key", "timout: 30\n", []string{"schema"}},
+		{"unknown profile key", "profiles:\n  dev:\n    url: http://localhost:9000\n", []string{"schema"}},
+		{"wrong type", "timeout: thirty\n", []string{"schema"}},
+		{"invalid value", "timeout: -1\ncolor: blue\n", []string{"timeout", "color"}},
+		{"command timeouts", "timeouts:\n  import: 600\n  features check: 5\n", nil},
+		{"invalid command timeout", "timeouts:\n  import: 0\n", []string{"timeouts"}},
+		{"rate limits", "max-requests-per-second: 2.5\nmax-concurrency: 4\nrate-limits:\n  features delete:\n    max-concurrency: 2\n", nil},
+		{"invalid rate limits", "max-concurrency: -1\nrate-limits:\n  import:\n    max-requests-per-second: -5\n", []string{"max-concurrency", "rate-limits"}},
+		{"unknown rate limit key", "rate-limits:\n  import:\n    rps: 5\n", []string{"schema"}},
+		{"syntax error", "timeout: [\n", []string{"yaml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateConfigData([]byte(tt.data))
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestResolvedConfig_CommandTimeout(t *testing.T) {
+	config := &ResolvedConfig{Timeouts: map[string]int{"import": 600, "check": 10, "features check": 5}}
+
+	assert.Equal(t, 5, config.CommandTimeout("admin features check"))
+	assert.Equal(t, 10, config.CommandTimeout("check"))
+	assert.Equal(t, 600, config.CommandTimeout("admin import"))
+	assert.Equal(t, 0, config.CommandTimeout("admin features list"))
+	assert.Equal(t, 0, (&ResolvedConfig{}).CommandTimeout("admin import"))
+}
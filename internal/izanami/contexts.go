@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 )
@@ -41,7 +42,7 @@ func (c *AdminClient) listContextsRaw(ctx context.Context, tenant, project strin
 
 	resp, err := req.Get(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListContexts, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListContexts), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -79,7 +80,7 @@ func (c *AdminClient) CreateContext(ctx context.Context, tenant, project, name,
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateContext, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateContext), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -103,7 +104,7 @@ func (c *AdminClient) UpdateContext(ctx context.Context, tenant, contextPath str
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateContext, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateContext), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -129,7 +130,7 @@ func (c *AdminClient) DeleteContext(ctx context.Context, tenant, project, contex
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteContext, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteContext), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -138,3 +139,27 @@ func (c *AdminClient) DeleteContext(ctx context.Context, tenant, project, contex
 
 	return nil
 }
+
+// FindContextByPath returns the context at a full path (e.g. "prod/eu") in a context tree,
+// or nil if there is none
+func FindContextByPath(contexts []Context, path string) *Context {
+	return findContextByPath(contexts, strings.Trim(path, "/"), "")
+}
+
+func findContextByPath(contexts []Context, targetPath, parentPath string) *Context {
+	for i := range contexts {
+		fullPath := contexts[i].Name
+		if parentPath != "" {
+			fullPath = parentPath + "/" + contexts[i].Name
+		}
+		if fullPath == targetPath {
+			return &contexts[i]
+		}
+		if len(contexts[i].Children) > 0 && strings.HasPrefix(targetPath, fullPath+"/") {
+			if found := findContextByPath(contextsToSlice(contexts[i].Children), targetPath, fullPath); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
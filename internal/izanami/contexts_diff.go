@@ -0,0 +1,32 @@
+package izanami
+
+// ============================================================================
+// CONTEXT DIFF
+// ============================================================================
+
+// ContextOverloadSnapshots returns the overloads defined in the context at
+// path (not inherited from its parents), from the context trees of projects
+// keyed by project name. Snapshots are keyed by project-qualified feature name;
+// found reports whether a project has a context at path.
+func ContextOverloadSnapshots(trees map[string][]Context, path string) (snapshots map[string]*FeatureSnapshot, found bool, err error) {
+	snapshots = make(map[string]*FeatureSnapshot)
+	for project, contexts := range trees {
+		node := FindContextByPath(contexts, path)
+		if node == nil {
+			continue
+		}
+		found = true
+		for _, overload := range node.Overloads {
+			overloadProject := overload.Project
+			if overloadProject == "" {
+				overloadProject = project
+			}
+			snapshot := &FeatureSnapshot{Project: overloadProject, Name: overload.Name, Fields: make(map[string]string)}
+			if err := flattenOverload(snapshot.Fields, "", overload); err != nil {
+				return nil, false, err
+			}
+			snapshots[snapshot.Key()] = snapshot
+		}
+	}
+	return snapshots, found, nil
+}
@@ -0,0 +1,38 @@
+package izanami
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextOverloadSnapshots(t *testing.T) {
+	trees := map[string][]Context{
+		"shop": {
+			{Name: "prod", Global: true, Overloads: []FeatureOverload{{Name: "checkout", Enabled: true}}, Children: []*Context{
+				{Name: "eu", Overloads: []FeatureOverload{{Name: "search", Project: "shop", Enabled: false, Conditions: []ActivationCondition{{}}}}},
+			}},
+		},
+		"web": {
+			{Name: "prod", Global: true, Overloads: []FeatureOverload{{ID: "x", Name: "checkout", Enabled: false}}},
+		},
+	}
+
+	prod, found, err := ContextOverloadSnapshots(trees, "/prod")
+	require.NoError(t, err)
+	assert.True(t, found)
+	require.Len(t, prod, 2)
+	assert.Equal(t, map[string]string{"enabled": "true"}, prod["shop/checkout"].Fields)
+	assert.Equal(t, map[string]string{"enabled": "false"}, prod["web/checkout"].Fields, "IDs are not compared")
+
+	eu, found, err := ContextOverloadSnapshots(trees, "prod/eu")
+	require.NoError(t, err)
+	assert.True(t, found)
+	require.Contains(t, eu, "shop/search", "only the overloads of the context itself")
+	assert.Len(t, eu, 1)
+
+	_, found, err = ContextOverloadSnapshots(trees, "staging")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
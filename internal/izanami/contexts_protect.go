@@ -0,0 +1,122 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// CONTEXT PROTECTION
+// ============================================================================
+
+// ContextProtectionChange is the outcome of setting the protected status of one context
+type ContextProtectionChange struct {
+	Path    string `json:"path"`
+	Before  bool   `json:"before"`
+	After   bool   `json:"after"`
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SetContextProtection sets the protected status of a global context and, with
+// recursive, of all its descendants. Contexts already in the target status are
+// left untouched. Updates run level by level with a pool of concurrency
+// workers: parents before children when protecting, children before parents
+// when unprotecting, so that a child is never less protected than its parent
+// in between. Failures are reported per context, and a failed context stops
+// the updates of the following levels.
+func SetContextProtection(c *AdminClient, ctx context.Context, tenant, path string, protected, recursive bool, concurrency int) ([]ContextProtectionChange, error) {
+	path = strings.Trim(path, "/")
+	contexts, err := ListContexts(c, ctx, tenant, "", true, ParseContexts)
+	if err != nil {
+		return nil, err
+	}
+	root := FindContextByPath(contexts, path)
+	if root == nil {
+		return nil, fmt.Errorf("global context not found: %s", path)
+	}
+
+	// Group the contexts by depth below the root
+	var levels [][]ContextProtectionChange
+	var collect func(node *Context, nodePath string, depth int)
+	collect = func(node *Context, nodePath string, depth int) {
+		if len(levels) <= depth {
+			levels = append(levels, nil)
+		}
+		levels[depth] = append(levels[depth], ContextProtectionChange{
+			Path: nodePath, Before: node.IsProtected, After: protected, Changed: node.IsProtected != protected,
+		})
+		if !recursive {
+			return
+		}
+		for _, child := range node.Children {
+			if child != nil {
+				collect(child, nodePath+"/"+child.Name, depth+1)
+			}
+		}
+	}
+	collect(root, path, 0)
+	if !protected {
+		for i, j := 0, len(levels)-1; i < j; i, j = i+1, j-1 {
+			levels[i], levels[j] = levels[j], levels[i]
+		}
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var changes []ContextProtectionChange
+	failed := false
+	for _, level := range levels {
+		if !failed {
+			failed = updateContextProtectionLevel(c, ctx, tenant, level, protected, concurrency)
+		} else {
+			for i := range level {
+				if level[i].Changed {
+					level[i].Changed = false
+					level[i].Error = "skipped after a previous failure"
+				}
+			}
+		}
+		changes = append(changes, level...)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// updateContextProtectionLevel updates the contexts of one level in parallel
+// and reports whether any update failed
+func updateContextProtectionLevel(c *AdminClient, ctx context.Context, tenant string, level []ContextProtectionChange, protected bool, concurrency int) bool {
+	indexes := make(chan int)
+	var mu sync.Mutex
+	failed := false
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(concurrency, len(level)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				err := c.UpdateContext(ctx, tenant, level[index].Path, map[string]interface{}{"protected": protected})
+				if err != nil {
+					mu.Lock()
+					level[index].Changed = false
+					level[index].Error = err.Error()
+					failed = true
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for index := range level {
+		if level[index].Changed {
+			indexes <- index
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return failed
+}
@@ -0,0 +1,102 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// protectServer serves the global tree prod (protected) > eu > france, prod > us,
+// records context updates in order and fails the updates of failPath
+func protectServer(t *testing.T, failPath string) (*AdminClient, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	updates := []string{}
+
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			path := strings.TrimPrefix(r.URL.Path, "/api/admin/tenants/acme/contexts/")
+			if path == failPath {
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `{"message":"forbidden"}`)
+				return
+			}
+			var body map[string]interface{}
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &body)
+			mu.Lock()
+			updates = append(updates, path)
+			mu.Unlock()
+			assert.Contains(t, body, "protected")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		assert.Equal(t, "/api/admin/tenants/acme/contexts", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("all"))
+		io.WriteString(w, `[{"name":"prod","protected":true,"global":true,"children":[
+			{"name":"eu","protected":false,"global":true,"children":[{"name":"france","protected":false,"global":true}]},
+			{"name":"us","protected":true,"global":true}
+		]},{"name":"dev","protected":false,"global":true}]`)
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client, &updates
+}
+
+func TestSetContextProtection_Recursive(t *testing.T) {
+	client, updates := protectServer(t, "")
+
+	changes, err := SetContextProtection(client, context.Background(), "acme", "prod", true, true, 4)
+	require.NoError(t, err)
+
+	require.Len(t, changes, 4)
+	assert.Equal(t, ContextProtectionChange{Path: "prod", Before: true, After: true}, changes[0])
+	assert.Equal(t, ContextProtectionChange{Path: "prod/eu", Before: false, After: true, Changed: true}, changes[1])
+	assert.Equal(t, "prod/eu/france", changes[2].Path)
+	assert.True(t, changes[2].Changed)
+	assert.False(t, changes[3].Changed)
+	// Parents are protected before their children
+	assert.Equal(t, []string{"prod/eu", "prod/eu/france"}, *updates)
+}
+
+func TestSetContextProtection_UnprotectChildrenFirst(t *testing.T) {
+	client, updates := protectServer(t, "")
+
+	_, err := SetContextProtection(client, context.Background(), "acme", "/prod/", false, true, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod/us", "prod"}, *updates)
+}
+
+func TestSetContextProtection_NotRecursive(t *testing.T) {
+	client, updates := protectServer(t, "")
+
+	changes, err := SetContextProtection(client, context.Background(), "acme", "prod/eu", true, false, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []string{"prod/eu"}, *updates)
+}
+
+func TestSetContextProtection_Failure(t *testing.T) {
+	client, updates := protectServer(t, "prod/eu")
+
+	changes, err := SetContextProtection(client, context.Background(), "acme", "prod", true, true, 2)
+	require.NoError(t, err)
+	assert.Contains(t, changes[1].Error, "forbidden")
+	assert.False(t, changes[1].Changed)
+	assert.Equal(t, "skipped after a previous failure", changes[2].Error)
+	assert.Empty(t, *updates)
+
+	_, err = SetContextProtection(client, context.Background(), "acme", "missing", true, true, 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "global context not found: missing")
+}
@@ -0,0 +1,132 @@
+package izanami
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// CONTEXT TEMPLATES
+// ============================================================================
+
+// Outcomes of applying a template context
+const (
+	ContextTemplateCreated = "created"
+	ContextTemplateExists  = "exists"
+	ContextTemplateFailed  = "failed"
+	ContextTemplateSkipped = "skipped"
+)
+
+// ContextTemplate declares a context hierarchy to create in a project or globally
+type ContextTemplate struct {
+	Contexts []ContextTemplateNode `yaml:"contexts" json:"contexts"`
+}
+
+// ContextTemplateNode declares a context and its children
+type ContextTemplateNode struct {
+	Name      string                `yaml:"name" json:"name"`
+	Protected bool                  `yaml:"protected,omitempty" json:"protected,omitempty"`
+	Children  []ContextTemplateNode `yaml:"children,omitempty" json:"children,omitempty"`
+}
+
+// ContextTemplateResult is the outcome of applying one context of a template
+type ContextTemplateResult struct {
+	Path      string `json:"path"`
+	Protected bool   `json:"protected"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ParseContextTemplate parses a YAML or JSON context template
+func ParseContextTemplate(data []byte) (*ContextTemplate, error) {
+	var template ContextTemplate
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&template); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(template.Contexts) == 0 {
+		return nil, fmt.Errorf("the template declares no contexts")
+	}
+	seen := make(map[string]bool)
+	var validate func(nodes []ContextTemplateNode, parentPath string) error
+	validate = func(nodes []ContextTemplateNode, parentPath string) error {
+		for _, node := range nodes {
+			if node.Name == "" || strings.Contains(node.Name, "/") {
+				return fmt.Errorf("invalid context name %q under %q", node.Name, parentPath)
+			}
+			path := joinContextPath(parentPath, node.Name)
+			if seen[path] {
+				return fmt.Errorf("context %s is declared twice", path)
+			}
+			seen[path] = true
+			if err := validate(node.Children, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := validate(template.Contexts, ""); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ApplyContextTemplate creates the contexts of a template missing in a project,
+// or in the global contexts when project is empty. Parents are created before
+// their children; existing contexts are left as is, protected status included.
+// When a context cannot be created, its descendants are skipped. With dryRun,
+// nothing is created and the missing contexts are reported as created.
+func ApplyContextTemplate(c *AdminClient, ctx context.Context, tenant, project string, template *ContextTemplate, dryRun bool) ([]ContextTemplateResult, error) {
+	contexts, err := ListContexts(c, ctx, tenant, project, true, ParseContexts)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]serverContext)
+	collectServerContexts(existing, project, contexts, "")
+
+	var results []ContextTemplateResult
+	var apply func(nodes []ContextTemplateNode, parentPath string, parentFailed bool)
+	apply = func(nodes []ContextTemplateNode, parentPath string, parentFailed bool) {
+		for _, node := range nodes {
+			path := joinContextPath(parentPath, node.Name)
+			result := ContextTemplateResult{Path: path, Protected: node.Protected}
+			current, exists := existing[ManifestContext{Project: project, Path: path}.Key()]
+			switch {
+			case parentFailed:
+				result.Status = ContextTemplateSkipped
+			case exists:
+				result.Status = ContextTemplateExists
+				result.Protected = current.protected
+			case dryRun:
+				result.Status = ContextTemplateCreated
+			default:
+				body := map[string]interface{}{"name": node.Name, "protected": node.Protected}
+				if err := c.CreateContext(ctx, tenant, project, node.Name, parentPath, body); err != nil {
+					result.Status = ContextTemplateFailed
+					result.Error = err.Error()
+				} else {
+					result.Status = ContextTemplateCreated
+				}
+			}
+			results = append(results, result)
+			failed := result.Status == ContextTemplateFailed || result.Status == ContextTemplateSkipped
+			apply(node.Children, path, failed)
+		}
+	}
+	apply(template.Contexts, "", false)
+	return results, nil
+}
+
+// joinContextPath appends a context name to a parent path
+func joinContextPath(parentPath, name string) string {
+	if parentPath == "" {
+		return name
+	}
+	return parentPath + "/" + name
+}
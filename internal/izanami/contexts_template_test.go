@@ -0,0 +1,116 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contextTemplateYAML = `
+contexts:
+  - name: prod
+    protected: true
+    children:
+      - name: eu
+        protected: true
+        children:
+          - name: france
+      - name: us
+  - name: dev
+`
+
+func TestParseContextTemplate(t *testing.T) {
+	template, err := ParseContextTemplate([]byte(contextTemplateYAML))
+	require.NoError(t, err)
+	require.Len(t, template.Contexts, 2)
+	assert.Equal(t, "france", template.Contexts[0].Children[0].Children[0].Name)
+
+	_, err = ParseContextTemplate([]byte("contexts:\n  - name: prod/eu\n"))
+	assert.ErrorContains(t, err, "invalid context name")
+
+	_, err = ParseContextTemplate([]byte("contexts:\n  - name: dev\n  - name: dev\n"))
+	assert.ErrorContains(t, err, "declared twice")
+
+	_, err = ParseContextTemplate([]byte("contexts:\n  - name: dev\n    protect: true\n"))
+	assert.Error(t, err)
+
+	_, err = ParseContextTemplate([]byte(""))
+	assert.ErrorContains(t, err, "no contexts")
+}
+
+func TestApplyContextTemplate(t *testing.T) {
+	var created []string
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var body map[string]interface{}
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &body))
+			path := strings.TrimPrefix(r.URL.Path, "/api/admin/tenants/acme/projects/shop/contexts")
+			if body["name"] == "us" {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"message":"invalid"}`)
+				return
+			}
+			created = append(created, path+" "+body["name"].(string))
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		assert.Equal(t, "/api/admin/tenants/acme/projects/shop/contexts", r.URL.Path)
+		io.WriteString(w, `[{"name":"prod","protected":false,"global":false,"children":[{"name":"eu","protected":true,"global":false}]},
+			{"name":"dev","protected":false,"global":true}]`)
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	template, err := ParseContextTemplate([]byte(contextTemplateYAML + "    children:\n      - name: preview\n"))
+	require.NoError(t, err)
+	template.Contexts[0].Children[1].Children = []ContextTemplateNode{{Name: "east"}}
+
+	results, err := ApplyContextTemplate(client, context.Background(), "acme", "shop", template, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ContextTemplateResult{
+		{Path: "prod", Protected: false, Status: ContextTemplateExists},
+		{Path: "prod/eu", Protected: true, Status: ContextTemplateExists},
+		{Path: "prod/eu/france", Status: ContextTemplateCreated},
+		{Path: "prod/us", Status: ContextTemplateFailed, Error: results[3].Error},
+		{Path: "prod/us/east", Status: ContextTemplateSkipped},
+		{Path: "dev", Status: ContextTemplateCreated},
+		{Path: "dev/preview", Status: ContextTemplateCreated},
+	}, results)
+	assert.NotEmpty(t, results[3].Error)
+	// The global dev context is not a context of the project
+	assert.Equal(t, []string{"/prod/eu france", " dev", "/dev preview"}, created)
+}
+
+func TestApplyContextTemplate_DryRun(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"name":"prod","protected":true,"global":true}]`)
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	template, err := ParseContextTemplate([]byte(contextTemplateYAML))
+	require.NoError(t, err)
+
+	results, err := ApplyContextTemplate(client, context.Background(), "acme", "", template, true)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+	assert.Equal(t, ContextTemplateExists, results[0].Status)
+	for _, result := range results[1:] {
+		assert.Equal(t, ContextTemplateCreated, result.Status, result.Path)
+	}
+}
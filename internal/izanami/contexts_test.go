@@ -347,3 +347,25 @@ func TestClient_DeleteContext_WithProjectAndNestedPath(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestFindContextByPath(t *testing.T) {
+	contexts := []Context{
+		{Name: "dev"},
+		{
+			Name: "prod",
+			Children: []*Context{
+				{Name: "eu", Overloads: []FeatureOverload{{Name: "checkout", Enabled: true}}},
+				{Name: "us"},
+			},
+		},
+	}
+
+	found := FindContextByPath(contexts, "prod/eu")
+	require.NotNil(t, found)
+	assert.Equal(t, "eu", found.Name)
+	require.Len(t, found.Overloads, 1)
+
+	assert.Equal(t, "prod", FindContextByPath(contexts, "/prod/").Name)
+	assert.Nil(t, FindContextByPath(contexts, "eu"), "a child must be addressed by its full path")
+	assert.Nil(t, FindContextByPath(contexts, "prod/asia"))
+}
@@ -0,0 +1,263 @@
+package izanami
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// OFFLINE EVALUATION
+// ============================================================================
+
+// Activation rule types
+const (
+	RuleTypeAll            = "All"
+	RuleTypeUserList       = "UserList"
+	RuleTypeUserPercentage = "UserPercentage"
+)
+
+// percentageHashSeed is the MurmurHash3 seed used by Izanami for percentage rules
+const percentageHashSeed = 42
+
+// FeatureDefinition is a boolean feature definition, in the format accepted by
+// features test-definition, that can be evaluated without the server
+type FeatureDefinition struct {
+	ID         string                `json:"id,omitempty"`
+	Name       string                `json:"name"`
+	Enabled    bool                  `json:"enabled"`
+	ResultType string                `json:"resultType,omitempty"`
+	Conditions []ActivationCondition `json:"conditions,omitempty"`
+	WasmConfig json.RawMessage       `json:"wasmConfig,omitempty"`
+}
+
+// EvaluationContext is the request evaluated by EvaluateFeature
+type EvaluationContext struct {
+	User string
+	Date time.Time
+}
+
+// EvaluationResult is the outcome of an offline evaluation
+type EvaluationResult struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	// Condition is the 1-based index of the matching condition, 0 when none matched
+	Condition int    `json:"condition,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// EvaluateFeature evaluates a boolean feature definition locally. A disabled feature
+// is inactive, a feature without conditions is active, otherwise the feature is
+// active when any condition matches: its period (if any) contains the date and its
+// rule (if any) matches the user.
+func EvaluateFeature(def FeatureDefinition, ectx EvaluationContext) (*EvaluationResult, error) {
+	if len(def.WasmConfig) > 0 && string(def.WasmConfig) != "null" {
+		return nil, fmt.Errorf("script (WASM) features cannot be evaluated offline")
+	}
+	if def.ResultType != "" && def.ResultType != "boolean" {
+		return nil, fmt.Errorf("only boolean features can be evaluated offline (result type is %s)", def.ResultType)
+	}
+
+	result := &EvaluationResult{Name: def.Name}
+	if !def.Enabled {
+		result.Reason = "feature is disabled"
+		return result, nil
+	}
+	if len(def.Conditions) == 0 {
+		result.Active = true
+		result.Reason = "feature is enabled without conditions"
+		return result, nil
+	}
+
+	featureID := def.ID
+	if featureID == "" {
+		featureID = def.Name
+	}
+	for i, condition := range def.Conditions {
+		if condition.Period != nil {
+			ok, err := periodActive(*condition.Period, ectx.Date)
+			if err != nil {
+				return nil, fmt.Errorf("condition %d: %w", i+1, err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if condition.Rule != nil {
+			ok, err := ruleActive(*condition.Rule, featureID, ectx.User)
+			if err != nil {
+				return nil, fmt.Errorf("condition %d: %w", i+1, err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		result.Active = true
+		result.Condition = i + 1
+		result.Reason = fmt.Sprintf("condition %d matches (%s)", i+1, describeCondition(condition))
+		return result, nil
+	}
+	result.Reason = "no condition matches"
+	return result, nil
+}
+
+// describeCondition returns a short description of a condition for evaluation reasons
+func describeCondition(condition ActivationCondition) string {
+	var parts []string
+	if condition.Period != nil {
+		parts = append(parts, "period")
+	}
+	if condition.Rule != nil {
+		switch condition.Rule.Type {
+		case RuleTypeUserList:
+			parts = append(parts, "user list")
+		case RuleTypeUserPercentage:
+			parts = append(parts, fmt.Sprintf("%g%% of users", condition.Rule.Percentage))
+		default:
+			parts = append(parts, "all users")
+		}
+	}
+	if len(parts) == 0 {
+		return "always"
+	}
+	return strings.Join(parts, " and ")
+}
+
+// ruleActive tells whether a user matches an activation rule
+func ruleActive(rule ActivationRule, featureID, user string) (bool, error) {
+	switch rule.Type {
+	case "", RuleTypeAll:
+		return true, nil
+	case RuleTypeUserList:
+		for _, u := range rule.Users {
+			if u == user && user != "" {
+				return true, nil
+			}
+		}
+		return false, nil
+	case RuleTypeUserPercentage:
+		if user == "" {
+			return false, nil
+		}
+		return UserPercentageBucket(featureID, user) <= int(rule.Percentage), nil
+	}
+	return false, fmt.Errorf("unknown rule type %q", rule.Type)
+}
+
+// UserPercentageBucket returns the bucket (1 to 100) of a user for a feature.
+// A percentage rule of N% activates the feature for users of buckets 1 to N.
+// Buckets come from the MurmurHash3 of "<feature id>-<user>", as on the server.
+func UserPercentageBucket(featureID, user string) int {
+	hash := murmur3([]byte(featureID+"-"+user), percentageHashSeed)
+	// Same arithmetic as the JVM: abs(MinInt32) stays negative
+	if hash < 0 && hash != -1<<31 {
+		hash = -hash
+	}
+	return int(hash%100) + 1
+}
+
+// periodActive tells whether a date is within a period, evaluated in its timezone (UTC by default)
+func periodActive(period FeaturePeriod, date time.Time) (bool, error) {
+	location := time.UTC
+	if period.Timezone != "" {
+		loc, err := time.LoadLocation(period.Timezone)
+		if err != nil {
+			return false, fmt.Errorf("invalid timezone %q: %w", period.Timezone, err)
+		}
+		location = loc
+	}
+	date = date.In(location)
+
+	if period.Begin != nil && date.Before(*period.Begin) {
+		return false, nil
+	}
+	if period.End != nil && !date.Before(*period.End) {
+		return false, nil
+	}
+
+	if len(period.Days) > 0 {
+		found := false
+		for _, day := range period.Days {
+			if strings.EqualFold(day, date.Weekday().String()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if len(period.HourPeriods) > 0 {
+		now := date.Hour()*3600 + date.Minute()*60 + date.Second()
+		for _, hours := range period.HourPeriods {
+			start, err := parseTimeOfDay(hours.StartTime)
+			if err != nil {
+				return false, err
+			}
+			end, err := parseTimeOfDay(hours.EndTime)
+			if err != nil {
+				return false, err
+			}
+			if now >= start && now < end {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// parseTimeOfDay parses HH:mm or HH:mm:ss into seconds since midnight
+func parseTimeOfDay(value string) (int, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Hour()*3600 + t.Minute()*60 + t.Second(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid hour %q (expected HH:mm or HH:mm:ss)", value)
+}
+
+// murmur3 is the 32-bit MurmurHash3 (x86 variant) of data
+func murmur3(data []byte, seed uint32) int32 {
+	const c1, c2 = 0xcc9e2d51, 0x1b873593
+	rotl := func(x uint32, r uint) uint32 { return x<<r | x>>(32-r) }
+
+	h := seed
+	n := len(data) / 4
+	for i := 0; i < n; i++ {
+		k := uint32(data[4*i]) | uint32(data[4*i+1])<<8 | uint32(data[4*i+2])<<16 | uint32(data[4*i+3])<<24
+		k *= c1
+		k = rotl(k, 15)
+		k *= c2
+		h ^= k
+		h = rotl(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	tail := data[4*n:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = rotl(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return int32(h)
+}
@@ -0,0 +1,118 @@
+package izanami
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMurmur3_ReferenceValues(t *testing.T) {
+	assert.Equal(t, int32(0), murmur3([]byte(""), 0))
+	assert.Equal(t, uint32(0x514e28b7), uint32(murmur3([]byte(""), 1)))
+	assert.Equal(t, uint32(0x248bfa47), uint32(murmur3([]byte("hello"), 0)))
+	assert.Equal(t, uint32(0x2e4ff723), uint32(murmur3([]byte("The quick brown fox jumps over the lazy dog"), 0)))
+}
+
+func TestUserPercentageBucket(t *testing.T) {
+	counts := make(map[int]int)
+	for i := 0; i < 10000; i++ {
+		bucket := UserPercentageBucket("feature", fmt.Sprintf("user-%d", i))
+		require.GreaterOrEqual(t, bucket, 1)
+		require.LessOrEqual(t, bucket, 100)
+		counts[bucket]++
+	}
+	assert.Equal(t, UserPercentageBucket("feature", "alice"), UserPercentageBucket("feature", "alice"))
+	// Buckets are spread evenly enough for percentage rollouts
+	for bucket, n := range counts {
+		assert.InDelta(t, 100, n, 50, "bucket %d", bucket)
+	}
+}
+
+func parseDefinition(t *testing.T, data string) FeatureDefinition {
+	t.Helper()
+	var def FeatureDefinition
+	require.NoError(t, json.Unmarshal([]byte(data), &def))
+	return def
+}
+
+func TestEvaluateFeature_EnabledAndRules(t *testing.T) {
+	now := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+
+	result, err := EvaluateFeature(parseDefinition(t, `{"name": "f", "enabled": false}`), EvaluationContext{Date: now})
+	require.NoError(t, err)
+	assert.False(t, result.Active)
+	assert.Equal(t, "feature is disabled", result.Reason)
+
+	result, err = EvaluateFeature(parseDefinition(t, `{"name": "f", "enabled": true}`), EvaluationContext{Date: now})
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+
+	def := parseDefinition(t, `{"name": "f", "enabled": true, "resultType": "boolean", "conditions": [
+		{"rule": {"type": "UserList", "users": ["alice"]}},
+		{"rule": {"type": "UserPercentage", "percentage": 100}}
+	]}`)
+	result, err = EvaluateFeature(def, EvaluationContext{User: "alice", Date: now})
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, 1, result.Condition)
+	assert.Equal(t, "condition 1 matches (user list)", result.Reason)
+
+	result, err = EvaluateFeature(def, EvaluationContext{User: "bob", Date: now})
+	require.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, 2, result.Condition)
+
+	// Percentage rules need a user
+	result, err = EvaluateFeature(def, EvaluationContext{Date: now})
+	require.NoError(t, err)
+	assert.False(t, result.Active)
+	assert.Equal(t, "no condition matches", result.Reason)
+}
+
+func TestEvaluateFeature_Periods(t *testing.T) {
+	def := parseDefinition(t, `{"name": "f", "enabled": true, "conditions": [{
+		"period": {
+			"begin": "2025-01-01T00:00:00Z",
+			"end": "2026-01-01T00:00:00Z",
+			"days": ["MONDAY", "TUESDAY"],
+			"hourPeriods": [{"startTime": "09:00:00", "endTime": "18:00:00"}],
+			"timezone": "Europe/Paris"
+		},
+		"rule": {"type": "All"}
+	}]}`)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		active bool
+	}{
+		{"monday within hours", time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), true},
+		{"hours use the period timezone", time.Date(2025, 6, 2, 6, 30, 0, 0, time.UTC), false},
+		{"end of hour window is excluded", time.Date(2025, 6, 2, 16, 0, 0, 0, time.UTC), false},
+		{"wednesday", time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC), false},
+		{"before begin", time.Date(2024, 12, 30, 10, 0, 0, 0, time.UTC), false},
+		{"after end", time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EvaluateFeature(def, EvaluationContext{User: "alice", Date: tt.date})
+			require.NoError(t, err)
+			assert.Equal(t, tt.active, result.Active)
+		})
+	}
+}
+
+func TestEvaluateFeature_Unsupported(t *testing.T) {
+	_, err := EvaluateFeature(parseDefinition(t, `{"name": "f", "enabled": true, "resultType": "string"}`), EvaluationContext{})
+	assert.ErrorContains(t, err, "only boolean features")
+
+	_, err = EvaluateFeature(parseDefinition(t, `{"name": "f", "enabled": true, "wasmConfig": {"name": "script"}}`), EvaluationContext{})
+	assert.ErrorContains(t, err, "WASM")
+
+	_, err = EvaluateFeature(parseDefinition(t, `{"name": "f", "enabled": true, "conditions": [{"rule": {"type": "Custom"}}]}`), EvaluationContext{})
+	assert.ErrorContains(t, err, `condition 1: unknown rule type "Custom"`)
+}
@@ -50,7 +50,7 @@ func readSSELine(ctx context.Context, reader *bufio.Reader) (string, error) {
 		if err.Error() == "EOF" {
 			return "", err
 		}
-		return "", fmt.Errorf("%s: %w", errmsg.MsgErrorReadingEventStream, err)
+		return "", fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgErrorReadingEventStream), err)
 	}
 
 	line = strings.TrimSuffix(line, "\n")
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 )
@@ -36,7 +38,7 @@ func (c *AdminClient) Export(ctx context.Context, tenant string) (string, error)
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", errmsg.MsgFailedToExport, err)
+		return "", fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToExport), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -46,6 +48,61 @@ func (c *AdminClient) Export(ctx context.Context, tenant string) (string, error)
 	return string(resp.Body()), nil
 }
 
+// RedactExportSecrets strips secrets from NDJSON export data.
+// Fields whose name contains "secret" or "password" are removed and webhook
+// header values are blanked, so the result can be stored safely (e.g. as a CI artifact).
+// Blank lines are preserved; lines that are not valid JSON cause an error.
+func RedactExportSecrets(data string) (string, error) {
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var entry interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return "", fmt.Errorf("failed to parse export line %d: %w", i+1, err)
+		}
+
+		redacted, err := json.Marshal(redactSecrets(entry))
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize export line %d: %w", i+1, err)
+		}
+		lines[i] = string(redacted)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// redactSecrets recursively removes secret fields from a decoded JSON value
+func redactSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			lower := strings.ToLower(key)
+			switch {
+			case strings.Contains(lower, "secret"), strings.Contains(lower, "password"):
+				delete(v, key)
+			case lower == "headers":
+				if headers, ok := child.(map[string]interface{}); ok {
+					for name := range headers {
+						headers[name] = ""
+					}
+				}
+			default:
+				v[key] = redactSecrets(child)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactSecrets(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
 // ImportV2 imports tenant data from a V2 export file (synchronous)
 // Returns messages on success, or messages+conflicts on conflict (HTTP 409)
 func (c *AdminClient) ImportV2(ctx context.Context, tenant, filePath string, req ImportRequest) (*ImportV2Response, error) {
@@ -63,7 +120,7 @@ func (c *AdminClient) ImportV2(ctx context.Context, tenant, filePath string, req
 
 	resp, err := httpReq.Post(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToImport, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToImport), err)
 	}
 
 	var result ImportV2Response
@@ -87,6 +144,50 @@ func (c *AdminClient) ImportV2(ctx context.Context, tenant, filePath string, req
 	return &result, nil
 }
 
+// RemoveImportEntities returns NDJSON export data without the lines of the
+// given conflicting entities, matched on the row id, or on the row name for
+// conflicts without id, and the number of removed lines
+func RemoveImportEntities(data []byte, conflicts []ImportConflict) ([]byte, int, error) {
+	ids := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, conflict := range conflicts {
+		if conflict.ID != "" {
+			ids[conflict.ID] = true
+		} else if conflict.Name != "" {
+			names[conflict.Name] = true
+		}
+	}
+
+	lines := strings.Split(string(data), "\n")
+	kept := lines[:0]
+	removed := 0
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			kept = append(kept, line)
+			continue
+		}
+		var entry struct {
+			Row struct {
+				ID   interface{} `json:"id"`
+				Name string      `json:"name"`
+			} `json:"row"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse export line %d: %w", i+1, err)
+		}
+		id := ""
+		if entry.Row.ID != nil {
+			id = fmt.Sprint(entry.Row.ID)
+		}
+		if (id != "" && ids[id]) || (entry.Row.Name != "" && names[entry.Row.Name]) {
+			removed++
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return []byte(strings.Join(kept, "\n")), removed, nil
+}
+
 // ImportV1 imports Izanami v1 data into v2 server (async migration)
 // Returns an import job ID that can be polled with GetImportStatus
 func (c *AdminClient) ImportV1(ctx context.Context, tenant, filePath string, req ImportRequest) (*ImportV1Response, error) {
@@ -122,7 +223,7 @@ func (c *AdminClient) ImportV1(ctx context.Context, tenant, filePath string, req
 
 	resp, err := httpReq.Post(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToImport, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToImport), err)
 	}
 
 	if resp.StatusCode() != http.StatusAccepted {
@@ -159,3 +260,42 @@ func (c *AdminClient) GetImportStatus(ctx context.Context, tenant, importID stri
 
 	return &status, nil
 }
+
+// Statuses of an async V1 import
+const (
+	ImportStatusPending = "Pending"
+	ImportStatusSuccess = "Success"
+	ImportStatusFailed  = "Failed"
+)
+
+// DefaultImportPollInterval is the delay between two status requests of WaitForImport
+const DefaultImportPollInterval = 2 * time.Second
+
+// WaitForImport polls the status of an async V1 import every interval until it
+// is no longer pending, and returns the final status. progress, when not nil,
+// is called with every status received, including the final one.
+func WaitForImport(c *AdminClient, ctx context.Context, tenant, importID string, interval time.Duration, progress func(*ImportV1Status)) (*ImportV1Status, error) {
+	if interval <= 0 {
+		interval = DefaultImportPollInterval
+	}
+	for {
+		status, err := c.GetImportStatus(ctx, tenant, importID)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(status)
+		}
+		if status.Status != ImportStatusPending {
+			return status, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -566,3 +567,88 @@ func TestClient_GetImportStatus(t *testing.T) {
 	assert.Equal(t, "Success", status.Status)
 	assert.Equal(t, 10, status.Features)
 }
+
+func TestRemoveImportEntities(t *testing.T) {
+	data := `{"_type":"project","row":{"id":"p1","name":"shop"}}
+{"_type":"feature","row":{"id":"f1","name":"checkout"}}
+
+{"_type":"key","row":{"name":"ci-key"}}
+`
+	filtered, removed, err := RemoveImportEntities([]byte(data), []ImportConflict{{ID: "f1", Name: "checkout"}, {Name: "ci-key"}, {ID: "missing"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, "{\"_type\":\"project\",\"row\":{\"id\":\"p1\",\"name\":\"shop\"}}\n\n", string(filtered))
+
+	_, _, err = RemoveImportEntities([]byte("not json\n"), nil)
+	assert.Error(t, err)
+}
+
+func TestWaitForImport(t *testing.T) {
+	calls := 0
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/test-tenant/_import/import-123", r.URL.Path)
+		calls++
+		status := ImportV1Status{ID: "import-123", Status: ImportStatusPending, Features: calls * 10}
+		if calls == 3 {
+			status.Status = ImportStatusFailed
+			status.Errors = []string{"bad script"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	var seen []int
+	status, err := WaitForImport(client, context.Background(), "test-tenant", "import-123", time.Millisecond, func(s *ImportV1Status) {
+		seen = append(seen, s.Features)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, ImportStatusFailed, status.Status)
+	assert.Equal(t, []string{"bad script"}, status.Errors)
+	assert.Equal(t, []int{10, 20, 30}, seen)
+}
+
+func TestWaitForImport_Canceled(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ImportV1Status{ID: "import-123", Status: ImportStatusPending})
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = WaitForImport(client, ctx, "test-tenant", "import-123", time.Hour, func(*ImportV1Status) { cancel() })
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRedactExportSecrets(t *testing.T) {
+	exportData := `{"_type":"key","row":{"name":"my-key","clientid":"abc","clientsecret":"s3cr3t"}}
+{"_type":"webhook","row":{"name":"hook","url":"http://example.com","headers":{"Authorization":"Bearer token"}}}
+{"_type":"feature","row":{"id":"feature-1","name":"feature-1","enabled":true}}
+`
+
+	result, err := RedactExportSecrets(exportData)
+	require.NoError(t, err)
+
+	assert.NotContains(t, result, "s3cr3t")
+	assert.NotContains(t, result, "clientsecret")
+	assert.NotContains(t, result, "Bearer token")
+	assert.Contains(t, result, `"clientid":"abc"`)
+	assert.Contains(t, result, `"Authorization":""`)
+	assert.Contains(t, result, `"name":"feature-1"`)
+	assert.True(t, len(result) > 0 && result[len(result)-1] == '\n', "trailing newline should be preserved")
+}
+
+func TestRedactExportSecrets_InvalidLine(t *testing.T) {
+	_, err := RedactExportSecrets("{\"_type\":\"key\"}\nnot-json\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
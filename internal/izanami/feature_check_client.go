@@ -40,7 +40,11 @@ func NewFeatureCheckClient(config *ResolvedConfig) (*FeatureCheckClient, error)
 	// Use WorkerURL if set, otherwise use LeaderURL
 	baseURL := configCopy.GetWorkerURL()
 
-	httpClient := newHTTPClient(baseURL, configCopy.Timeout, configCopy.InsecureSkipVerify)
+	httpClient := newHTTPClient(baseURL, configCopy.Timeout, configCopy.InsecureSkipVerify, retryPolicyFromConfig(configCopy))
+	if err := configureTransport(httpClient, configCopy); err != nil {
+		return nil, err
+	}
+	httpClient.SetTransport(rateLimitTransport(traceTransport(recordTransport(httpClient.GetClient().Transport)), configCopy))
 
 	client := &FeatureCheckClient{
 		http:   httpClient,
@@ -179,7 +183,7 @@ func (c *FeatureCheckClient) checkFeatureRaw(ctx context.Context, featureID, use
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCheckFeature, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCheckFeature), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -249,7 +253,7 @@ func (c *FeatureCheckClient) checkFeaturesRaw(ctx context.Context, request Check
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCheckFeatures, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCheckFeatures), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -321,8 +325,11 @@ func (c *FeatureCheckClient) WatchEvents(ctx context.Context, request EventsWatc
 func (c *FeatureCheckClient) streamEvents(ctx context.Context, request EventsWatchRequest, lastEventID string, callback EventCallback) (time.Duration, error) {
 	req := c.http.R().SetContext(ctx).SetDoNotParseResponse(true)
 
-	// Collect headers for logging
+	// Collect headers for logging, including the custom headers the client sends
 	headers := make(map[string]string)
+	for name, value := range c.config.HTTPHeaders {
+		headers[name] = value
+	}
 
 	// Set client authentication
 	c.setClientAuth(req)
@@ -413,7 +420,7 @@ func (c *FeatureCheckClient) streamEvents(ctx context.Context, request EventsWat
 		if ctx.Err() != nil {
 			return 0, ctx.Err()
 		}
-		return 0, fmt.Errorf("%s: %w", errmsg.MsgFailedToConnectToEventStream, err)
+		return 0, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToConnectToEventStream), err)
 	}
 	defer resp.RawBody().Close()
 
@@ -421,7 +428,7 @@ func (c *FeatureCheckClient) streamEvents(ctx context.Context, request EventsWat
 	c.LogSSEResponse(resp.StatusCode(), resp.Status())
 
 	if resp.StatusCode() != http.StatusOK {
-		return 0, fmt.Errorf(errmsg.MsgEventStreamReturnedStatus, resp.StatusCode())
+		return 0, errmsg.Errorf(errmsg.MsgEventStreamReturnedStatus, resp.StatusCode())
 	}
 
 	retryDelay, err := c.parseSSE(ctx, resp.RawBody(), callback)
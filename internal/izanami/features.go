@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/go-resty/resty/v2"
 	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 )
 
@@ -27,17 +28,9 @@ func ListFeatures[T any](c *AdminClient, ctx context.Context, tenant string, tag
 func (c *AdminClient) listFeaturesRaw(ctx context.Context, tenant string, tag string) ([]byte, error) {
 	path := apiAdminTenants + buildPath(tenant, "features")
 
-	req := c.http.R().SetContext(ctx)
-	c.setAdminAuth(req)
-
-	// Add tag filter if specified (server-side filtering)
-	if tag != "" {
-		req.SetQueryParam("tag", tag)
-	}
-
-	resp, err := req.Get(path)
+	resp, err := c.listFeaturesRequest(ctx, tag).Get(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListFeatures, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListFeatures), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -47,6 +40,18 @@ func (c *AdminClient) listFeaturesRaw(ctx context.Context, tenant string, tag st
 	return resp.Body(), nil
 }
 
+// listFeaturesRequest builds the request listing the features of a tenant
+func (c *AdminClient) listFeaturesRequest(ctx context.Context, tag string) *resty.Request {
+	req := c.http.R().SetContext(ctx)
+	c.setAdminAuth(req)
+
+	// Add tag filter if specified (server-side filtering)
+	if tag != "" {
+		req.SetQueryParam("tag", tag)
+	}
+	return req
+}
+
 // GetFeature retrieves a specific feature and applies the given mapper.
 // Use Identity mapper for raw JSON output, or ParseFeature for typed struct.
 func GetFeature[T any](c *AdminClient, ctx context.Context, tenant, featureID string, mapper Mapper[T]) (T, error) {
@@ -67,7 +72,7 @@ func (c *AdminClient) getFeatureRaw(ctx context.Context, tenant, featureID strin
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetFeature, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetFeature), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -92,7 +97,7 @@ func (c *AdminClient) CreateFeature(ctx context.Context, tenant, project string,
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateFeature, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateFeature), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -119,7 +124,7 @@ func (c *AdminClient) UpdateFeature(ctx context.Context, tenant, featureID strin
 
 	resp, err := req.Put(path)
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateFeature, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateFeature), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -138,7 +143,7 @@ func (c *AdminClient) DeleteFeature(ctx context.Context, tenant, featureID strin
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteFeature, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteFeature), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusNotFound {
@@ -161,7 +166,7 @@ func (c *AdminClient) PatchFeatures(ctx context.Context, tenant string, patches
 
 	resp, err := req.Patch(path)
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToPatchFeatures, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToPatchFeatures), err)
 	}
 
 	if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusOK {
@@ -217,7 +222,7 @@ func (c *AdminClient) testFeatureRaw(ctx context.Context, tenant, featureID, con
 
 	resp, err := req.Post(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToTestFeature, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToTestFeature), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -261,7 +266,7 @@ func (c *AdminClient) testFeatureDefinitionRaw(ctx context.Context, tenant, user
 
 	resp, err := req.Post(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToTestFeatureDefinition, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToTestFeatureDefinition), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -286,6 +291,20 @@ func TestFeaturesBulk[T any](c *AdminClient, ctx context.Context, tenant string,
 func (c *AdminClient) testFeaturesBulkRaw(ctx context.Context, tenant string, request TestFeaturesAdminRequest) ([]byte, error) {
 	path := apiAdminTenants + buildPath(tenant, "features", "_test")
 
+	resp, err := c.testFeaturesBulkRequest(ctx, request).Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToTestFeaturesBulk), err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, c.handleError(resp)
+	}
+
+	return resp.Body(), nil
+}
+
+// testFeaturesBulkRequest builds the request testing several features
+func (c *AdminClient) testFeaturesBulkRequest(ctx context.Context, request TestFeaturesAdminRequest) *resty.Request {
 	req := c.http.R().SetContext(ctx)
 	c.setAdminAuth(req)
 
@@ -325,14 +344,5 @@ func (c *AdminClient) testFeaturesBulkRaw(ctx context.Context, tenant string, re
 		}
 	}
 
-	resp, err := req.Get(path)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToTestFeaturesBulk, err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, c.handleError(resp)
-	}
-
-	return resp.Body(), nil
+	return req
 }
@@ -0,0 +1,187 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// ============================================================================
+// FEATURE ARCHIVING
+// ============================================================================
+
+// ArchivedTag is the tag of archived features
+const ArchivedTag = "archived"
+
+// metadataKeyArchived is the feature metadata key holding the ArchiveInfo of an archived feature
+const metadataKeyArchived = "archived"
+
+// archivePrefix matches the description prefix added by ArchiveFeature
+var archivePrefix = regexp.MustCompile(`^\[archived [^\]]*\] ?`)
+
+// ArchiveInfo records when and why a feature was archived, and its state before
+type ArchiveInfo struct {
+	At         time.Time `json:"at"`
+	By         string    `json:"by,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	WasEnabled bool      `json:"wasEnabled"`
+}
+
+// ArchivedFeature is the outcome of archiving or unarchiving a feature
+type ArchivedFeature struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Project     string `json:"project"`
+	Archived    bool   `json:"archived"`
+	Enabled     bool   `json:"enabled"`
+	Description string `json:"description"`
+}
+
+// IsArchived reports whether a feature carries the archived tag
+func IsArchived(feature Feature) bool {
+	return slices.Contains(feature.Tags, ArchivedTag)
+}
+
+// FilterArchived keeps or removes the archived features of a list
+func FilterArchived(features []Feature, archived bool) []Feature {
+	filtered := make([]Feature, 0, len(features))
+	for _, feature := range features {
+		if IsArchived(feature) == archived {
+			filtered = append(filtered, feature)
+		}
+	}
+	return filtered
+}
+
+// FilterRawFeaturesArchived keeps or removes the archived features of a raw JSON
+// feature list, preserving the JSON of each feature
+func FilterRawFeaturesArchived(raw []byte, archived bool) ([]byte, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse features: %w", err)
+	}
+	kept := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		var feature Feature
+		if err := json.Unmarshal(item, &feature); err != nil {
+			return nil, fmt.Errorf("failed to parse feature: %w", err)
+		}
+		if IsArchived(feature) == archived {
+			kept = append(kept, item)
+		}
+	}
+	return json.Marshal(kept)
+}
+
+// ArchiveFeature soft-deletes a feature: it is disabled, tagged archived (the
+// tag is created when missing), its description is prefixed with the archive
+// date, author and reason, and its previous state is kept in its metadata for
+// UnarchiveFeature. Context overloads are left as is.
+func ArchiveFeature(c *AdminClient, ctx context.Context, tenant, featureID string, info ArchiveInfo) (*ArchivedFeature, error) {
+	feature, err := GetFeature(c, ctx, tenant, featureID, Unmarshal[map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+	tags := featureTags(feature)
+	if slices.Contains(tags, ArchivedTag) {
+		return nil, fmt.Errorf("feature %v is already archived", feature["name"])
+	}
+	if err := ensureArchivedTag(c, ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	info.WasEnabled, _ = feature["enabled"].(bool)
+	prefix := "[archived " + info.At.Format("2006-01-02")
+	if info.By != "" {
+		prefix += " by " + info.By
+	}
+	if info.Reason != "" {
+		prefix += ": " + info.Reason
+	}
+	description, _ := feature["description"].(string)
+
+	feature["enabled"] = false
+	feature["tags"] = append(tags, ArchivedTag)
+	feature["description"] = prefix + "] " + description
+	setFeatureMetadata(feature, metadataKeyArchived, info)
+
+	if err := c.UpdateFeature(ctx, tenant, featureID, feature, false); err != nil {
+		return nil, err
+	}
+	return archivedFeatureOf(featureID, feature, true), nil
+}
+
+// UnarchiveFeature restores a feature archived by ArchiveFeature: the archived
+// tag and description prefix are removed and the feature is enabled again if it
+// was enabled when archived
+func UnarchiveFeature(c *AdminClient, ctx context.Context, tenant, featureID string) (*ArchivedFeature, error) {
+	feature, err := GetFeature(c, ctx, tenant, featureID, Unmarshal[map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+	tags := featureTags(feature)
+	if !slices.Contains(tags, ArchivedTag) {
+		return nil, fmt.Errorf("feature %v is not archived", feature["name"])
+	}
+
+	var info ArchiveInfo
+	metadata, _ := feature["metadata"].(map[string]interface{})
+	if data, err := json.Marshal(metadata[metadataKeyArchived]); err == nil {
+		_ = json.Unmarshal(data, &info)
+	}
+	delete(metadata, metadataKeyArchived)
+	description, _ := feature["description"].(string)
+
+	feature["enabled"] = info.WasEnabled
+	feature["tags"] = slices.DeleteFunc(tags, func(tag string) bool { return tag == ArchivedTag })
+	feature["description"] = archivePrefix.ReplaceAllString(description, "")
+
+	if err := c.UpdateFeature(ctx, tenant, featureID, feature, false); err != nil {
+		return nil, err
+	}
+	return archivedFeatureOf(featureID, feature, false), nil
+}
+
+// ensureArchivedTag creates the archived tag of the tenant when it does not exist
+func ensureArchivedTag(c *AdminClient, ctx context.Context, tenant string) error {
+	_, err := EnsureTag(c, ctx, tenant, ArchivedTag, "Archived features (iz admin features archive)")
+	return err
+}
+
+// featureTags returns the tags of a feature decoded as a map
+func featureTags(feature map[string]interface{}) []string {
+	items, _ := feature["tags"].([]interface{})
+	tags := make([]string, 0, len(items)+1)
+	for _, item := range items {
+		if tag, ok := item.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// setFeatureMetadata sets a metadata entry of a feature decoded as a map
+func setFeatureMetadata(feature map[string]interface{}, key string, value interface{}) {
+	metadata, _ := feature["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	var entry interface{}
+	data, _ := json.Marshal(value)
+	_ = json.Unmarshal(data, &entry)
+	metadata[key] = entry
+	feature["metadata"] = metadata
+}
+
+// archivedFeatureOf describes an archived or restored feature
+func archivedFeatureOf(featureID string, feature map[string]interface{}, archived bool) *ArchivedFeature {
+	result := &ArchivedFeature{ID: featureID, Archived: archived}
+	result.Name, _ = feature["name"].(string)
+	result.Project, _ = feature["project"].(string)
+	result.Enabled, _ = feature["enabled"].(bool)
+	result.Description, _ = feature["description"].(string)
+	return result
+}
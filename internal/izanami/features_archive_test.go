@@ -0,0 +1,94 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveFeature(t *testing.T) {
+	var updated map[string]interface{}
+	tagCreated := false
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/admin/tenants/acme/features/f1":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id": "f1", "name": "checkout", "project": "shop", "enabled": true, "description": "Old checkout", "tags": ["web"], "metadata": {"owner": "team-payments"}}`)
+		case "GET /api/admin/tenants/acme/tags/archived":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "Tag not found"}`)
+		case "POST /api/admin/tenants/acme/tags":
+			tagCreated = true
+			w.WriteHeader(http.StatusCreated)
+		case "PUT /api/admin/tenants/acme/features/f1":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &updated))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	archived, err := ArchiveFeature(client, context.Background(), "acme", "f1", ArchiveInfo{At: at, By: "alice", Reason: "replaced"})
+	require.NoError(t, err)
+
+	assert.True(t, tagCreated)
+	assert.Equal(t, &ArchivedFeature{ID: "f1", Name: "checkout", Project: "shop", Archived: true, Description: "[archived 2024-03-01 by alice: replaced] Old checkout"}, archived)
+	assert.Equal(t, false, updated["enabled"])
+	assert.Equal(t, []interface{}{"web", "archived"}, updated["tags"])
+	metadata := updated["metadata"].(map[string]interface{})
+	assert.Equal(t, "team-payments", metadata["owner"])
+	assert.Equal(t, true, metadata["archived"].(map[string]interface{})["wasEnabled"])
+}
+
+func TestUnarchiveFeature(t *testing.T) {
+	var updated map[string]interface{}
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features/f1", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id": "f1", "name": "checkout", "project": "shop", "enabled": false,
+				"description": "[archived 2024-03-01 by alice: replaced] Old checkout", "tags": ["web", "archived"],
+				"metadata": {"owner": "team-payments", "archived": {"at": "2024-03-01T10:00:00Z", "by": "alice", "wasEnabled": true}}}`)
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &updated))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	restored, err := UnarchiveFeature(client, context.Background(), "acme", "f1")
+	require.NoError(t, err)
+
+	assert.Equal(t, &ArchivedFeature{ID: "f1", Name: "checkout", Project: "shop", Enabled: true, Description: "Old checkout"}, restored)
+	assert.Equal(t, []interface{}{"web"}, updated["tags"])
+	assert.Equal(t, map[string]interface{}{"owner": "team-payments"}, updated["metadata"])
+}
+
+func TestFilterRawFeaturesArchived(t *testing.T) {
+	raw := []byte(`[{"id": "1", "tags": ["archived"]}, {"id": "2", "tags": ["web"]}, {"id": "3"}]`)
+
+	active, err := FilterRawFeaturesArchived(raw, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "2", "tags": ["web"]}, {"id": "3"}]`, string(active))
+
+	archived, err := FilterRawFeaturesArchived(raw, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "1", "tags": ["archived"]}]`, string(archived))
+}
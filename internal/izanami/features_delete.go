@@ -0,0 +1,96 @@
+package izanami
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// BATCH FEATURE DELETION
+// ============================================================================
+
+// FeatureDeleteFilter selects the features deleted by a batch deletion
+type FeatureDeleteFilter struct {
+	Tag     string
+	Project string
+	// OlderThan keeps the features whose last audit event is older than this
+	// age (or that have no audit event); zero disables the age filter
+	OlderThan time.Duration
+	Now       time.Time
+}
+
+// FeatureDeletion is a feature of a batch deletion plan, and its outcome once applied
+type FeatureDeletion struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Project      string `json:"project"`
+	LastModified string `json:"lastModified,omitempty"`
+	Deleted      bool   `json:"deleted"`
+	Error        string `json:"error,omitempty"`
+}
+
+// PlanFeatureDeletion resolves the features of a tenant matching the filter,
+// sorted by project and name. Nothing is deleted.
+func PlanFeatureDeletion(c *AdminClient, ctx context.Context, tenant string, filter FeatureDeleteFilter) ([]FeatureDeletion, error) {
+	features, err := ListFeatures(c, ctx, tenant, filter.Tag, ParseFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := []FeatureDeletion{}
+	if filter.OlderThan > 0 {
+		stale, err := findStaleFeatures(c, ctx, tenant, filter.Project, features, filter.Now.Add(-filter.OlderThan), filter.Now)
+		if err != nil {
+			return nil, err
+		}
+		for _, feature := range stale {
+			plan = append(plan, FeatureDeletion{ID: feature.ID, Name: feature.Name, Project: feature.Project, LastModified: feature.LastModified})
+		}
+	} else {
+		for _, feature := range features {
+			if filter.Project == "" || feature.Project == filter.Project {
+				plan = append(plan, FeatureDeletion{ID: feature.ID, Name: feature.Name, Project: feature.Project})
+			}
+		}
+	}
+
+	sort.SliceStable(plan, func(i, j int) bool {
+		if plan[i].Project != plan[j].Project {
+			return plan[i].Project < plan[j].Project
+		}
+		return plan[i].Name < plan[j].Name
+	})
+	return plan, nil
+}
+
+// DeleteFeatures deletes the features of a plan with a pool of concurrency
+// workers and records the outcome of each deletion in the plan
+func DeleteFeatures(c *AdminClient, ctx context.Context, tenant string, plan []FeatureDeletion, concurrency int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(concurrency, len(plan)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				// Each worker writes its own entries only
+				if err := c.DeleteFeature(ctx, tenant, plan[index].ID); err != nil {
+					plan[index].Error = err.Error()
+				} else {
+					plan[index].Deleted = true
+				}
+			}
+		}()
+	}
+	for index := range plan {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,77 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanFeatureDeletion(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "deprecated", r.URL.Query().Get("tag"))
+		io.WriteString(w, `[{"id":"f1","name":"zeta","project":"legacy"},{"id":"f2","name":"alpha","project":"legacy"},
+			{"id":"f3","name":"other","project":"web"}]`)
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	plan, err := PlanFeatureDeletion(client, context.Background(), "acme", FeatureDeleteFilter{Tag: "deprecated", Project: "legacy"})
+	require.NoError(t, err)
+	assert.Equal(t, []FeatureDeletion{
+		{ID: "f2", Name: "alpha", Project: "legacy"},
+		{ID: "f1", Name: "zeta", Project: "legacy"},
+	}, plan)
+}
+
+func TestPlanFeatureDeletion_OlderThan(t *testing.T) {
+	client := staleServer(t)
+	now := time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)
+
+	plan, err := PlanFeatureDeletion(client, context.Background(), "acme", FeatureDeleteFilter{
+		Project: "shop", OlderThan: 90 * 24 * time.Hour, Now: now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []FeatureDeletion{
+		{ID: "f2", Name: "old", Project: "shop", LastModified: "2023-12-02T00:00:00Z"},
+		{ID: "f3", Name: "unknown", Project: "shop"},
+	}, plan)
+}
+
+func TestDeleteFeatures(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if id == "f2" {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `{"message":"forbidden"}`)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, id)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	plan := []FeatureDeletion{{ID: "f1"}, {ID: "f2"}, {ID: "f3"}}
+	DeleteFeatures(client, context.Background(), "acme", plan, 2)
+
+	assert.ElementsMatch(t, []string{"f1", "f3"}, deleted)
+	assert.True(t, plan[0].Deleted)
+	assert.False(t, plan[1].Deleted)
+	assert.NotEmpty(t, plan[1].Error)
+	assert.True(t, plan[2].Deleted)
+}
@@ -0,0 +1,323 @@
+package izanami
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ============================================================================
+// FEATURE DEPENDENCIES
+// ============================================================================
+
+// MetadataKeyDependsOn holds the features a feature depends on: names of the
+// same project, or project/name for features of other projects
+const MetadataKeyDependsOn = "dependsOn"
+
+// DependenciesOf returns the dependencies declared in feature metadata, as a
+// JSON array of strings or a comma-separated string
+func DependenciesOf(metadata map[string]interface{}) []string {
+	var refs []string
+	switch value := metadata[MetadataKeyDependsOn].(type) {
+	case string:
+		refs = strings.Split(value, ",")
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				refs = append(refs, s)
+			}
+		}
+	case []string:
+		refs = value
+	}
+	deps := []string{}
+	for _, ref := range refs {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			deps = append(deps, ref)
+		}
+	}
+	return deps
+}
+
+// SetDependencies records the dependencies of a feature payload in its
+// metadata; no dependencies removes the entry
+func SetDependencies(payload map[string]interface{}, deps []string) {
+	metadata, _ := payload["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	var cleaned []interface{}
+	for _, dep := range deps {
+		if dep = strings.TrimSpace(dep); dep != "" {
+			cleaned = append(cleaned, dep)
+		}
+	}
+	if len(cleaned) == 0 {
+		delete(metadata, MetadataKeyDependsOn)
+	} else {
+		metadata[MetadataKeyDependsOn] = cleaned
+	}
+	payload["metadata"] = metadata
+}
+
+// FeatureKey identifies a feature in a tenant as project/name
+func FeatureKey(project, name string) string {
+	return project + "/" + name
+}
+
+// DependencyEdge is a dependency of a feature on another one
+type DependencyEdge struct {
+	From string `json:"from"` // project/name of the dependent feature
+	To   string `json:"to"`   // project/name of the dependency
+	// Missing is set when no feature of the tenant matches the dependency
+	Missing bool `json:"missing,omitempty"`
+}
+
+// DependencyGraph is the graph of the dependencies between the features of a tenant
+type DependencyGraph struct {
+	// Features are the features of the graph by project/name
+	Features map[string]Feature `json:"-"`
+	Edges    []DependencyEdge   `json:"edges"`
+	// Cycles lists the dependency cycles, each as the project/name of its
+	// features, starting with the smallest
+	Cycles [][]string `json:"cycles"`
+}
+
+// resolveDependency returns the project/name a dependency of a feature of
+// project refers to
+func resolveDependency(project, ref string) string {
+	if strings.Contains(ref, "/") {
+		return ref
+	}
+	return FeatureKey(project, ref)
+}
+
+// BuildDependencyGraph builds the dependency graph of features. override
+// replaces the dependencies of features by project/name, for changes about to
+// be made.
+func BuildDependencyGraph(features []Feature, override map[string][]string) *DependencyGraph {
+	graph := &DependencyGraph{Features: make(map[string]Feature, len(features)), Edges: []DependencyEdge{}}
+	for _, f := range features {
+		graph.Features[FeatureKey(f.Project, f.Name)] = f
+	}
+	for _, key := range sortedKeys(graph.Features) {
+		f := graph.Features[key]
+		deps, ok := override[key]
+		if !ok {
+			deps = DependenciesOf(f.Metadata)
+		}
+		for _, dep := range deps {
+			to := resolveDependency(f.Project, dep)
+			_, found := graph.Features[to]
+			graph.Edges = append(graph.Edges, DependencyEdge{From: key, To: to, Missing: !found})
+		}
+	}
+	graph.Cycles = findDependencyCycles(graph.Edges)
+	return graph
+}
+
+// findDependencyCycles returns the elementary cycles found by a depth-first
+// search, one per back edge
+func findDependencyCycles(edges []DependencyEdge) [][]string {
+	next := make(map[string][]string)
+	for _, e := range edges {
+		if !e.Missing {
+			next[e.From] = append(next[e.From], e.To)
+		}
+	}
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	cycles := [][]string{}
+	seen := make(map[string]bool)
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = inProgress
+		stack = append(stack, node)
+		for _, to := range next[node] {
+			switch state[to] {
+			case unvisited:
+				visit(to)
+			case inProgress:
+				start := len(stack) - 1
+				for stack[start] != to {
+					start--
+				}
+				cycle := rotateToSmallest(append([]string(nil), stack[start:]...))
+				if id := strings.Join(cycle, " "); !seen[id] {
+					seen[id] = true
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[node] = done
+	}
+	for _, node := range sortedKeys(next) {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+	return cycles
+}
+
+// rotateToSmallest rotates a cycle to start with its smallest element
+func rotateToSmallest(cycle []string) []string {
+	smallest := 0
+	for i, node := range cycle {
+		if node < cycle[smallest] {
+			smallest = i
+		}
+	}
+	rotated := make([]string, 0, len(cycle))
+	return append(append(rotated, cycle[smallest:]...), cycle[:smallest]...)
+}
+
+// CycleError reports the dependency cycles a change would create
+func (g *DependencyGraph) CycleError() error {
+	if len(g.Cycles) == 0 {
+		return nil
+	}
+	cycles := make([]string, len(g.Cycles))
+	for i, cycle := range g.Cycles {
+		cycles[i] = strings.Join(cycle, " -> ") + " -> " + cycle[0]
+	}
+	return fmt.Errorf("dependency cycle: %s", strings.Join(cycles, "; "))
+}
+
+// DisabledDependencies returns the dependencies of a feature, as
+// project/name, that are disabled (or missing) and not in enabling, the
+// project/name of the features being enabled with it
+func (g *DependencyGraph) DisabledDependencies(key string, enabling map[string]bool) []string {
+	var disabled []string
+	for _, e := range g.Edges {
+		if e.From != key || enabling[e.To] {
+			continue
+		}
+		if dep, ok := g.Features[e.To]; !ok || !dep.Enabled {
+			disabled = append(disabled, e.To)
+		}
+	}
+	return disabled
+}
+
+// DependencyWarnings returns a warning for each feature of keys, being
+// enabled, that depends on disabled or missing features
+func (g *DependencyGraph) DependencyWarnings(keys []string) []string {
+	enabling := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		enabling[key] = true
+	}
+	var warnings []string
+	for _, key := range keys {
+		for _, dep := range g.DisabledDependencies(key, enabling) {
+			state := "disabled"
+			if _, ok := g.Features[dep]; !ok {
+				state = "missing"
+			}
+			warnings = append(warnings, fmt.Sprintf("%s depends on %s, which is %s", key, dep, state))
+		}
+	}
+	return warnings
+}
+
+// nodes returns the project/name of the features with dependencies or
+// dependents, in order
+func (g *DependencyGraph) nodes() []string {
+	set := make(map[string]bool)
+	for _, e := range g.Edges {
+		set[e.From], set[e.To] = true, true
+	}
+	return sortedKeys(set)
+}
+
+// inCycle returns the edges that belong to a cycle
+func (g *DependencyGraph) inCycle() map[[2]string]bool {
+	edges := make(map[[2]string]bool)
+	for _, cycle := range g.Cycles {
+		for i, node := range cycle {
+			edges[[2]string{node, cycle[(i+1)%len(cycle)]}] = true
+		}
+	}
+	return edges
+}
+
+// nodeState returns the state of a node: enabled, disabled or missing
+func (g *DependencyGraph) nodeState(key string) string {
+	f, ok := g.Features[key]
+	switch {
+	case !ok:
+		return "missing"
+	case f.Enabled:
+		return FeatureStateEnabled
+	default:
+		return FeatureStateDisabled
+	}
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Disabled features
+// are grey, missing ones dashed and edges of cycles red.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, node := range g.nodes() {
+		attrs := ""
+		switch g.nodeState(node) {
+		case FeatureStateDisabled:
+			attrs = `, style=filled, fillcolor=lightgrey`
+		case "missing":
+			attrs = `, style=dashed`
+		}
+		fmt.Fprintf(&b, "  %q [label=%q%s];\n", node, node, attrs)
+	}
+	cycleEdges := g.inCycle()
+	for _, e := range g.Edges {
+		attrs := ""
+		if cycleEdges[[2]string{e.From, e.To}] {
+			attrs = " [color=red]"
+		}
+		fmt.Fprintf(&b, "  %q -> %q%s;\n", e.From, e.To, attrs)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart
+func (g *DependencyGraph) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string)
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, node := range g.nodes() {
+		ids[node] = fmt.Sprintf("n%d", i)
+		label := strings.ReplaceAll(node, `"`, "#quot;")
+		switch g.nodeState(node) {
+		case "missing":
+			fmt.Fprintf(&b, "  %s[\"%s (missing)\"]:::missing\n", ids[node], label)
+		case FeatureStateDisabled:
+			fmt.Fprintf(&b, "  %s[\"%s\"]:::disabled\n", ids[node], label)
+		default:
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node], label)
+		}
+	}
+	cycleEdges := g.inCycle()
+	var cycleLinks []string
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", ids[e.From], ids[e.To])
+		if cycleEdges[[2]string{e.From, e.To}] {
+			cycleLinks = append(cycleLinks, fmt.Sprint(i))
+		}
+	}
+	b.WriteString("  classDef disabled fill:#ddd,color:#666\n")
+	b.WriteString("  classDef missing stroke-dasharray:5 5\n")
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red\n", strings.Join(cycleLinks, ","))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
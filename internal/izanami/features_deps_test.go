@@ -0,0 +1,97 @@
+package izanami
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependenciesOf(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, DependenciesOf(map[string]interface{}{"dependsOn": []interface{}{"a", 3, " b "}}))
+	assert.Equal(t, []string{"a", "shop/b"}, DependenciesOf(map[string]interface{}{"dependsOn": "a, shop/b,"}))
+	assert.Empty(t, DependenciesOf(nil))
+}
+
+func TestSetDependencies(t *testing.T) {
+	payload := map[string]interface{}{"name": "checkout"}
+	SetDependencies(payload, []string{"payments", " "})
+	assert.Equal(t, map[string]interface{}{"dependsOn": []interface{}{"payments"}}, payload["metadata"])
+
+	SetDependencies(payload, nil)
+	assert.Equal(t, map[string]interface{}{}, payload["metadata"])
+}
+
+func dependencyTestFeatures() []Feature {
+	deps := func(refs ...interface{}) map[string]interface{} {
+		return map[string]interface{}{MetadataKeyDependsOn: refs}
+	}
+	return []Feature{
+		{ID: "1", Name: "checkout", Project: "shop", Enabled: true, Metadata: deps("payments", "auth/sso")},
+		{ID: "2", Name: "payments", Project: "shop", Enabled: false, Metadata: deps("ghost")},
+		{ID: "3", Name: "sso", Project: "auth", Enabled: true},
+	}
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	graph := BuildDependencyGraph(dependencyTestFeatures(), nil)
+	assert.Equal(t, []DependencyEdge{
+		{From: "shop/checkout", To: "shop/payments"},
+		{From: "shop/checkout", To: "auth/sso"},
+		{From: "shop/payments", To: "shop/ghost", Missing: true},
+	}, graph.Edges)
+	assert.Empty(t, graph.Cycles)
+	assert.NoError(t, graph.CycleError())
+
+	assert.Equal(t, []string{
+		"shop/checkout depends on shop/payments, which is disabled",
+	}, graph.DependencyWarnings([]string{"shop/checkout"}))
+	// Dependencies enabled together are not reported
+	assert.Equal(t, []string{
+		"shop/payments depends on shop/ghost, which is missing",
+	}, graph.DependencyWarnings([]string{"shop/checkout", "shop/payments"}))
+}
+
+func TestBuildDependencyGraph_Cycles(t *testing.T) {
+	graph := BuildDependencyGraph(dependencyTestFeatures(), map[string][]string{
+		"auth/sso": {"shop/checkout"},
+	})
+	require.Len(t, graph.Cycles, 1)
+	assert.Equal(t, []string{"auth/sso", "shop/checkout"}, graph.Cycles[0])
+	assert.EqualError(t, graph.CycleError(), "dependency cycle: auth/sso -> shop/checkout -> auth/sso")
+
+	selfLoop := BuildDependencyGraph([]Feature{{Name: "a", Project: "p"}}, map[string][]string{"p/a": {"a"}})
+	assert.Equal(t, [][]string{{"p/a"}}, selfLoop.Cycles)
+}
+
+func TestDependencyGraph_WriteDOT(t *testing.T) {
+	graph := BuildDependencyGraph(dependencyTestFeatures(), map[string][]string{"auth/sso": {"shop/checkout"}})
+	var b strings.Builder
+	require.NoError(t, graph.WriteDOT(&b))
+	out := b.String()
+	assert.True(t, strings.HasPrefix(out, "digraph dependencies {\n"))
+	assert.Contains(t, out, `"shop/payments" [label="shop/payments", style=filled, fillcolor=lightgrey];`)
+	assert.Contains(t, out, `"shop/ghost" [label="shop/ghost", style=dashed];`)
+	assert.Contains(t, out, `"auth/sso" -> "shop/checkout" [color=red];`)
+	assert.Contains(t, out, `"shop/payments" -> "shop/ghost";`)
+}
+
+func TestDependencyGraph_WriteMermaid(t *testing.T) {
+	graph := BuildDependencyGraph(dependencyTestFeatures(), map[string][]string{"auth/sso": {"shop/checkout"}})
+	var b strings.Builder
+	require.NoError(t, graph.WriteMermaid(&b))
+	assert.Equal(t, `flowchart LR
+  n0["auth/sso"]
+  n1["shop/checkout"]
+  n2["shop/ghost (missing)"]:::missing
+  n3["shop/payments"]:::disabled
+  n0 --> n1
+  n1 --> n3
+  n1 --> n0
+  n3 --> n2
+  classDef disabled fill:#ddd,color:#666
+  classDef missing stroke-dasharray:5 5
+  linkStyle 0,2 stroke:red
+`, b.String())
+}
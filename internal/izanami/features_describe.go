@@ -0,0 +1,271 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// FEATURE DESCRIPTION
+// ============================================================================
+
+// describeMaxUsers is the number of users of a user list rule named in a description
+const describeMaxUsers = 5
+
+// weekdayOrder lists the Izanami day names from Monday to Sunday
+var weekdayOrder = []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+// FeatureDescription is a human-oriented view of a feature: its activation
+// conditions explained in English, its overloads and its audit history
+type FeatureDescription struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Project     string      `json:"project"`
+	Enabled     bool        `json:"enabled"`
+	ResultType  string      `json:"resultType"`
+	Value       interface{} `json:"value,omitempty"`
+	// Script is the name of the WASM script of script features
+	Script     string                `json:"script,omitempty"`
+	Tags       []string              `json:"tags"`
+	Conditions []string              `json:"conditions"`
+	Overloads  []OverloadDescription `json:"overloads"`
+	// Audit data, empty when the audit log has no event for the feature
+	CreatedAt      string `json:"createdAt,omitempty"`
+	CreatedBy      string `json:"createdBy,omitempty"`
+	LastModified   string `json:"lastModified,omitempty"`
+	LastModifiedBy string `json:"lastModifiedBy,omitempty"`
+	LastEvent      string `json:"lastEvent,omitempty"`
+}
+
+// OverloadDescription is the strategy of a feature in one context
+type OverloadDescription struct {
+	Context    string      `json:"context"`
+	Enabled    bool        `json:"enabled"`
+	Value      interface{} `json:"value,omitempty"`
+	Script     string      `json:"script,omitempty"`
+	Conditions []string    `json:"conditions"`
+}
+
+// describedStrategy is the activation strategy of a feature or an overload
+type describedStrategy struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Project     string               `json:"project"`
+	Enabled     bool                 `json:"enabled"`
+	ResultType  string               `json:"resultType"`
+	Value       interface{}          `json:"value"`
+	Tags        []string             `json:"tags"`
+	Conditions  []describedCondition `json:"conditions"`
+	WasmConfig  *struct {
+		Name string `json:"name"`
+	} `json:"wasmConfig"`
+}
+
+// describedCondition is an activation condition with the value returned when it
+// matches, for string and number features
+type describedCondition struct {
+	ActivationCondition
+	Value interface{} `json:"value,omitempty"`
+}
+
+// DescribeFeature fetches a feature with its overloads in the contexts of its
+// project (global contexts included) and the first and last events of its audit
+// log. Audit data is best effort: it is left empty when the log cannot be read.
+func DescribeFeature(c *AdminClient, ctx context.Context, tenant, featureID string) (*FeatureDescription, error) {
+	feature, err := GetFeature(c, ctx, tenant, featureID, UnmarshalPtr[describedStrategy]())
+	if err != nil {
+		return nil, err
+	}
+	description := &FeatureDescription{
+		ID:          feature.ID,
+		Name:        feature.Name,
+		Description: feature.Description,
+		Project:     feature.Project,
+		Enabled:     feature.Enabled,
+		ResultType:  feature.ResultType,
+		Tags:        feature.Tags,
+		Overloads:   []OverloadDescription{},
+	}
+	if description.ID == "" {
+		description.ID = featureID
+	}
+	if description.ResultType == "" {
+		description.ResultType = "boolean"
+	}
+	if description.Tags == nil {
+		description.Tags = []string{}
+	}
+	description.Value, description.Script, description.Conditions = feature.describe(description.ResultType)
+
+	contexts, err := ListContexts(c, ctx, tenant, feature.Project, false, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contexts of project %s: %w", feature.Project, err)
+	}
+	walkSnapshotContexts(contexts, "", func(path string, node *snapshotContextNode) {
+		for _, overload := range node.Overloads {
+			if id, _ := overload["id"].(string); id != description.ID {
+				if name, _ := overload["name"].(string); id != "" || name != description.Name {
+					continue
+				}
+			}
+			raw, err := json.Marshal(overload)
+			if err != nil {
+				continue
+			}
+			var strategy describedStrategy
+			if err := json.Unmarshal(raw, &strategy); err != nil {
+				continue
+			}
+			resultType := strategy.ResultType
+			if resultType == "" {
+				resultType = description.ResultType
+			}
+			value, script, conditions := strategy.describe(resultType)
+			description.Overloads = append(description.Overloads, OverloadDescription{
+				Context: path, Enabled: strategy.Enabled, Value: value, Script: script, Conditions: conditions,
+			})
+		}
+	})
+	sort.Slice(description.Overloads, func(i, j int) bool {
+		return description.Overloads[i].Context < description.Overloads[j].Context
+	})
+
+	if logs, err := ListTenantLogs(c, ctx, tenant, &LogsRequest{Order: "desc", Features: description.ID, Count: 1}, ParseLogsResponse); err == nil && len(logs.Events) > 0 {
+		description.LastModified = logs.Events[0].EmittedAt
+		description.LastModifiedBy = logs.Events[0].User
+		description.LastEvent = logs.Events[0].Type
+	}
+	if logs, err := ListTenantLogs(c, ctx, tenant, &LogsRequest{Order: "asc", Features: description.ID, Types: "FEATURE_CREATED", Count: 1}, ParseLogsResponse); err == nil && len(logs.Events) > 0 {
+		description.CreatedAt = logs.Events[0].EmittedAt
+		description.CreatedBy = logs.Events[0].User
+	}
+	return description, nil
+}
+
+// describe returns the default value, script name and explained conditions of a strategy
+func (s *describedStrategy) describe(resultType string) (interface{}, string, []string) {
+	script := ""
+	if s.WasmConfig != nil {
+		script = s.WasmConfig.Name
+	}
+	conditions := make([]string, 0, len(s.Conditions))
+	for _, condition := range s.Conditions {
+		text := DescribeCondition(condition.ActivationCondition)
+		if resultType != "boolean" && condition.Value != nil {
+			text += fmt.Sprintf(": %v", condition.Value)
+		}
+		conditions = append(conditions, text)
+	}
+	var value interface{}
+	if resultType != "boolean" {
+		value = s.Value
+	}
+	return value, script, conditions
+}
+
+// DescribeCondition explains an activation condition in English, e.g.
+// "active Mon–Fri 09:00–17:00 Europe/Paris for 25% of users"
+func DescribeCondition(condition ActivationCondition) string {
+	parts := []string{"active"}
+	if period := condition.Period; period != nil {
+		if days := describeDays(period.Days); days != "" {
+			parts = append(parts, days)
+		}
+		if len(period.HourPeriods) > 0 {
+			ranges := make([]string, len(period.HourPeriods))
+			for i, hours := range period.HourPeriods {
+				ranges[i] = shortTimeOfDay(hours.StartTime) + "–" + shortTimeOfDay(hours.EndTime)
+			}
+			parts = append(parts, strings.Join(ranges, " and "))
+		}
+		if period.Begin != nil {
+			parts = append(parts, "from "+describeDate(*period.Begin, period.Timezone))
+		}
+		if period.End != nil {
+			parts = append(parts, "until "+describeDate(*period.End, period.Timezone))
+		}
+		if period.Timezone != "" {
+			parts = append(parts, period.Timezone)
+		}
+	}
+
+	rule := condition.Rule
+	switch {
+	case rule == nil || rule.Type == "" || rule.Type == RuleTypeAll:
+		parts = append(parts, "for all users")
+	case rule.Type == RuleTypeUserPercentage:
+		parts = append(parts, fmt.Sprintf("for %g%% of users", rule.Percentage))
+	case rule.Type == RuleTypeUserList && len(rule.Users) > describeMaxUsers:
+		parts = append(parts, fmt.Sprintf("for %d users (%s, ...)", len(rule.Users), strings.Join(rule.Users[:describeMaxUsers], ", ")))
+	case rule.Type == RuleTypeUserList && len(rule.Users) == 1:
+		parts = append(parts, "for user "+rule.Users[0])
+	case rule.Type == RuleTypeUserList:
+		parts = append(parts, "for users "+strings.Join(rule.Users, ", "))
+	default:
+		parts = append(parts, "for users matching "+rule.Type)
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeDays abbreviates days of the week, collapsing runs of three days or
+// more ("Mon–Fri"); empty when the period applies every day
+func describeDays(days []string) string {
+	selected := make(map[string]bool, len(days))
+	for _, day := range days {
+		selected[strings.ToUpper(day)] = true
+	}
+	if len(selected) == 0 || len(selected) == len(weekdayOrder) {
+		return ""
+	}
+
+	var parts []string
+	for i := 0; i < len(weekdayOrder); i++ {
+		if !selected[weekdayOrder[i]] {
+			continue
+		}
+		j := i
+		for j+1 < len(weekdayOrder) && selected[weekdayOrder[j+1]] {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			parts = append(parts, shortDay(weekdayOrder[i])+"–"+shortDay(weekdayOrder[j]))
+		case j > i:
+			parts = append(parts, shortDay(weekdayOrder[i]), shortDay(weekdayOrder[j]))
+		default:
+			parts = append(parts, shortDay(weekdayOrder[i]))
+		}
+		i = j
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortDay abbreviates an Izanami day name ("MONDAY" -> "Mon")
+func shortDay(day string) string {
+	return day[:1] + strings.ToLower(day[1:3])
+}
+
+// shortTimeOfDay drops the seconds of a "15:04:05" time of day
+func shortTimeOfDay(value string) string {
+	if len(value) == len("15:04:05") && strings.HasSuffix(value, ":00") {
+		return value[:len("15:04")]
+	}
+	return value
+}
+
+// describeDate formats a period bound in the period timezone when it is known
+func describeDate(date time.Time, timezone string) string {
+	if loc, err := time.LoadLocation(timezone); err == nil && timezone != "" {
+		date = date.In(loc)
+	}
+	if date.Hour() == 0 && date.Minute() == 0 {
+		return date.Format("2006-01-02")
+	}
+	return date.Format("2006-01-02 15:04")
+}
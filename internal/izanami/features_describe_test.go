@@ -0,0 +1,103 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeCondition(t *testing.T) {
+	begin := time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		condition ActivationCondition
+		want      string
+	}{
+		{"all users", ActivationCondition{}, "active for all users"},
+		{
+			"office hours",
+			ActivationCondition{
+				Period: &FeaturePeriod{
+					Days:        []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY"},
+					HourPeriods: []HourPeriod{{StartTime: "09:00:00", EndTime: "17:00:00"}},
+					Timezone:    "Europe/Paris",
+				},
+				Rule: &ActivationRule{Type: RuleTypeUserPercentage, Percentage: 25},
+			},
+			"active Mon–Fri 09:00–17:00 Europe/Paris for 25% of users",
+		},
+		{
+			"scattered days and dates",
+			ActivationCondition{
+				Period: &FeaturePeriod{Days: []string{"SUNDAY", "MONDAY", "WEDNESDAY", "THURSDAY"}, Begin: &begin, Timezone: "Europe/Paris"},
+				Rule:   &ActivationRule{Type: RuleTypeUserList, Users: []string{"alice"}},
+			},
+			"active Mon, Wed, Thu, Sun from 2025-01-01 08:00 Europe/Paris for user alice",
+		},
+		{
+			"every day",
+			ActivationCondition{
+				Period: &FeaturePeriod{Days: weekdayOrder, HourPeriods: []HourPeriod{{StartTime: "08:30:00", EndTime: "12:00:00"}, {StartTime: "14:00:00", EndTime: "18:00:00"}}},
+			},
+			"active 08:30–12:00 and 14:00–18:00 for all users",
+		},
+		{
+			"long user list",
+			ActivationCondition{Rule: &ActivationRule{Type: RuleTypeUserList, Users: []string{"a", "b", "c", "d", "e", "f"}}},
+			"active for 6 users (a, b, c, d, e, ...)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DescribeCondition(tt.condition))
+		})
+	}
+}
+
+func TestDescribeFeature(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features/f1":
+			io.WriteString(w, `{"id":"f1","name":"banner","project":"shop","enabled":true,"resultType":"string","value":"off",
+				"tags":["ui"],"conditions":[{"value":"blue","rule":{"type":"UserPercentage","percentage":10}}]}`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[{"name":"prod","global":true,"overloads":[{"id":"f1","name":"banner","enabled":false,"resultType":"string","value":"off"}],
+				"children":[{"name":"eu","overloads":[{"id":"f2","name":"other","enabled":true},{"id":"f1","name":"banner","enabled":true,"value":"red"}]}]}]`)
+		case "/api/admin/tenants/acme/logs":
+			assert.Equal(t, "f1", query.Get("features"))
+			if query.Get("types") == "FEATURE_CREATED" {
+				io.WriteString(w, `{"events":[{"eventId":1,"type":"FEATURE_CREATED","user":"alice","emittedAt":"2024-01-02T00:00:00Z"}]}`)
+				return
+			}
+			io.WriteString(w, `{"events":[{"eventId":9,"type":"FEATURE_UPDATED","user":"bob","emittedAt":"2024-03-04T00:00:00Z"}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	description, err := DescribeFeature(client, context.Background(), "acme", "f1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "banner", description.Name)
+	assert.Equal(t, "string", description.ResultType)
+	assert.Equal(t, "off", description.Value)
+	assert.Equal(t, []string{"active for 10% of users: blue"}, description.Conditions)
+	assert.Equal(t, []OverloadDescription{
+		{Context: "prod", Enabled: false, Value: "off", Conditions: []string{}},
+		{Context: "prod/eu", Enabled: true, Value: "red", Conditions: []string{}},
+	}, description.Overloads)
+	assert.Equal(t, "alice", description.CreatedBy)
+	assert.Equal(t, "2024-03-04T00:00:00Z", description.LastModified)
+	assert.Equal(t, "bob", description.LastModifiedBy)
+	assert.Equal(t, "FEATURE_UPDATED", description.LastEvent)
+}
@@ -0,0 +1,237 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// FEATURE DIFF
+// ============================================================================
+
+// Feature diff statuses
+const (
+	FeatureDiffAdded   = "added"
+	FeatureDiffRemoved = "removed"
+	FeatureDiffChanged = "changed"
+)
+
+// snapshotIgnoredFields are not compared: IDs differ between tenants and
+// project/name identify the feature
+var snapshotIgnoredFields = map[string]bool{
+	"id":      true,
+	"name":    true,
+	"project": true,
+	"tenant":  true,
+}
+
+// FeatureSnapshot is the comparable state of a feature and its overloads.
+// Fields maps a field path (e.g. "enabled", "overloads[prod].enabled") to its JSON value.
+type FeatureSnapshot struct {
+	Project string
+	Name    string
+	Fields  map[string]string
+}
+
+// Key returns the project-qualified feature name used to match features across sources
+func (s *FeatureSnapshot) Key() string {
+	return s.Project + "/" + s.Name
+}
+
+// FieldChange is one field that differs between two feature snapshots
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// FeatureDiff describes a feature that was added, removed or changed between two sources
+type FeatureDiff struct {
+	Project string        `json:"project"`
+	Name    string        `json:"name"`
+	Status  string        `json:"status"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// SnapshotFeatures fetches the features of a tenant with their context overloads.
+// When project is set, only the features of that project are included.
+// The result is keyed by project-qualified feature name.
+func SnapshotFeatures(c *AdminClient, ctx context.Context, tenant, project string) (map[string]*FeatureSnapshot, error) {
+	// Raw maps keep every field the server returns, including ones the CLI does not model
+	features, err := ListFeatures(c, ctx, tenant, "", Unmarshal[[]map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]*FeatureSnapshot)
+	projects := make(map[string]bool)
+	for _, feature := range features {
+		name, _ := feature["name"].(string)
+		featureProject, _ := feature["project"].(string)
+		if project != "" && featureProject != project {
+			continue
+		}
+		snapshot := &FeatureSnapshot{Project: featureProject, Name: name, Fields: make(map[string]string)}
+		for key, value := range feature {
+			if !snapshotIgnoredFields[key] {
+				flattenSnapshotField(snapshot.Fields, key, value)
+			}
+		}
+		snapshots[snapshot.Key()] = snapshot
+		projects[featureProject] = true
+	}
+
+	for featureProject := range projects {
+		contexts, err := ListContexts(c, ctx, tenant, featureProject, false, ParseContexts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list contexts of project %s: %w", featureProject, err)
+		}
+		if err := addOverloadsToSnapshots(snapshots, featureProject, contexts, ""); err != nil {
+			return nil, err
+		}
+	}
+	return snapshots, nil
+}
+
+// addOverloadsToSnapshots walks a context tree and records each overload under
+// "overloads[<context path>]" in the snapshot of its feature
+func addOverloadsToSnapshots(snapshots map[string]*FeatureSnapshot, project string, contexts []Context, parentPath string) error {
+	for _, node := range contexts {
+		path := node.Name
+		if parentPath != "" {
+			path = parentPath + "/" + node.Name
+		}
+		for _, overload := range node.Overloads {
+			overloadProject := overload.Project
+			if overloadProject == "" {
+				overloadProject = project
+			}
+			snapshot, ok := snapshots[overloadProject+"/"+overload.Name]
+			if !ok {
+				continue
+			}
+			if err := flattenOverload(snapshot.Fields, "overloads["+path+"].", overload); err != nil {
+				return err
+			}
+		}
+		if err := addOverloadsToSnapshots(snapshots, project, contextsToSlice(node.Children), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenOverload records the fields of an overload under prefix
+func flattenOverload(fields map[string]string, prefix string, overload FeatureOverload) error {
+	raw, err := json.Marshal(overload)
+	if err != nil {
+		return fmt.Errorf("failed to serialize overload: %w", err)
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("failed to serialize overload: %w", err)
+	}
+	for key, value := range values {
+		if !snapshotIgnoredFields[key] {
+			flattenSnapshotField(fields, prefix+key, value)
+		}
+	}
+	return nil
+}
+
+// flattenSnapshotField records value under path, descending into objects.
+// Arrays are kept whole; tags are sorted since their order is not significant.
+func flattenSnapshotField(fields map[string]string, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return
+		}
+		for key, child := range v {
+			flattenSnapshotField(fields, path+"."+key, child)
+		}
+		return
+	case []interface{}:
+		if len(v) == 0 {
+			return
+		}
+		if path == "tags" || strings.HasSuffix(path, ".tags") {
+			sorted := make([]string, 0, len(v))
+			for _, tag := range v {
+				sorted = append(sorted, fmt.Sprint(tag))
+			}
+			sort.Strings(sorted)
+			value = sorted
+		}
+	case nil:
+		return
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		fields[path] = fmt.Sprint(value)
+		return
+	}
+	fields[path] = string(raw)
+}
+
+// DiffFeatureSnapshots compares two sets of feature snapshots and returns the
+// features added in to, removed from from, or changed, sorted by project and name
+func DiffFeatureSnapshots(from, to map[string]*FeatureSnapshot) []FeatureDiff {
+	keys := make(map[string]bool)
+	for key := range from {
+		keys[key] = true
+	}
+	for key := range to {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	var diffs []FeatureDiff
+	for _, key := range sortedKeys {
+		before, inFrom := from[key]
+		after, inTo := to[key]
+		switch {
+		case !inFrom:
+			diffs = append(diffs, FeatureDiff{Project: after.Project, Name: after.Name, Status: FeatureDiffAdded})
+		case !inTo:
+			diffs = append(diffs, FeatureDiff{Project: before.Project, Name: before.Name, Status: FeatureDiffRemoved})
+		default:
+			if changes := diffSnapshotFields(before.Fields, after.Fields); len(changes) > 0 {
+				diffs = append(diffs, FeatureDiff{Project: before.Project, Name: before.Name, Status: FeatureDiffChanged, Changes: changes})
+			}
+		}
+	}
+	return diffs
+}
+
+// diffSnapshotFields returns the fields whose values differ, sorted by field path
+func diffSnapshotFields(from, to map[string]string) []FieldChange {
+	paths := make(map[string]bool)
+	for path := range from {
+		paths[path] = true
+	}
+	for path := range to {
+		paths[path] = true
+	}
+	sortedPaths := make([]string, 0, len(paths))
+	for path := range paths {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	var changes []FieldChange
+	for _, path := range sortedPaths {
+		if from[path] != to[path] {
+			changes = append(changes, FieldChange{Field: path, From: from[path], To: to[path]})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,74 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotFeatures_IncludesOverloads(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id":"1","name":"checkout","project":"shop","enabled":true,"tags":["b","a"],"resultType":"boolean","metadata":{}},
+				{"id":"2","name":"banner","project":"web","enabled":false}
+			]`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[
+				{"name":"prod","overloads":[{"id":"1","name":"checkout","project":"shop","enabled":false}],
+				 "children":[{"name":"eu","overloads":[{"id":"1","name":"checkout","project":"shop","enabled":true}]}]}
+			]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	snapshots, err := SnapshotFeatures(client, context.Background(), "acme", "shop")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1, "features of other projects are filtered out")
+
+	checkout := snapshots["shop/checkout"]
+	require.NotNil(t, checkout)
+	assert.Equal(t, "true", checkout.Fields["enabled"])
+	assert.Equal(t, `["a","b"]`, checkout.Fields["tags"], "tags are sorted")
+	assert.Equal(t, "false", checkout.Fields["overloads[prod].enabled"])
+	assert.Equal(t, "true", checkout.Fields["overloads[prod/eu].enabled"])
+	assert.NotContains(t, checkout.Fields, "id")
+	assert.NotContains(t, checkout.Fields, "metadata", "empty objects are ignored")
+}
+
+func TestDiffFeatureSnapshots(t *testing.T) {
+	from := map[string]*FeatureSnapshot{
+		"shop/checkout": {Project: "shop", Name: "checkout", Fields: map[string]string{"enabled": "true", "overloads[prod].enabled": "false"}},
+		"shop/legacy":   {Project: "shop", Name: "legacy", Fields: map[string]string{"enabled": "false"}},
+		"web/banner":    {Project: "web", Name: "banner", Fields: map[string]string{"enabled": "true"}},
+	}
+	to := map[string]*FeatureSnapshot{
+		"shop/checkout": {Project: "shop", Name: "checkout", Fields: map[string]string{"enabled": "false"}},
+		"shop/search":   {Project: "shop", Name: "search", Fields: map[string]string{"enabled": "true"}},
+		"web/banner":    {Project: "web", Name: "banner", Fields: map[string]string{"enabled": "true"}},
+	}
+
+	diffs := DiffFeatureSnapshots(from, to)
+	require.Len(t, diffs, 3)
+
+	assert.Equal(t, FeatureDiff{Project: "shop", Name: "checkout", Status: FeatureDiffChanged, Changes: []FieldChange{
+		{Field: "enabled", From: "true", To: "false"},
+		{Field: "overloads[prod].enabled", From: "false"},
+	}}, diffs[0])
+	assert.Equal(t, FeatureDiff{Project: "shop", Name: "legacy", Status: FeatureDiffRemoved}, diffs[1])
+	assert.Equal(t, FeatureDiff{Project: "shop", Name: "search", Status: FeatureDiffAdded}, diffs[2])
+
+	assert.Empty(t, DiffFeatureSnapshots(from, from))
+}
@@ -0,0 +1,46 @@
+package izanami
+
+import (
+	"strings"
+)
+
+// ============================================================================
+// FEATURE HISTORY
+// ============================================================================
+
+// AuditEventChanges returns the activation fields changed by an audit event,
+// comparing its previous conditions with its conditions, sorted by field path.
+// Fields are prefixed by their context, the root context having no prefix
+// (e.g. "enabled", "prod/eu.conditions").
+func AuditEventChanges(event AuditEvent) []FieldChange {
+	return diffSnapshotFields(flattenAuditConditions(event.PreviousConditions), flattenAuditConditions(event.Conditions))
+}
+
+// flattenAuditConditions flattens the conditions of an audit event, keyed by
+// context, into field paths and JSON values
+func flattenAuditConditions(conditions map[string]interface{}) map[string]string {
+	fields := make(map[string]string)
+	for context, value := range conditions {
+		// Fields of the root context ("" key) have no context prefix
+		if root, ok := value.(map[string]interface{}); ok && context == "" {
+			for field, child := range root {
+				flattenSnapshotField(fields, field, child)
+			}
+			continue
+		}
+		flattenSnapshotField(fields, context, value)
+	}
+	return fields
+}
+
+// ChangedFields returns the comma-separated fields of changes, "-" for none
+func ChangedFields(changes []FieldChange) string {
+	if len(changes) == 0 {
+		return "-"
+	}
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	return strings.Join(fields, ", ")
+}
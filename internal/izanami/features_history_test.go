@@ -0,0 +1,39 @@
+package izanami
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventChanges(t *testing.T) {
+	event := AuditEvent{
+		PreviousConditions: map[string]interface{}{
+			"": map[string]interface{}{"enabled": false, "resultType": "boolean"},
+			"prod/eu": map[string]interface{}{
+				"enabled":    true,
+				"conditions": []interface{}{map[string]interface{}{"rule": map[string]interface{}{"type": "All"}}},
+			},
+		},
+		Conditions: map[string]interface{}{
+			"":        map[string]interface{}{"enabled": true, "resultType": "boolean"},
+			"prod/us": map[string]interface{}{"enabled": true},
+		},
+	}
+
+	changes := AuditEventChanges(event)
+	assert.Equal(t, []FieldChange{
+		{Field: "enabled", From: "false", To: "true"},
+		{Field: "prod/eu.conditions", From: `[{"rule":{"type":"All"}}]`},
+		{Field: "prod/eu.enabled", From: "true"},
+		{Field: "prod/us.enabled", To: "true"},
+	}, changes)
+	assert.Equal(t, "enabled, prod/eu.conditions, prod/eu.enabled, prod/us.enabled", ChangedFields(changes))
+}
+
+func TestAuditEventChanges_Created(t *testing.T) {
+	changes := AuditEventChanges(AuditEvent{Conditions: map[string]interface{}{"": map[string]interface{}{"enabled": true}}})
+	assert.Equal(t, []FieldChange{{Field: "enabled", To: "true"}}, changes)
+	assert.Empty(t, AuditEventChanges(AuditEvent{}))
+	assert.Equal(t, "-", ChangedFields(nil))
+}
@@ -0,0 +1,300 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// FEATURE LINT
+// ============================================================================
+
+// Lint rules
+const (
+	// LintDisabledStale flags disabled boolean features without conditions not modified for a while
+	LintDisabledStale = "disabled-stale"
+	// LintOverlappingHours flags hour periods of a condition that overlap
+	LintOverlappingHours = "overlapping-hours"
+	// LintPercentageBounds flags percentage rules at 0% or 100%
+	LintPercentageBounds = "percentage-bounds"
+	// LintMissingDescription flags features without description
+	LintMissingDescription = "missing-description"
+	// LintUnknownTag flags features with tags that do not exist in the tenant
+	LintUnknownTag = "unknown-tag"
+	// LintDuplicateName flags feature names used in several projects of the tenant
+	LintDuplicateName = "duplicate-name"
+)
+
+// Lint severities
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+	LintSeverityOff     = "off"
+)
+
+// LintRule describes a lint rule and its default severity
+type LintRule struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Desc     string `json:"description"`
+}
+
+// LintRules lists the lint rules with their default severities
+var LintRules = []LintRule{
+	{LintDisabledStale, LintSeverityWarning, "Disabled boolean feature without conditions, unchanged for a long time"},
+	{LintOverlappingHours, LintSeverityError, "Hour periods of a condition overlap"},
+	{LintPercentageBounds, LintSeverityWarning, "Percentage rule at 0% (matches nobody) or 100% (matches everybody)"},
+	{LintMissingDescription, LintSeverityWarning, "Feature has no description"},
+	{LintUnknownTag, LintSeverityError, "Feature has a tag that does not exist in the tenant"},
+	{LintDuplicateName, LintSeverityWarning, "Feature name is used in several projects"},
+}
+
+// LintFinding is a problem found on a feature
+type LintFinding struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Project  string `json:"project"`
+	Feature  string `json:"feature"`
+	ID       string `json:"id"`
+	Message  string `json:"message"`
+}
+
+// LintFindingTableView represents a lint finding for table display
+type LintFindingTableView struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Project  string `json:"project"`
+	Feature  string `json:"feature"`
+	Message  string `json:"message"`
+}
+
+// ToTableView converts a LintFinding to a table-friendly view
+func (f LintFinding) ToTableView() LintFindingTableView {
+	return LintFindingTableView{Severity: f.Severity, Rule: f.Rule, Project: f.Project, Feature: f.Feature, Message: f.Message}
+}
+
+// LintOptions configures LintFeatures
+type LintOptions struct {
+	// Project limits the lint to one project; duplicate names are still looked up in the whole tenant
+	Project string
+	// Severities overrides the default severity of rules
+	Severities map[string]string
+	// StaleAfter is the age from which disabled features are reported by disabled-stale
+	StaleAfter time.Duration
+	Now        time.Time
+}
+
+// lintFeature is the part of a feature definition checked by the linter
+type lintFeature struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Project     string                `json:"project"`
+	Enabled     bool                  `json:"enabled"`
+	ResultType  string                `json:"resultType"`
+	Tags        []string              `json:"tags"`
+	Conditions  []ActivationCondition `json:"conditions"`
+}
+
+// isBoolean reports whether the feature is a boolean flag
+func (f lintFeature) isBoolean() bool {
+	return f.ResultType == "" || f.ResultType == "boolean"
+}
+
+// ResolveLintSeverities merges severity overrides with the rule defaults,
+// rejecting unknown rules and severities
+func ResolveLintSeverities(overrides map[string]string) (map[string]string, error) {
+	severities := make(map[string]string, len(LintRules))
+	for _, rule := range LintRules {
+		severities[rule.Name] = rule.Severity
+	}
+	for name, severity := range overrides {
+		if _, ok := severities[name]; !ok {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+		switch severity {
+		case LintSeverityError, LintSeverityWarning, LintSeverityOff:
+			severities[name] = severity
+		default:
+			return nil, fmt.Errorf("invalid severity %q for rule %s (expected error, warning or off)", severity, name)
+		}
+	}
+	return severities, nil
+}
+
+// LintFeatures checks the features of a tenant (or of one project) for common
+// problems and returns the findings sorted by project and feature. Tags and the
+// audit log are only fetched when the rules needing them are enabled.
+func LintFeatures(c *AdminClient, ctx context.Context, tenant string, opts LintOptions) ([]LintFinding, error) {
+	severities, err := ResolveLintSeverities(opts.Severities)
+	if err != nil {
+		return nil, err
+	}
+	features, err := ListFeatures(c, ctx, tenant, "", Unmarshal[[]lintFeature]())
+	if err != nil {
+		return nil, err
+	}
+
+	var tags map[string]bool
+	if severities[LintUnknownTag] != LintSeverityOff {
+		existing, err := ListTags(c, ctx, tenant, ParseTags)
+		if err != nil {
+			return nil, err
+		}
+		tags = make(map[string]bool, len(existing))
+		for _, tag := range existing {
+			tags[tag.Name] = true
+		}
+	}
+
+	var stale map[string]StaleFeature
+	if severities[LintDisabledStale] != LintSeverityOff {
+		// Only disabled features without conditions are looked up in the audit log
+		var candidates []Feature
+		for _, feature := range features {
+			if feature.isBoolean() && !feature.Enabled && len(feature.Conditions) == 0 {
+				candidates = append(candidates, Feature{ID: feature.ID, Name: feature.Name, Project: feature.Project})
+			}
+		}
+		stale = make(map[string]StaleFeature)
+		if len(candidates) > 0 {
+			staleFeatures, err := findStaleFeatures(c, ctx, tenant, opts.Project, candidates, opts.Now.Add(-opts.StaleAfter), opts.Now)
+			if err != nil {
+				return nil, err
+			}
+			for _, feature := range staleFeatures {
+				stale[feature.ID] = feature
+			}
+		}
+	}
+
+	return lintFeatures(features, opts.Project, severities, tags, stale), nil
+}
+
+// lintFeatures applies the enabled rules to the features of the project (all
+// features when project is empty)
+func lintFeatures(features []lintFeature, project string, severities map[string]string, tags map[string]bool, stale map[string]StaleFeature) []LintFinding {
+	projectsByName := make(map[string][]string)
+	for _, feature := range features {
+		projectsByName[feature.Name] = append(projectsByName[feature.Name], feature.Project)
+	}
+
+	findings := []LintFinding{}
+	for _, feature := range features {
+		if project != "" && feature.Project != project {
+			continue
+		}
+		report := func(rule, format string, args ...interface{}) {
+			if severity := severities[rule]; severity != LintSeverityOff {
+				findings = append(findings, LintFinding{
+					Severity: severity, Rule: rule, Project: feature.Project, Feature: feature.Name, ID: feature.ID,
+					Message: fmt.Sprintf(format, args...),
+				})
+			}
+		}
+
+		if candidate, ok := stale[feature.ID]; ok {
+			if candidate.AgeDays >= 0 {
+				report(LintDisabledStale, "disabled without conditions and unchanged for %d days: remove it or enable it", candidate.AgeDays)
+			} else {
+				report(LintDisabledStale, "disabled without conditions and no change in the audit log: remove it or enable it")
+			}
+		}
+
+		for i, condition := range feature.Conditions {
+			if condition.Period != nil {
+				for _, overlap := range overlappingHourPeriods(condition.Period.HourPeriods) {
+					report(LintOverlappingHours, "condition %d: %s", i+1, overlap)
+				}
+			}
+			if rule := condition.Rule; rule != nil && rule.Type == RuleTypeUserPercentage {
+				switch {
+				case rule.Percentage <= 0:
+					report(LintPercentageBounds, "condition %d: percentage rule at 0%% matches no user", i+1)
+				case rule.Percentage >= 100:
+					report(LintPercentageBounds, "condition %d: percentage rule at 100%% matches every user, use an All rule", i+1)
+				}
+			}
+		}
+
+		if strings.TrimSpace(feature.Description) == "" {
+			report(LintMissingDescription, "no description")
+		}
+
+		if tags != nil {
+			for _, tag := range feature.Tags {
+				if !tags[tag] {
+					report(LintUnknownTag, "tag %s does not exist", tag)
+				}
+			}
+		}
+
+		if projects := projectsByName[feature.Name]; len(projects) > 1 {
+			var others []string
+			for _, other := range projects {
+				if other != feature.Project {
+					others = append(others, other)
+				}
+			}
+			if len(others) > 0 {
+				sort.Strings(others)
+				report(LintDuplicateName, "name also used in project(s) %s", strings.Join(others, ", "))
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Feature < b.Feature
+	})
+	return findings
+}
+
+// overlappingHourPeriods describes the pairs of hour periods that overlap, and
+// the hour periods that cannot be parsed
+func overlappingHourPeriods(periods []HourPeriod) []string {
+	type span struct {
+		label      string
+		start, end int
+	}
+	var problems []string
+	var spans []span
+	for _, period := range periods {
+		start, err := parseTimeOfDay(period.StartTime)
+		if err == nil {
+			var end int
+			if end, err = parseTimeOfDay(period.EndTime); err == nil {
+				spans = append(spans, span{period.StartTime + "-" + period.EndTime, start, end})
+				continue
+			}
+		}
+		problems = append(problems, fmt.Sprintf("hour period %s-%s: %v", period.StartTime, period.EndTime, err))
+	}
+	for i := range spans {
+		for j := i + 1; j < len(spans); j++ {
+			if spans[i].start < spans[j].end && spans[j].start < spans[i].end {
+				problems = append(problems, fmt.Sprintf("hour periods %s and %s overlap", spans[i].label, spans[j].label))
+			}
+		}
+	}
+	return problems
+}
+
+// CountLintFindings returns the number of findings of each severity
+func CountLintFindings(findings []LintFinding) (errors, warnings int) {
+	for _, finding := range findings {
+		switch finding.Severity {
+		case LintSeverityError:
+			errors++
+		case LintSeverityWarning:
+			warnings++
+		}
+	}
+	return errors, warnings
+}
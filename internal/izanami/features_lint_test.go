@@ -0,0 +1,126 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintFindingRules(findings []LintFinding) []string {
+	rules := []string{}
+	for _, finding := range findings {
+		rules = append(rules, finding.Feature+":"+finding.Rule)
+	}
+	return rules
+}
+
+func TestLintFeatures_Rules(t *testing.T) {
+	severities, err := ResolveLintSeverities(nil)
+	require.NoError(t, err)
+
+	features := []lintFeature{
+		{ID: "f1", Name: "old-flag", Project: "shop", Description: "Old", Enabled: false},
+		{ID: "f2", Name: "hours", Project: "shop", Description: "Office hours", Enabled: true, Conditions: []ActivationCondition{
+			{Period: &FeaturePeriod{HourPeriods: []HourPeriod{{StartTime: "08:00", EndTime: "12:00"}, {StartTime: "11:00", EndTime: "14:00"}, {StartTime: "14:00", EndTime: "18:00"}}}},
+		}},
+		{ID: "f3", Name: "rollout", Project: "shop", Description: "Rollout", Enabled: true, Conditions: []ActivationCondition{
+			{Rule: &ActivationRule{Type: RuleTypeUserPercentage, Percentage: 0}},
+			{Rule: &ActivationRule{Type: RuleTypeUserPercentage, Percentage: 100}},
+			{Rule: &ActivationRule{Type: RuleTypeUserPercentage, Percentage: 50}},
+		}},
+		{ID: "f4", Name: "tagged", Project: "shop", Enabled: true, Tags: []string{"beta", "ghost"}},
+		{ID: "f5", Name: "rollout", Project: "web", Description: "Same name", Enabled: true},
+	}
+	tags := map[string]bool{"beta": true}
+	stale := map[string]StaleFeature{"f1": {ID: "f1", AgeDays: 200}}
+
+	findings := lintFeatures(features, "", severities, tags, stale)
+	assert.Equal(t, []string{
+		"hours:overlapping-hours",
+		"old-flag:disabled-stale",
+		"rollout:percentage-bounds",
+		"rollout:percentage-bounds",
+		"rollout:duplicate-name",
+		"tagged:missing-description",
+		"tagged:unknown-tag",
+		"rollout:duplicate-name",
+	}, lintFindingRules(findings))
+
+	assert.Equal(t, "condition 1: hour periods 08:00-12:00 and 11:00-14:00 overlap", findings[0].Message)
+	assert.Equal(t, LintSeverityError, findings[0].Severity)
+	assert.Contains(t, findings[1].Message, "unchanged for 200 days")
+	assert.Contains(t, findings[3].Message, "100%")
+	assert.Equal(t, "name also used in project(s) web", findings[4].Message)
+	assert.Equal(t, "tag ghost does not exist", findings[6].Message)
+
+	// Project scope still sees duplicates in other projects
+	findings = lintFeatures(features, "web", severities, tags, stale)
+	assert.Equal(t, []string{"rollout:duplicate-name"}, lintFindingRules(findings))
+}
+
+func TestLintFeatures_Severities(t *testing.T) {
+	severities, err := ResolveLintSeverities(map[string]string{LintMissingDescription: LintSeverityOff, LintDuplicateName: LintSeverityError})
+	require.NoError(t, err)
+
+	features := []lintFeature{
+		{ID: "f1", Name: "a", Project: "shop"},
+		{ID: "f2", Name: "a", Project: "web", Description: "A"},
+	}
+	findings := lintFeatures(features, "", severities, nil, nil)
+	require.Len(t, findings, 2)
+	assert.Equal(t, LintSeverityError, findings[0].Severity)
+	assert.Equal(t, LintDuplicateName, findings[0].Rule)
+
+	errors, warnings := CountLintFindings(findings)
+	assert.Equal(t, 2, errors)
+	assert.Equal(t, 0, warnings)
+
+	_, err = ResolveLintSeverities(map[string]string{"nope": LintSeverityOff})
+	assert.EqualError(t, err, `unknown lint rule "nope"`)
+	_, err = ResolveLintSeverities(map[string]string{LintUnknownTag: "fatal"})
+	assert.Error(t, err)
+}
+
+func TestOverlappingHourPeriods_InvalidHour(t *testing.T) {
+	problems := overlappingHourPeriods([]HourPeriod{{StartTime: "25:00", EndTime: "26:00"}, {StartTime: "10:00", EndTime: "11:00"}})
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "invalid hour")
+}
+
+func TestLintFeatures_FetchesOnlyWhatRulesNeed(t *testing.T) {
+	var paths []string
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id":"f1","name":"old","project":"shop","description":"","enabled":false,"resultType":"boolean","conditions":[]},
+				{"id":"f2","name":"cfg","project":"shop","description":"Config","enabled":false,"resultType":"string","conditions":[]}
+			]`)
+		case "/api/admin/tenants/acme/logs":
+			io.WriteString(w, `{"events":[],"count":0}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	findings, err := LintFeatures(client, context.Background(), "acme", LintOptions{
+		Severities: map[string]string{LintUnknownTag: LintSeverityOff},
+		StaleAfter: 180 * 24 * time.Hour,
+		Now:        time.Now(),
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, paths, "/api/admin/tenants/acme/tags")
+	// Only the boolean feature is a disabled-stale candidate
+	assert.Equal(t, []string{"old:disabled-stale", "old:missing-description"}, lintFindingRules(findings))
+	assert.Contains(t, findings[0].Message, "no change in the audit log")
+}
@@ -0,0 +1,147 @@
+package izanami
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// FEATURE METRICS (Prometheus text exposition format)
+// ============================================================================
+
+// Feature states counted by the metrics
+const (
+	FeatureStateEnabled  = "enabled"
+	FeatureStateDisabled = "disabled"
+	FeatureStateArchived = "archived"
+)
+
+// FeatureCount is the number of features of a project or tag in a state
+type FeatureCount struct {
+	Project string `json:"project,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	State   string `json:"state"`
+	Count   int    `json:"count"`
+}
+
+// FeatureMetrics is the flag posture of a tenant and the health of its server,
+// for monitoring scripts and scrapers
+type FeatureMetrics struct {
+	Tenant    string         `json:"tenant"`
+	Total     int            `json:"total"`
+	ByProject []FeatureCount `json:"byProject"`
+	ByTag     []FeatureCount `json:"byTag"`
+	// Up is true when the server answered its health check with a healthy database
+	Up bool `json:"up"`
+	// HealthLatencyMs is the duration of the health check
+	HealthLatencyMs int64  `json:"healthLatencyMs"`
+	Version         string `json:"version,omitempty"`
+}
+
+// FeatureState returns the state of a feature: archived features are counted
+// apart from enabled and disabled ones
+func FeatureState(f Feature) string {
+	switch {
+	case IsArchived(f):
+		return FeatureStateArchived
+	case f.Enabled:
+		return FeatureStateEnabled
+	default:
+		return FeatureStateDisabled
+	}
+}
+
+// BuildFeatureMetrics counts the features of a tenant by project and state and
+// by tag and state, sorted by project or tag, then state
+func BuildFeatureMetrics(tenant string, features []Feature) *FeatureMetrics {
+	metrics := &FeatureMetrics{Tenant: tenant, Total: len(features)}
+	byProject := make(map[[2]string]int)
+	byTag := make(map[[2]string]int)
+	for _, f := range features {
+		state := FeatureState(f)
+		byProject[[2]string{f.Project, state}]++
+		for _, tag := range f.Tags {
+			byTag[[2]string{tag, state}]++
+		}
+	}
+	for _, key := range sortedCountKeys(byProject) {
+		metrics.ByProject = append(metrics.ByProject, FeatureCount{Project: key[0], State: key[1], Count: byProject[key]})
+	}
+	for _, key := range sortedCountKeys(byTag) {
+		metrics.ByTag = append(metrics.ByTag, FeatureCount{Tag: key[0], State: key[1], Count: byTag[key]})
+	}
+	if metrics.ByProject == nil {
+		metrics.ByProject = []FeatureCount{}
+	}
+	if metrics.ByTag == nil {
+		metrics.ByTag = []FeatureCount{}
+	}
+	return metrics
+}
+
+// sortedCountKeys returns the (name, state) keys of counts in order
+func sortedCountKeys(counts map[[2]string]int) [][2]string {
+	keys := make([][2]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *FeatureMetrics) WritePrometheus(w io.Writer) error {
+	tenant := promLabel("tenant", m.Tenant)
+	var b strings.Builder
+
+	writePromHeader(&b, "izanami_features_total", "Number of features of the tenant")
+	fmt.Fprintf(&b, "izanami_features_total{%s} %d\n", tenant, m.Total)
+
+	writePromHeader(&b, "izanami_features", "Number of features by project and state (enabled, disabled, archived)")
+	for _, count := range m.ByProject {
+		fmt.Fprintf(&b, "izanami_features{%s,%s,%s} %d\n", tenant, promLabel("project", count.Project), promLabel("state", count.State), count.Count)
+	}
+
+	writePromHeader(&b, "izanami_features_by_tag", "Number of features by tag and state (enabled, disabled, archived)")
+	for _, count := range m.ByTag {
+		fmt.Fprintf(&b, "izanami_features_by_tag{%s,%s,%s} %d\n", tenant, promLabel("tag", count.Tag), promLabel("state", count.State), count.Count)
+	}
+
+	writePromHeader(&b, "izanami_up", "Whether the server answered its health check with a healthy database")
+	up := 0
+	if m.Up {
+		up = 1
+	}
+	fmt.Fprintf(&b, "izanami_up %d\n", up)
+
+	writePromHeader(&b, "izanami_health_latency_seconds", "Duration of the health check")
+	fmt.Fprintf(&b, "izanami_health_latency_seconds %.3f\n", float64(m.HealthLatencyMs)/1000)
+
+	if m.Version != "" {
+		writePromHeader(&b, "izanami_build_info", "Version of the server, as a label")
+		fmt.Fprintf(&b, "izanami_build_info{%s} 1\n", promLabel("version", m.Version))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writePromHeader writes the HELP and TYPE lines of a gauge
+func writePromHeader(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// promLabelEscaper escapes label values as the exposition format requires
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promLabel formats a label pair
+func promLabel(name, value string) string {
+	return name + `="` + promLabelEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,62 @@
+package izanami
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFeatureMetrics(t *testing.T) {
+	features := []Feature{
+		{Name: "checkout", Project: "shop", Enabled: true, Tags: []string{"payments"}},
+		{Name: "search", Project: "shop", Tags: []string{"payments", "ui"}},
+		{Name: "legacy", Project: "shop", Enabled: true, Tags: []string{ArchivedTag}},
+		{Name: "banner", Project: "web", Enabled: true},
+	}
+
+	metrics := BuildFeatureMetrics("acme", features)
+	assert.Equal(t, 4, metrics.Total)
+	assert.Equal(t, []FeatureCount{
+		{Project: "shop", State: FeatureStateArchived, Count: 1},
+		{Project: "shop", State: FeatureStateDisabled, Count: 1},
+		{Project: "shop", State: FeatureStateEnabled, Count: 1},
+		{Project: "web", State: FeatureStateEnabled, Count: 1},
+	}, metrics.ByProject)
+	assert.Equal(t, []FeatureCount{
+		{Tag: ArchivedTag, State: FeatureStateArchived, Count: 1},
+		{Tag: "payments", State: FeatureStateDisabled, Count: 1},
+		{Tag: "payments", State: FeatureStateEnabled, Count: 1},
+		{Tag: "ui", State: FeatureStateDisabled, Count: 1},
+	}, metrics.ByTag)
+}
+
+func TestFeatureMetrics_WritePrometheus(t *testing.T) {
+	metrics := BuildFeatureMetrics(`ac"me`, []Feature{{Name: "checkout", Project: "shop", Enabled: true, Tags: []string{"pay"}}})
+	metrics.Up = true
+	metrics.HealthLatencyMs = 42
+	metrics.Version = "2.9.0"
+
+	var buf bytes.Buffer
+	require.NoError(t, metrics.WritePrometheus(&buf))
+	assert.Equal(t, `# HELP izanami_features_total Number of features of the tenant
+# TYPE izanami_features_total gauge
+izanami_features_total{tenant="ac\"me"} 1
+# HELP izanami_features Number of features by project and state (enabled, disabled, archived)
+# TYPE izanami_features gauge
+izanami_features{tenant="ac\"me",project="shop",state="enabled"} 1
+# HELP izanami_features_by_tag Number of features by tag and state (enabled, disabled, archived)
+# TYPE izanami_features_by_tag gauge
+izanami_features_by_tag{tenant="ac\"me",tag="pay",state="enabled"} 1
+# HELP izanami_up Whether the server answered its health check with a healthy database
+# TYPE izanami_up gauge
+izanami_up 1
+# HELP izanami_health_latency_seconds Duration of the health check
+# TYPE izanami_health_latency_seconds gauge
+izanami_health_latency_seconds 0.042
+# HELP izanami_build_info Version of the server, as a label
+# TYPE izanami_build_info gauge
+izanami_build_info{version="2.9.0"} 1
+`, buf.String())
+}
@@ -0,0 +1,127 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// FEATURE OWNERSHIP
+// ============================================================================
+
+// Feature metadata keys of the ownership conventions
+const (
+	// MetadataKeyOwner holds the team owning a feature (e.g. team-payments)
+	MetadataKeyOwner = "owner"
+	// MetadataKeyJira holds the issue tracking a feature (e.g. PAY-123)
+	MetadataKeyJira = "jira"
+)
+
+// FeatureOwnership is the owner and tracking issue of a feature
+type FeatureOwnership struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Owner   string `json:"owner,omitempty"`
+	Jira    string `json:"jira,omitempty"`
+}
+
+// FeatureOwnershipTableView represents a feature ownership for table display
+type FeatureOwnershipTableView struct {
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Owner   string `json:"owner"`
+	Jira    string `json:"jira"`
+}
+
+// ToTableView converts a FeatureOwnership to a table-friendly view
+func (o FeatureOwnership) ToTableView() FeatureOwnershipTableView {
+	view := FeatureOwnershipTableView{Name: o.Name, Project: o.Project, Owner: o.Owner, Jira: o.Jira}
+	if view.Owner == "" {
+		view.Owner = "(none)"
+	}
+	if view.Jira == "" {
+		view.Jira = "-"
+	}
+	return view
+}
+
+// metadataString returns a string metadata value, "" when absent or not a string
+func metadataString(metadata map[string]interface{}, key string) string {
+	value, _ := metadata[key].(string)
+	return strings.TrimSpace(value)
+}
+
+// OwnershipOf returns the ownership recorded in the metadata of a feature
+func OwnershipOf(feature Feature) FeatureOwnership {
+	return FeatureOwnership{
+		ID:      feature.ID,
+		Name:    feature.Name,
+		Project: feature.Project,
+		Owner:   metadataString(feature.Metadata, MetadataKeyOwner),
+		Jira:    metadataString(feature.Metadata, MetadataKeyJira),
+	}
+}
+
+// OwnedBy reports whether a feature is owned by owner (case-insensitive)
+func OwnedBy(feature Feature, owner string) bool {
+	return strings.EqualFold(metadataString(feature.Metadata, MetadataKeyOwner), strings.TrimSpace(owner))
+}
+
+// FilterRawFeaturesByOwner keeps the features of a raw JSON feature list owned
+// by owner, preserving the JSON of each feature
+func FilterRawFeaturesByOwner(raw []byte, owner string) ([]byte, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse features: %w", err)
+	}
+	kept := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		var feature Feature
+		if err := json.Unmarshal(item, &feature); err != nil {
+			return nil, fmt.Errorf("failed to parse feature: %w", err)
+		}
+		if OwnedBy(feature, owner) {
+			kept = append(kept, item)
+		}
+	}
+	return json.Marshal(kept)
+}
+
+// SetFeatureOwnership updates the owner and tracking issue in the metadata of a
+// feature. A nil value is left unchanged and an empty one is removed.
+func SetFeatureOwnership(c *AdminClient, ctx context.Context, tenant, featureID string, owner, jira *string) (*FeatureOwnership, error) {
+	feature, err := GetFeature(c, ctx, tenant, featureID, Unmarshal[map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+	metadata, _ := feature["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	for key, value := range map[string]*string{MetadataKeyOwner: owner, MetadataKeyJira: jira} {
+		switch {
+		case value == nil:
+		case strings.TrimSpace(*value) == "":
+			delete(metadata, key)
+		default:
+			metadata[key] = strings.TrimSpace(*value)
+		}
+	}
+	feature["metadata"] = metadata
+
+	if err := c.UpdateFeature(ctx, tenant, featureID, feature, false); err != nil {
+		return nil, err
+	}
+
+	ownership := FeatureOwnership{
+		ID:    featureID,
+		Owner: metadataString(metadata, MetadataKeyOwner),
+		Jira:  metadataString(metadata, MetadataKeyJira),
+	}
+	ownership.Name, _ = feature["name"].(string)
+	ownership.Project, _ = feature["project"].(string)
+	return &ownership, nil
+}
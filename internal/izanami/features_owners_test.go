@@ -0,0 +1,70 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRawFeaturesByOwner(t *testing.T) {
+	raw := []byte(`[
+		{"id": "1", "name": "a", "metadata": {"owner": "Team-Payments", "extra": 1}},
+		{"id": "2", "name": "b", "metadata": {"owner": "team-search"}},
+		{"id": "3", "name": "c"}
+	]`)
+
+	filtered, err := FilterRawFeaturesByOwner(raw, "team-payments")
+	require.NoError(t, err)
+
+	var features []map[string]interface{}
+	require.NoError(t, json.Unmarshal(filtered, &features))
+	require.Len(t, features, 1)
+	assert.Equal(t, "1", features[0]["id"])
+	assert.Equal(t, float64(1), features[0]["metadata"].(map[string]interface{})["extra"])
+
+	filtered, err = FilterRawFeaturesByOwner([]byte(`[]`), "nobody")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(filtered))
+}
+
+func TestOwnershipOf(t *testing.T) {
+	ownership := OwnershipOf(Feature{ID: "1", Name: "checkout", Project: "shop", Metadata: map[string]interface{}{"owner": " team-payments ", "jira": 12}})
+
+	assert.Equal(t, "team-payments", ownership.Owner)
+	assert.Empty(t, ownership.Jira)
+	assert.Equal(t, FeatureOwnershipTableView{Name: "checkout", Project: "shop", Owner: "team-payments", Jira: "-"}, ownership.ToTableView())
+	assert.Equal(t, "(none)", OwnershipOf(Feature{}).ToTableView().Owner)
+}
+
+func TestSetFeatureOwnership(t *testing.T) {
+	var updated map[string]interface{}
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features/f1", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id": "f1", "name": "checkout", "project": "shop", "metadata": {"jira": "OLD-1", "git": {"commit": "abc"}}}`)
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &updated))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	owner, jira := "team-payments", ""
+	ownership, err := SetFeatureOwnership(client, context.Background(), "acme", "f1", &owner, &jira)
+	require.NoError(t, err)
+
+	assert.Equal(t, &FeatureOwnership{ID: "f1", Name: "checkout", Project: "shop", Owner: "team-payments"}, ownership)
+	assert.Equal(t, "checkout", updated["name"])
+	assert.Equal(t, map[string]interface{}{"owner": "team-payments", "git": map[string]interface{}{"commit": "abc"}}, updated["metadata"])
+}
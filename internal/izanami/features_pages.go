@@ -0,0 +1,166 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ============================================================================
+// FEATURE PAGINATION
+// ============================================================================
+
+// FeatureListOptions controls how ListFeaturePage fetches and pages features.
+//
+// The Izanami features endpoint has no pagination parameters, so pages are cut on
+// the client. With Concurrency > 1 the features are fetched project by project in
+// parallel, which splits the tenant-wide response of large tenants into smaller ones.
+type FeatureListOptions struct {
+	// Tag keeps features carrying this tag
+	Tag string
+	// Project keeps features of this project
+	Project string
+	// Concurrency is the number of projects fetched in parallel, 1 for a single request
+	Concurrency int
+	// Limit is the number of features per page, 0 for all features
+	Limit int
+	// Page is the 1-based page number
+	Page int
+	// ExcludeArchived leaves out the features tagged archived
+	ExcludeArchived bool
+}
+
+// FeaturePage is one page of a feature listing
+type FeaturePage struct {
+	Features []Feature
+	// Raw holds the JSON of each feature of the page, as returned by the server
+	Raw   []json.RawMessage
+	Page  int
+	Pages int
+	Total int
+}
+
+// ListFeaturePage fetches the features of a tenant and returns the requested page,
+// in server order (project by project in project listing order with Concurrency > 1)
+func ListFeaturePage(c *AdminClient, ctx context.Context, tenant string, opts FeatureListOptions) (*FeaturePage, error) {
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+
+	var raw []json.RawMessage
+	var err error
+	if opts.Concurrency > 1 {
+		raw, err = listFeaturesByProject(c, ctx, tenant, opts)
+	} else {
+		raw, err = ListFeatures(c, ctx, tenant, opts.Tag, Unmarshal[[]json.RawMessage]())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := &FeaturePage{Page: opts.Page}
+	for _, item := range raw {
+		var feature Feature
+		if err := json.Unmarshal(item, &feature); err != nil {
+			return nil, fmt.Errorf("failed to parse feature: %w", err)
+		}
+		if opts.Project != "" && feature.Project != "" && feature.Project != opts.Project {
+			continue
+		}
+		if opts.Tag != "" && opts.Concurrency > 1 && !slices.Contains(feature.Tags, opts.Tag) {
+			continue
+		}
+		if opts.ExcludeArchived && IsArchived(feature) {
+			continue
+		}
+		page.Features = append(page.Features, feature)
+		page.Raw = append(page.Raw, item)
+	}
+
+	page.Total = len(page.Features)
+	page.Pages = 1
+	if opts.Limit > 0 {
+		page.Pages = (page.Total + opts.Limit - 1) / opts.Limit
+		if page.Pages == 0 {
+			page.Pages = 1
+		}
+		start := min((opts.Page-1)*opts.Limit, page.Total)
+		end := min(start+opts.Limit, page.Total)
+		page.Features = page.Features[start:end]
+		page.Raw = page.Raw[start:end]
+	}
+	if page.Features == nil {
+		page.Features = []Feature{}
+		page.Raw = []json.RawMessage{}
+	}
+	return page, nil
+}
+
+// listFeaturesByProject fetches the features of each project with a pool of workers
+// and merges them in project order. The first error cancels the remaining requests.
+func listFeaturesByProject(c *AdminClient, ctx context.Context, tenant string, opts FeatureListOptions) ([]json.RawMessage, error) {
+	var names []string
+	if opts.Project != "" {
+		names = []string{opts.Project}
+	} else {
+		projects, err := ListProjects(c, ctx, tenant, ParseProjects)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			names = append(names, project.Name)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type projectFeatures struct {
+		Features []json.RawMessage `json:"features"`
+	}
+	results := make([][]json.RawMessage, len(names))
+	indexes := make(chan int)
+	var firstErr error
+	var errOnce sync.Once
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(opts.Concurrency, len(names)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				project, err := GetProject(c, ctx, tenant, names[index], Unmarshal[projectFeatures]())
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("project %s: %w", names[index], err)
+						cancel()
+					})
+					continue
+				}
+				results[index] = project.Features
+			}
+		}()
+	}
+	for index := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	var merged []json.RawMessage
+	for _, features := range results {
+		merged = append(merged, features...)
+	}
+	return merged, nil
+}
@@ -0,0 +1,130 @@
+package izanami
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFeaturePagesClient(t *testing.T, handler http.HandlerFunc) *AdminClient {
+	server := mockServer(t, handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{
+		LeaderURL: server.URL,
+		Username:  "test-user",
+		JwtToken:  "test-jwt-token",
+		Timeout:   30,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func featureIDs(page *FeaturePage) []string {
+	ids := make([]string, len(page.Features))
+	for i, feature := range page.Features {
+		ids[i] = feature.ID
+	}
+	return ids
+}
+
+func TestListFeaturePage_SingleRequest(t *testing.T) {
+	client := newFeaturePagesClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/test-tenant/features", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id":"f1","name":"f1","project":"p1","enabled":true,"extra":"kept"},
+			{"id":"f2","name":"f2","project":"p2","enabled":false},
+			{"id":"f3","name":"f3","project":"p1","enabled":false},
+			{"id":"f4","name":"f4","project":"p1","enabled":true},
+			{"id":"f5","name":"f5","project":"p1","enabled":true}
+		]`))
+	})
+	ctx := context.Background()
+
+	page, err := ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{Limit: 2, Page: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"f1", "f2"}, featureIDs(page))
+	assert.Equal(t, 5, page.Total)
+	assert.Equal(t, 3, page.Pages)
+	assert.Contains(t, string(page.Raw[0]), `"extra":"kept"`)
+
+	page, err = ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{Project: "p1", Limit: 2, Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"f4", "f5"}, featureIDs(page))
+	assert.Equal(t, 4, page.Total)
+	assert.Equal(t, 2, page.Pages)
+
+	page, err = ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{Limit: 2, Page: 9})
+	require.NoError(t, err)
+	assert.Empty(t, page.Features)
+	assert.NotNil(t, page.Raw)
+
+	page, err = ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, page.Features, 5)
+	assert.Equal(t, 1, page.Pages)
+}
+
+func TestListFeaturePage_ConcurrentProjectsMergedInOrder(t *testing.T) {
+	client := newFeaturePagesClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/test-tenant/projects":
+			w.Write([]byte(`[{"name":"a"},{"name":"b"},{"name":"c"}]`))
+		case "/api/admin/tenants/test-tenant/projects/a":
+			// Slowest project comes first in the listing
+			time.Sleep(30 * time.Millisecond)
+			w.Write([]byte(`{"name":"a","features":[{"id":"a1","project":"a","tags":["beta"]},{"id":"a2","project":"a"}]}`))
+		case "/api/admin/tenants/test-tenant/projects/b":
+			w.Write([]byte(`{"name":"b","features":[{"id":"b1","project":"b","tags":["beta"]}]}`))
+		case "/api/admin/tenants/test-tenant/projects/c":
+			w.Write([]byte(`{"name":"c","features":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	page, err := ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{Concurrency: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2", "b1"}, featureIDs(page))
+
+	page, err = ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{Concurrency: 3, Tag: "beta"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1", "b1"}, featureIDs(page))
+
+	page, err = ListFeaturePage(client, ctx, "test-tenant", FeatureListOptions{Concurrency: 3, Project: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1"}, featureIDs(page))
+}
+
+func TestListFeaturePage_ConcurrentProjectError(t *testing.T) {
+	client := newFeaturePagesClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/admin/tenants/test-tenant/projects" {
+			w.Write([]byte(`[{"name":"a"},{"name":"b"}]`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/b") {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"forbidden"}`))
+			return
+		}
+		w.Write([]byte(`{"name":"a","features":[]}`))
+	})
+
+	_, err := ListFeaturePage(client, context.Background(), "test-tenant", FeatureListOptions{Concurrency: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "project b")
+}
+
+func TestListFeaturePage_NegativeLimit(t *testing.T) {
+	_, err := ListFeaturePage(&AdminClient{}, context.Background(), "test-tenant", FeatureListOptions{Limit: -1})
+	assert.EqualError(t, err, "limit must be positive")
+}
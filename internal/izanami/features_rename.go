@@ -0,0 +1,225 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// FEATURE RENAME
+// ============================================================================
+
+// Kinds of objects referencing a renamed feature
+const (
+	FeatureRefOverload = "overload"
+	FeatureRefWebhook  = "webhook"
+)
+
+// Actions taken on the references of a renamed feature
+const (
+	// FeatureRefKept references the feature by ID and follows the rename
+	FeatureRefKept = "kept"
+	// FeatureRefUpdate is rewritten to the new name
+	FeatureRefUpdate = "update"
+	// FeatureRefReview mentions the old name and is left for manual review
+	FeatureRefReview = "review"
+	// FeatureRefRestored was lost by the rename and set again under the new name
+	FeatureRefRestored = "restored"
+)
+
+// FeatureReference is an object of the tenant referencing a feature being renamed
+type FeatureReference struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// FeatureRename is a planned feature rename with the objects referencing the feature
+type FeatureRename struct {
+	Tenant     string             `json:"tenant"`
+	FeatureID  string             `json:"featureId"`
+	Project    string             `json:"project"`
+	From       string             `json:"from"`
+	To         string             `json:"to"`
+	References []FeatureReference `json:"references"`
+
+	client    *AdminClient
+	feature   map[string]interface{}
+	overloads []ProjectSnapshotOverload
+}
+
+// PlanFeatureRename checks that a feature can be renamed and finds the overloads
+// and webhooks of the tenant referencing it. Overloads and webhook feature lists
+// reference the feature by ID; webhook body templates may compare the feature
+// name with a string literal, which is rewritten.
+func PlanFeatureRename(c *AdminClient, ctx context.Context, tenant, featureID, newName string) (*FeatureRename, error) {
+	if strings.TrimSpace(newName) == "" {
+		return nil, fmt.Errorf("new feature name cannot be empty")
+	}
+
+	feature, err := GetFeature(c, ctx, tenant, featureID, Unmarshal[map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+	rename := &FeatureRename{
+		Tenant:     tenant,
+		FeatureID:  featureID,
+		To:         newName,
+		References: []FeatureReference{},
+		client:     c,
+		feature:    feature,
+	}
+	rename.From, _ = feature["name"].(string)
+	rename.Project, _ = feature["project"].(string)
+	if rename.From == newName {
+		return nil, fmt.Errorf("feature is already named %s", newName)
+	}
+
+	features, err := ListFeatures(c, ctx, tenant, "", ParseFeatures)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range features {
+		if other.Project == rename.Project && other.Name == newName {
+			return nil, fmt.Errorf("feature %s already exists in project %s", newName, rename.Project)
+		}
+	}
+
+	// Overloads, in the project contexts and the global contexts
+	contexts, err := ListContexts(c, ctx, tenant, rename.Project, false, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contexts of project %s: %w", rename.Project, err)
+	}
+	walkSnapshotContexts(contexts, "", func(path string, node *snapshotContextNode) {
+		for _, overload := range node.Overloads {
+			if !rename.isFeature(overload) {
+				continue
+			}
+			rename.overloads = append(rename.overloads, ProjectSnapshotOverload{Feature: rename.From, Context: path, Strategy: overloadStrategy(overload)})
+			rename.References = append(rename.References, FeatureReference{
+				Kind: FeatureRefOverload, Name: path, Action: FeatureRefKept, Detail: "follows the feature, checked after the rename",
+			})
+		}
+	})
+
+	webhooks, err := ListWebhooks(c, ctx, tenant, ParseWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhook := webhooks[i]
+		for _, ref := range webhook.Features {
+			if ref.ID == featureID {
+				rename.References = append(rename.References, FeatureReference{
+					Kind: FeatureRefWebhook, Name: webhook.Name, Action: FeatureRefKept, Detail: "listens to the feature by ID",
+				})
+				break
+			}
+		}
+		if !strings.Contains(webhook.BodyTemplate, rename.From) {
+			continue
+		}
+		template := replaceQuotedName(webhook.BodyTemplate, rename.From, newName)
+		if template == webhook.BodyTemplate {
+			rename.References = append(rename.References, FeatureReference{
+				Kind: FeatureRefWebhook, Name: webhook.Name, Action: FeatureRefReview, Detail: fmt.Sprintf("body template mentions %s", rename.From),
+			})
+			continue
+		}
+		body := webhook.UpdateBody()
+		body["bodyTemplate"] = template
+		webhookID := webhook.ID
+		rename.References = append(rename.References, FeatureReference{
+			Kind: FeatureRefWebhook, Name: webhook.Name, Action: FeatureRefUpdate, Detail: fmt.Sprintf("body template compares with %q", rename.From),
+			apply: func(ctx context.Context) error {
+				return c.UpdateWebhook(ctx, tenant, webhookID, body)
+			},
+		})
+	}
+	return rename, nil
+}
+
+// Count returns the number of references with the given action
+func (r *FeatureRename) Count(action string) int {
+	count := 0
+	for _, ref := range r.References {
+		if ref.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Apply renames the feature, updates the references to rewrite and checks that
+// the overloads followed the feature; overloads lost by the rename are set
+// again under the new name and reported as restored.
+func (r *FeatureRename) Apply(ctx context.Context) error {
+	r.feature["name"] = r.To
+	if err := r.client.UpdateFeature(ctx, r.Tenant, r.FeatureID, r.feature, false); err != nil {
+		return err
+	}
+
+	for _, ref := range r.References {
+		if ref.apply == nil {
+			continue
+		}
+		if err := ref.apply(ctx); err != nil {
+			return fmt.Errorf("feature renamed, but failed to update %s %s: %w", ref.Kind, ref.Name, err)
+		}
+	}
+
+	if len(r.overloads) == 0 {
+		return nil
+	}
+	contexts, err := ListContexts(r.client, ctx, r.Tenant, r.Project, false, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return fmt.Errorf("feature renamed, but failed to check its overloads: %w", err)
+	}
+	present := make(map[string]bool)
+	walkSnapshotContexts(contexts, "", func(path string, node *snapshotContextNode) {
+		for _, overload := range node.Overloads {
+			if r.isFeature(overload) {
+				present[path] = true
+			}
+		}
+	})
+	for _, overload := range r.overloads {
+		if present[overload.Context] {
+			continue
+		}
+		if err := r.client.SetOverload(ctx, r.Tenant, r.Project, overload.Context, r.To, overload.Strategy, false); err != nil {
+			return fmt.Errorf("feature renamed, but failed to restore its overload in %s: %w", overload.Context, err)
+		}
+		for i := range r.References {
+			if r.References[i].Kind == FeatureRefOverload && r.References[i].Name == overload.Context {
+				r.References[i].Action = FeatureRefRestored
+				r.References[i].Detail = "set again under the new name"
+			}
+		}
+	}
+	return nil
+}
+
+// isFeature reports whether a raw overload belongs to the renamed feature,
+// by ID when the server returns it
+func (r *FeatureRename) isFeature(overload map[string]interface{}) bool {
+	if id, _ := overload["id"].(string); id != "" {
+		return id == r.FeatureID
+	}
+	name, _ := overload["name"].(string)
+	project, _ := overload["project"].(string)
+	return (name == r.From || name == r.To) && (project == "" || project == r.Project)
+}
+
+// replaceQuotedName replaces the name in double or single quoted string
+// literals of a template; other mentions are left untouched
+func replaceQuotedName(template, from, to string) string {
+	for _, quote := range []string{`"`, "'"} {
+		template = strings.ReplaceAll(template, quote+from+quote, quote+to+quote)
+	}
+	return template
+}
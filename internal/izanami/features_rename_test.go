@@ -0,0 +1,142 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renameServer serves feature f1 "checkout" of project shop, overloaded in the
+// prod and eu contexts, and three webhooks. After the rename, the eu overload
+// is missing. Write requests are recorded with their body.
+func renameServer(t *testing.T) (*AdminClient, *[]string, map[string]interface{}) {
+	t.Helper()
+	var mu sync.Mutex
+	writes := []string{}
+	bodies := map[string]interface{}{}
+	renamed := false
+
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method != http.MethodGet {
+			request := r.Method + " " + r.URL.Path
+			writes = append(writes, request)
+			var body interface{}
+			if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+				json.Unmarshal(data, &body)
+				bodies[request] = body
+			}
+			if request == "PUT /api/admin/tenants/acme/features/f1" {
+				renamed = true
+			}
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features/f1":
+			io.WriteString(w, `{"id":"f1","name":"checkout","project":"shop","enabled":true,"resultType":"boolean","conditions":[]}`)
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id":"f1","name":"checkout","project":"shop","enabled":true},
+				{"id":"f2","name":"checkout-v2","project":"web","enabled":true}
+			]`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			eu := `{"id":"f1","name":"checkout","project":"shop","enabled":true,"resultType":"boolean"}`
+			if renamed {
+				eu = `{"id":"f9","name":"other","project":"shop","enabled":true}`
+			}
+			io.WriteString(w, `[{"name":"prod","global":false,"overloads":[
+				{"id":"f1","name":"checkout","project":"shop","enabled":false,"resultType":"boolean"}
+			],"children":[{"name":"eu","global":false,"overloads":[`+eu+`]}]}]`)
+		case "/api/admin/tenants/acme/webhooks":
+			io.WriteString(w, `[
+				{"id":"w1","name":"slack","url":"https://hooks.example.com","enabled":true,"features":[{"id":"f1","name":"checkout","project":"shop"}]},
+				{"id":"w2","name":"audit","url":"https://audit.example.com","enabled":true,"projects":[{"id":"p1","name":"shop"}],
+				 "bodyTemplate":"{{#if (eq payload.name \"checkout\")}}checkout changed{{/if}}"},
+				{"id":"w3","name":"other","url":"https://other.example.com","enabled":false,"bodyTemplate":"{{payload.name}}"}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client, &writes, bodies
+}
+
+func TestPlanFeatureRename(t *testing.T) {
+	client, writes, _ := renameServer(t)
+
+	rename, err := PlanFeatureRename(client, context.Background(), "acme", "f1", "new-checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", rename.From)
+	assert.Equal(t, "shop", rename.Project)
+
+	require.Len(t, rename.References, 4)
+	assert.Equal(t, FeatureReference{Kind: FeatureRefOverload, Name: "prod", Action: FeatureRefKept, Detail: "follows the feature, checked after the rename"}, rename.References[0])
+	assert.Equal(t, "prod/eu", rename.References[1].Name)
+	assert.Equal(t, FeatureRefKept, rename.References[2].Action)
+	assert.Equal(t, "slack", rename.References[2].Name)
+	assert.Equal(t, FeatureRefUpdate, rename.References[3].Action)
+	assert.Equal(t, "audit", rename.References[3].Name)
+	assert.Empty(t, *writes, "planning must not write")
+}
+
+func TestPlanFeatureRename_Conflicts(t *testing.T) {
+	client, _, _ := renameServer(t)
+	ctx := context.Background()
+
+	_, err := PlanFeatureRename(client, ctx, "acme", "f1", "checkout")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already named checkout")
+
+	_, err = PlanFeatureRename(client, ctx, "acme", "f1", " ")
+	require.Error(t, err)
+
+	// Names are unique per project: checkout-v2 exists in another project only
+	_, err = PlanFeatureRename(client, ctx, "acme", "f1", "checkout-v2")
+	require.NoError(t, err)
+}
+
+func TestFeatureRename_Apply(t *testing.T) {
+	client, writes, bodies := renameServer(t)
+	ctx := context.Background()
+
+	rename, err := PlanFeatureRename(client, ctx, "acme", "f1", "new-checkout")
+	require.NoError(t, err)
+	require.NoError(t, rename.Apply(ctx))
+
+	assert.Equal(t, []string{
+		"PUT /api/admin/tenants/acme/features/f1",
+		"PUT /api/admin/tenants/acme/webhooks/w2",
+		"PUT /api/admin/tenants/acme/projects/shop/contexts/prod/eu/features/new-checkout",
+	}, *writes)
+
+	feature := bodies["PUT /api/admin/tenants/acme/features/f1"].(map[string]interface{})
+	assert.Equal(t, "new-checkout", feature["name"])
+	webhook := bodies["PUT /api/admin/tenants/acme/webhooks/w2"].(map[string]interface{})
+	assert.Equal(t, `{{#if (eq payload.name "new-checkout")}}checkout changed{{/if}}`, webhook["bodyTemplate"])
+	assert.Equal(t, []interface{}{"p1"}, webhook["projects"])
+
+	assert.Equal(t, FeatureRefKept, rename.References[0].Action)
+	assert.Equal(t, FeatureRefRestored, rename.References[1].Action)
+	assert.Equal(t, 1, rename.Count(FeatureRefRestored))
+}
+
+func TestReplaceQuotedName(t *testing.T) {
+	template := `{{#if (eq payload.name 'checkout')}}checkout {{payload.name}}{{/if}} "checkout-v2"`
+	assert.Equal(t, `{{#if (eq payload.name 'cart')}}checkout {{payload.name}}{{/if}} "checkout-v2"`,
+		replaceQuotedName(template, "checkout", "cart"))
+}
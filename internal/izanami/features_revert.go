@@ -0,0 +1,180 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ============================================================================
+// FEATURE REVERT
+// ============================================================================
+
+// revertLogsPageSize is the number of audit events fetched per page when
+// looking up the event a feature is reverted to
+const revertLogsPageSize = 100
+
+// FeatureRevert is a planned restoration of a feature to its activation state
+// right after an audit event: the root strategy (enabled, conditions, result
+// type and value) and the context overloads
+type FeatureRevert struct {
+	Tenant    string `json:"tenant"`
+	FeatureID string `json:"featureId"`
+	Name      string `json:"name"`
+	Project   string `json:"project"`
+	EventID   int64  `json:"eventId"`
+	EventType string `json:"eventType"`
+	EmittedAt string `json:"emittedAt"`
+	User      string `json:"user"`
+	// Changes turn the current state into the state after the event
+	Changes []FieldChange `json:"changes"`
+
+	client  *AdminClient
+	feature map[string]interface{}
+	// current and target map a context path, "" for the root, to its strategy
+	current map[string]interface{}
+	target  map[string]interface{}
+}
+
+// FindFeatureEvent returns an audit event of a feature by ID, paging through
+// the feature's events newest first
+func FindFeatureEvent(c *AdminClient, ctx context.Context, tenant, featureID string, eventID int64) (*AuditEvent, error) {
+	req := &LogsRequest{Order: "desc", Features: featureID, Count: revertLogsPageSize}
+	for {
+		logs, err := ListTenantLogs(c, ctx, tenant, req, ParseLogsResponse)
+		if err != nil {
+			return nil, err
+		}
+		for i, event := range logs.Events {
+			if event.EventID == eventID {
+				return &logs.Events[i], nil
+			}
+		}
+		if len(logs.Events) < req.Count {
+			break
+		}
+		last := logs.Events[len(logs.Events)-1].EventID
+		if last == req.Cursor || last < eventID {
+			break
+		}
+		req.Cursor = last
+	}
+	return nil, fmt.Errorf("audit event %d not found for feature %s (see 'iz admin features history %s')", eventID, featureID, featureID)
+}
+
+// PlanFeatureRevert compares the current activation state of a feature with
+// its state right after an audit event. Fields other than the activation
+// strategy (name, description, tags, metadata) are not restored.
+func PlanFeatureRevert(c *AdminClient, ctx context.Context, tenant, featureID string, eventID int64) (*FeatureRevert, error) {
+	event, err := FindFeatureEvent(c, ctx, tenant, featureID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if len(event.Conditions) == 0 {
+		return nil, fmt.Errorf("audit event %d (%s) records no feature state to restore", eventID, event.Type)
+	}
+
+	feature, err := GetFeature(c, ctx, tenant, featureID, Unmarshal[map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+	revert := &FeatureRevert{
+		Tenant:    tenant,
+		FeatureID: featureID,
+		EventID:   event.EventID,
+		EventType: event.Type,
+		EmittedAt: event.EmittedAt,
+		User:      event.User,
+		client:    c,
+		feature:   feature,
+		current:   map[string]interface{}{"": overloadStrategy(feature)},
+		target:    make(map[string]interface{}),
+	}
+	revert.Name, _ = feature["name"].(string)
+	revert.Project, _ = feature["project"].(string)
+
+	contexts, err := ListContexts(c, ctx, tenant, revert.Project, false, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contexts of project %s: %w", revert.Project, err)
+	}
+	walkSnapshotContexts(contexts, "", func(path string, node *snapshotContextNode) {
+		for _, overload := range node.Overloads {
+			id, _ := overload["id"].(string)
+			name, _ := overload["name"].(string)
+			if id == featureID || (id == "" && name == revert.Name) {
+				revert.current[path] = overloadStrategy(overload)
+			}
+		}
+	})
+
+	for path, value := range event.Conditions {
+		strategy, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("audit event %d has an invalid state for context %q", eventID, path)
+		}
+		revert.target[path] = overloadStrategy(strategy)
+	}
+	if _, ok := revert.target[""]; !ok {
+		return nil, fmt.Errorf("audit event %d records no root state for the feature", eventID)
+	}
+
+	revert.Changes = diffSnapshotFields(flattenAuditConditions(revert.current), flattenAuditConditions(revert.target))
+	return revert, nil
+}
+
+// Apply updates the root strategy of the feature and sets or deletes its
+// overloads to match the state after the event; unchanged contexts are skipped
+func (r *FeatureRevert) Apply(ctx context.Context) error {
+	contexts := make(map[string]bool)
+	for path := range r.current {
+		contexts[path] = true
+	}
+	for path := range r.target {
+		contexts[path] = true
+	}
+	paths := make([]string, 0, len(contexts))
+	for path := range contexts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		current, target := strategyFields(r.current, path), strategyFields(r.target, path)
+		if len(diffSnapshotFields(current, target)) == 0 {
+			continue
+		}
+		switch {
+		case path == "":
+			target := r.target[""].(map[string]interface{})
+			for _, field := range overloadStrategyFields {
+				if value, ok := target[field]; ok {
+					r.feature[field] = value
+				} else {
+					delete(r.feature, field)
+				}
+			}
+			if err := r.client.UpdateFeature(ctx, r.Tenant, r.FeatureID, r.feature, false); err != nil {
+				return err
+			}
+		case r.target[path] == nil:
+			if err := r.client.DeleteOverload(ctx, r.Tenant, r.Project, path, r.Name, false); err != nil {
+				return fmt.Errorf("failed to delete the overload in %s: %w", path, err)
+			}
+		default:
+			if err := r.client.SetOverload(ctx, r.Tenant, r.Project, path, r.Name, r.target[path], false); err != nil {
+				return fmt.Errorf("failed to set the overload in %s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// strategyFields flattens the strategy of a context of a state, empty when the
+// context has no overload
+func strategyFields(state map[string]interface{}, path string) map[string]string {
+	strategy, ok := state[path]
+	if !ok {
+		return map[string]string{}
+	}
+	return flattenAuditConditions(map[string]interface{}{"": strategy})
+}
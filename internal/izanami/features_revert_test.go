@@ -0,0 +1,112 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revertServer serves feature f1 "checkout" of project shop, enabled with a
+// prod overload, and two audit events: event 7 created the feature disabled
+// with an eu overload, event 9 enabled it. Write requests are recorded with
+// their body.
+func revertServer(t *testing.T) (*AdminClient, *[]string, map[string]interface{}) {
+	t.Helper()
+	var mu sync.Mutex
+	writes := []string{}
+	bodies := map[string]interface{}{}
+
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method != http.MethodGet {
+			request := r.Method + " " + r.URL.Path
+			writes = append(writes, request)
+			var body interface{}
+			if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+				json.Unmarshal(data, &body)
+				bodies[request] = body
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features/f1":
+			io.WriteString(w, `{"id":"f1","name":"checkout","project":"shop","description":"Checkout","enabled":true,"resultType":"boolean","conditions":[]}`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[{"name":"prod","global":false,"overloads":[
+				{"id":"f1","name":"checkout","project":"shop","enabled":true,"resultType":"boolean"}
+			],"children":[{"name":"eu","global":false}]}]`)
+		case "/api/admin/tenants/acme/logs":
+			assert.Equal(t, "f1", r.URL.Query().Get("features"))
+			io.WriteString(w, `{"events":[
+				{"eventId":9,"id":"f1","type":"FEATURE_UPDATED","user":"bob","emittedAt":"2026-10-02T10:00:00Z",
+				 "conditions":{"":{"enabled":true,"resultType":"boolean","conditions":[]},"prod":{"enabled":true,"resultType":"boolean"}}},
+				{"eventId":8,"id":"f1","type":"FEATURE_DELETED","user":"bob","emittedAt":"2026-10-01T12:00:00Z"},
+				{"eventId":7,"id":"f1","type":"FEATURE_CREATED","user":"alice","emittedAt":"2026-10-01T10:00:00Z",
+				 "conditions":{"":{"enabled":false,"resultType":"boolean","conditions":[]},"prod/eu":{"enabled":true,"resultType":"boolean"}}}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client, &writes, bodies
+}
+
+func TestPlanFeatureRevert(t *testing.T) {
+	client, writes, bodies := revertServer(t)
+
+	revert, err := PlanFeatureRevert(client, context.Background(), "acme", "f1", 7)
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", revert.Name)
+	assert.Equal(t, "shop", revert.Project)
+	assert.Equal(t, "FEATURE_CREATED", revert.EventType)
+	assert.Equal(t, []FieldChange{
+		{Field: "enabled", From: "true", To: "false"},
+		{Field: "prod.enabled", From: "true"},
+		{Field: "prod.resultType", From: `"boolean"`},
+		{Field: "prod/eu.enabled", To: "true"},
+		{Field: "prod/eu.resultType", To: `"boolean"`},
+	}, revert.Changes)
+	assert.Empty(t, *writes, "planning does not write")
+
+	require.NoError(t, revert.Apply(context.Background()))
+	assert.Equal(t, []string{
+		"PUT /api/admin/tenants/acme/features/f1",
+		"DELETE /api/admin/tenants/acme/projects/shop/contexts/prod/features/checkout",
+		"PUT /api/admin/tenants/acme/projects/shop/contexts/prod/eu/features/checkout",
+	}, *writes)
+	feature := bodies["PUT /api/admin/tenants/acme/features/f1"].(map[string]interface{})
+	assert.Equal(t, false, feature["enabled"])
+	assert.Equal(t, "Checkout", feature["description"], "other fields are kept")
+}
+
+func TestPlanFeatureRevert_NothingToRevert(t *testing.T) {
+	client, _, _ := revertServer(t)
+
+	revert, err := PlanFeatureRevert(client, context.Background(), "acme", "f1", 9)
+	require.NoError(t, err)
+	assert.Empty(t, revert.Changes)
+}
+
+func TestPlanFeatureRevert_Errors(t *testing.T) {
+	client, _, _ := revertServer(t)
+
+	_, err := PlanFeatureRevert(client, context.Background(), "acme", "f1", 8)
+	assert.ErrorContains(t, err, "audit event 8 (FEATURE_DELETED) records no feature state")
+
+	_, err = PlanFeatureRevert(client, context.Background(), "acme", "f1", 5)
+	assert.ErrorContains(t, err, "audit event 5 not found for feature f1")
+}
@@ -0,0 +1,320 @@
+package izanami
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// FEATURE SCAFFOLD TEMPLATES
+// ============================================================================
+
+// FeatureTemplateSourceBuiltin is the source of the templates shipped with the CLI
+const FeatureTemplateSourceBuiltin = "built-in"
+
+// Parameters every feature template accepts
+const (
+	FeatureTemplateParamName        = "name"
+	FeatureTemplateParamDescription = "description"
+)
+
+// Types of feature template parameters
+const (
+	FeatureTemplateParamString  = "string"
+	FeatureTemplateParamNumber  = "number"
+	FeatureTemplateParamBoolean = "boolean"
+)
+
+// FeatureTemplate generates the JSON of a feature from parameters. String
+// values of the feature may contain {{param}} placeholders; a value made of a
+// single placeholder takes the type of the parameter.
+type FeatureTemplate struct {
+	Name        string                          `yaml:"-" json:"name"`
+	Source      string                          `yaml:"-" json:"source"`
+	Description string                          `yaml:"description" json:"description"`
+	Params      map[string]FeatureTemplateParam `yaml:"params,omitempty" json:"params,omitempty"`
+	Feature     map[string]interface{}          `yaml:"feature" json:"feature"`
+}
+
+// FeatureTemplateParam declares a template parameter. Parameters without a
+// default value are required.
+type FeatureTemplateParam struct {
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Type is string (the default), number or boolean
+	Type    string `yaml:"type,omitempty" json:"type,omitempty"`
+	Default string `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+// templatePlaceholder matches a {{param}} placeholder
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// builtinFeatureTemplates are the templates shipped with the CLI, by name
+var builtinFeatureTemplates = map[string]string{
+	"boolean-kill-switch": `description: Kill switch, enabled for everyone until turned off during an incident
+feature:
+  name: "{{name}}"
+  description: "{{description}}"
+  enabled: true
+  resultType: boolean
+  conditions: []
+  metadata:
+    kind: kill-switch
+`,
+	"percentage-rollout": `description: Feature enabled for a percentage of users
+params:
+  percentage:
+    description: Percentage of users (0-100)
+    type: number
+    default: "10"
+feature:
+  name: "{{name}}"
+  description: "{{description}}"
+  enabled: true
+  resultType: boolean
+  conditions:
+    - rule:
+        type: UserPercentage
+        percentage: "{{percentage}}"
+  metadata: {}
+`,
+	"date-window": `description: Launch enabled for everyone between two dates
+params:
+  from:
+    description: Start of the window (RFC 3339, e.g. 2026-11-01T08:00:00Z)
+  to:
+    description: End of the window (RFC 3339)
+  timezone:
+    description: Timezone of the window
+    default: UTC
+feature:
+  name: "{{name}}"
+  description: "{{description}}"
+  enabled: true
+  resultType: boolean
+  conditions:
+    - period:
+        begin: "{{from}}"
+        end: "{{to}}"
+        timezone: "{{timezone}}"
+      rule:
+        type: All
+  metadata: {}
+`,
+}
+
+// featureTemplatesDir returns the directory of user-defined feature templates
+func featureTemplatesDir() string {
+	return filepath.Join(getConfigDir(), "templates", "features")
+}
+
+// ParseFeatureTemplate parses a YAML or JSON feature template
+func ParseFeatureTemplate(name string, data []byte) (*FeatureTemplate, error) {
+	template := &FeatureTemplate{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(template); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid feature template %s: %w", name, err)
+	}
+	if len(template.Feature) == 0 {
+		return nil, fmt.Errorf("invalid feature template %s: no feature declared", name)
+	}
+	template.Name = name
+	for param, declared := range template.Params {
+		switch declared.Type {
+		case "", FeatureTemplateParamString, FeatureTemplateParamNumber, FeatureTemplateParamBoolean:
+		default:
+			return nil, fmt.Errorf("invalid feature template %s: parameter %s has unknown type %q", name, param, declared.Type)
+		}
+	}
+
+	// Every placeholder must be declared, name and description being implicit
+	var undeclared []string
+	walkTemplateStrings(template.Feature, func(value string) {
+		for _, match := range templatePlaceholder.FindAllStringSubmatch(value, -1) {
+			param := match[1]
+			if _, ok := template.Params[param]; !ok && param != FeatureTemplateParamName && param != FeatureTemplateParamDescription {
+				undeclared = append(undeclared, param)
+			}
+		}
+	})
+	if len(undeclared) > 0 {
+		sort.Strings(undeclared)
+		return nil, fmt.Errorf("invalid feature template %s: undeclared parameters %s", name, strings.Join(undeclared, ", "))
+	}
+	return template, nil
+}
+
+// LoadFeatureTemplate returns a feature template by name. Templates of the
+// config dir (templates/features/<name>.yaml, .yml or .json) override the
+// built-in ones.
+func LoadFeatureTemplate(name string) (*FeatureTemplate, error) {
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(featureTemplatesDir(), name+ext)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read feature template: %w", err)
+		}
+		template, err := ParseFeatureTemplate(name, data)
+		if err != nil {
+			return nil, err
+		}
+		template.Source = path
+		return template, nil
+	}
+
+	source, ok := builtinFeatureTemplates[name]
+	if !ok {
+		templates, _ := ListFeatureTemplates()
+		names := make([]string, len(templates))
+		for i, template := range templates {
+			names[i] = template.Name
+		}
+		return nil, fmt.Errorf("unknown feature template %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	template, err := ParseFeatureTemplate(name, []byte(source))
+	if err != nil {
+		return nil, err
+	}
+	template.Source = FeatureTemplateSourceBuiltin
+	return template, nil
+}
+
+// ListFeatureTemplates returns the built-in and user-defined feature templates,
+// sorted by name, user-defined templates replacing built-in ones of the same name
+func ListFeatureTemplates() ([]*FeatureTemplate, error) {
+	names := make(map[string]bool)
+	for name := range builtinFeatureTemplates {
+		names[name] = true
+	}
+	entries, err := os.ReadDir(featureTemplatesDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read feature templates: %w", err)
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml" || ext == ".json") {
+			names[strings.TrimSuffix(entry.Name(), ext)] = true
+		}
+	}
+
+	templates := make([]*FeatureTemplate, 0, len(names))
+	for _, name := range sortedKeys(names) {
+		template, err := LoadFeatureTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// Render returns the feature of the template with its placeholders replaced by
+// the values, parameter defaults filling the values not given
+func (t *FeatureTemplate) Render(values map[string]string) (map[string]interface{}, error) {
+	params := map[string]string{FeatureTemplateParamDescription: ""}
+	for name, param := range t.Params {
+		params[name] = param.Default
+	}
+	var unknown []string
+	for name, value := range values {
+		if _, ok := params[name]; !ok && name != FeatureTemplateParamName {
+			unknown = append(unknown, name)
+			continue
+		}
+		params[name] = value
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown parameters for template %s: %s", t.Name, strings.Join(unknown, ", "))
+	}
+	var missing []string
+	if strings.TrimSpace(values[FeatureTemplateParamName]) == "" {
+		missing = append(missing, FeatureTemplateParamName)
+	}
+	for _, name := range sortedKeys(t.Params) {
+		if params[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing parameters for template %s: %s", t.Name, strings.Join(missing, ", "))
+	}
+
+	typed := make(map[string]interface{}, len(params))
+	for name, value := range params {
+		typed[name] = value
+		switch t.Params[name].Type {
+		case FeatureTemplateParamNumber:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s of template %s must be a number, got %q", name, t.Name, value)
+			}
+			typed[name] = number
+		case FeatureTemplateParamBoolean:
+			boolean, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s of template %s must be true or false, got %q", name, t.Name, value)
+			}
+			typed[name] = boolean
+		}
+	}
+
+	rendered, _ := renderTemplateValue(t.Feature, params, typed).(map[string]interface{})
+	return rendered, nil
+}
+
+// renderTemplateValue returns a copy of a decoded template value with its
+// placeholders replaced, by the typed value for a string made of a single one
+func renderTemplateValue(value interface{}, params map[string]string, typed map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			rendered[key] = renderTemplateValue(item, params, typed)
+		}
+		return rendered
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, item := range v {
+			rendered[i] = renderTemplateValue(item, params, typed)
+		}
+		return rendered
+	case string:
+		if match := templatePlaceholder.FindStringSubmatch(v); match != nil && match[0] == v {
+			return typed[match[1]]
+		}
+		return templatePlaceholder.ReplaceAllStringFunc(v, func(placeholder string) string {
+			return params[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+		})
+	default:
+		return value
+	}
+}
+
+// walkTemplateStrings calls fn on every string of a decoded template value
+func walkTemplateStrings(value interface{}, fn func(string)) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			walkTemplateStrings(item, fn)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkTemplateStrings(item, fn)
+		}
+	case string:
+		fn(v)
+	}
+}
@@ -0,0 +1,119 @@
+package izanami
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupFeatureTemplatesDir points the config dir to a temp dir and returns its
+// feature templates directory
+func setupFeatureTemplatesDir(t *testing.T) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	originalGetConfigDir := getConfigDir
+	t.Cleanup(func() { getConfigDir = originalGetConfigDir })
+	getConfigDir = func() string { return tempDir }
+
+	dir := filepath.Join(tempDir, "templates", "features")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	return dir
+}
+
+func TestBuiltinFeatureTemplates(t *testing.T) {
+	setupFeatureTemplatesDir(t)
+
+	templates, err := ListFeatureTemplates()
+	require.NoError(t, err)
+	names := make([]string, len(templates))
+	for i, template := range templates {
+		names[i] = template.Name
+		assert.Equal(t, FeatureTemplateSourceBuiltin, template.Source)
+	}
+	assert.Equal(t, []string{"boolean-kill-switch", "date-window", "percentage-rollout"}, names)
+}
+
+func TestFeatureTemplate_Render(t *testing.T) {
+	setupFeatureTemplatesDir(t)
+
+	template, err := LoadFeatureTemplate("percentage-rollout")
+	require.NoError(t, err)
+
+	feature, err := template.Render(map[string]string{"name": "new-search", "percentage": "25"})
+	require.NoError(t, err)
+	assert.Equal(t, "new-search", feature["name"])
+	assert.Equal(t, "", feature["description"])
+	rule := feature["conditions"].([]interface{})[0].(map[string]interface{})["rule"].(map[string]interface{})
+	assert.Equal(t, 25.0, rule["percentage"])
+
+	// Defaults fill parameters not given
+	feature, err = template.Render(map[string]string{"name": "new-search"})
+	require.NoError(t, err)
+	rule = feature["conditions"].([]interface{})[0].(map[string]interface{})["rule"].(map[string]interface{})
+	assert.Equal(t, 10.0, rule["percentage"])
+
+	_, err = template.Render(map[string]string{"name": "x", "percentage": "many"})
+	assert.ErrorContains(t, err, "percentage of template percentage-rollout must be a number")
+	_, err = template.Render(map[string]string{"name": "x", "ratio": "1"})
+	assert.ErrorContains(t, err, "unknown parameters for template percentage-rollout: ratio")
+	_, err = template.Render(map[string]string{})
+	assert.ErrorContains(t, err, "missing parameters for template percentage-rollout: name")
+}
+
+func TestFeatureTemplate_RenderRequiredParams(t *testing.T) {
+	setupFeatureTemplatesDir(t)
+
+	template, err := LoadFeatureTemplate("date-window")
+	require.NoError(t, err)
+
+	_, err = template.Render(map[string]string{"name": "launch"})
+	assert.ErrorContains(t, err, "missing parameters for template date-window: from, to")
+
+	feature, err := template.Render(map[string]string{"name": "launch", "from": "2026-11-01T08:00:00Z", "to": "2026-11-02T08:00:00Z"})
+	require.NoError(t, err)
+	period := feature["conditions"].([]interface{})[0].(map[string]interface{})["period"].(map[string]interface{})
+	assert.Equal(t, "2026-11-01T08:00:00Z", period["begin"])
+	assert.Equal(t, "UTC", period["timezone"])
+}
+
+func TestLoadFeatureTemplate_UserDefined(t *testing.T) {
+	dir := setupFeatureTemplatesDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "boolean-kill-switch.yaml"), []byte(`description: Team kill switch
+params:
+  team: {}
+feature:
+  name: "{{name}}"
+  description: "{{team}}: {{description}}"
+  enabled: true
+  metadata: {owner: "{{team}}"}
+`), 0600))
+
+	template, err := LoadFeatureTemplate("boolean-kill-switch")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "boolean-kill-switch.yaml"), template.Source)
+
+	feature, err := template.Render(map[string]string{"name": "pay-off", "team": "payments", "description": "stop payments"})
+	require.NoError(t, err)
+	assert.Equal(t, "payments: stop payments", feature["description"])
+	assert.Equal(t, map[string]interface{}{"owner": "payments"}, feature["metadata"])
+
+	_, err = LoadFeatureTemplate("missing")
+	assert.ErrorContains(t, err, `unknown feature template "missing" (available: boolean-kill-switch, date-window, percentage-rollout)`)
+}
+
+func TestParseFeatureTemplate_Invalid(t *testing.T) {
+	_, err := ParseFeatureTemplate("t", []byte("description: x\n"))
+	assert.ErrorContains(t, err, "no feature declared")
+
+	_, err = ParseFeatureTemplate("t", []byte("feature:\n  name: \"{{name}}-{{env}}\"\n"))
+	assert.ErrorContains(t, err, "undeclared parameters env")
+
+	_, err = ParseFeatureTemplate("t", []byte("params:\n  n: {type: int}\nfeature:\n  name: x\n"))
+	assert.ErrorContains(t, err, `parameter n has unknown type "int"`)
+
+	_, err = ParseFeatureTemplate("t", []byte("feature: {name: x}\nunknown: 1\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package izanami
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ============================================================================
+// STALE FEATURE DETECTION
+// ============================================================================
+
+// staleLogsPageSize is the number of audit events fetched per request
+const staleLogsPageSize = 500
+
+// StaleFeature is a feature that was not modified since the cutoff date
+type StaleFeature struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Enabled bool   `json:"enabled"`
+	// LastModified is the date of the last audit event of the feature, empty when
+	// the audit log has no event for it
+	LastModified   string `json:"lastModified,omitempty"`
+	LastModifiedBy string `json:"lastModifiedBy,omitempty"`
+	// AgeDays is the number of days since the last modification, -1 when unknown
+	AgeDays int `json:"ageDays"`
+}
+
+// StaleFeatureTableView represents a stale feature for table display
+type StaleFeatureTableView struct {
+	Name         string `json:"name"`
+	Project      string `json:"project"`
+	Enabled      bool   `json:"enabled"`
+	LastModified string `json:"lastModified"`
+	By           string `json:"by"`
+	Days         string `json:"days"`
+}
+
+// ToTableView converts a StaleFeature to a table-friendly view
+func (f StaleFeature) ToTableView() StaleFeatureTableView {
+	view := StaleFeatureTableView{
+		Name:         f.Name,
+		Project:      f.Project,
+		Enabled:      f.Enabled,
+		LastModified: f.LastModified,
+		By:           f.LastModifiedBy,
+		Days:         "unknown",
+	}
+	if view.LastModified == "" {
+		view.LastModified = "-"
+	}
+	if f.AgeDays >= 0 {
+		view.Days = strconv.Itoa(f.AgeDays)
+	}
+	return view
+}
+
+// FindStaleFeatures returns the features of a tenant (or of one project) whose last
+// audit event is older than cutoff, oldest first.
+//
+// Events since the cutoff are scanned once to rule out recently modified features;
+// the last event of each remaining feature is then looked up individually.
+func FindStaleFeatures(c *AdminClient, ctx context.Context, tenant, project string, cutoff, now time.Time) ([]StaleFeature, error) {
+	features, err := ListFeatures(c, ctx, tenant, "", ParseFeatures)
+	if err != nil {
+		return nil, err
+	}
+	return findStaleFeatures(c, ctx, tenant, project, features, cutoff, now)
+}
+
+// findStaleFeatures returns the given features whose last audit event is older than cutoff
+func findStaleFeatures(c *AdminClient, ctx context.Context, tenant, project string, features []Feature, cutoff, now time.Time) ([]StaleFeature, error) {
+	recent, err := recentlyModifiedFeatures(c, ctx, tenant, project, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := []StaleFeature{}
+	for _, feature := range features {
+		if (project != "" && feature.Project != project) || recent[feature.ID] {
+			continue
+		}
+		candidate := StaleFeature{ID: feature.ID, Name: feature.Name, Project: feature.Project, Enabled: feature.Enabled, AgeDays: -1}
+
+		logs, err := ListTenantLogs(c, ctx, tenant, &LogsRequest{Order: "desc", Features: feature.ID, Count: 1}, ParseLogsResponse)
+		if err != nil {
+			return nil, err
+		}
+		if len(logs.Events) > 0 {
+			last := logs.Events[0]
+			emittedAt, err := time.Parse(time.RFC3339, last.EmittedAt)
+			if err == nil && emittedAt.After(cutoff) {
+				// Modified after the first scan
+				continue
+			}
+			candidate.LastModified = last.EmittedAt
+			candidate.LastModifiedBy = last.User
+			if err == nil {
+				candidate.AgeDays = int(now.Sub(emittedAt).Hours() / 24)
+			}
+		}
+		stale = append(stale, candidate)
+	}
+
+	SortStaleFeatures(stale, "age")
+	return stale, nil
+}
+
+// recentlyModifiedFeatures returns the IDs of the features with audit events since cutoff
+func recentlyModifiedFeatures(c *AdminClient, ctx context.Context, tenant, project string, cutoff time.Time) (map[string]bool, error) {
+	recent := make(map[string]bool)
+	req := &LogsRequest{Order: "desc", Projects: project, Start: cutoff.UTC().Format(time.RFC3339), Count: staleLogsPageSize}
+	for {
+		logs, err := ListTenantLogs(c, ctx, tenant, req, ParseLogsResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range logs.Events {
+			recent[event.ID] = true
+		}
+		if len(logs.Events) < req.Count {
+			return recent, nil
+		}
+		last := logs.Events[len(logs.Events)-1].EventID
+		if last == req.Cursor {
+			return recent, nil
+		}
+		req.Cursor = last
+	}
+}
+
+// SortStaleFeatures sorts stale features by age (oldest and unknown first), name or project
+func SortStaleFeatures(features []StaleFeature, by string) {
+	sort.SliceStable(features, func(i, j int) bool {
+		a, b := features[i], features[j]
+		switch by {
+		case "name":
+			return a.Name < b.Name
+		case "project":
+			if a.Project != b.Project {
+				return a.Project < b.Project
+			}
+			return a.Name < b.Name
+		}
+		if (a.AgeDays < 0) != (b.AgeDays < 0) {
+			return a.AgeDays < 0
+		}
+		if a.AgeDays != b.AgeDays {
+			return a.AgeDays > b.AgeDays
+		}
+		return a.Name < b.Name
+	})
+}
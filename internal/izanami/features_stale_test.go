@@ -0,0 +1,80 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staleServer serves four features: f1 modified recently, f2 modified long ago,
+// f3 without audit events and f4 in another project
+func staleServer(t *testing.T) *AdminClient {
+	t.Helper()
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[{"id":"f1","name":"fresh","project":"shop"},{"id":"f2","name":"old","project":"shop","enabled":true},
+				{"id":"f3","name":"unknown","project":"shop"},{"id":"f4","name":"elsewhere","project":"web"}]`)
+		case "/api/admin/tenants/acme/logs":
+			switch {
+			case query.Get("start") != "":
+				assert.Equal(t, "2024-03-01T00:00:00Z", query.Get("start"))
+				assert.Equal(t, "shop", query.Get("projects"))
+				io.WriteString(w, `{"events":[{"eventId":10,"id":"f1","type":"FEATURE_UPDATED"}]}`)
+			case query.Get("features") == "f2":
+				io.WriteString(w, `{"events":[{"eventId":3,"id":"f2","user":"alice","emittedAt":"2023-12-02T00:00:00Z"}]}`)
+			case query.Get("features") == "f3":
+				io.WriteString(w, `{"events":[]}`)
+			default:
+				t.Errorf("unexpected logs query %s", r.URL.RawQuery)
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client
+}
+
+func TestFindStaleFeatures(t *testing.T) {
+	client := staleServer(t)
+	now := time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	stale, err := FindStaleFeatures(client, context.Background(), "acme", "shop", cutoff, now)
+	require.NoError(t, err)
+
+	require.Len(t, stale, 2)
+	assert.Equal(t, "unknown", stale[0].Name, "features without history come first")
+	assert.Equal(t, -1, stale[0].AgeDays)
+	assert.Equal(t, StaleFeature{ID: "f2", Name: "old", Project: "shop", Enabled: true,
+		LastModified: "2023-12-02T00:00:00Z", LastModifiedBy: "alice", AgeDays: 180}, stale[1])
+
+	view := stale[0].ToTableView()
+	assert.Equal(t, "-", view.LastModified)
+	assert.Equal(t, "unknown", view.Days)
+}
+
+func TestSortStaleFeatures(t *testing.T) {
+	features := []StaleFeature{
+		{Name: "b", Project: "web", AgeDays: 100},
+		{Name: "a", Project: "shop", AgeDays: 200},
+		{Name: "c", Project: "shop", AgeDays: -1},
+	}
+	SortStaleFeatures(features, "age")
+	assert.Equal(t, "c a b", features[0].Name+" "+features[1].Name+" "+features[2].Name)
+	SortStaleFeatures(features, "name")
+	assert.Equal(t, "a b c", features[0].Name+" "+features[1].Name+" "+features[2].Name)
+	SortStaleFeatures(features, "project")
+	assert.Equal(t, "a c b", features[0].Name+" "+features[1].Name+" "+features[2].Name)
+}
@@ -0,0 +1,32 @@
+package izanami
+
+import (
+	"context"
+	"time"
+)
+
+// ============================================================================
+// FEATURE USAGE STATISTICS
+// ============================================================================
+
+// ListFeatureChanges returns the audit events of a feature emitted since the
+// given date, most recent first, following the log cursor across pages.
+func ListFeatureChanges(c *AdminClient, ctx context.Context, tenant, featureID string, since time.Time) ([]AuditEvent, error) {
+	changes := []AuditEvent{}
+	req := &LogsRequest{Order: "desc", Features: featureID, Start: since.UTC().Format(time.RFC3339), Count: staleLogsPageSize}
+	for {
+		logs, err := ListTenantLogs(c, ctx, tenant, req, ParseLogsResponse)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, logs.Events...)
+		if len(logs.Events) < req.Count {
+			return changes, nil
+		}
+		last := logs.Events[len(logs.Events)-1].EventID
+		if last == req.Cursor {
+			return changes, nil
+		}
+		req.Cursor = last
+	}
+}
@@ -0,0 +1,49 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFeatureChanges(t *testing.T) {
+	pages := 0
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/api/admin/tenants/acme/logs", r.URL.Path)
+		assert.Equal(t, "f1", query.Get("features"))
+		assert.Equal(t, "2024-05-23T00:00:00Z", query.Get("start"))
+		w.Header().Set("Content-Type", "application/json")
+
+		pages++
+		if query.Get("cursor") == "" {
+			// A full first page, then the rest
+			fmt.Fprint(w, `{"events":[`)
+			for i := 0; i < staleLogsPageSize; i++ {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"eventId":%d,"id":"f1","type":"FEATURE_UPDATED"}`, 1000-i)
+			}
+			fmt.Fprint(w, `]}`)
+			return
+		}
+		assert.Equal(t, fmt.Sprint(1000-staleLogsPageSize+1), query.Get("cursor"))
+		fmt.Fprint(w, `{"events":[{"eventId":1,"id":"f1","type":"FEATURE_CREATED"}]}`)
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	changes, err := ListFeatureChanges(client, context.Background(), "acme", "f1", time.Date(2024, 5, 23, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Len(t, changes, staleLogsPageSize+1)
+	assert.Equal(t, "FEATURE_CREATED", changes[len(changes)-1].Type)
+	assert.Equal(t, 2, pages)
+}
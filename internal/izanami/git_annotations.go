@@ -0,0 +1,220 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// GIT ANNOTATIONS
+// ============================================================================
+
+// GitMetadataKey is the feature metadata key holding git annotations
+const GitMetadataKey = "git"
+
+// maxGitAnnotations is the number of annotations kept in a feature's metadata
+const maxGitAnnotations = 20
+
+// gitAnnotationMatchWindow is how far apart an audit event and an annotation
+// may be to be considered the same change
+const gitAnnotationMatchWindow = 2 * time.Minute
+
+// GitAnnotation records the git state of the working directory a feature was changed from
+type GitAnnotation struct {
+	Branch string `json:"branch,omitempty"`
+	Commit string `json:"commit"`
+	// Author is the author of the commit, as "Name <email>"
+	Author string `json:"author,omitempty"`
+	// Dirty is set when the working tree had uncommitted changes
+	Dirty bool `json:"dirty,omitempty"`
+	// User is the Izanami user who made the change
+	User string    `json:"user,omitempty"`
+	At   time.Time `json:"at"`
+}
+
+// ReadGitAnnotation returns the git state of dir, or nil when dir is not in a
+// git repository (or git is not installed)
+func ReadGitAnnotation(ctx context.Context, dir string) *GitAnnotation {
+	git := func(args ...string) (string, bool) {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err == nil
+	}
+
+	commit, ok := git("rev-parse", "HEAD")
+	if !ok || commit == "" {
+		return nil
+	}
+	annotation := &GitAnnotation{Commit: commit, At: time.Now().UTC()}
+	if branch, ok := git("rev-parse", "--abbrev-ref", "HEAD"); ok && branch != "HEAD" {
+		annotation.Branch = branch
+	}
+	if author, ok := git("log", "-1", "--format=%an <%ae>"); ok {
+		annotation.Author = author
+	}
+	if status, ok := git("status", "--porcelain", "--untracked-files=no"); ok && status != "" {
+		annotation.Dirty = true
+	}
+	return annotation
+}
+
+// gitMetadata is the "git" entry of a feature's metadata: the last annotation
+// at the top level, for display in the UI, and the recent ones in History
+type gitMetadata struct {
+	GitAnnotation
+	History []GitAnnotation `json:"history,omitempty"`
+}
+
+// AnnotateFeatureMetadata adds annotation to the metadata of a feature payload,
+// keeping the annotations of previous, the metadata of the feature before the
+// change (nil for a new feature)
+func AnnotateFeatureMetadata(payload map[string]interface{}, previous map[string]interface{}, annotation *GitAnnotation) {
+	history := append(GitAnnotationsOf(previous), *annotation)
+	if len(history) > maxGitAnnotations {
+		history = history[len(history)-maxGitAnnotations:]
+	}
+
+	metadata, _ := payload["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	var entry map[string]interface{}
+	data, _ := json.Marshal(gitMetadata{GitAnnotation: *annotation, History: history})
+	_ = json.Unmarshal(data, &entry)
+	metadata[GitMetadataKey] = entry
+	payload["metadata"] = metadata
+}
+
+// GitAnnotationsOf returns the annotations recorded in feature metadata, oldest first
+func GitAnnotationsOf(metadata map[string]interface{}) []GitAnnotation {
+	entry, ok := metadata[GitMetadataKey]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil
+	}
+	var git gitMetadata
+	if err := json.Unmarshal(data, &git); err != nil {
+		return nil
+	}
+	if len(git.History) == 0 && git.Commit != "" {
+		return []GitAnnotation{git.GitAnnotation}
+	}
+	return git.History
+}
+
+// FeatureHistoryEntry is an audit event of a feature with the git annotation of the change
+type FeatureHistoryEntry struct {
+	AuditEvent
+	Git *GitAnnotation `json:"git,omitempty"`
+	// Changes are the activation fields changed by the event
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// FeatureHistoryTableView represents a feature history entry for table display
+type FeatureHistoryTableView struct {
+	EventID   int64  `json:"eventId"`
+	EmittedAt string `json:"emittedAt"`
+	Type      string `json:"type"`
+	User      string `json:"user"`
+	Changed   string `json:"changed"`
+	Branch    string `json:"branch"`
+	Commit    string `json:"commit"`
+	Author    string `json:"author"`
+}
+
+// ToTableView converts a FeatureHistoryEntry to a table-friendly view
+func (e FeatureHistoryEntry) ToTableView() FeatureHistoryTableView {
+	view := FeatureHistoryTableView{EventID: e.EventID, EmittedAt: e.EmittedAt, Type: e.Type, User: e.User, Changed: ChangedFields(e.Changes), Branch: "-", Commit: "-", Author: "-"}
+	if e.Git != nil {
+		view.Commit = e.Git.Commit
+		if len(view.Commit) > 12 {
+			view.Commit = view.Commit[:12]
+		}
+		if e.Git.Dirty {
+			view.Commit += " (dirty)"
+		}
+		if e.Git.Branch != "" {
+			view.Branch = e.Git.Branch
+		}
+		if e.Git.Author != "" {
+			view.Author = e.Git.Author
+		}
+	}
+	return view
+}
+
+// MatchGitAnnotations pairs audit events with the annotations of the changes
+// that emitted them: the closest annotation in time, within a few minutes and
+// by the same user when both are known. Each annotation matches one event.
+func MatchGitAnnotations(events []AuditEvent, annotations []GitAnnotation) []FeatureHistoryEntry {
+	entries := make([]FeatureHistoryEntry, len(events))
+	used := make([]bool, len(annotations))
+	for i, event := range events {
+		entries[i].AuditEvent = event
+		emittedAt, err := time.Parse(time.RFC3339, event.EmittedAt)
+		if err != nil {
+			continue
+		}
+		best := -1
+		var bestGap time.Duration
+		for j, annotation := range annotations {
+			if used[j] || (annotation.User != "" && event.User != "" && annotation.User != event.User) {
+				continue
+			}
+			gap := emittedAt.Sub(annotation.At)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap <= gitAnnotationMatchWindow && (best < 0 || gap < bestGap) {
+				best, bestGap = j, gap
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+			entries[i].Git = &annotations[best]
+		}
+	}
+	return entries
+}
+
+// GetFeatureMetadata returns the metadata of a feature
+func GetFeatureMetadata(c *AdminClient, ctx context.Context, tenant, featureID string) (map[string]interface{}, error) {
+	raw, err := GetFeature(c, ctx, tenant, featureID, Identity)
+	if err != nil {
+		return nil, err
+	}
+	var feature struct {
+		Metadata map[string]interface{} `json:"metadata"`
+	}
+	if err := json.Unmarshal(raw, &feature); err != nil {
+		return nil, fmt.Errorf("failed to parse feature: %w", err)
+	}
+	return feature.Metadata, nil
+}
+
+// FeatureHistory returns the last limit audit events of a feature, newest
+// first, with the fields they changed and the git annotations of the changes
+// recorded in its metadata
+func FeatureHistory(c *AdminClient, ctx context.Context, tenant, featureID string, limit int) ([]FeatureHistoryEntry, error) {
+	metadata, err := GetFeatureMetadata(c, ctx, tenant, featureID)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := ListTenantLogs(c, ctx, tenant, &LogsRequest{Order: "desc", Features: featureID, Count: limit}, ParseLogsResponse)
+	if err != nil {
+		return nil, err
+	}
+	entries := MatchGitAnnotations(logs.Events, GitAnnotationsOf(metadata))
+	for i := range entries {
+		entries[i].Changes = AuditEventChanges(entries[i].AuditEvent)
+	}
+	return entries, nil
+}
@@ -0,0 +1,84 @@
+package izanami
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGitAnnotation(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	assert.Nil(t, ReadGitAnnotation(context.Background(), dir))
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	run("init", "-q", "-b", "feature/flags")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0600))
+	run("add", ".")
+	run("-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "-q", "-m", "init")
+
+	annotation := ReadGitAnnotation(context.Background(), dir)
+	require.NotNil(t, annotation)
+	assert.Equal(t, "feature/flags", annotation.Branch)
+	assert.Len(t, annotation.Commit, 40)
+	assert.Equal(t, "Jane Doe <jane@example.com>", annotation.Author)
+	assert.False(t, annotation.Dirty)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main // changed\n"), 0600))
+	assert.True(t, ReadGitAnnotation(context.Background(), dir).Dirty)
+}
+
+func TestAnnotateFeatureMetadata(t *testing.T) {
+	var previous map[string]interface{}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxGitAnnotations+2; i++ {
+		payload := map[string]interface{}{"metadata": map[string]interface{}{"owner": "team"}}
+		AnnotateFeatureMetadata(payload, previous, &GitAnnotation{Commit: string(rune('a' + i)), At: start.Add(time.Duration(i) * time.Hour)})
+		previous = payload["metadata"].(map[string]interface{})
+	}
+
+	assert.Equal(t, "team", previous["owner"])
+	annotations := GitAnnotationsOf(previous)
+	require.Len(t, annotations, maxGitAnnotations)
+	assert.Equal(t, "c", annotations[0].Commit)
+	assert.Equal(t, string(rune('a'+maxGitAnnotations+1)), annotations[maxGitAnnotations-1].Commit)
+	assert.Equal(t, annotations[maxGitAnnotations-1].Commit, previous[GitMetadataKey].(map[string]interface{})["commit"])
+
+	assert.Nil(t, GitAnnotationsOf(map[string]interface{}{}))
+}
+
+func TestMatchGitAnnotations(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := []AuditEvent{
+		{EventID: 3, User: "alice", EmittedAt: at.Add(time.Hour + 5*time.Second).Format(time.RFC3339)},
+		{EventID: 2, User: "bob", EmittedAt: at.Add(30 * time.Minute).Format(time.RFC3339)},
+		{EventID: 1, User: "alice", EmittedAt: at.Add(2 * time.Second).Format(time.RFC3339)},
+	}
+	annotations := []GitAnnotation{
+		{Commit: "first", User: "alice", At: at},
+		{Commit: "second", User: "alice", At: at.Add(time.Hour)},
+		{Commit: "other-user", User: "carol", At: at.Add(30 * time.Minute)},
+	}
+
+	entries := MatchGitAnnotations(events, annotations)
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, "second", entries[0].Git.Commit)
+	assert.Nil(t, entries[1].Git)
+	assert.Equal(t, "-", entries[1].ToTableView().Commit)
+	assert.Equal(t, "first", entries[2].Git.Commit)
+}
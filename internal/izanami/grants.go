@@ -0,0 +1,227 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// TEMPORARY GRANTS
+// ============================================================================
+
+// grantsFileName is the file of the config dir recording temporary grants.
+// Izanami rights have no expiry, so the CLI keeps track of them to revert them.
+const grantsFileName = "grants.yaml"
+
+// TemporaryGrant is a project right granted until ExpiresAt
+type TemporaryGrant struct {
+	URL     string `yaml:"url" json:"url"`
+	Tenant  string `yaml:"tenant" json:"tenant"`
+	Project string `yaml:"project" json:"project"`
+	User    string `yaml:"user" json:"user"`
+	Level   string `yaml:"level" json:"level"`
+	// Previous is the project right of the user before the grant, empty for none
+	Previous  string    `yaml:"previous,omitempty" json:"previous,omitempty"`
+	GrantedBy string    `yaml:"granted_by,omitempty" json:"grantedBy,omitempty"`
+	GrantedAt time.Time `yaml:"granted_at" json:"grantedAt"`
+	ExpiresAt time.Time `yaml:"expires_at" json:"expiresAt"`
+}
+
+// grantsFile is the structure of the grants file
+type grantsFile struct {
+	Grants []TemporaryGrant `yaml:"grants"`
+}
+
+// Expired reports whether the grant has expired at now
+func (g *TemporaryGrant) Expired(now time.Time) bool {
+	return !now.Before(g.ExpiresAt)
+}
+
+// sameRight reports whether two grants are about the same user and project
+func (g *TemporaryGrant) sameRight(other *TemporaryGrant) bool {
+	return g.URL == other.URL && g.Tenant == other.Tenant && g.Project == other.Project && g.User == other.User
+}
+
+// GetGrantsPath returns the path of the file recording temporary grants
+func GetGrantsPath() string {
+	return filepath.Join(getConfigDir(), grantsFileName)
+}
+
+// LoadGrants reads the recorded temporary grants, sorted by expiry
+func LoadGrants() ([]TemporaryGrant, error) {
+	data, err := os.ReadFile(GetGrantsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []TemporaryGrant{}, nil
+		}
+		return nil, fmt.Errorf("failed to read grants file: %w", err)
+	}
+	var file grantsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse grants file %s: %w", GetGrantsPath(), err)
+	}
+	if file.Grants == nil {
+		file.Grants = []TemporaryGrant{}
+	}
+	sort.SliceStable(file.Grants, func(i, j int) bool {
+		return file.Grants[i].ExpiresAt.Before(file.Grants[j].ExpiresAt)
+	})
+	return file.Grants, nil
+}
+
+// SaveGrants writes the temporary grants with owner-only permissions
+func SaveGrants(grants []TemporaryGrant) error {
+	if err := os.MkdirAll(getConfigDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err := yaml.Marshal(&grantsFile{Grants: grants})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(GetGrantsPath(), data, 0600); err != nil {
+		return fmt.Errorf("failed to write grants file: %w", err)
+	}
+	return nil
+}
+
+// GrantsForURL returns the grants made on the server at url
+func GrantsForURL(grants []TemporaryGrant, url string) []TemporaryGrant {
+	var matched []TemporaryGrant
+	for _, grant := range grants {
+		if grant.URL == url {
+			matched = append(matched, grant)
+		}
+	}
+	return matched
+}
+
+// projectRightOf returns the project right of a user, empty when the user has none
+func projectRightOf(c *AdminClient, ctx context.Context, tenant, project, user string) (string, error) {
+	users, err := c.ListUsersForProject(ctx, tenant, project)
+	if err != nil {
+		return "", err
+	}
+	for _, u := range users {
+		if u.Username == user {
+			return u.Right, nil
+		}
+	}
+	return "", nil
+}
+
+// GrantTemporaryProjectRight gives user the level on a project until expiresAt
+// and records the grant. A grant can only raise a right. Granting again a right
+// already granted temporarily moves its expiry and keeps the right to revert to.
+func GrantTemporaryProjectRight(c *AdminClient, ctx context.Context, grant TemporaryGrant) (*TemporaryGrant, error) {
+	if _, ok := rightLevelRank[grant.Level]; !ok {
+		return nil, fmt.Errorf("invalid level %q (expected Read, Update, Write or Admin)", grant.Level)
+	}
+	grants, err := LoadGrants()
+	if err != nil {
+		return nil, err
+	}
+	current, err := projectRightOf(c, ctx, grant.Tenant, grant.Project, grant.User)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := -1
+	for i := range grants {
+		if grants[i].sameRight(&grant) {
+			existing = i
+		}
+	}
+	if existing >= 0 {
+		grant.Previous = grants[existing].Previous
+	} else {
+		if rightLevelRank[current] >= rightLevelRank[grant.Level] {
+			return nil, fmt.Errorf("%s already has %s rights on project %s; a temporary grant can only raise rights", grant.User, current, grant.Project)
+		}
+		grant.Previous = current
+	}
+
+	if current != grant.Level {
+		if err := c.UpdateUserProjectRights(ctx, grant.Tenant, grant.Project, grant.User, &ProjectRightUpdateRequest{Level: grant.Level}); err != nil {
+			return nil, err
+		}
+	}
+
+	if existing >= 0 {
+		grants[existing] = grant
+	} else {
+		grants = append(grants, grant)
+	}
+	if err := SaveGrants(grants); err != nil {
+		return nil, fmt.Errorf("right granted but not recorded, it will not expire: %w", err)
+	}
+	return &grant, nil
+}
+
+// GrantRevocation is the outcome of reverting one expired grant
+type GrantRevocation struct {
+	Grant TemporaryGrant `json:"grant"`
+	// RestoredTo is the level the user was given back, empty when the right was removed
+	RestoredTo string `json:"restoredTo,omitempty"`
+	// Skipped explains why the right was left as is
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RevokeExpiredGrants reverts the grants made on the server of c that expired at
+// now to the right the user had before, and forgets them. A right changed since
+// the grant is left as is. Grants that fail to revert are kept for a next run.
+// With dryRun, nothing is changed.
+func RevokeExpiredGrants(c *AdminClient, ctx context.Context, now time.Time, dryRun bool) ([]GrantRevocation, error) {
+	grants, err := LoadGrants()
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.config.LeaderURL
+	revocations := []GrantRevocation{}
+	kept := make([]TemporaryGrant, 0, len(grants))
+	for _, grant := range grants {
+		if grant.URL != url || !grant.Expired(now) {
+			kept = append(kept, grant)
+			continue
+		}
+
+		revocation := GrantRevocation{Grant: grant, RestoredTo: grant.Previous}
+		current, err := projectRightOf(c, ctx, grant.Tenant, grant.Project, grant.User)
+		switch {
+		case err != nil:
+			revocation.Error = err.Error()
+		case !strings.EqualFold(current, grant.Level):
+			revocation.RestoredTo = ""
+			revocation.Skipped = fmt.Sprintf("right changed to %q since the grant", current)
+		case dryRun:
+		default:
+			// An empty object removes the project right
+			var request interface{} = map[string]interface{}{}
+			if grant.Previous != "" {
+				request = &ProjectRightUpdateRequest{Level: grant.Previous}
+			}
+			if err := c.UpdateUserProjectRights(ctx, grant.Tenant, grant.Project, grant.User, request); err != nil {
+				revocation.Error = err.Error()
+			}
+		}
+		if revocation.Error != "" || dryRun {
+			kept = append(kept, grant)
+		}
+		revocations = append(revocations, revocation)
+	}
+
+	if !dryRun && len(kept) != len(grants) {
+		if err := SaveGrants(kept); err != nil {
+			return revocations, err
+		}
+	}
+	return revocations, nil
+}
@@ -0,0 +1,157 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grantsTestServer serves the users of project "p" of tenant "t" from rights
+// and records the project right updates in it
+func grantsTestServer(t *testing.T, rights map[string]string) (*AdminClient, *[]string) {
+	t.Helper()
+	var updates []string
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/api/admin/tenants/t/projects/p/users"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == prefix:
+			users := []ProjectScopedUser{}
+			for name, right := range rights {
+				users = append(users, ProjectScopedUser{Username: name, Right: right})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(users)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, prefix+"/"):
+			user := strings.TrimPrefix(r.URL.Path, prefix+"/")
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["level"] == "" {
+				delete(rights, user)
+			} else {
+				rights[user] = body["level"]
+			}
+			updates = append(updates, user+"="+body["level"])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "admin", JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client, &updates
+}
+
+func useTempGrantsDir(t *testing.T) {
+	t.Helper()
+	originalGetConfigDir := getConfigDir
+	dir := t.TempDir()
+	SetGetConfigDirFunc(func() string { return dir })
+	t.Cleanup(func() { SetGetConfigDirFunc(originalGetConfigDir) })
+}
+
+func newTestGrant(client *AdminClient, user, level string, expiresAt time.Time) TemporaryGrant {
+	return TemporaryGrant{URL: client.config.LeaderURL, Tenant: "t", Project: "p", User: user, Level: level, ExpiresAt: expiresAt}
+}
+
+func TestGrantTemporaryProjectRight(t *testing.T) {
+	useTempGrantsDir(t)
+	rights := map[string]string{"alice": "Read"}
+	client, updates := grantsTestServer(t, rights)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	grant, err := GrantTemporaryProjectRight(client, ctx, newTestGrant(client, "alice", "Write", expiresAt))
+	require.NoError(t, err)
+	assert.Equal(t, "Read", grant.Previous)
+	assert.Equal(t, "Write", rights["alice"])
+	assert.Equal(t, []string{"alice=Write"}, *updates)
+
+	// Granting again moves the expiry and keeps the right to revert to
+	later := expiresAt.Add(time.Hour)
+	grant, err = GrantTemporaryProjectRight(client, ctx, newTestGrant(client, "alice", "Write", later))
+	require.NoError(t, err)
+	assert.Equal(t, "Read", grant.Previous)
+	assert.Len(t, *updates, 1)
+
+	grants, err := LoadGrants()
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.True(t, grants[0].ExpiresAt.Equal(later))
+	assert.Equal(t, "Read", grants[0].Previous)
+}
+
+func TestGrantTemporaryProjectRight_Refused(t *testing.T) {
+	useTempGrantsDir(t)
+	client, updates := grantsTestServer(t, map[string]string{"alice": "Admin"})
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	_, err := GrantTemporaryProjectRight(client, ctx, newTestGrant(client, "alice", "Write", expiresAt))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can only raise rights")
+
+	_, err = GrantTemporaryProjectRight(client, ctx, newTestGrant(client, "alice", "Owner", expiresAt))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid level")
+
+	assert.Empty(t, *updates)
+	grants, err := LoadGrants()
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+}
+
+func TestRevokeExpiredGrants(t *testing.T) {
+	useTempGrantsDir(t)
+	rights := map[string]string{"alice": "Write", "bob": "Admin", "carol": "Admin", "dave": "Write"}
+	client, updates := grantsTestServer(t, rights)
+	now := time.Now()
+	past := now.Add(-time.Minute)
+
+	alice := newTestGrant(client, "alice", "Write", past)
+	alice.Previous = "Read"
+	bob := newTestGrant(client, "bob", "Admin", past)
+	carol := newTestGrant(client, "carol", "Write", past) // raised to Admin since the grant
+	dave := newTestGrant(client, "dave", "Write", now.Add(time.Hour))
+	other := newTestGrant(client, "alice", "Write", past)
+	other.URL = "http://other:9000"
+	require.NoError(t, SaveGrants([]TemporaryGrant{alice, bob, carol, dave, other}))
+
+	// Dry run changes nothing
+	revocations, err := RevokeExpiredGrants(client, context.Background(), now, true)
+	require.NoError(t, err)
+	assert.Len(t, revocations, 3)
+	assert.Empty(t, *updates)
+	grants, err := LoadGrants()
+	require.NoError(t, err)
+	assert.Len(t, grants, 5)
+
+	revocations, err = RevokeExpiredGrants(client, context.Background(), now, false)
+	require.NoError(t, err)
+	require.Len(t, revocations, 3)
+	byUser := map[string]GrantRevocation{}
+	for _, revocation := range revocations {
+		byUser[revocation.Grant.User] = revocation
+	}
+	assert.Equal(t, "Read", byUser["alice"].RestoredTo)
+	assert.Empty(t, byUser["bob"].RestoredTo)
+	assert.Contains(t, byUser["carol"].Skipped, "Admin")
+
+	assert.ElementsMatch(t, []string{"alice=Read", "bob="}, *updates)
+	assert.Equal(t, "Read", rights["alice"])
+	assert.NotContains(t, rights, "bob")
+	assert.Equal(t, "Admin", rights["carol"])
+
+	grants, err = LoadGrants()
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, "http://other:9000", grants[0].URL)
+	assert.Equal(t, "dave", grants[1].User)
+}
@@ -0,0 +1,227 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// ROLLOUT GUARDRAILS (iz admin features validate-rollout)
+// ============================================================================
+
+// Guardrail statuses
+const (
+	GuardrailOK       = "ok"
+	GuardrailViolated = "violated"
+	GuardrailNoData   = "no data"
+	GuardrailError    = "error"
+)
+
+// RolloutGuardrails are the metrics checked before a feature is enabled: each
+// guardrail is a Prometheus query whose value must stay within bounds
+type RolloutGuardrails struct {
+	// PrometheusURL is the base URL of the Prometheus HTTP API, e.g. https://prometheus.example.com
+	PrometheusURL string `yaml:"prometheus-url" mapstructure:"prometheus-url"`
+	// Headers are sent with every query, e.g. Authorization
+	Headers    map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
+	Guardrails []Guardrail       `yaml:"guardrails" mapstructure:"guardrails"`
+}
+
+// Guardrail is a Prometheus query with the bounds its value must stay within.
+// {{feature}} and {{project}} in the query are replaced by the feature checked.
+type Guardrail struct {
+	Name  string   `yaml:"name" mapstructure:"name"`
+	Query string   `yaml:"query" mapstructure:"query"`
+	Max   *float64 `yaml:"max,omitempty" mapstructure:"max"`
+	Min   *float64 `yaml:"min,omitempty" mapstructure:"min"`
+}
+
+// GuardrailResult is the evaluation of one guardrail
+type GuardrailResult struct {
+	Name      string   `json:"name"`
+	Query     string   `json:"query"`
+	Value     *float64 `json:"value"`
+	Threshold string   `json:"threshold"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// Passed reports whether the guardrail holds
+func (r GuardrailResult) Passed() bool {
+	return r.Status == GuardrailOK
+}
+
+// Threshold describes the bounds of a guardrail, e.g. "<= 0.01"
+func (g Guardrail) Threshold() string {
+	var bounds []string
+	if g.Min != nil {
+		bounds = append(bounds, ">= "+strconv.FormatFloat(*g.Min, 'g', -1, 64))
+	}
+	if g.Max != nil {
+		bounds = append(bounds, "<= "+strconv.FormatFloat(*g.Max, 'g', -1, 64))
+	}
+	return strings.Join(bounds, " and ")
+}
+
+// holds reports whether a value is within the bounds of the guardrail
+func (g Guardrail) holds(value float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	return (g.Min == nil || value >= *g.Min) && (g.Max == nil || value <= *g.Max)
+}
+
+// ValidateGuardrails checks the rollout-guardrails section of the config
+func ValidateGuardrails(guardrails *RolloutGuardrails) []ValidationError {
+	if guardrails == nil {
+		return nil
+	}
+	var errs []ValidationError
+	if len(guardrails.Guardrails) > 0 && guardrails.PrometheusURL == "" {
+		errs = append(errs, ValidationError{Field: "rollout-guardrails", Message: "prometheus-url is required"})
+	}
+	for i, g := range guardrails.Guardrails {
+		name := g.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+			errs = append(errs, ValidationError{Field: "rollout-guardrails", Message: fmt.Sprintf("Guardrail %s must have a name", name)})
+		}
+		if strings.TrimSpace(g.Query) == "" {
+			errs = append(errs, ValidationError{Field: "rollout-guardrails", Message: fmt.Sprintf("Guardrail %s must have a query", name)})
+		}
+		if g.Min == nil && g.Max == nil {
+			errs = append(errs, ValidationError{Field: "rollout-guardrails", Message: fmt.Sprintf("Guardrail %s must have a min or a max", name)})
+		}
+	}
+	return errs
+}
+
+// EvaluateGuardrails runs the query of each guardrail for a feature. A query
+// failing or returning no data fails its guardrail: a rollout is only allowed
+// on metrics that are known to be healthy.
+func EvaluateGuardrails(ctx context.Context, client *http.Client, guardrails *RolloutGuardrails, feature, project string) []GuardrailResult {
+	results := make([]GuardrailResult, len(guardrails.Guardrails))
+	replacer := strings.NewReplacer("{{feature}}", feature, "{{project}}", project)
+	for i, g := range guardrails.Guardrails {
+		query := replacer.Replace(g.Query)
+		result := GuardrailResult{Name: g.Name, Query: query, Threshold: g.Threshold()}
+
+		values, err := QueryPrometheus(ctx, client, guardrails, query)
+		switch {
+		case err != nil:
+			result.Status, result.Message = GuardrailError, err.Error()
+		case len(values) == 0:
+			result.Status, result.Message = GuardrailNoData, "the query returned no data"
+		default:
+			// Every series must hold; the worst one is reported
+			worst := values[0]
+			result.Status = GuardrailOK
+			for _, v := range values {
+				if !g.holds(v) {
+					worst, result.Status = v, GuardrailViolated
+					break
+				}
+				if g.Max != nil && v > worst || g.Max == nil && v < worst {
+					worst = v
+				}
+			}
+			result.Value = &worst
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// prometheusResponse is the body of a Prometheus instant query
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// QueryPrometheus runs an instant query and returns the value of each series
+// (one value for scalar results)
+func QueryPrometheus(ctx context.Context, client *http.Client, guardrails *RolloutGuardrails, query string) ([]float64, error) {
+	endpoint := strings.TrimRight(guardrails.PrometheusURL, "/") + "/api/v1/query?query=" + url.QueryEscape(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prometheus-url: %w", err)
+	}
+	for name, value := range guardrails.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+
+	var parsed prometheusResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid Prometheus response (status %d)", resp.StatusCode)
+	}
+	if parsed.Status != "success" {
+		return nil, fmt.Errorf("Prometheus query failed (status %d): %s", resp.StatusCode, parsed.Error)
+	}
+
+	switch parsed.Data.ResultType {
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(parsed.Data.Result, &sample); err != nil {
+			return nil, fmt.Errorf("invalid Prometheus scalar: %w", err)
+		}
+		v, err := prometheusSampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+	case "vector":
+		var series []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(parsed.Data.Result, &series); err != nil {
+			return nil, fmt.Errorf("invalid Prometheus vector: %w", err)
+		}
+		values := make([]float64, 0, len(series))
+		for _, s := range series {
+			v, err := prometheusSampleValue(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported Prometheus result type %q (use an instant vector or scalar query)", parsed.Data.ResultType)
+	}
+}
+
+// prometheusSampleValue returns the value of a [timestamp, "value"] sample
+func prometheusSampleValue(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid Prometheus sample %v", sample)
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid Prometheus sample value %v", sample[1])
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Prometheus sample value %q", raw)
+	}
+	return v, nil
+}
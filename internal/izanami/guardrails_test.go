@@ -0,0 +1,88 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prometheusServer answers instant queries with the response of each query
+func prometheusServer(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, ok := responses[r.URL.Query().Get("query")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestEvaluateGuardrails(t *testing.T) {
+	server := prometheusServer(t, map[string]string{
+		`errors{flag="checkout"}`: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"a"},"value":[1719824400,"0.002"]},{"metric":{"pod":"b"},"value":[1719824400,"0.004"]}]}}`,
+		`latency`:                 `{"status":"success","data":{"resultType":"scalar","result":[1719824400,"0.9"]}}`,
+		`success`:                 `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+	})
+	guardrails := &RolloutGuardrails{
+		PrometheusURL: server.URL + "/",
+		Headers:       map[string]string{"Authorization": "Bearer secret"},
+		Guardrails: []Guardrail{
+			{Name: "error-rate", Query: `errors{flag="{{feature}}"}`, Max: floatPtr(0.01)},
+			{Name: "p99", Query: "latency", Max: floatPtr(0.5)},
+			{Name: "success", Query: "success", Min: floatPtr(0.99)},
+			{Name: "broken", Query: "rate(", Min: floatPtr(0), Max: floatPtr(1)},
+		},
+	}
+
+	results := EvaluateGuardrails(context.Background(), http.DefaultClient, guardrails, "checkout", "shop")
+	require.Len(t, results, 4)
+
+	assert.Equal(t, GuardrailOK, results[0].Status)
+	assert.Equal(t, `errors{flag="checkout"}`, results[0].Query)
+	assert.Equal(t, 0.004, *results[0].Value, "the worst series is reported")
+	assert.Equal(t, "<= 0.01", results[0].Threshold)
+	assert.True(t, results[0].Passed())
+
+	assert.Equal(t, GuardrailViolated, results[1].Status)
+	assert.Equal(t, 0.9, *results[1].Value)
+
+	assert.Equal(t, GuardrailNoData, results[2].Status)
+	assert.Nil(t, results[2].Value)
+
+	assert.Equal(t, GuardrailError, results[3].Status)
+	assert.Contains(t, results[3].Message, "parse error")
+	assert.Equal(t, ">= 0 and <= 1", results[3].Threshold)
+}
+
+func TestValidateGuardrails(t *testing.T) {
+	assert.Empty(t, ValidateGuardrails(nil))
+	assert.Empty(t, ValidateGuardrails(&RolloutGuardrails{
+		PrometheusURL: "http://prometheus",
+		Guardrails:    []Guardrail{{Name: "errors", Query: "up", Max: floatPtr(1)}},
+	}))
+
+	errs := ValidateGuardrails(&RolloutGuardrails{Guardrails: []Guardrail{{Query: " "}}})
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Message
+	}
+	assert.Equal(t, []string{
+		"prometheus-url is required",
+		"Guardrail #1 must have a name",
+		"Guardrail #1 must have a query",
+		"Guardrail #1 must have a min or a max",
+	}, messages)
+}
@@ -0,0 +1,165 @@
+package izanami
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// NOTIFICATION HOOKS
+// ============================================================================
+
+// HookTimeout bounds the run of one hook
+const HookTimeout = 10 * time.Second
+
+// Hook events sent after successful CLI mutations
+const (
+	HookFeatureCreate  = "feature.create"
+	HookFeatureUpdate  = "feature.update"
+	HookFeatureDelete  = "feature.delete"
+	HookOverloadSet    = "overload.set"
+	HookOverloadDelete = "overload.delete"
+	HookProjectCreate  = "project.create"
+	HookProjectDelete  = "project.delete"
+	HookKeyCreate      = "key.create"
+	HookKeyUpdate      = "key.update"
+	HookKeyDelete      = "key.delete"
+	HookImportApply    = "import.apply"
+)
+
+// HookEvents lists the events hooks can be attached to
+var HookEvents = []string{
+	HookFeatureCreate, HookFeatureUpdate, HookFeatureDelete,
+	HookOverloadSet, HookOverloadDelete,
+	HookProjectCreate, HookProjectDelete,
+	HookKeyCreate, HookKeyUpdate, HookKeyDelete,
+	HookImportApply,
+}
+
+// HookConfig is a hook run after a mutation: a shell command receiving the
+// payload on stdin, or a URL receiving it as a JSON POST
+type HookConfig struct {
+	Command string `yaml:"command,omitempty" mapstructure:"command"`
+	URL     string `yaml:"url,omitempty" mapstructure:"url"`
+}
+
+// HookPayload is the JSON document sent to hooks
+type HookPayload struct {
+	Event    string                 `json:"event"`
+	Time     string                 `json:"time"`
+	URL      string                 `json:"url,omitempty"`
+	Tenant   string                 `json:"tenant,omitempty"`
+	Project  string                 `json:"project,omitempty"`
+	User     string                 `json:"user,omitempty"`
+	Command  string                 `json:"command"`
+	Resource map[string]interface{} `json:"resource,omitempty"`
+}
+
+// MatchHooks returns the hooks configured for an event. Keys match an event
+// exactly, by resource with a trailing wildcard ("feature.*") or all events ("*").
+func MatchHooks(hooks map[string][]HookConfig, event string) []HookConfig {
+	keys := make([]string, 0, len(hooks))
+	for key := range hooks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var matched []HookConfig
+	for _, key := range keys {
+		if key == event || key == "*" || strings.HasSuffix(key, ".*") && strings.HasPrefix(event, strings.TrimSuffix(key, "*")) {
+			matched = append(matched, hooks[key]...)
+		}
+	}
+	return matched
+}
+
+// ValidateHooks checks the events and targets of a hooks section
+func ValidateHooks(hooks map[string][]HookConfig) []ValidationError {
+	known := make(map[string]bool, len(HookEvents))
+	resources := make(map[string]bool)
+	for _, event := range HookEvents {
+		known[event] = true
+		resource, _, _ := strings.Cut(event, ".")
+		resources[resource] = true
+	}
+
+	var errs []ValidationError
+	keys := make([]string, 0, len(hooks))
+	for key := range hooks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		resource, ok := strings.CutSuffix(key, ".*")
+		if !known[key] && key != "*" && !(ok && resources[resource]) {
+			errs = append(errs, ValidationError{Field: "hooks", Message: fmt.Sprintf("Unknown hook event %q", key)})
+		}
+		for _, hook := range hooks[key] {
+			if (hook.Command == "") == (hook.URL == "") {
+				errs = append(errs, ValidationError{Field: "hooks", Message: fmt.Sprintf("Hook of %q must have either a command or a url", key)})
+			}
+		}
+	}
+	return errs
+}
+
+// RunHook runs one hook with the payload: commands get it on stdin (and the
+// event in IZ_HOOK_EVENT), URLs as a JSON POST body
+func RunHook(ctx context.Context, hook HookConfig, payload *HookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, HookTimeout)
+	defer cancel()
+
+	if hook.Command != "" {
+		return runHookCommand(ctx, hook.Command, payload.Event, body)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", hook.URL, resp.Status)
+	}
+	return nil
+}
+
+// runHookCommand runs a hook command through the shell of the platform
+func runHookCommand(ctx context.Context, command, event string, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "IZ_HOOK_EVENT="+event)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
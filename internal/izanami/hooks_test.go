@@ -0,0 +1,90 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchHooks(t *testing.T) {
+	hooks := map[string][]HookConfig{
+		"feature.update": {{URL: "http://exact"}},
+		"feature.*":      {{URL: "http://resource"}},
+		"*":              {{Command: "true"}},
+		"key.delete":     {{Command: "false"}},
+	}
+
+	assert.Equal(t, []HookConfig{{Command: "true"}, {URL: "http://resource"}, {URL: "http://exact"}}, MatchHooks(hooks, HookFeatureUpdate))
+	assert.Equal(t, []HookConfig{{Command: "true"}, {Command: "false"}}, MatchHooks(hooks, HookKeyDelete))
+	assert.Empty(t, MatchHooks(nil, HookFeatureCreate))
+}
+
+func TestValidateHooks(t *testing.T) {
+	errs := ValidateHooks(map[string][]HookConfig{
+		"feature.update": {{URL: "http://example.com"}},
+		"project.*":      {{Command: "echo"}},
+		"*":              {{Command: "echo"}},
+	})
+	assert.Empty(t, errs)
+
+	errs = ValidateHooks(map[string][]HookConfig{
+		"feature.rename": {{URL: "http://example.com"}},
+		"tenant.*":       {{URL: "http://example.com"}},
+		"key.create":     {{}, {Command: "echo", URL: "http://example.com"}},
+	})
+	require.Len(t, errs, 4)
+	assert.Contains(t, errs[0].Message, `Unknown hook event "feature.rename"`)
+	assert.Contains(t, errs[1].Message, `Hook of "key.create" must have either a command or a url`)
+	assert.Contains(t, errs[3].Message, `Unknown hook event "tenant.*"`)
+}
+
+func TestRunHook_URL(t *testing.T) {
+	var received HookPayload
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	payload := &HookPayload{Event: HookFeatureDelete, Tenant: "acme", Command: "iz admin features delete", Resource: map[string]interface{}{"id": "f1"}}
+	require.NoError(t, RunHook(context.Background(), HookConfig{URL: server.URL}, payload))
+	assert.Equal(t, HookFeatureDelete, received.Event)
+	assert.Equal(t, "acme", received.Tenant)
+	assert.Equal(t, "f1", received.Resource["id"])
+}
+
+func TestRunHook_URLError(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	err := RunHook(context.Background(), HookConfig{URL: server.URL}, &HookPayload{Event: HookKeyCreate})
+	assert.ErrorContains(t, err, "500")
+}
+
+func TestRunHook_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	out := filepath.Join(t.TempDir(), "payload.json")
+
+	payload := &HookPayload{Event: HookProjectCreate, Resource: map[string]interface{}{"name": "shop"}}
+	require.NoError(t, RunHook(context.Background(), HookConfig{Command: `cat > "` + out + `"; echo "$IZ_HOOK_EVENT" >> "` + out + `"`}, payload))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"resource":{"name":"shop"}}project.create`)
+
+	err = RunHook(context.Background(), HookConfig{Command: "echo boom >&2; exit 3"}, payload)
+	assert.ErrorContains(t, err, "boom")
+}
@@ -0,0 +1,148 @@
+package izanami
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Log levels and formats accepted by NewLogger
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// httpLogger traces every API call when set (see SetHTTPLogger).
+// It is shared by all clients created during a command, like the circuit breakers.
+var (
+	httpLoggerMu sync.RWMutex
+	httpLogger   *slog.Logger
+)
+
+// NewLogger creates a structured logger writing to w.
+// level is one of debug, info or warn; format is text or json.
+func NewLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	switch strings.ToLower(level) {
+	case LogLevelDebug:
+		lvl = slog.LevelDebug
+	case LogLevelInfo, "":
+		lvl = slog.LevelInfo
+	case LogLevelWarn:
+		lvl = slog.LevelWarn
+	default:
+		return nil, fmt.Errorf("invalid log level %q (must be debug, info or warn)", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case LogFormatText, "":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q (must be text or json)", format)
+	}
+}
+
+// SetHTTPLogger enables HTTP tracing for clients created afterwards (nil disables it).
+//
+// Each API call is logged with its method, URL, status, latency and attempt:
+// successful calls at info level, 4xx/5xx responses and network errors at warn
+// level. At debug level the request and response headers are added, with
+// sensitive headers (cookies, credentials) redacted.
+func SetHTTPLogger(logger *slog.Logger) {
+	httpLoggerMu.Lock()
+	defer httpLoggerMu.Unlock()
+	httpLogger = logger
+}
+
+// getHTTPLogger returns the current HTTP logger, or nil when tracing is disabled
+func getHTTPLogger() *slog.Logger {
+	httpLoggerMu.RLock()
+	defer httpLoggerMu.RUnlock()
+	return httpLogger
+}
+
+// enableHTTPTracing installs the logging hooks on a resty client
+func enableHTTPTracing(client *resty.Client, logger *slog.Logger) {
+	sensitiveHeaders := sensitiveHeadersMap()
+
+	client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		req := resp.Request
+		level := slog.LevelInfo
+		if resp.StatusCode() >= 400 {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", tracedURL(req)),
+			slog.Int("status", resp.StatusCode()),
+			slog.Duration("latency", resp.Time()),
+			slog.Int("attempt", req.Attempt),
+		}
+		if logger.Enabled(req.Context(), slog.LevelDebug) {
+			attrs = append(attrs,
+				slog.Any("request_headers", redactHeaders(tracedHeaders(req), sensitiveHeaders)),
+				slog.Any("response_headers", redactHeaders(resp.Header(), sensitiveHeaders)),
+			)
+		}
+		logger.LogAttrs(req.Context(), level, "HTTP request", attrs...)
+		return nil
+	})
+
+	client.OnError(func(req *resty.Request, err error) {
+		// Errors raised after a response was received were already logged by OnAfterResponse
+		if respErr, ok := err.(*resty.ResponseError); ok {
+			if respErr.Response != nil && respErr.Response.RawResponse != nil {
+				return
+			}
+			err = respErr.Err
+		}
+		logger.LogAttrs(req.Context(), slog.LevelWarn, "HTTP request failed",
+			slog.String("method", req.Method),
+			slog.String("url", tracedURL(req)),
+			slog.Int("attempt", req.Attempt),
+			slog.String("error", err.Error()),
+		)
+	})
+}
+
+// tracedURL returns the URL of a request, including its query string
+func tracedURL(req *resty.Request) string {
+	if req.RawRequest != nil {
+		return req.RawRequest.URL.String()
+	}
+	return req.URL
+}
+
+// tracedHeaders returns the headers actually sent, including authentication
+func tracedHeaders(req *resty.Request) http.Header {
+	if req.RawRequest != nil {
+		return req.RawRequest.Header
+	}
+	return req.Header
+}
+
+// redactHeaders flattens headers for logging, hiding the values of sensitive ones
+func redactHeaders(headers http.Header, sensitiveHeaders map[string]bool) map[string]string {
+	result := make(map[string]string, len(headers))
+	for key := range headers {
+		if sensitiveHeaders[strings.ToLower(key)] {
+			result[key] = "[REDACTED]"
+		} else {
+			result[key] = strings.Join(headers[key], ", ")
+		}
+	}
+	return result
+}
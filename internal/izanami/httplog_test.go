@@ -0,0 +1,142 @@
+package izanami
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useHTTPLogger installs a JSON HTTP logger for the duration of a test
+func useHTTPLogger(t *testing.T, level string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, level, LogFormatJSON)
+	require.NoError(t, err)
+	SetHTTPLogger(logger)
+	t.Cleanup(func() { SetHTTPLogger(nil) })
+	return &buf
+}
+
+// logRecords decodes the JSON log lines written to buf
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestNewLogger_InvalidOptions(t *testing.T) {
+	_, err := NewLogger(&bytes.Buffer{}, "trace", LogFormatText)
+	assert.ErrorContains(t, err, "invalid log level")
+
+	_, err = NewLogger(&bytes.Buffer{}, LogLevelInfo, "xml")
+	assert.ErrorContains(t, err, "invalid log format")
+}
+
+func TestHTTPTracing_LogsEveryCall(t *testing.T) {
+	buf := useHTTPLogger(t, LogLevelInfo)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{})
+	_, err := client.R().SetContext(context.Background()).Get("/api/admin/tenants?page=1")
+	require.NoError(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "INFO", records[0]["level"])
+	assert.Equal(t, "HTTP request", records[0]["msg"])
+	assert.Equal(t, "GET", records[0]["method"])
+	assert.Equal(t, server.URL+"/api/admin/tenants?page=1", records[0]["url"])
+	assert.Equal(t, float64(200), records[0]["status"])
+	assert.Contains(t, records[0], "latency")
+	assert.NotContains(t, records[0], "request_headers", "headers are only logged at debug level")
+}
+
+func TestHTTPTracing_DebugRedactsSensitiveHeaders(t *testing.T) {
+	buf := useHTTPLogger(t, LogLevelDebug)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "token=secret")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{})
+	_, err := client.R().
+		SetContext(context.Background()).
+		SetHeader("Cookie", "token=secret").
+		SetHeader("Izanami-Client-Secret", "secret").
+		SetHeader("Accept", "application/json").
+		Get("/api/test")
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "token=secret")
+	records := logRecords(t, buf)
+	require.Len(t, records, 1)
+
+	reqHeaders := records[0]["request_headers"].(map[string]interface{})
+	assert.Equal(t, "[REDACTED]", reqHeaders["Cookie"])
+	assert.Equal(t, "[REDACTED]", reqHeaders["Izanami-Client-Secret"])
+	assert.Equal(t, "application/json", reqHeaders["Accept"])
+
+	respHeaders := records[0]["response_headers"].(map[string]interface{})
+	assert.Equal(t, "[REDACTED]", respHeaders["Set-Cookie"])
+}
+
+func TestHTTPTracing_WarnLevelLogsFailuresOnly(t *testing.T) {
+	buf := useHTTPLogger(t, LogLevelWarn)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{})
+	_, err := client.R().SetContext(context.Background()).Get("/ok")
+	require.NoError(t, err)
+	_, err = client.R().SetContext(context.Background()).Get("/missing")
+	require.NoError(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, float64(404), records[0]["status"])
+}
+
+func TestHTTPTracing_LogsNetworkErrors(t *testing.T) {
+	buf := useHTTPLogger(t, LogLevelInfo)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := newHTTPClient(url, 1, false, RetryPolicy{MaxWait: time.Millisecond})
+	_, err := client.R().SetContext(context.Background()).Get("/api/test")
+	require.Error(t, err)
+
+	records := logRecords(t, buf)
+	require.NotEmpty(t, records)
+	last := records[len(records)-1]
+	assert.Equal(t, "HTTP request failed", last["msg"])
+	assert.Equal(t, "WARN", last["level"])
+	assert.NotEmpty(t, last["error"])
+}
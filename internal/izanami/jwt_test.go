@@ -0,0 +1,64 @@
+package izanami
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeJWT builds an unsigned JWT with the given JSON claims
+func makeJWT(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return header + "." + payload + ".signature"
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		token  string
+		want   time.Time
+		wantOK bool
+	}{
+		{"exp claim", makeJWT(fmt.Sprintf(`{"sub":"admin","exp":%d}`, exp.Unix())), exp, true},
+		{"fractional exp", makeJWT(fmt.Sprintf(`{"exp":%d.5}`, exp.Unix())), exp, true},
+		{"padded payload", "h." + base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix()))) + ".s", exp, true},
+		{"no exp claim", makeJWT(`{"sub":"admin"}`), time.Time{}, false},
+		{"not a JWT", "opaque-token", time.Time{}, false},
+		{"invalid payload", "a.!!!.c", time.Time{}, false},
+		{"empty", "", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := JWTExpiry(tt.token)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestSession_IsTokenExpired_UsesExpClaim(t *testing.T) {
+	// A recent session whose token already expired
+	expired := &Session{
+		JwtToken:  makeJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(-time.Minute).Unix())),
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+	assert.True(t, expired.IsTokenExpired(0))
+
+	// An old session whose token is still valid
+	valid := &Session{
+		JwtToken:  makeJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix())),
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+	}
+	assert.False(t, valid.IsTokenExpired(0))
+
+	exp, ok := valid.ExpiresAt()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), exp, 2*time.Second)
+}
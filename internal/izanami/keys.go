@@ -32,7 +32,7 @@ func (c *AdminClient) listAPIKeysRaw(ctx context.Context, tenant string) ([]byte
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListAPIKeys, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListAPIKeys), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -49,7 +49,7 @@ func (c *AdminClient) listAPIKeysRaw(ctx context.Context, tenant string) ([]byte
 func (c *AdminClient) GetAPIKeyByName(ctx context.Context, tenant, name string) (*APIKey, error) {
 	keys, err := ListAPIKeys(c, ctx, tenant, ParseAPIKeys)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetAPIKey, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetAPIKey), err)
 	}
 
 	// Find the key with matching name
@@ -81,7 +81,7 @@ func (c *AdminClient) CreateAPIKey(ctx context.Context, tenant string, key inter
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateAPIKey, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateAPIKey), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -104,7 +104,7 @@ func (c *AdminClient) UpdateAPIKey(ctx context.Context, tenant, name string, key
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateAPIKey, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateAPIKey), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -123,7 +123,7 @@ func (c *AdminClient) DeleteAPIKey(ctx context.Context, tenant, name string) err
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteAPIKey, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteAPIKey), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -153,7 +153,7 @@ func (c *AdminClient) listAPIKeyUsersRaw(ctx context.Context, tenant, clientID s
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListAPIKeyUsers, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListAPIKeyUsers), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -0,0 +1,176 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// API KEY USAGE REPORT
+// ============================================================================
+
+// lastUsedFields are the API key fields that may hold the last use date.
+// Izanami does not expose it in all versions: the first one present is used.
+var lastUsedFields = []string{"lastUsed", "lastUsedAt", "lastUsage", "last_used"}
+
+// APIKeyUsage describes who can use an API key and where it is referenced locally
+type APIKeyUsage struct {
+	Name     string   `json:"name"`
+	ClientID string   `json:"clientId"`
+	Enabled  bool     `json:"enabled"`
+	Admin    bool     `json:"admin"`
+	Projects []string `json:"projects"`
+	// LastUsed is the last use date reported by the server, empty when it does not expose it
+	LastUsed string `json:"lastUsed,omitempty"`
+	// References are the local client-keys entries using the key's client ID
+	References []ClientKeyReference `json:"references"`
+}
+
+// ClientKeyReference locates a client-keys entry of the local config
+type ClientKeyReference struct {
+	Profile string `json:"profile"`
+	// Worker is set for client keys of a named worker of the profile
+	Worker  string `json:"worker,omitempty"`
+	Tenant  string `json:"tenant"`
+	Project string `json:"project,omitempty"`
+}
+
+// String formats the reference as profile[/worker]:tenant[/project]
+func (r ClientKeyReference) String() string {
+	location := r.Profile
+	if r.Worker != "" {
+		location += "/" + r.Worker
+	}
+	location += ":" + r.Tenant
+	if r.Project != "" {
+		location += "/" + r.Project
+	}
+	return location
+}
+
+// APIKeyUsageTableView represents an API key usage for table display
+type APIKeyUsageTableView struct {
+	Name       string `json:"name"`
+	ClientID   string `json:"clientId"`
+	Enabled    bool   `json:"enabled"`
+	Projects   string `json:"projects"`
+	LastUsed   string `json:"lastUsed"`
+	References string `json:"references"`
+}
+
+// ToTableView converts an APIKeyUsage to a table-friendly view
+func (u APIKeyUsage) ToTableView() APIKeyUsageTableView {
+	view := APIKeyUsageTableView{
+		Name:       u.Name,
+		ClientID:   u.ClientID,
+		Enabled:    u.Enabled,
+		Projects:   strings.Join(u.Projects, ", "),
+		LastUsed:   u.LastUsed,
+		References: "-",
+	}
+	if u.Admin {
+		view.Projects = "all (admin)"
+	} else if view.Projects == "" {
+		view.Projects = "-"
+	}
+	if view.LastUsed == "" {
+		view.LastUsed = "unknown"
+	}
+	if len(u.References) > 0 {
+		refs := make([]string, len(u.References))
+		for i, ref := range u.References {
+			refs[i] = ref.String()
+		}
+		view.References = strings.Join(refs, ", ")
+	}
+	return view
+}
+
+// ListAPIKeyUsage lists the API keys of a tenant with the local client-keys
+// entries of profiles referencing them, sorted by name
+func ListAPIKeyUsage(c *AdminClient, ctx context.Context, tenant string, profiles map[string]*Profile) ([]APIKeyUsage, error) {
+	raw, err := c.listAPIKeysRaw(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	return buildAPIKeyUsage(raw, FindClientKeyReferences(profiles))
+}
+
+// buildAPIKeyUsage parses listed API keys and attaches their references
+func buildAPIKeyUsage(raw []byte, references map[string][]ClientKeyReference) ([]APIKeyUsage, error) {
+	var keys []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys: %w", err)
+	}
+
+	usages := make([]APIKeyUsage, 0, len(keys))
+	for _, fields := range keys {
+		var key APIKey
+		data, _ := json.Marshal(fields)
+		if err := json.Unmarshal(data, &key); err != nil {
+			return nil, fmt.Errorf("failed to parse API key: %w", err)
+		}
+		usage := APIKeyUsage{
+			Name:       key.Name,
+			ClientID:   key.ClientID,
+			Enabled:    key.Enabled,
+			Admin:      key.Admin,
+			Projects:   key.Projects,
+			References: references[key.ClientID],
+		}
+		if usage.Projects == nil {
+			usage.Projects = []string{}
+		}
+		if usage.References == nil {
+			usage.References = []ClientKeyReference{}
+		}
+		for _, name := range lastUsedFields {
+			var value string
+			if json.Unmarshal(fields[name], &value) == nil && value != "" {
+				usage.LastUsed = value
+				break
+			}
+		}
+		usages = append(usages, usage)
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].Name < usages[j].Name })
+	return usages, nil
+}
+
+// FindClientKeyReferences indexes the client-keys entries of profiles and of
+// their workers by client ID, in profile, worker, tenant and project order
+func FindClientKeyReferences(profiles map[string]*Profile) map[string][]ClientKeyReference {
+	references := make(map[string][]ClientKeyReference)
+	add := func(profile, worker string, clientKeys map[string]TenantClientKeysConfig) {
+		for _, tenant := range sortedKeys(clientKeys) {
+			tenantKeys := clientKeys[tenant]
+			if tenantKeys.ClientID != "" {
+				references[tenantKeys.ClientID] = append(references[tenantKeys.ClientID], ClientKeyReference{Profile: profile, Worker: worker, Tenant: tenant})
+			}
+			for _, project := range sortedKeys(tenantKeys.Projects) {
+				clientID := tenantKeys.Projects[project].ClientID
+				if clientID != "" {
+					references[clientID] = append(references[clientID], ClientKeyReference{Profile: profile, Worker: worker, Tenant: tenant, Project: project})
+				}
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(profiles) {
+		profile := profiles[name]
+		if profile == nil {
+			continue
+		}
+		add(name, "", profile.ClientKeys)
+		for _, worker := range sortedKeys(profile.Workers) {
+			if profile.Workers[worker] != nil {
+				add(name, worker, profile.Workers[worker].ClientKeys)
+			}
+		}
+	}
+	return references
+}
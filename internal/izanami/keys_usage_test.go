@@ -0,0 +1,74 @@
+package izanami
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindClientKeyReferences(t *testing.T) {
+	profiles := map[string]*Profile{
+		"prod": {
+			ClientKeys: map[string]TenantClientKeysConfig{
+				"acme": {
+					ClientID: "tenant-key",
+					Projects: map[string]ProjectClientKeysConfig{
+						"web": {ClientID: "web-key"},
+					},
+				},
+			},
+			Workers: map[string]*WorkerConfig{
+				"eu": {ClientKeys: map[string]TenantClientKeysConfig{"acme": {ClientID: "web-key"}}},
+			},
+		},
+		"dev": {
+			ClientKeys: map[string]TenantClientKeysConfig{"acme": {ClientID: "web-key"}},
+		},
+	}
+
+	refs := FindClientKeyReferences(profiles)
+
+	assert.Equal(t, []ClientKeyReference{{Profile: "prod", Tenant: "acme"}}, refs["tenant-key"])
+	require.Len(t, refs["web-key"], 3)
+	assert.Equal(t, "dev:acme", refs["web-key"][0].String())
+	assert.Equal(t, "prod:acme/web", refs["web-key"][1].String())
+	assert.Equal(t, "prod/eu:acme", refs["web-key"][2].String())
+}
+
+func TestListAPIKeyUsage(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/keys", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"clientId": "id-b", "name": "b-key", "enabled": true, "admin": false, "projects": ["web"], "lastUsedAt": "2026-09-01T10:00:00Z"},
+			{"clientId": "id-a", "name": "a-key", "enabled": false, "admin": true}
+		]`))
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	profiles := map[string]*Profile{
+		"prod": {ClientKeys: map[string]TenantClientKeysConfig{"acme": {ClientID: "id-b"}}},
+	}
+	usages, err := ListAPIKeyUsage(client, context.Background(), "acme", profiles)
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+
+	assert.Equal(t, "a-key", usages[0].Name)
+	assert.Empty(t, usages[0].References)
+	view := usages[0].ToTableView()
+	assert.Equal(t, "all (admin)", view.Projects)
+	assert.Equal(t, "unknown", view.LastUsed)
+	assert.Equal(t, "-", view.References)
+
+	assert.Equal(t, "b-key", usages[1].Name)
+	assert.Equal(t, "2026-09-01T10:00:00Z", usages[1].LastUsed)
+	view = usages[1].ToTableView()
+	assert.Equal(t, "web", view.Projects)
+	assert.Equal(t, "prod:acme", view.References)
+}
@@ -0,0 +1,243 @@
+package izanami
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// DECLARATIVE MANIFESTS
+// ============================================================================
+
+// Manifest declares the desired state of a tenant's tags, contexts and features
+type Manifest struct {
+	Tenant   string            `yaml:"tenant,omitempty" json:"tenant,omitempty"`
+	Tags     []ManifestTag     `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Contexts []ManifestContext `yaml:"contexts,omitempty" json:"contexts,omitempty"`
+	Features []ManifestFeature `yaml:"features,omitempty" json:"features,omitempty"`
+}
+
+// ManifestTag declares a tag
+type ManifestTag struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// ManifestContext declares a context. Contexts without a project are global.
+type ManifestContext struct {
+	Path      string `yaml:"path" json:"path"`
+	Project   string `yaml:"project,omitempty" json:"project,omitempty"`
+	Protected bool   `yaml:"protected,omitempty" json:"protected,omitempty"`
+}
+
+// ManifestFeature declares a feature. Omitted fields take their default value:
+// disabled, boolean result, no tags, conditions or metadata.
+type ManifestFeature struct {
+	Name        string                 `yaml:"name" json:"name"`
+	Project     string                 `yaml:"project" json:"project"`
+	Enabled     bool                   `yaml:"enabled" json:"enabled"`
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Tags        []string               `yaml:"tags,omitempty" json:"tags,omitempty"`
+	ResultType  string                 `yaml:"resultType,omitempty" json:"resultType,omitempty"`
+	Value       interface{}            `yaml:"value,omitempty" json:"value,omitempty"`
+	Conditions  []interface{}          `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+	Metadata    map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+// Key returns the project-qualified name of the feature
+func (f ManifestFeature) Key() string {
+	return f.Project + "/" + f.Name
+}
+
+// Key returns the scope-qualified path of the context
+func (c ManifestContext) Key() string {
+	return contextScope(c.Project) + ":" + c.Path
+}
+
+// contextScope names the scope of a context: its project, or "global"
+func contextScope(project string) string {
+	if project == "" {
+		return "global"
+	}
+	return project
+}
+
+// ParseManifest parses a YAML or JSON manifest
+func ParseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// LoadManifests reads and merges manifests from files and directories.
+// Directories are read non-recursively for .yaml, .yml and .json files; "-" reads stdin.
+func LoadManifests(paths []string, stdin io.Reader) (*Manifest, error) {
+	var files []string
+	for _, path := range paths {
+		if path == "-" {
+			files = append(files, path)
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no manifest files found")
+	}
+
+	merged := &Manifest{}
+	for _, file := range files {
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifest, err := ParseManifest(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest %s: %w", file, err)
+		}
+		if manifest.Tenant != "" {
+			if merged.Tenant != "" && merged.Tenant != manifest.Tenant {
+				return nil, fmt.Errorf("manifest %s targets tenant %s but another manifest targets %s", file, manifest.Tenant, merged.Tenant)
+			}
+			merged.Tenant = manifest.Tenant
+		}
+		merged.Tags = append(merged.Tags, manifest.Tags...)
+		merged.Contexts = append(merged.Contexts, manifest.Contexts...)
+		merged.Features = append(merged.Features, manifest.Features...)
+	}
+
+	if err := merged.Validate(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// Validate checks required fields and rejects resources declared twice
+func (m *Manifest) Validate() error {
+	var problems []string
+
+	tags := make(map[string]bool)
+	for i, tag := range m.Tags {
+		switch {
+		case tag.Name == "":
+			problems = append(problems, fmt.Sprintf("tags[%d]: name is required", i))
+		case tags[tag.Name]:
+			problems = append(problems, fmt.Sprintf("tag %s is declared more than once", tag.Name))
+		}
+		tags[tag.Name] = true
+	}
+
+	contexts := make(map[string]bool)
+	for i := range m.Contexts {
+		declared := &m.Contexts[i]
+		declared.Path = strings.Trim(declared.Path, "/")
+		switch {
+		case declared.Path == "":
+			problems = append(problems, fmt.Sprintf("contexts[%d]: path is required", i))
+		case contexts[declared.Key()]:
+			problems = append(problems, fmt.Sprintf("context %s is declared more than once", declared.Key()))
+		}
+		contexts[declared.Key()] = true
+	}
+
+	features := make(map[string]bool)
+	for i, feature := range m.Features {
+		switch {
+		case feature.Name == "" || feature.Project == "":
+			problems = append(problems, fmt.Sprintf("features[%d]: name and project are required", i))
+		case features[feature.Key()]:
+			problems = append(problems, fmt.Sprintf("feature %s is declared more than once", feature.Key()))
+		}
+		features[feature.Key()] = true
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid manifest:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// payload returns the request body creating or replacing the feature, applying defaults
+func (f ManifestFeature) payload() map[string]interface{} {
+	resultType := f.ResultType
+	if resultType == "" {
+		resultType = "boolean"
+	}
+	payload := map[string]interface{}{
+		"name":        f.Name,
+		"project":     f.Project,
+		"enabled":     f.Enabled,
+		"description": f.Description,
+		"resultType":  resultType,
+		"conditions":  normalizeManifestValue(f.Conditions),
+		"metadata":    normalizeManifestValue(f.Metadata),
+		"tags":        f.Tags,
+	}
+	if payload["conditions"] == nil {
+		payload["conditions"] = []interface{}{}
+	}
+	if payload["metadata"] == nil {
+		payload["metadata"] = map[string]interface{}{}
+	}
+	if f.Tags == nil {
+		payload["tags"] = []string{}
+	}
+	if f.Value != nil {
+		payload["value"] = normalizeManifestValue(f.Value)
+	}
+	return payload
+}
+
+// normalizeManifestValue round-trips a decoded YAML value through JSON so that it
+// compares equal to the same value returned by the API
+func normalizeManifestValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
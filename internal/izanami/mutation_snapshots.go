@@ -0,0 +1,288 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// MUTATION SNAPSHOTS (iz snapshots / iz rollback)
+// ============================================================================
+
+// mutationSnapshotIDLayout formats the creation time at the start of snapshot IDs,
+// so that IDs sort by date
+const mutationSnapshotIDLayout = "20060102-150405"
+
+// MutationSnapshot is the state of the projects affected by a bulk change,
+// saved before the change so that it can be rolled back
+type MutationSnapshot struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	// Command is the command that made the change, e.g. "admin features toggle"
+	Command   string `json:"command"`
+	LeaderURL string `json:"leaderUrl"`
+	Tenant    string `json:"tenant"`
+	// Prune deletes, on rollback, the features and overloads the change created
+	Prune    bool               `json:"prune"`
+	Projects []*ProjectSnapshot `json:"projects"`
+	// RolledBackAt is set once the snapshot has been restored
+	RolledBackAt string `json:"rolledBackAt,omitempty"`
+}
+
+// MutationSnapshotSummary describes a saved snapshot for listing
+type MutationSnapshotSummary struct {
+	ID         string `json:"id"`
+	CreatedAt  string `json:"createdAt"`
+	Command    string `json:"command"`
+	Tenant     string `json:"tenant"`
+	Projects   string `json:"projects"`
+	Features   int    `json:"features"`
+	RolledBack bool   `json:"rolledBack"`
+}
+
+// Summary returns the listing summary of a snapshot
+func (s *MutationSnapshot) Summary() MutationSnapshotSummary {
+	summary := MutationSnapshotSummary{ID: s.ID, CreatedAt: s.CreatedAt, Command: s.Command, Tenant: s.Tenant, RolledBack: s.RolledBackAt != ""}
+	projects := make([]string, len(s.Projects))
+	for i, project := range s.Projects {
+		projects[i] = project.Project
+		summary.Features += len(project.Features)
+	}
+	summary.Projects = strings.Join(projects, ", ")
+	return summary
+}
+
+// mutationSnapshotsDir returns the directory of the saved snapshots
+func mutationSnapshotsDir() string {
+	return filepath.Join(getConfigDir(), "snapshots")
+}
+
+// SaveMutationSnapshot captures the projects of a tenant about to be changed by
+// a bulk command and saves them in the snapshots directory of the config dir
+func SaveMutationSnapshot(c *AdminClient, ctx context.Context, tenant, command string, projects []string, prune bool) (*MutationSnapshot, error) {
+	now := time.Now().UTC()
+	snapshot := &MutationSnapshot{
+		ID:        now.Format(mutationSnapshotIDLayout) + "-" + strings.ReplaceAll(strings.TrimPrefix(command, "admin "), " ", "-"),
+		CreatedAt: now.Format(time.RFC3339),
+		Command:   command,
+		LeaderURL: c.config.LeaderURL,
+		Tenant:    tenant,
+		Prune:     prune,
+		Projects:  []*ProjectSnapshot{},
+	}
+	seen := make(map[string]bool)
+	sorted := make([]string, 0, len(projects))
+	for _, project := range projects {
+		if project != "" && !seen[project] {
+			seen[project] = true
+			sorted = append(sorted, project)
+		}
+	}
+	sort.Strings(sorted)
+	for _, project := range sorted {
+		captured, err := SnapshotProject(c, ctx, tenant, project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot project %s: %w", project, err)
+		}
+		snapshot.Projects = append(snapshot.Projects, captured)
+	}
+
+	// Two changes in the same second get distinct IDs
+	base := snapshot.ID
+	for i := 2; ; i++ {
+		if _, err := os.Stat(mutationSnapshotPath(snapshot.ID)); os.IsNotExist(err) {
+			break
+		}
+		snapshot.ID = fmt.Sprintf("%s-%d", base, i)
+	}
+	if err := writeMutationSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// TenantProjects returns the names of the projects of a tenant
+func TenantProjects(c *AdminClient, ctx context.Context, tenant string) ([]string, error) {
+	projects, err := ListProjects(c, ctx, tenant, ParseProjects)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(projects))
+	for i, project := range projects {
+		names[i] = project.Name
+	}
+	return names, nil
+}
+
+// PatchedProjects returns the projects a features patch (iz admin features
+// patch) affects: those of the patched features and the projects features are
+// moved to. moves reports whether the patch moves features between projects.
+func PatchedProjects(features []Feature, patches interface{}) (projects []string, moves bool) {
+	projectOf := make(map[string]string, len(features))
+	for _, feature := range features {
+		projectOf[feature.ID] = feature.Project
+	}
+	operations, _ := patches.([]interface{})
+	for _, item := range operations {
+		operation, _ := item.(map[string]interface{})
+		path, _ := operation["path"].(string)
+		id, field, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		if project, ok := projectOf[id]; ok {
+			projects = append(projects, project)
+		}
+		if destination, ok := operation["value"].(string); ok && field == "project" {
+			projects = append(projects, destination)
+			moves = true
+		}
+	}
+	return projects, moves
+}
+
+// mutationSnapshotPath returns the file of a snapshot
+func mutationSnapshotPath(id string) string {
+	return filepath.Join(mutationSnapshotsDir(), id+".json")
+}
+
+// writeMutationSnapshot saves a snapshot, readable by the user only
+func writeMutationSnapshot(snapshot *MutationSnapshot) error {
+	if err := os.MkdirAll(mutationSnapshotsDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize snapshot: %w", err)
+	}
+	if err := os.WriteFile(mutationSnapshotPath(snapshot.ID), data, 0600); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadMutationSnapshot reads a saved snapshot by ID
+func LoadMutationSnapshot(id string) (*MutationSnapshot, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid snapshot ID %q", id)
+	}
+	data, err := os.ReadFile(mutationSnapshotPath(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("snapshot %s not found (see 'iz snapshots list')", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snapshot MutationSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", id, err)
+	}
+	return &snapshot, nil
+}
+
+// ListMutationSnapshots returns the saved snapshots, newest first
+func ListMutationSnapshots() ([]*MutationSnapshot, error) {
+	entries, err := os.ReadDir(mutationSnapshotsDir())
+	if os.IsNotExist(err) {
+		return []*MutationSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots directory: %w", err)
+	}
+	snapshots := []*MutationSnapshot{}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		snapshot, err := LoadMutationSnapshot(id)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].ID > snapshots[j].ID })
+	return snapshots, nil
+}
+
+// LastMutationSnapshot returns the newest snapshot not rolled back yet
+func LastMutationSnapshot() (*MutationSnapshot, error) {
+	snapshots, err := ListMutationSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.RolledBackAt == "" {
+			return snapshot, nil
+		}
+	}
+	return nil, fmt.Errorf("no snapshot to roll back to (bulk changes save one before they run)")
+}
+
+// PruneMutationSnapshots deletes the snapshots beyond the keep newest ones and,
+// when olderThan is positive, those older than it. It returns the deleted IDs.
+func PruneMutationSnapshots(keep int, olderThan time.Duration, now time.Time) ([]string, error) {
+	snapshots, err := ListMutationSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	deleted := []string{}
+	for i, snapshot := range snapshots {
+		expired := false
+		if createdAt, err := time.Parse(time.RFC3339, snapshot.CreatedAt); err == nil && olderThan > 0 {
+			expired = now.Sub(createdAt) > olderThan
+		}
+		if (keep < 0 || i < keep) && !expired {
+			continue
+		}
+		if err := os.Remove(mutationSnapshotPath(snapshot.ID)); err != nil {
+			return deleted, fmt.Errorf("failed to delete snapshot %s: %w", snapshot.ID, err)
+		}
+		deleted = append(deleted, snapshot.ID)
+	}
+	return deleted, nil
+}
+
+// BuildRollbackPlan plans the changes restoring the projects of a snapshot:
+// features and overloads changed since are restored and, for snapshots with
+// Prune, those created since are deleted. Contexts and tags are left as they are.
+func BuildRollbackPlan(c *AdminClient, ctx context.Context, snapshot *MutationSnapshot) (*Plan, error) {
+	if snapshot.LeaderURL != "" && strings.TrimRight(snapshot.LeaderURL, "/") != strings.TrimRight(c.config.LeaderURL, "/") {
+		return nil, fmt.Errorf("snapshot %s was taken on %s, not %s: select its server with --profile or --url", snapshot.ID, snapshot.LeaderURL, c.config.LeaderURL)
+	}
+	plan := &Plan{Tenant: snapshot.Tenant, Changes: []PlannedChange{}}
+	for _, project := range snapshot.Projects {
+		restore, err := BuildRestorePlan(c, ctx, snapshot.Tenant, project, project.Project, snapshot.Prune)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range restore.Changes {
+			if change.Kind == PlanKindContext || change.Kind == PlanKindTag {
+				continue
+			}
+			change.Name = qualifyRollbackChange(project.Project, change)
+			plan.Changes = append(plan.Changes, change)
+		}
+		plan.Warnings = append(plan.Warnings, restore.Warnings...)
+	}
+	return plan, nil
+}
+
+// qualifyRollbackChange prefixes overload changes with their project, as
+// feature changes already are
+func qualifyRollbackChange(project string, change PlannedChange) string {
+	if change.Kind == PlanKindOverload {
+		return project + "/" + change.Name
+	}
+	return change.Name
+}
+
+// MarkRolledBack records that a snapshot has been restored, so that
+// 'rollback --last' moves on to the previous one
+func MarkRolledBack(snapshot *MutationSnapshot, at time.Time) error {
+	snapshot.RolledBackAt = at.UTC().Format(time.RFC3339)
+	return writeMutationSnapshot(snapshot)
+}
@@ -0,0 +1,153 @@
+package izanami
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempSnapshotsDir points the config dir, and so the snapshots directory, to a temp dir
+func useTempSnapshotsDir(t *testing.T) {
+	t.Helper()
+	tempDir := t.TempDir()
+	originalGetConfigDir := getConfigDir
+	t.Cleanup(func() { getConfigDir = originalGetConfigDir })
+	getConfigDir = func() string { return tempDir }
+}
+
+func TestSaveMutationSnapshot(t *testing.T) {
+	useTempSnapshotsDir(t)
+	client, _, _ := snapshotServer(t)
+	ctx := context.Background()
+
+	snapshot, err := SaveMutationSnapshot(client, ctx, "acme", "admin features toggle", []string{"shop", "", "shop"}, false)
+	require.NoError(t, err)
+	assert.Regexp(t, `^\d{8}-\d{6}-features-toggle$`, snapshot.ID)
+	assert.Equal(t, client.config.LeaderURL, snapshot.LeaderURL)
+	require.Len(t, snapshot.Projects, 1)
+	assert.Len(t, snapshot.Projects[0].Features, 2)
+
+	info, err := os.Stat(mutationSnapshotPath(snapshot.ID))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A second snapshot in the same second gets a distinct ID
+	second, err := SaveMutationSnapshot(client, ctx, "acme", "admin features toggle", []string{"shop"}, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, snapshot.ID, second.ID)
+
+	snapshots, err := ListMutationSnapshots()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, MutationSnapshotSummary{
+		ID: snapshot.ID, CreatedAt: snapshot.CreatedAt, Command: "admin features toggle", Tenant: "acme", Projects: "shop", Features: 2,
+	}, snapshots[1].Summary())
+
+	loaded, err := LoadMutationSnapshot(snapshot.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Projects, 1)
+	assert.Equal(t, "checkout", loaded.Projects[0].Features[0].Name)
+	assert.Equal(t, "search", loaded.Projects[0].Features[1].Name)
+
+	_, err = LoadMutationSnapshot("../config")
+	assert.ErrorContains(t, err, "invalid snapshot ID")
+	_, err = LoadMutationSnapshot("missing")
+	assert.ErrorContains(t, err, "snapshot missing not found")
+}
+
+func TestLastMutationSnapshot_SkipsRolledBack(t *testing.T) {
+	useTempSnapshotsDir(t)
+
+	_, err := LastMutationSnapshot()
+	assert.ErrorContains(t, err, "no snapshot to roll back to")
+
+	older := &MutationSnapshot{ID: "20261001-100000-apply", CreatedAt: "2026-10-01T10:00:00Z"}
+	newer := &MutationSnapshot{ID: "20261002-100000-apply", CreatedAt: "2026-10-02T10:00:00Z"}
+	require.NoError(t, writeMutationSnapshot(older))
+	require.NoError(t, writeMutationSnapshot(newer))
+
+	last, err := LastMutationSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, newer.ID, last.ID)
+
+	require.NoError(t, MarkRolledBack(last, time.Now()))
+	last, err = LastMutationSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, older.ID, last.ID)
+}
+
+func TestPruneMutationSnapshots(t *testing.T) {
+	useTempSnapshotsDir(t)
+	for _, id := range []string{"20261001-100000-apply", "20261010-100000-apply", "20261015-100000-apply"} {
+		createdAt, _ := time.Parse(mutationSnapshotIDLayout, id[:15])
+		require.NoError(t, writeMutationSnapshot(&MutationSnapshot{ID: id, CreatedAt: createdAt.Format(time.RFC3339)}))
+	}
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	deleted, err := PruneMutationSnapshots(-1, 10*24*time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20261001-100000-apply"}, deleted)
+
+	deleted, err = PruneMutationSnapshots(1, 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20261010-100000-apply"}, deleted)
+
+	snapshots, err := ListMutationSnapshots()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "20261015-100000-apply", snapshots[0].ID)
+}
+
+func TestBuildRollbackPlan(t *testing.T) {
+	useTempSnapshotsDir(t)
+	client, writes, _ := snapshotServer(t)
+	ctx := context.Background()
+
+	snapshot, err := SaveMutationSnapshot(client, ctx, "acme", "apply", []string{"shop"}, true)
+	require.NoError(t, err)
+
+	// Nothing changed since the snapshot
+	plan, err := BuildRollbackPlan(client, ctx, snapshot)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+
+	// Since the snapshot, checkout was enabled in prod and search was created;
+	// contexts are never rolled back
+	snapshot.Projects[0].Overloads[0].Strategy["enabled"] = true
+	snapshot.Projects[0].Features = snapshot.Projects[0].Features[:1]
+	snapshot.Projects[0].Contexts = nil
+	plan, err = BuildRollbackPlan(client, ctx, snapshot)
+	require.NoError(t, err)
+	var summary []string
+	for _, change := range plan.Changes {
+		summary = append(summary, change.Action+" "+change.Kind+" "+change.Name)
+	}
+	assert.Equal(t, []string{"update overload shop/checkout@prod", "delete feature shop/search"}, summary)
+	assert.Empty(t, *writes)
+
+	snapshot.LeaderURL = "https://other.example.com"
+	_, err = BuildRollbackPlan(client, ctx, snapshot)
+	assert.ErrorContains(t, err, "was taken on https://other.example.com")
+}
+
+func TestPatchedProjects(t *testing.T) {
+	features := []Feature{{ID: "f1", Project: "shop"}, {ID: "f2", Project: "web"}}
+
+	projects, moves := PatchedProjects(features, []interface{}{
+		map[string]interface{}{"op": "replace", "path": "/f1/enabled", "value": false},
+	})
+	assert.Equal(t, []string{"shop"}, projects)
+	assert.False(t, moves)
+
+	projects, moves = PatchedProjects(features, []interface{}{
+		map[string]interface{}{"op": "replace", "path": "/f2/project", "value": "mobile"},
+		map[string]interface{}{"op": "remove", "path": "/f1"},
+		map[string]interface{}{"op": "remove", "path": "/unknown"},
+	})
+	assert.Equal(t, []string{"web", "mobile", "shop"}, projects)
+	assert.True(t, moves)
+}
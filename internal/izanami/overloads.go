@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
 )
@@ -33,7 +34,7 @@ func (c *AdminClient) SetOverload(ctx context.Context, tenant, project, contextP
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToSetOverload, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToSetOverload), err)
 	}
 
 	if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusOK {
@@ -59,7 +60,7 @@ func (c *AdminClient) DeleteOverload(ctx context.Context, tenant, project, conte
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteOverload, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteOverload), err)
 	}
 
 	if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusOK {
@@ -91,25 +92,25 @@ func (c *AdminClient) getOverloadRaw(ctx context.Context, tenant, project, featu
 	// Fetch the context tree for the project
 	contextsRaw, err := c.listContextsRaw(ctx, tenant, project, true) // all=true to get nested contexts
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetOverload, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetOverload), err)
 	}
 
 	// Parse the context tree
 	var contexts []Context
 	if err := json.Unmarshal(contextsRaw, &contexts); err != nil {
-		return nil, fmt.Errorf("%s: failed to parse context tree: %w", errmsg.MsgFailedToGetOverload, err)
+		return nil, fmt.Errorf("%s: failed to parse context tree: %w", errmsg.T(errmsg.MsgFailedToGetOverload), err)
 	}
 
 	// Find the context at the specified path and get the overload
 	overload := findOverloadInContextTree(contexts, contextPath, featureName, "")
 	if overload == nil {
-		return nil, fmt.Errorf("%s: no overload found at context path '%s' for feature '%s'", errmsg.MsgFailedToGetOverload, contextPath, featureName)
+		return nil, fmt.Errorf("%s: no overload found at context path '%s' for feature '%s'", errmsg.T(errmsg.MsgFailedToGetOverload), contextPath, featureName)
 	}
 
 	// Convert the overload to JSON
 	overloadBytes, err := json.Marshal(overload)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to serialize overload: %w", errmsg.MsgFailedToGetOverload, err)
+		return nil, fmt.Errorf("%s: failed to serialize overload: %w", errmsg.T(errmsg.MsgFailedToGetOverload), err)
 	}
 
 	return overloadBytes, nil
@@ -156,3 +157,55 @@ func contextsToSlice(ptrs []*Context) []Context {
 	}
 	return result
 }
+
+// ContextStrategy returns the strategy a feature has in a context: its overload
+// in the context, else its overload in the closest parent context, else the
+// strategy of the feature itself. from is the path of the context the strategy
+// comes from, "" when it is the feature's. It fails when the context does not
+// exist in the project.
+func ContextStrategy(c *AdminClient, ctx context.Context, tenant, project, featureID, featureName, path string) (strategy map[string]interface{}, from string, err error) {
+	contexts, err := ListContexts(c, ctx, tenant, project, true, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return nil, "", err
+	}
+	strategy, from, err = inheritedOverload(contexts, path, project, featureName)
+	if err != nil || strategy != nil {
+		return strategy, from, err
+	}
+	feature, err := GetFeature(c, ctx, tenant, featureID, Unmarshal[map[string]interface{}]())
+	if err != nil {
+		return nil, "", err
+	}
+	return overloadStrategy(feature), "", nil
+}
+
+// inheritedOverload walks the context tree down to path and returns the
+// strategy of the deepest overload of the feature on the way, with the path of
+// its context
+func inheritedOverload(contexts []*snapshotContextNode, path, project, featureName string) (strategy map[string]interface{}, from string, err error) {
+	current := ""
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		var node *snapshotContextNode
+		for _, c := range contexts {
+			if c != nil && c.Name == segment {
+				node = c
+				break
+			}
+		}
+		if current != "" {
+			current += "/"
+		}
+		current += segment
+		if node == nil {
+			return nil, "", fmt.Errorf("context '%s' not found in project '%s'", current, project)
+		}
+		for _, overload := range node.Overloads {
+			overloadProject, _ := overload["project"].(string)
+			if overload["name"] == featureName && (overloadProject == "" || overloadProject == project) {
+				strategy, from = overloadStrategy(overload), current
+			}
+		}
+		contexts = node.Children
+	}
+	return strategy, from, nil
+}
@@ -711,3 +711,37 @@ func Test_contextsToSlice(t *testing.T) {
 		})
 	}
 }
+
+func Test_inheritedOverload(t *testing.T) {
+	var contexts []*snapshotContextNode
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name": "prod", "overloads": [
+			{"name": "checkout", "project": "shop", "enabled": false, "resultType": "boolean", "conditions": [{"rule": {"type": "UserList", "users": ["bob"]}}]},
+			{"name": "checkout", "project": "other", "enabled": true}
+		], "children": [
+			{"name": "eu", "children": [{"name": "fr", "overloads": [{"name": "checkout", "enabled": true, "resultType": "boolean"}]}]}
+		]},
+		{"name": "dev"}
+	]`), &contexts))
+
+	// The deepest overload on the path wins
+	strategy, from, err := inheritedOverload(contexts, "prod/eu/fr", "shop", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "prod/eu/fr", from)
+	assert.Equal(t, map[string]interface{}{"enabled": true, "resultType": "boolean"}, strategy)
+
+	// Without an overload of its own, a context inherits its parent's
+	strategy, from, err = inheritedOverload(contexts, "/prod/eu/", "shop", "checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", from)
+	assert.Equal(t, false, strategy["enabled"])
+	assert.Len(t, strategy["conditions"], 1)
+
+	strategy, from, err = inheritedOverload(contexts, "dev", "shop", "checkout")
+	require.NoError(t, err)
+	assert.Nil(t, strategy)
+	assert.Empty(t, from)
+
+	_, _, err = inheritedOverload(contexts, "prod/us", "shop", "checkout")
+	assert.EqualError(t, err, "context 'prod/us' not found in project 'shop'")
+}
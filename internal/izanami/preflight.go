@@ -0,0 +1,136 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+)
+
+// ============================================================================
+// PERMISSION PREFLIGHT
+// ============================================================================
+
+// Scopes of a right requirement
+const (
+	RightScopeAdmin   = "admin"
+	RightScopeTenant  = "tenant"
+	RightScopeProject = "project"
+)
+
+// RightRequirement is the right a user needs to call an admin route
+type RightRequirement struct {
+	Scope string `json:"scope"`
+	Level string `json:"level,omitempty"`
+}
+
+// routeRightRule maps a mutating route to the right it requires
+type routeRightRule struct {
+	method      string
+	path        string
+	requirement RightRequirement
+}
+
+// routeRightRules lists the rights required by mutating admin routes, as in
+// command "route" annotations. The lowest level Izanami accepts is used, so
+// that a preflight never rejects a call the server would allow. Routes not
+// listed (keys, webhooks and feature updates by ID, whose right depends on the
+// resource) are not checked.
+var routeRightRules = []routeRightRule{
+	{"POST", "/api/admin/tenants", RightRequirement{RightScopeAdmin, ""}},
+	{"PUT", "/api/admin/tenants/:name", RightRequirement{RightScopeTenant, "Admin"}},
+	{"DELETE", "/api/admin/tenants/:name", RightRequirement{RightScopeTenant, "Admin"}},
+	{"POST", "/api/admin/users", RightRequirement{RightScopeAdmin, ""}},
+	{"DELETE", "/api/admin/users/:user", RightRequirement{RightScopeAdmin, ""}},
+	{"PUT", "/api/admin/users/:user/rights", RightRequirement{RightScopeAdmin, ""}},
+	{"POST", "/api/admin/tenants/:tenant/projects", RightRequirement{RightScopeTenant, "Write"}},
+	{"PUT", "/api/admin/tenants/:tenant/projects/:project", RightRequirement{RightScopeProject, "Admin"}},
+	{"DELETE", "/api/admin/tenants/:tenant/projects/:project", RightRequirement{RightScopeProject, "Admin"}},
+	{"POST", "/api/admin/tenants/:tenant/projects/:project/features", RightRequirement{RightScopeProject, "Write"}},
+	{"POST", "/api/admin/tenants/:tenant/projects/:project/contexts", RightRequirement{RightScopeProject, "Write"}},
+	{"PUT", "/api/admin/tenants/:tenant/projects/:project/contexts/:context/features/:name", RightRequirement{RightScopeProject, "Update"}},
+	{"DELETE", "/api/admin/tenants/:tenant/projects/:project/contexts/:context/features/:name", RightRequirement{RightScopeProject, "Update"}},
+	{"PUT", "/api/admin/tenants/:tenant/projects/:project/users/:user/rights", RightRequirement{RightScopeProject, "Admin"}},
+	{"POST", "/api/admin/tenants/:tenant/projects/:project/users/_invitation", RightRequirement{RightScopeProject, "Admin"}},
+	{"POST", "/api/admin/tenants/:tenant/tags", RightRequirement{RightScopeTenant, "Write"}},
+	{"PUT", "/api/admin/tenants/:tenant/tags/:name", RightRequirement{RightScopeTenant, "Write"}},
+	{"DELETE", "/api/admin/tenants/:tenant/tags/:name", RightRequirement{RightScopeTenant, "Write"}},
+	{"POST", "/api/admin/tenants/:tenant/contexts", RightRequirement{RightScopeTenant, "Write"}},
+	{"PUT", "/api/admin/tenants/:tenant/contexts/:path", RightRequirement{RightScopeTenant, "Write"}},
+	{"DELETE", "/api/admin/tenants/:tenant/contexts/:path", RightRequirement{RightScopeTenant, "Write"}},
+	{"POST", "/api/admin/tenants/:tenant/keys", RightRequirement{RightScopeTenant, "Write"}},
+	{"POST", "/api/admin/tenants/:tenant/webhooks", RightRequirement{RightScopeTenant, "Write"}},
+	{"PUT", "/api/admin/tenants/:tenant/users/:user/rights", RightRequirement{RightScopeTenant, "Admin"}},
+	{"POST", "/api/admin/tenants/:tenant/users/_invitation", RightRequirement{RightScopeTenant, "Admin"}},
+	{"POST", "/api/admin/tenants/:tenant/_import", RightRequirement{RightScopeTenant, "Write"}},
+}
+
+// RightRequirementForRoute returns the right a command route ("PUT /api/admin/...")
+// requires, nil when the route is not checked
+func RightRequirementForRoute(route string) *RightRequirement {
+	method, path, ok := strings.Cut(route, " ")
+	if !ok {
+		return nil
+	}
+	for i := range routeRightRules {
+		if routeRightRules[i].method == method && routeRightRules[i].path == path {
+			return &routeRightRules[i].requirement
+		}
+	}
+	return nil
+}
+
+// GetCurrentUserRights retrieves the authenticated user with its rights
+func (c *AdminClient) GetCurrentUserRights(ctx context.Context) (*User, error) {
+	path := "/api/admin/users/rights"
+
+	var user User
+	req := c.http.R().SetContext(ctx).SetResult(&user)
+	c.setAdminAuth(req)
+	resp, err := req.Get(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetUser), err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, c.handleError(resp)
+	}
+
+	return &user, nil
+}
+
+// CheckRight returns an actionable error when user lacks the required right on
+// tenant, or on project for project requirements. Tenant admins hold every
+// right of their tenant, and a project without an explicit right falls back to
+// the default project right of the tenant.
+func (r RightRequirement) CheckRight(user *User, tenant, project string) error {
+	if user.Admin {
+		return nil
+	}
+	if r.Scope == RightScopeAdmin {
+		return fmt.Errorf("global admin rights are required; %s is not an admin", user.Username)
+	}
+
+	right := user.Rights.Tenants[tenant]
+	if right.Level == string(RightLevelAdmin) {
+		return nil
+	}
+	have, target := right.Level, "tenant "+tenant
+	if r.Scope == RightScopeProject {
+		target = "project " + project
+		have = right.Projects[project].Level
+		if have == "" && right.DefaultProjectRight != nil {
+			have = *right.DefaultProjectRight
+		}
+	}
+	if rightLevelRank[have] >= rightLevelRank[r.Level] {
+		return nil
+	}
+	if have == "" {
+		return fmt.Errorf("your %s right on %s is required; you have no right on it", r.Level, target)
+	}
+	return fmt.Errorf("your %s right on %s is required; you have %s", r.Level, target, have)
+}
@@ -0,0 +1,56 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRightRequirementForRoute(t *testing.T) {
+	assert.Equal(t, &RightRequirement{Scope: RightScopeProject, Level: "Write"}, RightRequirementForRoute("POST /api/admin/tenants/:tenant/projects/:project/features"))
+	assert.Equal(t, &RightRequirement{Scope: RightScopeAdmin}, RightRequirementForRoute("POST /api/admin/tenants"))
+	assert.Nil(t, RightRequirementForRoute("GET /api/admin/tenants/:tenant/projects/:project/features"))
+	assert.Nil(t, RightRequirementForRoute("PUT /api/admin/tenants/:tenant/features/:id"))
+	assert.Nil(t, RightRequirementForRoute(""))
+}
+
+func TestRightRequirement_CheckRight(t *testing.T) {
+	read := "Read"
+	user := &User{Username: "bob", Rights: UserRights{Tenants: map[string]TenantRight{
+		"acme":  {Level: "Read", Projects: map[string]ProjectRight{"shop": {Level: "Update"}}, DefaultProjectRight: &read},
+		"other": {Level: "Admin"},
+	}}}
+	writeProject := RightRequirement{Scope: RightScopeProject, Level: "Write"}
+
+	assert.EqualError(t, writeProject.CheckRight(user, "acme", "shop"), "your Write right on project shop is required; you have Update")
+	assert.NoError(t, RightRequirement{Scope: RightScopeProject, Level: "Update"}.CheckRight(user, "acme", "shop"))
+	assert.EqualError(t, writeProject.CheckRight(user, "acme", "web"), "your Write right on project web is required; you have Read")
+	assert.NoError(t, writeProject.CheckRight(user, "other", "web"))
+	assert.EqualError(t, RightRequirement{Scope: RightScopeTenant, Level: "Write"}.CheckRight(user, "acme", ""), "your Write right on tenant acme is required; you have Read")
+	assert.EqualError(t, RightRequirement{Scope: RightScopeTenant, Level: "Write"}.CheckRight(user, "unknown", ""), "your Write right on tenant unknown is required; you have no right on it")
+	assert.EqualError(t, RightRequirement{Scope: RightScopeAdmin}.CheckRight(user, "", ""), "global admin rights are required; bob is not an admin")
+
+	user.Admin = true
+	assert.NoError(t, RightRequirement{Scope: RightScopeAdmin}.CheckRight(user, "", ""))
+}
+
+func TestGetCurrentUserRights(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/users/rights", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"username": "bob", "admin": false, "rights": {"tenants": {"acme": {"level": "Write"}}}}`)
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	user, err := client.GetCurrentUserRights(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "bob", user.Username)
+	assert.Equal(t, "Write", user.Rights.Tenants["acme"].Level)
+}
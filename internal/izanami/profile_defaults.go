@@ -0,0 +1,64 @@
+package izanami
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandDefaults returns the flag defaults of the profile for a command path,
+// without the "iz" prefix (e.g. "features check" or "admin users search").
+// Values are formatted as flag values: lists are joined with commas.
+func (p *Profile) CommandDefaults(commandPath string) map[string]string {
+	commandPath = normalizeCommandPath(commandPath)
+	for path, flags := range p.Defaults {
+		if normalizeCommandPath(path) != commandPath {
+			continue
+		}
+		defaults := make(map[string]string, len(flags))
+		for flag, value := range flags {
+			defaults[strings.TrimLeft(flag, "-")] = formatDefaultValue(value)
+		}
+		return defaults
+	}
+	return nil
+}
+
+// normalizeCommandPath lowercases a command path and collapses its spaces
+func normalizeCommandPath(path string) string {
+	return strings.ToLower(strings.Join(strings.Fields(path), " "))
+}
+
+// formatDefaultValue formats a YAML value as a flag value
+func formatDefaultValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// mergeCommandDefaults merges imported command defaults over local ones, flag by flag
+func mergeCommandDefaults(local, imported map[string]map[string]interface{}) map[string]map[string]interface{} {
+	merged := make(map[string]map[string]interface{}, len(local)+len(imported))
+	for path, flags := range local {
+		merged[path] = flags
+	}
+	for path, flags := range imported {
+		combined := make(map[string]interface{}, len(merged[path])+len(flags))
+		for flag, value := range merged[path] {
+			combined[flag] = value
+		}
+		for flag, value := range flags {
+			combined[flag] = value
+		}
+		merged[path] = combined
+	}
+	return merged
+}
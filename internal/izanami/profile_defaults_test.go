@@ -0,0 +1,35 @@
+package izanami
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_CommandDefaults(t *testing.T) {
+	profile := &Profile{Defaults: map[string]map[string]interface{}{
+		"features check":     {"conditions": true, "--context": "prod"},
+		"admin users search": {"count": 50, "tags": []interface{}{"a", "b"}},
+	}}
+
+	assert.Equal(t, map[string]string{"conditions": "true", "context": "prod"}, profile.CommandDefaults("features check"))
+	assert.Equal(t, map[string]string{"count": "50", "tags": "a,b"}, profile.CommandDefaults("admin  Users search"))
+	assert.Nil(t, profile.CommandDefaults("features"))
+	assert.Nil(t, (&Profile{}).CommandDefaults("features check"))
+}
+
+func TestProfile_DefaultsPersisted(t *testing.T) {
+	setupProfileShareTest(t, "")
+
+	require.NoError(t, AddProfile("dev", &Profile{
+		LeaderURL: "http://localhost:9000",
+		Defaults: map[string]map[string]interface{}{
+			"features check": {"conditions": true},
+		},
+	}))
+
+	profile, err := GetProfile("dev")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"conditions": "true"}, profile.CommandDefaults("features check"))
+}
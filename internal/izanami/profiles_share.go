@@ -0,0 +1,208 @@
+package izanami
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// PROFILE SHARING (export/import)
+// ============================================================================
+
+// profileExportHeader is prepended to exported profiles
+const profileExportHeader = `# Izanami CLI profiles, exported with 'iz profiles export'
+# Import with: iz profiles import <file>
+`
+
+// ProfilesFile is the format of exported profiles: the profiles section of config.yaml
+type ProfilesFile struct {
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// ProfileImportResult describes what happened to an imported profile
+type ProfileImportResult struct {
+	Name    string
+	Created bool // false when merged into an existing profile
+}
+
+// WithoutCredentials returns a copy of the profile without anything personal:
+// session reference, personal access token and username, client keys
+// (of the profile and of its workers) and the mutual TLS client certificate.
+// URLs, tenant, project, context, CA certificate, workers and command defaults
+// are kept, so the profile can be shared with a team.
+func (p *Profile) WithoutCredentials() *Profile {
+	shared := &Profile{
+		LeaderURL:          p.LeaderURL,
+		Tenant:             p.Tenant,
+		Project:            p.Project,
+		Context:            p.Context,
+		InsecureSkipVerify: p.InsecureSkipVerify,
+		CACertFile:         p.CACertFile,
+		DefaultWorker:      p.DefaultWorker,
+		Defaults:           p.Defaults,
+	}
+	if len(p.Workers) > 0 {
+		shared.Workers = make(map[string]*WorkerConfig, len(p.Workers))
+		for name, worker := range p.Workers {
+			if worker != nil {
+				shared.Workers[name] = &WorkerConfig{URL: worker.URL}
+			}
+		}
+	}
+	return shared
+}
+
+// ExportProfiles returns the named profiles as a YAML document that ImportProfiles
+// reads back. With withoutSecrets, credentials are removed (see WithoutCredentials);
+// otherwise secrets are exported decrypted.
+func ExportProfiles(names []string, withoutSecrets bool) ([]byte, error) {
+	export := ProfilesFile{Profiles: make(map[string]*Profile, len(names))}
+	for _, name := range names {
+		profile, err := GetProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		if withoutSecrets {
+			profile = profile.WithoutCredentials()
+		}
+		export.Profiles[name] = profile
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(profileExportHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(export); err != nil {
+		return nil, fmt.Errorf("failed to encode profiles: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseProfilesFile parses and validates exported profiles. Unknown keys are
+// rejected, and every profile needs a leader URL or a session reference.
+func ParseProfilesFile(data []byte) (*ProfilesFile, error) {
+	file := &ProfilesFile{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(file); err != nil {
+		return nil, fmt.Errorf("invalid profiles file: %w", err)
+	}
+	if len(file.Profiles) == 0 {
+		return nil, fmt.Errorf("invalid profiles file: no profiles found")
+	}
+	for name, profile := range file.Profiles {
+		if name == "" {
+			return nil, fmt.Errorf("invalid profiles file: empty profile name")
+		}
+		if profile == nil || (profile.LeaderURL == "" && profile.Session == "") {
+			return nil, fmt.Errorf("invalid profiles file: profile '%s' has no leader-url", name)
+		}
+	}
+	return file, nil
+}
+
+// MergeProfile merges an imported profile into an existing one: values set in the
+// imported profile replace local ones, and local values missing from the import,
+// such as personal credentials, are kept. Workers and client keys merge by name.
+func MergeProfile(existing, imported *Profile) *Profile {
+	merged := *existing
+	if imported.Session != "" {
+		merged.Session = imported.Session
+	}
+	if imported.LeaderURL != "" {
+		merged.LeaderURL = imported.LeaderURL
+	}
+	if imported.PersonalAccessTokenUsername != "" {
+		merged.PersonalAccessTokenUsername = imported.PersonalAccessTokenUsername
+	}
+	if imported.PersonalAccessToken != "" {
+		merged.PersonalAccessToken = imported.PersonalAccessToken
+	}
+	if imported.Tenant != "" {
+		merged.Tenant = imported.Tenant
+	}
+	if imported.Project != "" {
+		merged.Project = imported.Project
+	}
+	if imported.Context != "" {
+		merged.Context = imported.Context
+	}
+	if imported.InsecureSkipVerify {
+		merged.InsecureSkipVerify = true
+	}
+	if imported.CACertFile != "" {
+		merged.CACertFile = imported.CACertFile
+	}
+	if imported.DefaultWorker != "" {
+		merged.DefaultWorker = imported.DefaultWorker
+	}
+
+	if len(imported.ClientKeys) > 0 {
+		merged.ClientKeys = make(map[string]TenantClientKeysConfig, len(existing.ClientKeys)+len(imported.ClientKeys))
+		for tenant, keys := range existing.ClientKeys {
+			merged.ClientKeys[tenant] = keys
+		}
+		for tenant, keys := range imported.ClientKeys {
+			merged.ClientKeys[tenant] = keys
+		}
+	}
+
+	if len(imported.Workers) > 0 {
+		merged.Workers = make(map[string]*WorkerConfig, len(existing.Workers)+len(imported.Workers))
+		for name, worker := range existing.Workers {
+			merged.Workers[name] = worker
+		}
+		for name, worker := range imported.Workers {
+			if worker == nil {
+				continue
+			}
+			mergedWorker := &WorkerConfig{URL: worker.URL, ClientKeys: worker.ClientKeys}
+			if local := existing.Workers[name]; local != nil && len(worker.ClientKeys) == 0 {
+				mergedWorker.ClientKeys = local.ClientKeys
+			}
+			merged.Workers[name] = mergedWorker
+		}
+	}
+	if len(imported.Defaults) > 0 {
+		merged.Defaults = mergeCommandDefaults(existing.Defaults, imported.Defaults)
+	}
+	return &merged
+}
+
+// ImportProfiles adds the profiles of an exported file to the config. New profiles
+// are created as is; existing ones are merged with MergeProfile.
+// Results are sorted by profile name.
+func ImportProfiles(data []byte) ([]ProfileImportResult, error) {
+	file, err := ParseProfilesFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _, err := ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(file.Profiles))
+	for name := range file.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]ProfileImportResult, 0, len(names))
+	for _, name := range names {
+		profile := file.Profiles[name]
+		local, exists := existing[name]
+		if exists && local != nil {
+			profile = MergeProfile(local, profile)
+		}
+		if err := AddProfile(name, profile); err != nil {
+			return results, fmt.Errorf("failed to import profile '%s': %w", name, err)
+		}
+		results = append(results, ProfileImportResult{Name: name, Created: !exists})
+	}
+	return results, nil
+}
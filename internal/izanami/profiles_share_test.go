@@ -0,0 +1,133 @@
+package izanami
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profileShareTestConfig = `timeout: 30
+active_profile: prod
+profiles:
+  prod:
+    leader-url: https://izanami.example.com
+    personal-access-token-username: alice
+    personal-access-token: alice-pat
+    tenant: production
+    context: prod
+    ca-cert: /etc/ssl/corp-ca.pem
+    client-cert: /home/alice/.iz/client.pem
+    client-key: /home/alice/.iz/client-key.pem
+    client-keys:
+      production:
+        client-id: alice-id
+        client-secret: alice-secret
+    default-worker: eu
+    workers:
+      eu:
+        url: https://eu.izanami.example.com
+        client-keys:
+          production:
+            client-id: eu-id
+            client-secret: eu-secret
+`
+
+func setupProfileShareTest(t *testing.T, config string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	originalGetConfigDir := getConfigDir
+	t.Cleanup(func() { getConfigDir = originalGetConfigDir })
+	getConfigDir = func() string { return tempDir }
+	if config != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte(config), 0600))
+	}
+}
+
+func TestExportProfiles_WithoutSecrets(t *testing.T) {
+	setupProfileShareTest(t, profileShareTestConfig)
+
+	data, err := ExportProfiles([]string{"prod"}, true)
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.Contains(t, content, "# Izanami CLI profiles")
+	assert.Contains(t, content, "leader-url: https://izanami.example.com")
+	assert.Contains(t, content, "tenant: production")
+	assert.Contains(t, content, "ca-cert: /etc/ssl/corp-ca.pem")
+	assert.Contains(t, content, "url: https://eu.izanami.example.com")
+	for _, secret := range []string{"alice", "alice-pat", "alice-id", "alice-secret", "eu-id", "eu-secret", "client-keys", "client-cert"} {
+		assert.NotContains(t, content, secret)
+	}
+
+	file, err := ParseProfilesFile(data)
+	require.NoError(t, err)
+	assert.Equal(t, "eu", file.Profiles["prod"].DefaultWorker)
+
+	data, err = ExportProfiles([]string{"prod"}, false)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "personal-access-token: alice-pat")
+
+	_, err = ExportProfiles([]string{"missing"}, true)
+	assert.ErrorContains(t, err, "profile 'missing' not found")
+}
+
+func TestImportProfiles_MergeKeepsLocalCredentials(t *testing.T) {
+	setupProfileShareTest(t, profileShareTestConfig)
+
+	results, err := ImportProfiles([]byte(`profiles:
+  prod:
+    leader-url: https://new.izanami.example.com
+    project: main
+    workers:
+      eu:
+        url: https://eu2.izanami.example.com
+      us:
+        url: https://us.izanami.example.com
+  staging:
+    leader-url: https://staging.example.com
+    tenant: staging
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []ProfileImportResult{{Name: "prod", Created: false}, {Name: "staging", Created: true}}, results)
+
+	prod, err := GetProfile("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "https://new.izanami.example.com", prod.LeaderURL)
+	assert.Equal(t, "main", prod.Project)
+	assert.Equal(t, "production", prod.Tenant)
+	assert.Equal(t, "alice-pat", prod.PersonalAccessToken)
+	assert.Equal(t, "alice-secret", prod.ClientKeys["production"].ClientSecret)
+	assert.Equal(t, "https://eu2.izanami.example.com", prod.Workers["eu"].URL)
+	assert.Equal(t, "eu-secret", prod.Workers["eu"].ClientKeys["production"].ClientSecret)
+	assert.Equal(t, "https://us.izanami.example.com", prod.Workers["us"].URL)
+
+	staging, err := GetProfile("staging")
+	require.NoError(t, err)
+	assert.Equal(t, "staging", staging.Tenant)
+
+	active, err := GetActiveProfileName()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", active)
+}
+
+func TestParseProfilesFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"no profiles", "timeout: 30\n", "field timeout not found"},
+		{"empty", "profiles: {}\n", "no profiles found"},
+		{"missing url", "profiles:\n  dev:\n    tenant: t\n", "profile 'dev' has no leader-url"},
+		{"unknown key", "profiles:\n  dev:\n    url: http://localhost\n", "field url not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseProfilesFile([]byte(tt.data))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
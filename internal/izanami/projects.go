@@ -32,7 +32,7 @@ func (c *AdminClient) listProjectsRaw(ctx context.Context, tenant string) ([]byt
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListProjects, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListProjects), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -62,7 +62,7 @@ func (c *AdminClient) getProjectRaw(ctx context.Context, tenant, project string)
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetProject, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetProject), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -85,7 +85,7 @@ func (c *AdminClient) CreateProject(ctx context.Context, tenant string, project
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateProject, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateProject), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -108,7 +108,7 @@ func (c *AdminClient) UpdateProject(ctx context.Context, tenant, name string, pr
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateProject, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateProject), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -127,7 +127,7 @@ func (c *AdminClient) DeleteProject(ctx context.Context, tenant, project string)
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteProject, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteProject), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -189,7 +189,7 @@ func (c *AdminClient) listProjectLogsRaw(ctx context.Context, tenant, project st
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListProjectLogs, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListProjectLogs), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
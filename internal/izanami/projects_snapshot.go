@@ -0,0 +1,290 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ============================================================================
+// PROJECT SNAPSHOTS
+// ============================================================================
+
+// ProjectSnapshotVersion is the format version written in project snapshots
+const ProjectSnapshotVersion = 1
+
+// PlanKindOverload is the plan kind of feature overloads, used by project restores
+const PlanKindOverload = "overload"
+
+// overloadStrategyFields are the overload fields captured and restored
+var overloadStrategyFields = []string{"enabled", "resultType", "value", "conditions"}
+
+// ProjectSnapshot captures the features, overloads and tags of one project
+type ProjectSnapshot struct {
+	Version   int    `json:"version"`
+	Tenant    string `json:"tenant"`
+	Project   string `json:"project"`
+	CreatedAt string `json:"createdAt"`
+	// Tags used by the features of the project
+	Tags []ManifestTag `json:"tags,omitempty"`
+	// Contexts of the project; global contexts are not captured
+	Contexts  []ManifestContext         `json:"contexts,omitempty"`
+	Features  []ManifestFeature         `json:"features"`
+	Overloads []ProjectSnapshotOverload `json:"overloads,omitempty"`
+}
+
+// ProjectSnapshotOverload is the overload of a feature in a context
+type ProjectSnapshotOverload struct {
+	Feature  string                 `json:"feature"`
+	Context  string                 `json:"context"`
+	Strategy map[string]interface{} `json:"strategy"`
+}
+
+// Key returns the feature and context path identifying the overload
+func (o ProjectSnapshotOverload) Key() string {
+	return o.Feature + "@" + o.Context
+}
+
+// snapshotContextNode is a context with its raw overloads, so that snapshots
+// keep every strategy field the server returns
+type snapshotContextNode struct {
+	Name      string                   `json:"name"`
+	Protected bool                     `json:"protected"`
+	Global    bool                     `json:"global"`
+	Overloads []map[string]interface{} `json:"overloads,omitempty"`
+	Children  []*snapshotContextNode   `json:"children,omitempty"`
+}
+
+// SnapshotProject captures the features of a project with their overloads, the
+// project contexts and the tags used by the features
+func SnapshotProject(c *AdminClient, ctx context.Context, tenant, project string) (*ProjectSnapshot, error) {
+	snapshot := &ProjectSnapshot{
+		Version:   ProjectSnapshotVersion,
+		Tenant:    tenant,
+		Project:   project,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Features:  []ManifestFeature{},
+	}
+
+	features, err := ListFeatures(c, ctx, tenant, "", Unmarshal[[]map[string]interface{}]())
+	if err != nil {
+		return nil, err
+	}
+	featureNames := make(map[string]bool)
+	usedTags := make(map[string]bool)
+	for _, raw := range features {
+		if featureProject, _ := raw["project"].(string); featureProject != project {
+			continue
+		}
+		// The manifest fields are a subset of the API fields: round-trip through JSON
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize feature: %w", err)
+		}
+		var feature ManifestFeature
+		if err := json.Unmarshal(data, &feature); err != nil {
+			return nil, fmt.Errorf("failed to serialize feature: %w", err)
+		}
+		snapshot.Features = append(snapshot.Features, feature)
+		featureNames[feature.Name] = true
+		for _, tag := range feature.Tags {
+			usedTags[tag] = true
+		}
+	}
+	if len(snapshot.Features) == 0 {
+		if _, err := GetProject(c, ctx, tenant, project, Identity); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(snapshot.Features, func(i, j int) bool { return snapshot.Features[i].Name < snapshot.Features[j].Name })
+
+	if len(usedTags) > 0 {
+		tags, err := ListTags(c, ctx, tenant, ParseTags)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			if usedTags[tag.Name] {
+				snapshot.Tags = append(snapshot.Tags, ManifestTag{Name: tag.Name, Description: tag.Description})
+			}
+		}
+	}
+
+	contexts, err := ListContexts(c, ctx, tenant, project, false, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contexts of project %s: %w", project, err)
+	}
+	walkSnapshotContexts(contexts, "", func(path string, node *snapshotContextNode) {
+		if !node.Global {
+			snapshot.Contexts = append(snapshot.Contexts, ManifestContext{Path: path, Protected: node.Protected})
+		}
+		for _, overload := range node.Overloads {
+			name, _ := overload["name"].(string)
+			if featureNames[name] {
+				snapshot.Overloads = append(snapshot.Overloads, ProjectSnapshotOverload{Feature: name, Context: path, Strategy: overloadStrategy(overload)})
+			}
+		}
+	})
+	return snapshot, nil
+}
+
+// ParseProjectSnapshot parses and validates a project snapshot
+func ParseProjectSnapshot(data []byte) (*ProjectSnapshot, error) {
+	var snapshot ProjectSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if snapshot.Version != ProjectSnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (expected %d)", snapshot.Version, ProjectSnapshotVersion)
+	}
+	if snapshot.Project == "" {
+		return nil, fmt.Errorf("invalid snapshot: project is missing")
+	}
+	return &snapshot, nil
+}
+
+// BuildRestorePlan plans the changes restoring a snapshot into a project: tags,
+// contexts and features are reconciled as with a manifest, then overloads.
+// With prune, features, project contexts and overloads of the project missing
+// from the snapshot are deleted; tags are never deleted, as they are shared by
+// the whole tenant.
+func BuildRestorePlan(c *AdminClient, ctx context.Context, tenant string, snapshot *ProjectSnapshot, project string, prune bool) (*Plan, error) {
+	if _, err := GetProject(c, ctx, tenant, project, Identity); err != nil {
+		return nil, fmt.Errorf("cannot restore into project %s (create it first with 'iz admin projects create'): %w", project, err)
+	}
+
+	manifest := &Manifest{Tags: snapshot.Tags}
+	for _, declared := range snapshot.Contexts {
+		declared.Project = project
+		manifest.Contexts = append(manifest.Contexts, declared)
+	}
+	for _, feature := range snapshot.Features {
+		feature.Project = project
+		manifest.Features = append(manifest.Features, feature)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan, err := BuildPlan(c, ctx, tenant, manifest, prune)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes, deletes []PlannedChange
+	for _, change := range plan.Changes {
+		switch {
+		case change.Action == PlanDelete && change.Kind == PlanKindTag:
+			// Tags are shared by the tenant
+		case change.Action == PlanDelete:
+			deletes = append(deletes, change)
+		default:
+			changes = append(changes, change)
+		}
+	}
+
+	// Overloads are set once features and contexts exist, and deleted before features are
+	contexts, err := ListContexts(c, ctx, tenant, project, false, Unmarshal[[]*snapshotContextNode]())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contexts of project %s: %w", project, err)
+	}
+	existing := make(map[string]ProjectSnapshotOverload)
+	walkSnapshotContexts(contexts, "", func(path string, node *snapshotContextNode) {
+		for _, overload := range node.Overloads {
+			name, _ := overload["name"].(string)
+			current := ProjectSnapshotOverload{Feature: name, Context: path, Strategy: overloadStrategy(overload)}
+			existing[current.Key()] = current
+		}
+	})
+
+	features := make(map[string]bool)
+	desired := make(map[string]bool)
+	for _, feature := range snapshot.Features {
+		features[feature.Name] = true
+	}
+	for _, overload := range snapshot.Overloads {
+		key := overload.Key()
+		desired[key] = true
+		feature, contextPath, strategy := overload.Feature, overload.Context, normalizeManifestValue(overload.Strategy)
+		apply := func(ctx context.Context) error {
+			return c.SetOverload(ctx, tenant, project, contextPath, feature, strategy, false)
+		}
+		current, exists := existing[key]
+		if !exists {
+			changes = append(changes, PlannedChange{Action: PlanCreate, Kind: PlanKindOverload, Name: key, apply: apply})
+			continue
+		}
+		if fieldChanges := diffSnapshotFields(overloadFields(current.Strategy), overloadFields(overload.Strategy)); len(fieldChanges) > 0 {
+			changes = append(changes, PlannedChange{Action: PlanUpdate, Kind: PlanKindOverload, Name: key, Changes: fieldChanges, apply: apply})
+		}
+	}
+	if prune {
+		var overloadDeletes []PlannedChange
+		for _, key := range sortedKeys(existing) {
+			if desired[key] {
+				continue
+			}
+			feature, contextPath := existing[key].Feature, existing[key].Context
+			// Overloads of deleted features go with them
+			if !features[feature] {
+				continue
+			}
+			apply := func(ctx context.Context) error {
+				return c.DeleteOverload(ctx, tenant, project, contextPath, feature, false)
+			}
+			overloadDeletes = append(overloadDeletes, PlannedChange{Action: PlanDelete, Kind: PlanKindOverload, Name: key, apply: apply})
+		}
+		deletes = append(overloadDeletes, deletes...)
+	}
+
+	plan.Changes = append(changes, deletes...)
+	if plan.Changes == nil {
+		plan.Changes = []PlannedChange{}
+	}
+	return plan, nil
+}
+
+// walkSnapshotContexts calls visit for each context of a tree with its full path
+func walkSnapshotContexts(nodes []*snapshotContextNode, parentPath string, visit func(path string, node *snapshotContextNode)) {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		path := node.Name
+		if parentPath != "" {
+			path = parentPath + "/" + node.Name
+		}
+		visit(path, node)
+		walkSnapshotContexts(node.Children, path, visit)
+	}
+}
+
+// overloadStrategy keeps the strategy fields of a raw overload
+func overloadStrategy(overload map[string]interface{}) map[string]interface{} {
+	strategy := make(map[string]interface{})
+	for _, field := range overloadStrategyFields {
+		if value, ok := overload[field]; ok && value != nil {
+			strategy[field] = value
+		}
+	}
+	if _, ok := strategy["resultType"]; !ok {
+		strategy["resultType"] = "boolean"
+	}
+	return strategy
+}
+
+// overloadFields flattens an overload strategy for comparison
+func overloadFields(strategy map[string]interface{}) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range overloadStrategyFields {
+		if value, ok := strategy[field]; ok {
+			flattenSnapshotField(fields, field, normalizeManifestValue(value))
+		}
+	}
+	if fields["enabled"] == "" {
+		fields["enabled"] = "false"
+	}
+	return fields
+}
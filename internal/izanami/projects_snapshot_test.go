@@ -0,0 +1,145 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotServer serves project shop with two features, a project context
+// overloading one of them and a global context overloading the other, and
+// records every write request as "METHOD path"
+func snapshotServer(t *testing.T) (*AdminClient, *[]string, map[string]interface{}) {
+	t.Helper()
+	var mu sync.Mutex
+	writes := []string{}
+	bodies := map[string]interface{}{}
+
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			mu.Lock()
+			defer mu.Unlock()
+			request := r.Method + " " + r.URL.Path
+			writes = append(writes, request)
+			var body interface{}
+			if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+				json.Unmarshal(data, &body)
+				bodies[request] = body
+			}
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/projects/shop":
+			io.WriteString(w, `{"name":"shop"}`)
+		case "/api/admin/tenants/acme/tags":
+			io.WriteString(w, `[{"name":"checkout","description":"Checkout funnel"},{"name":"legacy","description":""}]`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[
+				{"name":"prod","protected":true,"global":false,"overloads":[
+					{"id":"f1","name":"checkout","project":"shop","enabled":false,"resultType":"boolean","conditions":[{"rule":{"type":"UserList","users":["alice"]}}]}
+				],"children":[{"name":"eu","protected":false,"global":false}]},
+				{"name":"shared","protected":false,"global":true,"overloads":[
+					{"id":"f2","name":"search","project":"shop","enabled":true,"resultType":"boolean"}
+				]}]`)
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id":"f1","name":"checkout","project":"shop","enabled":true,"description":"","resultType":"boolean","conditions":[],"metadata":{},"tags":["checkout"]},
+				{"id":"f2","name":"search","project":"shop","enabled":false,"resultType":"boolean"},
+				{"id":"f3","name":"other","project":"web","enabled":true}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"not found"}`)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client, &writes, bodies
+}
+
+func TestSnapshotProject(t *testing.T) {
+	client, _, _ := snapshotServer(t)
+
+	snapshot, err := SnapshotProject(client, context.Background(), "acme", "shop")
+	require.NoError(t, err)
+
+	assert.Equal(t, ProjectSnapshotVersion, snapshot.Version)
+	assert.Equal(t, "shop", snapshot.Project)
+	assert.Equal(t, []ManifestTag{{Name: "checkout", Description: "Checkout funnel"}}, snapshot.Tags)
+	assert.Equal(t, []ManifestContext{{Path: "prod", Protected: true}, {Path: "prod/eu"}}, snapshot.Contexts)
+	require.Len(t, snapshot.Features, 2)
+	assert.Equal(t, "checkout", snapshot.Features[0].Name)
+	assert.Equal(t, []string{"checkout"}, snapshot.Features[0].Tags)
+	require.Len(t, snapshot.Overloads, 2)
+	assert.Equal(t, "checkout@prod", snapshot.Overloads[0].Key())
+	assert.Equal(t, false, snapshot.Overloads[0].Strategy["enabled"])
+	assert.NotContains(t, snapshot.Overloads[0].Strategy, "id")
+	assert.Equal(t, "search@shared", snapshot.Overloads[1].Key())
+
+	_, err = SnapshotProject(client, context.Background(), "acme", "missing")
+	assert.Error(t, err)
+}
+
+func TestBuildRestorePlan(t *testing.T) {
+	client, writes, bodies := snapshotServer(t)
+	ctx := context.Background()
+
+	snapshot, err := SnapshotProject(client, ctx, "acme", "shop")
+	require.NoError(t, err)
+
+	// Unchanged project: nothing to do
+	plan, err := BuildRestorePlan(client, ctx, "acme", snapshot, "shop", true)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+
+	// The snapshot had checkout enabled in prod, no search feature, and a new overload
+	snapshot.Overloads[0].Strategy["enabled"] = true
+	snapshot.Overloads = append(snapshot.Overloads[:1], ProjectSnapshotOverload{Feature: "checkout", Context: "prod/eu", Strategy: map[string]interface{}{"enabled": true, "resultType": "boolean"}})
+	snapshot.Features = snapshot.Features[:1]
+
+	plan, err = BuildRestorePlan(client, ctx, "acme", snapshot, "shop", true)
+	require.NoError(t, err)
+	var summary []string
+	for _, change := range plan.Changes {
+		summary = append(summary, change.Action+" "+change.Kind+" "+change.Name)
+	}
+	assert.Equal(t, []string{
+		"update overload checkout@prod",
+		"create overload checkout@prod/eu",
+		"delete feature shop/search",
+	}, summary)
+
+	require.NoError(t, plan.Apply(ctx, nil))
+	assert.Equal(t, []string{
+		"PUT /api/admin/tenants/acme/projects/shop/contexts/prod/features/checkout",
+		"PUT /api/admin/tenants/acme/projects/shop/contexts/prod/eu/features/checkout",
+		"DELETE /api/admin/tenants/acme/features/f2",
+	}, *writes)
+	body := bodies["PUT /api/admin/tenants/acme/projects/shop/contexts/prod/features/checkout"].(map[string]interface{})
+	assert.Equal(t, true, body["enabled"])
+	assert.NotEmpty(t, body["conditions"])
+
+	_, err = BuildRestorePlan(client, ctx, "acme", snapshot, "missing", false)
+	assert.ErrorContains(t, err, "cannot restore into project missing")
+}
+
+func TestParseProjectSnapshot(t *testing.T) {
+	_, err := ParseProjectSnapshot([]byte(`{"version": 2, "project": "shop"}`))
+	assert.ErrorContains(t, err, "unsupported snapshot version 2")
+	_, err = ParseProjectSnapshot([]byte(`{"version": 1}`))
+	assert.ErrorContains(t, err, "project is missing")
+	snapshot, err := ParseProjectSnapshot([]byte(`{"version": 1, "project": "shop", "features": []}`))
+	require.NoError(t, err)
+	assert.Equal(t, "shop", snapshot.Project)
+}
@@ -0,0 +1,171 @@
+package izanami
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// CLIENT-SIDE RATE LIMITING
+// ============================================================================
+
+// Default politeness limits, applied to every request of a CLI invocation.
+// They only slow down bulk operations (batch deletes, test matrices,
+// multi-tenant listings); a value of 0 in the config disables a limit.
+const (
+	DefaultMaxRequestsPerSecond = 50
+	DefaultMaxConcurrency       = 16
+)
+
+// maxRateBurst caps the requests sent back to back before the rate applies
+const maxRateBurst = 10
+
+// RateLimit overrides the request limits for a command in the rate-limits
+// section of the config. A zero field keeps the global value.
+type RateLimit struct {
+	MaxRequestsPerSecond float64 `yaml:"max-requests-per-second,omitempty" mapstructure:"max-requests-per-second"`
+	MaxConcurrency       int     `yaml:"max-concurrency,omitempty" mapstructure:"max-concurrency"`
+}
+
+// ApplyCommandRateLimit applies the rate-limits entry of a command path
+// ("admin features delete"), matched like CommandTimeout: the most specific
+// key wins, from the full path down to the command name ("delete").
+func (c *ResolvedConfig) ApplyCommandRateLimit(path string) {
+	words := strings.Fields(path)
+	for i := range words {
+		if limit, ok := c.RateLimits[strings.Join(words[i:], " ")]; ok {
+			if limit.MaxRequestsPerSecond > 0 {
+				c.MaxRequestsPerSecond = limit.MaxRequestsPerSecond
+			}
+			if limit.MaxConcurrency > 0 {
+				c.MaxConcurrency = limit.MaxConcurrency
+			}
+			return
+		}
+	}
+}
+
+// requestLimiter bounds the rate and the number of in-flight requests. One
+// limiter is shared by every client of the process, so parallel workers of
+// a bulk command and nested pools (tenants × projects) add up to one budget.
+type requestLimiter struct {
+	rps            float64
+	maxConcurrency int
+
+	slots    chan struct{} // nil when concurrency is unlimited
+	interval time.Duration // 0 when the rate is unlimited
+	burst    time.Duration
+
+	mu   sync.Mutex
+	next time.Time // when the next request may start without burst
+}
+
+var (
+	sharedLimiterMu sync.Mutex
+	sharedLimiter   *requestLimiter
+)
+
+// getRequestLimiter returns the process-wide limiter for the given limits,
+// nil when both are disabled. The limiter is replaced when the limits change.
+func getRequestLimiter(rps float64, maxConcurrency int) *requestLimiter {
+	if rps <= 0 && maxConcurrency <= 0 {
+		return nil
+	}
+	sharedLimiterMu.Lock()
+	defer sharedLimiterMu.Unlock()
+	if sharedLimiter == nil || sharedLimiter.rps != rps || sharedLimiter.maxConcurrency != maxConcurrency {
+		sharedLimiter = newRequestLimiter(rps, maxConcurrency)
+	}
+	return sharedLimiter
+}
+
+// newRequestLimiter creates a limiter; a limit <= 0 is disabled
+func newRequestLimiter(rps float64, maxConcurrency int) *requestLimiter {
+	l := &requestLimiter{rps: rps, maxConcurrency: maxConcurrency}
+	if maxConcurrency > 0 {
+		l.slots = make(chan struct{}, maxConcurrency)
+	}
+	if rps > 0 {
+		l.interval = time.Duration(float64(time.Second) / rps)
+		burst := int(rps)
+		if burst > maxRateBurst {
+			burst = maxRateBurst
+		}
+		if burst > 1 {
+			l.burst = time.Duration(burst-1) * l.interval
+		}
+	}
+	return l
+}
+
+// acquire waits for a concurrency slot and the rate, and returns the function
+// releasing the slot. It gives up when ctx is done.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if wait := l.reserve(time.Now()); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// reserve books the next request start and returns how long to wait for it
+func (l *requestLimiter) reserve(now time.Time) time.Duration {
+	if l.interval == 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now) - l.burst
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// rateLimitedTransport sends requests through a requestLimiter. A slot is held
+// until the response headers are received, so long-lived event streams do not
+// keep it.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *requestLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.base.RoundTrip(req)
+}
+
+// rateLimitTransport wraps base with the shared limiter of the config limits,
+// or returns base when they are disabled
+func rateLimitTransport(base http.RoundTripper, config *ResolvedConfig) http.RoundTripper {
+	limiter := getRequestLimiter(config.MaxRequestsPerSecond, config.MaxConcurrency)
+	if limiter == nil {
+		return base
+	}
+	return &rateLimitedTransport{base: base, limiter: limiter}
+}
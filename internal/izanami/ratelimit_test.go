@@ -0,0 +1,127 @@
+package izanami
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvedConfig_ApplyCommandRateLimit(t *testing.T) {
+	newConfig := func() *ResolvedConfig {
+		return &ResolvedConfig{
+			MaxRequestsPerSecond: 50,
+			MaxConcurrency:       16,
+			RateLimits: map[string]RateLimit{
+				"delete":                {MaxConcurrency: 4},
+				"admin features delete": {MaxRequestsPerSecond: 5, MaxConcurrency: 2},
+			},
+		}
+	}
+
+	config := newConfig()
+	config.ApplyCommandRateLimit("admin features delete")
+	assert.Equal(t, 5.0, config.MaxRequestsPerSecond)
+	assert.Equal(t, 2, config.MaxConcurrency)
+
+	// A zero field keeps the global value
+	config = newConfig()
+	config.ApplyCommandRateLimit("admin tags delete")
+	assert.Equal(t, 50.0, config.MaxRequestsPerSecond)
+	assert.Equal(t, 4, config.MaxConcurrency)
+
+	config = newConfig()
+	config.ApplyCommandRateLimit("admin features list")
+	assert.Equal(t, 16, config.MaxConcurrency)
+}
+
+func TestGetRequestLimiter(t *testing.T) {
+	assert.Nil(t, getRequestLimiter(0, 0))
+
+	limiter := getRequestLimiter(20, 3)
+	assert.Same(t, limiter, getRequestLimiter(20, 3), "clients share one limiter")
+	assert.NotSame(t, limiter, getRequestLimiter(20, 4))
+}
+
+func TestRequestLimiter_Rate(t *testing.T) {
+	limiter := newRequestLimiter(100, 0)
+	now := time.Now()
+
+	// The first requests go back to back, then one every 10ms
+	for i := 0; i < maxRateBurst; i++ {
+		assert.LessOrEqual(t, limiter.reserve(now), time.Duration(0), "request %d", i)
+	}
+	assert.Equal(t, 10*time.Millisecond, limiter.reserve(now))
+	assert.Equal(t, 20*time.Millisecond, limiter.reserve(now))
+
+	// Unused budget does not pile up beyond the burst
+	later := now.Add(time.Minute)
+	for i := 0; i < maxRateBurst; i++ {
+		assert.LessOrEqual(t, limiter.reserve(later), time.Duration(0))
+	}
+	assert.Greater(t, limiter.reserve(later), time.Duration(0))
+
+	assert.Zero(t, newRequestLimiter(0, 5).reserve(now))
+}
+
+func TestRequestLimiter_CanceledWait(t *testing.T) {
+	limiter := newRequestLimiter(0, 1)
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = limiter.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
+
+func TestRateLimitedClient_MaxConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+
+	// Two clients, as in nested worker pools, share the limit
+	config := &ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 5, MaxConcurrency: 2}
+	first, err := NewAdminClient(config)
+	require.NoError(t, err)
+	second, err := NewAdminClient(config)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		client := first
+		if i%2 == 1 {
+			client = second
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ListTenants(client, context.Background(), nil, ParseTenants)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
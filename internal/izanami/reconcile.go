@@ -0,0 +1,335 @@
+package izanami
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// MANIFEST RECONCILIATION (iz plan / iz apply)
+// ============================================================================
+
+// Plan actions
+const (
+	PlanCreate = "create"
+	PlanUpdate = "update"
+	PlanDelete = "delete"
+)
+
+// Plan resource kinds
+const (
+	PlanKindTag     = "tag"
+	PlanKindContext = "context"
+	PlanKindFeature = "feature"
+)
+
+// managedFeatureFields are the feature fields a manifest controls
+var managedFeatureFields = []string{"enabled", "description", "resultType", "value", "conditions", "metadata", "tags"}
+
+// PlannedChange is one create, update or delete needed to reconcile the server with a manifest
+type PlannedChange struct {
+	Action  string        `json:"action"`
+	Kind    string        `json:"kind"`
+	Name    string        `json:"name"`
+	Changes []FieldChange `json:"changes,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// Plan lists the changes reconciling a tenant with a manifest, in the order they are applied
+type Plan struct {
+	Tenant   string          `json:"tenant"`
+	Changes  []PlannedChange `json:"changes"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// serverContext is an existing context with its full path and scope
+type serverContext struct {
+	project   string
+	path      string
+	protected bool
+}
+
+// BuildPlan compares a manifest with the current state of a tenant.
+//
+// Only the projects referenced by the manifest are reconciled, and global contexts only
+// when the manifest declares one. With prune, resources in that scope which the manifest
+// does not declare are deleted; tags are pruned only when the manifest declares tags.
+func BuildPlan(c *AdminClient, ctx context.Context, tenant string, manifest *Manifest, prune bool) (*Plan, error) {
+	plan := &Plan{Tenant: tenant, Changes: []PlannedChange{}}
+
+	projects := make(map[string]bool)
+	manageGlobalContexts := false
+	for _, feature := range manifest.Features {
+		projects[feature.Project] = true
+	}
+	for _, declared := range manifest.Contexts {
+		if declared.Project == "" {
+			manageGlobalContexts = true
+		} else {
+			projects[declared.Project] = true
+		}
+	}
+
+	var deletes []PlannedChange
+
+	// Tags
+	if len(manifest.Tags) > 0 {
+		existing, err := ListTags(c, ctx, tenant, ParseTags)
+		if err != nil {
+			return nil, err
+		}
+		existingByName := make(map[string]Tag)
+		for _, tag := range existing {
+			existingByName[tag.Name] = tag
+		}
+		declared := make(map[string]bool)
+		for _, tag := range manifest.Tags {
+			declared[tag.Name] = true
+			current, exists := existingByName[tag.Name]
+			if !exists {
+				body := map[string]interface{}{"name": tag.Name, "description": tag.Description}
+				plan.Changes = append(plan.Changes, PlannedChange{Action: PlanCreate, Kind: PlanKindTag, Name: tag.Name,
+					apply: func(ctx context.Context) error { return c.CreateTag(ctx, tenant, body) }})
+			} else if current.Description != tag.Description {
+				name, body := tag.Name, map[string]interface{}{"name": tag.Name, "description": tag.Description}
+				plan.Changes = append(plan.Changes, PlannedChange{Action: PlanUpdate, Kind: PlanKindTag, Name: name,
+					Changes: []FieldChange{{Field: "description", From: current.Description, To: tag.Description}},
+					apply:   func(ctx context.Context) error { return c.UpdateTag(ctx, tenant, name, body) }})
+			}
+		}
+		if prune {
+			for _, tag := range existing {
+				if !declared[tag.Name] {
+					name := tag.Name
+					deletes = append(deletes, PlannedChange{Action: PlanDelete, Kind: PlanKindTag, Name: name,
+						apply: func(ctx context.Context) error { return c.DeleteTag(ctx, tenant, name) }})
+				}
+			}
+		}
+	}
+
+	// Contexts
+	existingContexts := make(map[string]serverContext)
+	scopes := sortedKeys(projects)
+	if manageGlobalContexts {
+		scopes = append([]string{""}, scopes...)
+	}
+	for _, scope := range scopes {
+		contexts, err := ListContexts(c, ctx, tenant, scope, false, ParseContexts)
+		if err != nil {
+			return nil, err
+		}
+		collectServerContexts(existingContexts, scope, contexts, "")
+	}
+
+	desiredContexts := make(map[string]ManifestContext)
+	for _, declared := range manifest.Contexts {
+		desiredContexts[declared.Key()] = declared
+		// Parents of a declared context are implicitly desired
+		segments := strings.Split(declared.Path, "/")
+		for i := 1; i < len(segments); i++ {
+			parent := ManifestContext{Project: declared.Project, Path: strings.Join(segments[:i], "/")}
+			if _, exists := desiredContexts[parent.Key()]; !exists {
+				if current, found := existingContexts[parent.Key()]; found {
+					parent.Protected = current.protected
+				}
+				desiredContexts[parent.Key()] = parent
+			}
+		}
+	}
+
+	for _, key := range sortedContextKeys(desiredContexts, false) {
+		desired := desiredContexts[key]
+		current, exists := existingContexts[key]
+		project, path := desired.Project, desired.Path
+		switch {
+		case !exists:
+			name, parent := path, ""
+			if i := strings.LastIndex(path, "/"); i >= 0 {
+				parent, name = path[:i], path[i+1:]
+			}
+			body := map[string]interface{}{"name": name, "protected": desired.Protected}
+			plan.Changes = append(plan.Changes, PlannedChange{Action: PlanCreate, Kind: PlanKindContext, Name: key,
+				apply: func(ctx context.Context) error { return c.CreateContext(ctx, tenant, project, name, parent, body) }})
+		case current.protected != desired.Protected && project == "":
+			body := map[string]interface{}{"protected": desired.Protected}
+			plan.Changes = append(plan.Changes, PlannedChange{Action: PlanUpdate, Kind: PlanKindContext, Name: key,
+				Changes: []FieldChange{{Field: "protected", From: fmt.Sprint(current.protected), To: fmt.Sprint(desired.Protected)}},
+				apply:   func(ctx context.Context) error { return c.UpdateContext(ctx, tenant, path, body) }})
+		case current.protected != desired.Protected:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("context %s: protected differs but project contexts cannot be updated", key))
+		}
+	}
+
+	var contextDeletes []PlannedChange
+	if prune {
+		undeclared := make(map[string]ManifestContext)
+		for key, current := range existingContexts {
+			if _, declared := desiredContexts[key]; !declared {
+				undeclared[key] = ManifestContext{Project: current.project, Path: current.path}
+			}
+		}
+		// Children are deleted before their parents
+		for _, key := range sortedContextKeys(undeclared, true) {
+			project, path := undeclared[key].Project, undeclared[key].Path
+			contextDeletes = append(contextDeletes, PlannedChange{Action: PlanDelete, Kind: PlanKindContext, Name: key,
+				apply: func(ctx context.Context) error { return c.DeleteContext(ctx, tenant, project, path) }})
+		}
+	}
+
+	// Features
+	if len(projects) > 0 {
+		features, err := ListFeatures(c, ctx, tenant, "", Unmarshal[[]map[string]interface{}]())
+		if err != nil {
+			return nil, err
+		}
+		existingFeatures := make(map[string]map[string]interface{})
+		for _, feature := range features {
+			name, _ := feature["name"].(string)
+			project, _ := feature["project"].(string)
+			if projects[project] {
+				existingFeatures[project+"/"+name] = feature
+			}
+		}
+
+		declared := make(map[string]bool)
+		for _, feature := range manifest.Features {
+			key := feature.Key()
+			declared[key] = true
+			desired := feature.payload()
+			current, exists := existingFeatures[key]
+			if !exists {
+				project := feature.Project
+				plan.Changes = append(plan.Changes, PlannedChange{Action: PlanCreate, Kind: PlanKindFeature, Name: key,
+					apply: func(ctx context.Context) error {
+						_, err := c.CreateFeature(ctx, tenant, project, desired)
+						return err
+					}})
+				continue
+			}
+
+			changes := diffSnapshotFields(managedFields(current), managedFields(desired))
+			if len(changes) == 0 {
+				continue
+			}
+			id, _ := current["id"].(string)
+			body := make(map[string]interface{}, len(current))
+			for field, value := range current {
+				body[field] = value
+			}
+			for _, field := range managedFeatureFields {
+				if value, ok := desired[field]; ok {
+					body[field] = value
+				} else {
+					delete(body, field)
+				}
+			}
+			plan.Changes = append(plan.Changes, PlannedChange{Action: PlanUpdate, Kind: PlanKindFeature, Name: key, Changes: changes,
+				apply: func(ctx context.Context) error { return c.UpdateFeature(ctx, tenant, id, body, false) }})
+		}
+
+		if prune {
+			for _, key := range sortedKeys(existingFeatures) {
+				if declared[key] {
+					continue
+				}
+				id, _ := existingFeatures[key]["id"].(string)
+				plan.Changes = append(plan.Changes, PlannedChange{Action: PlanDelete, Kind: PlanKindFeature, Name: key,
+					apply: func(ctx context.Context) error { return c.DeleteFeature(ctx, tenant, id) }})
+			}
+		}
+	}
+
+	// Deletions run last: features, then contexts, then tags
+	plan.Changes = append(plan.Changes, contextDeletes...)
+	plan.Changes = append(plan.Changes, deletes...)
+	return plan, nil
+}
+
+// Apply runs the planned changes in order, reporting each one to progress.
+// It stops at the first failure.
+func (p *Plan) Apply(ctx context.Context, progress func(change PlannedChange)) error {
+	for _, change := range p.Changes {
+		if change.apply == nil {
+			continue
+		}
+		if err := change.apply(ctx); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Kind, change.Name, err)
+		}
+		if progress != nil {
+			progress(change)
+		}
+	}
+	return nil
+}
+
+// Count returns the number of planned changes with the given action
+func (p *Plan) Count(action string) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// collectServerContexts indexes a context tree by scope-qualified path, skipping
+// global contexts listed in a project scope
+func collectServerContexts(index map[string]serverContext, project string, contexts []Context, parentPath string) {
+	for _, node := range contexts {
+		path := node.Name
+		if parentPath != "" {
+			path = parentPath + "/" + node.Name
+		}
+		if project == "" || !node.Global {
+			key := ManifestContext{Project: project, Path: path}.Key()
+			index[key] = serverContext{project: project, path: path, protected: node.IsProtected}
+		}
+		collectServerContexts(index, project, contextsToSlice(node.Children), path)
+	}
+}
+
+// managedFields flattens the manifest-controlled fields of a feature for comparison
+func managedFields(feature map[string]interface{}) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range managedFeatureFields {
+		if value, ok := feature[field]; ok {
+			flattenSnapshotField(fields, field, normalizeManifestValue(value))
+		}
+	}
+	if _, ok := fields["description"]; !ok {
+		fields["description"] = `""`
+	}
+	if fields["enabled"] == "" {
+		fields["enabled"] = "false"
+	}
+	return fields
+}
+
+// sortedContextKeys sorts contexts parents first, or children first when reverse is set
+func sortedContextKeys(contexts map[string]ManifestContext, reverse bool) []string {
+	keys := sortedKeys(contexts)
+	sort.SliceStable(keys, func(i, j int) bool {
+		di := strings.Count(contexts[keys[i]].Path, "/")
+		dj := strings.Count(contexts[keys[j]].Path, "/")
+		if reverse {
+			return di > dj
+		}
+		return di < dj
+	})
+	return keys
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,211 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `
+tags:
+  - name: checkout
+    description: Checkout funnel
+contexts:
+  - path: prod/eu
+    project: shop
+features:
+  - name: checkout
+    project: shop
+    enabled: false
+    tags: [checkout]
+  - name: search
+    project: shop
+    enabled: true
+    description: Search v2
+`
+
+// reconcileServer serves a tenant with one tag, one context and two features,
+// and records every write request as "METHOD path"
+func reconcileServer(t *testing.T) (*AdminClient, *[]string, map[string]interface{}) {
+	t.Helper()
+	var mu sync.Mutex
+	writes := []string{}
+	bodies := map[string]interface{}{}
+
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			mu.Lock()
+			defer mu.Unlock()
+			request := r.Method + " " + r.URL.Path
+			writes = append(writes, request)
+			var body interface{}
+			if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+				json.Unmarshal(data, &body)
+				bodies[request] = body
+			}
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/tags":
+			io.WriteString(w, `[{"id":"t1","name":"legacy","description":""}]`)
+		case "/api/admin/tenants/acme/projects/shop/contexts":
+			io.WriteString(w, `[{"name":"prod","protected":false,"global":false,"children":[{"name":"us","protected":false,"global":false}]},
+				{"name":"shared","global":true,"protected":false}]`)
+		case "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id":"f1","name":"checkout","project":"shop","enabled":true,"description":"","resultType":"boolean","conditions":[],"metadata":{},"tags":["checkout"]},
+				{"id":"f2","name":"old","project":"shop","enabled":true,"resultType":"boolean"},
+				{"id":"f3","name":"other","project":"web","enabled":true}
+			]`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+	return client, &writes, bodies
+}
+
+// planSummary returns "action kind name" for each planned change
+func planSummary(plan *Plan) []string {
+	var summary []string
+	for _, change := range plan.Changes {
+		summary = append(summary, change.Action+" "+change.Kind+" "+change.Name)
+	}
+	return summary
+}
+
+func TestBuildPlan_CreatesAndUpdates(t *testing.T) {
+	client, writes, _ := reconcileServer(t)
+	manifest, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+	require.NoError(t, manifest.Validate())
+
+	plan, err := BuildPlan(client, context.Background(), "acme", manifest, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"create tag checkout",
+		"create context shop:prod/eu",
+		"update feature shop/checkout",
+		"create feature shop/search",
+	}, planSummary(plan))
+	assert.Equal(t, []FieldChange{{Field: "enabled", From: "true", To: "false"}}, plan.Changes[2].Changes)
+	assert.Empty(t, *writes, "planning must not change anything")
+}
+
+func TestBuildPlan_Prune(t *testing.T) {
+	client, _, _ := reconcileServer(t)
+	manifest, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+
+	plan, err := BuildPlan(client, context.Background(), "acme", manifest, true)
+	require.NoError(t, err)
+
+	summary := planSummary(plan)
+	assert.Contains(t, summary, "delete feature shop/old")
+	assert.Contains(t, summary, "delete context shop:prod/us")
+	assert.Contains(t, summary, "delete tag legacy")
+	assert.NotContains(t, summary, "delete feature web/other", "projects outside the manifest are not pruned")
+	assert.NotContains(t, summary, "delete context shop:prod", "parents of declared contexts are kept")
+	assert.NotContains(t, summary, "delete context shop:shared", "global contexts are not pruned unless declared")
+	assert.Equal(t, "delete tag legacy", summary[len(summary)-1], "tags are deleted last")
+}
+
+func TestBuildPlan_UpdatesTagDescription(t *testing.T) {
+	client, writes, bodies := reconcileServer(t)
+	manifest, err := ParseManifest([]byte("tags:\n  - name: legacy\n    description: Old flags\n"))
+	require.NoError(t, err)
+
+	plan, err := BuildPlan(client, context.Background(), "acme", manifest, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"update tag legacy"}, planSummary(plan))
+	assert.Equal(t, []FieldChange{{Field: "description", From: "", To: "Old flags"}}, plan.Changes[0].Changes)
+
+	require.NoError(t, plan.Apply(context.Background(), nil))
+	assert.Equal(t, []string{"PUT /api/admin/tenants/acme/tags/legacy"}, *writes)
+	assert.Equal(t, "Old flags", bodies["PUT /api/admin/tenants/acme/tags/legacy"].(map[string]interface{})["description"])
+}
+
+func TestPlanApply(t *testing.T) {
+	client, writes, bodies := reconcileServer(t)
+	manifest, err := ParseManifest([]byte(testManifest))
+	require.NoError(t, err)
+
+	plan, err := BuildPlan(client, context.Background(), "acme", manifest, false)
+	require.NoError(t, err)
+
+	var applied []string
+	require.NoError(t, plan.Apply(context.Background(), func(change PlannedChange) {
+		applied = append(applied, change.Name)
+	}))
+
+	assert.Len(t, applied, 4)
+	assert.Equal(t, []string{
+		"POST /api/admin/tenants/acme/tags",
+		"POST /api/admin/tenants/acme/projects/shop/contexts/prod",
+		"PUT /api/admin/tenants/acme/features/f1",
+		"POST /api/admin/tenants/acme/projects/shop/features",
+	}, *writes)
+
+	update := bodies["PUT /api/admin/tenants/acme/features/f1"].(map[string]interface{})
+	assert.Equal(t, false, update["enabled"])
+	assert.Equal(t, "f1", update["id"], "unmanaged fields are preserved")
+
+	created := bodies["POST /api/admin/tenants/acme/projects/shop/features"].(map[string]interface{})
+	assert.Equal(t, "search", created["name"])
+	assert.Equal(t, "boolean", created["resultType"])
+	assert.Equal(t, "Search v2", created["description"])
+}
+
+func TestParseManifest_RejectsUnknownFields(t *testing.T) {
+	_, err := ParseManifest([]byte("features:\n  - name: a\n    project: p\n    enabled: true\n    colour: red\n"))
+	assert.Error(t, err)
+}
+
+func TestManifestValidate(t *testing.T) {
+	manifest := &Manifest{
+		Tags:     []ManifestTag{{Name: "a"}, {Name: "a"}},
+		Contexts: []ManifestContext{{Path: "/"}},
+		Features: []ManifestFeature{{Name: "f"}, {Name: "g", Project: "p"}, {Name: "g", Project: "p"}},
+	}
+	err := manifest.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tag a is declared more than once")
+	assert.Contains(t, err.Error(), "contexts[0]: path is required")
+	assert.Contains(t, err.Error(), "features[0]: name and project are required")
+	assert.Contains(t, err.Error(), "feature p/g is declared more than once")
+}
+
+func TestLoadManifests_MergesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tags.yaml"), []byte("tenant: acme\ntags:\n  - name: a\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "features.json"), []byte(`{"features":[{"name":"f","project":"p","enabled":true}]}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0600))
+
+	manifest, err := LoadManifests([]string{dir}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", manifest.Tenant)
+	assert.Len(t, manifest.Tags, 1)
+	assert.Len(t, manifest.Features, 1)
+
+	other := filepath.Join(t.TempDir(), "other.yaml")
+	require.NoError(t, os.WriteFile(other, []byte("tenant: other\n"), 0600))
+	_, err = LoadManifests([]string{dir, other}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "targets tenant other")
+}
@@ -0,0 +1,287 @@
+package izanami
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/webskin/izanami-go-cli/internal/utils"
+)
+
+// ============================================================================
+// HTTP RECORDING AND REPLAY (iz --record, iz mock-server --replay)
+// ============================================================================
+
+// RecordingVersion is the version of the recording file format
+const RecordingVersion = 1
+
+// RecordedRequest is a request of a recording. Headers are not recorded: they
+// carry the credentials of the session.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"` // encoded with sorted keys
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response of the server to a recorded request
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Interaction is a request of the CLI and the response of the server
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Recording is the file written by --record and served by mock-server
+type Recording struct {
+	Version      int            `json:"version"`
+	RecordedAt   string         `json:"recordedAt"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// recordedResponseHeaders are the response headers kept in recordings
+var recordedResponseHeaders = []string{"Content-Type", "Location", "Retry-After", "X-Request-Id"}
+
+// Recorder records the HTTP interactions of the clients for later replay
+type Recorder struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Recording returns what was recorded so far, in request order
+func (r *Recorder) Recording(now time.Time) *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interactions := make([]*Interaction, len(r.interactions))
+	for i, interaction := range r.interactions {
+		cp := *interaction
+		interactions[i] = &cp
+	}
+	return &Recording{Version: RecordingVersion, RecordedAt: now.UTC().Format(time.RFC3339), Interactions: interactions}
+}
+
+// Save writes what was recorded so far to a file, readable by the owner only
+// since responses may include secrets
+func (r *Recorder) Save(path string, now time.Time) error {
+	data, err := json.MarshalIndent(r.Recording(now), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize recording: %w", err)
+	}
+	return utils.WriteFileAtomic(path, append(data, '\n'), 0600)
+}
+
+// add records a request, before its response is known
+func (r *Recorder) add(interaction *Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, interaction)
+}
+
+// LoadRecording reads a recording written by --record
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+	}
+	if recording.Version != RecordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d in %s", recording.Version, path)
+	}
+	return &recording, nil
+}
+
+// canonicalQuery encodes a raw query with sorted keys, so that the order of
+// the parameters does not matter when matching requests
+func canonicalQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	return values.Encode()
+}
+
+// recorder records the interactions when set (see SetRecorder). Like the HTTP
+// logger, it is shared by all clients created during a command.
+var (
+	recorderMu sync.RWMutex
+	recorder   *Recorder
+)
+
+// SetRecorder enables the recording of the interactions of clients created
+// afterwards (nil disables it)
+func SetRecorder(r *Recorder) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorder = r
+}
+
+// getRecorder returns the current recorder, or nil when recording is disabled
+func getRecorder() *Recorder {
+	recorderMu.RLock()
+	defer recorderMu.RUnlock()
+	return recorder
+}
+
+// recordingTransport records each request sent and the response received
+type recordingTransport struct {
+	base     http.RoundTripper
+	recorder *Recorder
+}
+
+// recordTransport wraps a transport with recording when a recorder is set,
+// and returns it unchanged otherwise
+func recordTransport(base http.RoundTripper) http.RoundTripper {
+	r := getRecorder()
+	if r == nil {
+		return base
+	}
+	return &recordingTransport{base: base, recorder: r}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction := &Interaction{Request: RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  canonicalQuery(req.URL.RawQuery),
+	}}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		interaction.Request.Body = string(body)
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// Network failures cannot be replayed
+		return resp, err
+	}
+	interaction.Response = RecordedResponse{Status: resp.StatusCode}
+	for _, name := range recordedResponseHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if interaction.Response.Headers == nil {
+				interaction.Response.Headers = make(map[string]string)
+			}
+			interaction.Response.Headers[name] = value
+		}
+	}
+	t.recorder.add(interaction)
+	// The body is recorded as it is read, so that event streams are kept
+	// up to where the command stopped reading them
+	resp.Body = &recordingBody{ReadCloser: resp.Body, recorder: t.recorder, interaction: interaction}
+	return resp, nil
+}
+
+// recordingBody copies a response body into its interaction as it is read
+type recordingBody struct {
+	io.ReadCloser
+	recorder    *Recorder
+	interaction *Interaction
+}
+
+// Read implements io.Reader
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.recorder.mu.Lock()
+		b.interaction.Response.Body += string(p[:n])
+		b.recorder.mu.Unlock()
+	}
+	return n, err
+}
+
+// ReplayHandler serves the responses of a recording. A request is answered by
+// the first interaction not served yet with the same method, path, query and
+// body (any body when none matches), then by the last one once all are served.
+// Requests without a recorded response get a 404.
+type ReplayHandler struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	served       []bool
+	// OnUnmatched is called for requests without a recorded response
+	OnUnmatched func(req *http.Request)
+}
+
+// NewReplayHandler creates a handler serving the responses of a recording
+func NewReplayHandler(recording *Recording) *ReplayHandler {
+	return &ReplayHandler{interactions: recording.Interactions, served: make([]bool, len(recording.Interactions))}
+}
+
+// ServeHTTP implements http.Handler
+func (h *ReplayHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	interaction := h.match(req.Method, req.URL.Path, canonicalQuery(req.URL.RawQuery), string(body))
+	if interaction == nil {
+		if h.OnUnmatched != nil {
+			h.OnUnmatched(req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "no recorded response for " + req.Method + " " + req.URL.RequestURI()})
+		return
+	}
+	for name, value := range interaction.Response.Headers {
+		w.Header().Set(name, value)
+	}
+	w.WriteHeader(interaction.Response.Status)
+	io.WriteString(w, interaction.Response.Body)
+}
+
+// match returns the interaction answering a request, nil when none was recorded
+func (h *ReplayHandler) match(method, path, query, body string) *Interaction {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	last, unserved, unservedSameBody := -1, -1, -1
+	for i, interaction := range h.interactions {
+		r := interaction.Request
+		if r.Method != method || r.Path != path || r.Query != query {
+			continue
+		}
+		last = i
+		if h.served[i] {
+			continue
+		}
+		if unserved < 0 {
+			unserved = i
+		}
+		if unservedSameBody < 0 && r.Body == body {
+			unservedSameBody = i
+		}
+	}
+	i := unservedSameBody
+	if i < 0 {
+		i = unserved
+	}
+	if i < 0 {
+		i = last
+	}
+	if i < 0 {
+		return nil
+	}
+	h.served[i] = true
+	return h.interactions[i]
+}
@@ -0,0 +1,149 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RecordsAndReplays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Internal", "dropped")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/features":
+			w.Write([]byte(`[{"id":"f1","name":"checkout","project":"shop","enabled":true}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "jwt", Timeout: 5})
+	require.NoError(t, err)
+	ctx := context.Background()
+	features, err := ListFeatures(client, ctx, "acme", "beta", ParseFeatures)
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	_, err = GetFeature(client, ctx, "acme", "missing", ParseFeature)
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, recorder.Save(path, time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "jwt", "credentials are not recorded")
+
+	recording, err := LoadRecording(path)
+	require.NoError(t, err)
+	require.Len(t, recording.Interactions, 2)
+	first := recording.Interactions[0]
+	assert.Equal(t, "GET", first.Request.Method)
+	assert.Equal(t, "/api/admin/tenants/acme/features", first.Request.Path)
+	assert.Equal(t, "tag=beta", first.Request.Query)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, first.Response.Headers)
+	assert.Equal(t, http.StatusNotFound, recording.Interactions[1].Response.Status)
+
+	// The replayed server answers the CLI as the live one did
+	SetRecorder(nil)
+	mock := httptest.NewServer(NewReplayHandler(recording))
+	defer mock.Close()
+	replayClient, err := NewAdminClient(&ResolvedConfig{LeaderURL: mock.URL, JwtToken: "other", Timeout: 5})
+	require.NoError(t, err)
+	replayed, err := ListFeatures(replayClient, ctx, "acme", "beta", ParseFeatures)
+	require.NoError(t, err)
+	assert.Equal(t, features, replayed)
+	_, err = GetFeature(replayClient, ctx, "acme", "missing", ParseFeature)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestRecorder_RecordsRequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"checkout"}`, string(body), "the server still gets the body")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"f1","name":"checkout"}`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "jwt", Timeout: 5})
+	require.NoError(t, err)
+	_, err = client.CreateFeature(context.Background(), "acme", "shop", map[string]string{"name": "checkout"})
+	require.NoError(t, err)
+
+	recording := recorder.Recording(time.Now())
+	require.Len(t, recording.Interactions, 1)
+	assert.Equal(t, `{"name":"checkout"}`, recording.Interactions[0].Request.Body)
+	assert.Equal(t, `{"id":"f1","name":"checkout"}`, recording.Interactions[0].Response.Body)
+}
+
+func TestReplayHandler_Matching(t *testing.T) {
+	recording := &Recording{Version: RecordingVersion, Interactions: []*Interaction{
+		{Request: RecordedRequest{Method: "GET", Path: "/api/_health"}, Response: RecordedResponse{Status: 200, Body: "first"}},
+		{Request: RecordedRequest{Method: "GET", Path: "/api/_health"}, Response: RecordedResponse{Status: 200, Body: "second"}},
+		{Request: RecordedRequest{Method: "POST", Path: "/api/v2/features", Query: "a=1&b=2", Body: "x"}, Response: RecordedResponse{Status: 200, Body: "body x"}},
+		{Request: RecordedRequest{Method: "POST", Path: "/api/v2/features", Query: "a=1&b=2", Body: "y"}, Response: RecordedResponse{Status: 200, Body: "body y"}},
+	}}
+	var unmatched []string
+	handler := NewReplayHandler(recording)
+	handler.OnUnmatched = func(req *http.Request) { unmatched = append(unmatched, req.URL.Path) }
+
+	serve := func(method, target, body string) (int, string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec.Code, rec.Body.String()
+	}
+
+	// Repeated requests are answered in order, then by the last response
+	_, body := serve("GET", "/api/_health", "")
+	assert.Equal(t, "first", body)
+	_, body = serve("GET", "/api/_health", "")
+	assert.Equal(t, "second", body)
+	_, body = serve("GET", "/api/_health", "")
+	assert.Equal(t, "second", body)
+
+	// Query order does not matter and the body selects the interaction
+	_, body = serve("POST", "/api/v2/features?b=2&a=1", "y")
+	assert.Equal(t, "body y", body)
+	_, body = serve("POST", "/api/v2/features?a=1&b=2", "x")
+	assert.Equal(t, "body x", body)
+
+	code, body := serve("GET", "/api/admin/tenants", "")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, body, "no recorded response for GET /api/admin/tenants")
+	assert.Equal(t, []string{"/api/admin/tenants"}, unmatched)
+}
+
+func TestLoadRecording_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadRecording(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":2,"interactions":[]}`), 0600))
+	_, err = LoadRecording(path)
+	assert.ErrorContains(t, err, "unsupported recording version 2")
+}
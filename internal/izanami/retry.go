@@ -0,0 +1,108 @@
+package izanami
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Retry defaults.
+// Failed idempotent requests are retried with an exponential backoff starting
+// at retryBaseWait and capped at the configured max wait (retry-max-wait).
+const (
+	DefaultRetries      = 3
+	DefaultRetryMaxWait = 30 // seconds
+)
+
+// retryBaseWait is the wait before the first retry, doubled on each attempt
+// (variable so tests can shorten it)
+var retryBaseWait = 1 * time.Second
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	Retries int           // Number of retries after the first attempt (0 disables retries)
+	MaxWait time.Duration // Upper bound for a single wait between attempts
+}
+
+// retryPolicyFromConfig builds the retry policy of a resolved config.
+// A zero max wait falls back to the default so hand-built configs keep a sane cap.
+func retryPolicyFromConfig(config *ResolvedConfig) RetryPolicy {
+	maxWait := time.Duration(config.RetryMaxWait) * time.Second
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait * time.Second
+	}
+	retries := config.Retries
+	if retries < 0 {
+		retries = 0
+	}
+	return RetryPolicy{Retries: retries, MaxWait: maxWait}
+}
+
+// configureRetries installs the retry policy on a resty client.
+//
+// IMPORTANT: Only idempotent methods (GET, HEAD, OPTIONS) are retried, on network
+// errors, 429 Too Many Requests or 5xx responses. Non-idempotent methods (POST,
+// PUT, DELETE, PATCH) are NOT retried to avoid creating duplicate resources or
+// applying the same modification multiple times.
+func configureRetries(client *resty.Client, policy RetryPolicy) {
+	client.
+		SetRetryCount(policy.Retries).
+		SetRetryWaitTime(retryBaseWait).
+		SetRetryMaxWaitTime(policy.MaxWait).
+		SetRetryAfter(func(_ *resty.Client, r *resty.Response) (time.Duration, error) {
+			// Zero means "no hint": resty then uses its jittered exponential backoff
+			return parseRetryAfter(r.Header().Get("Retry-After"), time.Now()), nil
+		}).
+		AddRetryCondition(shouldRetry)
+}
+
+// shouldRetry reports whether a failed request can safely be sent again
+func shouldRetry(r *resty.Response, err error) bool {
+	if r == nil {
+		// A request middleware failed before sending (circuit open, body
+		// serialization): resty marks it as not retryable
+		return false
+	}
+	if r.Request == nil {
+		// Network error, safe to retry
+		return err != nil
+	}
+	if !isIdempotentMethod(r.Request.Method) {
+		return false
+	}
+	return err != nil || r.StatusCode() == http.StatusTooManyRequests || r.StatusCode() >= 500
+}
+
+// isIdempotentMethod reports whether requests with this method are retried
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter returns the delay requested by a Retry-After header,
+// given either as a number of seconds or as an HTTP date. It returns 0
+// when the header is missing, invalid or already in the past.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -0,0 +1,166 @@
+package izanami
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortenRetryWait makes retries fast for the duration of a test
+func shortenRetryWait(t *testing.T) {
+	t.Helper()
+	previous := retryBaseWait
+	retryBaseWait = time.Millisecond
+	t.Cleanup(func() { retryBaseWait = previous })
+}
+
+// countingServer returns a server answering with status and the number of hits received
+func countingServer(t *testing.T, status int, header http.Header) (*httptest.Server, *int32) {
+	t.Helper()
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestRetry_IdempotentRequestsAreRetried(t *testing.T) {
+	shortenRetryWait(t)
+	server, hits := countingServer(t, http.StatusServiceUnavailable, nil)
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{Retries: 2, MaxWait: 10 * time.Millisecond})
+	_, err := client.R().SetContext(context.Background()).Get("/api/test")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(hits), "first attempt + 2 retries")
+}
+
+func TestRetry_NonIdempotentRequestsAreNotRetried(t *testing.T) {
+	shortenRetryWait(t)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			server, hits := countingServer(t, http.StatusServiceUnavailable, nil)
+
+			client := newHTTPClient(server.URL, 5, false, RetryPolicy{Retries: 3, MaxWait: 10 * time.Millisecond})
+			_, err := client.R().SetContext(context.Background()).Execute(method, "/api/test")
+			require.NoError(t, err)
+
+			assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+		})
+	}
+}
+
+func TestRetry_ClientErrorsAreNotRetried(t *testing.T) {
+	shortenRetryWait(t)
+	server, hits := countingServer(t, http.StatusNotFound, nil)
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{Retries: 3, MaxWait: 10 * time.Millisecond})
+	_, err := client.R().SetContext(context.Background()).Get("/api/test")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+func TestRetry_ZeroRetriesDisablesRetrying(t *testing.T) {
+	shortenRetryWait(t)
+	server, hits := countingServer(t, http.StatusBadGateway, nil)
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{Retries: 0, MaxWait: 10 * time.Millisecond})
+	_, err := client.R().SetContext(context.Background()).Get("/api/test")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	shortenRetryWait(t)
+	server, hits := countingServer(t, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}})
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{Retries: 1, MaxWait: 5 * time.Second})
+	start := time.Now()
+	_, err := client.R().SetContext(context.Background()).Get("/api/test")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "should wait for Retry-After")
+}
+
+func TestRetry_RetryAfterIsCappedByMaxWait(t *testing.T) {
+	shortenRetryWait(t)
+	server, hits := countingServer(t, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"120"}})
+
+	client := newHTTPClient(server.URL, 5, false, RetryPolicy{Retries: 1, MaxWait: 20 * time.Millisecond})
+	start := time.Now()
+	_, err := client.R().SetContext(context.Background()).Get("/api/test")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"seconds with spaces", " 2 ", 2 * time.Second},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-3", 0},
+		{"http date", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{"past http date", now.Add(-10 * time.Second).Format(http.TimeFormat), 0},
+		{"invalid", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.header, now))
+		})
+	}
+}
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	t.Run("uses configured values", func(t *testing.T) {
+		policy := retryPolicyFromConfig(&ResolvedConfig{Retries: 5, RetryMaxWait: 10})
+		assert.Equal(t, RetryPolicy{Retries: 5, MaxWait: 10 * time.Second}, policy)
+	})
+
+	t.Run("defaults max wait when unset", func(t *testing.T) {
+		policy := retryPolicyFromConfig(&ResolvedConfig{Retries: 1})
+		assert.Equal(t, DefaultRetryMaxWait*time.Second, policy.MaxWait)
+	})
+
+	t.Run("negative retries disable retrying", func(t *testing.T) {
+		policy := retryPolicyFromConfig(&ResolvedConfig{Retries: -1})
+		assert.Equal(t, 0, policy.Retries)
+	})
+}
+
+func TestMergeWithFlags_Retries(t *testing.T) {
+	cfg := &ResolvedConfig{Retries: DefaultRetries, RetryMaxWait: DefaultRetryMaxWait}
+
+	cfg.MergeWithFlags(FlagValues{})
+	assert.Equal(t, DefaultRetries, cfg.Retries, "unset flag keeps config value")
+	assert.Equal(t, DefaultRetryMaxWait, cfg.RetryMaxWait)
+
+	zero := 0
+	cfg.MergeWithFlags(FlagValues{Retries: &zero, RetryMaxWait: 5})
+	assert.Equal(t, 0, cfg.Retries, "--retries 0 disables retries")
+	assert.Equal(t, 5, cfg.RetryMaxWait)
+}
@@ -0,0 +1,236 @@
+package izanami
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// SCHEDULED JOBS (iz schedule)
+// ============================================================================
+
+// scheduledJobIDLayout formats the due time at the start of job IDs, so that
+// IDs sort by due time
+const scheduledJobIDLayout = "20060102-1504"
+
+// scheduledJobOutputLimit is the number of bytes of output kept per job
+const scheduledJobOutputLimit = 4096
+
+// Scheduled job statuses
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobDone      = "done"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// ScheduledJob is an iz command to run at a given time by 'iz schedule run'
+type ScheduledJob struct {
+	ID string `json:"id"`
+	// At is the time the job is due, in RFC 3339
+	At        string `json:"at"`
+	CreatedAt string `json:"createdAt"`
+	// Args are the arguments of the command, without the leading "iz"
+	Args []string `json:"args"`
+	// Profile is the profile the command runs with, when set
+	Profile  string `json:"profile,omitempty"`
+	Status   string `json:"status"`
+	RanAt    string `json:"ranAt,omitempty"`
+	ExitCode int    `json:"exitCode,omitempty"`
+	// Output is the end of the combined output of the command
+	Output string `json:"output,omitempty"`
+}
+
+// ScheduledJobSummary describes a scheduled job for listing
+type ScheduledJobSummary struct {
+	ID      string `json:"id"`
+	At      string `json:"at"`
+	Command string `json:"command"`
+	Profile string `json:"profile"`
+	Status  string `json:"status"`
+	RanAt   string `json:"ranAt"`
+}
+
+// Command returns the command line of the job
+func (j *ScheduledJob) Command() string {
+	quoted := make([]string, len(j.Args))
+	for i, arg := range j.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'\\") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return "iz " + strings.Join(quoted, " ")
+}
+
+// Summary returns the listing summary of a job
+func (j *ScheduledJob) Summary() ScheduledJobSummary {
+	return ScheduledJobSummary{ID: j.ID, At: j.At, Command: j.Command(), Profile: j.Profile, Status: j.Status, RanAt: j.RanAt}
+}
+
+// DueAt returns the time the job is due
+func (j *ScheduledJob) DueAt() (time.Time, error) {
+	at, err := time.Parse(time.RFC3339, j.At)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due time of job %s: %w", j.ID, err)
+	}
+	return at, nil
+}
+
+// Finish records the result of a run of the job, keeping the end of its output
+func (j *ScheduledJob) Finish(at time.Time, output []byte, exitCode int) {
+	j.RanAt = at.UTC().Format(time.RFC3339)
+	j.ExitCode = exitCode
+	j.Status = JobDone
+	if exitCode != 0 {
+		j.Status = JobFailed
+	}
+	if len(output) > scheduledJobOutputLimit {
+		output = output[len(output)-scheduledJobOutputLimit:]
+	}
+	j.Output = strings.TrimSpace(string(output))
+}
+
+// scheduledJobsDir returns the directory of the scheduled jobs
+func scheduledJobsDir() string {
+	return filepath.Join(getConfigDir(), "schedule")
+}
+
+// scheduledJobPath returns the file of a job
+func scheduledJobPath(id string) string {
+	return filepath.Join(scheduledJobsDir(), id+".json")
+}
+
+// AddScheduledJob saves a new pending job due at the given time. name is
+// appended to its ID, e.g. "features-toggle".
+func AddScheduledJob(at time.Time, name string, args []string, profile string, now time.Time) (*ScheduledJob, error) {
+	job := &ScheduledJob{
+		ID:        at.UTC().Format(scheduledJobIDLayout) + "-" + name,
+		At:        at.Format(time.RFC3339),
+		CreatedAt: now.UTC().Format(time.RFC3339),
+		Args:      args,
+		Profile:   profile,
+		Status:    JobPending,
+	}
+
+	// Two jobs due in the same minute get distinct IDs
+	base := job.ID
+	for i := 2; ; i++ {
+		if _, err := os.Stat(scheduledJobPath(job.ID)); os.IsNotExist(err) {
+			break
+		}
+		job.ID = fmt.Sprintf("%s-%d", base, i)
+	}
+	if err := SaveScheduledJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// SaveScheduledJob writes a job, readable by the user only
+func SaveScheduledJob(job *ScheduledJob) error {
+	if err := os.MkdirAll(scheduledJobsDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize job: %w", err)
+	}
+	if err := os.WriteFile(scheduledJobPath(job.ID), data, 0600); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+// LoadScheduledJob reads a job by ID
+func LoadScheduledJob(id string) (*ScheduledJob, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid job ID %q", id)
+	}
+	data, err := os.ReadFile(scheduledJobPath(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("job %s not found (see 'iz schedule list')", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job: %w", err)
+	}
+	var job ScheduledJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("invalid job %s: %w", id, err)
+	}
+	return &job, nil
+}
+
+// ListScheduledJobs returns the scheduled jobs, by due time
+func ListScheduledJobs() ([]*ScheduledJob, error) {
+	entries, err := os.ReadDir(scheduledJobsDir())
+	if os.IsNotExist(err) {
+		return []*ScheduledJob{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule directory: %w", err)
+	}
+	jobs := []*ScheduledJob{}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		job, err := LoadScheduledJob(id)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs, nil
+}
+
+// DueScheduledJobs returns the pending jobs due at now, oldest first
+func DueScheduledJobs(now time.Time) ([]*ScheduledJob, error) {
+	jobs, err := ListScheduledJobs()
+	if err != nil {
+		return nil, err
+	}
+	due := []*ScheduledJob{}
+	for _, job := range jobs {
+		if job.Status != JobPending {
+			continue
+		}
+		at, err := job.DueAt()
+		if err != nil {
+			return nil, err
+		}
+		if !at.After(now) {
+			due = append(due, job)
+		}
+	}
+	return due, nil
+}
+
+// LockScheduledJobs prevents concurrent runs of the due jobs, e.g. when a
+// cron run lasts longer than its interval. The returned function releases the
+// lock.
+func LockScheduledJobs() (func(), error) {
+	if err := os.MkdirAll(scheduledJobsDir(), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+	path := filepath.Join(scheduledJobsDir(), ".lock")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("scheduled jobs are already running (remove %s if no 'iz schedule run' is in progress)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock scheduled jobs: %w", err)
+	}
+	fmt.Fprintf(f, "%d\n", os.Getpid())
+	f.Close()
+	return func() { os.Remove(path) }, nil
+}
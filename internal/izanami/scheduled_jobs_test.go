@@ -0,0 +1,83 @@
+package izanami
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledJobs(t *testing.T) {
+	useTempSnapshotsDir(t)
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	job, err := AddScheduledJob(at, "features-toggle", []string{"admin", "features", "toggle", "launch banner", "--enable"}, "prod", now)
+	require.NoError(t, err)
+	assert.Equal(t, "20240701-0900-features-toggle", job.ID)
+	assert.Equal(t, JobPending, job.Status)
+	assert.Equal(t, "iz admin features toggle 'launch banner' --enable", job.Command())
+
+	info, err := os.Stat(scheduledJobPath(job.ID))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A second job due in the same minute gets a distinct ID
+	second, err := AddScheduledJob(at, "features-toggle", []string{"admin", "features", "toggle", "search"}, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, "20240701-0900-features-toggle-2", second.ID)
+	early, err := AddScheduledJob(at.Add(-time.Hour), "features-delete", []string{"admin", "features", "delete", "old"}, "", now)
+	require.NoError(t, err)
+
+	jobs, err := ListScheduledJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 3)
+	assert.Equal(t, early.ID, jobs[0].ID, "jobs are listed by due time")
+
+	due, err := DueScheduledJobs(at.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, early.ID, due[0].ID)
+
+	second.Status = JobCancelled
+	require.NoError(t, SaveScheduledJob(second))
+	due, err = DueScheduledJobs(at)
+	require.NoError(t, err)
+	assert.Len(t, due, 2, "cancelled jobs are not due")
+
+	_, err = LoadScheduledJob("../config")
+	assert.ErrorContains(t, err, "invalid job ID")
+	_, err = LoadScheduledJob("missing")
+	assert.ErrorContains(t, err, "job missing not found")
+}
+
+func TestScheduledJobFinish(t *testing.T) {
+	job := &ScheduledJob{Status: JobRunning}
+	job.Finish(time.Date(2024, 7, 1, 9, 0, 30, 0, time.UTC), []byte("Feature enabled\n"), 0)
+	assert.Equal(t, JobDone, job.Status)
+	assert.Equal(t, "2024-07-01T09:00:30Z", job.RanAt)
+	assert.Equal(t, "Feature enabled", job.Output)
+
+	job.Finish(time.Now(), []byte(strings.Repeat("x", scheduledJobOutputLimit)+"end"), 4)
+	assert.Equal(t, JobFailed, job.Status)
+	assert.Equal(t, 4, job.ExitCode)
+	assert.Len(t, job.Output, scheduledJobOutputLimit)
+	assert.True(t, strings.HasSuffix(job.Output, "end"), "the end of the output is kept")
+}
+
+func TestLockScheduledJobs(t *testing.T) {
+	useTempSnapshotsDir(t)
+
+	unlock, err := LockScheduledJobs()
+	require.NoError(t, err)
+	_, err = LockScheduledJobs()
+	assert.ErrorContains(t, err, "already running")
+
+	unlock()
+	unlock, err = LockScheduledJobs()
+	require.NoError(t, err)
+	unlock()
+}
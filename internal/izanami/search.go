@@ -44,7 +44,7 @@ func (c *AdminClient) searchRaw(ctx context.Context, tenant, query string, filte
 
 	resp, err := req.Get(path)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToSearch, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToSearch), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -0,0 +1,156 @@
+package izanami
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/webskin/izanami-go-cli/internal/update"
+)
+
+// ============================================================================
+// SERVER CAPABILITIES
+// ============================================================================
+
+// ServerInfoTTL is how long a detected server version is reused before the
+// server is asked again
+const ServerInfoTTL = time.Hour
+
+// serverInfoCacheDirName is the sub-directory of the cache dir holding detected server versions
+const serverInfoCacheDirName = "servers"
+
+// ServerCapability is a group of endpoints only available from a given Izanami version
+type ServerCapability struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinVersion  string `json:"minVersion"`
+	// RoutePrefixes are the paths of the endpoints, as in command "route" annotations
+	RoutePrefixes []string `json:"-"`
+}
+
+// ServerCapabilities lists the endpoints the CLI uses that older Izanami 2.x
+// servers do not expose. Endpoints not listed are available from 2.0.0.
+var ServerCapabilities = []ServerCapability{
+	{
+		Name:          "webhooks",
+		Description:   "Webhooks",
+		MinVersion:    "2.2.0",
+		RoutePrefixes: []string{"/api/admin/tenants/:tenant/webhooks"},
+	},
+	{
+		Name:          "search",
+		Description:   "Global search",
+		MinVersion:    "2.3.0",
+		RoutePrefixes: []string{"/api/admin/tenants/:tenant/search", "/api/admin/search"},
+	},
+	{
+		Name:          "audit-logs",
+		Description:   "Audit logs",
+		MinVersion:    "2.4.0",
+		RoutePrefixes: []string{"/api/admin/tenants/:tenant/logs", "/api/admin/tenants/:tenant/projects/:project/logs"},
+	},
+}
+
+// ServerInfo is the detected version of an Izanami server
+type ServerInfo struct {
+	URL        string    `json:"url"`
+	Version    string    `json:"version,omitempty"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// CapabilityForRoute returns the capability a command route ("GET /api/admin/...")
+// requires, nil when the route is available on every Izanami 2.x server
+func CapabilityForRoute(route string) *ServerCapability {
+	path := route
+	if _, p, ok := strings.Cut(route, " "); ok {
+		path = p
+	}
+	for i := range ServerCapabilities {
+		for _, prefix := range ServerCapabilities[i].RoutePrefixes {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return &ServerCapabilities[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Supports reports whether the server has a capability. known is false when the
+// server does not report its version; callers should then let the request go.
+func (s *ServerInfo) Supports(capability *ServerCapability) (supported, known bool) {
+	if s == nil || s.Version == "" {
+		return true, false
+	}
+	return update.CompareVersions(s.Version, capability.MinVersion) >= 0, true
+}
+
+// RequireCapability returns an error when the server is known to lack the
+// capability needed by command
+func (s *ServerInfo) RequireCapability(capability *ServerCapability, command string) error {
+	if supported, _ := s.Supports(capability); supported {
+		return nil
+	}
+	return fmt.Errorf("%s requires Izanami server >= %s (%s), but %s runs %s",
+		command, capability.MinVersion, strings.ToLower(capability.Description), s.URL, s.Version)
+}
+
+// DetectServerInfo returns the version of the server of c. A version detected
+// less than ServerInfoTTL ago is read from the cache dir unless refresh is set.
+func DetectServerInfo(c *AdminClient, ctx context.Context, refresh bool) (*ServerInfo, error) {
+	url := c.config.LeaderURL
+	path := serverInfoCachePath(url)
+	if !refresh {
+		if info, ok := loadServerInfo(path, url); ok {
+			return info, nil
+		}
+	}
+
+	health, err := Health(c, ctx, ParseHealthStatus)
+	if err != nil {
+		return nil, err
+	}
+	info := &ServerInfo{URL: url, Version: health.Version, DetectedAt: time.Now().UTC()}
+	// A cache that cannot be written must never fail the command
+	_ = storeServerInfo(path, info)
+	return info, nil
+}
+
+// serverInfoCachePath returns the cache file of the server at url
+func serverInfoCachePath(url string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSuffix(url, "/")))
+	return filepath.Join(getCacheDir(), serverInfoCacheDirName, hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadServerInfo reads a cached server info if it is still fresh
+func loadServerInfo(path, url string) (*ServerInfo, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var info ServerInfo
+	if err := json.Unmarshal(data, &info); err != nil || info.URL != url {
+		return nil, false
+	}
+	if time.Since(info.DetectedAt) > ServerInfoTTL {
+		return nil, false
+	}
+	return &info, true
+}
+
+// storeServerInfo writes a server info to the cache with owner-only permissions
+func storeServerInfo(path string, info *ServerInfo) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
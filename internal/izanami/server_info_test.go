@@ -0,0 +1,72 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilityForRoute(t *testing.T) {
+	capability := CapabilityForRoute("GET /api/admin/tenants/:tenant/webhooks/:id")
+	require.NotNil(t, capability)
+	assert.Equal(t, "webhooks", capability.Name)
+
+	capability = CapabilityForRoute("GET /api/admin/tenants/:tenant/projects/:project/logs")
+	require.NotNil(t, capability)
+	assert.Equal(t, "audit-logs", capability.Name)
+
+	assert.Nil(t, CapabilityForRoute("GET /api/admin/tenants/:tenant/features"))
+	assert.Nil(t, CapabilityForRoute(""))
+}
+
+func TestServerInfo_Supports(t *testing.T) {
+	webhooks := CapabilityForRoute("GET /api/admin/tenants/:tenant/webhooks")
+
+	supported, known := (&ServerInfo{Version: "2.1.0"}).Supports(webhooks)
+	assert.False(t, supported)
+	assert.True(t, known)
+
+	supported, known = (&ServerInfo{Version: webhooks.MinVersion}).Supports(webhooks)
+	assert.True(t, supported)
+	assert.True(t, known)
+
+	supported, known = (&ServerInfo{}).Supports(webhooks)
+	assert.True(t, supported)
+	assert.False(t, known)
+
+	err := (&ServerInfo{URL: "http://izanami", Version: "2.1.0"}).RequireCapability(webhooks, "iz admin webhooks list")
+	assert.ErrorContains(t, err, "iz admin webhooks list requires Izanami server >= "+webhooks.MinVersion)
+	assert.ErrorContains(t, err, "http://izanami runs 2.1.0")
+	assert.NoError(t, (&ServerInfo{}).RequireCapability(webhooks, "iz admin webhooks list"))
+}
+
+func TestDetectServerInfo_Cached(t *testing.T) {
+	useTempCacheDir(t)
+	var calls int32
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(HealthStatus{Database: true, Version: "2.5.0"})
+	})
+	defer server.Close()
+
+	client, err := NewAdminClientNoAuth(&ResolvedConfig{LeaderURL: server.URL, Timeout: 30})
+	require.NoError(t, err)
+
+	info, err := DetectServerInfo(client, context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "2.5.0", info.Version)
+	assert.Equal(t, server.URL, info.URL)
+
+	_, err = DetectServerInfo(client, context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = DetectServerInfo(client, context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/webskin/izanami-go-cli/internal/errors"
+	"github.com/webskin/izanami-go-cli/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -39,16 +40,36 @@ type Sessions struct {
 // getSessionsPath is a variable that returns the path to the sessions file
 // It's a variable (not a function) to allow tests to override it
 var getSessionsPath = func() string {
-	var sessionsPath string
+	return sessionsPathFor(runtime.GOOS)
+}
 
-	switch runtime.GOOS {
-	case "windows":
-		sessionsPath = filepath.Join(os.Getenv("USERPROFILE"), ".izsessions")
-	default: // linux, darwin, etc.
-		sessionsPath = filepath.Join(os.Getenv("HOME"), ".izsessions")
+// sessionsPathFor returns the sessions file for an OS: ~/.izsessions, or
+// sessions.yaml in the config directory (%APPDATA%\iz) on Windows, where a
+// ~/.izsessions left by older versions is moved on first use
+func sessionsPathFor(goos string) string {
+	legacy := filepath.Join(homeDir(), ".izsessions")
+	if goos != "windows" {
+		return legacy
 	}
+	return migrateSessionsFile(legacy, filepath.Join(getConfigDir(), "sessions.yaml"))
+}
 
-	return sessionsPath
+// migrateSessionsFile moves the sessions file from legacy to path when only the
+// legacy one exists, and returns the path to use: legacy if it cannot be moved
+func migrateSessionsFile(legacy, path string) string {
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return path
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return legacy
+	}
+	if err := os.Rename(legacy, path); err != nil {
+		return legacy
+	}
+	return path
 }
 
 // GetSessionsPath returns the path to the sessions file
@@ -73,12 +94,12 @@ func LoadSessions() (*Sessions, error) {
 				Sessions: make(map[string]*Session),
 			}, nil
 		}
-		return nil, fmt.Errorf("%s: %w", errors.MsgFailedToReadSessionsFile, err)
+		return nil, fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToReadSessionsFile), err)
 	}
 
 	var sessions Sessions
 	if err := yaml.Unmarshal(data, &sessions); err != nil {
-		return nil, fmt.Errorf("%s: %w", errors.MsgFailedToParseSessionsFile, err)
+		return nil, fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToParseSessionsFile), err)
 	}
 
 	if sessions.Sessions == nil {
@@ -94,12 +115,17 @@ func (s *Sessions) Save() error {
 
 	data, err := yaml.Marshal(s)
 	if err != nil {
-		return fmt.Errorf("%s: %w", errors.MsgFailedToMarshalSessions, err)
+		return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToMarshalSessions), err)
 	}
 
-	// Create with restricted permissions (600)
-	if err := os.WriteFile(sessionsPath, data, 0600); err != nil {
-		return fmt.Errorf("%s: %w", errors.MsgFailedToWriteSessionsFile, err)
+	// The config directory holding the file on Windows may not exist yet
+	if err := os.MkdirAll(filepath.Dir(sessionsPath), 0700); err != nil {
+		return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToWriteSessionsFile), err)
+	}
+
+	// Create with restricted permissions (600), atomically so a crash never leaves a truncated file
+	if err := utils.WriteFileAtomic(sessionsPath, data, 0600); err != nil {
+		return fmt.Errorf("%s: %w", errors.T(errors.MsgFailedToWriteSessionsFile), err)
 	}
 
 	return nil
@@ -117,7 +143,7 @@ func (s *Sessions) AddSession(name string, session *Session) {
 func (s *Sessions) GetSession(name string) (*Session, error) {
 	session, ok := s.Sessions[name]
 	if !ok {
-		return nil, fmt.Errorf(errors.MsgSessionNotFound, name)
+		return nil, errors.Errorf(errors.MsgSessionNotFound, name)
 	}
 	return session, nil
 }
@@ -125,7 +151,7 @@ func (s *Sessions) GetSession(name string) (*Session, error) {
 // DeleteSession removes a session
 func (s *Sessions) DeleteSession(name string) error {
 	if _, ok := s.Sessions[name]; !ok {
-		return fmt.Errorf(errors.MsgSessionNotFound, name)
+		return errors.Errorf(errors.MsgSessionNotFound, name)
 	}
 
 	delete(s.Sessions, name)
@@ -133,9 +159,18 @@ func (s *Sessions) DeleteSession(name string) error {
 	return nil
 }
 
-// IsTokenExpired checks if a token is likely expired
-// JWT tokens contain expiry info, but for simplicity we check age
+// ExpiresAt returns the expiry time of the session token (from the JWT exp claim).
+// It returns false if the token does not carry an expiry.
+func (s *Session) ExpiresAt() (time.Time, bool) {
+	return JWTExpiry(s.JwtToken)
+}
+
+// IsTokenExpired checks if a token is likely expired.
+// The JWT exp claim is used when present, otherwise the token age is compared to maxAge.
 func (s *Session) IsTokenExpired(maxAge time.Duration) bool {
+	if exp, ok := s.ExpiresAt(); ok {
+		return !time.Now().Before(exp)
+	}
 	if maxAge == 0 {
 		maxAge = 24 * time.Hour // Default: tokens valid for 24 hours
 	}
@@ -163,7 +198,7 @@ func LoadConfigFromSession(sessionName string) (*ResolvedConfig, error) {
 	fileConfig, err := LoadConfig()
 	if err != nil {
 		// If config file doesn't exist, create a minimal config
-		fileConfig = &Config{Timeout: 30}
+		fileConfig = &Config{Timeout: 30, Retries: DefaultRetries, RetryMaxWait: DefaultRetryMaxWait}
 	}
 
 	resolved := NewResolvedConfig(fileConfig)
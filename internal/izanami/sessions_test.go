@@ -89,6 +89,36 @@ func TestGetSessionsPath_Override(t *testing.T) {
 	assert.Equal(t, customPath, GetSessionsPath())
 }
 
+func TestSessionsPathFor_WindowsMigratesLegacyFile(t *testing.T) {
+	paths := setupSessionTestPaths(t)
+	overrideSessionPathFunctions(t, paths)
+	t.Setenv("HOME", paths.homeDir)
+	require.NoError(t, os.WriteFile(paths.sessionsPath, []byte("sessions: {}\n"), 0600))
+
+	assert.Equal(t, paths.sessionsPath, sessionsPathFor("linux"))
+
+	path := sessionsPathFor("windows")
+	assert.Equal(t, filepath.Join(paths.configDir, "sessions.yaml"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "sessions: {}\n", string(data))
+	assert.NoFileExists(t, paths.sessionsPath, "the legacy file should be moved")
+
+	// Once migrated, the file in the config directory is kept
+	assert.Equal(t, path, sessionsPathFor("windows"))
+}
+
+func TestMigrateSessionsFile_KeepsExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	legacy, path := filepath.Join(dir, ".izsessions"), filepath.Join(dir, "iz", "sessions.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(legacy, []byte("old"), 0600))
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0600))
+
+	assert.Equal(t, path, migrateSessionsFile(legacy, path))
+	assert.FileExists(t, legacy, "the legacy file is left alone when both exist")
+}
+
 func TestLoadSessions_NoFile(t *testing.T) {
 	paths := setupSessionTestPaths(t)
 	overrideSessionPathFunctions(t, paths)
@@ -0,0 +1,130 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	errmsg "github.com/webskin/izanami-go-cli/internal/errors"
+)
+
+// ============================================================================
+// STREAMED LISTINGS
+// ============================================================================
+
+// ItemHandler receives the items of a streamed listing one by one, as they are
+// decoded from the response
+type ItemHandler func(item json.RawMessage) error
+
+// StreamFeatures calls handle for each feature of a tenant while the response
+// is received, instead of buffering the whole listing
+func StreamFeatures(c *AdminClient, ctx context.Context, tenant, tag string, handle ItemHandler) error {
+	path := apiAdminTenants + buildPath(tenant, "features")
+	return c.stream(c.listFeaturesRequest(ctx, tag), path, errmsg.T(errmsg.MsgFailedToListFeatures), func(body io.Reader) error {
+		return decodeJSONArray(body, handle)
+	})
+}
+
+// StreamUsers calls handle for each visible user while the response is received
+func StreamUsers(c *AdminClient, ctx context.Context, handle ItemHandler) error {
+	req := c.http.R().SetContext(ctx)
+	c.setAdminAuth(req)
+	return c.stream(req, "/api/admin/users", errmsg.T(errmsg.MsgFailedToListUsers), func(body io.Reader) error {
+		return decodeJSONArray(body, handle)
+	})
+}
+
+// StreamTestFeaturesBulk calls handle for each result of a bulk feature test
+// while the response is received. The server returns an object keyed by feature
+// ID; each result is passed with its key as an "id" field.
+func StreamTestFeaturesBulk(c *AdminClient, ctx context.Context, tenant string, request TestFeaturesAdminRequest, handle ItemHandler) error {
+	path := apiAdminTenants + buildPath(tenant, "features", "_test")
+	return c.stream(c.testFeaturesBulkRequest(ctx, request), path, errmsg.T(errmsg.MsgFailedToTestFeaturesBulk), func(body io.Reader) error {
+		return decodeJSONObject(body, func(key string, value json.RawMessage) error {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(value, &fields); err != nil {
+				return fmt.Errorf("failed to parse result of %s: %w", key, err)
+			}
+			id, _ := json.Marshal(key)
+			fields["id"] = id
+			item, err := json.Marshal(fields)
+			if err != nil {
+				return err
+			}
+			return handle(item)
+		})
+	})
+}
+
+// stream sends a GET request without buffering the response and passes the
+// body of a successful response to decode
+func (c *AdminClient) stream(req *resty.Request, path, failure string, decode func(body io.Reader) error) error {
+	resp, err := req.SetDoNotParseResponse(true).Get(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", failure, err)
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.StatusCode() != http.StatusOK {
+		raw, _ := io.ReadAll(body)
+		return apiErrorFromBody(resp.StatusCode(), raw)
+	}
+	return decode(body)
+}
+
+// decodeJSONArray calls handle for each element of the JSON array read from r
+func decodeJSONArray(r io.Reader, handle ItemHandler) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		if err := handle(item); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// decodeJSONObject calls handle for each member of the JSON object read from r
+func decodeJSONObject(r io.Reader, handle func(key string, value json.RawMessage) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		if err := handle(key, value); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to parse response: expected %s, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamFeatures(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features", r.URL.Path)
+		assert.Equal(t, "beta", r.URL.Query().Get("tag"))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"id":"f1","name":"a"}, {"id":"f2","name":"b"}]`)
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	var items []string
+	err = StreamFeatures(client, context.Background(), "acme", "beta", func(item json.RawMessage) error {
+		items = append(items, string(item))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"f1","name":"a"}`, `{"id":"f2","name":"b"}`}, items)
+}
+
+func TestStreamFeatures_APIError(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"not allowed"}`)
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	err = StreamFeatures(client, context.Background(), "acme", "", func(json.RawMessage) error { return nil })
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "not allowed", apiErr.Message)
+}
+
+func TestStreamTestFeaturesBulk(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features/_test", r.URL.Path)
+		assert.Equal(t, "alice", r.URL.Query().Get("user"))
+		io.WriteString(w, `{"f1":{"name":"a","active":true,"project":"shop"}}`)
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 30})
+	require.NoError(t, err)
+
+	var items []string
+	err = StreamTestFeaturesBulk(client, context.Background(), "acme", TestFeaturesAdminRequest{User: "alice"}, func(item json.RawMessage) error {
+		items = append(items, string(item))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"active":true,"id":"f1","name":"a","project":"shop"}`}, items)
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	count := 0
+	handle := func(json.RawMessage) error {
+		count++
+		return nil
+	}
+
+	require.NoError(t, decodeJSONArray(strings.NewReader(`[]`), handle))
+	assert.Equal(t, 0, count)
+
+	assert.ErrorContains(t, decodeJSONArray(strings.NewReader(`{"id":1}`), handle), "expected [")
+	assert.ErrorContains(t, decodeJSONArray(strings.NewReader(`[{"id":1},`), handle), "failed to parse response")
+	assert.Equal(t, 1, count, "items before a malformed one are handled")
+
+	stop := errors.New("stop")
+	assert.Equal(t, stop, decodeJSONArray(strings.NewReader(`[1,2]`), func(json.RawMessage) error { return stop }))
+}
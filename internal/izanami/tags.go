@@ -2,6 +2,7 @@ package izanami
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -32,7 +33,7 @@ func (c *AdminClient) listTagsRaw(ctx context.Context, tenant string) ([]byte, e
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListTags, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListTags), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -62,7 +63,7 @@ func (c *AdminClient) getTagRaw(ctx context.Context, tenant, tagName string) ([]
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetTag, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetTag), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -85,7 +86,7 @@ func (c *AdminClient) CreateTag(ctx context.Context, tenant string, tag interfac
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateTag, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateTag), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -95,6 +96,28 @@ func (c *AdminClient) CreateTag(ctx context.Context, tenant string, tag interfac
 	return nil
 }
 
+// UpdateTag replaces a tag's name and description
+func (c *AdminClient) UpdateTag(ctx context.Context, tenant, tagName string, tag interface{}) error {
+	path := apiAdminTenants + buildPath(tenant, "tags", tagName)
+
+	req := c.http.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(tag)
+	c.setAdminAuth(req)
+	resp, err := req.Put(path)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateTag), err)
+	}
+
+	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
+		return c.handleError(resp)
+	}
+
+	return nil
+}
+
 // DeleteTag deletes a tag
 func (c *AdminClient) DeleteTag(ctx context.Context, tenant, tagName string) error {
 	path := apiAdminTenants + buildPath(tenant, "tags", tagName)
@@ -104,7 +127,7 @@ func (c *AdminClient) DeleteTag(ctx context.Context, tenant, tagName string) err
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteTag, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteTag), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -113,3 +136,17 @@ func (c *AdminClient) DeleteTag(ctx context.Context, tenant, tagName string) err
 
 	return nil
 }
+
+// EnsureTag creates a tag of the tenant when it does not exist and reports
+// whether it was created
+func EnsureTag(c *AdminClient, ctx context.Context, tenant, name, description string) (bool, error) {
+	_, err := GetTag(c, ctx, tenant, name, Identity)
+	var apiErr *APIError
+	if err == nil || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return false, err
+	}
+	if err := c.CreateTag(ctx, tenant, &Tag{Name: name, Description: description}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
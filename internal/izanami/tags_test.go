@@ -122,6 +122,36 @@ func TestClient_CreateTag(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClient_UpdateTag(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/test-tenant/tags/test-tag", r.URL.Path)
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "renamed", body["name"])
+		assert.Equal(t, "New description", body["description"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	config := &ResolvedConfig{
+		LeaderURL: server.URL,
+		Username:  "test-user",
+		JwtToken:  "test-jwt-token",
+		Timeout:   30,
+	}
+
+	client, err := NewAdminClient(config)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = client.UpdateTag(ctx, "test-tenant", "test-tag", map[string]interface{}{"name": "renamed", "description": "New description"})
+
+	assert.NoError(t, err)
+}
+
 func TestClient_DeleteTag(t *testing.T) {
 	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/admin/tenants/test-tenant/tags/test-tag", r.URL.Path)
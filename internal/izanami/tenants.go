@@ -36,7 +36,7 @@ func (c *AdminClient) listTenantsRaw(ctx context.Context, right *RightLevel) ([]
 	resp, err := req.Get("/api/admin/tenants")
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListTenants, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListTenants), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -66,7 +66,7 @@ func (c *AdminClient) getTenantRaw(ctx context.Context, name string) ([]byte, er
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetTenant, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetTenant), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -87,7 +87,7 @@ func (c *AdminClient) CreateTenant(ctx context.Context, tenant interface{}) erro
 	resp, err := req.Post("/api/admin/tenants")
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateTenant, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateTenant), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -110,7 +110,7 @@ func (c *AdminClient) UpdateTenant(ctx context.Context, name string, tenant inte
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateTenant, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateTenant), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -129,7 +129,7 @@ func (c *AdminClient) DeleteTenant(ctx context.Context, name string) error {
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteTenant, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteTenant), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -194,7 +194,7 @@ func (c *AdminClient) listTenantLogsRaw(ctx context.Context, tenant string, opts
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListTenantLogs, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListTenantLogs), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -0,0 +1,374 @@
+package izanami
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// TRACE CONTEXT PROPAGATION (W3C traceparent) AND OTLP SPAN EXPORT
+// ============================================================================
+
+// Headers of the W3C trace context
+const (
+	HeaderTraceparent = "traceparent"
+	HeaderTracestate  = "tracestate"
+)
+
+// otlpTracesPath is appended to OTLP endpoints given without a path
+const otlpTracesPath = "/v1/traces"
+
+// OTLP span kinds and status codes
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindClient   = 3
+	otlpStatusError      = 2
+)
+
+// SpanContext identifies a span within a trace, as carried by traceparent
+type SpanContext struct {
+	TraceID string // 32 lowercase hex digits
+	SpanID  string // 16 lowercase hex digits
+	Sampled bool
+}
+
+// ParseTraceparent parses a version 00 W3C traceparent header value
+// ("00-<trace-id>-<parent-id>-<flags>"); ok is false when it is invalid
+func ParseTraceparent(value string) (sc SpanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || parts[0] != "00" || !isHexID(parts[1], 32) || !isHexID(parts[2], 16) || !isHexID(parts[3], 2) {
+		return SpanContext{}, false
+	}
+	flags, _ := strconv.ParseUint(parts[3], 16, 8)
+	return SpanContext{TraceID: parts[1], SpanID: parts[2], Sampled: flags&1 == 1}, true
+}
+
+// isHexID reports whether s is a non-zero ID of n lowercase hex digits
+// (flags may be zero)
+func isHexID(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	zero := true
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+		if r != '0' {
+			zero = false
+		}
+	}
+	return n == 2 || !zero
+}
+
+// Traceparent formats the span context as a traceparent header value
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID + "-" + sc.SpanID + "-" + flags
+}
+
+// randomHexID returns a random ID of n bytes as hex
+func randomHexID(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Span is a timed operation of a trace: the command, or one API call
+type Span struct {
+	Name         string
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Kind         int
+	Start        time.Time
+	End          time.Time
+	Attributes   map[string]interface{}
+	Error        string
+}
+
+// Tracer propagates the trace context of the environment to API requests and,
+// once an OTLP endpoint is set, records a span for the command and for each API
+// call so that they can be exported to a collector.
+type Tracer struct {
+	parent     SpanContext
+	hasParent  bool
+	tracestate string
+
+	mu       sync.Mutex
+	endpoint string
+	root     *Span
+	spans    []*Span
+}
+
+// NewTracer creates a tracer continuing the trace of a traceparent (typically
+// the TRACEPARENT environment variable of an instrumented CI system). An
+// invalid or empty traceparent starts a new trace when spans are exported.
+func NewTracer(traceparent, tracestate string) *Tracer {
+	parent, ok := ParseTraceparent(traceparent)
+	return &Tracer{parent: parent, hasParent: ok, tracestate: tracestate}
+}
+
+// EnableExport records the spans of the command, named name, for export to an
+// OTLP/HTTP endpoint. Spans of a trace the parent did not sample are not recorded.
+func (t *Tracer) EnableExport(endpoint, name string, attributes map[string]interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if endpoint == "" || (t.hasParent && !t.parent.Sampled) {
+		return
+	}
+	t.endpoint = endpoint
+	t.root = &Span{Name: name, SpanID: randomHexID(8), Kind: otlpSpanKindInternal, Start: time.Now(), Attributes: attributes}
+	if t.hasParent {
+		t.root.TraceID, t.root.ParentSpanID = t.parent.TraceID, t.parent.SpanID
+	} else {
+		t.root.TraceID = randomHexID(16)
+	}
+	t.spans = []*Span{t.root}
+}
+
+// Exporting reports whether spans are recorded for export
+func (t *Tracer) Exporting() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.root != nil
+}
+
+// startRequestSpan returns the traceparent to send with a request and, when
+// exporting, the span of the request (nil otherwise)
+func (t *Tracer) startRequestSpan(req *http.Request) (string, *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.root == nil {
+		// Not participating in the trace: forward the parent unchanged
+		if !t.hasParent {
+			return "", nil
+		}
+		return t.parent.Traceparent(), nil
+	}
+	span := &Span{
+		Name:         "HTTP " + req.Method,
+		TraceID:      t.root.TraceID,
+		SpanID:       randomHexID(8),
+		ParentSpanID: t.root.SpanID,
+		Kind:         otlpSpanKindClient,
+		Start:        time.Now(),
+		Attributes: map[string]interface{}{
+			"http.request.method": req.Method,
+			"url.full":            redactedURL(req.URL),
+			"server.address":      req.URL.Hostname(),
+		},
+	}
+	t.spans = append(t.spans, span)
+	return SpanContext{TraceID: span.TraceID, SpanID: span.SpanID, Sampled: true}.Traceparent(), span
+}
+
+// endSpan records the end of a span and its outcome
+func (t *Tracer) endSpan(span *Span, status int, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span.End = time.Now()
+	if status > 0 {
+		span.Attributes["http.response.status_code"] = status
+	}
+	switch {
+	case err != nil:
+		span.Error = err.Error()
+	case status >= 400:
+		span.Error = http.StatusText(status)
+	}
+}
+
+// redactedURL returns a request URL without credentials or query, which may
+// carry user IDs or tokens
+func redactedURL(u *url.URL) string {
+	cp := *u
+	cp.User = nil
+	cp.RawQuery = ""
+	return cp.String()
+}
+
+// Finish ends the command span, marking it failed when err is not nil
+func (t *Tracer) Finish(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.root == nil || !t.root.End.IsZero() {
+		return
+	}
+	t.root.End = time.Now()
+	if err != nil {
+		t.root.Error = err.Error()
+	}
+}
+
+// Export sends the recorded spans to the OTLP/HTTP endpoint as JSON.
+// It does nothing when no span was recorded.
+func (t *Tracer) Export(ctx context.Context, client *http.Client) error {
+	t.mu.Lock()
+	if t.root == nil {
+		t.mu.Unlock()
+		return nil
+	}
+	body, err := json.Marshal(otlpTraces(t.spans))
+	endpoint := otlpEndpoint(t.endpoint)
+	t.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to serialize spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid otel-endpoint: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector answered %s", resp.Status)
+	}
+	return nil
+}
+
+// otlpEndpoint appends the traces path to endpoints given without a path
+func otlpEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || strings.Trim(u.Path, "/") != "" {
+		return endpoint
+	}
+	u.Path = otlpTracesPath
+	return u.String()
+}
+
+// otlpTraces builds the OTLP/JSON export request of spans
+func otlpTraces(spans []*Span) map[string]interface{} {
+	items := make([]map[string]interface{}, len(spans))
+	for i, span := range spans {
+		end := span.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		item := map[string]interface{}{
+			"traceId":           span.TraceID,
+			"spanId":            span.SpanID,
+			"name":              span.Name,
+			"kind":              span.Kind,
+			"startTimeUnixNano": strconv.FormatInt(span.Start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(end.UnixNano(), 10),
+			"attributes":        otlpAttributes(span.Attributes),
+		}
+		if span.ParentSpanID != "" {
+			item["parentSpanId"] = span.ParentSpanID
+		}
+		if span.Error != "" {
+			item["status"] = map[string]interface{}{"code": otlpStatusError, "message": span.Error}
+		}
+		items[i] = item
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": otlpAttributes(map[string]interface{}{"service.name": "iz"}),
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "github.com/webskin/izanami-go-cli"},
+				"spans": items,
+			}},
+		}},
+	}
+}
+
+// otlpAttributes converts attributes to OTLP key/value pairs, sorted by key
+func otlpAttributes(attributes map[string]interface{}) []interface{} {
+	keys := sortedKeys(attributes)
+	pairs := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		var value map[string]interface{}
+		switch v := attributes[key].(type) {
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		pairs = append(pairs, map[string]interface{}{"key": key, "value": value})
+	}
+	return pairs
+}
+
+// tracer propagates the trace context when set (see SetTracer). Like the HTTP
+// logger, it is shared by all clients created during a command.
+var (
+	tracerMu sync.RWMutex
+	tracer   *Tracer
+)
+
+// SetTracer enables trace context propagation for clients created afterwards
+// (nil disables it)
+func SetTracer(t *Tracer) {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	tracer = t
+}
+
+// getTracer returns the current tracer, or nil when propagation is disabled
+func getTracer() *Tracer {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+	return tracer
+}
+
+// tracingTransport sends the trace context with each request and, when
+// exporting, records a span per attempt
+type tracingTransport struct {
+	base   http.RoundTripper
+	tracer *Tracer
+}
+
+// traceTransport wraps a transport with trace context propagation when a
+// tracer is set, and returns it unchanged otherwise
+func traceTransport(base http.RoundTripper) http.RoundTripper {
+	t := getTracer()
+	if t == nil {
+		return base
+	}
+	return &tracingTransport{base: base, tracer: t}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	traceparent, span := t.tracer.startRequestSpan(req)
+	if traceparent == "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderTraceparent, traceparent)
+	if t.tracer.tracestate != "" {
+		req.Header.Set(HeaderTracestate, t.tracer.tracestate)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if span != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		t.tracer.endSpan(span, status, err)
+	}
+	return resp, err
+}
@@ -0,0 +1,132 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// withTracer sets the tracer for the clients created during a test
+func withTracer(t *testing.T, tr *Tracer) {
+	SetTracer(tr)
+	t.Cleanup(func() { SetTracer(nil) })
+}
+
+// traceHeaderServer records the trace headers of the requests it receives
+func traceHeaderServer(t *testing.T, headers *[]http.Header) *AdminClient {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*headers = append(*headers, r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"database":true}`)
+	})
+	t.Cleanup(server.Close)
+	client, err := NewAdminClientNoAuth(&ResolvedConfig{LeaderURL: server.URL, Timeout: 5})
+	require.NoError(t, err)
+	return client
+}
+
+func TestParseTraceparent(t *testing.T) {
+	sc, ok := ParseTraceparent(testTraceparent)
+	require.True(t, ok)
+	assert.Equal(t, SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}, sc)
+	assert.Equal(t, testTraceparent, sc.Traceparent())
+
+	for _, invalid := range []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+	} {
+		_, ok := ParseTraceparent(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestTracer_ForwardsTraceContext(t *testing.T) {
+	withTracer(t, NewTracer(testTraceparent, "vendor=value"))
+	var headers []http.Header
+	client := traceHeaderServer(t, &headers)
+
+	_, err := Health(client, context.Background(), ParseHealthStatus)
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+	assert.Equal(t, testTraceparent, headers[0].Get(HeaderTraceparent))
+	assert.Equal(t, "vendor=value", headers[0].Get(HeaderTracestate))
+}
+
+func TestTracer_NoTraceContext(t *testing.T) {
+	withTracer(t, NewTracer("", ""))
+	var headers []http.Header
+	client := traceHeaderServer(t, &headers)
+
+	_, err := Health(client, context.Background(), ParseHealthStatus)
+	require.NoError(t, err)
+	assert.Empty(t, headers[0].Get(HeaderTraceparent))
+}
+
+func TestTracer_ExportsSpans(t *testing.T) {
+	var exported map[string]interface{}
+	collector := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, otlpTracesPath, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&exported))
+	})
+	defer collector.Close()
+
+	tr := NewTracer(testTraceparent, "")
+	tr.EnableExport(collector.URL, "iz admin health", map[string]interface{}{"iz.command": "admin health"})
+	withTracer(t, tr)
+	var headers []http.Header
+	client := traceHeaderServer(t, &headers)
+
+	_, err := Health(client, context.Background(), ParseHealthStatus)
+	require.NoError(t, err)
+	sent, ok := ParseTraceparent(headers[0].Get(HeaderTraceparent))
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sent.TraceID, "the request continues the trace")
+	assert.NotEqual(t, "00f067aa0ba902b7", sent.SpanID, "the request has its own span")
+
+	tr.Finish(nil)
+	require.NoError(t, tr.Export(context.Background(), http.DefaultClient))
+
+	resourceSpans := exported["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	require.Len(t, spans, 2)
+	command, request := spans[0].(map[string]interface{}), spans[1].(map[string]interface{})
+	assert.Equal(t, "iz admin health", command["name"])
+	assert.Equal(t, "00f067aa0ba902b7", command["parentSpanId"])
+	assert.Equal(t, "HTTP GET", request["name"])
+	assert.Equal(t, command["spanId"], request["parentSpanId"])
+	assert.Equal(t, sent.SpanID, request["spanId"])
+	assert.Contains(t, request["attributes"], map[string]interface{}{"key": "http.response.status_code", "value": map[string]interface{}{"intValue": "200"}})
+}
+
+func TestTracer_UnsampledParentIsNotExported(t *testing.T) {
+	tr := NewTracer(strings.TrimSuffix(testTraceparent, "01")+"00", "")
+	tr.EnableExport("http://localhost:4318", "iz admin health", nil)
+	assert.False(t, tr.Exporting())
+}
+
+func TestOtlpEndpoint(t *testing.T) {
+	assert.Equal(t, "http://collector:4318/v1/traces", otlpEndpoint("http://collector:4318"))
+	assert.Equal(t, "http://collector:4318/v1/traces", otlpEndpoint("http://collector:4318/"))
+	assert.Equal(t, "https://otel.example.com/custom/traces", otlpEndpoint("https://otel.example.com/custom/traces"))
+}
+
+func TestValidateConfig_OtelEndpoint(t *testing.T) {
+	assert.Empty(t, validateConfig(&Config{OtelEndpoint: "http://localhost:4318"}))
+
+	errs := validateConfig(&Config{OtelEndpoint: "localhost:4318"})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "otel-endpoint", errs[0].Field)
+}
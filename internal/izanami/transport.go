@@ -0,0 +1,162 @@
+package izanami
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ============================================================================
+// GATEWAY SETTINGS (CUSTOM HEADERS, PROXY AND TLS)
+// ============================================================================
+
+// configureTransport applies the gateway settings of the config to a client:
+// extra headers sent with every request, an HTTP proxy, and custom CA or
+// client certificates.
+// It must run before the transport is wrapped, as the proxy and TLS settings
+// require the default *http.Transport.
+func configureTransport(client *resty.Client, config *ResolvedConfig) error {
+	tlsConfig, err := buildTLSConfig(config)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		client.SetTLSClientConfig(tlsConfig)
+	}
+	if config.ProxyURL != "" {
+		proxyURL, err := ParseProxyURL(config.ProxyURL)
+		if err != nil {
+			return err
+		}
+		client.SetProxy(proxyURL.String())
+		if config.Verbose {
+			fmt.Fprintf(os.Stderr, "[verbose] Using proxy: %s\n", proxyURL.Redacted())
+		}
+	}
+	if len(config.HTTPHeaders) > 0 {
+		client.SetHeaders(config.HTTPHeaders)
+	}
+	return nil
+}
+
+// buildTLSConfig returns the TLS settings for the CA certificate and client
+// certificate of the config, or nil when none are set
+func buildTLSConfig(config *ResolvedConfig) (*tls.Config, error) {
+	if config.CACertFile == "" && config.ClientCertFile == "" && config.ClientKeyFile == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+
+	if config.CACertFile != "" {
+		pem, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca-cert: %w", err)
+		}
+		// Trust the CA in addition to the system roots
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("invalid ca-cert %s: no PEM certificate found", config.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		if config.ClientCertFile == "" || config.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client-cert and client-key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// ParseProxyURL parses a proxy URL (http, https or socks5).
+// Errors never include the URL, which may contain credentials.
+func ParseProxyURL(value string) (*url.URL, error) {
+	proxyURL, err := url.Parse(value)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy-url: expected an absolute URL such as http://proxy.example.com:3128")
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+		return proxyURL, nil
+	}
+	return nil, fmt.Errorf("invalid proxy-url: unsupported scheme %q (expected http, https or socks5)", proxyURL.Scheme)
+}
+
+// RedactProxyURL returns a proxy URL with its password masked, for display
+func RedactProxyURL(value string) string {
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return RedactedValue
+	}
+	return proxyURL.Redacted()
+}
+
+// ParseHTTPHeader parses a "Name: value" header, as given to --header
+func ParseHTTPHeader(header string) (string, string, error) {
+	name, value, ok := strings.Cut(header, ":")
+	name = strings.TrimSpace(name)
+	if !ok || !validHeaderName(name) {
+		return "", "", fmt.Errorf("invalid header %q: expected \"Name: value\"", header)
+	}
+	return http.CanonicalHeaderKey(name), strings.TrimSpace(value), nil
+}
+
+// ParseHTTPHeaders parses "Name: value" headers into a map. Later values of a
+// header replace earlier ones.
+func ParseHTTPHeaders(headers []string) (map[string]string, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name, value, err := ParseHTTPHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		parsed[name] = value
+	}
+	return parsed, nil
+}
+
+// mergeHTTPHeaders returns the headers of base overridden by those of override,
+// header names being compared case-insensitively
+func mergeHTTPHeaders(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for name, value := range base {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+	for name, value := range override {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+	return merged
+}
+
+// validHeaderName reports whether name is a valid HTTP header field name (RFC 7230 token)
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > 127 || !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+	return true
+}
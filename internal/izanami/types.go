@@ -17,7 +17,7 @@ type Feature struct {
 	Project     string                 `json:"project"`
 	Enabled     bool                   `json:"enabled"`
 	Tags        []string               `json:"tags,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty" table:"wide"`
 }
 
 // FeatureWithOverloads represents a feature with context-specific overloads
@@ -444,8 +444,8 @@ type FeatureOverload struct {
 	ResultType  string                 `json:"resultType,omitempty"`
 	Value       interface{}            `json:"value,omitempty"`
 	Tags        []string               `json:"tags,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	Conditions  []ActivationCondition  `json:"conditions,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty" table:"wide"`
+	Conditions  []ActivationCondition  `json:"conditions,omitempty" table:"wide"`
 }
 
 // FormatForTable implements custom table formatting for FeatureOverload
@@ -507,14 +507,14 @@ type Webhook struct {
 	Name         string            `json:"name"`
 	Description  string            `json:"description"`
 	URL          string            `json:"url"`
-	Headers      map[string]string `json:"headers,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty" table:"wide"`
 	Features     []string          `json:"features,omitempty"`
 	Projects     []string          `json:"projects,omitempty"`
 	Enabled      bool              `json:"enabled"`
 	Global       bool              `json:"global"`
 	Context      string            `json:"context,omitempty"`
 	User         string            `json:"user,omitempty"`
-	BodyTemplate string            `json:"bodyTemplate,omitempty"`
+	BodyTemplate string            `json:"bodyTemplate,omitempty" table:"wide"`
 }
 
 // WebhookFeatureRef represents a feature reference in webhook response
@@ -536,14 +536,14 @@ type WebhookFull struct {
 	Name         string              `json:"name"`
 	Description  string              `json:"description"`
 	URL          string              `json:"url"`
-	Headers      map[string]string   `json:"headers,omitempty"`
+	Headers      map[string]string   `json:"headers,omitempty" table:"wide"`
 	Features     []WebhookFeatureRef `json:"features,omitempty"`
 	Projects     []WebhookProjectRef `json:"projects,omitempty"`
 	Enabled      bool                `json:"enabled"`
 	Global       bool                `json:"global"`
 	Context      string              `json:"context,omitempty"`
 	User         string              `json:"user,omitempty"`
-	BodyTemplate string              `json:"bodyTemplate,omitempty"`
+	BodyTemplate string              `json:"bodyTemplate,omitempty" table:"wide"`
 }
 
 // UserWithWebhookRight represents a user with webhook access rights
@@ -718,20 +718,11 @@ type SearchResultTableView struct {
 
 // ToTableView converts a SearchResult to a table view
 func (s *SearchResult) ToTableView() SearchResultTableView {
-	project := ""
-	// Extract project from path if present
-	for _, entry := range s.Path {
-		if entry.Type == "project" {
-			project = entry.Name
-			break
-		}
-	}
-
 	return SearchResultTableView{
 		Type:    s.Type,
 		Name:    s.Name,
 		Tenant:  s.Tenant,
-		Project: project,
+		Project: s.pathEntry("project"),
 	}
 }
 
@@ -34,7 +34,7 @@ func (c *AdminClient) listUsersRaw(ctx context.Context) ([]byte, error) {
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListUsers, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListUsers), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -64,7 +64,7 @@ func (c *AdminClient) getUserRaw(ctx context.Context, username string) ([]byte,
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetUser, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetUser), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -88,7 +88,7 @@ func (c *AdminClient) CreateUser(ctx context.Context, user interface{}) (*User,
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateUser, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateUser), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -110,7 +110,7 @@ func (c *AdminClient) UpdateUser(ctx context.Context, username string, updateReq
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateUser, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateUser), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -129,7 +129,7 @@ func (c *AdminClient) DeleteUser(ctx context.Context, username string) error {
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteUser, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteUser), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -151,7 +151,7 @@ func (c *AdminClient) UpdateUserRights(ctx context.Context, username string, rig
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateUserRights, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateUserRights), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -179,7 +179,7 @@ func (c *AdminClient) SearchUsers(ctx context.Context, query string, count int)
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToSearchUsers, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToSearchUsers), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -199,7 +199,7 @@ func (c *AdminClient) ListUsersForTenant(ctx context.Context, tenant string) ([]
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListUsers, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListUsers), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -219,7 +219,7 @@ func (c *AdminClient) GetUserForTenant(ctx context.Context, tenant, username str
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToGetUser, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToGetUser), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -241,7 +241,7 @@ func (c *AdminClient) UpdateUserTenantRights(ctx context.Context, tenant, userna
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateTenantRights, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateTenantRights), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -263,7 +263,7 @@ func (c *AdminClient) InviteUsersToTenant(ctx context.Context, tenant string, in
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToInviteUsersToTenant, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToInviteUsersToTenant), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -283,7 +283,7 @@ func (c *AdminClient) ListUsersForProject(ctx context.Context, tenant, project s
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListUsers, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListUsers), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -305,7 +305,7 @@ func (c *AdminClient) UpdateUserProjectRights(ctx context.Context, tenant, proje
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateProjectRights, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateProjectRights), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -327,7 +327,7 @@ func (c *AdminClient) InviteUsersToProject(ctx context.Context, tenant, project
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToInviteUsersToProject, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToInviteUsersToProject), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
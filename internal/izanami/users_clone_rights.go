@@ -0,0 +1,150 @@
+package izanami
+
+import (
+	"sort"
+)
+
+// ============================================================================
+// RIGHTS CLONING
+// ============================================================================
+
+// Kinds of rights changed by a rights clone
+const (
+	RightKindTenant         = "tenant"
+	RightKindProject        = "project"
+	RightKindKey            = "key"
+	RightKindWebhook        = "webhook"
+	RightKindDefaultProject = "default-project"
+	RightKindDefaultKey     = "default-key"
+	RightKindDefaultWebhook = "default-webhook"
+)
+
+// rightLevelRank orders right levels; "Update" only exists for projects
+var rightLevelRank = map[string]int{"Read": 1, "Update": 2, "Write": 3, "Admin": 4}
+
+// RightChange is a right granted to the target user by a rights clone
+type RightChange struct {
+	Tenant string `json:"tenant"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name,omitempty"`
+	// Current is the level the target user has today, empty for none
+	Current string `json:"current,omitempty"`
+	Granted string `json:"granted"`
+}
+
+// RightsClonePlan is the rights of the target user after a clone, per tenant,
+// with the changes it makes
+type RightsClonePlan struct {
+	Source  string                 `json:"source"`
+	Target  string                 `json:"target"`
+	Tenants map[string]TenantRight `json:"tenants"`
+	Changes []RightChange          `json:"changes"`
+}
+
+// PlanRightsClone merges the tenant rights of source into those of target. Rights
+// are only added or raised, never lowered: each right of the target ends up with
+// the higher of both levels. When tenant is not empty, only that tenant is cloned.
+// The global admin status is not part of the plan.
+func PlanRightsClone(source, target *User, tenant string) *RightsClonePlan {
+	plan := &RightsClonePlan{
+		Source:  source.Username,
+		Target:  target.Username,
+		Tenants: make(map[string]TenantRight),
+		Changes: []RightChange{},
+	}
+
+	tenants := make([]string, 0, len(source.Rights.Tenants))
+	for name := range source.Rights.Tenants {
+		if tenant == "" || name == tenant {
+			tenants = append(tenants, name)
+		}
+	}
+	sort.Strings(tenants)
+
+	for _, name := range tenants {
+		from := source.Rights.Tenants[name]
+		current, hasCurrent := target.Rights.Tenants[name]
+		var changes []RightChange
+		change := func(kind, resource, currentLevel, grantedLevel string) string {
+			if rightLevelRank[grantedLevel] <= rightLevelRank[currentLevel] {
+				return currentLevel
+			}
+			changes = append(changes, RightChange{Tenant: name, Kind: kind, Name: resource, Current: currentLevel, Granted: grantedLevel})
+			return grantedLevel
+		}
+
+		merged := TenantRight{
+			Projects: make(map[string]ProjectRight),
+			Keys:     make(map[string]GeneralAtomicRight),
+			Webhooks: make(map[string]GeneralAtomicRight),
+		}
+		if hasCurrent {
+			merged.Level = current.Level
+			merged.DefaultProjectRight = current.DefaultProjectRight
+			merged.DefaultKeyRight = current.DefaultKeyRight
+			merged.DefaultWebhookRight = current.DefaultWebhookRight
+			for project, right := range current.Projects {
+				merged.Projects[project] = right
+			}
+			for key, right := range current.Keys {
+				merged.Keys[key] = right
+			}
+			for webhook, right := range current.Webhooks {
+				merged.Webhooks[webhook] = right
+			}
+		}
+
+		merged.Level = change(RightKindTenant, "", merged.Level, from.Level)
+		merged.DefaultProjectRight = mergeDefaultRight(change, RightKindDefaultProject, merged.DefaultProjectRight, from.DefaultProjectRight)
+		merged.DefaultKeyRight = mergeDefaultRight(change, RightKindDefaultKey, merged.DefaultKeyRight, from.DefaultKeyRight)
+		merged.DefaultWebhookRight = mergeDefaultRight(change, RightKindDefaultWebhook, merged.DefaultWebhookRight, from.DefaultWebhookRight)
+		for _, project := range sortedKeys(from.Projects) {
+			merged.Projects[project] = ProjectRight{Level: change(RightKindProject, project, merged.Projects[project].Level, from.Projects[project].Level)}
+		}
+		for _, key := range sortedKeys(from.Keys) {
+			merged.Keys[key] = GeneralAtomicRight{Level: change(RightKindKey, key, merged.Keys[key].Level, from.Keys[key].Level)}
+		}
+		for _, webhook := range sortedKeys(from.Webhooks) {
+			merged.Webhooks[webhook] = GeneralAtomicRight{Level: change(RightKindWebhook, webhook, merged.Webhooks[webhook].Level, from.Webhooks[webhook].Level)}
+		}
+
+		if len(changes) > 0 {
+			plan.Tenants[name] = merged
+			plan.Changes = append(plan.Changes, changes...)
+		}
+	}
+	return plan
+}
+
+// mergeDefaultRight merges a tenant default right, which may be unset
+func mergeDefaultRight(change func(kind, resource, current, granted string) string, kind string, current, granted *string) *string {
+	if granted == nil {
+		return current
+	}
+	currentLevel := ""
+	if current != nil {
+		currentLevel = *current
+	}
+	level := change(kind, "", currentLevel, *granted)
+	if level == "" {
+		return current
+	}
+	return &level
+}
+
+// TenantRightUpdate converts a tenant right to the request updating it
+func TenantRightUpdate(right TenantRight) *TenantRightUpdateRequest {
+	request := &TenantRightUpdateRequest{
+		DefaultProjectRight: right.DefaultProjectRight,
+		DefaultKeyRight:     right.DefaultKeyRight,
+		DefaultWebhookRight: right.DefaultWebhookRight,
+		Projects:            right.Projects,
+		Keys:                right.Keys,
+		Webhooks:            right.Webhooks,
+	}
+	if right.Level != "" {
+		level := right.Level
+		request.Level = &level
+	}
+	return request
+}
@@ -0,0 +1,62 @@
+package izanami
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRightsClone(t *testing.T) {
+	write := "Write"
+	source := &User{Username: "alice", Rights: UserRights{Tenants: map[string]TenantRight{
+		"acme": {
+			Level:               "Write",
+			Projects:            map[string]ProjectRight{"shop": {Level: "Admin"}, "blog": {Level: "Read"}},
+			Keys:                map[string]GeneralAtomicRight{"backend": {Level: "Read"}},
+			DefaultWebhookRight: &write,
+		},
+		"other": {Level: "Read"},
+	}}}
+	target := &User{Username: "bob", Rights: UserRights{Tenants: map[string]TenantRight{
+		"acme": {
+			Level:    "Read",
+			Projects: map[string]ProjectRight{"blog": {Level: "Write"}, "legacy": {Level: "Read"}},
+		},
+	}}}
+
+	plan := PlanRightsClone(source, target, "")
+	assert.Equal(t, []RightChange{
+		{Tenant: "acme", Kind: RightKindTenant, Current: "Read", Granted: "Write"},
+		{Tenant: "acme", Kind: RightKindDefaultWebhook, Granted: "Write"},
+		{Tenant: "acme", Kind: RightKindProject, Name: "shop", Granted: "Admin"},
+		{Tenant: "acme", Kind: RightKindKey, Name: "backend", Granted: "Read"},
+		{Tenant: "other", Kind: RightKindTenant, Granted: "Read"},
+	}, plan.Changes)
+
+	acme := plan.Tenants["acme"]
+	assert.Equal(t, "Write", acme.Level)
+	// Higher rights of the target and rights the source lacks are kept
+	assert.Equal(t, "Write", acme.Projects["blog"].Level)
+	assert.Equal(t, "Read", acme.Projects["legacy"].Level)
+	assert.Equal(t, "Admin", acme.Projects["shop"].Level)
+	require.NotNil(t, acme.DefaultWebhookRight)
+	assert.Equal(t, "Write", *acme.DefaultWebhookRight)
+	assert.Nil(t, acme.DefaultProjectRight)
+
+	scoped := PlanRightsClone(source, target, "other")
+	assert.Len(t, scoped.Changes, 1)
+	assert.Contains(t, scoped.Tenants, "other")
+	assert.NotContains(t, scoped.Tenants, "acme")
+
+	assert.Empty(t, PlanRightsClone(source, source, "").Changes)
+}
+
+func TestTenantRightUpdate(t *testing.T) {
+	request := TenantRightUpdate(TenantRight{Level: "Read", Projects: map[string]ProjectRight{"shop": {Level: "Write"}}})
+	require.NotNil(t, request.Level)
+	assert.Equal(t, "Read", *request.Level)
+	assert.Equal(t, "Write", request.Projects["shop"].Level)
+
+	assert.Nil(t, TenantRightUpdate(TenantRight{}).Level)
+}
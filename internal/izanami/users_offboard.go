@@ -0,0 +1,87 @@
+package izanami
+
+import (
+	"context"
+	"sort"
+)
+
+// ============================================================================
+// USER OFFBOARDING
+// ============================================================================
+
+// UserRight is a right held by a user, as listed before the user is removed
+type UserRight struct {
+	Tenant string `json:"tenant"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name,omitempty"`
+	Level  string `json:"level"`
+}
+
+// OwnedFeature is a feature whose owner metadata names a user being removed
+type OwnedFeature struct {
+	Tenant string `json:"tenant"`
+	FeatureOwnership
+}
+
+// UserRemoval is what removing a user affects: the rights lost with the user
+// and the features it owns
+type UserRemoval struct {
+	Username      string         `json:"username"`
+	Admin         bool           `json:"admin"`
+	Rights        []UserRight    `json:"rights"`
+	OwnedFeatures []OwnedFeature `json:"ownedFeatures"`
+}
+
+// UserRightsOf lists the tenant rights of a user, sorted by tenant, in the
+// order of a rights clone (tenant level, default rights, projects, keys, webhooks)
+func UserRightsOf(user *User) []UserRight {
+	plan := PlanRightsClone(user, &User{}, "")
+	rights := make([]UserRight, len(plan.Changes))
+	for i, change := range plan.Changes {
+		rights[i] = UserRight{Tenant: change.Tenant, Kind: change.Kind, Name: change.Name, Level: change.Granted}
+	}
+	return rights
+}
+
+// UserTenants returns the sorted names of the tenants a user has rights on
+func UserTenants(user *User) []string {
+	return sortedKeys(user.Rights.Tenants)
+}
+
+// FindOwnedFeatures returns the features of the given tenants owned by any of
+// the users, by username
+func FindOwnedFeatures(c *AdminClient, ctx context.Context, tenants []string, usernames []string) (map[string][]OwnedFeature, error) {
+	owned := make(map[string][]OwnedFeature)
+	for _, tenant := range tenants {
+		features, err := ListFeatures(c, ctx, tenant, "", ParseFeatures)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(features, func(i, j int) bool {
+			if features[i].Project != features[j].Project {
+				return features[i].Project < features[j].Project
+			}
+			return features[i].Name < features[j].Name
+		})
+		for _, feature := range features {
+			for _, username := range usernames {
+				if OwnedBy(feature, username) {
+					owned[username] = append(owned[username], OwnedFeature{Tenant: tenant, FeatureOwnership: OwnershipOf(feature)})
+				}
+			}
+		}
+	}
+	return owned, nil
+}
+
+// TransferFeatureOwnership sets the owner metadata of features to a new owner,
+// keeping their tracking issue. It returns the number of features transferred
+// before any error.
+func TransferFeatureOwnership(c *AdminClient, ctx context.Context, features []OwnedFeature, owner string) (int, error) {
+	for i, feature := range features {
+		if _, err := SetFeatureOwnership(c, ctx, feature.Tenant, feature.ID, &owner, nil); err != nil {
+			return i, err
+		}
+	}
+	return len(features), nil
+}
@@ -0,0 +1,64 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRightsOf(t *testing.T) {
+	user := &User{Username: "alice", Rights: UserRights{Tenants: map[string]TenantRight{
+		"zeta": {Level: "Read"},
+		"acme": {Level: "Write", Projects: map[string]ProjectRight{"shop": {Level: "Admin"}}, Webhooks: map[string]GeneralAtomicRight{"slack": {Level: "Read"}}},
+	}}}
+
+	assert.Equal(t, []UserRight{
+		{Tenant: "acme", Kind: RightKindTenant, Level: "Write"},
+		{Tenant: "acme", Kind: RightKindProject, Name: "shop", Level: "Admin"},
+		{Tenant: "acme", Kind: RightKindWebhook, Name: "slack", Level: "Read"},
+		{Tenant: "zeta", Kind: RightKindTenant, Level: "Read"},
+	}, UserRightsOf(user))
+	assert.Equal(t, []string{"acme", "zeta"}, UserTenants(user))
+	assert.Empty(t, UserRightsOf(&User{Username: "bob"}))
+}
+
+func TestFindOwnedFeaturesAndTransfer(t *testing.T) {
+	var updated []string
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/tenants/acme/features":
+			io.WriteString(w, `[
+				{"id": "f2", "name": "search", "project": "shop", "metadata": {"owner": "Alice"}},
+				{"id": "f1", "name": "checkout", "project": "shop", "metadata": {"owner": "alice"}},
+				{"id": "f3", "name": "other", "project": "shop", "metadata": {"owner": "bob"}},
+				{"id": "f4", "name": "orphan", "project": "shop"}
+			]`)
+		case r.Method == http.MethodGet:
+			io.WriteString(w, `{"id": "f1", "name": "checkout", "project": "shop", "metadata": {"owner": "alice"}}`)
+		case r.Method == http.MethodPut:
+			updated = append(updated, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer server.Close()
+
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, Username: "u", JwtToken: "t", Timeout: 30})
+	require.NoError(t, err)
+
+	owned, err := FindOwnedFeatures(client, context.Background(), []string{"acme"}, []string{"alice", "carol"})
+	require.NoError(t, err)
+	require.Len(t, owned["alice"], 2)
+	assert.Equal(t, "checkout", owned["alice"][0].Name)
+	assert.Equal(t, "acme", owned["alice"][0].Tenant)
+	assert.Empty(t, owned["carol"])
+
+	transferred, err := TransferFeatureOwnership(client, context.Background(), owned["alice"], "team-payments")
+	require.NoError(t, err)
+	assert.Equal(t, 2, transferred)
+	assert.Equal(t, []string{"/api/admin/tenants/acme/features/f1", "/api/admin/tenants/acme/features/f2"}, updated)
+}
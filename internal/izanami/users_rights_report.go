@@ -0,0 +1,178 @@
+package izanami
+
+import (
+	"context"
+	"sort"
+)
+
+// ============================================================================
+// RIGHTS MATRIX
+// ============================================================================
+
+// Resource types of a rights matrix
+const (
+	RightsResourceProject = "project"
+	RightsResourceKey     = "key"
+	RightsResourceWebhook = "webhook"
+)
+
+// Sources of a rights matrix cell
+const (
+	// RightSourceExplicit is a right granted on the resource itself
+	RightSourceExplicit = "explicit"
+	// RightSourceDefault comes from the tenant default right for the resource type
+	RightSourceDefault = "default"
+	// RightSourceAdmin comes from global or tenant admin rights
+	RightSourceAdmin = "admin"
+)
+
+// RightsResource is a column of a rights matrix
+type RightsResource struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// RightsCell is the right of a user on a resource
+type RightsCell struct {
+	Level  string `json:"level"`
+	Source string `json:"source"`
+}
+
+// RightsMatrixUser is a row of a rights matrix
+type RightsMatrixUser struct {
+	Username    string `json:"username"`
+	Admin       bool   `json:"admin"`
+	TenantLevel string `json:"tenantLevel"`
+	// Rights is keyed by "<type>:<name>", users without any right on a resource have no entry
+	Rights map[string]RightsCell `json:"rights"`
+}
+
+// RightsMatrix holds the rights of every user of a tenant on its projects, keys and webhooks
+type RightsMatrix struct {
+	Tenant    string             `json:"tenant"`
+	Resources []RightsResource   `json:"resources"`
+	Users     []RightsMatrixUser `json:"users"`
+}
+
+// Key returns the key of a resource in RightsMatrixUser.Rights
+func (r RightsResource) Key() string {
+	return r.Type + ":" + r.Name
+}
+
+// BuildRightsMatrix fetches the users of a tenant and their rights on the given
+// resource types (all of them when types is empty)
+func BuildRightsMatrix(c *AdminClient, ctx context.Context, tenant string, types []string) (*RightsMatrix, error) {
+	wanted := func(t string) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, w := range types {
+			if w == t {
+				return true
+			}
+		}
+		return false
+	}
+
+	matrix := &RightsMatrix{Tenant: tenant, Resources: []RightsResource{}, Users: []RightsMatrixUser{}}
+	seen := make(map[string]bool)
+	addResource := func(t, name string) {
+		r := RightsResource{Type: t, Name: name}
+		if wanted(t) && !seen[r.Key()] {
+			seen[r.Key()] = true
+			matrix.Resources = append(matrix.Resources, r)
+		}
+	}
+
+	if wanted(RightsResourceProject) {
+		projects, err := ListProjects(c, ctx, tenant, ParseProjects)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range projects {
+			addResource(RightsResourceProject, p.Name)
+		}
+	}
+	if wanted(RightsResourceKey) {
+		keys, err := ListAPIKeys(c, ctx, tenant, ParseAPIKeys)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			addResource(RightsResourceKey, k.Name)
+		}
+	}
+	if wanted(RightsResourceWebhook) {
+		webhooks, err := ListWebhooks(c, ctx, tenant, ParseWebhooks)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range webhooks {
+			addResource(RightsResourceWebhook, w.Name)
+		}
+	}
+
+	users, err := c.ListUsersForTenant(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	rights := make([]TenantRight, len(users))
+	for i, u := range users {
+		user, err := c.GetUserForTenant(ctx, tenant, u.Username)
+		if err != nil {
+			return nil, err
+		}
+		rights[i] = user.Rights.Tenants[tenant]
+		// Resources that only appear in rights (e.g. deleted since) still get a column
+		for name := range rights[i].Projects {
+			addResource(RightsResourceProject, name)
+		}
+		for name := range rights[i].Keys {
+			addResource(RightsResourceKey, name)
+		}
+		for name := range rights[i].Webhooks {
+			addResource(RightsResourceWebhook, name)
+		}
+	}
+
+	for i, u := range users {
+		row := RightsMatrixUser{Username: u.Username, Admin: u.Admin, TenantLevel: rights[i].Level, Rights: make(map[string]RightsCell)}
+		if row.TenantLevel == "" {
+			row.TenantLevel = u.Right
+		}
+		for _, r := range matrix.Resources {
+			if cell, ok := resolveRight(u.Admin, row.TenantLevel, rights[i], r); ok {
+				row.Rights[r.Key()] = cell
+			}
+		}
+		matrix.Users = append(matrix.Users, row)
+	}
+	return matrix, nil
+}
+
+// resolveRight returns the effective right of a user on a resource
+func resolveRight(admin bool, tenantLevel string, right TenantRight, r RightsResource) (RightsCell, bool) {
+	if admin || tenantLevel == string(RightLevelAdmin) {
+		return RightsCell{Level: string(RightLevelAdmin), Source: RightSourceAdmin}, true
+	}
+
+	var level string
+	var def *string
+	switch r.Type {
+	case RightsResourceProject:
+		level, def = right.Projects[r.Name].Level, right.DefaultProjectRight
+	case RightsResourceKey:
+		level, def = right.Keys[r.Name].Level, right.DefaultKeyRight
+	case RightsResourceWebhook:
+		level, def = right.Webhooks[r.Name].Level, right.DefaultWebhookRight
+	}
+	if level != "" {
+		return RightsCell{Level: level, Source: RightSourceExplicit}, true
+	}
+	if def != nil && *def != "" {
+		return RightsCell{Level: *def, Source: RightSourceDefault}, true
+	}
+	return RightsCell{}, false
+}
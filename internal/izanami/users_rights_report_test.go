@@ -0,0 +1,92 @@
+package izanami
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rightsMatrixHandler serves a tenant with two projects, one key, one webhook and three users
+func rightsMatrixHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/tenants/acme/projects":
+			io.WriteString(w, `[{"name": "shop"}, {"name": "blog"}]`)
+		case "/api/admin/tenants/acme/keys":
+			io.WriteString(w, `[{"clientId": "c1", "name": "backend"}]`)
+		case "/api/admin/tenants/acme/webhooks":
+			io.WriteString(w, `[{"id": "w1", "name": "slack"}]`)
+		case "/api/admin/tenants/acme/users":
+			io.WriteString(w, `[{"username": "zoe", "right": "Read"}, {"username": "root", "admin": true, "right": "Read"}, {"username": "ann", "right": "Admin"}]`)
+		case "/api/admin/tenants/acme/users/zoe":
+			io.WriteString(w, `{"username": "zoe", "rights": {"tenants": {"acme": {"level": "Read",
+				"projects": {"shop": {"level": "Write"}, "legacy": {"level": "Read"}},
+				"defaultKeyRight": "Read"}}}}`)
+		case "/api/admin/tenants/acme/users/root":
+			io.WriteString(w, `{"username": "root", "admin": true, "rights": {"tenants": {}}}`)
+		case "/api/admin/tenants/acme/users/ann":
+			io.WriteString(w, `{"username": "ann", "rights": {"tenants": {"acme": {"level": "Admin"}}}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestBuildRightsMatrix(t *testing.T) {
+	server := mockServer(t, rightsMatrixHandler(t))
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 5})
+	require.NoError(t, err)
+
+	matrix, err := BuildRightsMatrix(client, context.Background(), "acme", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RightsResource{
+		{Type: "project", Name: "shop"},
+		{Type: "project", Name: "blog"},
+		{Type: "key", Name: "backend"},
+		{Type: "webhook", Name: "slack"},
+		{Type: "project", Name: "legacy"},
+	}, matrix.Resources)
+
+	require.Len(t, matrix.Users, 3)
+	ann, root, zoe := matrix.Users[0], matrix.Users[1], matrix.Users[2]
+
+	assert.Equal(t, "ann", ann.Username)
+	assert.Equal(t, RightsCell{Level: "Admin", Source: RightSourceAdmin}, ann.Rights["webhook:slack"])
+
+	assert.Equal(t, "root", root.Username)
+	assert.True(t, root.Admin)
+	assert.Equal(t, "Read", root.TenantLevel)
+	assert.Len(t, root.Rights, 5)
+
+	assert.Equal(t, "zoe", zoe.Username)
+	assert.Equal(t, map[string]RightsCell{
+		"project:shop":   {Level: "Write", Source: RightSourceExplicit},
+		"project:legacy": {Level: "Read", Source: RightSourceExplicit},
+		"key:backend":    {Level: "Read", Source: RightSourceDefault},
+	}, zoe.Rights)
+}
+
+func TestBuildRightsMatrix_Types(t *testing.T) {
+	server := mockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/admin/tenants/acme/keys" || r.URL.Path == "/api/admin/tenants/acme/webhooks" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		rightsMatrixHandler(t)(w, r)
+	})
+	defer server.Close()
+	client, err := NewAdminClient(&ResolvedConfig{LeaderURL: server.URL, JwtToken: "token", Timeout: 5})
+	require.NoError(t, err)
+
+	matrix, err := BuildRightsMatrix(client, context.Background(), "acme", []string{"project"})
+	require.NoError(t, err)
+	assert.Len(t, matrix.Resources, 3)
+	assert.NotContains(t, matrix.Users[2].Rights, "key:backend")
+}
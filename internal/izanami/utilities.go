@@ -30,7 +30,7 @@ func (c *AdminClient) healthRaw(ctx context.Context) ([]byte, error) {
 		Get("/api/_health")
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCheckHealth, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCheckHealth), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
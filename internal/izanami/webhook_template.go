@@ -0,0 +1,526 @@
+package izanami
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// WEBHOOK BODY TEMPLATES
+// ============================================================================
+
+// SampleWebhookEvent is a representative FEATURE_UPDATED event, used to render
+// body templates when no sample event is given
+const SampleWebhookEvent = `{
+  "eventId": 1234,
+  "type": "FEATURE_UPDATED",
+  "tenant": "my-tenant",
+  "user": "admin",
+  "emittedAt": "2024-01-31T12:00:00Z",
+  "origin": "NORMAL",
+  "authentication": "BACKOFFICE",
+  "payload": {
+    "id": "f1a2b3c4-0000-0000-0000-000000000000",
+    "name": "new-checkout",
+    "project": "shop",
+    "description": "New checkout flow",
+    "active": true,
+    "tags": ["checkout"],
+    "conditions": {}
+  },
+  "metadata": {
+    "context": "prod"
+  }
+}`
+
+// TemplateError reports a syntax or rendering error at a position of a template
+type TemplateError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// WebhookTemplate is a parsed webhook body template.
+//
+// Templates use the Handlebars syntax: {{path.to.value}} (HTML-escaped),
+// {{{raw}}} or {{& raw}}, comments, the if, unless, each and with block helpers,
+// Mustache-style sections ({{#path}}, {{^path}}) and ~ whitespace control.
+type WebhookTemplate struct {
+	source string
+	nodes  []templateNode
+}
+
+// templateNode is a text, value or block node of a parsed template
+type templateNode interface{}
+
+type textNode struct {
+	text string
+}
+
+type valueNode struct {
+	name   string
+	args   []string
+	raw    bool
+	offset int
+}
+
+type blockNode struct {
+	name     string
+	args     []string
+	inverted bool
+	body     []templateNode
+	elseBody []templateNode
+	offset   int
+}
+
+// templateTag is one {{...}} tag of a template
+type templateTag struct {
+	kind      byte // 0 for values, or one of # ^ / ! &
+	content   string
+	raw       bool
+	offset    int
+	trimLeft  bool
+	trimRight bool
+}
+
+// ParseWebhookTemplate parses a body template, reporting syntax errors with their position
+func ParseWebhookTemplate(source string) (*WebhookTemplate, error) {
+	t := &WebhookTemplate{source: source}
+
+	type openBlock struct {
+		block    *blockNode
+		inElse   bool
+		previous *[]templateNode
+	}
+	root := []templateNode{}
+	current := &root
+	var stack []*openBlock
+
+	pos := 0
+	trimNext := false
+	for pos < len(source) {
+		start := strings.Index(source[pos:], "{{")
+		if start < 0 {
+			appendText(current, source[pos:], trimNext, false)
+			break
+		}
+		start += pos
+
+		tag, end, err := t.readTag(start)
+		if err != nil {
+			return nil, err
+		}
+		appendText(current, source[pos:start], trimNext, tag.trimLeft)
+		trimNext = tag.trimRight
+		pos = end
+
+		switch {
+		case tag.kind == '!':
+			continue
+		case tag.kind == '#' || (tag.kind == '^' && tag.content != ""):
+			fields := strings.Fields(tag.content)
+			if len(fields) == 0 {
+				return nil, t.errorAt(tag.offset, "block without a name")
+			}
+			block := &blockNode{name: fields[0], args: fields[1:], inverted: tag.kind == '^', offset: tag.offset}
+			*current = append(*current, block)
+			stack = append(stack, &openBlock{block: block, previous: current})
+			current = &block.body
+		case tag.kind == '^' || (tag.kind == 0 && tag.content == "else"):
+			if len(stack) == 0 {
+				return nil, t.errorAt(tag.offset, "{{else}} outside of a block")
+			}
+			open := stack[len(stack)-1]
+			if open.inElse {
+				return nil, t.errorAt(tag.offset, fmt.Sprintf("duplicate {{else}} in {{#%s}}", open.block.name))
+			}
+			open.inElse = true
+			current = &open.block.elseBody
+		case tag.kind == '/':
+			name := strings.TrimSpace(tag.content)
+			if len(stack) == 0 {
+				return nil, t.errorAt(tag.offset, fmt.Sprintf("unexpected {{/%s}} without an opening block", name))
+			}
+			open := stack[len(stack)-1]
+			if name != open.block.name {
+				line, column := t.position(open.block.offset)
+				return nil, t.errorAt(tag.offset, fmt.Sprintf("{{/%s}} does not close {{#%s}} opened at line %d, column %d", name, open.block.name, line, column))
+			}
+			stack = stack[:len(stack)-1]
+			current = open.previous
+		default:
+			fields := strings.Fields(tag.content)
+			if len(fields) == 0 {
+				return nil, t.errorAt(tag.offset, "empty tag")
+			}
+			*current = append(*current, &valueNode{name: fields[0], args: fields[1:], raw: tag.raw, offset: tag.offset})
+		}
+	}
+
+	if len(stack) > 0 {
+		open := stack[len(stack)-1]
+		return nil, t.errorAt(open.block.offset, fmt.Sprintf("{{#%s}} is never closed", open.block.name))
+	}
+	t.nodes = root
+	return t, nil
+}
+
+// readTag reads the tag starting at offset and returns the offset following it
+func (t *WebhookTemplate) readTag(offset int) (templateTag, int, error) {
+	tag := templateTag{offset: offset}
+	inner := offset + 2
+	closing := "}}"
+	if strings.HasPrefix(t.source[inner:], "{") {
+		tag.raw = true
+		inner++
+		closing = "}}}"
+	}
+
+	// Long comments may contain }}
+	if strings.HasPrefix(strings.TrimPrefix(t.source[inner:], "~"), "!--") {
+		tag.trimLeft = strings.HasPrefix(t.source[inner:], "~")
+		end := strings.Index(t.source[inner:], "--}}")
+		endTrim := strings.Index(t.source[inner:], "--~}}")
+		switch {
+		case end < 0 && endTrim < 0:
+			return tag, 0, t.errorAt(offset, "unclosed comment")
+		case endTrim >= 0 && (end < 0 || endTrim < end):
+			tag.kind, tag.trimRight = '!', true
+			return tag, inner + endTrim + len("--~}}"), nil
+		default:
+			tag.kind = '!'
+			return tag, inner + end + len("--}}"), nil
+		}
+	}
+
+	end := strings.Index(t.source[inner:], "}}")
+	if end < 0 {
+		return tag, 0, t.errorAt(offset, "unclosed tag, expected "+closing)
+	}
+	content := t.source[inner : inner+end]
+	next := inner + end + 2
+	if tag.raw {
+		if !strings.HasPrefix(t.source[next:], "}") {
+			return tag, 0, t.errorAt(offset, "unclosed tag, expected }}}")
+		}
+		next++
+	}
+	if strings.Contains(content, "{{") {
+		return tag, 0, t.errorAt(offset, "unclosed tag, expected "+closing)
+	}
+
+	if strings.HasPrefix(content, "~") {
+		tag.trimLeft = true
+		content = content[1:]
+	}
+	if strings.HasSuffix(content, "~") {
+		tag.trimRight = true
+		content = content[:len(content)-1]
+	}
+	content = strings.TrimSpace(content)
+	if !tag.raw && content != "" {
+		switch content[0] {
+		case '#', '^', '/', '!':
+			tag.kind = content[0]
+			content = strings.TrimSpace(content[1:])
+		case '&':
+			tag.raw = true
+			content = strings.TrimSpace(content[1:])
+		}
+	}
+	tag.content = content
+	return tag, next, nil
+}
+
+// appendText adds a text node, applying whitespace control
+func appendText(nodes *[]templateNode, text string, trimLeft, trimRight bool) {
+	if trimLeft {
+		text = strings.TrimLeft(text, " \t\r\n")
+	}
+	if trimRight {
+		text = strings.TrimRight(text, " \t\r\n")
+	}
+	if text != "" {
+		*nodes = append(*nodes, &textNode{text: text})
+	}
+}
+
+// position converts a byte offset into a 1-based line and column
+func (t *WebhookTemplate) position(offset int) (int, int) {
+	before := t.source[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+	return line, column
+}
+
+func (t *WebhookTemplate) errorAt(offset int, message string) *TemplateError {
+	line, column := t.position(offset)
+	return &TemplateError{Line: line, Column: column, Message: message}
+}
+
+// templateFrame is one level of the rendering context
+type templateFrame struct {
+	data   interface{}
+	vars   map[string]interface{}
+	parent *templateFrame
+}
+
+// templateRenderer renders a template, recording the values that resolve to nothing
+type templateRenderer struct {
+	template *WebhookTemplate
+	root     interface{}
+	missing  map[string]bool
+	out      bytes.Buffer
+}
+
+// Render renders the template against an event (decoded JSON). It returns the
+// rendered body and the sorted list of referenced variables missing from the event.
+func (t *WebhookTemplate) Render(event interface{}) (string, []string, error) {
+	r := &templateRenderer{template: t, root: event, missing: make(map[string]bool)}
+	if err := r.render(t.nodes, &templateFrame{data: event}); err != nil {
+		return "", nil, err
+	}
+	missing := make([]string, 0, len(r.missing))
+	for path := range r.missing {
+		missing = append(missing, path)
+	}
+	sort.Strings(missing)
+	return r.out.String(), missing, nil
+}
+
+func (r *templateRenderer) render(nodes []templateNode, frame *templateFrame) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *textNode:
+			r.out.WriteString(n.text)
+		case *valueNode:
+			if len(n.args) > 0 {
+				return r.template.errorAt(n.offset, fmt.Sprintf("unsupported helper %q", n.name))
+			}
+			value, found := r.lookup(n.name, frame)
+			if !found {
+				r.missing[n.name] = true
+			}
+			text := formatTemplateValue(value)
+			if !n.raw {
+				text = escapeTemplateValue(text)
+			}
+			r.out.WriteString(text)
+		case *blockNode:
+			if err := r.renderBlock(n, frame); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *templateRenderer) renderBlock(n *blockNode, frame *templateFrame) error {
+	// Built-in block helpers take one argument
+	switch n.name {
+	case "if", "unless", "each", "with":
+		if n.inverted {
+			return r.template.errorAt(n.offset, fmt.Sprintf("{{^%s}} is not supported, use {{#unless}}", n.name))
+		}
+		if len(n.args) != 1 {
+			return r.template.errorAt(n.offset, fmt.Sprintf("{{#%s}} expects exactly one argument", n.name))
+		}
+	default:
+		if len(n.args) > 0 {
+			return r.template.errorAt(n.offset, fmt.Sprintf("unsupported block helper %q", n.name))
+		}
+	}
+
+	switch n.name {
+	case "if", "unless":
+		value, _ := r.lookup(n.args[0], frame)
+		if isTemplateTruthy(value) == (n.name == "if") {
+			return r.render(n.body, frame)
+		}
+		return r.render(n.elseBody, frame)
+	case "with":
+		value, _ := r.lookup(n.args[0], frame)
+		if !isTemplateTruthy(value) {
+			return r.render(n.elseBody, frame)
+		}
+		return r.render(n.body, &templateFrame{data: value, parent: frame})
+	case "each":
+		value, _ := r.lookup(n.args[0], frame)
+		return r.renderEach(n, value, frame)
+	}
+
+	// Mustache-style section
+	value, _ := r.lookup(n.name, frame)
+	if n.inverted {
+		if !isTemplateTruthy(value) {
+			return r.render(n.body, frame)
+		}
+		return r.render(n.elseBody, frame)
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		return r.renderEach(n, v, frame)
+	case map[string]interface{}:
+		return r.render(n.body, &templateFrame{data: v, parent: frame})
+	}
+	if isTemplateTruthy(value) {
+		return r.render(n.body, frame)
+	}
+	return r.render(n.elseBody, frame)
+}
+
+// renderEach renders the block body for each element of an array or object
+func (r *templateRenderer) renderEach(n *blockNode, value interface{}, frame *templateFrame) error {
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			break
+		}
+		for i, item := range v {
+			vars := map[string]interface{}{"index": i, "first": i == 0, "last": i == len(v)-1}
+			if err := r.render(n.body, &templateFrame{data: item, vars: vars, parent: frame}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			break
+		}
+		keys := sortedKeys(v)
+		for i, key := range keys {
+			vars := map[string]interface{}{"key": key, "index": i, "first": i == 0, "last": i == len(keys)-1}
+			if err := r.render(n.body, &templateFrame{data: v[key], vars: vars, parent: frame}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return r.render(n.elseBody, frame)
+}
+
+// lookup resolves a path (a.b, this, ../a, @index, @root.a) in the current frame
+func (r *templateRenderer) lookup(path string, frame *templateFrame) (interface{}, bool) {
+	if strings.HasPrefix(path, "@root") {
+		return lookupTemplatePath(r.root, strings.TrimPrefix(strings.TrimPrefix(path, "@root"), "."))
+	}
+	if strings.HasPrefix(path, "@") {
+		for f := frame; f != nil; f = f.parent {
+			if value, ok := f.vars[path[1:]]; ok {
+				return value, true
+			}
+		}
+		return nil, false
+	}
+	for strings.HasPrefix(path, "../") {
+		path = path[3:]
+		if frame.parent != nil {
+			frame = frame.parent
+		}
+	}
+	switch {
+	case path == "this" || path == ".":
+		return frame.data, true
+	case strings.HasPrefix(path, "this."):
+		path = path[len("this."):]
+	case strings.HasPrefix(path, "./"):
+		path = path[2:]
+	}
+	return lookupTemplatePath(frame.data, path)
+}
+
+// lookupTemplatePath resolves a dotted path in decoded JSON
+func lookupTemplatePath(data interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return data, true
+	}
+	current := data
+	for _, segment := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			value, ok := v[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			current = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// isTemplateTruthy follows the Handlebars rules: false, null, "", 0 and empty arrays are falsy
+func isTemplateTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case json.Number:
+		f, err := v.Float64()
+		return err != nil || f != 0
+	case float64:
+		return v != 0
+	case int:
+		return v != 0
+	case []interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// formatTemplateValue renders scalars as text and objects or arrays as compact JSON
+func formatTemplateValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool, int, float64:
+		return fmt.Sprint(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// templateEscaper escapes values like Handlebars does for {{...}}
+var templateEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#x27;", "`", "&#x60;", "=", "&#x3D;",
+)
+
+func escapeTemplateValue(text string) string {
+	return templateEscaper.Replace(text)
+}
+
+// DecodeTemplateEvent decodes a JSON sample event, keeping numbers as written
+func DecodeTemplateEvent(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var event interface{}
+	if err := decoder.Decode(&event); err != nil {
+		return nil, fmt.Errorf("invalid sample event: %w", err)
+	}
+	return event, nil
+}
@@ -0,0 +1,89 @@
+package izanami
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderTemplate(t *testing.T, source, event string) (string, []string) {
+	t.Helper()
+	tmpl, err := ParseWebhookTemplate(source)
+	require.NoError(t, err)
+	data, err := DecodeTemplateEvent([]byte(event))
+	require.NoError(t, err)
+	body, missing, err := tmpl.Render(data)
+	require.NoError(t, err)
+	return body, missing
+}
+
+func TestWebhookTemplate_Render(t *testing.T) {
+	event := `{"type": "FEATURE_UPDATED", "count": 3, "payload": {"name": "a<b", "active": false, "tags": ["x", "y"], "meta": {"k": "v"}}}`
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"value", `{{type}}`, "FEATURE_UPDATED"},
+		{"nested and number", `{{payload.name}}/{{count}}`, "a&lt;b/3"},
+		{"raw", `{{{payload.name}}} {{& payload.name}}`, "a<b a<b"},
+		{"object as JSON", `{{{payload.meta}}}`, `{"k":"v"}`},
+		{"if else", `{{#if payload.active}}on{{else}}off{{/if}}`, "off"},
+		{"unless", `{{#unless payload.active}}off{{/unless}}`, "off"},
+		{"each array", `{{#each payload.tags}}{{@index}}={{this}}{{#unless @last}},{{/unless}}{{/each}}`, "0=x,1=y"},
+		{"each object", `{{#each payload.meta}}{{@key}}:{{.}}{{/each}}`, "k:v"},
+		{"with and parent", `{{#with payload}}{{name}} {{../type}}{{/with}}`, "a&lt;b FEATURE_UPDATED"},
+		{"section", `{{#payload}}{{name}}{{/payload}}{{^missing}}none{{/missing}}`, "a&lt;bnone"},
+		{"comments", `a{{! short }}{{!-- long }} --}}b`, "ab"},
+		{"whitespace control", "x  {{~type~}}  \n y", "xFEATURE_UPDATEDy"},
+		{"root", `{{#each payload.tags}}{{@root.type}}{{/each}}`, "FEATURE_UPDATEDFEATURE_UPDATED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := renderTemplate(t, tt.template, event)
+			assert.Equal(t, tt.want, body)
+		})
+	}
+}
+
+func TestWebhookTemplate_Missing(t *testing.T) {
+	body, missing := renderTemplate(t, `{{#if previous}}{{previous.name}}{{/if}}{{user}}-{{payload.id}}-{{user}}`, `{"payload": {}}`)
+	assert.Equal(t, "--", body)
+	assert.Equal(t, []string{"payload.id", "user"}, missing, "values tested by if are not reported")
+}
+
+func TestParseWebhookTemplate_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"{{name", "line 1, column 1: unclosed tag, expected }}"},
+		{"a\n {{{name}}", "line 2, column 2: unclosed tag, expected }}}"},
+		{"{{#if a}}\n{{#each b}}{{/if}}", "line 2, column 12: {{/if}} does not close {{#each}} opened at line 2, column 1"},
+		{"{{#if a}}x", "line 1, column 1: {{#if}} is never closed"},
+		{"x{{/if}}", "line 1, column 2: unexpected {{/if}} without an opening block"},
+		{"{{else}}", "{{else}} outside of a block"},
+		{"{{ }}", "empty tag"},
+		{"{{!-- never closed", "unclosed comment"},
+	}
+	for _, tt := range tests {
+		_, err := ParseWebhookTemplate(tt.template)
+		require.Error(t, err, tt.template)
+		assert.Contains(t, err.Error(), tt.want, tt.template)
+	}
+}
+
+func TestWebhookTemplate_UnsupportedHelper(t *testing.T) {
+	tmpl, err := ParseWebhookTemplate(`{{#if a}}{{json payload}}{{/if}}`)
+	require.NoError(t, err, "helpers are only checked when rendering")
+	_, _, err = tmpl.Render(map[string]interface{}{"a": true})
+	assert.ErrorContains(t, err, `line 1, column 10: unsupported helper "json"`)
+}
+
+func TestSampleWebhookEvent(t *testing.T) {
+	body, missing := renderTemplate(t, `{{type}} {{payload.name}}`, SampleWebhookEvent)
+	assert.Equal(t, "FEATURE_UPDATED new-checkout", body)
+	assert.Empty(t, missing)
+}
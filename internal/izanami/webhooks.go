@@ -32,7 +32,7 @@ func (c *AdminClient) listWebhooksRaw(ctx context.Context, tenant string) ([]byt
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListWebhooks, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListWebhooks), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -60,7 +60,7 @@ func (c *AdminClient) CreateWebhook(ctx context.Context, tenant string, webhook
 	resp, err := req.Post(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToCreateWebhook, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToCreateWebhook), err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
@@ -83,7 +83,7 @@ func (c *AdminClient) UpdateWebhook(ctx context.Context, tenant, webhookID strin
 	resp, err := req.Put(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToUpdateWebhook, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToUpdateWebhook), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -93,6 +93,49 @@ func (c *AdminClient) UpdateWebhook(ctx context.Context, tenant, webhookID strin
 	return nil
 }
 
+// UpdateBody returns the update payload keeping the current values of the webhook.
+// Feature and project references are replaced by their IDs, as expected on update.
+func (w *WebhookFull) UpdateBody() map[string]interface{} {
+	data := map[string]interface{}{
+		"name":    w.Name,
+		"url":     w.URL,
+		"enabled": w.Enabled,
+		"global":  w.Global,
+	}
+
+	// Include optional fields if they have values
+	if w.Description != "" {
+		data["description"] = w.Description
+	}
+	if len(w.Features) > 0 {
+		featureIDs := make([]string, len(w.Features))
+		for i, f := range w.Features {
+			featureIDs[i] = f.ID
+		}
+		data["features"] = featureIDs
+	}
+	if len(w.Projects) > 0 {
+		projectIDs := make([]string, len(w.Projects))
+		for i, p := range w.Projects {
+			projectIDs[i] = p.ID
+		}
+		data["projects"] = projectIDs
+	}
+	if w.Context != "" {
+		data["context"] = w.Context
+	}
+	if w.User != "" {
+		data["user"] = w.User
+	}
+	if w.BodyTemplate != "" {
+		data["bodyTemplate"] = w.BodyTemplate
+	}
+	if len(w.Headers) > 0 {
+		data["headers"] = w.Headers
+	}
+	return data
+}
+
 // DeleteWebhook deletes a webhook
 func (c *AdminClient) DeleteWebhook(ctx context.Context, tenant, webhookID string) error {
 	path := apiAdminTenants + buildPath(tenant, "webhooks", webhookID)
@@ -102,7 +145,7 @@ func (c *AdminClient) DeleteWebhook(ctx context.Context, tenant, webhookID strin
 	resp, err := req.Delete(path)
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", errmsg.MsgFailedToDeleteWebhook, err)
+		return fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToDeleteWebhook), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK && resp.StatusCode() != http.StatusNoContent {
@@ -132,7 +175,7 @@ func (c *AdminClient) listWebhookUsersRaw(ctx context.Context, tenant, webhookID
 	resp, err := req.Get(path)
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errmsg.MsgFailedToListWebhookUsers, err)
+		return nil, fmt.Errorf("%s: %w", errmsg.T(errmsg.MsgFailedToListWebhookUsers), err)
 	}
 
 	if resp.StatusCode() != http.StatusOK {
@@ -0,0 +1,83 @@
+package izanami
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ============================================================================
+// WEB UI LINKS
+// ============================================================================
+
+// WebUIURL returns the URL of the web UI page of a resource. The web UI is
+// served by the leader. resourceType is a search result type (project, feature,
+// key, tag, script, global_context, local_context, webhook); features and
+// local contexts require their project.
+func WebUIURL(baseURL, tenant, project, resourceType, name string) (string, error) {
+	if tenant == "" {
+		return "", fmt.Errorf("no tenant for %s %q", resourceType, name)
+	}
+	tenantPath := "/tenants/" + buildPath(tenant)
+	var path string
+	query := url.Values{}
+	switch strings.ToLower(resourceType) {
+	case "tenant":
+		path = tenantPath
+	case "project":
+		path = tenantPath + "/projects/" + buildPath(name)
+	case "feature":
+		if project == "" {
+			return "", fmt.Errorf("no project for feature %q", name)
+		}
+		// The project page lists its features, filtered by name
+		path = tenantPath + "/projects/" + buildPath(project)
+		query.Set("filter", name)
+	case "local_context":
+		if project == "" {
+			return "", fmt.Errorf("no project for context %q", name)
+		}
+		path = tenantPath + "/projects/" + buildPath(project) + "/contexts"
+	case "global_context":
+		path = tenantPath + "/contexts"
+	case "key":
+		path = tenantPath + "/keys"
+	case "tag":
+		path = tenantPath + "/tags/" + buildPath(name)
+	case "webhook":
+		path = tenantPath + "/webhooks"
+	case "script":
+		path = tenantPath + "/scripts"
+	default:
+		return "", fmt.Errorf("no web UI page for resource type %q", resourceType)
+	}
+
+	link := strings.TrimSuffix(baseURL, "/") + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link, nil
+}
+
+// WebUIURL returns the URL of the web UI page of the search result.
+// defaultTenant is used for results of a tenant search, which may omit it.
+func (s *SearchResult) WebUIURL(baseURL, defaultTenant string) (string, error) {
+	tenant := s.Tenant
+	if tenant == "" {
+		tenant = s.pathEntry("tenant")
+	}
+	if tenant == "" {
+		tenant = defaultTenant
+	}
+	return WebUIURL(baseURL, tenant, s.pathEntry("project"), s.Type, s.Name)
+}
+
+// pathEntry returns the name of the first path entry of the given type
+func (s *SearchResult) pathEntry(entryType string) string {
+	for _, entry := range s.Path {
+		if entry.Type == entryType {
+			return entry.Name
+		}
+	}
+	return ""
+}
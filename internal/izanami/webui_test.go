@@ -0,0 +1,46 @@
+package izanami
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebUIURL(t *testing.T) {
+	tests := []struct {
+		resourceType, project, name string
+		want                        string
+	}{
+		{"project", "", "billing", "https://iz.example.com/tenants/acme/projects/billing"},
+		{"feature", "billing", "new checkout", "https://iz.example.com/tenants/acme/projects/billing?filter=new+checkout"},
+		{"local_context", "billing", "prod", "https://iz.example.com/tenants/acme/projects/billing/contexts"},
+		{"global_context", "", "prod", "https://iz.example.com/tenants/acme/contexts"},
+		{"key", "", "ci-key", "https://iz.example.com/tenants/acme/keys"},
+		{"tag", "", "team/a", "https://iz.example.com/tenants/acme/tags/team%2Fa"},
+		{"WEBHOOK", "", "slack", "https://iz.example.com/tenants/acme/webhooks"},
+	}
+	for _, tt := range tests {
+		got, err := WebUIURL("https://iz.example.com/", "acme", tt.project, tt.resourceType, tt.name)
+		assert.NoError(t, err, tt.resourceType)
+		assert.Equal(t, tt.want, got, tt.resourceType)
+	}
+
+	_, err := WebUIURL("https://iz.example.com", "acme", "", "feature", "f1")
+	assert.ErrorContains(t, err, "no project for feature")
+	_, err = WebUIURL("https://iz.example.com", "", "", "project", "p1")
+	assert.ErrorContains(t, err, "no tenant")
+	_, err = WebUIURL("https://iz.example.com", "acme", "", "unknown", "x")
+	assert.ErrorContains(t, err, "no web UI page")
+}
+
+func TestSearchResult_WebUIURL(t *testing.T) {
+	result := SearchResult{Type: "feature", Name: "f1", Path: []SearchPathEntry{{Type: "tenant", Name: "acme"}, {Type: "project", Name: "shop"}}}
+	link, err := result.WebUIURL("http://localhost:9000", "other")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000/tenants/acme/projects/shop?filter=f1", link)
+
+	result = SearchResult{Type: "project", Name: "shop"}
+	link, err = result.WebUIURL("http://localhost:9000", "acme")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000/tenants/acme/projects/shop", link)
+}
@@ -19,6 +19,8 @@ type Format string
 const (
 	JSON  Format = "json"
 	Table Format = "table"
+	// NDJSON prints one compact JSON object per line
+	NDJSON Format = "ndjson"
 )
 
 // TableFormatter is an interface for types that want custom table formatting
@@ -38,6 +40,8 @@ func PrintTo(w io.Writer, data interface{}, format Format) error {
 		return printJSON(w, data)
 	case Table:
 		return printTable(w, data)
+	case NDJSON:
+		return printNDJSON(w, data)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -54,6 +58,44 @@ func printJSON(w io.Writer, data interface{}) error {
 	return nil
 }
 
+// printNDJSON outputs each element of a slice as one compact JSON line, or data
+// itself as a single line when it is not a slice
+func printNDJSON(w io.Writer, data interface{}) error {
+	val := reflect.ValueOf(data)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array || val.Type() == reflect.TypeOf(json.RawMessage{}) {
+		return WriteNDJSON(w, data)
+	}
+	for i := 0; i < val.Len(); i++ {
+		if err := WriteNDJSON(w, val.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteNDJSON writes one item as a compact JSON line. Raw JSON items are
+// compacted as is, so that streamed items keep their fields and order.
+func WriteNDJSON(w io.Writer, item interface{}) error {
+	var line bytes.Buffer
+	if raw, ok := item.(json.RawMessage); ok {
+		if err := json.Compact(&line, raw); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		line.WriteByte('\n')
+	} else {
+		encoder := json.NewEncoder(&line)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	}
+	_, err := w.Write(line.Bytes())
+	return err
+}
+
 // PrintRawJSON prints raw JSON bytes, optionally pretty-printed
 // If compact is false, the JSON will be pretty-printed with 2-space indentation
 func PrintRawJSON(w io.Writer, rawJSON []byte, compact bool) error {
@@ -143,11 +185,12 @@ func printSliceTable(w io.Writer, val reflect.Value) error {
 	table.SetTablePadding("\t")
 	table.SetNoWhiteSpace(true)
 
+	var columns []tableColumn
+	rows := make([][]string, 0, val.Len())
 	// Handle structs
 	if firstElem.Kind() == reflect.Struct {
 		// Extract headers from struct fields
-		headers := extractHeaders(firstElem.Type())
-		table.SetHeader(headers)
+		columns = extractColumns(firstElem.Type())
 
 		// Add rows
 		for i := 0; i < val.Len(); i++ {
@@ -155,18 +198,25 @@ func printSliceTable(w io.Writer, val reflect.Value) error {
 			if elem.Kind() == reflect.Ptr {
 				elem = elem.Elem()
 			}
-			row := extractRow(elem, headers)
-			table.Append(row)
+			rows = append(rows, extractRow(elem))
 		}
 	} else {
 		// For simple types, create a single column table
-		table.SetHeader([]string{"Value"})
+		columns = []tableColumn{{name: "Value"}}
 		for i := 0; i < val.Len(); i++ {
 			elem := val.Index(i)
-			table.Append([]string{fmt.Sprint(elem.Interface())})
+			rows = append(rows, []string{fmt.Sprint(elem.Interface())})
 		}
 	}
 
+	// Column selection, sort and wrapping of --columns, --sort-by, --reverse and --wide
+	headers, rows, err := arrangeTable(columns, rows, tableOptions)
+	if err != nil {
+		return err
+	}
+	table.SetHeader(headers)
+	wrapTable(table, headers, rows, tabColumnWidth)
+	table.AppendBulk(rows)
 	table.Render()
 	return nil
 }
@@ -275,22 +325,22 @@ func shouldOmitEmpty(field reflect.StructField, val reflect.Value) bool {
 	return val.IsZero()
 }
 
-// extractHeaders extracts field names from a struct type
-func extractHeaders(typ reflect.Type) []string {
-	var headers []string
+// extractColumns extracts the columns of a struct type from its field names
+func extractColumns(typ reflect.Type) []tableColumn {
+	var columns []tableColumn
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 		if !field.IsExported() {
 			continue
 		}
-		headers = append(headers, getFieldName(field))
+		columns = append(columns, tableColumn{name: getFieldName(field), wide: isWideField(field)})
 	}
-	return headers
+	return columns
 }
 
 // extractRow extracts field values from a struct
-func extractRow(val reflect.Value, headers []string) []string {
-	row := make([]string, 0, len(headers))
+func extractRow(val reflect.Value) []string {
+	row := make([]string, 0, val.NumField())
 	typ := val.Type()
 
 	for i := 0; i < typ.NumField(); i++ {
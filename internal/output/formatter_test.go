@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -184,6 +185,43 @@ func TestFormatValue(t *testing.T) {
 	}
 }
 
+func TestPrintNDJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     interface{}
+		expected string
+	}{
+		{
+			name:     "slice of structs",
+			data:     []testStruct{{Name: "a", Enabled: true, Count: 1}, {Name: "b<c>"}},
+			expected: "{\"name\":\"a\",\"enabled\":true,\"count\":1}\n{\"name\":\"b<c>\",\"enabled\":false,\"count\":0}\n",
+		},
+		{
+			name:     "raw JSON items are compacted",
+			data:     []json.RawMessage{json.RawMessage(`{ "z": 1, "a": [1, 2] }`)},
+			expected: "{\"z\":1,\"a\":[1,2]}\n",
+		},
+		{
+			name:     "single struct",
+			data:     testStruct{Name: "one"},
+			expected: "{\"name\":\"one\",\"enabled\":false,\"count\":0}\n",
+		},
+		{
+			name:     "empty slice",
+			data:     []testStruct{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, PrintTo(&buf, tt.data, NDJSON))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
 func TestPrintInvalidFormat(t *testing.T) {
 	var buf bytes.Buffer
 	data := testStruct{Name: "test", Enabled: true, Count: 1}
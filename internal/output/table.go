@@ -0,0 +1,237 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// TableOptions customizes the tables printed for lists of items
+type TableOptions struct {
+	// Columns selects and orders the columns by name (case-insensitive);
+	// empty shows the default columns
+	Columns []string
+	// Wide shows the columns hidden by default (table:"wide" fields) and
+	// disables wrapping
+	Wide bool
+	// SortBy sorts the rows by a column, numbers numerically
+	SortBy string
+	// Reverse reverses the order of the rows, after sorting
+	Reverse bool
+	// Width is the terminal width long cells are wrapped to, 0 to never wrap
+	Width int
+}
+
+// tableOptions applies to every list table, set once by the cmd layer
+var tableOptions TableOptions
+
+// minWrappedColumnWidth is the narrowest a column gets when wrapping to the terminal width
+const minWrappedColumnWidth = 12
+
+// ansiSequence matches the color escape sequences of a cell
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// SetTableOptions sets the column selection, sort and width of list tables
+func SetTableOptions(options TableOptions) {
+	tableOptions = options
+}
+
+// tableColumn is a column of a list table
+type tableColumn struct {
+	name string
+	// wide columns are only shown with --wide or when selected with --columns
+	wide bool
+}
+
+// isWideField reports whether a struct field is only shown in wide tables
+func isWideField(field reflect.StructField) bool {
+	return field.Tag.Get("table") == "wide"
+}
+
+// PrintRows prints rows under a header with the spacing of grouped tables,
+// applying the column selection, sort and width of the table options
+func PrintRows(w io.Writer, header []string, rows [][]string) error {
+	columns := make([]tableColumn, len(header))
+	for i, name := range header {
+		columns[i] = tableColumn{name: name}
+	}
+	header, rows, err := arrangeTable(columns, rows, tableOptions)
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetColumnSeparator("")
+	table.SetHeaderLine(false)
+	table.SetAutoWrapText(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	// Cells are surrounded by a space on each side
+	wrapTable(table, header, rows, func(width int, last bool) int { return width + 2 })
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
+
+// arrangeTable sorts the rows and selects the columns of a table according to
+// the options, returning the header and rows to print
+func arrangeTable(columns []tableColumn, rows [][]string, options TableOptions) ([]string, [][]string, error) {
+	if options.SortBy != "" {
+		index, err := findColumn(columns, options.SortBy, "--sort-by")
+		if err != nil {
+			return nil, nil, err
+		}
+		sorted := make([][]string, len(rows))
+		copy(sorted, rows)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return compareCells(sorted[i][index], sorted[j][index]) < 0
+		})
+		rows = sorted
+	}
+	if options.Reverse {
+		reversed := make([][]string, len(rows))
+		for i, row := range rows {
+			reversed[len(rows)-1-i] = row
+		}
+		rows = reversed
+	}
+
+	var selected []int
+	if len(options.Columns) > 0 {
+		for _, name := range options.Columns {
+			index, err := findColumn(columns, name, "--columns")
+			if err != nil {
+				return nil, nil, err
+			}
+			selected = append(selected, index)
+		}
+	} else {
+		for i, column := range columns {
+			if !column.wide || options.Wide {
+				selected = append(selected, i)
+			}
+		}
+	}
+
+	header := make([]string, len(selected))
+	for i, index := range selected {
+		header[i] = columns[index].name
+	}
+	arranged := make([][]string, len(rows))
+	for r, row := range rows {
+		cells := make([]string, len(selected))
+		for i, index := range selected {
+			if index < len(row) {
+				cells[i] = row[index]
+			}
+		}
+		arranged[r] = cells
+	}
+	return header, arranged, nil
+}
+
+// findColumn returns the index of a column by name, ignoring case, dashes,
+// underscores and spaces
+func findColumn(columns []tableColumn, name, flag string) (int, error) {
+	wanted := normalizeColumnName(name)
+	for i, column := range columns {
+		if normalizeColumnName(column.name) == wanted {
+			return i, nil
+		}
+	}
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = strings.ToLower(column.name)
+	}
+	return 0, fmt.Errorf("unknown column %q for %s (available: %s)", name, flag, strings.Join(names, ", "))
+}
+
+// normalizeColumnName lowercases a column name and removes its separators
+func normalizeColumnName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// compareCells compares two cells, numerically when both are numbers, as text
+// without color sequences otherwise
+func compareCells(a, b string) int {
+	a, b = ansiSequence.ReplaceAllString(a, ""), ansiSequence.ReplaceAllString(b, "")
+	numberA, errA := strconv.ParseFloat(a, 64)
+	numberB, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numberA < numberB:
+			return -1
+		case numberA > numberB:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// wrapTable makes the table wrap long cells when its rows are wider than the
+// terminal. columnWidth returns the printed width of a column of a given
+// content width, separators and padding included.
+func wrapTable(table *tablewriter.Table, header []string, rows [][]string, columnWidth func(width int, last bool) int) {
+	if tableOptions.Width <= 0 || tableOptions.Wide || len(header) == 0 {
+		return
+	}
+	widths := make([]int, len(header))
+	for i, name := range header {
+		widths[i] = tablewriter.DisplayWidth(name)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				for _, line := range strings.Split(cell, "\n") {
+					if width := tablewriter.DisplayWidth(line); width > widths[i] {
+						widths[i] = width
+					}
+				}
+			}
+		}
+	}
+	total := func(limit int) int {
+		sum := 0
+		for i, width := range widths {
+			if width > limit {
+				width = limit
+			}
+			sum += columnWidth(width, i == len(widths)-1)
+		}
+		return sum
+	}
+
+	widest := 0
+	for _, width := range widths {
+		if width > widest {
+			widest = width
+		}
+	}
+	if total(widest) <= tableOptions.Width {
+		return
+	}
+	limit := widest
+	for limit > minWrappedColumnWidth && total(limit) > tableOptions.Width {
+		limit--
+	}
+	table.SetAutoWrapText(true)
+	table.SetColWidth(limit)
+}
+
+// tabColumnWidth is the printed width of a column of list tables, whose
+// columns are separated by a tab that moves to the next multiple of 8
+func tabColumnWidth(width int, last bool) int {
+	if last {
+		return width
+	}
+	return (width/8 + 1) * 8
+}
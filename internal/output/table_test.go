@@ -0,0 +1,143 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type wideStruct struct {
+	Name     string            `json:"name"`
+	Count    int               `json:"count"`
+	Metadata map[string]string `json:"metadata" table:"wide"`
+}
+
+// withTableOptions sets the table options for the duration of a test
+func withTableOptions(t *testing.T, options TableOptions) {
+	previous := tableOptions
+	SetTableOptions(options)
+	t.Cleanup(func() { SetTableOptions(previous) })
+}
+
+func testColumns() []tableColumn {
+	return []tableColumn{{name: "ID"}, {name: "Name"}, {name: "Count"}, {name: "Metadata", wide: true}}
+}
+
+func testRows() [][]string {
+	return [][]string{
+		{"1", "beta", "10", "a"},
+		{"2", "Alpha", "9", "b"},
+		{"3", "gamma", "100", "c"},
+	}
+}
+
+func TestArrangeTable_DefaultHidesWideColumns(t *testing.T) {
+	header, rows, err := arrangeTable(testColumns(), testRows(), TableOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "Name", "Count"}, header)
+	assert.Equal(t, []string{"1", "beta", "10"}, rows[0])
+}
+
+func TestArrangeTable_Wide(t *testing.T) {
+	header, rows, err := arrangeTable(testColumns(), testRows(), TableOptions{Wide: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "Name", "Count", "Metadata"}, header)
+	assert.Equal(t, []string{"1", "beta", "10", "a"}, rows[0])
+}
+
+func TestArrangeTable_Columns(t *testing.T) {
+	header, rows, err := arrangeTable(testColumns(), testRows(), TableOptions{Columns: []string{"metadata", "id"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Metadata", "ID"}, header)
+	assert.Equal(t, [][]string{{"a", "1"}, {"b", "2"}, {"c", "3"}}, rows)
+}
+
+func TestArrangeTable_UnknownColumn(t *testing.T) {
+	_, _, err := arrangeTable(testColumns(), testRows(), TableOptions{Columns: []string{"owner"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown column "owner" for --columns`)
+	assert.Contains(t, err.Error(), "id, name, count, metadata")
+
+	_, _, err = arrangeTable(testColumns(), testRows(), TableOptions{SortBy: "owner"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--sort-by")
+}
+
+func TestArrangeTable_SortBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  TableOptions
+		expected []string
+	}{
+		{"text ignores case", TableOptions{SortBy: "name"}, []string{"2", "1", "3"}},
+		{"numbers numerically", TableOptions{SortBy: "count"}, []string{"2", "1", "3"}},
+		{"reversed", TableOptions{SortBy: "count", Reverse: true}, []string{"3", "1", "2"}},
+		{"reverse without sort", TableOptions{Reverse: true}, []string{"3", "2", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, rows, err := arrangeTable(testColumns(), testRows(), tt.options)
+			require.NoError(t, err)
+			ids := make([]string, len(rows))
+			for i, row := range rows {
+				ids[i] = row[0]
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestCompareCells_IgnoresColors(t *testing.T) {
+	assert.Equal(t, -1, compareCells("\x1b[32mactive\x1b[0m", "disabled\x1b[0m"))
+	assert.Equal(t, 0, compareCells("\x1b[31m5\x1b[0m", "5"))
+	assert.Equal(t, 1, compareCells("10", "9"))
+}
+
+func TestPrintTable_ColumnsAndWide(t *testing.T) {
+	data := []wideStruct{{Name: "b", Count: 2, Metadata: map[string]string{"owner": "team"}}, {Name: "a", Count: 1}}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintTo(&buf, data, Table))
+	assert.NotContains(t, buf.String(), "METADATA")
+
+	withTableOptions(t, TableOptions{Wide: true})
+	buf.Reset()
+	require.NoError(t, PrintTo(&buf, data, Table))
+	assert.Contains(t, buf.String(), "METADATA")
+	assert.Contains(t, buf.String(), "{1 entries}")
+
+	withTableOptions(t, TableOptions{Columns: []string{"name"}, SortBy: "name"})
+	buf.Reset()
+	require.NoError(t, PrintTo(&buf, data, Table))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "NAME", strings.TrimSpace(lines[0]))
+	assert.Equal(t, "a", strings.TrimSpace(lines[1]))
+	assert.Equal(t, "b", strings.TrimSpace(lines[2]))
+}
+
+func TestPrintRows_WrapsToTerminalWidth(t *testing.T) {
+	long := strings.Repeat("word ", 20)
+	rows := [][]string{{"flag", strings.TrimSpace(long)}}
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintRows(&buf, []string{"NAME", "DESCRIPTION"}, rows))
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 2, "no wrapping without a terminal width")
+
+	withTableOptions(t, TableOptions{Width: 40})
+	buf.Reset()
+	require.NoError(t, PrintRows(&buf, []string{"NAME", "DESCRIPTION"}, rows))
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Greater(t, len(lines), 2)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(strings.TrimRight(line, " ")), 40, line)
+	}
+
+	withTableOptions(t, TableOptions{Width: 40, Wide: true})
+	buf.Reset()
+	require.NoError(t, PrintRows(&buf, []string{"NAME", "DESCRIPTION"}, rows))
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 2, "--wide disables wrapping")
+}
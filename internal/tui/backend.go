@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"context"
+	"sort"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Backend provides the data displayed by the TUI.
+// It is an interface so the UI can be tested without an Izanami server.
+type Backend interface {
+	ListTenants(ctx context.Context) ([]izanami.Tenant, error)
+	ListProjects(ctx context.Context, tenant string) ([]izanami.Project, error)
+	ListFeatures(ctx context.Context, tenant, project string) ([]izanami.Feature, error)
+	GetFeature(ctx context.Context, tenant, featureID string) (*izanami.FeatureWithOverloads, error)
+	SetFeatureEnabled(ctx context.Context, tenant, featureID string, enabled bool) error
+}
+
+// clientBackend implements Backend on top of the admin API client
+type clientBackend struct {
+	client *izanami.AdminClient
+}
+
+// NewClientBackend creates a Backend backed by an AdminClient
+func NewClientBackend(client *izanami.AdminClient) Backend {
+	return &clientBackend{client: client}
+}
+
+func (b *clientBackend) ListTenants(ctx context.Context) ([]izanami.Tenant, error) {
+	tenants, err := izanami.ListTenants(b.client, ctx, nil, izanami.ParseTenants)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Name < tenants[j].Name })
+	return tenants, nil
+}
+
+func (b *clientBackend) ListProjects(ctx context.Context, tenant string) ([]izanami.Project, error) {
+	projects, err := izanami.ListProjects(b.client, ctx, tenant, izanami.ParseProjects)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, nil
+}
+
+// ListFeatures lists the features of a project.
+// The list endpoint is tenant-wide, so features are filtered client-side.
+func (b *clientBackend) ListFeatures(ctx context.Context, tenant, project string) ([]izanami.Feature, error) {
+	features, err := izanami.ListFeatures(b.client, ctx, tenant, "", izanami.ParseFeatures)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []izanami.Feature
+	for _, f := range features {
+		if f.Project == project {
+			filtered = append(filtered, f)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered, nil
+}
+
+func (b *clientBackend) GetFeature(ctx context.Context, tenant, featureID string) (*izanami.FeatureWithOverloads, error) {
+	return izanami.GetFeature(b.client, ctx, tenant, featureID, izanami.ParseFeature)
+}
+
+func (b *clientBackend) SetFeatureEnabled(ctx context.Context, tenant, featureID string, enabled bool) error {
+	patches := []izanami.FeaturePatch{
+		{Op: "replace", Path: "/" + featureID + "/enabled", Value: enabled},
+	}
+	return b.client.PatchFeatures(ctx, tenant, patches)
+}
@@ -0,0 +1,449 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// screen identifies the level of the tenant → project → feature hierarchy being browsed
+type screen int
+
+const (
+	screenTenants screen = iota
+	screenProjects
+	screenFeatures
+	screenOverloads
+)
+
+// item is a single selectable row
+type item struct {
+	id      string
+	title   string
+	detail  string
+	enabled *bool // nil when the row has no activation state
+}
+
+// Messages produced by asynchronous backend calls
+type (
+	tenantsLoadedMsg struct {
+		tenants []izanami.Tenant
+		err     error
+	}
+	projectsLoadedMsg struct {
+		projects []izanami.Project
+		err      error
+	}
+	featuresLoadedMsg struct {
+		features []izanami.Feature
+		err      error
+	}
+	overloadsLoadedMsg struct {
+		feature *izanami.FeatureWithOverloads
+		err     error
+	}
+	toggledMsg struct {
+		featureID string
+		enabled   bool
+		err       error
+	}
+)
+
+// Model is the bubbletea model of the feature browser
+type Model struct {
+	ctx     context.Context
+	backend Backend
+
+	screen    screen
+	tenant    string
+	project   string
+	feature   string // name of the feature whose overloads are displayed
+	featureID string // ID of the feature whose overloads are displayed
+
+	items   []item
+	cursor  int
+	loading bool
+	err     error
+	status  string
+	height  int
+}
+
+// NewModel creates the browser model.
+// If tenant is set, browsing starts at the project list of that tenant.
+func NewModel(ctx context.Context, backend Backend, tenant string) Model {
+	m := Model{
+		ctx:     ctx,
+		backend: backend,
+		screen:  screenTenants,
+		loading: true,
+	}
+	if tenant != "" {
+		m.screen = screenProjects
+		m.tenant = tenant
+	}
+	return m
+}
+
+// Run starts the interactive browser and blocks until the user quits
+func Run(ctx context.Context, backend Backend, tenant string, opts ...tea.ProgramOption) error {
+	_, err := tea.NewProgram(NewModel(ctx, backend, tenant), opts...).Run()
+	return err
+}
+
+// Init loads the first screen
+func (m Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// load returns the command fetching the data of the current screen
+func (m Model) load() tea.Cmd {
+	ctx, backend, tenant, project := m.ctx, m.backend, m.tenant, m.project
+	switch m.screen {
+	case screenTenants:
+		return func() tea.Msg {
+			tenants, err := backend.ListTenants(ctx)
+			return tenantsLoadedMsg{tenants: tenants, err: err}
+		}
+	case screenProjects:
+		return func() tea.Msg {
+			projects, err := backend.ListProjects(ctx, tenant)
+			return projectsLoadedMsg{projects: projects, err: err}
+		}
+	case screenFeatures:
+		return func() tea.Msg {
+			features, err := backend.ListFeatures(ctx, tenant, project)
+			return featuresLoadedMsg{features: features, err: err}
+		}
+	case screenOverloads:
+		featureID := m.featureID
+		return func() tea.Msg {
+			feature, err := backend.GetFeature(ctx, tenant, featureID)
+			return overloadsLoadedMsg{feature: feature, err: err}
+		}
+	}
+	return nil
+}
+
+// selected returns the row under the cursor
+func (m Model) selected() (item, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.items) {
+		return item{}, false
+	}
+	return m.items[m.cursor], true
+}
+
+// setItems replaces the rows of the current screen
+func (m *Model) setItems(items []item, err error) {
+	m.loading = false
+	m.err = err
+	m.items = items
+	if m.cursor >= len(items) {
+		m.cursor = 0
+	}
+}
+
+// navigate switches to another screen and triggers its loading
+func (m Model) navigate(s screen) (tea.Model, tea.Cmd) {
+	m.screen = s
+	m.cursor = 0
+	m.items = nil
+	m.err = nil
+	m.status = ""
+	m.loading = true
+	return m, m.load()
+}
+
+// Update handles key presses and backend responses
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case tenantsLoadedMsg:
+		items := make([]item, len(msg.tenants))
+		for i, t := range msg.tenants {
+			items[i] = item{id: t.Name, title: t.Name, detail: t.Description}
+		}
+		m.setItems(items, msg.err)
+		return m, nil
+
+	case projectsLoadedMsg:
+		items := make([]item, len(msg.projects))
+		for i, p := range msg.projects {
+			items[i] = item{id: p.Name, title: p.Name, detail: p.Description}
+		}
+		m.setItems(items, msg.err)
+		return m, nil
+
+	case featuresLoadedMsg:
+		items := make([]item, len(msg.features))
+		for i, f := range msg.features {
+			enabled := f.Enabled
+			items[i] = item{id: f.ID, title: f.Name, detail: f.Description, enabled: &enabled}
+		}
+		m.setItems(items, msg.err)
+		return m, nil
+
+	case overloadsLoadedMsg:
+		if msg.err != nil {
+			m.setItems(nil, msg.err)
+			return m, nil
+		}
+		m.feature = msg.feature.Name
+		m.setItems(overloadItems(msg.feature), nil)
+		return m, nil
+
+	case toggledMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("failed to update feature: %v", msg.err)
+			return m, nil
+		}
+		for i := range m.items {
+			if m.items[i].id == msg.featureID {
+				enabled := msg.enabled
+				m.items[i].enabled = &enabled
+				m.status = fmt.Sprintf("%s is now %s", m.items[i].title, stateLabel(enabled))
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey processes keyboard input
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		if len(m.items) > 0 {
+			m.cursor = len(m.items) - 1
+		}
+
+	case "r":
+		return m.navigate(m.screen)
+
+	case "esc", "backspace", "left", "h":
+		switch m.screen {
+		case screenProjects:
+			m.tenant = ""
+			return m.navigate(screenTenants)
+		case screenFeatures:
+			m.project = ""
+			return m.navigate(screenProjects)
+		case screenOverloads:
+			m.feature = ""
+			m.featureID = ""
+			return m.navigate(screenFeatures)
+		}
+
+	case "enter", "right", "l":
+		it, ok := m.selected()
+		if !ok || m.loading {
+			return m, nil
+		}
+		switch m.screen {
+		case screenTenants:
+			m.tenant = it.id
+			return m.navigate(screenProjects)
+		case screenProjects:
+			m.project = it.id
+			return m.navigate(screenFeatures)
+		case screenFeatures:
+			return m.showOverloads()
+		}
+
+	case "o":
+		if m.screen == screenFeatures {
+			return m.showOverloads()
+		}
+
+	case " ", "t":
+		if m.screen != screenFeatures {
+			return m, nil
+		}
+		it, ok := m.selected()
+		if !ok || it.enabled == nil {
+			return m, nil
+		}
+		ctx, backend, tenant := m.ctx, m.backend, m.tenant
+		id, enabled := it.id, !*it.enabled
+		m.status = fmt.Sprintf("updating %s...", it.title)
+		return m, func() tea.Msg {
+			err := backend.SetFeatureEnabled(ctx, tenant, id, enabled)
+			return toggledMsg{featureID: id, enabled: enabled, err: err}
+		}
+	}
+	return m, nil
+}
+
+// showOverloads opens the overload screen for the selected feature
+func (m Model) showOverloads() (tea.Model, tea.Cmd) {
+	it, ok := m.selected()
+	if !ok {
+		return m, nil
+	}
+	m.feature = it.title
+	m.featureID = it.id
+	return m.navigate(screenOverloads)
+}
+
+// overloadItems converts the overloads of a feature into rows, sorted by context path
+func overloadItems(feature *izanami.FeatureWithOverloads) []item {
+	paths := make([]string, 0, len(feature.Overloads))
+	for path := range feature.Overloads {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	items := make([]item, 0, len(paths))
+	for _, path := range paths {
+		it := item{id: path, title: path}
+		if overload, ok := feature.Overloads[path].(map[string]interface{}); ok {
+			if enabled, ok := overload["enabled"].(bool); ok {
+				it.enabled = &enabled
+			}
+			if conditions, ok := overload["conditions"].([]interface{}); ok && len(conditions) > 0 {
+				it.detail = fmt.Sprintf("%d condition(s)", len(conditions))
+			}
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// stateLabel returns a colored enabled/disabled label
+func stateLabel(enabled bool) string {
+	if enabled {
+		return color.GreenString("enabled")
+	}
+	return color.RedString("disabled")
+}
+
+// View renders the current screen
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(color.New(color.Bold).Sprint(m.breadcrumb()))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString("  Loading...\n")
+	case m.err != nil:
+		b.WriteString(color.RedString("  Error: %v", m.err))
+		b.WriteString("\n")
+	case len(m.items) == 0:
+		b.WriteString("  " + m.emptyMessage() + "\n")
+	default:
+		start, end := m.visibleRange()
+		for i := start; i < end; i++ {
+			it := m.items[i]
+			cursor := "  "
+			if i == m.cursor {
+				cursor = "> "
+			}
+			line := cursor + it.title
+			if it.enabled != nil {
+				line += " [" + stateLabel(*it.enabled) + "]"
+			}
+			if it.detail != "" {
+				line += color.HiBlackString(" - %s", it.detail)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+	b.WriteString(color.HiBlackString(m.help()))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// breadcrumb shows where the user is in the hierarchy
+func (m Model) breadcrumb() string {
+	parts := []string{"Izanami"}
+	switch m.screen {
+	case screenTenants:
+		parts = append(parts, "tenants")
+	case screenProjects:
+		parts = append(parts, m.tenant, "projects")
+	case screenFeatures:
+		parts = append(parts, m.tenant, m.project, "features")
+	case screenOverloads:
+		parts = append(parts, m.tenant, m.project, m.feature, "overloads")
+	}
+	return strings.Join(parts, " › ")
+}
+
+// emptyMessage is displayed when the current screen has no rows
+func (m Model) emptyMessage() string {
+	switch m.screen {
+	case screenTenants:
+		return "No tenants found."
+	case screenProjects:
+		return "No projects in this tenant."
+	case screenFeatures:
+		return "No features in this project."
+	default:
+		return "This feature has no overloads."
+	}
+}
+
+// help lists the key bindings available on the current screen
+func (m Model) help() string {
+	keys := []string{"↑/↓ move"}
+	switch m.screen {
+	case screenTenants, screenProjects:
+		keys = append(keys, "enter open")
+	case screenFeatures:
+		keys = append(keys, "space toggle", "enter/o overloads")
+	}
+	if m.screen != screenTenants {
+		keys = append(keys, "esc back")
+	}
+	keys = append(keys, "r refresh", "q quit")
+	return strings.Join(keys, " • ")
+}
+
+// visibleRange returns the slice of rows that fits in the terminal, keeping the cursor visible
+func (m Model) visibleRange() (int, int) {
+	// Header, blank lines, status and help take about 6 lines
+	rows := m.height - 6
+	if m.height == 0 || rows >= len(m.items) || rows <= 0 {
+		return 0, len(m.items)
+	}
+	start := m.cursor - rows/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + rows
+	if end > len(m.items) {
+		end = len(m.items)
+		start = end - rows
+	}
+	return start, end
+}
@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// fakeBackend is an in-memory Backend for tests
+type fakeBackend struct {
+	tenants   []izanami.Tenant
+	projects  map[string][]izanami.Project
+	features  map[string][]izanami.Feature
+	overloads map[string]*izanami.FeatureWithOverloads
+	toggleErr error
+	toggled   map[string]bool
+}
+
+func (f *fakeBackend) ListTenants(ctx context.Context) ([]izanami.Tenant, error) {
+	return f.tenants, nil
+}
+
+func (f *fakeBackend) ListProjects(ctx context.Context, tenant string) ([]izanami.Project, error) {
+	return f.projects[tenant], nil
+}
+
+func (f *fakeBackend) ListFeatures(ctx context.Context, tenant, project string) ([]izanami.Feature, error) {
+	return f.features[project], nil
+}
+
+func (f *fakeBackend) GetFeature(ctx context.Context, tenant, featureID string) (*izanami.FeatureWithOverloads, error) {
+	feature, ok := f.overloads[featureID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return feature, nil
+}
+
+func (f *fakeBackend) SetFeatureEnabled(ctx context.Context, tenant, featureID string, enabled bool) error {
+	if f.toggleErr != nil {
+		return f.toggleErr
+	}
+	if f.toggled == nil {
+		f.toggled = map[string]bool{}
+	}
+	f.toggled[featureID] = enabled
+	return nil
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		tenants: []izanami.Tenant{{Name: "acme", Description: "Acme corp"}},
+		projects: map[string][]izanami.Project{
+			"acme": {{Name: "shop"}},
+		},
+		features: map[string][]izanami.Feature{
+			"shop": {
+				{ID: "f1", Name: "checkout", Project: "shop", Enabled: false},
+				{ID: "f2", Name: "search", Project: "shop", Enabled: true},
+			},
+		},
+		overloads: map[string]*izanami.FeatureWithOverloads{
+			"f1": {
+				ID:   "f1",
+				Name: "checkout",
+				Overloads: map[string]interface{}{
+					"prod/eu": map[string]interface{}{"enabled": true},
+					"dev":     map[string]interface{}{"enabled": false},
+				},
+			},
+		},
+	}
+}
+
+// step applies a message and runs the returned command synchronously, feeding its result back
+func step(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	m = next.(Model)
+	if cmd != nil {
+		if result := cmd(); result != nil {
+			if _, quit := result.(tea.QuitMsg); !quit {
+				next, _ = m.Update(result)
+				m = next.(Model)
+			}
+		}
+	}
+	return m
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func initModel(t *testing.T, backend Backend, tenant string) Model {
+	t.Helper()
+	m := NewModel(context.Background(), backend, tenant)
+	next, _ := m.Update(m.Init()())
+	return next.(Model)
+}
+
+func TestModel_NavigateHierarchy(t *testing.T) {
+	m := initModel(t, newFakeBackend(), "")
+
+	assert.Equal(t, screenTenants, m.screen)
+	require.Len(t, m.items, 1)
+	assert.Contains(t, m.View(), "acme")
+
+	m = step(t, m, key("enter"))
+	assert.Equal(t, screenProjects, m.screen)
+	assert.Equal(t, "acme", m.tenant)
+	assert.Contains(t, m.View(), "shop")
+
+	m = step(t, m, key("enter"))
+	assert.Equal(t, screenFeatures, m.screen)
+	require.Len(t, m.items, 2)
+	assert.Contains(t, m.View(), "checkout")
+	assert.Contains(t, m.View(), "Izanami › acme › shop › features")
+
+	m = step(t, m, key("esc"))
+	assert.Equal(t, screenProjects, m.screen)
+
+	m = step(t, m, key("esc"))
+	assert.Equal(t, screenTenants, m.screen)
+	assert.Empty(t, m.tenant)
+}
+
+func TestModel_StartsInConfiguredTenant(t *testing.T) {
+	m := initModel(t, newFakeBackend(), "acme")
+
+	assert.Equal(t, screenProjects, m.screen)
+	assert.Contains(t, m.View(), "shop")
+}
+
+func TestModel_ToggleFeature(t *testing.T) {
+	backend := newFakeBackend()
+	m := initModel(t, backend, "acme")
+	m = step(t, m, key("enter"))
+
+	m = step(t, m, key("space"))
+
+	assert.Equal(t, true, backend.toggled["f1"])
+	require.NotNil(t, m.items[0].enabled)
+	assert.True(t, *m.items[0].enabled)
+	assert.Contains(t, m.status, "checkout is now")
+}
+
+func TestModel_ToggleFeatureError(t *testing.T) {
+	backend := newFakeBackend()
+	backend.toggleErr = errors.New("forbidden")
+	m := initModel(t, backend, "acme")
+	m = step(t, m, key("enter"))
+
+	m = step(t, m, key("down"))
+	m = step(t, m, key("t"))
+
+	assert.True(t, *m.items[1].enabled, "state must not change on error")
+	assert.Contains(t, m.status, "forbidden")
+}
+
+func TestModel_ShowOverloads(t *testing.T) {
+	m := initModel(t, newFakeBackend(), "acme")
+	m = step(t, m, key("enter"))
+
+	m = step(t, m, key("o"))
+	assert.Equal(t, screenOverloads, m.screen)
+	require.Len(t, m.items, 2)
+	assert.Equal(t, "dev", m.items[0].title)
+	assert.Equal(t, "prod/eu", m.items[1].title)
+	assert.True(t, *m.items[1].enabled)
+
+	// Refresh keeps the same feature
+	m = step(t, m, key("r"))
+	assert.Equal(t, screenOverloads, m.screen)
+	assert.Len(t, m.items, 2)
+
+	m = step(t, m, key("esc"))
+	assert.Equal(t, screenFeatures, m.screen)
+}
+
+func TestModel_Quit(t *testing.T) {
+	m := initModel(t, newFakeBackend(), "")
+
+	_, cmd := m.Update(key("q"))
+	require.NotNil(t, cmd)
+	_, ok := cmd().(tea.QuitMsg)
+	assert.True(t, ok)
+}
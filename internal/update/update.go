@@ -0,0 +1,307 @@
+// Package update checks GitHub for new iz releases and replaces the running
+// binary with a verified release build.
+package update
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Repository is the GitHub repository iz releases are published to
+const Repository = "webskin/izanami-go-cli"
+
+// ChecksumsAsset is the release asset listing the SHA-256 of every archive
+const ChecksumsAsset = "checksums.txt"
+
+// maxDownloadSize bounds the size of downloaded release assets
+const maxDownloadSize = 100 << 20
+
+// APIURL is the GitHub API base URL (replaced in tests)
+var APIURL = "https://api.github.com"
+
+// Release is a GitHub release of iz
+type Release struct {
+	TagName     string  `json:"tag_name"`
+	Name        string  `json:"name"`
+	HTMLURL     string  `json:"html_url"`
+	PublishedAt string  `json:"published_at"`
+	Assets      []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version returns the release version without its "v" prefix
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// Asset returns the asset with the given name, nil when the release has none
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// LatestRelease fetches the latest published release from GitHub
+func LatestRelease(ctx context.Context, client *http.Client) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimSuffix(APIURL, "/"), Repository)
+	body, err := download(ctx, client, url, "application/vnd.github+json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the latest release: %w", err)
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse the latest release: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("failed to parse the latest release: no tag name")
+	}
+	return &release, nil
+}
+
+// IsDevelopmentBuild reports whether version is not a release build: "dev",
+// or a snapshot such as "1.4.1-next"
+func IsDevelopmentBuild(version string) bool {
+	_, _, ok := parseVersion(version)
+	return !ok || strings.Contains(version, "-")
+}
+
+// CompareVersions compares two versions ("1.4.0", "v1.10.2"), returning -1, 0
+// or 1. A pre-release ("1.4.0-rc1") sorts before its release. Versions that do
+// not parse sort before any release.
+func CompareVersions(a, b string) int {
+	numbersA, preA, okA := parseVersion(a)
+	numbersB, preB, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	for i := range numbersA {
+		if numbersA[i] != numbersB[i] {
+			if numbersA[i] < numbersB[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	case preA < preB:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// parseVersion splits "v1.2.3-rc1" into its numbers and pre-release suffix
+func parseVersion(version string) ([3]int, string, bool) {
+	var numbers [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	core, pre, _ := strings.Cut(version, "-")
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return numbers, "", false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return numbers, "", false
+		}
+		numbers[i] = n
+	}
+	return numbers, pre, true
+}
+
+// ArchiveName returns the name of the release archive for a platform, as
+// published by GoReleaser ("izanami-go-cli_1.4.0_linux_x86_64.tar.gz")
+func ArchiveName(version, goos, goarch string) string {
+	arch := goarch
+	if goarch == "amd64" {
+		arch = "x86_64"
+	}
+	return fmt.Sprintf("izanami-go-cli_%s_%s_%s.tar.gz", strings.TrimPrefix(version, "v"), goos, arch)
+}
+
+// binaryName returns the name of the iz binary inside release archives
+func binaryName(goos string) string {
+	if goos == "windows" {
+		return "iz.exe"
+	}
+	return "iz"
+}
+
+// Apply downloads the archive of release for the current platform, verifies it
+// against the release checksums and replaces the executable at path with the
+// binary it contains
+func Apply(ctx context.Context, client *http.Client, release *Release, path string) error {
+	name := ArchiveName(release.Version(), runtime.GOOS, runtime.GOARCH)
+	archiveAsset := release.Asset(name)
+	if archiveAsset == nil {
+		return fmt.Errorf("release %s has no archive for %s/%s (%s)", release.TagName, runtime.GOOS, runtime.GOARCH, name)
+	}
+	checksumsAsset := release.Asset(ChecksumsAsset)
+	if checksumsAsset == nil {
+		return fmt.Errorf("release %s has no %s, refusing to install an unverified binary", release.TagName, ChecksumsAsset)
+	}
+
+	checksums, err := download(ctx, client, checksumsAsset.URL, "")
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+	}
+	archive, err := download(ctx, client, archiveAsset.URL, "")
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	if err := VerifyChecksum(checksums, name, archive); err != nil {
+		return err
+	}
+	binary, err := ExtractBinary(archive, binaryName(runtime.GOOS))
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	return ReplaceExecutable(path, binary)
+}
+
+// VerifyChecksum checks data against the SHA-256 listed for name in a
+// checksums file ("<hex>  <name>" lines)
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, fields[0], hex.EncodeToString(sum[:]))
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum listed for %s", name)
+}
+
+// ExtractBinary returns the content of the file called name in a tar.gz archive
+func ExtractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != name {
+			continue
+		}
+		return io.ReadAll(io.LimitReader(reader, maxDownloadSize))
+	}
+}
+
+// ReplaceExecutable replaces the executable at path with binary. The new binary
+// is written next to it then renamed over it, so a failure leaves the current
+// one in place. On Windows, where a running executable cannot be overwritten,
+// the current one is moved aside to "<path>.old" first.
+func ReplaceExecutable(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".iz-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s (try again with sufficient permissions): %w", filepath.Dir(path), err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0111); err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return err
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			os.Rename(old, path)
+			return err
+		}
+		return nil
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// download fetches a URL, failing on non-2xx responses
+func download(ctx context.Context, client *http.Client, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxDownloadSize {
+		return nil, errors.New("response too large")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, url)
+	}
+	return body, nil
+}
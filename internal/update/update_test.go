@@ -0,0 +1,148 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testArchive builds a tar.gz archive holding one file
+func testArchive(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: 2, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("hi"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.4.0", "1.4.0", 0},
+		{"v1.4.0", "1.4.0", 0},
+		{"1.10.0", "1.9.3", 1},
+		{"1.4", "1.4.1", -1},
+		{"2.0.0-rc1", "2.0.0", -1},
+		{"2.0.0-rc2", "2.0.0-rc1", 1},
+		{"dev", "0.0.1", -1},
+		{"dev", "dev", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, CompareVersions(tt.a, tt.b))
+		})
+	}
+}
+
+func TestIsDevelopmentBuild(t *testing.T) {
+	assert.True(t, IsDevelopmentBuild("dev"))
+	assert.True(t, IsDevelopmentBuild("1.4.1-next"))
+	assert.False(t, IsDevelopmentBuild("1.4.0"))
+	assert.False(t, IsDevelopmentBuild("v1.4.0"))
+}
+
+func TestArchiveName(t *testing.T) {
+	assert.Equal(t, "izanami-go-cli_1.4.0_linux_x86_64.tar.gz", ArchiveName("v1.4.0", "linux", "amd64"))
+	assert.Equal(t, "izanami-go-cli_1.4.0_darwin_arm64.tar.gz", ArchiveName("1.4.0", "darwin", "arm64"))
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("archive")
+	checksums := []byte(fmt.Sprintf("%s  other.tar.gz\n%s  iz.tar.gz\n", sha256Hex([]byte("other")), sha256Hex(data)))
+
+	assert.NoError(t, VerifyChecksum(checksums, "iz.tar.gz", data))
+	assert.ErrorContains(t, VerifyChecksum(checksums, "iz.tar.gz", []byte("tampered")), "checksum mismatch")
+	assert.ErrorContains(t, VerifyChecksum(checksums, "missing.tar.gz", data), "no checksum listed")
+}
+
+func TestExtractBinary(t *testing.T) {
+	archive := testArchive(t, "iz", []byte("binary"))
+
+	binary, err := ExtractBinary(archive, "iz")
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(binary))
+
+	_, err = ExtractBinary(archive, "iz.exe")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestLatestRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/"+Repository+"/releases/latest", r.URL.Path)
+		json.NewEncoder(w).Encode(Release{TagName: "v1.5.0", HTMLURL: "https://example.com/v1.5.0"})
+	}))
+	defer server.Close()
+	defer func(url string) { APIURL = url }(APIURL)
+	APIURL = server.URL
+
+	release, err := LatestRelease(context.Background(), server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0", release.Version())
+}
+
+func TestApply(t *testing.T) {
+	name := ArchiveName("1.5.0", runtime.GOOS, runtime.GOARCH)
+	archive := testArchive(t, binaryName(runtime.GOOS), []byte("new binary"))
+	checksums := fmt.Sprintf("%s  %s\n", sha256Hex(archive), name)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + name:
+			w.Write(archive)
+		case "/checksums.txt":
+			w.Write([]byte(checksums))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	release := &Release{TagName: "v1.5.0", Assets: []Asset{
+		{Name: name, URL: server.URL + "/" + name},
+		{Name: ChecksumsAsset, URL: server.URL + "/checksums.txt"},
+	}}
+	path := filepath.Join(t.TempDir(), "iz")
+	require.NoError(t, os.WriteFile(path, []byte("old binary"), 0755))
+
+	require.NoError(t, Apply(context.Background(), server.Client(), release, path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new binary", string(content))
+
+	// A tampered archive leaves the binary in place
+	checksums = fmt.Sprintf("%s  %s\n", sha256Hex([]byte("other")), name)
+	require.NoError(t, os.WriteFile(path, []byte("old binary"), 0755))
+	assert.ErrorContains(t, Apply(context.Background(), server.Client(), release, path), "checksum mismatch")
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "old binary", string(content))
+}
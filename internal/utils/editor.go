@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// EditorCommand returns the user's editor command line from $VISUAL or $EDITOR,
+// falling back to notepad on Windows and vi elsewhere. Arguments are kept,
+// so values such as "code --wait" work.
+func EditorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// EditFile opens a file in the user's editor and waits for it to exit
+func EditFile(path string) error {
+	editor := EditorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", strings.Join(editor, " "), err)
+	}
+	return nil
+}
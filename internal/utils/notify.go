@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Notify shows a desktop notification: osascript on macOS, notify-send on Linux
+// and a PowerShell balloon tip on Windows. The title and message are passed in
+// environment variables so they are never interpreted by a shell.
+func Notify(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e",
+			`display notification (system attribute "IZ_NOTIFY_MESSAGE") with title (system attribute "IZ_NOTIFY_TITLE")`)
+	case "linux":
+		cmd = exec.Command("notify-send", "--app-name=iz", title, message)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+			`Add-Type -AssemblyName System.Windows.Forms; `+
+				`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+				`$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; `+
+				`$n.ShowBalloonTip(10000, $env:IZ_NOTIFY_TITLE, $env:IZ_NOTIFY_MESSAGE, 'Info'); `+
+				`Start-Sleep -Seconds 10; $n.Dispose()`)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	cmd.Env = append(os.Environ(), "IZ_NOTIFY_TITLE="+title, "IZ_NOTIFY_MESSAGE="+message)
+	if runtime.GOOS == "windows" {
+		// The balloon tip stays visible while PowerShell runs
+		return cmd.Start()
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if len(out) > 0 {
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
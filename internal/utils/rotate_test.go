@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+
+	r, err := NewRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		_, err := r.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Close())
+
+	read := func(p string) string {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "gggg\n", read(path))
+	assert.Equal(t, "eeee\nffff\n", read(path+".1"))
+	assert.Equal(t, "cccc\ndddd\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestRotatingFile_AppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte("old\n"), 0644))
+
+	r, err := NewRotatingFile(path, 0, 1)
+	require.NoError(t, err)
+	_, err = r.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "old\nnew\n", string(data))
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"100MB": 100 << 20,
+		"512k":  512 << 10,
+		"1G":    1 << 30,
+		"1.5KB": 1536,
+		"2048":  2048,
+		"10 MB": 10 << 20,
+	}
+	for input, want := range tests {
+		got, err := ParseByteSize(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "big", "-1MB", "10TB"} {
+		_, err := ParseByteSize(input)
+		assert.Error(t, err, input)
+	}
+}
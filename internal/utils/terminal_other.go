@@ -0,0 +1,11 @@
+//go:build !windows
+
+package utils
+
+import "os"
+
+// EnableVirtualTerminal is a no-op outside Windows: ANSI escape sequences are
+// always interpreted by Unix terminals.
+func EnableVirtualTerminal(f *os.File) bool {
+	return true
+}
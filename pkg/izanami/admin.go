@@ -0,0 +1,203 @@
+package izanami
+
+import (
+	"context"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// DefaultAuditPageSize is the number of audit events per page when
+// AuditQuery.PageSize is zero
+const DefaultAuditPageSize = 50
+
+// AdminClient calls the admin API (/api/admin/*) with a personal access token
+// or a JWT. It is safe for concurrent use.
+type AdminClient struct {
+	c *izanami.AdminClient
+}
+
+// NewAdminClient creates an admin client. URL and either Username and
+// PersonalAccessToken or JWTToken are required.
+func NewAdminClient(cfg Config) (*AdminClient, error) {
+	c, err := izanami.NewAdminClient(cfg.resolved())
+	if err != nil {
+		return nil, err
+	}
+	return &AdminClient{c: c}, nil
+}
+
+// Health returns the health status of the server
+func (a *AdminClient) Health(ctx context.Context) (*HealthStatus, error) {
+	return izanami.Health(a.c, ctx, izanami.ParseHealthStatus)
+}
+
+// ListTenants returns the tenants visible to the user
+func (a *AdminClient) ListTenants(ctx context.Context) ([]Tenant, error) {
+	return izanami.ListTenants(a.c, ctx, nil, izanami.ParseTenants)
+}
+
+// ListProjects returns the projects of a tenant
+func (a *AdminClient) ListProjects(ctx context.Context, tenant string) ([]Project, error) {
+	return izanami.ListProjects(a.c, ctx, tenant, izanami.ParseProjects)
+}
+
+// ListTags returns the tags of a tenant
+func (a *AdminClient) ListTags(ctx context.Context, tenant string) ([]Tag, error) {
+	return izanami.ListTags(a.c, ctx, tenant, izanami.ParseTags)
+}
+
+// ListContexts returns the context tree of a project, or the global contexts
+// of the tenant when project is empty
+func (a *AdminClient) ListContexts(ctx context.Context, tenant, project string) ([]Context, error) {
+	return izanami.ListContexts(a.c, ctx, tenant, project, true, izanami.ParseContexts)
+}
+
+// FeatureQuery filters and pages a feature listing
+type FeatureQuery struct {
+	// Project keeps the features of this project
+	Project string
+	// Tag keeps the features carrying this tag
+	Tag string
+	// ExcludeArchived leaves out the features tagged archived
+	ExcludeArchived bool
+	// Concurrency is the number of projects fetched in parallel, 1 when zero
+	Concurrency int
+	// PageSize is the number of features per page of Features, all when zero
+	PageSize int
+}
+
+// ListFeatures returns the features of a tenant matching the query, ignoring
+// its PageSize
+func (a *AdminClient) ListFeatures(ctx context.Context, tenant string, query FeatureQuery) ([]Feature, error) {
+	page, err := izanami.ListFeaturePage(a.c, ctx, tenant, izanami.FeatureListOptions{
+		Tag:             query.Tag,
+		Project:         query.Project,
+		Concurrency:     query.Concurrency,
+		ExcludeArchived: query.ExcludeArchived,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page.Features, nil
+}
+
+// Features pages the features of a tenant matching the query. The server does
+// not page features: they are fetched by the first Next and cut into pages of
+// query.PageSize.
+func (a *AdminClient) Features(tenant string, query FeatureQuery) *Pager[Feature] {
+	var remaining []Feature
+	fetched := false
+	return newPager(func(ctx context.Context) ([]Feature, bool, error) {
+		if !fetched {
+			features, err := a.ListFeatures(ctx, tenant, query)
+			if err != nil {
+				return nil, false, err
+			}
+			remaining, fetched = features, true
+		}
+		n := len(remaining)
+		if query.PageSize > 0 {
+			n = min(n, query.PageSize)
+		}
+		page := remaining[:n]
+		remaining = remaining[n:]
+		return page, len(remaining) > 0, nil
+	})
+}
+
+// GetFeature returns a feature and its overloads by ID
+func (a *AdminClient) GetFeature(ctx context.Context, tenant, featureID string) (*FeatureWithOverloads, error) {
+	return izanami.GetFeature(a.c, ctx, tenant, featureID, izanami.ParseFeature)
+}
+
+// CreateFeature creates a feature in a project. feature is a Feature or any
+// value marshalling to the feature JSON of the API.
+func (a *AdminClient) CreateFeature(ctx context.Context, tenant, project string, feature interface{}) (*Feature, error) {
+	return a.c.CreateFeature(ctx, tenant, project, feature)
+}
+
+// UpdateFeature replaces a feature. With preserveProtectedContexts, the
+// overloads of protected contexts are kept.
+func (a *AdminClient) UpdateFeature(ctx context.Context, tenant, featureID string, feature interface{}, preserveProtectedContexts bool) error {
+	return a.c.UpdateFeature(ctx, tenant, featureID, feature, preserveProtectedContexts)
+}
+
+// DeleteFeature deletes a feature
+func (a *AdminClient) DeleteFeature(ctx context.Context, tenant, featureID string) error {
+	return a.c.DeleteFeature(ctx, tenant, featureID)
+}
+
+// PatchFeatures applies JSON patches to several features in one request
+func (a *AdminClient) PatchFeatures(ctx context.Context, tenant string, patches []FeaturePatch) error {
+	return a.c.PatchFeatures(ctx, tenant, patches)
+}
+
+// SetOverload sets the strategy of a feature in a context ("prod" or
+// "prod/eu"). strategy is a FeatureOverload or its JSON equivalent.
+func (a *AdminClient) SetOverload(ctx context.Context, tenant, project, contextPath, featureName string, strategy interface{}, preserveProtected bool) error {
+	return a.c.SetOverload(ctx, tenant, project, contextPath, featureName, strategy, preserveProtected)
+}
+
+// DeleteOverload removes the strategy of a feature in a context
+func (a *AdminClient) DeleteOverload(ctx context.Context, tenant, project, contextPath, featureName string, preserveProtected bool) error {
+	return a.c.DeleteOverload(ctx, tenant, project, contextPath, featureName, preserveProtected)
+}
+
+// AuditQuery filters and pages the audit events of a tenant
+type AuditQuery struct {
+	// Project keeps the events of this project
+	Project string
+	// Features, Users and Types are comma-separated filters
+	Features string
+	Users    string
+	Types    string
+	// Start and End bound the emission date (ISO 8601)
+	Start string
+	End   string
+	// Ascending returns the oldest events first; the newest come first otherwise
+	Ascending bool
+	// PageSize is the number of events per page, DefaultAuditPageSize when zero
+	PageSize int
+}
+
+// AuditEvents pages the audit events of a tenant, following the cursor of the
+// logs API
+func (a *AdminClient) AuditEvents(tenant string, query AuditQuery) *Pager[AuditEvent] {
+	req := &izanami.LogsRequest{
+		Order:    "desc",
+		Features: query.Features,
+		Users:    query.Users,
+		Types:    query.Types,
+		Start:    query.Start,
+		End:      query.End,
+		Count:    query.PageSize,
+	}
+	if query.Ascending {
+		req.Order = "asc"
+	}
+	if req.Count <= 0 {
+		req.Count = DefaultAuditPageSize
+	}
+	return newPager(func(ctx context.Context) ([]AuditEvent, bool, error) {
+		var logs *izanami.LogsResponse
+		var err error
+		if query.Project != "" {
+			logs, err = izanami.ListProjectLogs(a.c, ctx, tenant, query.Project, req, izanami.ParseLogsResponse)
+		} else {
+			logs, err = izanami.ListTenantLogs(a.c, ctx, tenant, req, izanami.ParseLogsResponse)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if len(logs.Events) < req.Count {
+			return logs.Events, false, nil
+		}
+		last := logs.Events[len(logs.Events)-1].EventID
+		if last == req.Cursor {
+			// The server ignored the cursor, stop rather than loop forever
+			return logs.Events, false, nil
+		}
+		req.Cursor = last
+		return logs.Events, true, nil
+	})
+}
@@ -0,0 +1,156 @@
+package izanami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdminClient(t *testing.T, handler http.HandlerFunc) *AdminClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewAdminClient(Config{URL: server.URL, Username: "bot", PersonalAccessToken: "secret", Retries: -1})
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewAdminClient_RequiresCredentials(t *testing.T) {
+	_, err := NewAdminClient(Config{URL: "http://localhost:9000"})
+	assert.Error(t, err)
+
+	_, err = NewAdminClient(Config{PersonalAccessToken: "secret", Username: "bot"})
+	assert.Error(t, err)
+
+	client, err := NewAdminClient(Config{URL: "http://localhost:9000", JWTToken: "jwt"})
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
+func TestConfig_resolved(t *testing.T) {
+	resolved := Config{URL: "http://localhost:9000"}.resolved()
+	assert.Equal(t, 30, resolved.Timeout)
+	assert.Equal(t, DefaultRetries, resolved.Retries)
+	assert.Equal(t, 30, resolved.RetryMaxWait)
+
+	resolved = Config{Timeout: 1500 * time.Millisecond, Retries: -1, RetryMaxWait: 5 * time.Second}.resolved()
+	assert.Equal(t, 2, resolved.Timeout)
+	assert.Equal(t, 0, resolved.Retries)
+	assert.Equal(t, 5, resolved.RetryMaxWait)
+}
+
+func TestAdminClient_ListFeatures(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/features", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "bot", user)
+		assert.Equal(t, "secret", pass)
+		json.NewEncoder(w).Encode([]Feature{
+			{ID: "1", Name: "checkout", Project: "shop", Enabled: true},
+			{ID: "2", Name: "search", Project: "shop", Tags: []string{"archived"}},
+			{ID: "3", Name: "banner", Project: "web"},
+		})
+	})
+
+	features, err := client.ListFeatures(context.Background(), "acme", FeatureQuery{Project: "shop", ExcludeArchived: true})
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Equal(t, "checkout", features[0].Name)
+}
+
+func TestAdminClient_FeaturesPager(t *testing.T) {
+	requests := 0
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var features []Feature
+		for i := 1; i <= 5; i++ {
+			features = append(features, Feature{ID: strconv.Itoa(i), Name: fmt.Sprintf("f%d", i), Project: "shop"})
+		}
+		json.NewEncoder(w).Encode(features)
+	})
+
+	pager := client.Features("acme", FeatureQuery{PageSize: 2})
+	var sizes []int
+	for pager.More() {
+		page, err := pager.Next(context.Background())
+		require.NoError(t, err)
+		sizes = append(sizes, len(page))
+	}
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+	assert.Equal(t, 1, requests, "features are fetched once")
+
+	page, err := pager.Next(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func TestAdminClient_AuditEventsPager(t *testing.T) {
+	var cursors []string
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/admin/tenants/acme/logs", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("count"))
+		assert.Equal(t, "desc", r.URL.Query().Get("order"))
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		var events []AuditEvent
+		switch cursor {
+		case "":
+			events = []AuditEvent{{EventID: 50}, {EventID: 40}}
+		case "40":
+			events = []AuditEvent{{EventID: 30}, {EventID: 20}}
+		case "20":
+			events = []AuditEvent{{EventID: 10}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"events": events})
+	})
+
+	events, err := client.AuditEvents("acme", AuditQuery{PageSize: 2}).All(context.Background())
+	require.NoError(t, err)
+	var ids []int64
+	for _, event := range events {
+		ids = append(ids, event.EventID)
+	}
+	assert.Equal(t, []int64{50, 40, 30, 20, 10}, ids)
+	assert.Equal(t, []string{"", "40", "20"}, cursors)
+}
+
+func TestAdminClient_AuditEventsPager_ProjectAndIgnoredCursor(t *testing.T) {
+	requests := 0
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/admin/tenants/acme/projects/shop/logs", r.URL.Path)
+		assert.Equal(t, "asc", r.URL.Query().Get("order"))
+		// The server ignores the cursor and always returns a full page
+		json.NewEncoder(w).Encode(map[string]interface{}{"events": []AuditEvent{{EventID: 1}}})
+	})
+
+	events, err := client.AuditEvents("acme", AuditQuery{Project: "shop", Ascending: true, PageSize: 1}).All(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, 2, requests)
+}
+
+func TestAdminClient_GetFeature_NotFound(t *testing.T) {
+	client := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Feature not found"}`))
+	})
+
+	_, err := client.GetFeature(context.Background(), "acme", "missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Feature not found", apiErr.Message)
+	assert.False(t, IsNotFound(fmt.Errorf("other")))
+}
@@ -0,0 +1,45 @@
+package izanami
+
+import (
+	"context"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Client checks features through the client API (/api/v2/*) with a client ID
+// and secret. It calls WorkerURL when set, URL otherwise. It is safe for
+// concurrent use.
+type Client struct {
+	c *izanami.FeatureCheckClient
+}
+
+// NewClient creates a feature check client. URL or WorkerURL, ClientID and
+// ClientSecret are required.
+func NewClient(cfg Config) (*Client, error) {
+	c, err := izanami.NewFeatureCheckClient(cfg.resolved())
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c: c}, nil
+}
+
+// CheckOptions are the evaluation parameters of a feature check
+type CheckOptions struct {
+	// User is the user the feature is evaluated for
+	User string
+	// Context is the context path ("prod" or "prod/eu")
+	Context string
+	// Payload is the JSON body passed to script features
+	Payload string
+}
+
+// CheckFeature evaluates a feature by ID
+func (c *Client) CheckFeature(ctx context.Context, featureID string, opts CheckOptions) (*FeatureCheckResult, error) {
+	return izanami.CheckFeature(c.c, ctx, featureID, opts.User, opts.Context, opts.Payload, izanami.ParseFeatureCheckResult)
+}
+
+// CheckFeatures evaluates several features, selected by ID, project or tag,
+// and returns their activations by feature ID
+func (c *Client) CheckFeatures(ctx context.Context, request CheckFeaturesRequest) (ActivationsWithConditions, error) {
+	return izanami.CheckFeatures(c.c, ctx, request, izanami.ParseActivationsWithConditions)
+}
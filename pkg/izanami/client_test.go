@@ -0,0 +1,53 @@
+package izanami
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_RequiresClientCredentials(t *testing.T) {
+	_, err := NewClient(Config{URL: "http://localhost:9000"})
+	assert.Error(t, err)
+}
+
+func TestClient_CheckFeature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/features/f1", r.URL.Path)
+		assert.Equal(t, "alice", r.URL.Query().Get("user"))
+		assert.Equal(t, "/prod/eu", r.URL.Query().Get("context"))
+		assert.Equal(t, "id", r.Header.Get("Izanami-Client-Id"))
+		assert.Equal(t, "secret", r.Header.Get("Izanami-Client-Secret"))
+		w.Write([]byte(`{"active":true,"name":"checkout","project":"shop"}`))
+	}))
+	defer server.Close()
+
+	// Feature checks go to the worker
+	client, err := NewClient(Config{URL: "http://leader.invalid", WorkerURL: server.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+
+	result, err := client.CheckFeature(context.Background(), "f1", CheckOptions{User: "alice", Context: "prod/eu"})
+	require.NoError(t, err)
+	assert.Equal(t, true, result.Active)
+	assert.Equal(t, "checkout", result.Name)
+}
+
+func TestClient_CheckFeatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/features", r.URL.Path)
+		w.Write([]byte(`{"f1":{"name":"checkout","active":true,"project":"shop"},"f2":{"name":"search","active":false,"project":"shop"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{URL: server.URL, ClientID: "id", ClientSecret: "secret"})
+	require.NoError(t, err)
+
+	activations, err := client.CheckFeatures(context.Background(), CheckFeaturesRequest{Projects: []string{"shop"}})
+	require.NoError(t, err)
+	require.Len(t, activations, 2)
+	assert.Equal(t, false, activations["f2"].Active)
+}
@@ -0,0 +1,98 @@
+package izanami
+
+import (
+	"math"
+	"time"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Defaults applied to the zero values of Config
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultRetries      = izanami.DefaultRetries
+	DefaultRetryMaxWait = izanami.DefaultRetryMaxWait * time.Second
+)
+
+// Config holds the server address, credentials and transport settings of a client
+type Config struct {
+	// URL is the base URL of the Izanami server (the leader)
+	URL string
+	// WorkerURL is the base URL used by Client for feature checks, URL when empty
+	WorkerURL string
+
+	// Username and PersonalAccessToken authenticate AdminClient with a
+	// personal access token; JWTToken may be used instead
+	Username            string
+	PersonalAccessToken string
+	JWTToken            string
+
+	// ClientID and ClientSecret authenticate Client
+	ClientID     string
+	ClientSecret string
+
+	// Timeout of each request, rounded up to the second; DefaultTimeout when zero
+	Timeout time.Duration
+	// Retries of idempotent requests; DefaultRetries when zero, none when negative
+	Retries int
+	// RetryMaxWait caps the wait between retries; DefaultRetryMaxWait when zero
+	RetryMaxWait time.Duration
+
+	InsecureSkipVerify bool
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ProxyURL           string
+	// HTTPHeaders are sent with every request
+	HTTPHeaders map[string]string
+
+	// MaxRequestsPerSecond and MaxConcurrency limit the requests of all the
+	// clients of the process; 0 disables a limit
+	MaxRequestsPerSecond float64
+	MaxConcurrency       int
+}
+
+// resolved converts the config to the config of the internal clients
+func (c Config) resolved() *izanami.ResolvedConfig {
+	timeout := DefaultTimeout
+	if c.Timeout > 0 {
+		timeout = c.Timeout
+	}
+	retries := c.Retries
+	switch {
+	case retries == 0:
+		retries = DefaultRetries
+	case retries < 0:
+		retries = 0
+	}
+	maxWait := DefaultRetryMaxWait
+	if c.RetryMaxWait > 0 {
+		maxWait = c.RetryMaxWait
+	}
+
+	headers := make(map[string]string, len(c.HTTPHeaders))
+	for k, v := range c.HTTPHeaders {
+		headers[k] = v
+	}
+	return &izanami.ResolvedConfig{
+		LeaderURL:                   c.URL,
+		WorkerURL:                   c.WorkerURL,
+		PersonalAccessTokenUsername: c.Username,
+		Username:                    c.Username,
+		PersonalAccessToken:         c.PersonalAccessToken,
+		JwtToken:                    c.JWTToken,
+		ClientID:                    c.ClientID,
+		ClientSecret:                c.ClientSecret,
+		Timeout:                     int(math.Ceil(timeout.Seconds())),
+		Retries:                     retries,
+		RetryMaxWait:                int(math.Ceil(maxWait.Seconds())),
+		InsecureSkipVerify:          c.InsecureSkipVerify,
+		CACertFile:                  c.CACertFile,
+		ClientCertFile:              c.ClientCertFile,
+		ClientKeyFile:               c.ClientKeyFile,
+		ProxyURL:                    c.ProxyURL,
+		HTTPHeaders:                 headers,
+		MaxRequestsPerSecond:        c.MaxRequestsPerSecond,
+		MaxConcurrency:              c.MaxConcurrency,
+	}
+}
@@ -0,0 +1,36 @@
+// Package izanami is a Go client for the Izanami feature flag server, built on
+// the same API layer as the iz CLI.
+//
+// AdminClient manages tenants, projects, features and overloads through the
+// admin API, authenticated by a personal access token or a JWT. Client checks
+// features through the client API, authenticated by a client ID and secret.
+// Both retry idempotent requests on network errors, 429 and 5xx responses.
+//
+// Listings the server pages (audit events) and large feature listings are
+// read through a Pager:
+//
+//	admin, err := izanami.NewAdminClient(izanami.Config{
+//		URL:                 "https://izanami.example.com",
+//		Username:            "ci-bot",
+//		PersonalAccessToken: os.Getenv("IZ_PERSONAL_ACCESS_TOKEN"),
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	pager := admin.Features("my-tenant", izanami.FeatureQuery{Project: "shop", PageSize: 100})
+//	for pager.More() {
+//		features, err := pager.Next(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		for _, f := range features {
+//			fmt.Println(f.Name, f.Enabled)
+//		}
+//	}
+//
+// Errors returned by the server are *APIError values; IsNotFound reports a
+// missing resource.
+//
+// The package follows semantic versioning with the module: exported names are
+// not removed nor changed incompatibly within a major version.
+package izanami
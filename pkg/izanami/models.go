@@ -0,0 +1,41 @@
+package izanami
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/webskin/izanami-go-cli/internal/izanami"
+)
+
+// Models of the Izanami API, shared with the iz CLI
+type (
+	Feature                   = izanami.Feature
+	FeatureWithOverloads      = izanami.FeatureWithOverloads
+	FeatureOverload           = izanami.FeatureOverload
+	FeaturePatch              = izanami.FeaturePatch
+	Project                   = izanami.Project
+	Tenant                    = izanami.Tenant
+	Tag                       = izanami.Tag
+	Context                   = izanami.Context
+	AuditEvent                = izanami.AuditEvent
+	HealthStatus              = izanami.HealthStatus
+	FeatureCheckResult        = izanami.FeatureCheckResult
+	ActivationWithConditions  = izanami.ActivationWithConditions
+	ActivationsWithConditions = izanami.ActivationsWithConditions
+	CheckFeaturesRequest      = izanami.CheckFeaturesRequest
+	LogsRequest               = izanami.LogsRequest
+)
+
+// APIError is an error response of the server, with its status code
+type APIError = izanami.APIError
+
+// IsNotFound reports whether err is a 404 response of the server
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsArchived reports whether a feature carries the archived tag
+func IsArchived(f Feature) bool {
+	return izanami.IsArchived(f)
+}
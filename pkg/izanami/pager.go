@@ -0,0 +1,52 @@
+package izanami
+
+import (
+	"context"
+)
+
+// Pager reads a listing page by page. More reports whether Next may return
+// another page; a failed Next may be retried.
+type Pager[T any] struct {
+	fetch func(ctx context.Context) (items []T, more bool, err error)
+	done  bool
+}
+
+// newPager creates a pager over a fetch function returning a page and
+// whether pages follow it
+func newPager[T any](fetch func(ctx context.Context) ([]T, bool, error)) *Pager[T] {
+	return &Pager[T]{fetch: fetch}
+}
+
+// More reports whether pages remain to be read
+func (p *Pager[T]) More() bool {
+	return !p.done
+}
+
+// Next returns the next page, empty once the listing is exhausted
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return []T{}, nil
+	}
+	items, more, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.done = !more
+	if items == nil {
+		items = []T{}
+	}
+	return items, nil
+}
+
+// All reads the remaining pages and returns their items
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	all := []T{}
+	for p.More() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}